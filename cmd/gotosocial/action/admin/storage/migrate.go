@@ -0,0 +1,72 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"code.superseriousbusiness.org/gotosocial/cmd/gotosocial/action"
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/storage"
+)
+
+// check function conformance.
+var _ action.GTSAction = Migrate
+
+// Migrate copies all media from one storage backend to the other.
+func Migrate(ctx context.Context) error {
+	var (
+		to   = config.GetAdminStorageMigrateTo()
+		from string
+	)
+
+	switch to {
+	case "s3":
+		from = "local"
+	case "local":
+		from = "s3"
+	default:
+		return fmt.Errorf("invalid storage backend to migrate to: %q", to)
+	}
+
+	src, err := storage.NewBackend(from)
+	if err != nil {
+		return fmt.Errorf("error opening %s storage: %w", from, err)
+	}
+
+	dst, err := storage.NewBackend(to)
+	if err != nil {
+		return fmt.Errorf("error opening %s storage: %w", to, err)
+	}
+
+	res, err := storage.Migrate(ctx, src, dst)
+	if err != nil {
+		return err
+	}
+
+	log.Infof(ctx, "copied %d keys (%d bytes), skipped %d keys already migrated, %d keys failed",
+		res.Copied, res.Bytes, res.Skipped, res.Failed)
+
+	if res.Failed > 0 {
+		return fmt.Errorf("%d keys failed to migrate, run the command again to retry", res.Failed)
+	}
+
+	return nil
+}
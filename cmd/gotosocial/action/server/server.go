@@ -62,6 +62,7 @@
 	gtsstorage "code.superseriousbusiness.org/gotosocial/internal/storage"
 	"code.superseriousbusiness.org/gotosocial/internal/subscriptions"
 	"code.superseriousbusiness.org/gotosocial/internal/transport"
+	"code.superseriousbusiness.org/gotosocial/internal/trends"
 	"code.superseriousbusiness.org/gotosocial/internal/typeutils"
 	"code.superseriousbusiness.org/gotosocial/internal/web"
 	"code.superseriousbusiness.org/gotosocial/internal/webpush"
@@ -268,6 +269,7 @@ func Start(ctx context.Context) error {
 		handlers.GetValidateURIHandler(ctx),
 		handlers.GetClientScopeHandler(ctx, state),
 		handlers.GetAuthorizeScopeHandler(),
+		handlers.GetRefreshingScopeHandler(),
 		handlers.GetInternalErrorHandler(ctx),
 		handlers.GetResponseErrorHandler(ctx),
 		handlers.GetUserAuthorizationHandler(),
@@ -371,6 +373,16 @@ func(context.Context, time.Time) {
 		return fmt.Errorf("error scheduling subscriptions jobs: %w", err)
 	}
 
+	// Schedule background trends calculation.
+	if err := trends.New(state).ScheduleJobs(); err != nil {
+		return fmt.Errorf("error scheduling trends jobs: %w", err)
+	}
+
+	// Schedule background notification digest emails.
+	if err := process.Workers().ScheduleEmailDigests(); err != nil {
+		return fmt.Errorf("error scheduling email digest jobs: %w", err)
+	}
+
 	// Initialize the specialized workers pools.
 	state.Workers.Client.Init(messages.ClientMsgIndices())
 	state.Workers.Federator.Init(messages.FederatorMsgIndices())
@@ -386,6 +398,11 @@ func(context.Context, time.Time) {
 		return fmt.Errorf("error scheduling poll expiries: %w", err)
 	}
 
+	// Schedule publication tasks for all scheduled announcements.
+	if err := process.Announcements().ScheduleAll(ctx); err != nil {
+		return fmt.Errorf("error scheduling announcement publications: %w", err)
+	}
+
 	// schedule publication tasks for all scheduled statuses.
 	if err := process.Status().ScheduledStatusesScheduleAll(ctx); err != nil {
 		return fmt.Errorf("error scheduling status publications: %w", err)
@@ -438,6 +455,7 @@ func(context.Context, time.Time) {
 		// the logger, otherwise won't be accessible.
 		middleware.Logger(config.GetLogClientIP()),
 		middleware.HeaderFilter(state),
+		middleware.IPBlock(state),
 		middleware.UserAgent(),
 		middleware.CORS(),
 		middleware.ExtraHeaders(),
@@ -44,6 +44,7 @@
 	"code.superseriousbusiness.org/gotosocial/internal/state"
 	"code.superseriousbusiness.org/gotosocial/internal/storage"
 	"code.superseriousbusiness.org/gotosocial/internal/subscriptions"
+	"code.superseriousbusiness.org/gotosocial/internal/trends"
 	"code.superseriousbusiness.org/gotosocial/internal/typeutils"
 	"code.superseriousbusiness.org/gotosocial/internal/web"
 	"code.superseriousbusiness.org/gotosocial/testrig"
@@ -200,6 +201,7 @@ func Start(ctx context.Context) error {
 	middlewares = append(middlewares, []gin.HandlerFunc{
 		middleware.Logger(config.GetLogClientIP()),
 		middleware.HeaderFilter(state),
+		middleware.IPBlock(state),
 		middleware.UserAgent(),
 		middleware.CORS(),
 		middleware.ExtraHeaders(),
@@ -303,6 +305,11 @@ func Start(ctx context.Context) error {
 		return fmt.Errorf("error scheduling subscriptions jobs: %w", err)
 	}
 
+	// Schedule background trends calculation.
+	if err := trends.New(state).ScheduleJobs(); err != nil {
+		return fmt.Errorf("error scheduling trends jobs: %w", err)
+	}
+
 	// Finally start the main http server!
 	if err := route.Start(); err != nil {
 		return fmt.Errorf("error starting router: %w", err)
@@ -21,6 +21,7 @@
 	"code.superseriousbusiness.org/gotosocial/cmd/gotosocial/action/admin/account"
 	"code.superseriousbusiness.org/gotosocial/cmd/gotosocial/action/admin/media"
 	"code.superseriousbusiness.org/gotosocial/cmd/gotosocial/action/admin/media/prune"
+	"code.superseriousbusiness.org/gotosocial/cmd/gotosocial/action/admin/storage"
 	"code.superseriousbusiness.org/gotosocial/cmd/gotosocial/action/admin/trans"
 	"code.superseriousbusiness.org/gotosocial/internal/config"
 	"github.com/spf13/cobra"
@@ -281,5 +282,29 @@ func adminCommands() *cobra.Command {
 
 	adminCmd.AddCommand(adminMediaCmd)
 
+	/*
+		ADMIN STORAGE COMMANDS
+	*/
+
+	adminStorageCmd := &cobra.Command{
+		Use:   "storage",
+		Short: "admin commands related to media storage backends",
+	}
+
+	adminStorageMigrateCmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "copy all media from the other storage backend to the given one",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), storage.Migrate)
+		},
+	}
+	config.AddAdminStorageMigrate(adminStorageMigrateCmd)
+	adminStorageCmd.AddCommand(adminStorageMigrateCmd)
+
+	adminCmd.AddCommand(adminStorageCmd)
+
 	return adminCmd
 }
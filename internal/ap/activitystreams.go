@@ -112,9 +112,25 @@ func PublicIRI() *url.URL { var u url.URL; u = *publicIRI; return &u }
 	ObjectReplyAuthorization    = "ReplyAuthorization"
 	ObjectAnnounceAuthorization = "AnnounceAuthorization"
 
+	// QuoteRequest is used internally to route
+	// quote interaction request accepts/rejects.
+	ActivityQuoteRequest = "QuoteRequest"
+
+	/* LitePub stuff */
+
+	ActivityEmojiReact = "EmojiReact" // LitePubEmojiReact https://docs.pleroma.social/backend/development/ap_extensions/#emojireact
+
 	/* Funkwhale stuff */
 
 	ObjectAlbum = "Album"
+
+	/* FEP-e232 / Misskey stuff */
+
+	// Media type and rel values used on FEP-e232 object
+	// Links to indicate that a Link refers to a quoted post.
+	// See https://codeberg.org/fediverse/fep/src/branch/main/fep/e232/fep-e232.md
+	LinkMediaTypeActivityStreams = `application/ld+json; profile="https://www.w3.org/ns/activitystreams"`
+	LinkRelMisskeyQuote          = "https://misskey-hub.net/ns#_misskey_quote"
 )
 
 // isActivity returns whether AS type name is of an Activity (NOT IntransitiveActivity).
@@ -147,7 +163,8 @@ func isActivity(typeName string) bool {
 		ActivityDislike,
 		ActivityLikeRequest,
 		ActivityReplyRequest,
-		ActivityAnnounceRequest:
+		ActivityAnnounceRequest,
+		ActivityEmojiReact:
 		return true
 	default:
 		return false
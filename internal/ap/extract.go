@@ -906,24 +906,11 @@ func ExtractHashtags(i WithTag) ([]*gtsmodel.Tag, error) {
 			continue
 		}
 
-		tag, err := extractHashtag(hashtaggable)
+		tag, err := ExtractHashtag(hashtaggable)
 		if err != nil {
 			continue
 		}
 
-		// "Normalize" this tag by combining diacritics +
-		// unicode chars. If this returns false, it means
-		// we couldn't normalize it well enough to make it
-		// valid on our instance, so just ignore it.
-		normalized, ok := text.NormalizeHashtag(tag.Name)
-		if !ok {
-			continue
-		}
-
-		// We store tag names lowercased, might
-		// as well change case here already.
-		tag.Name = strings.ToLower(normalized)
-
 		// Only append this tag if we haven't
 		// seen it already, to avoid duplicates
 		// in the slice.
@@ -936,6 +923,31 @@ func ExtractHashtags(i WithTag) ([]*gtsmodel.Tag, error) {
 	return tags, nil
 }
 
+// ExtractHashtag extracts a minimal, normalized gtsmodel.Tag
+// from the given Hashtaggable. An error is returned if the
+// name of the tag cannot be normalized.
+func ExtractHashtag(i Hashtaggable) (*gtsmodel.Tag, error) {
+	tag, err := extractHashtag(i)
+	if err != nil {
+		return nil, err
+	}
+
+	// "Normalize" this tag by combining diacritics +
+	// unicode chars. If this returns false, it means
+	// we couldn't normalize it well enough to make it
+	// valid on our instance, so just ignore it.
+	normalized, ok := text.NormalizeHashtag(tag.Name)
+	if !ok {
+		return nil, gtserror.Newf("could not normalize tag name %s", tag.Name)
+	}
+
+	// We store tag names lowercased, might
+	// as well change case here already.
+	tag.Name = strings.ToLower(normalized)
+
+	return tag, nil
+}
+
 // extractHashtag extracts a minimal gtsmodel.Tag from the given
 // Hashtaggable, without yet doing any normalization on it.
 func extractHashtag(i Hashtaggable) (*gtsmodel.Tag, error) {
@@ -1133,6 +1145,71 @@ func ExtractMention(i Mentionable) (*gtsmodel.Mention, error) {
 	}, nil
 }
 
+// ExtractQuoteURI extracts the URI of a quoted post from
+// the FEP-e232 object Links in the given WithTag, if any.
+//
+// Non-standard quote properties like `quoteUri` and
+// `_misskey_quote` are converted into such Links on
+// the way in by NormalizeIncomingQuote.
+//
+// Returns nil if no quote Link was found.
+func ExtractQuoteURI(i WithTag) *url.URL {
+	tagsProp := i.GetActivityStreamsTag()
+	if tagsProp == nil {
+		return nil
+	}
+
+	for iter := tagsProp.Begin(); iter != tagsProp.End(); iter = iter.Next() {
+		if !iter.IsActivityStreamsLink() {
+			continue
+		}
+
+		link := iter.GetActivityStreamsLink()
+		if link == nil || !isQuoteLink(link) {
+			continue
+		}
+
+		hrefProp := link.GetActivityStreamsHref()
+		if hrefProp == nil || !hrefProp.IsIRI() {
+			continue
+		}
+
+		href := hrefProp.GetIRI()
+		if href.Scheme != "http" && href.Scheme != "https" {
+			continue
+		}
+
+		return href
+	}
+
+	return nil
+}
+
+// isQuoteLink returns whether the given Link is an
+// FEP-e232 object Link with the Misskey quote rel.
+func isQuoteLink(link vocab.ActivityStreamsLink) bool {
+	relProp := link.GetActivityStreamsRel()
+	if relProp == nil {
+		return false
+	}
+
+	for iter := relProp.Begin(); iter != relProp.End(); iter = iter.Next() {
+		var rel string
+		switch {
+		case iter.IsRFCRfc5988():
+			rel = iter.Get()
+		case iter.IsIRI():
+			rel = iter.GetIRI().String()
+		}
+
+		if rel == LinkRelMisskeyQuote {
+			return true
+		}
+	}
+
+	return false
+}
+
 // ExtractActorURI extracts the first Actor URI
 // it can find from a WithActor interface.
 func ExtractActorURI(withActor WithActor) (*url.URL, error) {
@@ -1272,6 +1349,7 @@ func ExtractInteractionPolicy(
 		CanLike:     extractCanLike(policy.GetGoToSocialCanLike(), owner),
 		CanReply:    extractCanReply(policy.GetGoToSocialCanReply(), owner),
 		CanAnnounce: extractCanAnnounce(policy.GetGoToSocialCanAnnounce(), owner),
+		CanQuote:    extractCanQuote(policy.GetGoToSocialCanQuote(), owner),
 	}
 }
 
@@ -1347,6 +1425,30 @@ func extractCanAnnounce(
 	return extractPolicyRules(withRules, owner)
 }
 
+// Returns either a parsed CanQuote sub-policy, or nil
+// if canQuote is not set, ie., if this post is from an
+// instance that doesn't know / care about canQuote.
+func extractCanQuote(
+	prop vocab.GoToSocialCanQuoteProperty,
+	owner *gtsmodel.Account,
+) *gtsmodel.PolicyRules {
+	if prop == nil || prop.Len() != 1 {
+		return nil
+	}
+
+	propIter := prop.At(0)
+	if !propIter.IsGoToSocialCanQuote() {
+		return nil
+	}
+
+	withRules := propIter.Get()
+	if withRules == nil {
+		return nil
+	}
+
+	return extractPolicyRules(withRules, owner)
+}
+
 func extractPolicyRules(
 	withRules WithPolicyRules,
 	owner *gtsmodel.Account,
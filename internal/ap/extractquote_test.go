@@ -0,0 +1,138 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ap_test
+
+import (
+	"testing"
+
+	"code.superseriousbusiness.org/activity/streams/vocab"
+	"code.superseriousbusiness.org/gotosocial/internal/ap"
+	"github.com/stretchr/testify/suite"
+)
+
+type ExtractQuoteTestSuite struct {
+	APTestSuite
+}
+
+func (suite *ExtractQuoteTestSuite) TestExtractQuoteLink() {
+	t, _ := suite.jsonToType(`{
+		"@context": "https://www.w3.org/ns/activitystreams",
+		"id": "https://example.org/users/someone/statuses/01J1CHSFW9QMAQZ4SNXRNFMEE5",
+		"type": "Note",
+		"attributedTo": "https://example.org/users/someone",
+		"to": "https://www.w3.org/ns/activitystreams#Public",
+		"content": "<p>look at this!</p><p>RE: https://example.org/users/someone_else/statuses/01J1CJ1K3JTJ3NKE2VPV7BBTGD</p>",
+		"tag": [
+		  {
+			"type": "Link",
+			"mediaType": "application/ld+json; profile=\"https://www.w3.org/ns/activitystreams\"",
+			"rel": "https://misskey-hub.net/ns#_misskey_quote",
+			"href": "https://example.org/users/someone_else/statuses/01J1CJ1K3JTJ3NKE2VPV7BBTGD",
+			"name": "RE: https://example.org/users/someone_else/statuses/01J1CJ1K3JTJ3NKE2VPV7BBTGD"
+		  }
+		]
+	  }`)
+
+	quoteURI := ap.ExtractQuoteURI(t.(vocab.ActivityStreamsNote))
+	if suite.NotNil(quoteURI) {
+		suite.Equal(
+			"https://example.org/users/someone_else/statuses/01J1CJ1K3JTJ3NKE2VPV7BBTGD",
+			quoteURI.String(),
+		)
+	}
+}
+
+func (suite *ExtractQuoteTestSuite) TestExtractQuoteURIProp() {
+	t, raw := suite.jsonToType(`{
+		"@context": "https://www.w3.org/ns/activitystreams",
+		"id": "https://example.org/users/someone/statuses/01J1CHSFW9QMAQZ4SNXRNFMEE5",
+		"type": "Note",
+		"attributedTo": "https://example.org/users/someone",
+		"to": "https://www.w3.org/ns/activitystreams#Public",
+		"content": "<p>look at this!</p>",
+		"quoteUri": "https://example.org/users/someone_else/statuses/01J1CJ1K3JTJ3NKE2VPV7BBTGD",
+		"_misskey_quote": "https://example.org/users/someone_else/statuses/01J1CJ1K3JTJ3NKE2VPV7BBTGD"
+	  }`)
+	note := t.(vocab.ActivityStreamsNote)
+
+	// Without normalization,
+	// quote isn't extractable.
+	suite.Nil(ap.ExtractQuoteURI(note))
+
+	// Normalize, and quote
+	// should be extractable.
+	ap.NormalizeIncomingQuote(note, raw)
+	quoteURI := ap.ExtractQuoteURI(note)
+	if suite.NotNil(quoteURI) {
+		suite.Equal(
+			"https://example.org/users/someone_else/statuses/01J1CJ1K3JTJ3NKE2VPV7BBTGD",
+			quoteURI.String(),
+		)
+	}
+
+	// Normalizing again shouldn't
+	// add a second quote Link.
+	ap.NormalizeIncomingQuote(note, raw)
+	suite.Equal(1, note.GetActivityStreamsTag().Len())
+}
+
+func (suite *ExtractQuoteTestSuite) TestExtractQuoteNone() {
+	note := suite.noteWithMentions1
+	suite.Nil(ap.ExtractQuoteURI(note))
+}
+
+func (suite *ExtractQuoteTestSuite) TestGetQuoteAuthorization() {
+	for _, testCase := range []struct {
+		prop   string
+		expect string
+	}{
+		{
+			prop:   `"https://example.org/users/someone_else/quote_authorizations/01J1CJ3E2B5QXC5ND3SQ0B4B38"`,
+			expect: "https://example.org/users/someone_else/quote_authorizations/01J1CJ3E2B5QXC5ND3SQ0B4B38",
+		},
+		{
+			// Embedded authorization.
+			prop:   `{"id": "https://example.org/users/someone_else/quote_authorizations/01J1CJ3E2B5QXC5ND3SQ0B4B38", "type": "QuoteAuthorization"}`,
+			expect: "https://example.org/users/someone_else/quote_authorizations/01J1CJ3E2B5QXC5ND3SQ0B4B38",
+		},
+		{
+			// Not http(s).
+			prop: `"javascript:alert(1)"`,
+		},
+	} {
+		t, _ := suite.jsonToType(`{
+			"@context": "https://www.w3.org/ns/activitystreams",
+			"id": "https://example.org/users/someone/statuses/01J1CHSFW9QMAQZ4SNXRNFMEE5",
+			"type": "Note",
+			"attributedTo": "https://example.org/users/someone",
+			"content": "<p>look at this!</p>",
+			"quoteAuthorization": ` + testCase.prop + `
+		}`)
+
+		authURI := ap.GetQuoteAuthorization(t.(vocab.ActivityStreamsNote))
+		if testCase.expect == "" {
+			suite.Nil(authURI)
+		} else if suite.NotNil(authURI) {
+			suite.Equal(testCase.expect, authURI.String())
+		}
+	}
+}
+
+func TestExtractQuoteTestSuite(t *testing.T) {
+	suite.Run(t, &ExtractQuoteTestSuite{})
+}
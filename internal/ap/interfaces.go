@@ -247,6 +247,7 @@ type Accountable interface {
 	WithSummary
 	WithAttachment
 	WithDiscoverable
+	WithIndexable
 	WithURL
 	WithPublicKey
 	WithInbox
@@ -254,6 +255,7 @@ type Accountable interface {
 	WithFollowing
 	WithFollowers
 	WithFeatured
+	WithUnknownProperties
 	WithMovedTo
 	WithAlsoKnownAs
 	WithManuallyApprovesFollowers
@@ -385,6 +387,19 @@ type Likeable interface {
 	WithObject
 }
 
+// EmojiReactable represents the minimum interface for an emoji
+// reaction activity, ie., either a litepub 'EmojiReact', or an
+// activitystreams 'like' with the reaction set as its content.
+type EmojiReactable interface {
+	WithJSONLDId
+	WithTypeName
+
+	WithActor
+	WithObject
+	WithContent
+	WithTag
+}
+
 // Blockable represents the minimum interface for an activitystreams 'block' activity.
 type Blockable interface {
 	WithJSONLDId
@@ -550,6 +565,12 @@ type WithDiscoverable interface {
 	SetTootDiscoverable(vocab.TootDiscoverableProperty)
 }
 
+// WithIndexable represents an activity with TootIndexableProperty
+type WithIndexable interface {
+	GetTootIndexable() vocab.TootIndexableProperty
+	SetTootIndexable(vocab.TootIndexableProperty)
+}
+
 // WithURL represents an activity with ActivityStreamsUrlProperty
 type WithURL interface {
 	GetActivityStreamsUrl() vocab.ActivityStreamsUrlProperty
@@ -598,6 +619,12 @@ type WithFeatured interface {
 	SetTootFeatured(vocab.TootFeaturedProperty)
 }
 
+// WithUnknownProperties represents an Object with properties
+// not known to the vocab, such as Mastodon's featuredTags.
+type WithUnknownProperties interface {
+	GetUnknownProperties() map[string]interface{}
+}
+
 // WithMovedTo represents an Object with ActivityStreamsMovedToProperty.
 type WithMovedTo interface {
 	GetActivityStreamsMovedTo() vocab.ActivityStreamsMovedToProperty
@@ -0,0 +1,239 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ap
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"math/big"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+const (
+	// DataIntegrityContext is the JSON-LD context defining
+	// the assertionMethod property and Multikey type.
+	DataIntegrityContext = "https://w3id.org/security/data-integrity/v1"
+
+	// ObjectMultikey is the type
+	// of FEP-521a actor keys.
+	ObjectMultikey = "Multikey"
+)
+
+// ed25519MulticodecPrefix is the varint-encoded
+// multicodec header for "ed25519-pub" keys.
+var ed25519MulticodecPrefix = []byte{0xed, 0x01}
+
+// base58btc alphabet, as used by the multibase "z" prefix.
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// EncodeEd25519Multikey encodes the given Ed25519 public key
+// as a base58btc multibase Multikey, eg. "z6Mk...".
+//
+// See: https://www.w3.org/TR/cid-1.0/#Multikey
+func EncodeEd25519Multikey(pub ed25519.PublicKey) string {
+	b := append(slices.Clone(ed25519MulticodecPrefix), pub...)
+	return "z" + base58Encode(b)
+}
+
+// DecodeEd25519Multikey decodes the given base58btc
+// multibase Multikey as an Ed25519 public key.
+func DecodeEd25519Multikey(s string) (ed25519.PublicKey, error) {
+	if len(s) == 0 || s[0] != 'z' {
+		return nil, errors.New("multikey not base58btc encoded")
+	}
+
+	b, err := base58Decode(s[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b) != len(ed25519MulticodecPrefix)+ed25519.PublicKeySize ||
+		b[0] != ed25519MulticodecPrefix[0] ||
+		b[1] != ed25519MulticodecPrefix[1] {
+		return nil, errors.New("multikey not an ed25519 public key")
+	}
+
+	return ed25519.PublicKey(b[len(ed25519MulticodecPrefix):]), nil
+}
+
+func base58Encode(b []byte) string {
+	var (
+		n    = new(big.Int).SetBytes(b)
+		base = big.NewInt(58)
+		mod  = new(big.Int)
+		out  []byte
+	)
+
+	for n.Sign() > 0 {
+		n.DivMod(n, base, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+
+	// Leading zero bytes are
+	// each encoded as a '1'.
+	for _, c := range b {
+		if c != 0 {
+			break
+		}
+		out = append(out, base58Alphabet[0])
+	}
+
+	slices.Reverse(out)
+	return string(out)
+}
+
+func base58Decode(s string) ([]byte, error) {
+	var (
+		n    = new(big.Int)
+		base = big.NewInt(58)
+	)
+
+	for i := 0; i < len(s); i++ {
+		idx := strings.IndexByte(base58Alphabet, s[i])
+		if idx < 0 {
+			return nil, errors.New("invalid base58 character")
+		}
+		n.Mul(n, base)
+		n.Add(n, big.NewInt(int64(idx)))
+	}
+
+	// Count leading '1's,
+	// which are zero bytes.
+	var zeros int
+	for zeros < len(s) && s[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
+
+// GetEd25519AssertionMethod returns the Ed25519 Multikey with given key ID
+// in the FEP-521a assertionMethod property of 'with', along with its ID and
+// controller. If keyID is nil, the first Ed25519 Multikey is returned instead.
+// If no such key is present, all return values will be nil.
+//
+// The vocab has no assertionMethod property, so it's read from the unknown properties.
+func GetEd25519AssertionMethod(with WithUnknownProperties, keyID *url.URL) (ed25519.PublicKey, *url.URL, *url.URL) {
+	var methods []interface{}
+	switch v := with.GetUnknownProperties()["assertionMethod"].(type) {
+	case []interface{}:
+		methods = v
+	case map[string]interface{}:
+		methods = []interface{}{v}
+	}
+
+	for _, method := range methods {
+		key, id, controller, err := ParseEd25519Multikey(method)
+		if err != nil {
+			continue
+		}
+
+		if keyID == nil || id.String() == keyID.String() {
+			return key, id, controller
+		}
+	}
+
+	return nil, nil, nil
+}
+
+// ParseEd25519Multikey parses the given raw JSON
+// value as an Ed25519 Multikey, returning the key
+// itself, along with its ID and controller.
+func ParseEd25519Multikey(raw interface{}) (ed25519.PublicKey, *url.URL, *url.URL, error) {
+	m, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, nil, nil, errors.New("multikey not an object")
+	}
+
+	if t, _ := m["type"].(string); t != ObjectMultikey {
+		return nil, nil, nil, errors.New("not a multikey")
+	}
+
+	idStr, _ := m["id"].(string)
+	id, err := url.Parse(idStr)
+	if err != nil || idStr == "" {
+		return nil, nil, nil, errors.New("multikey has no valid id")
+	}
+
+	controllerStr, _ := m["controller"].(string)
+	controller, err := url.Parse(controllerStr)
+	if err != nil || controllerStr == "" {
+		return nil, nil, nil, errors.New("multikey has no valid controller")
+	}
+
+	multibase, _ := m["publicKeyMultibase"].(string)
+	key, err := DecodeEd25519Multikey(multibase)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return key, id, controller, nil
+}
+
+// SetEd25519AssertionMethod sets the given Ed25519 public key as a Multikey
+// on the FEP-521a assertionMethod property of 'with', with given ID + controller.
+//
+// The vocab has no assertionMethod property, so it's set in the unknown properties.
+func SetEd25519AssertionMethod(with WithUnknownProperties, key ed25519.PublicKey, id *url.URL, controller *url.URL) {
+	with.GetUnknownProperties()["assertionMethod"] = []interface{}{
+		map[string]interface{}{
+			"id":                 id.String(),
+			"type":               ObjectMultikey,
+			"controller":         controller.String(),
+			"publicKeyMultibase": EncodeEd25519Multikey(key),
+		},
+	}
+}
+
+// addDataIntegrityContext adds the data integrity
+// context to the top-level @context of rawJSON, if
+// the rawJSON (or its embedded 'object', in the case
+// of an activity) contains an assertionMethod property.
+func addDataIntegrityContext(rawJSON map[string]interface{}) {
+	if !hasAssertionMethod(rawJSON) {
+		object, _ := rawJSON["object"].(map[string]interface{})
+		if !hasAssertionMethod(object) {
+			return
+		}
+	}
+
+	switch c := rawJSON["@context"].(type) {
+	case string:
+		rawJSON["@context"] = []interface{}{c, DataIntegrityContext}
+	case []interface{}:
+		// Insert after any other vocab URIs,
+		// but before inline context objects.
+		idx := slices.IndexFunc(c, func(v interface{}) bool {
+			_, ok := v.(string)
+			return !ok
+		})
+		if idx < 0 {
+			idx = len(c)
+		}
+		rawJSON["@context"] = slices.Insert(c, idx, interface{}(DataIntegrityContext))
+	}
+}
+
+// hasAssertionMethod returns whether
+// rawJSON has an assertionMethod property.
+func hasAssertionMethod(rawJSON map[string]interface{}) bool {
+	_, ok := rawJSON["assertionMethod"]
+	return ok
+}
@@ -0,0 +1,97 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ap_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"fmt"
+	"testing"
+
+	"code.superseriousbusiness.org/gotosocial/internal/ap"
+	"code.superseriousbusiness.org/gotosocial/testrig"
+	"github.com/stretchr/testify/suite"
+)
+
+type MultikeyTestSuite struct {
+	APTestSuite
+}
+
+func (suite *MultikeyTestSuite) TestGetEd25519AssertionMethod() {
+	var (
+		key1 = ed25519.NewKeyFromSeed(bytes.Repeat([]byte{1}, ed25519.SeedSize)).Public().(ed25519.PublicKey)
+		key2 = ed25519.NewKeyFromSeed(bytes.Repeat([]byte{2}, ed25519.SeedSize)).Public().(ed25519.PublicKey)
+	)
+
+	t, _ := suite.jsonToType(fmt.Sprintf(`{
+		"@context": [
+			"https://www.w3.org/ns/activitystreams",
+			"https://w3id.org/security/data-integrity/v1"
+		],
+		"id": "https://example.org/users/someone",
+		"type": "Person",
+		"preferredUsername": "someone",
+		"inbox": "https://example.org/users/someone/inbox",
+		"outbox": "https://example.org/users/someone/outbox",
+		"assertionMethod": [
+			{
+				"id": "https://example.org/users/someone#ed25519-key-1",
+				"type": "Multikey",
+				"controller": "https://example.org/users/someone",
+				"publicKeyMultibase": %q
+			},
+			{
+				"id": "https://example.org/users/someone#ed25519-key-2",
+				"type": "Multikey",
+				"controller": "https://example.org/users/someone",
+				"publicKeyMultibase": %q
+			}
+		]
+	}`, ap.EncodeEd25519Multikey(key1), ap.EncodeEd25519Multikey(key2)))
+
+	wup, ok := t.(ap.WithUnknownProperties)
+	if !ok {
+		suite.FailNow("type has no unknown properties")
+	}
+
+	// No key ID gets the first key.
+	key, id, controller := ap.GetEd25519AssertionMethod(wup, nil)
+	suite.Equal(key1, key)
+	suite.Equal("https://example.org/users/someone#ed25519-key-1", id.String())
+	suite.Equal("https://example.org/users/someone", controller.String())
+
+	// A key ID gets the matching key,
+	// whatever its position in the list.
+	key, id, _ = ap.GetEd25519AssertionMethod(wup,
+		testrig.URLMustParse("https://example.org/users/someone#ed25519-key-2"),
+	)
+	suite.Equal(key2, key)
+	suite.Equal("https://example.org/users/someone#ed25519-key-2", id.String())
+
+	// An unknown key ID gets nothing.
+	key, id, controller = ap.GetEd25519AssertionMethod(wup,
+		testrig.URLMustParse("https://example.org/users/someone#main-key"),
+	)
+	suite.Nil(key)
+	suite.Nil(id)
+	suite.Nil(controller)
+}
+
+func TestMultikeyTestSuite(t *testing.T) {
+	suite.Run(t, &MultikeyTestSuite{})
+}
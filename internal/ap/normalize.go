@@ -18,6 +18,8 @@
 package ap
 
 import (
+	"net/url"
+
 	"code.superseriousbusiness.org/activity/pub"
 	"code.superseriousbusiness.org/activity/streams"
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
@@ -74,6 +76,7 @@ func NormalizeIncomingActivity(activity pub.Activity, rawJSON map[string]interfa
 			NormalizeIncomingAttachments(statusable, rawData)
 			NormalizeIncomingSummary(statusable, rawData)
 			NormalizeIncomingName(statusable, rawData)
+			NormalizeIncomingQuote(statusable, rawData)
 			continue
 		}
 
@@ -435,6 +438,75 @@ func NormalizeIncomingPollOptions(item WithOneOf, rawJSON map[string]interface{}
 	}
 }
 
+// NormalizeIncomingQuote normalizes the various non-standard
+// properties used by other implementations to indicate a quoted
+// post (`quote`, `quoteUri`, `quoteUrl` and `_misskey_quote`),
+// by appending an equivalent FEP-e232 object Link to the Tag
+// property of the given item, so that ExtractQuoteURI can be
+// used regardless of where the status came from.
+//
+// noop if none of these properties are set to a valid http(s)
+// URI, or if the item already has a quote Link in its tags.
+func NormalizeIncomingQuote(item WithTag, rawJSON map[string]interface{}) {
+	var quoteURI *url.URL
+
+	for _, key := range []string{
+		"quote",
+		"quoteUri",
+		"quoteUrl",
+		"_misskey_quote",
+	} {
+		rawQuote, ok := rawJSON[key].(string)
+		if !ok || rawQuote == "" {
+			continue
+		}
+
+		uri, err := url.Parse(rawQuote)
+		if err != nil || (uri.Scheme != "http" && uri.Scheme != "https") {
+			continue
+		}
+
+		quoteURI = uri
+		break
+	}
+
+	if quoteURI == nil {
+		// No quote
+		// props set.
+		return
+	}
+
+	if ExtractQuoteURI(item) != nil {
+		// Already has
+		// a quote Link.
+		return
+	}
+
+	// Build a quote Link
+	// pointing to the URI.
+	link := streams.NewActivityStreamsLink()
+
+	hrefProp := streams.NewActivityStreamsHrefProperty()
+	hrefProp.SetIRI(quoteURI)
+	link.SetActivityStreamsHref(hrefProp)
+
+	mediaTypeProp := streams.NewActivityStreamsMediaTypeProperty()
+	mediaTypeProp.Set(LinkMediaTypeActivityStreams)
+	link.SetActivityStreamsMediaType(mediaTypeProp)
+
+	relProp := streams.NewActivityStreamsRelProperty()
+	relProp.AppendRFCRfc5988(LinkRelMisskeyQuote)
+	link.SetActivityStreamsRel(relProp)
+
+	// Append Link to the item tags.
+	tagProp := item.GetActivityStreamsTag()
+	if tagProp == nil {
+		tagProp = streams.NewActivityStreamsTagProperty()
+		item.SetActivityStreamsTag(tagProp)
+	}
+	tagProp.AppendActivityStreamsLink(link)
+}
+
 /*
 	OUTGOING NORMALIZATION
 	The below functions should be called to normalize the content
@@ -638,9 +710,10 @@ func NormalizeOutgoingInteractionPolicyProp(item WithInteractionPolicy, rawJSON
 		"canLike",
 		"canReply",
 		"canAnnounce",
+		"canQuote",
 	} {
-		// Either "canAnnounce",
-		// "canLike", or "canApprove"
+		// Either "canAnnounce", "canLike",
+		// "canReply", or "canQuote"
 		rulesVal, ok := policyMap[rulesKey]
 		if !ok {
 			// Not set.
@@ -678,6 +751,27 @@ func NormalizeOutgoingInteractionPolicyProp(item WithInteractionPolicy, rawJSON
 	}
 }
 
+// NormalizeOutgoingQuoteProp adds the non-standard `quote`,
+// `quoteUri` and `_misskey_quote` properties to the rawJSON
+// of the given item, derived from its FEP-e232 quote Link, for
+// compatibility with implementations that don't parse object
+// Links (yet).
+//
+// Noop for items without a quote Link.
+func NormalizeOutgoingQuoteProp(item WithTag, rawJSON map[string]interface{}) {
+	quoteURI := ExtractQuoteURI(item)
+	if quoteURI == nil {
+		// Not a quote,
+		// nothing to add.
+		return
+	}
+
+	quoteURIStr := quoteURI.String()
+	rawJSON["quote"] = quoteURIStr
+	rawJSON["quoteUri"] = quoteURIStr
+	rawJSON["_misskey_quote"] = quoteURIStr
+}
+
 // NormalizeOutgoingObjectProp normalizes each Object entry in the rawJSON of the given
 // item by calling custom serialization / normalization functions on them in turn.
 //
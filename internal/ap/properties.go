@@ -441,6 +441,68 @@ func SetFeatured(with WithFeatured, featured *url.URL) {
 	featuredProp.SetIRI(featured)
 }
 
+// GetFeaturedTags returns the IRI contained in the featuredTags property of 'with'.
+//
+// The vocab has no featuredTags property, so it's read from the unknown properties.
+func GetFeaturedTags(with WithUnknownProperties) *url.URL {
+	var iriStr string
+	switch v := with.GetUnknownProperties()["featuredTags"].(type) {
+	case string:
+		iriStr = v
+	case map[string]interface{}:
+		// Embedded collection, just take the ID.
+		iriStr, _ = v["id"].(string)
+	}
+
+	if iriStr == "" {
+		return nil
+	}
+
+	iri, err := url.Parse(iriStr)
+	if err != nil {
+		return nil
+	}
+	return iri
+}
+
+// SetFeaturedTags sets the given IRI on the featuredTags property of 'with'.
+//
+// The vocab has no featuredTags property, so it's set in the unknown properties.
+func SetFeaturedTags(with WithUnknownProperties, featuredTags *url.URL) {
+	with.GetUnknownProperties()["featuredTags"] = featuredTags.String()
+}
+
+// GetQuoteAuthorization returns the IRI contained in the quoteAuthorization property of 'with'.
+//
+// The vocab has no quoteAuthorization property, so it's read from the unknown properties.
+func GetQuoteAuthorization(with WithUnknownProperties) *url.URL {
+	var iriStr string
+	switch v := with.GetUnknownProperties()["quoteAuthorization"].(type) {
+	case string:
+		iriStr = v
+	case map[string]interface{}:
+		// Embedded authorization, just take the ID.
+		iriStr, _ = v["id"].(string)
+	}
+
+	if iriStr == "" {
+		return nil
+	}
+
+	iri, err := url.Parse(iriStr)
+	if err != nil || (iri.Scheme != "http" && iri.Scheme != "https") {
+		return nil
+	}
+	return iri
+}
+
+// SetQuoteAuthorization sets the given IRI on the quoteAuthorization property of 'with'.
+//
+// The vocab has no quoteAuthorization property, so it's set in the unknown properties.
+func SetQuoteAuthorization(with WithUnknownProperties, quoteAuthorization *url.URL) {
+	with.GetUnknownProperties()["quoteAuthorization"] = quoteAuthorization.String()
+}
+
 // GetMovedTo returns the IRI contained in the movedTo property of 'with'.
 func GetMovedTo(with WithMovedTo) *url.URL {
 	movedToProp := with.GetActivityStreamsMovedTo()
@@ -607,6 +669,27 @@ func SetDiscoverable(with WithDiscoverable, discoverable bool) {
 	discoverProp.Set(discoverable)
 }
 
+// GetIndexable returns the boolean contained in the Indexable property of 'with'.
+//
+// Returns default 'false' if property unusable or not set.
+func GetIndexable(with WithIndexable) bool {
+	indexableProp := with.GetTootIndexable()
+	if indexableProp == nil || !indexableProp.IsXMLSchemaBoolean() {
+		return false
+	}
+	return indexableProp.Get()
+}
+
+// SetIndexable sets the given boolean on the Indexable property of 'with'.
+func SetIndexable(with WithIndexable, indexable bool) {
+	indexableProp := with.GetTootIndexable()
+	if indexableProp == nil {
+		indexableProp = streams.NewTootIndexableProperty()
+		with.SetTootIndexable(indexableProp)
+	}
+	indexableProp.Set(indexable)
+}
+
 // GetManuallyApprovesFollowers returns the boolean contained in the ManuallyApprovesFollowers property of 'with'.
 //
 // Returns default 'false' if property unusable or not set.
@@ -121,6 +121,7 @@ func ResolveStatusable(ctx context.Context, body io.ReadCloser) (Statusable, err
 	NormalizeIncomingAttachments(statusable, raw)
 	NormalizeIncomingSummary(statusable, raw)
 	NormalizeIncomingName(statusable, raw)
+	NormalizeIncomingQuote(statusable, raw)
 
 	return statusable, nil
 }
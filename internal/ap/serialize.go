@@ -36,9 +36,9 @@
 //
 //   - OrderedCollection:       'orderedItems' property will always be made into an array.
 //   - OrderedCollectionPage:   'orderedItems' property will always be made into an array.
-//   - Any Accountable type:    'attachment' property will always be made into an array.
+//   - Any Accountable type:    'attachment' property will always be made into an array; data integrity @context added if 'assertionMethod' set.
 //   - Any Statusable type:     'attachment' property will always be made into an array; 'content', 'contentMap', and 'interactionPolicy' will be normalized.
-//   - Any Activityable type:   any 'object's set on an activity will be custom serialized as above.
+//   - Any Activityable type:   any 'object's set on an activity will be custom serialized as above; data integrity @context added if object has 'assertionMethod'.
 func Serialize(t vocab.Type) (m map[string]interface{}, e error) {
 	switch tn := t.GetTypeName(); {
 	case tn == ObjectOrderedCollection ||
@@ -126,6 +126,9 @@ func serializeAccountable(t vocab.Type, includeContext bool) (map[string]interfa
 
 	NormalizeOutgoingAttachmentProp(accountable, data)
 	NormalizeOutgoingAlsoKnownAsProp(accountable, data)
+	if includeContext {
+		addDataIntegrityContext(data)
+	}
 
 	return data, nil
 }
@@ -153,6 +156,7 @@ func serializeStatusable(t vocab.Type, includeContext bool) (map[string]interfac
 
 	NormalizeOutgoingAttachmentProp(statusable, data)
 	NormalizeOutgoingContentProp(statusable, data)
+	NormalizeOutgoingQuoteProp(statusable, data)
 	if wip, ok := statusable.(WithInteractionPolicy); ok {
 		NormalizeOutgoingInteractionPolicyProp(wip, data)
 	}
@@ -185,5 +189,9 @@ func serializeActivityable(t vocab.Type, includeContext bool) (map[string]interf
 		return nil, err
 	}
 
+	if includeContext {
+		addDataIntegrityContext(data)
+	}
+
 	return data, nil
 }
@@ -78,3 +78,38 @@ type SwaggerFeaturedCollection struct {
 	// example: 2
 	TotalItems int
 }
+
+// SwaggerFeaturedTagsCollection represents an ActivityPub OrderedCollection of Hashtags.
+// swagger:model swaggerFeaturedTagsCollection
+type SwaggerFeaturedTagsCollection struct {
+	// ActivityStreams JSON-LD context.
+	// A string or an array of strings, or more
+	// complex nested items.
+	// example: https://www.w3.org/ns/activitystreams
+	Context interface{} `json:"@context"`
+	// ActivityStreams ID.
+	// example: https://example.org/users/some_user/collections/tags
+	ID string `json:"id"`
+	// ActivityStreams type.
+	// example: OrderedCollection
+	Type string `json:"type"`
+	// List of Hashtag objects.
+	OrderedItems []SwaggerHashtag `json:"orderedItems"`
+	// Number of items in this collection.
+	// example: 2
+	TotalItems int
+}
+
+// SwaggerHashtag represents an ActivityPub Hashtag.
+// swagger:model swaggerHashtag
+type SwaggerHashtag struct {
+	// ActivityStreams type.
+	// example: Hashtag
+	Type string `json:"type"`
+	// Web link to the hashtag.
+	// example: https://example.org/tags/helloworld
+	Href string `json:"href"`
+	// Name of the hashtag, with leading `#`.
+	// example: #helloworld
+	Name string `json:"name"`
+}
@@ -24,6 +24,7 @@
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
@@ -581,6 +582,128 @@ func (suite *InboxPostTestSuite) TestPostUnauthorized() {
 	)
 }
 
+// TestPostForwardedReply verifies that a remote reply in a local
+// account's thread, addressed to that account's followers, gets
+// forwarded on to the account's followers on other instances.
+func (suite *InboxPostTestSuite) TestPostForwardedReply() {
+	var (
+		ctx               = suite.T().Context()
+		requestingAccount = suite.testAccounts["remote_account_1"]
+		targetAccount     = suite.testAccounts["local_account_1"]
+		followingAccount  = suite.testAccounts["remote_account_2"]
+		inReplyTo         = suite.testStatuses["local_account_1_status_1"]
+		replyURI          = requestingAccount.URI + "/statuses/01JZ5C3W1Y8V4N7K6T0Q2R9M3B"
+	)
+
+	// Have a remote account on a
+	// third instance follow target.
+	if err := suite.db.PutFollow(ctx, &gtsmodel.Follow{
+		ID:              "01JZ5C6HZXH6Q3J0X2D8C5W1NP",
+		URI:             followingAccount.URI + "/follows/01JZ5C6HZXH6Q3J0X2D8C5W1NP",
+		AccountID:       followingAccount.ID,
+		TargetAccountID: targetAccount.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	mention := streams.NewActivityStreamsMention()
+	mentionHref := streams.NewActivityStreamsHrefProperty()
+	mentionHref.Set(testrig.URLMustParse(targetAccount.URI))
+	mention.SetActivityStreamsHref(mentionHref)
+	mentionName := streams.NewActivityStreamsNameProperty()
+	mentionName.AppendXMLSchemaString("@" + targetAccount.Username + "@localhost:8080")
+	mention.SetActivityStreamsName(mentionName)
+
+	reply := testrig.NewAPNote(&testrig.NewAPNoteParams{
+		ID:           testrig.URLMustParse(replyURI),
+		CreatedAt:    time.Now(),
+		Content:      "@the_mighty_zork hey look i'm in your thread",
+		AttributedTo: testrig.URLMustParse(requestingAccount.URI),
+		To:           []*url.URL{ap.PublicIRI()},
+		CC: []*url.URL{
+			testrig.URLMustParse(targetAccount.URI),
+			testrig.URLMustParse(targetAccount.FollowersURI),
+		},
+		Mentions:  []vocab.ActivityStreamsMention{mention},
+		InReplyTo: testrig.URLMustParse(inReplyTo.URI),
+	})
+
+	create := testrig.WrapAPNoteInCreate(
+		testrig.URLMustParse(replyURI+"/activity"),
+		testrig.URLMustParse(requestingAccount.URI),
+		time.Now(),
+		reply,
+	)
+
+	suite.inboxPost(
+		create,
+		requestingAccount,
+		targetAccount,
+		http.StatusAccepted,
+		`{"status":"Accepted"}`,
+		suite.signatureCheck,
+	)
+
+	// The Create should be forwarded
+	// as-is to the follower's inbox.
+	var forwarded map[string]any
+	if !testrig.WaitFor(func() bool {
+		delivery, ok := suite.state.Workers.Delivery.Queue.Pop()
+		if !ok || delivery.Request.URL.String() != followingAccount.InboxURI {
+			return false
+		}
+
+		b, err := io.ReadAll(delivery.Request.Body)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		if err := json.Unmarshal(b, &forwarded); err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		return true
+	}) {
+		suite.FailNow("timed out waiting for forwarded delivery")
+	}
+
+	suite.Equal(replyURI+"/activity", forwarded["id"])
+	suite.Equal(requestingAccount.URI, forwarded["actor"])
+}
+
+// TestPostForwardedDelete verifies that a Delete of a status
+// forwarded by someone other than the status author is only
+// processed once the origin server confirms the status is gone.
+func (suite *InboxPostTestSuite) TestPostForwardedDelete() {
+	var (
+		ctx               = suite.T().Context()
+		requestingAccount = suite.testAccounts["remote_account_2"]
+		targetAccount     = suite.testAccounts["local_account_1"]
+		status            = suite.testStatuses["remote_account_1_status_1"]
+		activityID        = status.URI + "#delete"
+	)
+
+	delete := suite.newDelete(status.AccountURI, status.URI, activityID)
+
+	suite.inboxPost(
+		delete,
+		requestingAccount,
+		targetAccount,
+		http.StatusAccepted,
+		`{"status":"Accepted"}`,
+		suite.signatureCheck,
+	)
+
+	// Origin server responds 404 for the
+	// status, so the delete should go through.
+	if !testrig.WaitFor(func() bool {
+		_, err := suite.db.GetStatusByID(ctx, status.ID)
+		return errors.Is(err, db.ErrNoEntries)
+	}) {
+		suite.FailNow("timed out waiting for status to be removed")
+	}
+}
+
 func TestInboxPostTestSuite(t *testing.T) {
 	suite.Run(t, &InboxPostTestSuite{})
 }
@@ -47,6 +47,7 @@
 	OauthOOBTokenPath  = "/oob"   // #nosec G101 else we get a hardcoded credentials warning
 	OauthTokenPath     = "/token" // #nosec G101 else we get a hardcoded credentials warning
 	OauthRevokePath    = "/revoke"
+	OauthDevicePath    = "/device_authorization"
 
 	/*
 		params / session keys
@@ -63,6 +64,9 @@
 	sessionScope             = "scope"
 	sessionInternalState     = "internal_state"
 	sessionClientState       = "client_state"
+	sessionCodeChallenge     = "code_challenge"
+	sessionCodeChallengeMeth = "code_challenge_method"
+	sessionUserCode          = "user_code"
 	sessionClaims            = "claims"
 	sessionAppID             = "app_id"
 )
@@ -102,6 +106,7 @@ func (m *Module) RouteAuth(attachHandler func(method string, path string, f ...g
 func (m *Module) RouteOAuth(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodPost, OauthTokenPath, m.TokenPOSTHandler)
 	attachHandler(http.MethodPost, OauthRevokePath, m.TokenRevokePOSTHandler)
+	attachHandler(http.MethodPost, OauthDevicePath, m.DeviceAuthorizationPOSTHandler)
 	attachHandler(http.MethodGet, OauthAuthorizePath, m.AuthorizeGETHandler)
 	attachHandler(http.MethodPost, OauthAuthorizePath, m.AuthorizePOSTHandler)
 	attachHandler(http.MethodPost, OauthFinalizePath, m.FinalizePOSTHandler)
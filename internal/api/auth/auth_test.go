@@ -67,6 +67,8 @@ type AuthStandardTestSuite struct {
 const (
 	sessionUserID   = "userid"
 	sessionClientID = "client_id"
+	sessionScope    = "scope"
+	sessionUserCode = "user_code"
 )
 
 func (suite *AuthStandardTestSuite) SetupSuite() {
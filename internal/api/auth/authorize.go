@@ -18,6 +18,7 @@
 package auth
 
 import (
+	"errors"
 	"net/http"
 	"net/url"
 	"strings"
@@ -47,6 +48,14 @@ func (m *Module) AuthorizeGETHandler(c *gin.Context) {
 
 	s := sessions.Default(c)
 
+	// If a user code was submitted, the user has been
+	// sent here from the device page to approve a device
+	// authorization, so start the flow from the top.
+	if c.Query(sessionUserCode) != "" {
+		m.redirectDeviceFormToSignIn(c)
+		return
+	}
+
 	// UserID will be set in the session by
 	// AuthorizePOSTHandler if the caller has
 	// already gone through the auth flow.
@@ -82,11 +91,19 @@ func (m *Module) AuthorizeGETHandler(c *gin.Context) {
 		return
 	}
 
-	redirectURI := m.mustStringFromSession(c, s, sessionRedirectURI)
-	if redirectURI == "" {
-		// Error already
-		// written.
-		return
+	// User code will be set if this is a device
+	// flow, in which case there's no redirect URI,
+	// as the user goes back to their device instead.
+	userCode, _ := s.Get(sessionUserCode).(string)
+
+	var redirectURI string
+	if userCode == "" {
+		redirectURI = m.mustStringFromSession(c, s, sessionRedirectURI)
+		if redirectURI == "" {
+			// Error already
+			// written.
+			return
+		}
 	}
 
 	scope := m.mustStringFromSession(c, s, sessionScope)
@@ -116,6 +133,7 @@ func (m *Module) AuthorizeGETHandler(c *gin.Context) {
 			"appname":    app.Name,
 			"appwebsite": app.Website,
 			"redirect":   redirectURI,
+			"usercode":   userCode,
 			"scope":      scope,
 			"user":       user.Account.Username,
 		},
@@ -137,6 +155,13 @@ func (m *Module) AuthorizePOSTHandler(c *gin.Context) {
 	// can be validated by the oauth2 library.
 	s := sessions.Default(c)
 
+	// Device flows don't go through the
+	// oauth2 library, so handle them here.
+	if userCode, ok := s.Get(sessionUserCode).(string); ok && userCode != "" {
+		m.approveDevice(c, s, userCode)
+		return
+	}
+
 	responseType := m.mustStringFromSession(c, s, sessionResponseType)
 	if responseType == "" {
 		// Error already
@@ -184,6 +209,16 @@ func (m *Module) AuthorizePOSTHandler(c *gin.Context) {
 		clientState = cs
 	}
 
+	// PKCE code challenge (+ method) is optional with
+	// default of "", the oauth server will validate it.
+	var codeChallenge, codeChallengeMethod string
+	if cc, ok := s.Get(sessionCodeChallenge).(string); ok {
+		codeChallenge = cc
+	}
+	if ccm, ok := s.Get(sessionCodeChallengeMeth).(string); ok {
+		codeChallengeMethod = ccm
+	}
+
 	// If the user is unconfirmed, waiting approval,
 	// or suspended, redirect to an appropriate help page.
 	if !m.validateUser(c, user) {
@@ -220,6 +255,14 @@ func (m *Module) AuthorizePOSTHandler(c *gin.Context) {
 		c.Request.Form.Set("state", clientState)
 	}
 
+	if codeChallenge != "" {
+		// If PKCE code challenge was submitted,
+		// set it on the form so it's stored with
+		// the code + checked at token exchange.
+		c.Request.Form.Set(sessionCodeChallenge, codeChallenge)
+		c.Request.Form.Set(sessionCodeChallengeMeth, codeChallengeMethod)
+	}
+
 	// If OAuthHandleAuthorizeRequest is successful,
 	// it'll handle any further redirects for us,
 	// but we do still need to handle any errors.
@@ -260,6 +303,9 @@ func (m *Module) redirectAuthFormToSignIn(c *gin.Context) {
 	s.Set(sessionScope, form.Scope)
 	s.Set(sessionInternalState, uuid.NewString())
 	s.Set(sessionClientState, form.State)
+	s.Set(sessionCodeChallenge, form.CodeChallenge)
+	s.Set(sessionCodeChallengeMeth, form.CodeChallengeMethod)
+	s.Delete(sessionUserCode)
 
 	m.mustSaveSession(s)
 	c.Redirect(http.StatusSeeOther, "/auth"+AuthSignInPath)
@@ -301,3 +347,108 @@ func (m *Module) validateUser(
 		return true
 	}
 }
+
+// redirectDeviceFormToSignIn looks up the device
+// authorization for the user code submitted from
+// the device page, and stores its client ID and
+// scope in the session (in lieu of an authorize
+// form) before redirecting to the sign in page.
+func (m *Module) redirectDeviceFormToSignIn(c *gin.Context) {
+	s := sessions.Default(c)
+
+	deviceAuth, errWithCode := m.processor.OAuthGetDeviceAuthorization(
+		c.Request.Context(),
+		c.Query(sessionUserCode),
+	)
+	if errWithCode != nil {
+		m.mustClearSession(s)
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// Start from a clean session so nothing
+	// is left over from any previous flow.
+	s.Clear()
+	s.Set(sessionClientID, deviceAuth.ClientID)
+	s.Set(sessionScope, deviceAuth.Scope)
+	s.Set(sessionUserCode, deviceAuth.UserCode)
+	s.Set(sessionInternalState, uuid.NewString())
+
+	m.mustSaveSession(s)
+	c.Redirect(http.StatusSeeOther, "/auth"+AuthSignInPath)
+}
+
+// approveDevice approves the device authorization with the
+// given user code on behalf of the signed in user, and shows
+// a page telling them they can go back to their device.
+func (m *Module) approveDevice(
+	c *gin.Context,
+	s sessions.Session,
+	userCode string,
+) {
+	// Only approve for users who've
+	// fully signed in, including 2fa.
+	if userID, ok := s.Get(sessionUserID).(string); !ok || userID == "" {
+		const errText = "key userid not found in session"
+		m.clearSessionWithBadRequest(c, s, errors.New(errText), errText, oauth.HelpfulAdvice)
+		return
+	}
+
+	user := m.mustUserFromSession(c, s)
+	if user == nil {
+		// Error already
+		// written.
+		return
+	}
+
+	// If the user is unconfirmed, waiting approval,
+	// or suspended, redirect to an appropriate help page.
+	if !m.validateUser(c, user) {
+		// Already
+		// redirected.
+		return
+	}
+
+	scope := m.mustStringFromSession(c, s, sessionScope)
+	if scope == "" {
+		// Error already
+		// written.
+		return
+	}
+
+	app := m.mustAppFromSession(c, s)
+	if app == nil {
+		// Error already
+		// written.
+		return
+	}
+
+	// We're done with
+	// the session now.
+	m.mustClearSession(s)
+
+	if errWithCode := m.processor.OAuthApproveDeviceAuthorization(
+		c.Request.Context(),
+		userCode,
+		user,
+	); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	instance, errWithCode := m.processor.InstanceGetV1(c.Request.Context())
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.TemplateWebPage(c, apiutil.WebPage{
+		Template: "device-approved.tmpl",
+		Instance: instance,
+		Extra: map[string]any{
+			"appname": app.Name,
+			"scope":   scope,
+			"user":    user.Account.Username,
+		},
+	})
+}
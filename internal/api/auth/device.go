@@ -0,0 +1,128 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auth
+
+import (
+	"net/http"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	apiutil "code.superseriousbusiness.org/gotosocial/internal/api/util"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	oautherr "code.superseriousbusiness.org/oauth2/v4/errors"
+	"github.com/gin-gonic/gin"
+)
+
+// DeviceAuthorizationPOSTHandler swagger:operation POST /oauth/device_authorization oauthDeviceAuthorization
+//
+// Start an OAuth device authorization flow, as per RFC 8628.
+//
+// This allows clients that can't easily open a browser on the same machine
+// (eg., CLI applications, or apps running on a TV) to obtain an access token.
+//
+// The client shows the returned `user_code` and `verification_uri` to the user,
+// who then visits the verification URI in a browser on another device, signs in,
+// enters the user code, and approves the request.
+//
+// Meanwhile, the client polls `/oauth/token` with `grant_type` set to
+// `urn:ietf:params:oauth:grant-type:device_code` and the returned `device_code`,
+// waiting at least `interval` seconds between requests, until it receives a token.
+//
+//	---
+//	tags:
+//	- oauth
+//
+//	consumes:
+//	- multipart/form-data
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: client_id
+//		in: formData
+//		description: The client ID, obtained during app registration.
+//		type: string
+//		required: true
+//	-
+//		name: client_secret
+//		in: formData
+//		description: >-
+//			The client secret, obtained during app registration.
+//			Required for all clients except public clients.
+//		type: string
+//	-
+//		name: scope
+//		in: formData
+//		description: >-
+//			Space-separated list of requested scopes. Must be a subset
+//			of scopes declared during app registration. Defaults to read.
+//		type: string
+//
+//	responses:
+//		'200':
+//			description: Device code and user code.
+//			schema:
+//				"$ref": "#/definitions/deviceAuthorization"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) DeviceAuthorizationPOSTHandler(c *gin.Context) {
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.DeviceAuthorizationRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.OAuthErrorHandler(c, gtserror.NewErrorBadRequest(oautherr.ErrInvalidRequest, err.Error()))
+		return
+	}
+
+	if form.ClientID == "" {
+		errWithCode := gtserror.NewErrorBadRequest(
+			oautherr.ErrInvalidRequest,
+			"client_id not set",
+		)
+		apiutil.OAuthErrorHandler(c, errWithCode)
+		return
+	}
+
+	// Public clients don't have a client_secret,
+	// so leave it to the processor to check it.
+
+	deviceAuth, errWithCode := m.processor.OAuthDeviceAuthorization(
+		c.Request.Context(),
+		form,
+	)
+	if errWithCode != nil {
+		apiutil.OAuthErrorHandler(c, errWithCode)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+	apiutil.JSON(c, http.StatusOK, deviceAuth)
+}
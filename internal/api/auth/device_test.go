@@ -0,0 +1,289 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auth_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/oauth"
+	"code.superseriousbusiness.org/gotosocial/testrig"
+	"github.com/gin-contrib/sessions"
+	"github.com/stretchr/testify/suite"
+)
+
+type DeviceTestSuite struct {
+	AuthStandardTestSuite
+}
+
+func (suite *DeviceTestSuite) TestDeviceAuthorizationFlow() {
+	var (
+		app  = suite.testApplications["application_1"]
+		user = suite.testUsers["local_account_1"]
+	)
+
+	// Request device + user codes.
+	code, b := suite.postDeviceAuthorization(map[string][]string{
+		"client_id":     {app.ClientID},
+		"client_secret": {app.ClientSecret},
+		"scope":         {"read"},
+	})
+	suite.Equal(http.StatusOK, code, string(b))
+
+	deviceAuth := &apimodel.DeviceAuthorization{}
+	if err := json.Unmarshal(b, deviceAuth); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.NotEmpty(deviceAuth.DeviceCode)
+	suite.Regexp(regexp.MustCompile(`^[BCDFGHJKLMNPQRSTVWXZ]{4}-[BCDFGHJKLMNPQRSTVWXZ]{4}$`), deviceAuth.UserCode)
+	suite.Equal("http://localhost:8080/device", deviceAuth.VerificationURI)
+	suite.Equal("http://localhost:8080/device?user_code="+deviceAuth.UserCode, deviceAuth.VerificationURIComplete)
+	suite.InDelta(900, deviceAuth.ExpiresIn, 1)
+	suite.EqualValues(5, deviceAuth.Interval)
+
+	pollForm := map[string][]string{
+		"client_id":     {app.ClientID},
+		"client_secret": {app.ClientSecret},
+		"grant_type":    {oauth.DeviceCodeGrantType},
+		"device_code":   {deviceAuth.DeviceCode},
+	}
+
+	// User hasn't approved yet.
+	code, b = suite.postToken(pollForm)
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Equal(`{"error":"authorization_pending","error_description":"Bad Request: waiting for the user to approve the request"}`, string(b))
+
+	// Polling again straight away is too soon.
+	code, b = suite.postToken(pollForm)
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Equal(`{"error":"slow_down","error_description":"Bad Request: polling too frequently, increase the polling interval by 5 seconds"}`, string(b))
+
+	// User signs in and approves
+	// the request in their browser.
+	ctx, recorder := suite.newContext(http.MethodPost, "oauth/authorize", nil, "")
+	testSession := sessions.Default(ctx)
+	testSession.Set(sessionUserID, user.ID)
+	testSession.Set(sessionClientID, app.ClientID)
+	testSession.Set(sessionScope, "read")
+	testSession.Set(sessionUserCode, deviceAuth.UserCode)
+	if err := testSession.Save(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.authModule.AuthorizePOSTHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Contains(recorder.Body.String(), "go back to your device")
+
+	// Now the client gets a token.
+	code, b = suite.postToken(pollForm)
+	suite.Equal(http.StatusOK, code, string(b))
+
+	token := &apimodel.Token{}
+	if err := json.Unmarshal(b, token); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("read", token.Scope)
+
+	dbToken, err := suite.state.DB.GetTokenByAccess(suite.T().Context(), token.AccessToken)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(user.ID, dbToken.UserID)
+	suite.Equal(app.ClientID, dbToken.ClientID)
+
+	// Device code can't be used twice.
+	code, b = suite.postToken(pollForm)
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Equal(`{"error":"invalid_grant","error_description":"Bad Request: device code is invalid or has already been used: `+oauth.HelpfulAdvice+`"}`, string(b))
+}
+
+func (suite *DeviceTestSuite) TestDeviceAuthorizationBadSecret() {
+	app := suite.testApplications["application_1"]
+
+	code, b := suite.postDeviceAuthorization(map[string][]string{
+		"client_id":     {app.ClientID},
+		"client_secret": {"not the right secret"},
+	})
+	suite.Equal(http.StatusUnauthorized, code)
+	suite.Equal(`{"error":"invalid_client","error_description":"Unauthorized: client authentication failed, make sure client_secret is correct: `+oauth.HelpfulAdvice+`"}`, string(b))
+}
+
+func (suite *DeviceTestSuite) TestDeviceAuthorizationBadScope() {
+	app := suite.testApplications["application_1"]
+
+	code, b := suite.postDeviceAuthorization(map[string][]string{
+		"client_id":     {app.ClientID},
+		"client_secret": {app.ClientSecret},
+		"scope":         {"admin"},
+	})
+	suite.Equal(http.StatusForbidden, code)
+	suite.Equal(`{"error":"invalid_scope","error_description":"Forbidden: requested scope admin was not covered by client scope: `+oauth.HelpfulAdvice+`"}`, string(b))
+}
+
+func (suite *DeviceTestSuite) TestDeviceCodeExpired() {
+	app := suite.testApplications["application_1"]
+
+	code, b := suite.postDeviceAuthorization(map[string][]string{
+		"client_id":     {app.ClientID},
+		"client_secret": {app.ClientSecret},
+	})
+	suite.Equal(http.StatusOK, code, string(b))
+
+	deviceAuth := &apimodel.DeviceAuthorization{}
+	if err := json.Unmarshal(b, deviceAuth); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Expire the device code.
+	dbDeviceAuth, err := suite.state.DB.GetDeviceAuthorizationByDeviceCode(suite.T().Context(), deviceAuth.DeviceCode)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	dbDeviceAuth.ExpiresAt = time.Now().Add(-time.Minute)
+	if err := suite.state.DB.UpdateDeviceAuthorization(suite.T().Context(), dbDeviceAuth, "expires_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	code, b = suite.postToken(map[string][]string{
+		"client_id":     {app.ClientID},
+		"client_secret": {app.ClientSecret},
+		"grant_type":    {oauth.DeviceCodeGrantType},
+		"device_code":   {deviceAuth.DeviceCode},
+	})
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Equal(`{"error":"expired_token","error_description":"Bad Request: device code has expired, request a new one and try again"}`, string(b))
+
+	// User can't approve it anymore either.
+	_, errWithCode := suite.processor.OAuthGetDeviceAuthorization(suite.T().Context(), deviceAuth.UserCode)
+	suite.EqualError(errWithCode, "device authorization not found, expired, or already approved")
+}
+
+func (suite *DeviceTestSuite) TestDeviceCodeConcurrentPolls() {
+	var (
+		app  = suite.testApplications["application_1"]
+		user = suite.testUsers["local_account_1"]
+	)
+
+	code, b := suite.postDeviceAuthorization(map[string][]string{
+		"client_id":     {app.ClientID},
+		"client_secret": {app.ClientSecret},
+	})
+	suite.Equal(http.StatusOK, code, string(b))
+
+	deviceAuth := &apimodel.DeviceAuthorization{}
+	if err := json.Unmarshal(b, deviceAuth); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Approve the device code.
+	dbDeviceAuth, err := suite.state.DB.GetDeviceAuthorizationByDeviceCode(suite.T().Context(), deviceAuth.DeviceCode)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	dbDeviceAuth.UserID = user.ID
+	if err := suite.state.DB.UpdateDeviceAuthorization(suite.T().Context(), dbDeviceAuth, "user_id"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	pollForm := map[string][]string{
+		"client_id":     {app.ClientID},
+		"client_secret": {app.ClientSecret},
+		"grant_type":    {oauth.DeviceCodeGrantType},
+		"device_code":   {deviceAuth.DeviceCode},
+	}
+
+	// Poll several times at once,
+	// only one poll may get a token.
+	const polls = 8
+	codes := make([]int, polls)
+
+	var wg sync.WaitGroup
+	for i := range polls {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			codes[i], _ = suite.postToken(pollForm)
+		}()
+	}
+	wg.Wait()
+
+	var ok int
+	for _, code := range codes {
+		if code == http.StatusOK {
+			ok++
+		} else {
+			suite.Equal(http.StatusBadRequest, code)
+		}
+	}
+	suite.Equal(1, ok)
+}
+
+func (suite *DeviceTestSuite) postDeviceAuthorization(form map[string][]string) (int, []byte) {
+	requestBody, w, err := testrig.CreateMultipartFormData(nil, form)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ctx, recorder := suite.newContext(http.MethodPost, "oauth/device_authorization", requestBody.Bytes(), w.FormDataContentType())
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.authModule.DeviceAuthorizationPOSTHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return recorder.Code, b
+}
+
+func (suite *DeviceTestSuite) postToken(form map[string][]string) (int, []byte) {
+	requestBody, w, err := testrig.CreateMultipartFormData(nil, form)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ctx, recorder := suite.newContext(http.MethodPost, "oauth/token", requestBody.Bytes(), w.FormDataContentType())
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.authModule.TokenPOSTHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return recorder.Code, b
+}
+
+func TestDeviceTestSuite(t *testing.T) {
+	suite.Run(t, &DeviceTestSuite{})
+}
@@ -28,7 +28,10 @@
 
 // TokenRevokePOSTHandler swagger:operation POST /oauth/revoke oauthTokenRevoke
 //
-// Revoke an access token to make it no longer valid for use.
+// Revoke an access token or refresh token to make it no longer valid for use.
+//
+// Access and refresh tokens are issued in pairs, so revoking
+// one of them will also revoke the other one from the same pair.
 //
 //	---
 //	tags:
@@ -50,13 +53,14 @@
 //	-
 //		name: client_secret
 //		in: formData
-//		description: The client secret, obtained during app registration.
+//		description: >-
+//			The client secret, obtained during app registration.
+//			Required for all clients except public clients.
 //		type: string
-//		required: true
 //	-
 //		name: token
 //		in: formData
-//		description: The previously obtained token, to be invalidated.
+//		description: The previously obtained access token or refresh token, to be invalidated.
 //		type: string
 //		required: true
 //
@@ -111,16 +115,10 @@ func (m *Module) TokenRevokePOSTHandler(c *gin.Context) {
 		return
 	}
 
-	if form.ClientSecret == "" {
-		errWithCode := gtserror.NewErrorBadRequest(
-			oautherr.ErrInvalidRequest,
-			"client_secret not set",
-		)
-		apiutil.OAuthErrorHandler(c, errWithCode)
-		return
-	}
+	// Public clients don't have a client_secret,
+	// so leave it to the processor to check it.
 
-	errWithCode := m.processor.OAuthRevokeAccessToken(
+	errWithCode := m.processor.OAuthRevokeToken(
 		c.Request.Context(),
 		form.ClientID,
 		form.ClientSecret,
@@ -25,6 +25,8 @@
 	"testing"
 
 	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
 	"code.superseriousbusiness.org/gotosocial/testrig"
 	"github.com/stretchr/testify/suite"
 )
@@ -193,6 +195,69 @@ func (suite *RevokeTestSuite) TestRevokeNoClientID() {
 	suite.NoError(err)
 }
 
+func (suite *RevokeTestSuite) TestRevokeRefreshToken() {
+	var (
+		app   = suite.testApplications["application_1"]
+		token = new(gtsmodel.Token)
+	)
+
+	// Store a copy of the test token
+	// with new access + refresh tokens.
+	*token = *suite.testTokens["local_account_1"]
+	token.ID = id.NewULID()
+	token.Access = "SOMEACCESSTOKEN"
+	token.Refresh = "SOMEREFRESHTOKEN"
+	token.RefreshCreateAt = token.AccessCreateAt
+	if err := suite.state.DB.PutToken(
+		suite.T().Context(),
+		token,
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Prepare request form.
+	requestBody, w, err := testrig.CreateMultipartFormData(
+		nil,
+		map[string][]string{
+			"token":         {token.Refresh},
+			"client_id":     {app.ClientID},
+			"client_secret": {app.ClientSecret},
+		})
+	if err != nil {
+		panic(err)
+	}
+
+	// Prepare request ctx.
+	ctx, recorder := suite.newContext(
+		http.MethodPost,
+		"/oauth/revoke",
+		requestBody.Bytes(),
+		w.FormDataContentType(),
+	)
+
+	// Submit the revoke request.
+	suite.authModule.TokenRevokePOSTHandler(ctx)
+
+	// Check response code.
+	suite.Equal(http.StatusOK, recorder.Code)
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	// Ensure refresh token now gone,
+	// along with its access token.
+	_, err = suite.state.DB.GetTokenByRefresh(
+		suite.T().Context(),
+		token.Refresh,
+	)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	_, err = suite.state.DB.GetTokenByAccess(
+		suite.T().Context(),
+		token.Access,
+	)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
 func TestRevokeTestSuite(t *testing.T) {
 	suite.Run(t, new(RevokeTestSuite))
 }
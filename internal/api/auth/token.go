@@ -35,6 +35,9 @@ type tokenRequestForm struct {
 	ClientID     *string `form:"client_id" json:"client_id" xml:"client_id"`
 	ClientSecret *string `form:"client_secret" json:"client_secret" xml:"client_secret"`
 	Scope        *string `form:"scope" json:"scope" xml:"scope"`
+	CodeVerifier *string `form:"code_verifier" json:"code_verifier" xml:"code_verifier"`
+	RefreshToken *string `form:"refresh_token" json:"refresh_token" xml:"refresh_token"`
+	DeviceCode   *string `form:"device_code" json:"device_code" xml:"device_code"`
 }
 
 // TokenPOSTHandler should be served as a POST at https://example.org/oauth/token
@@ -60,7 +63,7 @@ func (m *Module) TokenPOSTHandler(c *gin.Context) {
 		grantType = *form.GrantType
 		c.Request.Form.Set("grant_type", grantType)
 	} else {
-		help = append(help, "grant_type was not set in the token request form, but must be set to authorization_code or client_credentials")
+		help = append(help, "grant_type was not set in the token request form, but must be set to authorization_code, client_credentials, refresh_token or "+oauth.DeviceCodeGrantType)
 	}
 
 	if form.ClientID != nil {
@@ -69,15 +72,21 @@ func (m *Module) TokenPOSTHandler(c *gin.Context) {
 		help = append(help, "client_id was not set in the token request form")
 	}
 
+	// Public clients don't have a client secret,
+	// but it's always required for client_credentials,
+	// as public clients can't use that grant type.
 	if form.ClientSecret != nil {
 		c.Request.Form.Set("client_secret", *form.ClientSecret)
-	} else {
-		help = append(help, "client_secret was not set in the token request form")
+	} else if grantType == "client_credentials" {
+		help = append(help, "client_secret was not set in the token request form, but must be set since grant_type is client_credentials")
 	}
 
+	// Redirect URI isn't used when refreshing a token
+	// or polling with a device code, but it's required
+	// otherwise.
 	if form.RedirectURI != nil {
 		c.Request.Form.Set("redirect_uri", *form.RedirectURI)
-	} else {
+	} else if grantType != "refresh_token" && grantType != oauth.DeviceCodeGrantType {
 		help = append(help, "redirect_uri was not set in the token request form")
 	}
 
@@ -93,6 +102,34 @@ func (m *Module) TokenPOSTHandler(c *gin.Context) {
 		help = append(help, "code was not set in the token request form, but must be set since grant_type is authorization_code")
 	}
 
+	if form.CodeVerifier != nil {
+		if grantType != "authorization_code" {
+			help = append(help, "a code_verifier was provided in the token request form, but grant_type was not set to authorization_code")
+		} else {
+			c.Request.Form.Set("code_verifier", *form.CodeVerifier)
+		}
+	}
+
+	if form.RefreshToken != nil {
+		if grantType != "refresh_token" {
+			help = append(help, "a refresh_token was provided in the token request form, but grant_type was not set to refresh_token")
+		} else {
+			c.Request.Form.Set("refresh_token", *form.RefreshToken)
+		}
+	} else if grantType == "refresh_token" {
+		help = append(help, "refresh_token was not set in the token request form, but must be set since grant_type is refresh_token")
+	}
+
+	if form.DeviceCode != nil {
+		if grantType != oauth.DeviceCodeGrantType {
+			help = append(help, "a device_code was provided in the token request form, but grant_type was not set to "+oauth.DeviceCodeGrantType)
+		} else {
+			c.Request.Form.Set("device_code", *form.DeviceCode)
+		}
+	} else if grantType == oauth.DeviceCodeGrantType {
+		help = append(help, "device_code was not set in the token request form, but must be set since grant_type is "+oauth.DeviceCodeGrantType)
+	}
+
 	if form.Scope != nil {
 		c.Request.Form.Set("scope", *form.Scope)
 	}
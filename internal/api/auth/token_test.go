@@ -18,15 +18,22 @@
 package auth_test
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
 	"io"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
+	"code.superseriousbusiness.org/gotosocial/internal/api/auth"
 	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/config"
 	"code.superseriousbusiness.org/gotosocial/internal/db"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
 	"code.superseriousbusiness.org/gotosocial/testrig"
 	"github.com/stretchr/testify/suite"
 )
@@ -49,7 +56,7 @@ func (suite *TokenTestSuite) TestPOSTTokenEmptyForm() {
 	b, err := io.ReadAll(result.Body)
 	suite.NoError(err)
 
-	suite.Equal(`{"error":"invalid_request","error_description":"Bad Request: grant_type was not set in the token request form, but must be set to authorization_code or client_credentials: client_id was not set in the token request form: client_secret was not set in the token request form: redirect_uri was not set in the token request form"}`, string(b))
+	suite.Equal(`{"error":"invalid_request","error_description":"Bad Request: grant_type was not set in the token request form, but must be set to authorization_code, client_credentials, refresh_token or urn:ietf:params:oauth:grant-type:device_code: client_id was not set in the token request form: redirect_uri was not set in the token request form"}`, string(b))
 }
 
 func (suite *TokenTestSuite) TestRetrieveClientCredentialsOK() {
@@ -273,6 +280,261 @@ func (suite *TokenTestSuite) TestRetrieveAuthorizationCodeWrongGrantType() {
 	suite.Equal(`{"error":"invalid_request","error_description":"Bad Request: a code was provided in the token request form, but grant_type was not set to authorization_code"}`, string(b))
 }
 
+func (suite *TokenTestSuite) TestRetrieveAuthorizationCodePKCE() {
+	const verifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gXk-fGX6nk"
+	challenge := pkceChallenge(verifier)
+
+	for _, test := range []struct {
+		verifier     string
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			verifier:     verifier,
+			expectedCode: http.StatusOK,
+		},
+		{
+			verifier:     "",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"error":"invalid_request","error_description":"Bad Request: code_verifier must be set if, and only if, a code_challenge was provided when requesting the authorization code: If you arrived at this error during a sign in/oauth flow, please try clearing your session cookies and signing in again; if problems persist, make sure you're using the correct credentials"}`,
+		},
+		{
+			verifier:     "not-the-right-verifier-not-the-right-verifier",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: `{"error":"invalid_grant","error_description":"Bad Request: could not get access token: invalid_grant: If you arrived at this error during a sign in/oauth flow, please try clearing your session cookies and signing in again; if problems persist, make sure you're using the correct credentials"}`,
+		},
+	} {
+		testApp := suite.testApplications["application_1"]
+		codeToken := suite.putCodeToken(testApp, challenge)
+
+		form := map[string][]string{
+			"grant_type":    {"authorization_code"},
+			"client_id":     {testApp.ClientID},
+			"client_secret": {testApp.ClientSecret},
+			"redirect_uri":  {"http://localhost:8080"},
+			"code":          {codeToken.Code},
+		}
+		if test.verifier != "" {
+			form["code_verifier"] = []string{test.verifier}
+		}
+
+		code, b := suite.postToken(form)
+		suite.Equal(test.expectedCode, code, string(b))
+		if test.expectedBody != "" {
+			suite.Equal(test.expectedBody, string(b))
+		}
+	}
+}
+
+func (suite *TokenTestSuite) TestRetrieveAuthorizationCodePublicClient() {
+	const verifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gXk-fGX6nk"
+
+	// Create a new public client
+	// based on application_1.
+	testApp := new(gtsmodel.Application)
+	*testApp = *suite.testApplications["application_1"]
+	testApp.ID = id.NewULID()
+	testApp.ClientID = id.NewRandomULID()
+	testApp.Public = util.Ptr(true)
+	if err := suite.db.PutApplication(suite.T().Context(), testApp); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Public client should be able to exchange
+	// a code with PKCE, without a client secret.
+	codeToken := suite.putCodeToken(testApp, pkceChallenge(verifier))
+	code, b := suite.postToken(map[string][]string{
+		"grant_type":    {"authorization_code"},
+		"client_id":     {testApp.ClientID},
+		"redirect_uri":  {"http://localhost:8080"},
+		"code":          {codeToken.Code},
+		"code_verifier": {verifier},
+	})
+	suite.Equal(http.StatusOK, code, string(b))
+
+	// Public client should not be able
+	// to get a client credentials token.
+	code, b = suite.postToken(map[string][]string{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {testApp.ClientID},
+		"client_secret": {testApp.ClientSecret},
+		"redirect_uri":  {"http://localhost:8080"},
+	})
+	suite.Equal(http.StatusUnauthorized, code)
+	suite.Equal(`{"error":"invalid_client","error_description":"Unauthorized: client authentication failed, make sure client_secret is correct: If you arrived at this error during a sign in/oauth flow, please try clearing your session cookies and signing in again; if problems persist, make sure you're using the correct credentials"}`, string(b))
+}
+
+func (suite *TokenTestSuite) TestRefreshToken() {
+	// Recreate the auth module
+	// with expiring access tokens.
+	config.SetOAuthAccessTokenExpiry(1 * time.Hour)
+	suite.processor = testrig.NewTestProcessor(
+		&suite.state,
+		suite.federator,
+		suite.emailSender,
+		testrig.NewNoopWebPushSender(),
+		suite.mediaManager,
+	)
+	suite.authModule = auth.New(&suite.state, suite.processor, suite.idp)
+
+	testApp := suite.testApplications["application_1"]
+	testUserAuthorizationToken := suite.testTokens["local_account_1_user_authorization_token"]
+
+	// Exchange code for access + refresh token.
+	code, b := suite.postToken(map[string][]string{
+		"grant_type":    {"authorization_code"},
+		"client_id":     {testApp.ClientID},
+		"client_secret": {testApp.ClientSecret},
+		"redirect_uri":  {"http://localhost:8080"},
+		"code":          {testUserAuthorizationToken.Code},
+	})
+	suite.Equal(http.StatusOK, code, string(b))
+
+	first := &apimodel.Token{}
+	if err := json.Unmarshal(b, first); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotEmpty(first.AccessToken)
+	suite.NotEmpty(first.RefreshToken)
+	suite.InDelta(3600, first.ExpiresIn, 5)
+
+	// Trying to refresh with the wrong
+	// client secret should not work.
+	code, b = suite.postToken(map[string][]string{
+		"grant_type":    {"refresh_token"},
+		"client_id":     {testApp.ClientID},
+		"client_secret": {"nope"},
+		"refresh_token": {first.RefreshToken},
+	})
+	suite.Equal(http.StatusUnauthorized, code)
+	suite.Equal(`{"error":"invalid_client","error_description":"Unauthorized: client authentication failed, make sure client_secret is correct: If you arrived at this error during a sign in/oauth flow, please try clearing your session cookies and signing in again; if problems persist, make sure you're using the correct credentials"}`, string(b))
+
+	// Trying to refresh as a different
+	// client should not work either.
+	otherApp := suite.testApplications["application_2"]
+	code, b = suite.postToken(map[string][]string{
+		"grant_type":    {"refresh_token"},
+		"client_id":     {otherApp.ClientID},
+		"client_secret": {otherApp.ClientSecret},
+		"refresh_token": {first.RefreshToken},
+	})
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Equal(`{"error":"invalid_grant","error_description":"Bad Request: refresh token was not issued to this client: If you arrived at this error during a sign in/oauth flow, please try clearing your session cookies and signing in again; if problems persist, make sure you're using the correct credentials"}`, string(b))
+
+	// Refresh with narrower scope.
+	code, b = suite.postToken(map[string][]string{
+		"grant_type":    {"refresh_token"},
+		"client_id":     {testApp.ClientID},
+		"client_secret": {testApp.ClientSecret},
+		"refresh_token": {first.RefreshToken},
+		"scope":         {"read"},
+	})
+	suite.Equal(http.StatusOK, code, string(b))
+
+	second := &apimodel.Token{}
+	if err := json.Unmarshal(b, second); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotEmpty(second.AccessToken)
+	suite.NotEmpty(second.RefreshToken)
+	suite.NotEqual(first.AccessToken, second.AccessToken)
+	suite.NotEqual(first.RefreshToken, second.RefreshToken)
+	suite.Equal("read", second.Scope)
+
+	// Old access + refresh tokens should be gone.
+	_, err := suite.db.GetTokenByAccess(suite.T().Context(), first.AccessToken)
+	suite.ErrorIs(err, db.ErrNoEntries)
+	_, err = suite.db.GetTokenByRefresh(suite.T().Context(), first.RefreshToken)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// So reusing the old refresh token should fail.
+	code, b = suite.postToken(map[string][]string{
+		"grant_type":    {"refresh_token"},
+		"client_id":     {testApp.ClientID},
+		"client_secret": {testApp.ClientSecret},
+		"refresh_token": {first.RefreshToken},
+	})
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Equal(`{"error":"invalid_grant","error_description":"Bad Request: refresh token is invalid, expired, or has already been used: If you arrived at this error during a sign in/oauth flow, please try clearing your session cookies and signing in again; if problems persist, make sure you're using the correct credentials"}`, string(b))
+
+	// Refreshing with a wider scope
+	// than the token had should fail.
+	code, _ = suite.postToken(map[string][]string{
+		"grant_type":    {"refresh_token"},
+		"client_id":     {testApp.ClientID},
+		"client_secret": {testApp.ClientSecret},
+		"refresh_token": {second.RefreshToken},
+		"scope":         {"read write"},
+	})
+	suite.Equal(http.StatusForbidden, code)
+
+	// New access token should be usable.
+	dbToken, err := suite.db.GetTokenByAccess(suite.T().Context(), second.AccessToken)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(testUserAuthorizationToken.UserID, dbToken.UserID)
+	suite.WithinDuration(time.Now().Add(1*time.Hour), dbToken.AccessExpiresAt, 1*time.Minute)
+	suite.WithinDuration(time.Now().Add(config.GetOAuthRefreshTokenExpiry()), dbToken.RefreshExpiresAt, 1*time.Minute)
+}
+
+// putCodeToken stores a new authorization code token for
+// local_account_1 and the given app, with the given PKCE
+// code challenge, as if the user had just authorized it.
+func (suite *TokenTestSuite) putCodeToken(
+	app *gtsmodel.Application,
+	codeChallenge string,
+) *gtsmodel.Token {
+	now := time.Now()
+	token := &gtsmodel.Token{
+		ID:                  id.NewULID(),
+		ClientID:            app.ClientID,
+		UserID:              suite.testUsers["local_account_1"].ID,
+		RedirectURI:         "http://localhost:8080",
+		Scope:               "read",
+		Code:                strings.ToUpper(id.NewULID()),
+		CodeChallenge:       codeChallenge,
+		CodeChallengeMethod: "S256",
+		CodeCreateAt:        now,
+		CodeExpiresAt:       now.Add(10 * time.Minute),
+	}
+	if err := suite.db.PutToken(suite.T().Context(), token); err != nil {
+		suite.FailNow(err.Error())
+	}
+	return token
+}
+
+// postToken submits the given form to the token
+// handler, returning response status code + body.
+func (suite *TokenTestSuite) postToken(form map[string][]string) (int, []byte) {
+	requestBody, w, err := testrig.CreateMultipartFormData(nil, form)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ctx, recorder := suite.newContext(http.MethodPost, "oauth/token", requestBody.Bytes(), w.FormDataContentType())
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.authModule.TokenPOSTHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return recorder.Code, b
+}
+
+// pkceChallenge returns the
+// S256 challenge for verifier.
+func pkceChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
 func TestTokenTestSuite(t *testing.T) {
 	suite.Run(t, &TokenTestSuite{})
 }
@@ -28,6 +28,7 @@
 	"code.superseriousbusiness.org/gotosocial/internal/api/client/bookmarks"
 	"code.superseriousbusiness.org/gotosocial/internal/api/client/conversations"
 	"code.superseriousbusiness.org/gotosocial/internal/api/client/customemojis"
+	"code.superseriousbusiness.org/gotosocial/internal/api/client/domainblocks"
 	"code.superseriousbusiness.org/gotosocial/internal/api/client/exports"
 	"code.superseriousbusiness.org/gotosocial/internal/api/client/favourites"
 	"code.superseriousbusiness.org/gotosocial/internal/api/client/featuredtags"
@@ -39,6 +40,7 @@
 	"code.superseriousbusiness.org/gotosocial/internal/api/client/instance"
 	"code.superseriousbusiness.org/gotosocial/internal/api/client/interactionpolicies"
 	"code.superseriousbusiness.org/gotosocial/internal/api/client/interactionrequests"
+	"code.superseriousbusiness.org/gotosocial/internal/api/client/invites"
 	"code.superseriousbusiness.org/gotosocial/internal/api/client/lists"
 	"code.superseriousbusiness.org/gotosocial/internal/api/client/markers"
 	"code.superseriousbusiness.org/gotosocial/internal/api/client/media"
@@ -78,6 +80,7 @@ type Client struct {
 	bookmarks           *bookmarks.Module           // api/v1/bookmarks
 	conversations       *conversations.Module       // api/v1/conversations
 	customEmojis        *customemojis.Module        // api/v1/custom_emojis
+	domainBlocks        *domainblocks.Module        // api/v1/domain_blocks
 	exports             *exports.Module             // api/v1/exports
 	favourites          *favourites.Module          // api/v1/favourites
 	featuredTags        *featuredtags.Module        // api/v1/featured_tags
@@ -89,6 +92,7 @@ type Client struct {
 	instance            *instance.Module            // api/v1/instance
 	interactionPolicies *interactionpolicies.Module // api/v1/interaction_policies
 	interactionRequests *interactionrequests.Module // api/v1/interaction_requests
+	invites             *invites.Module             // api/v1/invites
 	lists               *lists.Module               // api/v1/lists
 	markers             *markers.Module             // api/v1/markers
 	media               *media.Module               // api/v1/media, api/v2/media
@@ -102,7 +106,7 @@ type Client struct {
 	search              *search.Module              // api/v1/search, api/v2/search
 	statuses            *statuses.Module            // api/v1/statuses
 	streaming           *streaming.Module           // api/v1/streaming
-	suggestions         *suggestions.Module         // api/v2/suggestions
+	suggestions         *suggestions.Module         // api/v1/suggestions, api/v2/suggestions
 	tags                *tags.Module                // api/v1/tags
 	timelines           *timelines.Module           // api/v1/timelines
 	tokens              *tokens.Module              // api/v1/tokens
@@ -135,6 +139,7 @@ func (c *Client) Route(r *router.Router, m ...gin.HandlerFunc) {
 	c.bookmarks.Route(h)
 	c.conversations.Route(h)
 	c.customEmojis.Route(h)
+	c.domainBlocks.Route(h)
 	c.exports.Route(h)
 	c.favourites.Route(h)
 	c.featuredTags.Route(h)
@@ -146,6 +151,7 @@ func (c *Client) Route(r *router.Router, m ...gin.HandlerFunc) {
 	c.instance.Route(h)
 	c.interactionPolicies.Route(h)
 	c.interactionRequests.Route(h)
+	c.invites.Route(h)
 	c.lists.Route(h)
 	c.markers.Route(h)
 	c.media.Route(h)
@@ -180,6 +186,7 @@ func NewClient(state *state.State, p *processing.Processor) *Client {
 		bookmarks:           bookmarks.New(p),
 		conversations:       conversations.New(p),
 		customEmojis:        customemojis.New(p),
+		domainBlocks:        domainblocks.New(p),
 		exports:             exports.New(p),
 		favourites:          favourites.New(p),
 		featuredTags:        featuredtags.New(p),
@@ -191,6 +198,7 @@ func NewClient(state *state.State, p *processing.Processor) *Client {
 		instance:            instance.New(p),
 		interactionPolicies: interactionpolicies.New(p),
 		interactionRequests: interactionrequests.New(p),
+		invites:             invites.New(p),
 		lists:               lists.New(p),
 		markers:             markers.New(p),
 		media:               media.New(p),
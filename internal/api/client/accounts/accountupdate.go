@@ -55,6 +55,11 @@
 //		description: Account should be made discoverable and shown in the profile directory (if enabled).
 //		type: boolean
 //	-
+//		name: indexable
+//		in: formData
+//		description: Public statuses of this account may be included in full-text search results.
+//		type: boolean
+//	-
 //		name: bot
 //		in: formData
 //		description: Account is flagged as a bot.
@@ -161,6 +166,26 @@
 //			"gallery": gallery layout with media only.
 //		type: string
 //	-
+//		name: email_notifications
+//		in: formData
+//		description: |-
+//			Whether and how often to email notifications to the account's email address.
+//			"none": default, don't email notifications.
+//			"immediate": email each notification as it arrives.
+//			"daily": email a digest of notifications once a day.
+//			"weekly": email a digest of notifications once a week.
+//		type: string
+//	-
+//		name: email_notification_types[]
+//		in: formData
+//		description: |-
+//			Types of notifications to email, eg "mention", "follow_request".
+//			If email notifications are enabled without any types
+//			selected, mentions and follow requests will be emailed.
+//		type: array
+//		items:
+//			type: string
+//	-
 //		name: fields_attributes[0][name]
 //		in: formData
 //		description: Name of 1st profile field to be added to this account's profile.
@@ -342,6 +367,7 @@ func parseUpdateAccountForm(c *gin.Context) (*apimodel.UpdateCredentialsRequest,
 
 	if form == nil ||
 		(form.Discoverable == nil &&
+			form.Indexable == nil &&
 			form.Bot == nil &&
 			form.DisplayName == nil &&
 			form.Note == nil &&
@@ -360,7 +386,9 @@ func parseUpdateAccountForm(c *gin.Context) (*apimodel.UpdateCredentialsRequest,
 			form.EnableRSS == nil &&
 			form.HideCollections == nil &&
 			form.WebVisibility == nil &&
-			form.WebLayout == nil) {
+			form.WebLayout == nil &&
+			form.EmailNotifications == nil &&
+			form.EmailNotificationTypes == nil) {
 		return nil, errors.New("empty form submitted")
 	}
 
@@ -369,16 +369,18 @@ func (suite *AccountSearchTestSuite) TestSearchAFollowing() {
 		suite.FailNow(err.Error())
 	}
 
-	if l := len(accounts); l != 6 {
-		suite.FailNow("", "expected length %d got %d", 6, l)
+	// Only admin has a word starting
+	// with "a" in username / display name.
+	if l := len(accounts); l != 1 {
+		suite.FailNow("", "expected length %d got %d", 1, l)
 	}
 
-	usernames := make([]string, 0, 6)
+	usernames := make([]string, 0, 1)
 	for _, account := range accounts {
 		usernames = append(usernames, account.Username)
 	}
 
-	suite.EqualValues([]string{"her_fuckin_maj", "media_mogul", "foss_satan", "1happyturtle", "the_mighty_zork", "admin"}, usernames)
+	suite.EqualValues([]string{"admin"}, usernames)
 }
 
 func (suite *AccountSearchTestSuite) TestSearchANotFollowing() {
@@ -421,7 +423,10 @@ func (suite *AccountSearchTestSuite) TestSearchANotFollowing() {
 		usernames = append(usernames, account.Username)
 	}
 
-	suite.EqualValues([]string{"1happyturtle", "admin"}, usernames)
+	// Admin matches on username, which
+	// ranks higher than turtle's match
+	// on "about" in their bio.
+	suite.EqualValues([]string{"admin", "1happyturtle"}, usernames)
 }
 
 func TestAccountSearchTestSuite(t *testing.T) {
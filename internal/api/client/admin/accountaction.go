@@ -52,7 +52,7 @@
 //	-
 //		name: type
 //		in: formData
-//		description: Type of action to be taken, currently only supports `suspend`.
+//		description: Type of action to be taken, one of `suspend`, `unsuspend`, `silence`, `unsilence`, `disable`, `reenable`, `sensitive`, `unsensitive`. `disable` and `reenable` only apply to local accounts; `sensitive` only applies to remote accounts.
 //		type: string
 //		required: true
 //	-
@@ -60,6 +60,11 @@
 //		in: formData
 //		description: Optional text describing why this action was taken.
 //		type: string
+//	-
+//		name: content_warning
+//		in: formData
+//		description: Optional content warning to prepend to all incoming statuses from the account. Only used for `sensitive` action.
+//		type: string
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -79,6 +79,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "confirmed": true,
     "approved": true,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -88,6 +89,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
       "display_name": "happy little turtle :3",
       "locked": true,
       "discoverable": false,
+      "indexable": false,
       "bot": false,
       "created_at": "2022-06-04T13:12:00.000Z",
       "note": "<p>i post about things that concern me</p>",
@@ -139,6 +141,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "confirmed": true,
     "approved": true,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -148,6 +151,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
       "display_name": "",
       "locked": false,
       "discoverable": true,
+      "indexable": false,
       "bot": false,
       "created_at": "2022-05-17T13:10:59.000Z",
       "note": "",
@@ -195,6 +199,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "confirmed": false,
     "approved": false,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -204,6 +209,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
       "display_name": "",
       "locked": false,
       "discoverable": true,
+      "indexable": false,
       "bot": true,
       "created_at": "2020-05-17T13:10:59.000Z",
       "note": "",
@@ -242,6 +248,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "confirmed": true,
     "approved": true,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -251,6 +258,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
       "display_name": "",
       "locked": false,
       "discoverable": false,
+      "indexable": false,
       "bot": false,
       "created_at": "2025-03-15T11:08:00.000Z",
       "note": "<p>I'm a test account that posts a shitload of media and I have my account rendered in \"gallery\" mode</p>",
@@ -305,6 +313,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "confirmed": true,
     "approved": true,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -314,6 +323,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
       "display_name": "original zork (he/they)",
       "locked": false,
       "discoverable": true,
+      "indexable": false,
       "bot": false,
       "created_at": "2022-05-20T11:09:18.000Z",
       "note": "<p>hey yo this is my profile!</p>",
@@ -357,6 +367,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "confirmed": false,
     "approved": false,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -366,6 +377,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
       "display_name": "",
       "locked": false,
       "discoverable": false,
+      "indexable": false,
       "bot": false,
       "created_at": "2022-06-04T13:12:00.000Z",
       "note": "",
@@ -405,6 +417,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "confirmed": false,
     "approved": false,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -414,6 +427,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
       "display_name": "some user",
       "locked": true,
       "discoverable": true,
+      "indexable": false,
       "bot": false,
       "created_at": "2020-08-10T12:13:28.000Z",
       "note": "i'm a real son of a gun",
@@ -452,6 +466,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "confirmed": false,
     "approved": false,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -461,6 +476,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
       "display_name": "big gerald",
       "locked": false,
       "discoverable": true,
+      "indexable": false,
       "bot": false,
       "created_at": "2021-09-26T10:52:36.000Z",
       "note": "i post about like, i dunno, stuff, or whatever!!!!",
@@ -499,6 +515,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "confirmed": false,
     "approved": false,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -508,6 +525,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
       "display_name": "lizzzieeeeeeeeeeee",
       "locked": true,
       "discoverable": true,
+      "indexable": false,
       "bot": false,
       "created_at": "2020-08-10T12:13:28.000Z",
       "note": "if i die blame charles don't let that fuck become king",
@@ -547,6 +565,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "confirmed": false,
     "approved": false,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -556,6 +575,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
       "display_name": "",
       "locked": false,
       "discoverable": false,
+      "indexable": false,
       "bot": false,
       "created_at": "2020-08-10T12:13:28.000Z",
       "note": "",
@@ -633,6 +653,7 @@ func (suite *AccountsGetTestSuite) TestAccountsMinID() {
     "confirmed": true,
     "approved": true,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -642,6 +663,7 @@ func (suite *AccountsGetTestSuite) TestAccountsMinID() {
       "display_name": "",
       "locked": false,
       "discoverable": false,
+      "indexable": false,
       "bot": false,
       "created_at": "2025-03-15T11:08:00.000Z",
       "note": "<p>I'm a test account that posts a shitload of media and I have my account rendered in \"gallery\" mode</p>",
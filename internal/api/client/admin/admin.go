@@ -67,6 +67,30 @@
 	EmailTestPath                            = EmailPath + "/test"
 	InstanceRulesPath                        = BasePath + "/instance/rules"
 	InstanceRulesPathWithID                  = InstanceRulesPath + "/:" + apiutil.IDKey
+	DomainLimitsPath                         = BasePath + "/domain_limits"
+	AnnouncementsPath                        = BasePath + "/announcements"
+	AnnouncementsPathWithID                  = AnnouncementsPath + "/:" + apiutil.IDKey
+	DomainLimitsPathWithID                   = DomainLimitsPath + "/:" + apiutil.IDKey
+	InvitesPath                              = BasePath + "/invites"
+	InvitesPathWithID                        = InvitesPath + "/:" + apiutil.IDKey
+	IPBlocksPath                             = BasePath + "/ip_blocks"
+	IPBlocksPathWithID                       = IPBlocksPath + "/:" + apiutil.IDKey
+	EmailDomainBlocksPath                    = BasePath + "/email_domain_blocks"
+	EmailDomainBlocksPathWithID              = EmailDomainBlocksPath + "/:" + apiutil.IDKey
+	RelaysPath                               = BasePath + "/relays"
+	RelaysPathWithID                         = RelaysPath + "/:" + apiutil.IDKey
+	StaffPicksPath                           = BasePath + "/staff_picks"
+	StaffPicksPathWithID                     = StaffPicksPath + "/:" + apiutil.IDKey
+	TrendsPath                               = BasePath + "/trends"
+	TrendsTagsPath                           = TrendsPath + "/tags"
+	TrendsTagApprovePath                     = TrendsTagsPath + "/:" + apiutil.IDKey + "/approve"
+	TrendsTagRejectPath                      = TrendsTagsPath + "/:" + apiutil.IDKey + "/reject"
+	TrendsStatusesPath                       = TrendsPath + "/statuses"
+	TrendsStatusApprovePath                  = TrendsStatusesPath + "/:" + apiutil.IDKey + "/approve"
+	TrendsStatusRejectPath                   = TrendsStatusesPath + "/:" + apiutil.IDKey + "/reject"
+	TrendsLinksPath                          = TrendsPath + "/links"
+	TrendsLinkApprovePath                    = TrendsLinksPath + "/:" + apiutil.IDKey + "/approve"
+	TrendsLinkRejectPath                     = TrendsLinksPath + "/:" + apiutil.IDKey + "/reject"
 	DebugPath                                = BasePath + "/debug"
 	DebugAPUrlPath                           = DebugPath + "/apurl"
 	DebugClearCachesPath                     = DebugPath + "/caches/clear"
@@ -174,6 +198,59 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodPatch, InstanceRulesPathWithID, m.RulePATCHHandler)
 	attachHandler(http.MethodDelete, InstanceRulesPathWithID, m.RuleDELETEHandler)
 
+	// announcements stuff
+	attachHandler(http.MethodGet, AnnouncementsPath, m.AnnouncementsGETHandler)
+	attachHandler(http.MethodPost, AnnouncementsPath, m.AnnouncementPOSTHandler)
+	attachHandler(http.MethodGet, AnnouncementsPathWithID, m.AnnouncementGETHandler)
+	attachHandler(http.MethodPatch, AnnouncementsPathWithID, m.AnnouncementPATCHHandler)
+	attachHandler(http.MethodDelete, AnnouncementsPathWithID, m.AnnouncementDELETEHandler)
+
+	// domain limits stuff
+	attachHandler(http.MethodGet, DomainLimitsPath, m.DomainLimitsGETHandler)
+	attachHandler(http.MethodPost, DomainLimitsPath, m.DomainLimitPOSTHandler)
+	attachHandler(http.MethodGet, DomainLimitsPathWithID, m.DomainLimitGETHandler)
+	attachHandler(http.MethodPatch, DomainLimitsPathWithID, m.DomainLimitPATCHHandler)
+	attachHandler(http.MethodDelete, DomainLimitsPathWithID, m.DomainLimitDELETEHandler)
+
+	// invites stuff
+	attachHandler(http.MethodGet, InvitesPath, m.InvitesGETHandler)
+	attachHandler(http.MethodDelete, InvitesPathWithID, m.InviteDELETEHandler)
+
+	// ip blocks stuff
+	attachHandler(http.MethodGet, IPBlocksPath, m.IPBlocksGETHandler)
+	attachHandler(http.MethodPost, IPBlocksPath, m.IPBlockPOSTHandler)
+	attachHandler(http.MethodGet, IPBlocksPathWithID, m.IPBlockGETHandler)
+	attachHandler(http.MethodPut, IPBlocksPathWithID, m.IPBlockPUTHandler)
+	attachHandler(http.MethodDelete, IPBlocksPathWithID, m.IPBlockDELETEHandler)
+
+	// email domain blocks stuff
+	attachHandler(http.MethodGet, EmailDomainBlocksPath, m.EmailDomainBlocksGETHandler)
+	attachHandler(http.MethodPost, EmailDomainBlocksPath, m.EmailDomainBlockPOSTHandler)
+	attachHandler(http.MethodGet, EmailDomainBlocksPathWithID, m.EmailDomainBlockGETHandler)
+	attachHandler(http.MethodDelete, EmailDomainBlocksPathWithID, m.EmailDomainBlockDELETEHandler)
+
+	// relays stuff
+	attachHandler(http.MethodGet, RelaysPath, m.RelaysGETHandler)
+	attachHandler(http.MethodPost, RelaysPath, m.RelayPOSTHandler)
+	attachHandler(http.MethodGet, RelaysPathWithID, m.RelayGETHandler)
+	attachHandler(http.MethodDelete, RelaysPathWithID, m.RelayDELETEHandler)
+
+	// staff picks stuff
+	attachHandler(http.MethodGet, StaffPicksPath, m.StaffPicksGETHandler)
+	attachHandler(http.MethodPost, StaffPicksPathWithID, m.StaffPickPOSTHandler)
+	attachHandler(http.MethodDelete, StaffPicksPathWithID, m.StaffPickDELETEHandler)
+
+	// trends stuff
+	attachHandler(http.MethodGet, TrendsTagsPath, m.TrendTagsGETHandler)
+	attachHandler(http.MethodPost, TrendsTagApprovePath, m.TrendTagApprovePOSTHandler)
+	attachHandler(http.MethodPost, TrendsTagRejectPath, m.TrendTagRejectPOSTHandler)
+	attachHandler(http.MethodGet, TrendsStatusesPath, m.TrendStatusesGETHandler)
+	attachHandler(http.MethodPost, TrendsStatusApprovePath, m.TrendStatusApprovePOSTHandler)
+	attachHandler(http.MethodPost, TrendsStatusRejectPath, m.TrendStatusRejectPOSTHandler)
+	attachHandler(http.MethodGet, TrendsLinksPath, m.TrendLinksGETHandler)
+	attachHandler(http.MethodPost, TrendsLinkApprovePath, m.TrendLinkApprovePOSTHandler)
+	attachHandler(http.MethodPost, TrendsLinkRejectPath, m.TrendLinkRejectPOSTHandler)
+
 	// debug stuff
 	if debug.DEBUG {
 		attachHandler(http.MethodGet, DebugAPUrlPath, m.DebugAPUrlHandler)
@@ -0,0 +1,182 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	apiutil "code.superseriousbusiness.org/gotosocial/internal/api/util"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"github.com/gin-gonic/gin"
+)
+
+// AnnouncementPOSTHandler swagger:operation POST /api/v1/admin/announcements announcementCreate
+//
+// Create a new announcement.
+//
+// Unless scheduled for later, the announcement is published immediately, and pushed to users connected to the streaming API.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	consumes:
+//	- multipart/form-data
+//	- application/json
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: text
+//		in: formData
+//		description: Markdown text of the announcement. Custom emojis may be used.
+//		type: string
+//	-
+//		name: starts_at
+//		in: formData
+//		description: Start time of the event being announced (ISO 8601 Datetime). An empty string clears the start time when updating.
+//		type: string
+//	-
+//		name: ends_at
+//		in: formData
+//		description: Time after which the announcement is no longer shown (ISO 8601 Datetime). An empty string clears the end time when updating.
+//		type: string
+//	-
+//		name: all_day
+//		in: formData
+//		description: Show starts_at and ends_at as dates rather than times.
+//		type: boolean
+//	-
+//		name: scheduled_at
+//		in: formData
+//		description: Time at which to publish the announcement (ISO 8601 Datetime). If not set, or in the past, the announcement is published immediately. Cannot be changed once the announcement is published.
+//		type: string
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin:write
+//
+//	responses:
+//		'200':
+//			description: The newly-created announcement.
+//			schema:
+//				"$ref": "#/definitions/announcement"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) AnnouncementPOSTHandler(c *gin.Context) {
+	authed, errWithCode := apiutil.TokenAuth(c,
+		true, true, true, true,
+		apiutil.ScopeAdminWrite,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	if !*authed.User.Admin {
+		err := fmt.Errorf("user %s not an admin", authed.User.ID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if authed.Account.IsMoving() {
+		apiutil.ForbiddenAfterMove(c)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form, err := parseAnnouncementForm(c)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if form.Text == nil || *form.Text == "" {
+		const text = "text must be set"
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(errors.New(text), text), m.processor.InstanceGetV1)
+		return
+	}
+
+	announcement, errWithCode := m.processor.Announcements().Create(
+		c.Request.Context(),
+		authed.Account,
+		form,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, announcement)
+}
+
+// parseAnnouncementForm binds an announcement form from the
+// request, parsing any given times. Times given as empty
+// strings are parsed as zero, to clear them on update.
+func parseAnnouncementForm(c *gin.Context) (*apimodel.AnnouncementRequest, error) {
+	form := new(apimodel.AnnouncementRequest)
+	if err := c.ShouldBind(form); err != nil {
+		return nil, err
+	}
+
+	for _, field := range []struct {
+		raw    *string
+		parsed **time.Time
+		name   string
+	}{
+		{form.StartsAtRaw, &form.StartsAt, "starts_at"},
+		{form.EndsAtRaw, &form.EndsAt, "ends_at"},
+		{form.ScheduledAtRaw, &form.ScheduledAt, "scheduled_at"},
+	} {
+		if field.raw == nil {
+			// Not set.
+			continue
+		}
+
+		var t time.Time
+		if *field.raw != "" {
+			var err error
+			t, err = apiutil.ParseTime(*field.raw, field.name)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		*field.parsed = &t
+	}
+
+	return form, nil
+}
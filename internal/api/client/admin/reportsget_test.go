@@ -166,6 +166,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "confirmed": false,
       "approved": false,
       "disabled": false,
+      "sensitized": false,
       "silenced": false,
       "suspended": false,
       "account": {
@@ -175,6 +176,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
         "display_name": "big gerald",
         "locked": false,
         "discoverable": true,
+        "indexable": false,
         "bot": false,
         "created_at": "2021-09-26T10:52:36.000Z",
         "note": "i post about like, i dunno, stuff, or whatever!!!!",
@@ -213,6 +215,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "confirmed": true,
       "approved": true,
       "disabled": false,
+      "sensitized": false,
       "silenced": false,
       "suspended": false,
       "account": {
@@ -222,6 +225,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
         "display_name": "happy little turtle :3",
         "locked": true,
         "discoverable": false,
+        "indexable": false,
         "bot": false,
         "created_at": "2022-06-04T13:12:00.000Z",
         "note": "\u003cp\u003ei post about things that concern me\u003c/p\u003e",
@@ -273,6 +277,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "confirmed": true,
       "approved": true,
       "disabled": false,
+      "sensitized": false,
       "silenced": false,
       "suspended": false,
       "account": {
@@ -282,6 +287,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
         "display_name": "",
         "locked": false,
         "discoverable": true,
+        "indexable": false,
         "bot": false,
         "created_at": "2022-05-17T13:10:59.000Z",
         "note": "",
@@ -329,6 +335,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "confirmed": true,
       "approved": true,
       "disabled": false,
+      "sensitized": false,
       "silenced": false,
       "suspended": false,
       "account": {
@@ -338,6 +345,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
         "display_name": "",
         "locked": false,
         "discoverable": true,
+        "indexable": false,
         "bot": false,
         "created_at": "2022-05-17T13:10:59.000Z",
         "note": "",
@@ -398,6 +406,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "confirmed": true,
       "approved": true,
       "disabled": false,
+      "sensitized": false,
       "silenced": false,
       "suspended": false,
       "account": {
@@ -407,6 +416,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
         "display_name": "happy little turtle :3",
         "locked": true,
         "discoverable": false,
+        "indexable": false,
         "bot": false,
         "created_at": "2022-06-04T13:12:00.000Z",
         "note": "\u003cp\u003ei post about things that concern me\u003c/p\u003e",
@@ -458,6 +468,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "confirmed": false,
       "approved": false,
       "disabled": false,
+      "sensitized": false,
       "silenced": false,
       "suspended": false,
       "account": {
@@ -467,6 +478,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
         "display_name": "big gerald",
         "locked": false,
         "discoverable": true,
+        "indexable": false,
         "bot": false,
         "created_at": "2021-09-26T10:52:36.000Z",
         "note": "i post about like, i dunno, stuff, or whatever!!!!",
@@ -517,6 +529,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
           "display_name": "big gerald",
           "locked": false,
           "discoverable": true,
+          "indexable": false,
           "bot": false,
           "created_at": "2021-09-26T10:52:36.000Z",
           "note": "i post about like, i dunno, stuff, or whatever!!!!",
@@ -606,6 +619,22 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
               "me"
             ],
             "with_approval": []
+          },
+          "can_quote": {
+            "automatic_approval": [
+              "author",
+              "me"
+            ],
+            "manual_approval": [
+              "public"
+            ],
+            "always": [
+              "author",
+              "me"
+            ],
+            "with_approval": [
+              "public"
+            ]
           }
         }
       }
@@ -670,6 +699,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
       "confirmed": true,
       "approved": true,
       "disabled": false,
+      "sensitized": false,
       "silenced": false,
       "suspended": false,
       "account": {
@@ -679,6 +709,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
         "display_name": "happy little turtle :3",
         "locked": true,
         "discoverable": false,
+        "indexable": false,
         "bot": false,
         "created_at": "2022-06-04T13:12:00.000Z",
         "note": "\u003cp\u003ei post about things that concern me\u003c/p\u003e",
@@ -730,6 +761,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
       "confirmed": false,
       "approved": false,
       "disabled": false,
+      "sensitized": false,
       "silenced": false,
       "suspended": false,
       "account": {
@@ -739,6 +771,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
         "display_name": "big gerald",
         "locked": false,
         "discoverable": true,
+        "indexable": false,
         "bot": false,
         "created_at": "2021-09-26T10:52:36.000Z",
         "note": "i post about like, i dunno, stuff, or whatever!!!!",
@@ -789,6 +822,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
           "display_name": "big gerald",
           "locked": false,
           "discoverable": true,
+          "indexable": false,
           "bot": false,
           "created_at": "2021-09-26T10:52:36.000Z",
           "note": "i post about like, i dunno, stuff, or whatever!!!!",
@@ -878,6 +912,22 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
               "me"
             ],
             "with_approval": []
+          },
+          "can_quote": {
+            "automatic_approval": [
+              "author",
+              "me"
+            ],
+            "manual_approval": [
+              "public"
+            ],
+            "always": [
+              "author",
+              "me"
+            ],
+            "with_approval": [
+              "public"
+            ]
           }
         }
       }
@@ -942,6 +992,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
       "confirmed": true,
       "approved": true,
       "disabled": false,
+      "sensitized": false,
       "silenced": false,
       "suspended": false,
       "account": {
@@ -951,6 +1002,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
         "display_name": "happy little turtle :3",
         "locked": true,
         "discoverable": false,
+        "indexable": false,
         "bot": false,
         "created_at": "2022-06-04T13:12:00.000Z",
         "note": "\u003cp\u003ei post about things that concern me\u003c/p\u003e",
@@ -1002,6 +1054,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
       "confirmed": false,
       "approved": false,
       "disabled": false,
+      "sensitized": false,
       "silenced": false,
       "suspended": false,
       "account": {
@@ -1011,6 +1064,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
         "display_name": "big gerald",
         "locked": false,
         "discoverable": true,
+        "indexable": false,
         "bot": false,
         "created_at": "2021-09-26T10:52:36.000Z",
         "note": "i post about like, i dunno, stuff, or whatever!!!!",
@@ -1061,6 +1115,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
           "display_name": "big gerald",
           "locked": false,
           "discoverable": true,
+          "indexable": false,
           "bot": false,
           "created_at": "2021-09-26T10:52:36.000Z",
           "note": "i post about like, i dunno, stuff, or whatever!!!!",
@@ -1150,6 +1205,22 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
               "me"
             ],
             "with_approval": []
+          },
+          "can_quote": {
+            "automatic_approval": [
+              "author",
+              "me"
+            ],
+            "manual_approval": [
+              "public"
+            ],
+            "always": [
+              "author",
+              "me"
+            ],
+            "with_approval": [
+              "public"
+            ]
           }
         }
       }
@@ -27,9 +27,7 @@
 
 // AnnouncementsGETHandler swagger:operation GET /api/v1/announcements announcementsGet
 //
-// Get an array of currently active announcements.
-//
-// THIS ENDPOINT IS CURRENTLY NOT FULLY IMPLEMENTED: it will always return an empty array.
+// Get an array of currently active announcements, ie., those which are published and have not yet ended.
 //
 //	---
 //	tags:
@@ -38,16 +36,24 @@
 //	produces:
 //	- application/json
 //
+//	parameters:
+//	-
+//		name: with_dismissed
+//		type: boolean
+//		description: Include announcements that have already been dismissed by the requesting account.
+//		default: false
+//		in: query
+//
 //	security:
 //	- OAuth2 Bearer: []
 //
 //	responses:
 //		'200':
+//			description: Array of active announcements.
 //			schema:
 //				type: array
 //				items:
-//					type: object
-//				maxItems: 0
+//					"$ref": "#/definitions/announcement"
 //		'400':
 //			description: bad request
 //		'401':
@@ -57,7 +63,7 @@
 //		'500':
 //			description: internal server error
 func (m *Module) AnnouncementsGETHandler(c *gin.Context) {
-	_, errWithCode := apiutil.TokenAuth(c,
+	authed, errWithCode := apiutil.TokenAuth(c,
 		true, true, true, true,
 	)
 	if errWithCode != nil {
@@ -70,5 +76,24 @@ func (m *Module) AnnouncementsGETHandler(c *gin.Context) {
 		return
 	}
 
-	apiutil.JSON(c, http.StatusOK, apiutil.EmptyJSONArray)
+	withDismissed, errWithCode := apiutil.ParseAnnouncementWithDismissed(
+		c.Query(apiutil.AnnouncementWithDismissedKey),
+		false,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	announcements, errWithCode := m.processor.Announcements().GetActive(
+		c.Request.Context(),
+		authed.Account,
+		withDismissed,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, announcements)
 }
@@ -25,13 +25,15 @@
 )
 
 const (
-	BasePath      = "/v1/exports"
-	StatsPath     = BasePath + "/stats"
-	FollowingPath = BasePath + "/following.csv"
-	FollowersPath = BasePath + "/followers.csv"
-	ListsPath     = BasePath + "/lists.csv"
-	BlocksPath    = BasePath + "/blocks.csv"
-	MutesPath     = BasePath + "/mutes.csv"
+	BasePath        = "/v1/exports"
+	StatsPath       = BasePath + "/stats"
+	FollowingPath   = BasePath + "/following.csv"
+	FollowersPath   = BasePath + "/followers.csv"
+	ListsPath       = BasePath + "/lists.csv"
+	BlocksPath      = BasePath + "/blocks.csv"
+	MutesPath       = BasePath + "/mutes.csv"
+	ArchivePath     = BasePath + "/archive"
+	ArchiveFilePath = BasePath + "/archive.zip"
 )
 
 type Module struct {
@@ -51,4 +53,7 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, ListsPath, m.ExportListsGETHandler)
 	attachHandler(http.MethodGet, BlocksPath, m.ExportBlocksGETHandler)
 	attachHandler(http.MethodGet, MutesPath, m.ExportMutesGETHandler)
+	attachHandler(http.MethodGet, ArchivePath, m.ExportArchiveGETHandler)
+	attachHandler(http.MethodPost, ArchivePath, m.ExportArchivePOSTHandler)
+	attachHandler(http.MethodGet, ArchiveFilePath, m.ExportArchiveFileGETHandler)
 }
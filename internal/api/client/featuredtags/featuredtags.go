@@ -25,7 +25,10 @@
 )
 
 const (
-	BasePath = "/v1/featured_tags"
+	IDKey           = "id"
+	BasePath        = "/v1/featured_tags"
+	BasePathWithID  = BasePath + "/:" + IDKey
+	SuggestionsPath = BasePath + "/suggestions"
 )
 
 type Module struct {
@@ -40,4 +43,7 @@ func New(processor *processing.Processor) *Module {
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodGet, BasePath, m.FeaturedTagsGETHandler)
+	attachHandler(http.MethodPost, BasePath, m.FeaturedTagCreatePOSTHandler)
+	attachHandler(http.MethodDelete, BasePathWithID, m.FeaturedTagDELETEHandler)
+	attachHandler(http.MethodGet, SuggestionsPath, m.FeaturedTagSuggestionsGETHandler)
 }
@@ -84,6 +84,7 @@ func (suite *GetOutgoingTestSuite) TestGetOutgoing() {
     "display_name": "some user",
     "locked": true,
     "discoverable": true,
+    "indexable": false,
     "bot": false,
     "created_at": "2020-08-10T12:13:28.000Z",
     "note": "i'm a real son of a gun",
@@ -33,13 +33,18 @@
 )
 
 const (
-	BasePath = "/v1/import"
+	BasePath       = "/v1/import"
+	BasePathWithID = BasePath + "/:" + apiutil.IDKey
 )
 
 var types = []string{
 	"following",
 	"blocks",
 	"mutes",
+	"bookmarks",
+	"lists",
+	"domain_blocks",
+	"archive",
 }
 
 var modes = []string{
@@ -59,6 +64,8 @@ func New(processor *processing.Processor) *Module {
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
 	attachHandler(http.MethodPost, BasePath, m.ImportPOSTHandler)
+	attachHandler(http.MethodGet, BasePath, m.ImportsGETHandler)
+	attachHandler(http.MethodGet, BasePathWithID, m.ImportGETHandler)
 }
 
 // ImportPOSTHandler swagger:operation POST /api/v1/import importData
@@ -67,9 +74,16 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 //
 // This can be used to migrate data from a Mastodon-compatible CSV file to a GoToSocial account.
 //
+// With type `archive`, a zipped account archive (as exported from GoToSocial or Mastodon) can be
+// uploaded instead, to re-create the archived profile and statuses as backdated statuses. This
+// requires backdating statuses to be allowed on this instance.
+//
 // Uploaded data will be processed asynchronously, and not all entries may be processed depending
 // on domain blocks, user-level blocks, network availability of referenced accounts and statuses, etc.
 //
+// For CSV data, the created import is returned, which can be used to follow processing progress
+// and see which entries could not be imported via `GET /api/v1/import/{id}`.
+//
 //	---
 //	tags:
 //	- import-export
@@ -84,7 +98,7 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 //	-
 //		name: data
 //		in: formData
-//		description: The CSV data file to upload.
+//		description: The CSV data file (or zip archive) to upload.
 //		type: file
 //		required: true
 //	-
@@ -96,6 +110,10 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 //			- `following` - accounts to follow.
 //			- `blocks` - accounts to block.
 //			- `mutes` - accounts to mute.
+//			- `bookmarks` - statuses to bookmark.
+//			- `lists` - lists and the (followed) accounts in them.
+//			- `domain_blocks` - domains to block.
+//			- `archive` - account archive to re-create statuses and profile from.
 //
 //		type: string
 //		required: true
@@ -116,11 +134,16 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 //
 //	responses:
 //		'202':
-//			description: Upload accepted.
+//			description: >-
+//				Upload accepted. For CSV data, the body contains the created import.
+//			schema:
+//				"$ref": "#/definitions/import"
 //		'400':
 //			description: bad request
 //		'401':
 //			description: unauthorized
+//		'403':
+//			description: forbidden
 //		'406':
 //			description: not acceptable
 //		'500':
@@ -185,7 +208,22 @@ func (m *Module) ImportPOSTHandler(c *gin.Context) {
 	overwrite := form.Mode == "overwrite"
 
 	// Trigger the import.
-	errWithCode = m.processor.Account().ImportData(
+	if form.Type == "archive" {
+		errWithCode := m.processor.Archive().Import(
+			c.Request.Context(),
+			authed.Account,
+			form.Data,
+		)
+		if errWithCode != nil {
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		apiutil.JSON(c, http.StatusAccepted, gin.H{"status": "accepted"})
+		return
+	}
+
+	imp, errWithCode := m.processor.Account().ImportData(
 		c.Request.Context(),
 		authed.Account,
 		form.Data,
@@ -197,5 +235,5 @@ func (m *Module) ImportPOSTHandler(c *gin.Context) {
 		return
 	}
 
-	apiutil.JSON(c, http.StatusAccepted, gin.H{"status": "accepted"})
+	apiutil.JSON(c, http.StatusAccepted, imp)
 }
@@ -19,6 +19,7 @@
 
 import (
 	"bytes"
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -27,6 +28,8 @@
 	"github.com/stretchr/testify/suite"
 
 	importdata "code.superseriousbusiness.org/gotosocial/internal/api/client/import"
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	apiutil "code.superseriousbusiness.org/gotosocial/internal/api/util"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
 	"code.superseriousbusiness.org/gotosocial/internal/oauth"
 	"code.superseriousbusiness.org/gotosocial/internal/state"
@@ -43,6 +46,7 @@ type ImportTestSuite struct {
 	testApplications map[string]*gtsmodel.Application
 	testUsers        map[string]*gtsmodel.User
 	testAccounts     map[string]*gtsmodel.Account
+	testLists        map[string]*gtsmodel.List
 
 	// module being tested
 	importModule *importdata.Module
@@ -53,6 +57,7 @@ func (suite *ImportTestSuite) SetupSuite() {
 	suite.testApplications = testrig.NewTestApplications()
 	suite.testUsers = testrig.NewTestUsers()
 	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testLists = testrig.NewTestLists()
 }
 
 func (suite *ImportTestSuite) SetupTest() {
@@ -94,7 +99,7 @@ func (suite *ImportTestSuite) TriggerHandler(
 	importData string,
 	importType string,
 	importMode string,
-) {
+) *apimodel.Import {
 	// Set up request.
 	recorder := httptest.NewRecorder()
 	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
@@ -126,13 +131,59 @@ func (suite *ImportTestSuite) TriggerHandler(
 	// Trigger handler.
 	suite.importModule.ImportPOSTHandler(ctx)
 
+	resp, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		panic(err)
+	}
+
 	if code := recorder.Code; code != http.StatusAccepted {
-		b, err := io.ReadAll(recorder.Body)
-		if err != nil {
-			panic(err)
+		suite.FailNow("", "expected 202, got %d: %s", code, string(resp))
+	}
+
+	imp := new(apimodel.Import)
+	if err := json.Unmarshal(resp, imp); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return imp
+}
+
+// WaitForImport waits for the import with
+// the given ID to be finished, returning it.
+func (suite *ImportTestSuite) WaitForImport(id string) *apimodel.Import {
+	var imp *apimodel.Import
+
+	if !testrig.WaitFor(func() bool {
+		recorder := httptest.NewRecorder()
+		ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+
+		ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+		ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+		ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+		ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+
+		target := "http://localhost:8080/api/v1/import/" + id
+		ctx.Request = httptest.NewRequest(http.MethodGet, target, nil)
+		ctx.Request.Header.Set("Accept", "application/json")
+		ctx.AddParam(apiutil.IDKey, id)
+
+		suite.importModule.ImportGETHandler(ctx)
+
+		if code := recorder.Code; code != http.StatusOK {
+			suite.FailNow("", "expected 200, got %d: %s", code, recorder.Body.String())
+		}
+
+		imp = new(apimodel.Import)
+		if err := json.Unmarshal(recorder.Body.Bytes(), imp); err != nil {
+			suite.FailNow(err.Error())
 		}
-		suite.FailNow("", "expected 202, got %d: %s", code, string(b))
+
+		return imp.State == "finished"
+	}) {
+		suite.FailNow("timed out waiting for import to finish")
 	}
+
+	return imp
 }
 
 func (suite *ImportTestSuite) TearDownTest() {
@@ -284,7 +335,181 @@ func (suite *ImportTestSuite) TestImportMutes() {
 	}) {
 		suite.FailNow("timed out waiting for import to apply")
 	}
+}
+
+func (suite *ImportTestSuite) TestImportBookmarks() {
+	var (
+		ctx         = suite.T().Context()
+		testAccount = suite.testAccounts["local_account_1"]
+	)
+
+	// Have zork keep their bookmark of admin's
+	// status, bookmark a status of turtle's, and
+	// try to bookmark a status that doesn't exist.
+	data := `http://localhost:8080/users/admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R
+http://localhost:8080/users/1happyturtle/statuses/01F8MHC0H0A7XHTVH5F596ZKBM
+http://localhost:8080/users/1happyturtle/statuses/01J0000000000000000000000Z
+`
+
+	// Trigger the import handler.
+	imp := suite.TriggerHandler(data, "bookmarks", "overwrite")
+	suite.Equal("bookmarks", imp.Type)
+	suite.Equal("overwrite", imp.Mode)
+	suite.Equal(3, imp.TotalItems)
+
+	// Wait for import to finish.
+	imp = suite.WaitForImport(imp.ID)
+	suite.Equal(3, imp.ProcessedItems)
+	suite.Equal(1, imp.FailedItems)
+	suite.Equal([]apimodel.ImportFailure{{
+		Entry: "http://localhost:8080/users/1happyturtle/statuses/01J0000000000000000000000Z",
+		Error: "status could not be retrieved",
+	}}, imp.Failures)
+
+	// Only the bookmarks in the
+	// file should now be present.
+	bookmarks, err := suite.state.DB.GetStatusBookmarks(ctx, testAccount.ID, 0, "", "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	statusIDs := make([]string, 0, len(bookmarks))
+	for _, bookmark := range bookmarks {
+		statusIDs = append(statusIDs, bookmark.StatusID)
+	}
+
+	suite.ElementsMatch([]string{
+		"01F8MH75CBF9JFX4ZAD54N0W0R",
+		"01F8MHC0H0A7XHTVH5F596ZKBM",
+	}, statusIDs)
+}
+
+func (suite *ImportTestSuite) TestImportBookmarksRemote() {
+	var (
+		ctx         = suite.T().Context()
+		testAccount = suite.testAccounts["local_account_1"]
+		remoteURI   = "https://unknown-instance.com/users/brand_new_person/statuses/01FE4NTHKWW7THT67EF10EB839"
+	)
+
+	// Have zork bookmark a status we don't have
+	// stored yet, alongside one of turtle's that
+	// we do, in merge mode.
+	data := remoteURI + `
+http://localhost:8080/users/1happyturtle/statuses/01F8MHC0H0A7XHTVH5F596ZKBM
+`
+
+	// Trigger the import handler.
+	imp := suite.TriggerHandler(data, "bookmarks", "merge")
+	suite.Equal(2, imp.TotalItems)
+
+	// Wait for import to finish.
+	imp = suite.WaitForImport(imp.ID)
+	suite.Equal(2, imp.ProcessedItems)
+	suite.Zero(imp.FailedItems)
+
+	// The remote status should have been
+	// dereferenced, and bookmarked by zork.
+	status, err := suite.state.DB.GetStatusByURI(ctx, remoteURI)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	bookmark, err := suite.state.DB.GetStatusBookmark(ctx, testAccount.ID, status.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(status.AccountID, bookmark.TargetAccountID)
+}
+
+func (suite *ImportTestSuite) TestImportLists() {
+	var (
+		ctx         = suite.T().Context()
+		testAccount = suite.testAccounts["local_account_1"]
+		testList    = suite.testLists["local_account_1_list_1"]
+	)
+
+	// Have zork keep only admin in their existing
+	// list, add turtle to a new list, and try to add
+	// fossbro (who they don't follow) to it too.
+	data := `Cool Ass Posters From This Instance,admin@localhost:8080
+New List,1happyturtle@localhost:8080
+New List,foss_satan@fossbros-anonymous.io
+`
+
+	// Trigger the import handler.
+	imp := suite.TriggerHandler(data, "lists", "overwrite")
+
+	// Wait for import to finish.
+	imp = suite.WaitForImport(imp.ID)
+	suite.Equal(3, imp.ProcessedItems)
+	suite.Equal(1, imp.FailedItems)
+	suite.Equal([]apimodel.ImportFailure{{
+		Entry: "New List: foss_satan@fossbros-anonymous.io",
+		Error: "you are not following this account",
+	}}, imp.Failures)
+
+	// Existing list should now only contain admin.
+	accountIDs, err := suite.state.DB.GetAccountIDsInList(ctx, testList.ID, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal([]string{suite.testAccounts["admin_account"].ID}, accountIDs)
+
+	// New list should have been created with turtle.
+	lists, err := suite.state.DB.GetListsByAccountID(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	var newList *gtsmodel.List
+	for _, list := range lists {
+		if list.Title == "New List" {
+			newList = list
+		}
+	}
+
+	if newList == nil {
+		suite.FailNow("new list not created")
+	}
+
+	accountIDs, err = suite.state.DB.GetAccountIDsInList(ctx, newList.ID, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal([]string{suite.testAccounts["local_account_2"].ID}, accountIDs)
+}
+
+func (suite *ImportTestSuite) TestImportDomainBlocks() {
+	var (
+		ctx         = suite.T().Context()
+		testAccount = suite.testAccounts["local_account_1"]
+	)
 
+	// Have zork block fossbro's domain,
+	// and try to block our own domain.
+	data := `#domain
+fossbros-anonymous.io
+localhost:8080
+`
+
+	// Trigger the import handler.
+	imp := suite.TriggerHandler(data, "domain_blocks", "merge")
+
+	// Wait for import to finish.
+	imp = suite.WaitForImport(imp.ID)
+	suite.Equal(2, imp.TotalItems)
+	suite.Equal(2, imp.ProcessedItems)
+	suite.Equal(1, imp.FailedItems)
+	suite.Equal([]apimodel.ImportFailure{{
+		Entry: "localhost:8080",
+		Error: "Bad Request: domain localhost:8080 is not a valid domain",
+	}}, imp.Failures)
+
+	blocked, err := suite.state.DB.IsUserDomainBlocked(ctx, testAccount.ID, "fossbros-anonymous.io")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(blocked)
 }
 
 func TestImportTestSuite(t *testing.T) {
@@ -169,6 +169,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch1() {
     "display_name": "",
     "locked": false,
     "discoverable": true,
+    "indexable": false,
     "bot": false,
     "created_at": "2022-05-17T13:10:59.000Z",
     "note": "",
@@ -313,6 +314,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch2() {
     "display_name": "",
     "locked": false,
     "discoverable": true,
+    "indexable": false,
     "bot": false,
     "created_at": "2022-05-17T13:10:59.000Z",
     "note": "",
@@ -457,6 +459,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch3() {
     "display_name": "",
     "locked": false,
     "discoverable": true,
+    "indexable": false,
     "bot": false,
     "created_at": "2022-05-17T13:10:59.000Z",
     "note": "",
@@ -652,6 +655,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch6() {
     "display_name": "",
     "locked": false,
     "discoverable": true,
+    "indexable": false,
     "bot": false,
     "created_at": "2022-05-17T13:10:59.000Z",
     "note": "",
@@ -822,6 +826,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch8() {
     "display_name": "",
     "locked": false,
     "discoverable": true,
+    "indexable": false,
     "bot": false,
     "created_at": "2022-05-17T13:10:59.000Z",
     "note": "",
@@ -1003,6 +1008,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch9() {
     "display_name": "",
     "locked": false,
     "discoverable": true,
+    "indexable": false,
     "bot": false,
     "created_at": "2022-05-17T13:10:59.000Z",
     "note": "",
@@ -83,6 +83,13 @@
 //			description: The newly-created media attachment.
 //			schema:
 //				"$ref": "#/definitions/attachment"
+//		'202':
+//			description: >-
+//				The newly-created media attachment, which is still being transcoded.
+//				The attachment URL will be null until processing is complete.
+//				Only returned by the v2 endpoint when media transcoding is enabled.
+//			schema:
+//				"$ref": "#/definitions/attachment"
 //		'400':
 //			description: bad request
 //		'401':
@@ -92,7 +99,7 @@
 //		'500':
 //			description: internal server error
 func (m *Module) MediaCreatePOSTHandler(c *gin.Context) {
-	_, errWithCode := apiutil.ParseAPIVersion(
+	apiVersion, errWithCode := apiutil.ParseAPIVersion(
 		c.Param(apiutil.APIVersionKey),
 		apiutil.APIv1,
 		apiutil.APIv2,
@@ -132,7 +139,11 @@ func (m *Module) MediaCreatePOSTHandler(c *gin.Context) {
 		return
 	}
 
-	apiAttachment, errWithCode := m.processor.Media().Create(c.Request.Context(), authed.Account, form)
+	// Only the v2 endpoint may return
+	// before media processing is done.
+	async := (apiVersion == apiutil.APIv2)
+
+	apiAttachment, errWithCode := m.processor.Media().Create(c.Request.Context(), authed.Account, form, async)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
@@ -141,12 +152,16 @@ func (m *Module) MediaCreatePOSTHandler(c *gin.Context) {
 	// The v2 mastodon endpoint always returns TextURL,
 	// but in the case that a 202 Accepted (i.e. processing
 	// still in progress) is returned then the URL will be
-	// nil. Since we only ever return a 200 OK, we behave
-	// exactly the same as the v1 endpoint.
+	// nil. This only happens when media is being transcoded,
+	// otherwise we behave exactly the same as the v1 endpoint.
 	//
 	// https://docs.joinmastodon.org/methods/media/#v2
+	code := http.StatusOK
+	if apiAttachment.URL == nil {
+		code = http.StatusAccepted
+	}
 
-	apiutil.JSON(c, http.StatusOK, apiAttachment)
+	apiutil.JSON(c, code, apiAttachment)
 }
 
 func validateCreateMedia(form *apimodel.AttachmentRequest) error {
@@ -54,6 +54,12 @@
 //			description: The requested media attachment.
 //			schema:
 //				"$ref": "#/definitions/attachment"
+//		'206':
+//			description: >-
+//				The requested media attachment, which is still being transcoded.
+//				The attachment URL will be null until processing is complete.
+//			schema:
+//				"$ref": "#/definitions/attachment"
 //		'400':
 //			description: bad request
 //		'401':
@@ -102,5 +108,15 @@ func (m *Module) MediaGETHandler(c *gin.Context) {
 		return
 	}
 
-	apiutil.JSON(c, http.StatusOK, attachment)
+	// Media that is still being processed (i.e.
+	// transcoded) has no URL, in which case we
+	// return 206 Partial Content as mastodon does.
+	//
+	// https://docs.joinmastodon.org/methods/media/#get
+	code := http.StatusOK
+	if attachment.URL == nil && attachment.Type != "unknown" {
+		code = http.StatusPartialContent
+	}
+
+	apiutil.JSON(c, code, attachment)
 }
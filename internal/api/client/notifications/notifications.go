@@ -34,10 +34,28 @@
 	BasePathWithID    = BasePath + "/:" + IDKey
 	BasePathWithClear = BasePath + "/clear"
 
+	// RequestsPath is the base path for serving notification requests, minus the 'api' prefix.
+	RequestsPath              = BasePath + "/requests"
+	RequestsPathWithID        = RequestsPath + "/:" + IDKey
+	RequestsPathWithIDAccept  = RequestsPathWithID + "/accept"
+	RequestsPathWithIDDismiss = RequestsPathWithID + "/dismiss"
+	RequestsPathAccept        = RequestsPath + "/accept"
+	RequestsPathDismiss       = RequestsPath + "/dismiss"
+
+	// GroupKeyKey is for notification group keys.
+	GroupKeyKey = "group_key"
+	// BasePathV2 is the base path for serving the grouped notifications API, minus the 'api' prefix.
+	BasePathV2             = "/v2/notifications"
+	BasePathV2WithGroupKey = BasePathV2 + "/:" + GroupKeyKey
+	BasePathV2UnreadCount  = BasePathV2 + "/unread_count"
+	BasePathV2Policy       = BasePathV2 + "/policy"
+
 	// TypesKey names an array param specifying notification types to include.
 	TypesKey = "types[]"
 	// ExcludeTypesKey names an array param specifying notification types to exclude.
 	ExcludeTypesKey = "exclude_types[]"
+	// GroupedTypesKey names an array param specifying notification types that may be grouped.
+	GroupedTypesKey = "grouped_types[]"
 	MaxIDKey        = "max_id"
 	LimitKey        = "limit"
 	SinceIDKey      = "since_id"
@@ -58,4 +76,15 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, BasePath, m.NotificationsGETHandler)
 	attachHandler(http.MethodGet, BasePathWithID, m.NotificationGETHandler)
 	attachHandler(http.MethodPost, BasePathWithClear, m.NotificationsClearPOSTHandler)
+	attachHandler(http.MethodGet, BasePathV2, m.NotificationsGETV2Handler)
+	attachHandler(http.MethodGet, BasePathV2UnreadCount, m.NotificationsUnreadCountGETHandler)
+	attachHandler(http.MethodGet, BasePathV2WithGroupKey, m.NotificationGroupGETHandler)
+	attachHandler(http.MethodGet, BasePathV2Policy, m.NotificationPolicyGETHandler)
+	attachHandler(http.MethodPatch, BasePathV2Policy, m.NotificationPolicyPATCHHandler)
+	attachHandler(http.MethodGet, RequestsPath, m.NotificationRequestsGETHandler)
+	attachHandler(http.MethodGet, RequestsPathWithID, m.NotificationRequestGETHandler)
+	attachHandler(http.MethodPost, RequestsPathWithIDAccept, m.NotificationRequestAcceptPOSTHandler)
+	attachHandler(http.MethodPost, RequestsPathWithIDDismiss, m.NotificationRequestDismissPOSTHandler)
+	attachHandler(http.MethodPost, RequestsPathAccept, m.NotificationRequestsAcceptPOSTHandler)
+	attachHandler(http.MethodPost, RequestsPathDismiss, m.NotificationRequestsDismissPOSTHandler)
 }
@@ -114,6 +114,21 @@
 //		description: Types of notifications to exclude.
 //		in: query
 //		required: false
+//	-
+//		name: account_id
+//		type: string
+//		description: >-
+//			Return only notifications received from the account with the given ID.
+//			Notifications filtered by the notification policy are always included.
+//		in: query
+//		required: false
+//	-
+//		name: include_filtered
+//		type: boolean
+//		description: Include notifications filtered by the notification policy.
+//		default: false
+//		in: query
+//		required: false
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -166,6 +181,14 @@ func (m *Module) NotificationsGETHandler(c *gin.Context) {
 		return
 	}
 
+	includeFiltered, errWithCode := apiutil.ParseNotificationsIncludeFiltered(
+		c.Query(apiutil.NotificationsIncludeFilteredKey), false,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	ctx := c.Request.Context()
 	resp, errWithCode := m.processor.Timeline().NotificationsGet(
 		ctx,
@@ -173,6 +196,8 @@ func (m *Module) NotificationsGETHandler(c *gin.Context) {
 		page,
 		parseNotificationTypes(ctx, c.QueryArray(TypesKey)),        // Include types.
 		parseNotificationTypes(ctx, c.QueryArray(ExcludeTypesKey)), // Exclude types.
+		c.Query(apiutil.AccountIDKey),                              // Origin account.
+		includeFiltered,
 	)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
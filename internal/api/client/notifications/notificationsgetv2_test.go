@@ -0,0 +1,276 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package notifications_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/api/client/notifications"
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/oauth"
+	"code.superseriousbusiness.org/gotosocial/testrig"
+	"github.com/gin-gonic/gin"
+)
+
+// getV2 calls the given v2 notifications handler as local_account_1 with
+// given path params and query, checks the response code, and decodes
+// the response into out. The returned string is the Link header.
+func (suite *NotificationsTestSuite) getV2(
+	handler gin.HandlerFunc,
+	path string,
+	params gin.Params,
+	query url.Values,
+	expectedHTTPStatus int,
+	out any,
+) string {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+
+	ctx.Request = httptest.NewRequest(http.MethodGet, config.GetProtocol()+"://"+config.GetHost()+"/api"+path, nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Request.URL.RawQuery = query.Encode()
+	ctx.Params = params
+
+	handler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if !suite.Equal(expectedHTTPStatus, recorder.Code, string(b)) || out == nil {
+		return ""
+	}
+
+	if err := json.Unmarshal(b, out); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return result.Header.Get("Link")
+}
+
+// addFaveNotifications adds favourite notifications for the
+// first status of local_account_1 from two more accounts, on
+// top of the existing fixture fave, returning new notif IDs.
+func (suite *NotificationsTestSuite) addFaveNotifications() []string {
+	var ids []string
+	for i, origin := range []string{"local_account_2", "remote_account_1"} {
+		notif := &gtsmodel.Notification{
+			// Ensure newer than any other added
+			// notifs, and in order of creation.
+			ID:               id.NewULIDFromTime(time.Now().Add(time.Duration(i+1) * time.Second)),
+			NotificationType: gtsmodel.NotificationFavourite,
+			TargetAccountID:  suite.testAccounts["local_account_1"].ID,
+			OriginAccountID:  suite.testAccounts[origin].ID,
+			StatusOrEditID:   suite.testStatuses["local_account_1_status_1"].ID,
+		}
+		if err := suite.db.Put(suite.T().Context(), notif); err != nil {
+			suite.FailNow(err.Error())
+		}
+		ids = append(ids, notif.ID)
+	}
+	return ids
+}
+
+func (suite *NotificationsTestSuite) TestGetNotificationsV2Grouped() {
+	suite.addMoreNotifications(suite.testAccounts["local_account_1"])
+	faveIDs := suite.addFaveNotifications()
+
+	var resp apimodel.GroupedNotificationsResults
+	suite.getV2(
+		suite.notificationsModule.NotificationsGETV2Handler,
+		notifications.BasePathV2,
+		nil,
+		nil,
+		http.StatusOK,
+		&resp,
+	)
+
+	if !suite.Len(resp.NotificationGroups, 3) {
+		suite.FailNow("")
+	}
+
+	// All faves of the status should be
+	// grouped together, newest first.
+	faves := resp.NotificationGroups[0]
+	suite.Equal("favourite-01F8MHAMCHF6Y650WCRSCP4WMY", faves.GroupKey)
+	suite.Equal("favourite", faves.Type)
+	suite.Equal(3, faves.NotificationsCount)
+	suite.Equal(faveIDs[1], faves.MostRecentNotificationID)
+	suite.Equal(faveIDs[1], faves.PageMaxID)
+	suite.Equal("01F8Q0ANPTWW10DAKTX7BRPBJP", faves.PageMinID)
+	suite.Equal("01F8MHAMCHF6Y650WCRSCP4WMY", faves.StatusID)
+	suite.Equal([]string{
+		suite.testAccounts["remote_account_1"].ID,
+		suite.testAccounts["local_account_2"].ID,
+		suite.testAccounts["admin_account"].ID,
+	}, faves.SampleAccountIDs)
+
+	// Remaining groups were added in no
+	// particular order, so index by type.
+	groups := make(map[string]*apimodel.NotificationGroup)
+	for _, group := range resp.NotificationGroups[1:] {
+		groups[group.Type] = group
+	}
+
+	// Follow is grouped by day.
+	if follows := groups["follow"]; suite.NotNil(follows) {
+		suite.True(strings.HasPrefix(follows.GroupKey, "follow-"))
+		suite.Equal(1, follows.NotificationsCount)
+	}
+
+	// Follow requests are not grouped.
+	if requests := groups["follow_request"]; suite.NotNil(requests) {
+		suite.True(strings.HasPrefix(requests.GroupKey, "ungrouped-"))
+	}
+
+	// Referenced accounts and statuses
+	// should be included only once each.
+	suite.Len(resp.Accounts, 4)
+	if suite.Len(resp.Statuses, 1) {
+		suite.Equal("01F8MHAMCHF6Y650WCRSCP4WMY", resp.Statuses[0].ID)
+	}
+}
+
+func (suite *NotificationsTestSuite) TestGetNotificationsV2Ungrouped() {
+	suite.addFaveNotifications()
+
+	var resp apimodel.GroupedNotificationsResults
+	suite.getV2(
+		suite.notificationsModule.NotificationsGETV2Handler,
+		notifications.BasePathV2,
+		nil,
+		url.Values{notifications.GroupedTypesKey: []string{"follow"}},
+		http.StatusOK,
+		&resp,
+	)
+
+	// Faves should not be grouped.
+	suite.Len(resp.NotificationGroups, 3)
+	for _, group := range resp.NotificationGroups {
+		suite.True(strings.HasPrefix(group.GroupKey, "ungrouped-"))
+		suite.Equal(1, group.NotificationsCount)
+	}
+}
+
+func (suite *NotificationsTestSuite) TestGetNotificationsV2Limit() {
+	suite.addMoreNotifications(suite.testAccounts["local_account_1"])
+	faveIDs := suite.addFaveNotifications()
+
+	var resp apimodel.GroupedNotificationsResults
+	linkHeader := suite.getV2(
+		suite.notificationsModule.NotificationsGETV2Handler,
+		notifications.BasePathV2,
+		nil,
+		url.Values{notifications.LimitKey: []string{"1"}},
+		http.StatusOK,
+		&resp,
+	)
+
+	// Only the newest faves fit in the page, as
+	// the older fixture fave is behind the follow.
+	if suite.Len(resp.NotificationGroups, 1) {
+		suite.Equal(2, resp.NotificationGroups[0].NotificationsCount)
+	}
+	suite.Equal(`<http://localhost:8080/api/v2/notifications?limit=1&max_id=`+faveIDs[0]+`>; rel="next", <http://localhost:8080/api/v2/notifications?limit=1&min_id=`+faveIDs[1]+`>; rel="prev"`, linkHeader)
+}
+
+func (suite *NotificationsTestSuite) TestGetNotificationGroup() {
+	suite.addFaveNotifications()
+
+	var resp apimodel.GroupedNotificationsResults
+	suite.getV2(
+		suite.notificationsModule.NotificationGroupGETHandler,
+		notifications.BasePathV2+"/favourite-01F8MHAMCHF6Y650WCRSCP4WMY",
+		gin.Params{{Key: notifications.GroupKeyKey, Value: "favourite-01F8MHAMCHF6Y650WCRSCP4WMY"}},
+		nil,
+		http.StatusOK,
+		&resp,
+	)
+
+	if suite.Len(resp.NotificationGroups, 1) {
+		suite.Equal(3, resp.NotificationGroups[0].NotificationsCount)
+		suite.Len(resp.NotificationGroups[0].SampleAccountIDs, 3)
+	}
+	suite.Len(resp.Accounts, 3)
+	suite.Len(resp.Statuses, 1)
+}
+
+func (suite *NotificationsTestSuite) TestGetNotificationGroupNotFound() {
+	for _, groupKey := range []string{
+		"favourite-01F8MHAQCHF6Y650WCRSCP4WMY",
+		"mention-01F8MHAMCHF6Y650WCRSCP4WMY",
+		"ungrouped-01F8Q0ANPTWW10DAKTX7BRPBJQ",
+		"nonsense",
+	} {
+		suite.getV2(
+			suite.notificationsModule.NotificationGroupGETHandler,
+			notifications.BasePathV2+"/"+groupKey,
+			gin.Params{{Key: notifications.GroupKeyKey, Value: groupKey}},
+			nil,
+			http.StatusNotFound,
+			nil,
+		)
+	}
+}
+
+func (suite *NotificationsTestSuite) TestGetNotificationsUnreadCount() {
+	suite.addMoreNotifications(suite.testAccounts["local_account_1"])
+	suite.addFaveNotifications()
+
+	// New faves count as one group, plus
+	// follow and follow request. The fixture
+	// fave was already read as per marker.
+	var resp apimodel.NotificationsUnreadCount
+	suite.getV2(
+		suite.notificationsModule.NotificationsUnreadCountGETHandler,
+		notifications.BasePathV2UnreadCount,
+		nil,
+		nil,
+		http.StatusOK,
+		&resp,
+	)
+	suite.Equal(3, resp.Count)
+
+	// Without grouping each counts.
+	suite.getV2(
+		suite.notificationsModule.NotificationsUnreadCountGETHandler,
+		notifications.BasePathV2UnreadCount,
+		nil,
+		url.Values{notifications.GroupedTypesKey: []string{"follow"}},
+		http.StatusOK,
+		&resp,
+	)
+	suite.Equal(4, resp.Count)
+}
@@ -145,6 +145,7 @@ func (suite *ReportsGetTestSuite) TestGetReports() {
       "display_name": "big gerald",
       "locked": false,
       "discoverable": true,
+      "indexable": false,
       "bot": false,
       "created_at": "2021-09-26T10:52:36.000Z",
       "note": "i post about like, i dunno, stuff, or whatever!!!!",
@@ -237,6 +238,7 @@ func (suite *ReportsGetTestSuite) TestGetReports4() {
       "display_name": "big gerald",
       "locked": false,
       "discoverable": true,
+      "indexable": false,
       "bot": false,
       "created_at": "2021-09-26T10:52:36.000Z",
       "note": "i post about like, i dunno, stuff, or whatever!!!!",
@@ -313,6 +315,7 @@ func (suite *ReportsGetTestSuite) TestGetReports6() {
       "display_name": "big gerald",
       "locked": false,
       "discoverable": true,
+      "indexable": false,
       "bot": false,
       "created_at": "2021-09-26T10:52:36.000Z",
       "note": "i post about like, i dunno, stuff, or whatever!!!!",
@@ -373,6 +376,7 @@ func (suite *ReportsGetTestSuite) TestGetReports7() {
       "display_name": "big gerald",
       "locked": false,
       "discoverable": true,
+      "indexable": false,
       "bot": false,
       "created_at": "2021-09-26T10:52:36.000Z",
       "note": "i post about like, i dunno, stuff, or whatever!!!!",
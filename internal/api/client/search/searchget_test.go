@@ -913,8 +913,8 @@ func (suite *SearchGetTestSuite) TestSearchAAny() {
 		suite.FailNow(err.Error())
 	}
 
-	suite.Len(searchResult.Accounts, 6)
-	suite.Len(searchResult.Statuses, 9)
+	suite.Len(searchResult.Accounts, 1)
+	suite.Len(searchResult.Statuses, 6)
 	suite.Len(searchResult.Hashtags, 0)
 }
 
@@ -957,7 +957,7 @@ func (suite *SearchGetTestSuite) TestSearchAAnyFollowingOnly() {
 	}
 
 	suite.Len(searchResult.Accounts, 2)
-	suite.Len(searchResult.Statuses, 9)
+	suite.Len(searchResult.Statuses, 6)
 	suite.Len(searchResult.Hashtags, 0)
 }
 
@@ -1000,7 +1000,7 @@ func (suite *SearchGetTestSuite) TestSearchAStatuses() {
 	}
 
 	suite.Len(searchResult.Accounts, 0)
-	suite.Len(searchResult.Statuses, 9)
+	suite.Len(searchResult.Statuses, 6)
 	suite.Len(searchResult.Hashtags, 0)
 }
 
@@ -1128,7 +1128,7 @@ func (suite *SearchGetTestSuite) TestSearchAAccounts() {
 		suite.FailNow(err.Error())
 	}
 
-	suite.Len(searchResult.Accounts, 6)
+	suite.Len(searchResult.Accounts, 1)
 	suite.Len(searchResult.Statuses, 0)
 	suite.Len(searchResult.Hashtags, 0)
 }
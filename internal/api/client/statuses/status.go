@@ -27,6 +27,8 @@
 const (
 	// IDKey is for status UUIDs
 	IDKey = "id"
+	// EmojiKey is for emoji reaction names
+	EmojiKey = "emoji"
 	// BasePath is the base path for serving the statuses API, minus the 'api' prefix
 	BasePath = "/v1/statuses"
 	// BasePathWithID is just the base path with the ID key in it.
@@ -70,6 +72,11 @@
 
 	// SourcePath is used for fetching source of a post.
 	SourcePath = BasePathWithID + "/source"
+
+	// ReactionsPath is for viewing emoji reactions to a status, Pleroma-style.
+	ReactionsPath = "/v1/pleroma/statuses/:" + IDKey + "/reactions"
+	// ReactionPath is for viewing, adding and removing one emoji reaction to a status.
+	ReactionPath = ReactionsPath + "/:" + EmojiKey
 )
 
 type Module struct {
@@ -94,6 +101,12 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodPost, UnfavouritePath, m.StatusUnfavePOSTHandler)
 	attachHandler(http.MethodGet, FavouritedPath, m.StatusFavedByGETHandler)
 
+	// emoji reaction stuff
+	attachHandler(http.MethodGet, ReactionsPath, m.StatusReactionsGETHandler)
+	attachHandler(http.MethodGet, ReactionPath, m.StatusReactionsGETHandler)
+	attachHandler(http.MethodPut, ReactionPath, m.StatusReactionPUTHandler)
+	attachHandler(http.MethodDelete, ReactionPath, m.StatusReactionDELETEHandler)
+
 	// pin stuff
 	attachHandler(http.MethodPost, PinPath, m.StatusPinPOSTHandler)
 	attachHandler(http.MethodPost, UnpinPath, m.StatusUnpinPOSTHandler)
@@ -119,6 +119,22 @@ func (suite *StatusBoostTestSuite) TestPostBoost() {
       "manual_approval": [],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "author",
+        "me"
+      ],
+      "automatic_approval": [
+        "author",
+        "me"
+      ],
+      "manual_approval": [
+        "public"
+      ],
+      "with_approval": [
+        "public"
+      ]
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -189,6 +205,22 @@ func (suite *StatusBoostTestSuite) TestPostBoost() {
         "manual_approval": [],
         "with_approval": []
       },
+      "can_quote": {
+        "always": [
+          "author"
+        ],
+        "automatic_approval": [
+          "author"
+        ],
+        "manual_approval": [
+          "public",
+          "me"
+        ],
+        "with_approval": [
+          "public",
+          "me"
+        ]
+      },
       "can_reblog": {
         "always": [
           "public",
@@ -336,6 +368,18 @@ func (suite *StatusBoostTestSuite) TestPostBoostOwnFollowersOnly() {
       "manual_approval": [],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "author",
+        "me"
+      ],
+      "automatic_approval": [
+        "author",
+        "me"
+      ],
+      "manual_approval": [],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "author",
@@ -406,6 +450,18 @@ func (suite *StatusBoostTestSuite) TestPostBoostOwnFollowersOnly() {
         "manual_approval": [],
         "with_approval": []
       },
+      "can_quote": {
+        "always": [
+          "author",
+          "me"
+        ],
+        "automatic_approval": [
+          "author",
+          "me"
+        ],
+        "manual_approval": [],
+        "with_approval": []
+      },
       "can_reblog": {
         "always": [
           "author",
@@ -587,6 +643,22 @@ func (suite *StatusBoostTestSuite) TestPostBoostImplicitAccept() {
       "manual_approval": [],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "author",
+        "me"
+      ],
+      "automatic_approval": [
+        "author",
+        "me"
+      ],
+      "manual_approval": [
+        "public"
+      ],
+      "with_approval": [
+        "public"
+      ]
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -649,6 +721,22 @@ func (suite *StatusBoostTestSuite) TestPostBoostImplicitAccept() {
         "manual_approval": [],
         "with_approval": []
       },
+      "can_quote": {
+        "always": [
+          "author"
+        ],
+        "automatic_approval": [
+          "author"
+        ],
+        "manual_approval": [
+          "public",
+          "me"
+        ],
+        "with_approval": [
+          "public",
+          "me"
+        ]
+      },
       "can_reblog": {
         "always": [
           "public",
@@ -21,7 +21,6 @@
 	"errors"
 	"fmt"
 	"net/http"
-	"time"
 
 	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
 	apiutil "code.superseriousbusiness.org/gotosocial/internal/api/util"
@@ -131,6 +130,15 @@
 //		type: string
 //		in: formData
 //	-
+//		name: quoted_status_id
+//		x-go-name: QuotedStatusID
+//		description: |-
+//			ID of the status being quoted, if status is a quote.
+//			If the quoted status's author must approve the quote,
+//			the quote will be pending until they do so.
+//		type: string
+//		in: formData
+//	-
 //		name: sensitive
 //		x-go-name: Sensitive
 //		description: Status and attached media should be marked as sensitive.
@@ -415,24 +423,11 @@ func parseStatusCreateForm(c *gin.Context) (*apimodel.StatusCreateRequest, gtser
 
 	// Parse scheduled_at if given.
 	if form.ScheduledAtRaw != "" {
-		// Try RFC3339 initially, which
-		// is a stricter UTC subset of ISO8601.
-		scheduledAt, err := time.Parse(
-			time.RFC3339, form.ScheduledAtRaw,
+		scheduledAt, err := apiutil.ParseTime(
+			form.ScheduledAtRaw, "scheduled_at",
 		)
 		if err != nil {
-			// Try ISO8601 with offset
-			// (many clients use this).
-			scheduledAt, err = time.Parse(
-				util.ISO8601Offset, form.ScheduledAtRaw,
-			)
-		}
-
-		// If we still have an error
-		// we can't use this time.
-		if err != nil {
-			text := "could not parse scheduled_at value " + form.ScheduledAtRaw + " as ISO8601 time"
-			return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
 		}
 
 		form.ScheduledAt = &scheduledAt
@@ -164,6 +164,18 @@ func (suite *StatusCreateTestSuite) TestPostNewStatus() {
       "manual_approval": [],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "author",
+        "me"
+      ],
+      "automatic_approval": [
+        "author",
+        "me"
+      ],
+      "manual_approval": [],
+      "with_approval": []
+    },
     "can_reblog": {
       "always": [
         "author",
@@ -266,6 +278,22 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusIntPolicy() {
       "manual_approval": [],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "author",
+        "me"
+      ],
+      "automatic_approval": [
+        "author",
+        "me"
+      ],
+      "manual_approval": [
+        "public"
+      ],
+      "with_approval": [
+        "public"
+      ]
+    },
     "can_reblog": {
       "always": [
         "author",
@@ -383,6 +411,22 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusIntPolicyJSON() {
       "manual_approval": [],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "author",
+        "me"
+      ],
+      "automatic_approval": [
+        "author",
+        "me"
+      ],
+      "manual_approval": [
+        "public"
+      ],
+      "with_approval": [
+        "public"
+      ]
+    },
     "can_reblog": {
       "always": [
         "author",
@@ -679,6 +723,22 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusMarkdown() {
       "manual_approval": [],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "author",
+        "me"
+      ],
+      "automatic_approval": [
+        "author",
+        "me"
+      ],
+      "manual_approval": [
+        "public"
+      ],
+      "with_approval": [
+        "public"
+      ]
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -779,6 +839,22 @@ func (suite *StatusCreateTestSuite) TestMentionUnknownAccount() {
       "manual_approval": [],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "author",
+        "me"
+      ],
+      "automatic_approval": [
+        "author",
+        "me"
+      ],
+      "manual_approval": [
+        "public"
+      ],
+      "with_approval": [
+        "public"
+      ]
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -873,6 +949,22 @@ func (suite *StatusCreateTestSuite) TestPostStatusWithLinksAndTags() {
       "manual_approval": [],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "author",
+        "me"
+      ],
+      "automatic_approval": [
+        "author",
+        "me"
+      ],
+      "manual_approval": [
+        "public"
+      ],
+      "with_approval": [
+        "public"
+      ]
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -981,6 +1073,22 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusWithEmoji() {
       "manual_approval": [],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "author",
+        "me"
+      ],
+      "automatic_approval": [
+        "author",
+        "me"
+      ],
+      "manual_approval": [
+        "public"
+      ],
+      "with_approval": [
+        "public"
+      ]
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -1087,6 +1195,22 @@ func (suite *StatusCreateTestSuite) TestReplyToLocalStatus() {
       "manual_approval": [],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "author",
+        "me"
+      ],
+      "automatic_approval": [
+        "author",
+        "me"
+      ],
+      "manual_approval": [
+        "public"
+      ],
+      "with_approval": [
+        "public"
+      ]
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -1186,6 +1310,22 @@ func (suite *StatusCreateTestSuite) TestAttachNewMediaSuccess() {
       "manual_approval": [],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "author",
+        "me"
+      ],
+      "automatic_approval": [
+        "author",
+        "me"
+      ],
+      "manual_approval": [
+        "public"
+      ],
+      "with_approval": [
+        "public"
+      ]
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -1307,6 +1447,22 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusWithNoncanonicalLanguageTag
       "manual_approval": [],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "author",
+        "me"
+      ],
+      "automatic_approval": [
+        "author",
+        "me"
+      ],
+      "manual_approval": [
+        "public"
+      ],
+      "with_approval": [
+        "public"
+      ]
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -1398,6 +1554,22 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusWithPollForm() {
       "manual_approval": [],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "author",
+        "me"
+      ],
+      "automatic_approval": [
+        "author",
+        "me"
+      ],
+      "manual_approval": [
+        "public"
+      ],
+      "with_approval": [
+        "public"
+      ]
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -1511,6 +1683,22 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusWithPollJSON() {
       "manual_approval": [],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "author",
+        "me"
+      ],
+      "automatic_approval": [
+        "author",
+        "me"
+      ],
+      "manual_approval": [
+        "public"
+      ],
+      "with_approval": [
+        "public"
+      ]
+    },
     "can_reblog": {
       "always": [
         "public",
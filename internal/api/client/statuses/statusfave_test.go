@@ -125,6 +125,22 @@ func (suite *StatusFaveTestSuite) TestPostFave() {
       "manual_approval": [],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "author"
+      ],
+      "automatic_approval": [
+        "author"
+      ],
+      "manual_approval": [
+        "public",
+        "me"
+      ],
+      "with_approval": [
+        "public",
+        "me"
+      ]
+    },
     "can_reblog": {
       "always": [
         "public",
@@ -265,6 +281,22 @@ func (suite *StatusFaveTestSuite) TestPostFaveImplicitAccept() {
       "manual_approval": [],
       "with_approval": []
     },
+    "can_quote": {
+      "always": [
+        "author"
+      ],
+      "automatic_approval": [
+        "author"
+      ],
+      "manual_approval": [
+        "public",
+        "me"
+      ],
+      "with_approval": [
+        "public",
+        "me"
+      ]
+    },
     "can_reblog": {
       "always": [
         "public",
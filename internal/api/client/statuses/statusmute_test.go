@@ -121,6 +121,7 @@ func (suite *StatusMuteTestSuite) TestMuteUnmuteStatus() {
     "display_name": "original zork (he/they)",
     "locked": false,
     "discoverable": true,
+    "indexable": false,
     "bot": false,
     "created_at": "2022-05-20T11:09:18.000Z",
     "note": "\u003cp\u003ehey yo this is my profile!\u003c/p\u003e",
@@ -186,6 +187,22 @@ func (suite *StatusMuteTestSuite) TestMuteUnmuteStatus() {
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "automatic_approval": [
+        "author",
+        "me"
+      ],
+      "manual_approval": [
+        "public"
+      ],
+      "always": [
+        "author",
+        "me"
+      ],
+      "with_approval": [
+        "public"
+      ]
     }
   }
 }`, muted)
@@ -226,6 +243,7 @@ func (suite *StatusMuteTestSuite) TestMuteUnmuteStatus() {
     "display_name": "original zork (he/they)",
     "locked": false,
     "discoverable": true,
+    "indexable": false,
     "bot": false,
     "created_at": "2022-05-20T11:09:18.000Z",
     "note": "\u003cp\u003ehey yo this is my profile!\u003c/p\u003e",
@@ -291,6 +309,22 @@ func (suite *StatusMuteTestSuite) TestMuteUnmuteStatus() {
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "automatic_approval": [
+        "author",
+        "me"
+      ],
+      "manual_approval": [
+        "public"
+      ],
+      "always": [
+        "author",
+        "me"
+      ],
+      "with_approval": [
+        "public"
+      ]
     }
   }
 }`, unmuted)
@@ -0,0 +1,183 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package statuses_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"code.superseriousbusiness.org/gotosocial/internal/api/client/statuses"
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/oauth"
+	"code.superseriousbusiness.org/gotosocial/testrig"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type StatusReactionTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusReactionTestSuite) reactionRequest(
+	method string,
+	targetStatusID string,
+	emoji string,
+	handler func(*gin.Context),
+) *httptest.ResponseRecorder {
+	var (
+		app     = suite.testApplications["application_1"]
+		token   = suite.testTokens["local_account_1"]
+		user    = suite.testUsers["local_account_1"]
+		account = suite.testAccounts["local_account_1"]
+	)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, app)
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(token))
+	ctx.Set(oauth.SessionAuthorizedUser, user)
+	ctx.Set(oauth.SessionAuthorizedAccount, account)
+
+	path := "http://localhost:8080/api" + statuses.ReactionsPath
+	path = strings.ReplaceAll(path, ":"+statuses.IDKey, targetStatusID)
+	if emoji != "" {
+		path += "/" + url.PathEscape(emoji)
+	}
+	ctx.Request = httptest.NewRequest(method, path, nil)
+	ctx.Request.Header.Set("accept", "application/json")
+
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   statuses.IDKey,
+			Value: targetStatusID,
+		},
+		gin.Param{
+			Key:   statuses.EmojiKey,
+			Value: emoji,
+		},
+	}
+
+	handler(ctx)
+	return recorder
+}
+
+func (suite *StatusReactionTestSuite) getReactions(targetStatusID string) []*apimodel.EmojiReaction {
+	recorder := suite.reactionRequest(
+		http.MethodGet,
+		targetStatusID,
+		"",
+		suite.statusModule.StatusReactionsGETHandler,
+	)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	var reactions []*apimodel.EmojiReaction
+	if err := json.Unmarshal(b, &reactions); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return reactions
+}
+
+func (suite *StatusReactionTestSuite) TestReactions() {
+	targetStatus := suite.testStatuses["admin_account_status_2"]
+
+	// No reactions to begin with.
+	suite.Empty(suite.getReactions(targetStatus.ID))
+
+	// React with a unicode emoji and a local custom
+	// emoji, and then react with the unicode one again.
+	for _, emoji := range []string{"🐢", ":rainbow:", "🐢"} {
+		recorder := suite.reactionRequest(
+			http.MethodPut,
+			targetStatus.ID,
+			emoji,
+			suite.statusModule.StatusReactionPUTHandler,
+		)
+		suite.Equal(http.StatusOK, recorder.Code)
+	}
+
+	reactions := suite.getReactions(targetStatus.ID)
+	if !suite.Len(reactions, 2) {
+		suite.FailNow("")
+	}
+
+	suite.Equal("🐢", reactions[0].Name)
+	suite.Equal(1, reactions[0].Count)
+	suite.True(reactions[0].Me)
+	suite.Empty(reactions[0].URL)
+	suite.Len(reactions[0].Accounts, 1)
+
+	suite.Equal("rainbow", reactions[1].Name)
+	suite.Equal(1, reactions[1].Count)
+	suite.True(reactions[1].Me)
+	suite.Equal("http://localhost:8080/fileserver/01AY6P665V14JJR0AFVRT7311Y/emoji/original/01F8MH9H8E4VG3KDYJR9EGPXCQ.png", reactions[1].URL)
+
+	// Remove the unicode reaction.
+	recorder := suite.reactionRequest(
+		http.MethodDelete,
+		targetStatus.ID,
+		"🐢",
+		suite.statusModule.StatusReactionDELETEHandler,
+	)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	reactions = suite.getReactions(targetStatus.ID)
+	if !suite.Len(reactions, 1) {
+		suite.FailNow("")
+	}
+	suite.Equal("rainbow", reactions[0].Name)
+}
+
+func (suite *StatusReactionTestSuite) TestReactionInvalid() {
+	targetStatus := suite.testStatuses["admin_account_status_2"]
+
+	for emoji, expected := range map[string]string{
+		"not an emoji":     `{"error":"Unprocessable Entity: reaction not an emoji did not pass validation, must be a single unicode emoji or a custom emoji shortcode"}`,
+		":does_not_exist:": `{"error":"Unprocessable Entity: custom emoji does_not_exist not found"}`,
+	} {
+		recorder := suite.reactionRequest(
+			http.MethodPut,
+			targetStatus.ID,
+			emoji,
+			suite.statusModule.StatusReactionPUTHandler,
+		)
+		suite.Equal(http.StatusUnprocessableEntity, recorder.Code)
+
+		b, err := io.ReadAll(recorder.Body)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.Equal(expected, string(b))
+	}
+
+	suite.Empty(suite.getReactions(targetStatus.ID))
+}
+
+func TestStatusReactionTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusReactionTestSuite))
+}
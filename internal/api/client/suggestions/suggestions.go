@@ -21,13 +21,14 @@
 	"net/http"
 
 	apiutil "code.superseriousbusiness.org/gotosocial/internal/api/util"
-	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"code.superseriousbusiness.org/gotosocial/internal/processing"
 	"github.com/gin-gonic/gin"
 )
 
 const (
-	BasePath = "/v2/suggestions"
+	BasePathV1       = "/v1/suggestions"
+	BasePathV1WithID = BasePathV1 + "/:" + apiutil.IDKey
+	BasePathV2       = "/v2/suggestions"
 )
 
 type Module struct {
@@ -40,54 +41,8 @@ func New(processor *processing.Processor) *Module {
 	}
 }
 
-// SuggestionsGETHandler swagger:operation GET /api/v1/suggestions getSuggestions
-//
-// Accounts that are promoted by staff, or that the user has had past positive interactions with, but is not yet following.
-//
-// THIS ENDPOINT IS CURRENTLY NOT FULLY IMPLEMENTED: it will always return an empty array.
-//
-//	---
-//	tags:
-//	- suggestions
-//
-//	produces:
-//	- application/json
-//
-//	security:
-//	- OAuth2 Bearer:
-//		- read
-//
-//	responses:
-//		'200':
-//			schema:
-//				type: array
-//				items:
-//					type: object
-//				maxItems: 0
-//		'401':
-//			description: unauthorized
-//		'403':
-//			description: forbidden
-//		'406':
-//			description: not acceptable
-func (m *Module) SuggestionsGETHandler(c *gin.Context) {
-	_, errWithCode := apiutil.TokenAuth(c,
-		true, true, true, true,
-		apiutil.ScopeRead,
-	)
-	if errWithCode != nil {
-		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
-		return
-	}
-
-	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
-		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
-		return
-	}
-
-	apiutil.JSON(c, http.StatusOK, apiutil.EmptyJSONArray)
-}
-
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
-	attachHandler(http.MethodGet, BasePath, m.SuggestionsGETHandler)
+	attachHandler(http.MethodGet, BasePathV1, m.SuggestionsGETV1Handler)
+	attachHandler(http.MethodGet, BasePathV2, m.SuggestionsGETV2Handler)
+	attachHandler(http.MethodDelete, BasePathV1WithID, m.SuggestionDELETEHandler)
 }
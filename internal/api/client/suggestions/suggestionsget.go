@@ -0,0 +1,186 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package suggestions
+
+import (
+	"net/http"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	apiutil "code.superseriousbusiness.org/gotosocial/internal/api/util"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"github.com/gin-gonic/gin"
+)
+
+// SuggestionsGETV1Handler swagger:operation GET /api/v1/suggestions getSuggestionsV1
+//
+// Accounts that are promoted by staff, or that the user may be interested in, but is not yet following.
+//
+// Deprecated in favour of /api/v2/suggestions, which also includes the reasons each account is suggested.
+//
+//	---
+//	tags:
+//	- suggestions
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: limit
+//		type: integer
+//		description: Maximum number of suggested accounts to return.
+//		default: 40
+//		maximum: 80
+//		minimum: 1
+//		in: query
+//		required: false
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read
+//
+//	responses:
+//		'200':
+//			description: Array of suggested accounts.
+//			schema:
+//				type: array
+//				items:
+//					"$ref": "#/definitions/account"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) SuggestionsGETV1Handler(c *gin.Context) {
+	suggestions, ok := m.suggestionsGET(c)
+	if !ok {
+		return
+	}
+
+	accounts := make([]*apimodel.Account, len(suggestions))
+	for i, suggestion := range suggestions {
+		accounts[i] = suggestion.Account
+	}
+
+	apiutil.JSON(c, http.StatusOK, accounts)
+}
+
+// SuggestionsGETV2Handler swagger:operation GET /api/v2/suggestions getSuggestions
+//
+// Accounts that are promoted by staff, or that the user may be interested in, but is not yet following.
+//
+// Suggestions are drawn from staff picks, from accounts followed by accounts you follow,
+// and from accounts that often interact with accounts you follow. Accounts that have not
+// opted into discovery, blocked or muted accounts, and dismissed suggestions are never returned.
+//
+//	---
+//	tags:
+//	- suggestions
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: limit
+//		type: integer
+//		description: Maximum number of suggestions to return.
+//		default: 40
+//		maximum: 80
+//		minimum: 1
+//		in: query
+//		required: false
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read
+//
+//	responses:
+//		'200':
+//			description: Array of suggestions.
+//			schema:
+//				type: array
+//				items:
+//					"$ref": "#/definitions/suggestion"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) SuggestionsGETV2Handler(c *gin.Context) {
+	suggestions, ok := m.suggestionsGET(c)
+	if !ok {
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, suggestions)
+}
+
+// suggestionsGET handles common parts of
+// the v1 and v2 suggestions GET handlers,
+// returning false if an error was written.
+func (m *Module) suggestionsGET(c *gin.Context) ([]*apimodel.Suggestion, bool) {
+	authed, errWithCode := apiutil.TokenAuth(c,
+		true, true, true, true,
+		apiutil.ScopeRead,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return nil, false
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return nil, false
+	}
+
+	if authed.Account.IsMoving() {
+		// For moving/moved accounts, just
+		// return empty to avoid breaking
+		// client apps.
+		return []*apimodel.Suggestion{}, true
+	}
+
+	limit, errWithCode := apiutil.ParseLimit(c.Query(apiutil.LimitKey), 40, 80, 1)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return nil, false
+	}
+
+	suggestions, errWithCode := m.processor.Suggestions().SuggestionsGet(
+		c.Request.Context(),
+		authed.Account,
+		limit,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return nil, false
+	}
+
+	return suggestions, true
+}
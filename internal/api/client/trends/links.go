@@ -21,15 +21,18 @@
 	"net/http"
 
 	apiutil "code.superseriousbusiness.org/gotosocial/internal/api/util"
+	"code.superseriousbusiness.org/gotosocial/internal/config"
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"github.com/gin-gonic/gin"
 )
 
 // LinksGETHandler swagger:operation GET /api/v1/trends/links getTrendingLinks
 //
-// Links that have been shared more than others.
+// View links that are currently being shared more than usual.
 //
-// THIS ENDPOINT IS CURRENTLY NOT FULLY IMPLEMENTED: it will always return an empty array.
+// Only links that have been approved by an admin are returned, ordered by how much they're trending (most first).
+//
+// If trends are disabled on this instance, an empty array will always be returned.
 //
 //	---
 //	tags:
@@ -38,20 +41,77 @@
 //	produces:
 //	- application/json
 //
+//	parameters:
+//	-
+//		name: limit
+//		type: integer
+//		description: Maximum number of links to return.
+//		default: 10
+//		maximum: 20
+//		minimum: 1
+//		in: query
+//		required: false
+//	-
+//		name: offset
+//		type: integer
+//		description: Skip the first n results, for paging.
+//		default: 0
+//		minimum: 0
+//		in: query
+//		required: false
+//
 //	responses:
 //		'200':
+//			description: Array of trending links.
 //			schema:
 //				type: array
 //				items:
-//					type: object
-//				maxItems: 0
+//					"$ref": "#/definitions/trendsLink"
+//		'400':
+//			description: bad request
 //		'406':
 //			description: not acceptable
+//		'500':
+//			description: internal server error
 func (m *Module) LinksGETHandler(c *gin.Context) {
+	if _, errWithCode := apiutil.TokenAuth(c,
+		false, false, false, false,
+	); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
 		return
 	}
 
-	apiutil.JSON(c, http.StatusOK, apiutil.EmptyJSONArray)
+	if !config.GetInstanceTrendsEnabled() {
+		apiutil.JSON(c, http.StatusOK, apiutil.EmptyJSONArray)
+		return
+	}
+
+	limit, errWithCode := apiutil.ParseLimit(c.Query(apiutil.LimitKey), 10, 20, 1)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	offset, errWithCode := apiutil.ParseOffset(c.Query(apiutil.OffsetKey), 0, maxOffset, 0)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	links, errWithCode := m.processor.Trends().LinksGet(
+		c.Request.Context(),
+		limit,
+		offset,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, links)
 }
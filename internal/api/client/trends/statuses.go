@@ -21,15 +21,18 @@
 	"net/http"
 
 	apiutil "code.superseriousbusiness.org/gotosocial/internal/api/util"
+	"code.superseriousbusiness.org/gotosocial/internal/config"
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"github.com/gin-gonic/gin"
 )
 
 // StatusesGETHandler swagger:operation GET /api/v1/trends/statuses getTrendingStatuses
 //
-// Statuses that have been interacted with more than others.
+// View statuses that are currently being boosted and favourited more than usual.
 //
-// THIS ENDPOINT IS CURRENTLY NOT FULLY IMPLEMENTED: it will always return an empty array.
+// Only statuses that have been approved by an admin are returned, ordered by how much they're trending (most first).
+//
+// If trends are disabled on this instance, an empty array will always be returned.
 //
 //	---
 //	tags:
@@ -38,20 +41,79 @@
 //	produces:
 //	- application/json
 //
+//	parameters:
+//	-
+//		name: limit
+//		type: integer
+//		description: Maximum number of statuses to return.
+//		default: 20
+//		maximum: 40
+//		minimum: 1
+//		in: query
+//		required: false
+//	-
+//		name: offset
+//		type: integer
+//		description: Skip the first n results, for paging.
+//		default: 0
+//		minimum: 0
+//		in: query
+//		required: false
+//
 //	responses:
 //		'200':
+//			description: Array of trending statuses.
 //			schema:
 //				type: array
 //				items:
-//					type: object
-//				maxItems: 0
+//					"$ref": "#/definitions/status"
+//		'400':
+//			description: bad request
 //		'406':
 //			description: not acceptable
+//		'500':
+//			description: internal server error
 func (m *Module) StatusesGETHandler(c *gin.Context) {
+	authed, errWithCode := apiutil.TokenAuth(c,
+		false, false, false, false,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
 	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
 		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
 		return
 	}
 
-	apiutil.JSON(c, http.StatusOK, apiutil.EmptyJSONArray)
+	if !config.GetInstanceTrendsEnabled() {
+		apiutil.JSON(c, http.StatusOK, apiutil.EmptyJSONArray)
+		return
+	}
+
+	limit, errWithCode := apiutil.ParseLimit(c.Query(apiutil.LimitKey), 20, 40, 1)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	offset, errWithCode := apiutil.ParseOffset(c.Query(apiutil.OffsetKey), 0, maxOffset, 0)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	statuses, errWithCode := m.processor.Trends().StatusesGet(
+		c.Request.Context(),
+		authed.Account,
+		limit,
+		offset,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, statuses)
 }
@@ -29,6 +29,11 @@
 	TagsPath     = BasePath + "/tags"
 	StatusesPath = BasePath + "/statuses"
 	LinksPath    = BasePath + "/links"
+
+	// maxOffset is the maximum
+	// paging offset, trends are
+	// never kept beyond this.
+	maxOffset = 50
 )
 
 type Module struct {
@@ -48,6 +48,8 @@ type Account struct {
 	Locked bool `json:"locked"`
 	// Account has opted into discovery features.
 	Discoverable bool `json:"discoverable"`
+	// Account has opted into having its public statuses indexed for search.
+	Indexable bool `json:"indexable"`
 	// Account identifies as a bot.
 	Bot bool `json:"bot"`
 	// When the account was created (ISO 8601 Datetime).
@@ -196,6 +198,11 @@ type AccountCreateRequest struct {
 	// example: en
 	// Required: true
 	Locale string `form:"locale" json:"locale" xml:"locale" binding:"required"`
+	// Invite code to sign up with, if any. A valid invite code allows
+	// sign-up even when registration is closed, and skips approval.
+	// swagger:parameters
+	// example: 3b2Gq8WJ
+	InviteCode string `form:"invite_code" json:"invite_code" xml:"invite_code"`
 	// The IP of the sign up request, will not be parsed from the form.
 	// swagger:parameters
 	// swagger:ignore
@@ -208,6 +215,8 @@ type AccountCreateRequest struct {
 type UpdateCredentialsRequest struct {
 	// Account should be made discoverable and shown in the profile directory (if enabled).
 	Discoverable *bool `form:"discoverable" json:"discoverable"`
+	// Public statuses of this account may be included in full-text search results.
+	Indexable *bool `form:"indexable" json:"indexable"`
 	// Account is flagged as a bot.
 	Bot *bool `form:"bot" json:"bot"`
 	// The display name to use for the account.
@@ -247,6 +256,11 @@ type UpdateCredentialsRequest struct {
 	// "microblog": default, classic microblog layout.
 	// "gallery": gallery layout with media only.
 	WebLayout *string `form:"web_layout" json:"web_layout"`
+	// Whether and how often to email notifications.
+	// "none" (default), "immediate", "daily", or "weekly".
+	EmailNotifications *string `form:"email_notifications" json:"email_notifications"`
+	// Types of notifications to email.
+	EmailNotificationTypes []string `form:"email_notification_types[]" json:"email_notification_types"`
 }
 
 // UpdateSource is to be used specifically in an UpdateCredentialsRequest.
@@ -62,6 +62,10 @@ type AdminAccountInfo struct {
 	Approved bool `json:"approved"`
 	// Whether the account is currently disabled.
 	Disabled bool `json:"disabled"`
+	// Whether the account's statuses are currently forced to be marked as sensitive.
+	Sensitized bool `json:"sensitized"`
+	// Content warning prepended to the account's statuses, if sensitized.
+	SensitizedContentWarning string `json:"sensitized_content_warning,omitempty"`
 	// Whether the account is currently silenced
 	Silenced bool `json:"silenced"`
 	// Whether the account is currently suspended.
@@ -174,6 +178,8 @@ type AdminActionRequest struct {
 	Type string `form:"type" json:"type" xml:"type"`
 	// Text describing why an action was taken.
 	Text string `form:"text" json:"text" xml:"text"`
+	// Content warning to prepend to the target's statuses (sensitive action only).
+	ContentWarning string `form:"content_warning" json:"content_warning" xml:"content_warning"`
 	// ID of the target entity.
 	TargetID string `form:"-" json:"-" xml:"-"`
 }
@@ -17,9 +17,11 @@
 
 package model
 
+import "time"
+
 // Announcement models an admin announcement for the instance.
 //
-// TODO: swagger:model announcement once announcement API is supported
+// swagger:model announcement
 type Announcement struct {
 	// The ID of the announcement.
 	// example: 01FC30T7X4TNCZK0TH90QYF3M4
@@ -28,19 +30,24 @@ type Announcement struct {
 	// Should be HTML formatted.
 	// example: <p>This is an announcement. No malarky.</p>
 	Content string `json:"content"`
-	// When the announcement should begin to be displayed (ISO 8601 Datetime).
-	// If the announcement has no start time, this will be omitted or empty.
+	// When the event being announced begins (ISO 8601 Datetime).
+	// If the announcement has no start time, this will be null.
 	// example: 2021-07-30T09:20:25+00:00
-	StartsAt string `json:"starts_at"`
+	StartsAt *string `json:"starts_at"`
 	// When the announcement should stop being displayed (ISO 8601 Datetime).
-	// If the announcement has no end time, this will be omitted or empty.
+	// If the announcement has no end time, this will be null.
 	// example: 2021-07-30T09:20:25+00:00
-	EndsAt string `json:"ends_at"`
+	EndsAt *string `json:"ends_at"`
 	// Announcement doesn't have begin time and end time, but begin day and end day.
 	AllDay bool `json:"all_day"`
 	// When the announcement was first published (ISO 8601 Datetime).
+	// Empty if the announcement has not yet been published.
 	// example: 2021-07-30T09:20:25+00:00
 	PublishedAt string `json:"published_at"`
+	// When the announcement is scheduled to be published (ISO 8601 Datetime).
+	// Only set for announcements that have not yet been published.
+	// example: 2021-07-30T09:20:25+00:00
+	ScheduledAt *string `json:"scheduled_at,omitempty"`
 	// When the announcement was last updated (ISO 8601 Datetime).
 	// example: 2021-07-30T09:20:25+00:00
 	UpdatedAt string `json:"updated_at"`
@@ -56,7 +63,34 @@ type Announcement struct {
 	// Tags used in this announcement.
 	Tags []Tag `json:"tags"`
 	// Emojis used in this announcement.
-	Emojis []Emoji `json:"emoji"`
+	Emojis []Emoji `json:"emojis"`
 	// Reactions to this announcement.
 	Reactions []AnnouncementReaction `json:"reactions"`
 }
+
+// AnnouncementRequest is the form submitted
+// to create or update an announcement.
+//
+// swagger:ignore
+type AnnouncementRequest struct {
+	// Markdown text of the announcement.
+	Text *string `form:"text" json:"text"`
+	// Start time of the event being announced (ISO 8601 Datetime).
+	// An empty string clears the start time when updating.
+	StartsAtRaw *string `form:"starts_at" json:"starts_at"`
+	// StartsAtRaw parsed as a time; zero if cleared.
+	StartsAt *time.Time `form:"-" json:"-"`
+	// Time after which the announcement is no longer shown (ISO 8601 Datetime).
+	// An empty string clears the end time when updating.
+	EndsAtRaw *string `form:"ends_at" json:"ends_at"`
+	// EndsAtRaw parsed as a time; zero if cleared.
+	EndsAt *time.Time `form:"-" json:"-"`
+	// Show start and end as dates rather than times.
+	AllDay *bool `form:"all_day" json:"all_day"`
+	// Time at which to publish the announcement (ISO 8601 Datetime).
+	// If not set, or set in the past, the announcement is published
+	// immediately. Only applies to announcements not yet published.
+	ScheduledAtRaw *string `form:"scheduled_at" json:"scheduled_at"`
+	// ScheduledAtRaw parsed as a time; zero if cleared.
+	ScheduledAt *time.Time `form:"-" json:"-"`
+}
@@ -19,7 +19,7 @@
 
 // AnnouncementReaction models a user reaction to an announcement.
 //
-// TODO: swagger:model announcementReaction once announcement API is supported
+// swagger:model announcementReaction
 type AnnouncementReaction struct {
 	// The emoji used for the reaction. Either a unicode emoji, or a custom emoji's shortcode.
 	// example: blobcat_uwu
@@ -37,4 +37,8 @@ type AnnouncementReaction struct {
 	// Empty for unicode emojis.
 	// example: https://example.org/custom_emojis/statuc/blobcat_uwu.png
 	StaticURL string `json:"static_url,omitempty"`
+	// ID of the announcement reacted to.
+	// Only set when streamed to clients.
+	// example: 01FC30T7X4TNCZK0TH90QYF3M4
+	AnnouncementID string `json:"announcement_id,omitempty"`
 }
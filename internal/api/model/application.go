@@ -42,11 +42,17 @@ type Application struct {
 	// Client ID associated with this application.
 	ClientID string `json:"client_id,omitempty"`
 	// Client secret associated with this application.
+	// Not set for public clients, which don't use a client secret.
 	ClientSecret string `json:"client_secret,omitempty"`
 	// Push API key for this application.
 	VapidKey string `json:"vapid_key,omitempty"`
 	// OAuth scopes for this application.
 	Scopes []string `json:"scopes,omitempty"`
+	// Application is a public OAuth client, which must use PKCE
+	// when requesting an authorization code, and can't use the
+	// client_credentials grant type. Only shown to the creator
+	// or manager of the application.
+	Public bool `json:"public,omitempty"`
 }
 
 // ApplicationCreateRequest models app create parameters.
@@ -76,4 +82,12 @@ type ApplicationCreateRequest struct {
 	//
 	// in: formData
 	Website string `form:"website" json:"website" xml:"website"`
+	// Register the application as a public OAuth client (optional).
+	//
+	// Public clients, such as mobile apps or single-page web apps, can't keep a client secret confidential.
+	// They're not issued a client secret, they must use PKCE (with code_challenge_method S256) when requesting
+	// an authorization code, and they can't use the client_credentials grant type.
+	//
+	// in: formData
+	Public bool `form:"public" json:"public" xml:"public"`
 }
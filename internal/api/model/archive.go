@@ -0,0 +1,46 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// Archive represents a downloadable archive of
+// an account's statuses, media and profile.
+//
+// swagger:model archive
+type Archive struct {
+	// The ID of the archive.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+	// State of the archive.
+	// enum:
+	// - pending
+	// - ready
+	// - failed
+	// example: ready
+	State string `json:"state"`
+	// Time when the archive was requested (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Size of the archive in bytes, once ready.
+	// example: 1048576
+	Size int64 `json:"size,omitempty"`
+	// URL from which to download the archive, once ready.
+	// Downloading requires the same access token as this request.
+	// example: https://example.org/api/v1/exports/archive.zip
+	URL string `json:"url,omitempty"`
+}
@@ -115,6 +115,11 @@ type Attachment struct {
 	// A hash computed by the BlurHash algorithm, for generating colorful preview thumbnails when media has not been downloaded yet.
 	// See https://github.com/woltapp/blurhash
 	Blurhash *string `json:"blurhash"`
+	// Progress of transcoding the attachment, as a percentage.
+	// Only set while a local attachment is still being transcoded
+	// after upload, when the attachment is fetched by its owner.
+	// example: 42
+	ProcessingProgress *int `json:"processing_progress,omitempty"`
 }
 
 // WebAttachment is like Attachment, but with
@@ -212,3 +212,12 @@ type DomainPermissionSubscriptionRequest struct {
 	// example: admin123
 	FetchPassword *string `form:"fetch_password" json:"fetch_password"`
 }
+
+// UserDomainBlockRequest is the form submitted as a POST or DELETE
+// to /api/v1/domain_blocks to block or unblock a domain for oneself.
+//
+// swagger:ignore
+type UserDomainBlockRequest struct {
+	// Domain to block or unblock.
+	Domain string `form:"domain" json:"domain"`
+}
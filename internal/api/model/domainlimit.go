@@ -0,0 +1,66 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// DomainLimit represents moderation limits placed
+// on content from a domain and its subdomains.
+//
+// swagger:model domainLimit
+type DomainLimit struct {
+	// The ID of the domain limit.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+	// The hostname of the limited domain.
+	// example: example.org
+	Domain string `json:"domain"`
+	// Content warning prepended to all
+	// incoming statuses from this domain.
+	// example: Unmarked NSFW
+	ContentWarning string `json:"content_warning"`
+	// All incoming statuses from this domain are marked
+	// as sensitive. Always true if content_warning is set.
+	// example: true
+	Sensitive bool `json:"sensitive"`
+	// Private comment on this limit, viewable to admins.
+	// example: they don't mark their stuff as sensitive
+	PrivateComment string `json:"private_comment"`
+	// Time when the limit was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// ID of the admin account that created the limit.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	CreatedBy string `json:"created_by"`
+}
+
+// DomainLimitRequest is the form submitted
+// to create or update a domain limit.
+//
+// swagger:ignore
+type DomainLimitRequest struct {
+	// The hostname of the domain to limit.
+	// Ignored when updating.
+	Domain string `form:"domain" json:"domain"`
+	// Content warning to prepend to all
+	// incoming statuses from this domain.
+	ContentWarning *string `form:"content_warning" json:"content_warning"`
+	// Mark all incoming statuses from this domain as sensitive.
+	Sensitive *bool `form:"sensitive" json:"sensitive"`
+	// Private comment on this limit, viewable to admins.
+	PrivateComment *string `form:"private_comment" json:"private_comment"`
+}
@@ -18,15 +18,31 @@
 package model
 
 // FeaturedTag represents a hashtag that is featured on a profile.
+//
+// swagger:model featuredTag
 type FeaturedTag struct {
 	// The internal ID of the featured tag in the database.
+	// example: 01FBW25TF5J67JW3HFHZCSD23K
 	ID string `json:"id"`
 	// The name of the hashtag being featured.
+	// example: helloworld
 	Name string `json:"name"`
-	// A link to all statuses by a user that contain this hashtag.
+	// A link to the hashtag.
+	// example: https://example.org/tags/helloworld
 	URL string `json:"url"`
-	// The number of authored statuses containing this hashtag.
-	StatusesCount int `json:"statuses_count"`
-	// The timestamp of the last authored status containing this hashtag. (ISO 8601 Datetime)
-	LastStatusAt string `json:"last_status_at"`
+	// The number of authored public statuses containing this hashtag, as a string (like Mastodon).
+	// example: 12
+	StatusesCount string `json:"statuses_count"`
+	// The date of the last authored public status containing this hashtag. (ISO 8601 Date)
+	// Null if there is no such status.
+	// example: 2021-07-30
+	LastStatusAt *string `json:"last_status_at"`
+}
+
+// FeaturedTagCreateRequest models a request to feature a hashtag on the requester's profile.
+//
+// swagger:ignore
+type FeaturedTagCreateRequest struct {
+	// The hashtag to feature, with or without leading `#`.
+	Name string `form:"name" json:"name"`
 }
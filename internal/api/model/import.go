@@ -0,0 +1,57 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// Import models the progress of an import
+// of a data file to the requesting account.
+//
+// swagger:model import
+type Import struct {
+	// The ID of the import.
+	ID string `json:"id"`
+	// Type of data being imported, eg., `following`.
+	Type string `json:"type"`
+	// Mode used for the import: `merge` or `overwrite`.
+	Mode string `json:"mode"`
+	// State of the import: `in_progress` or `finished`.
+	State string `json:"state"`
+	// When the import was created (ISO 8601 Datetime).
+	CreatedAt string `json:"created_at"`
+	// When the import was last updated (ISO 8601 Datetime).
+	UpdatedAt string `json:"updated_at"`
+	// Number of entries found in the data file.
+	TotalItems int `json:"total_items"`
+	// Number of entries processed so far.
+	ProcessedItems int `json:"processed_items"`
+	// Number of processed entries that could not be imported.
+	FailedItems int `json:"failed_items"`
+	// Entries that could not be imported, and why.
+	// Only included when getting a single import.
+	Failures []ImportFailure `json:"failures,omitempty"`
+}
+
+// ImportFailure models one entry of
+// an import that could not be imported.
+//
+// swagger:model importFailure
+type ImportFailure struct {
+	// The entry that failed, eg., an account address or status URI.
+	Entry string `json:"entry"`
+	// Why the entry could not be imported.
+	Error string `json:"error"`
+}
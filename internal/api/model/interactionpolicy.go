@@ -71,6 +71,8 @@ type InteractionPolicy struct {
 	CanReply PolicyRules `form:"can_reply" json:"can_reply"`
 	// Rules for who can reblog this status.
 	CanReblog PolicyRules `form:"can_reblog" json:"can_reblog"`
+	// Rules for who can quote this status.
+	CanQuote PolicyRules `form:"can_quote" json:"can_quote"`
 }
 
 // Default interaction policies to use for new statuses by requesting account.
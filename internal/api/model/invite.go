@@ -0,0 +1,69 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// Invite represents an invite link which
+// lets new users sign up to this instance.
+//
+// swagger:model invite
+type Invite struct {
+	// The ID of the invite.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+	// Invite code to submit on the sign-up form.
+	// example: 3b2Gq8WJ
+	Code string `json:"code"`
+	// Link to the sign-up form with the invite code pre-filled.
+	// example: https://example.org/signup?invite=3b2Gq8WJ
+	URL string `json:"url"`
+	// Time when the invite was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Time when the invite expires (ISO 8601 Datetime), or null if it never expires.
+	// example: 2021-08-06T09:20:25+00:00
+	ExpiresAt *string `json:"expires_at"`
+	// Maximum number of sign-ups allowed with this invite, or null if unlimited.
+	// example: 5
+	MaxUses *int `json:"max_uses"`
+	// Number of sign-ups made with this invite so far.
+	// example: 2
+	Uses int `json:"uses"`
+	// New users signing up with this invite will automatically follow the inviter.
+	// example: true
+	Autofollow bool `json:"autofollow"`
+	// The invite has expired or been used up, and can no longer be used to sign up.
+	// example: false
+	Expired bool `json:"expired"`
+	// The account that created this invite.
+	// Only set when viewing invites via the admin API.
+	Account *Account `json:"account,omitempty"`
+}
+
+// InviteCreateRequest is the form
+// submitted to create a new invite.
+//
+// swagger:ignore
+type InviteCreateRequest struct {
+	// Maximum number of sign-ups allowed with this invite. 0 = unlimited.
+	MaxUses int `form:"max_uses" json:"max_uses"`
+	// Number of seconds from now after which the invite expires. 0 = never.
+	ExpiresIn int `form:"expires_in" json:"expires_in"`
+	// Make new users signing up with this invite follow the inviter.
+	Autofollow bool `form:"autofollow" json:"autofollow"`
+}
@@ -0,0 +1,95 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// AdminIPBlock represents an IP range from
+// which sign-ups and / or access are restricted.
+//
+// swagger:model adminIPBlock
+type AdminIPBlock struct {
+	// The ID of the IP block.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+	// Blocked IP range in CIDR notation.
+	// example: 192.0.2.0/24
+	IP string `json:"ip"`
+	// What the block applies to.
+	// enum:
+	// - sign_up_requires_approval
+	// - sign_up_block
+	// - no_access
+	// example: sign_up_block
+	Severity string `json:"severity"`
+	// Admin comment explaining the block.
+	// example: spam sign-ups
+	Comment string `json:"comment"`
+	// Time when the block was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Time when the block expires (ISO 8601 Datetime), if ever.
+	// example: 2021-08-30T09:20:25+00:00
+	ExpiresAt *string `json:"expires_at"`
+}
+
+// AdminIPBlockCreateRequest is the form
+// submitted to create or update an IP block.
+//
+// swagger:ignore
+type AdminIPBlockCreateRequest struct {
+	// IP range in CIDR notation. A single
+	// address is treated as a /32 or /128.
+	IP *string `form:"ip" json:"ip"`
+	// What the block applies to.
+	Severity *string `form:"severity" json:"severity"`
+	// Admin comment explaining the block.
+	Comment *string `form:"comment" json:"comment"`
+	// Number of seconds from now that
+	// the block should expire, 0 for never.
+	ExpiresIn *int `form:"expires_in" json:"expires_in"`
+}
+
+// AdminEmailDomainBlock represents an e-mail
+// domain from which sign-ups are rejected.
+//
+// swagger:model adminEmailDomainBlock
+type AdminEmailDomainBlock struct {
+	// The ID of the email domain block.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+	// Blocked e-mail domain. Subdomains
+	// of this domain are also blocked.
+	// example: example.org
+	Domain string `json:"domain"`
+	// Time when the block was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// ID of the admin account that created the block.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	CreatedBy string `json:"created_by"`
+}
+
+// AdminEmailDomainBlockCreateRequest is the
+// form submitted to create an email domain block.
+//
+// swagger:ignore
+type AdminEmailDomainBlockCreateRequest struct {
+	// E-mail domain to block.
+	Domain string `form:"domain" json:"domain"`
+}
@@ -34,7 +34,12 @@ type Notification struct {
 	// 	poll = A poll you have voted in or created has ended. `status` will be set. `account` will be set.
 	// 	status = Someone you enabled notifications for has posted a status. `status` will be set. `account` will be set.
 	// 	admin.sign_up = Someone has signed up for a new account on the instance. `account` will be set.
+	// 	pleroma:emoji_reaction = Someone reacted to one of your statuses with an emoji. `status`, `account` and `emoji` will be set.
+	// 	quote = Someone quoted one of your statuses. `status` will be set to the quoting status. `account` will be set.
+	// 	pending.quote = Someone quoted one of your statuses, and the quote requires your approval. `status` will be set to the quoting status. `account` will be set.
 	Type string `json:"type"`
+	// Key of the notification group this notification belongs to, see NotificationGroup.
+	GroupKey string `json:"group_key"`
 	// The timestamp of the notification (ISO 8601 Datetime)
 	CreatedAt string `json:"created_at"`
 	// The account that performed the action that generated the notification.
@@ -44,6 +49,13 @@ type Notification struct {
 
 	// Status that was the object of the notification, e.g. in mentions, reblogs, favourites, or polls.
 	Status *Status `json:"status,omitempty"`
+
+	// Emoji used to react to the status, for pleroma:emoji_reaction notifications.
+	// Either a unicode emoji, or the shortcode of a custom emoji.
+	Emoji string `json:"emoji,omitempty"`
+
+	// URL of the custom emoji used to react to the status, if applicable.
+	EmojiURL string `json:"emoji_url,omitempty"`
 }
 
 /*
@@ -66,3 +78,56 @@ func (n *Notification) GetBoostOfID() string {
 func (n *Notification) GetBoostOfAccountID() string {
 	return ""
 }
+
+// GroupedNotificationsResults models a page of grouped notifications,
+// along with the accounts and statuses referenced by those groups.
+//
+// swagger:model groupedNotificationsResults
+type GroupedNotificationsResults struct {
+	// Accounts referenced by notification groups.
+	Accounts []*Account `json:"accounts"`
+	// Statuses referenced by notification groups.
+	Statuses []*Status `json:"statuses"`
+	// Notification groups, most recent first.
+	NotificationGroups []*NotificationGroup `json:"notification_groups"`
+}
+
+// NotificationGroup represents a group of notifications of the same
+// type, eg., all favourites of a status, as shown in a page of results.
+//
+// swagger:model notificationGroup
+type NotificationGroup struct {
+	// Key identifying the group. Notifications of grouped types are keyed
+	// by type and status (or day, for follows). Notifications that are not
+	// grouped have a group key of the form "ungrouped-{notification_id}".
+	GroupKey string `json:"group_key"`
+	// Number of notifications in this group.
+	NotificationsCount int `json:"notifications_count"`
+	// The type of event that resulted in the notifications, see Notification.
+	Type string `json:"type"`
+	// ID of the most recent notification in the group.
+	MostRecentNotificationID string `json:"most_recent_notification_id"`
+	// ID of the oldest notification of this group in the page.
+	PageMinID string `json:"page_min_id,omitempty"`
+	// ID of the newest notification of this group in the page.
+	PageMaxID string `json:"page_max_id,omitempty"`
+	// Timestamp of the newest notification of this group in the page (ISO 8601 Datetime).
+	LatestPageNotificationAt string `json:"latest_page_notification_at,omitempty"`
+	// IDs of some of the accounts that triggered the notifications, most recent first.
+	SampleAccountIDs []string `json:"sample_account_ids"`
+	// ID of the status that the notifications relate to, if any.
+	StatusID string `json:"status_id,omitempty"`
+	// Emoji used to react to the status, for pleroma:emoji_reaction notifications.
+	// Either a unicode emoji, or the shortcode of a custom emoji.
+	Emoji string `json:"emoji,omitempty"`
+	// URL of the custom emoji used to react to the status, if applicable.
+	EmojiURL string `json:"emoji_url,omitempty"`
+}
+
+// NotificationsUnreadCount represents the number of unread notifications.
+//
+// swagger:model notificationsUnreadCount
+type NotificationsUnreadCount struct {
+	// Number of unread notification groups.
+	Count int `json:"count"`
+}
@@ -0,0 +1,95 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// NotificationPolicy represents how notifications from
+// accounts matching certain conditions are handled.
+//
+// Each condition is handled in one of the following ways:
+//
+//	accept = notify as normal.
+//	filter = hold notifications back in notification requests.
+//	drop = don't create notifications at all.
+//
+// swagger:model notificationPolicy
+type NotificationPolicy struct {
+	// Handling of notifications from accounts you don't follow.
+	ForNotFollowing string `json:"for_not_following"`
+	// Handling of notifications from accounts that don't follow you.
+	ForNotFollowers string `json:"for_not_followers"`
+	// Handling of notifications from accounts created in the past 30 days.
+	ForNewAccounts string `json:"for_new_accounts"`
+	// Handling of private mentions that aren't replies to you, from accounts you don't follow.
+	ForPrivateMentions string `json:"for_private_mentions"`
+	// Handling of notifications from moderated (silenced) accounts you don't follow.
+	ForLimitedAccounts string `json:"for_limited_accounts"`
+	// Summary of filtered notifications.
+	Summary NotificationPolicySummary `json:"summary"`
+}
+
+// NotificationPolicySummary summarizes
+// notifications held back by a NotificationPolicy.
+//
+// swagger:model notificationPolicySummary
+type NotificationPolicySummary struct {
+	// Number of pending notification requests.
+	PendingRequestsCount int `json:"pending_requests_count"`
+	// Number of notifications held in pending notification requests.
+	PendingNotificationsCount int `json:"pending_notifications_count"`
+}
+
+// NotificationPolicyUpdateRequest models a request to update a notification policy.
+// Each field is one of accept, filter, or drop, and is left unchanged if not set.
+//
+// swagger:ignore
+type NotificationPolicyUpdateRequest struct {
+	ForNotFollowing    *string `form:"for_not_following" json:"for_not_following"`
+	ForNotFollowers    *string `form:"for_not_followers" json:"for_not_followers"`
+	ForNewAccounts     *string `form:"for_new_accounts" json:"for_new_accounts"`
+	ForPrivateMentions *string `form:"for_private_mentions" json:"for_private_mentions"`
+	ForLimitedAccounts *string `form:"for_limited_accounts" json:"for_limited_accounts"`
+}
+
+// NotificationRequest represents notifications from
+// one account that were filtered by your notification
+// policy, and are pending your acceptance or dismissal.
+//
+// swagger:model notificationRequest
+type NotificationRequest struct {
+	// The ID of the notification request.
+	ID string `json:"id"`
+	// When the first filtered notification from the account was created (ISO 8601 Datetime).
+	CreatedAt string `json:"created_at"`
+	// When the notification request was last updated (ISO 8601 Datetime).
+	UpdatedAt string `json:"updated_at"`
+	// The account that performed the actions that generated the filtered notifications.
+	Account *Account `json:"account"`
+	// How many of this account's notifications were filtered.
+	// Provided as a string for Mastodon API compatibility.
+	NotificationsCount string `json:"notifications_count"`
+	// Most recent status associated with a filtered notification from that account.
+	LastStatus *Status `json:"last_status,omitempty"`
+}
+
+// NotificationRequestsBulkRequest models a request
+// to accept or dismiss multiple notification requests.
+//
+// swagger:ignore
+type NotificationRequestsBulkRequest struct {
+	IDs []string `form:"id[]" json:"id"`
+}
@@ -36,4 +36,51 @@ type OAuthAuthorize struct {
 	// The authorization server must return the unmodified state value back to the application.
 	// See https://www.oauth.com/oauth2-servers/authorization/the-authorization-request/
 	State string `form:"state" json:"state"`
+	// PKCE code challenge, derived from a code verifier known only to the application.
+	// Required for public clients, optional (but recommended) for others.
+	// See https://datatracker.ietf.org/doc/html/rfc7636
+	CodeChallenge string `form:"code_challenge" json:"code_challenge"`
+	// Method used to derive the PKCE code challenge from the code verifier.
+	// Only `S256` is supported, which is also the default if not set.
+	CodeChallengeMethod string `form:"code_challenge_method" json:"code_challenge_method"`
+}
+
+// DeviceAuthorizationRequest represents a request sent to https://example.org/oauth/device_authorization
+//
+// swagger:ignore
+type DeviceAuthorizationRequest struct {
+	// Client ID, obtained during app registration.
+	ClientID string `form:"client_id" json:"client_id" xml:"client_id"`
+	// Client secret, obtained during app registration.
+	// Not required for public clients.
+	ClientSecret string `form:"client_secret" json:"client_secret" xml:"client_secret"`
+	// List of requested OAuth scopes, separated by spaces.
+	// Must be a subset of scopes declared during app registration. If not provided, defaults to read.
+	Scope string `form:"scope" json:"scope" xml:"scope"`
+}
+
+// DeviceAuthorization represents a response to an OAuth device authorization request,
+// as per https://datatracker.ietf.org/doc/html/rfc8628#section-3.2
+//
+// swagger:model deviceAuthorization
+type DeviceAuthorization struct {
+	// Code that the client uses to poll the token endpoint.
+	DeviceCode string `json:"device_code"`
+	// Short code that the user should enter at the verification URI.
+	// example: BCDF-GHJK
+	UserCode string `json:"user_code"`
+	// Web page where the user should enter the user code.
+	// example: https://example.org/device
+	VerificationURI string `json:"verification_uri"`
+	// Web page where the user can approve the request
+	// without having to type in the user code.
+	// example: https://example.org/device?user_code=BCDF-GHJK
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	// Number of seconds until the device code and user code expire.
+	// example: 900
+	ExpiresIn int64 `json:"expires_in"`
+	// Minimum number of seconds the client should
+	// wait between polling the token endpoint.
+	// example: 5
+	Interval int64 `json:"interval"`
 }
@@ -0,0 +1,63 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// AdminRelay represents a subscription
+// of this instance to an ActivityPub relay.
+//
+// swagger:model adminRelay
+type AdminRelay struct {
+	// The ID of the relay.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+	// Inbox URL of the relay.
+	// example: https://relay.example.org/inbox
+	InboxURL string `json:"inbox_url"`
+	// URL of the relay actor, if known. This
+	// is set once the relay responds to our Follow.
+	// example: https://relay.example.org/actor
+	ActorURL string `json:"actor_url,omitempty"`
+	// State of the subscription to the relay.
+	// enum:
+	// - pending
+	// - accepted
+	// - rejected
+	// example: accepted
+	State string `json:"state"`
+	// Local public posts are delivered to the relay.
+	// example: true
+	Publish bool `json:"publish"`
+	// Time when the relay was added (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// ID of the admin account that added the relay.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	CreatedBy string `json:"created_by"`
+}
+
+// AdminRelayCreateRequest is the form
+// submitted to subscribe to a relay.
+//
+// swagger:ignore
+type AdminRelayCreateRequest struct {
+	// Inbox URL of the relay.
+	InboxURL string `form:"inbox_url" json:"inbox_url"`
+	// Deliver local public posts to the relay.
+	Publish bool `form:"publish" json:"publish"`
+}
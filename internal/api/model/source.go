@@ -35,6 +35,14 @@ type Source struct {
 	//    "microblog": default, classic microblog layout.
 	//    "gallery": gallery layout with media only.
 	WebLayout string `json:"web_layout"`
+	// Whether and how often notifications are emailed.
+	//    "none": default, don't email notifications.
+	//    "immediate": email each notification as it arrives.
+	//    "daily": email a digest of notifications once a day.
+	//    "weekly": email a digest of notifications once a week.
+	EmailNotifications string `json:"email_notifications"`
+	// Types of notifications to email.
+	EmailNotificationTypes []string `json:"email_notification_types"`
 	// Whether new statuses should be marked sensitive by default.
 	Sensitive bool `json:"sensitive"`
 	// The default posting language for new statuses.
@@ -88,6 +88,9 @@ type Status struct {
 	// The status that this status reblogs/boosts.
 	// nullable: true
 	Reblog *StatusReblogged `json:"reblog"`
+	// Information about the status that this status quotes, if any.
+	// Omitted if this status is not a quote.
+	Quote *StatusQuote `json:"quote,omitempty"`
 	// The application used to post this status, if visible.
 	Application *Application `json:"application,omitempty"`
 	// The account that authored this status.
@@ -211,6 +214,29 @@ type StatusReblogged struct {
 	*Status
 }
 
+// StatusQuote represents information
+// about a status quoted by another status.
+//
+// swagger:model statusQuote
+type StatusQuote struct {
+	// State of the quote. One of the following:
+	//
+	//   - pending: quote has not yet been approved by the quoted status's author.
+	//   - accepted: quote has been approved by the quoted status's author.
+	//   - deleted: quoted status has been deleted or is otherwise unavailable.
+	//   - unauthorized: quoted status is not visible to the account viewing this status.
+	//
+	// example: accepted
+	State string `json:"state"`
+	// The quoted status. Only set when state is "accepted".
+	// Quotes nested within the quoted status are not rendered.
+	// nullable: true
+	QuotedStatus *Status `json:"quoted_status,omitempty"`
+	// ID of the quoted status, if known.
+	// nullable: true
+	QuotedStatusID *string `json:"quoted_status_id,omitempty"`
+}
+
 // StatusCreateRequest models status creation parameters.
 //
 // swagger:ignore
@@ -231,6 +257,9 @@ type StatusCreateRequest struct {
 	// ID of the status being replied to, if status is a reply.
 	InReplyToID string `form:"in_reply_to_id" json:"in_reply_to_id"`
 
+	// ID of the status being quoted, if status is a quote.
+	QuotedStatusID string `form:"quoted_status_id" json:"quoted_status_id"`
+
 	// Status and attached media should be marked as sensitive.
 	Sensitive bool `form:"sensitive" json:"sensitive"`
 
@@ -32,6 +32,14 @@ type Token struct {
 	// When the OAuth token was generated (UNIX timestamp seconds).
 	// example: 1627644520
 	CreatedAt int64 `json:"created_at"`
+	// Number of seconds until the access token expires.
+	// Not set if the access token doesn't expire.
+	// example: 3600
+	ExpiresIn int64 `json:"expires_in,omitempty"`
+	// Refresh token which can be exchanged for a new access token
+	// (and refresh token) using the refresh_token grant type.
+	// Only set if the access token expires.
+	RefreshToken string `json:"refresh_token,omitempty"`
 }
 
 // TokenInfo represents metadata about one user-level access token.
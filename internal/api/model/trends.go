@@ -0,0 +1,65 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// TrendsLink represents a link that is
+// currently being shared more than usual.
+//
+// swagger:model trendsLink
+type TrendsLink struct {
+	Card
+
+	// History of this link's usage, newest day first.
+	History []History `json:"history"`
+}
+
+// AdminTrend models a trending tag, status or link,
+// along with the state of its review by an admin.
+//
+// swagger:model adminTrend
+type AdminTrend struct {
+	// The ID of the trend review, used
+	// to approve or reject this trend.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// Type of trend.
+	// enum:
+	// - tag
+	// - status
+	// - link
+	// example: tag
+	Type string `json:"type"`
+	// Review state of this trend. Only approved
+	// trends are shown on the public trends endpoints.
+	// enum:
+	// - pending
+	// - approved
+	// - rejected
+	// example: pending
+	State string `json:"state"`
+	// Time-decayed trending score of this
+	// trend, by which trends are ordered.
+	// example: 4.25
+	Score float64 `json:"score"`
+	// The trending tag, set if type is "tag".
+	Tag *Tag `json:"tag,omitempty"`
+	// The trending status, set if type is "status".
+	Status *Status `json:"status,omitempty"`
+	// The trending link, set if type is "link".
+	Link *TrendsLink `json:"link,omitempty"`
+}
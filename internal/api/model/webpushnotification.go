@@ -29,6 +29,9 @@ type WebPushNotification struct {
 	// NotificationType is the Notification.Type of the referenced Notification.
 	NotificationType string `json:"notification_type"`
 
+	// GroupKey is the Notification.GroupKey of the referenced Notification.
+	GroupKey string `json:"group_key"`
+
 	// Title is a title for the notification,
 	// generally describing an action taken by a user.
 	Title string `json:"title"`
@@ -22,6 +22,7 @@
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
@@ -128,3 +129,26 @@ func ParseNullableDuration(
 
 	return ParseDuration(rawI, fieldName)
 }
+
+// ParseTime parses the given raw string belonging to the
+// given fieldName as an ISO8601 time, accepting either
+// RFC3339 or ISO8601 with a numeric timezone offset.
+func ParseTime(raw string, fieldName string) (time.Time, error) {
+	// Try RFC3339 initially, which
+	// is a stricter UTC subset of ISO8601.
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		// Try ISO8601 with offset
+		// (many clients use this).
+		t, err = time.Parse(util.ISO8601Offset, raw)
+	}
+
+	if err != nil {
+		return time.Time{}, fmt.Errorf(
+			"could not parse %s value %s as ISO8601 time",
+			fieldName, raw,
+		)
+	}
+
+	return t, nil
+}
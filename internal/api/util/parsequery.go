@@ -36,6 +36,7 @@
 
 	IDKey              = "id"
 	LimitKey           = "limit"
+	OffsetKey          = "offset"
 	LocalKey           = "local"
 	MaxIDKey           = "max_id"
 	SinceIDKey         = "since_id"
@@ -63,6 +64,15 @@
 
 	TagNameKey = "tag_name"
 
+	/* Announcement keys */
+
+	AnnouncementWithDismissedKey = "with_dismissed"
+	AnnouncementReactionNameKey  = "name"
+
+	/* Notification keys */
+
+	NotificationsIncludeFilteredKey = "include_filtered"
+
 	/* Web endpoint keys */
 
 	WebStatusIDKey = "status"
@@ -101,6 +111,7 @@
 	InteractionFavouritesKey = "favourites"
 	InteractionRepliesKey    = "replies"
 	InteractionReblogsKey    = "reblogs"
+	InteractionQuotesKey     = "quotes"
 )
 
 /*
@@ -140,6 +151,10 @@ func ParseLimit(value string, defaultValue int, max, min int) (int, gtserror.Wit
 	return i, nil
 }
 
+func ParseOffset(value string, defaultValue int, max, min int) (int, gtserror.WithCode) {
+	return parseInt(value, defaultValue, max, min, OffsetKey)
+}
+
 func ParseLocal(value string, defaultValue bool) (bool, gtserror.WithCode) {
 	return parseBool(value, defaultValue, LocalKey)
 }
@@ -216,6 +231,18 @@ func ParseInteractionReblogs(value string, defaultValue bool) (bool, gtserror.Wi
 	return parseBool(value, defaultValue, InteractionReblogsKey)
 }
 
+func ParseInteractionQuotes(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	return parseBool(value, defaultValue, InteractionQuotesKey)
+}
+
+func ParseAnnouncementWithDismissed(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	return parseBool(value, defaultValue, AnnouncementWithDismissedKey)
+}
+
+func ParseNotificationsIncludeFiltered(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	return parseBool(value, defaultValue, NotificationsIncludeFilteredKey)
+}
+
 /*
 	Parse functions for *REQUIRED* parameters.
 */
@@ -270,6 +297,16 @@ func ParseSearchQuery(value string) (string, gtserror.WithCode) {
 	return value, nil
 }
 
+func ParseAnnouncementReactionName(value string) (string, gtserror.WithCode) {
+	key := AnnouncementReactionNameKey
+
+	if value == "" {
+		return "", requiredError(key)
+	}
+
+	return value, nil
+}
+
 func ParseTagName(value string) (string, gtserror.WithCode) {
 	key := TagNameKey
 
@@ -21,6 +21,7 @@
 	"time"
 
 	"code.superseriousbusiness.org/gotosocial/internal/cache/headerfilter"
+	"code.superseriousbusiness.org/gotosocial/internal/cache/ipblock"
 	"code.superseriousbusiness.org/gotosocial/internal/config"
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"code.superseriousbusiness.org/gotosocial/internal/log"
@@ -41,11 +42,19 @@ type Caches struct {
 	// the block []headerfilter.Filter cache.
 	BlockHeaderFilters headerfilter.Cache
 
+	// IPBlocks provides access to
+	// the admin []*gtsmodel.IPBlock cache.
+	IPBlocks ipblock.Cache
+
 	// Timelines provides access to the
 	// collection of timeline object caches,
 	// used in timeline lookups and streaming.
 	Timelines TimelineCaches
 
+	// Trends provides access to the most
+	// recently calculated instance trends.
+	Trends TrendsCache
+
 	// Mutes provides access to the item mutes
 	// cache. (used by the item mutes filter).
 	Mutes StructCache[*CachedMute]
@@ -78,13 +87,16 @@ func (c *Caches) Init() {
 	c.initBlock()
 	c.initBlockIDs()
 	c.initBoostOfIDs()
+	c.initCard()
 	c.initConversation()
 	c.initConversationLastStatusIDs()
 	c.initDomainAllow()
 	c.initDomainBlock()
+	c.initDomainLimit()
 	c.initDomainPermissionDraft()
 	c.initDomainPermissionSubscription()
 	c.initDomainPermissionExclude()
+	c.initEmailDomainBlock()
 	c.initEmoji()
 	c.initEmojiCategory()
 	c.initFilterIDs()
@@ -128,12 +140,14 @@ func (c *Caches) Init() {
 	c.initUser()
 	c.initUserMute()
 	c.initUserMuteIDs()
+	c.initUserDomainBlocks()
 	c.initWebfinger()
 	c.initWebPushSubscription()
 	c.initWebPushSubscriptionIDs()
 	c.initMutes()
 	c.initStatusFilter()
 	c.initVisibility()
+	c.Trends.Clear()
 }
 
 // Start will start any caches that require a background
@@ -173,6 +187,7 @@ func (c *Caches) Sweep(threshold float64) {
 	c.DB.Block.Trim(threshold)
 	c.DB.BlockIDs.Trim(threshold)
 	c.DB.BoostOfIDs.Trim(threshold)
+	c.DB.Card.Trim(threshold)
 	c.DB.Conversation.Trim(threshold)
 	c.DB.ConversationLastStatusIDs.Trim(threshold)
 	c.DB.Emoji.Trim(threshold)
@@ -52,6 +52,9 @@ type DBCaches struct {
 	// BoostOfIDs provides access to the boost of IDs list database cache.
 	BoostOfIDs SliceCache[string]
 
+	// Card provides access to the gtsmodel Card database cache.
+	Card StructCache[*gtsmodel.Card]
+
 	// Conversation provides access to the gtsmodel Conversation database cache.
 	Conversation StructCache[*gtsmodel.Conversation]
 
@@ -64,6 +67,9 @@ type DBCaches struct {
 	// DomainBlock provides access to the domain block database cache.
 	DomainBlock *domain.Cache
 
+	// DomainLimit provides access to the domain limit database cache.
+	DomainLimit *domain.Cache
+
 	// DomainPermissionDraft provides access to the domain permission draft database cache.
 	DomainPermissionDraft StructCache[*gtsmodel.DomainPermissionDraft]
 
@@ -73,6 +79,9 @@ type DBCaches struct {
 	// DomainPermissionExclude provides access to the domain permission exclude database cache.
 	DomainPermissionExclude *domain.Cache
 
+	// EmailDomainBlock provides access to the email domain block database cache.
+	EmailDomainBlock *domain.Cache
+
 	// Emoji provides access to the gtsmodel Emoji database cache.
 	Emoji StructCache[*gtsmodel.Emoji]
 
@@ -264,6 +273,9 @@ type DBCaches struct {
 	// UserMuteIDs provides access to the user mute IDs database cache.
 	UserMuteIDs SliceCache[string]
 
+	// UserDomainBlocks provides access to the user blocked domains database cache.
+	UserDomainBlocks SliceCache[string]
+
 	// VAPIDKeyPair caches the server's VAPID key pair.
 	VAPIDKeyPair atomic.Pointer[gtsmodel.VAPIDKeyPair]
 
@@ -315,6 +327,7 @@ func (c *Caches) initAccount() {
 			{Fields: "ID"},
 			{Fields: "URI"},
 			{Fields: "PublicKeyURI"},
+			{Fields: "Ed25519PublicKeyURI"},
 			{Fields: "Username,Domain", AllowZero: true},
 		},
 		MaxSize:    cap,
@@ -489,6 +502,39 @@ func (c *Caches) initBoostOfIDs() {
 	c.DB.BoostOfIDs.Init(0, cap)
 }
 
+func (c *Caches) initCard() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofCard(), // model in-mem size.
+		config.GetCacheCardMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(c1 *gtsmodel.Card) *gtsmodel.Card {
+		c2 := new(gtsmodel.Card)
+		*c2 = *c1
+
+		// Don't include ptr fields that
+		// will be populated separately.
+		// See internal/db/bundb/card.go.
+		c2.Image = nil
+
+		return c2
+	}
+
+	c.DB.Card.Init(structr.CacheConfig[*gtsmodel.Card]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+			{Fields: "URL"},
+			{Fields: "ImageID"},
+		},
+		MaxSize:   cap,
+		IgnoreErr: ignoreErrors,
+		Copy:      copyF,
+	})
+}
+
 func (c *Caches) initConversation() {
 	cap := calculateResultCacheMax(
 		sizeofConversation(), // model in-mem size.
@@ -543,6 +589,10 @@ func (c *Caches) initDomainBlock() {
 	c.DB.DomainBlock = new(domain.Cache)
 }
 
+func (c *Caches) initDomainLimit() {
+	c.DB.DomainLimit = new(domain.Cache)
+}
+
 func (c *Caches) initDomainPermissionDraft() {
 	// Calculate maximum cache size.
 	cap := calculateResultCacheMax(
@@ -610,6 +660,10 @@ func (c *Caches) initDomainPermissionExclude() {
 	c.DB.DomainPermissionExclude = new(domain.Cache)
 }
 
+func (c *Caches) initEmailDomainBlock() {
+	c.DB.EmailDomainBlock = new(domain.Cache)
+}
+
 func (c *Caches) initEmoji() {
 	// Calculate maximum cache size.
 	cap := calculateResultCacheMax(
@@ -1373,7 +1427,10 @@ func (c *Caches) initStatus() {
 		s2.InReplyToAccount = nil
 		s2.BoostOf = nil
 		s2.BoostOfAccount = nil
+		s2.QuoteOf = nil
+		s2.QuoteOfAccount = nil
 		s2.Poll = nil
+		s2.Card = nil
 		s2.Attachments = nil
 		s2.Tags = nil
 		s2.Mentions = nil
@@ -1719,6 +1776,16 @@ func (c *Caches) initUserMuteIDs() {
 	c.DB.UserMuteIDs.Init(0, cap)
 }
 
+func (c *Caches) initUserDomainBlocks() {
+	cap := calculateSliceCacheMax(
+		config.GetCacheUserDomainBlocksMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	c.DB.UserDomainBlocks.Init(0, cap)
+}
+
 func (c *Caches) initWebPushSubscription() {
 	cap := calculateResultCacheMax(
 		sizeofWebPushSubscription(), // model in-mem size.
@@ -0,0 +1,119 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ipblock
+
+import (
+	"fmt"
+	"net/netip"
+	"sync/atomic"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+)
+
+// Cache provides a means of caching IP blocks in
+// memory to reduce load on an underlying storage
+// mechanism, e.g. a database. This is important as
+// the blocks are checked on every incoming request.
+type Cache struct {
+	// current cached ip blocks slice.
+	ptr atomic.Pointer[[]entry]
+}
+
+// entry is a cached
+// IP block with its
+// pre-parsed prefix.
+type entry struct {
+	prefix netip.Prefix
+	block  *gtsmodel.IPBlock
+}
+
+// Match returns the most severe, unexpired IP block that contains the
+// given address, or nil if there is none. If the cache is not currently
+// loaded, then the provided load function is used to hydrate it.
+func (c *Cache) Match(addr netip.Addr, load func() ([]*gtsmodel.IPBlock, error)) (*gtsmodel.IPBlock, error) {
+	// Load ptr value.
+	ptr := c.ptr.Load()
+
+	if ptr == nil {
+		// Cache is not hydrated.
+		// Load blocks from callback.
+		entries, err := loadEntries(load)
+		if err != nil {
+			return nil, err
+		}
+
+		// Store the new
+		// ip block entries.
+		ptr = &entries
+		c.ptr.Store(ptr)
+	}
+
+	// Unmap any IPv4-mapped
+	// IPv6 addresses so they
+	// match IPv4 prefixes.
+	addr = addr.Unmap()
+
+	var (
+		now   = time.Now()
+		match *gtsmodel.IPBlock
+	)
+
+	for _, e := range *ptr {
+		if !e.prefix.Contains(addr) ||
+			e.block.Expired(now) {
+			continue
+		}
+
+		if match == nil || e.block.Severity > match.Severity {
+			match = e.block
+		}
+	}
+
+	return match, nil
+}
+
+// Clear will drop the currently loaded blocks,
+// triggering a reload on next call to .Match().
+func (c *Cache) Clear() { c.ptr.Store(nil) }
+
+// loadEntries will load blocks from given load callback, parsing their IP ranges.
+func loadEntries(load func() ([]*gtsmodel.IPBlock, error)) ([]entry, error) {
+	// Load blocks from callback.
+	blocks, err := load()
+	if err != nil {
+		return nil, fmt.Errorf("error reloading cache: %w", err)
+	}
+
+	// Allocate new entry slice to store parsed blocks.
+	entries := make([]entry, 0, len(blocks))
+
+	for _, block := range blocks {
+		prefix, err := block.Prefix()
+		if err != nil {
+			return nil, fmt.Errorf("error parsing ip block %s: %w", block.ID, err)
+		}
+
+		entries = append(entries, entry{
+			prefix: prefix.Masked(),
+			block:  block,
+		})
+	}
+
+	return entries, nil
+}
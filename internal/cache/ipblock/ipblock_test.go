@@ -0,0 +1,72 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ipblock_test
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/cache/ipblock"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+)
+
+func TestCache(t *testing.T) {
+	c := new(ipblock.Cache)
+
+	cachedBlocks := []*gtsmodel.IPBlock{
+		{ID: "approval", IP: "192.0.2.0/24", Severity: gtsmodel.IPBlockSeveritySignUpRequiresApproval},
+		{ID: "signup", IP: "192.0.2.128/25", Severity: gtsmodel.IPBlockSeveritySignUpBlock},
+		{ID: "expired", IP: "198.51.100.0/24", Severity: gtsmodel.IPBlockSeverityNoAccess, ExpiresAt: time.Now().Add(-time.Hour)},
+		{ID: "noaccess", IP: "2001:db8::/32", Severity: gtsmodel.IPBlockSeverityNoAccess},
+	}
+
+	loader := func() ([]*gtsmodel.IPBlock, error) {
+		t.Log("load: returning cached ip blocks")
+		return cachedBlocks, nil
+	}
+
+	for _, test := range []struct {
+		addr   string
+		expect string
+	}{
+		{"192.0.2.1", "approval"},
+		{"192.0.2.200", "signup"},        // most severe of both matches
+		{"::ffff:192.0.2.200", "signup"}, // ipv4-mapped ipv6
+		{"198.51.100.1", ""},             // expired
+		{"2001:db8:1234::1", "noaccess"}, //
+		{"203.0.113.1", ""},              // not blocked
+		{"2001:db9::1", ""},              // not blocked
+	} {
+		t.Logf("checking address: %s", test.addr)
+
+		block, err := c.Match(netip.MustParseAddr(test.addr), loader)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var id string
+		if block != nil {
+			id = block.ID
+		}
+
+		if id != test.expect {
+			t.Fatalf("expected match %q for %s, got %q", test.expect, test.addr, id)
+		}
+	}
+}
@@ -18,6 +18,7 @@
 package cache
 
 import (
+	"crypto/ed25519"
 	"crypto/rsa"
 	"regexp"
 	"strings"
@@ -189,6 +190,7 @@ func sizeofAccount() uintptr {
 		FetchedAt:               exampleTime,
 		Locked:                  util.Ptr(true),
 		Discoverable:            util.Ptr(false),
+		Indexable:               util.Ptr(false),
 		URI:                     exampleURI,
 		URL:                     exampleURI,
 		InboxURI:                exampleURI,
@@ -196,10 +198,13 @@ func sizeofAccount() uintptr {
 		FollowersURI:            exampleURI,
 		FollowingURI:            exampleURI,
 		FeaturedCollectionURI:   exampleURI,
+		FeaturedTagsURI:         exampleURI,
 		ActorType:               gtsmodel.AccountActorTypePerson,
 		PrivateKey:              &rsa.PrivateKey{},
 		PublicKey:               &rsa.PublicKey{},
 		PublicKeyURI:            exampleURI,
+		Ed25519PublicKey:        make(ed25519.PublicKey, ed25519.PublicKeySize),
+		Ed25519PublicKeyURI:     exampleURI,
 		SensitizedAt:            exampleTime,
 		SilencedAt:              exampleTime,
 		SuspendedAt:             exampleTime,
@@ -252,6 +257,7 @@ func sizeofApplication() uintptr {
 		ClientSecret:    exampleID,
 		Scopes:          exampleTextSmall,
 		ManagedByUserID: exampleID,
+		Public:          util.Ptr(false),
 	}))
 }
 
@@ -266,6 +272,24 @@ func sizeofBlock() uintptr {
 	}))
 }
 
+func sizeofCard() uintptr {
+	return uintptr(size.Of(&gtsmodel.Card{
+		ID:           exampleID,
+		CreatedAt:    exampleTime,
+		UpdatedAt:    exampleTime,
+		FetchedAt:    exampleTime,
+		URL:          exampleURI,
+		Type:         gtsmodel.CardTypeLink,
+		Title:        exampleUsername,
+		Description:  exampleText,
+		ProviderName: exampleUsername,
+		ProviderURL:  exampleURI,
+		Width:        1280,
+		Height:       720,
+		ImageID:      exampleID,
+	}))
+}
+
 func sizeofConversation() uintptr {
 	return uintptr(size.Of(&gtsmodel.Conversation{
 		ID:               exampleID,
@@ -514,6 +538,7 @@ func sizeofNotification() uintptr {
 		OriginAccountID:  exampleID,
 		StatusOrEditID:   exampleID,
 		Read:             func() *bool { ok := false; return &ok }(),
+		Filtered:         func() *bool { ok := false; return &ok }(),
 	}))
 }
 
@@ -620,6 +645,9 @@ func sizeofStatus() uintptr {
 		InReplyToAccountID:       exampleID,
 		BoostOfID:                exampleID,
 		BoostOfAccountID:         exampleID,
+		QuoteOfID:                exampleID,
+		QuoteOfURI:               exampleURI,
+		QuoteOfAccountID:         exampleID,
 		ContentWarning:           exampleUsername, // similar length
 		ContentWarningText:       exampleUsername, // similar length
 		Visibility:               gtsmodel.VisibilityPublic,
@@ -717,15 +745,15 @@ func sizeofToken() uintptr {
 		UserID:              exampleID,
 		RedirectURI:         exampleURI,
 		Scope:               "r:w",
-		Code:                "", // TODO
-		CodeChallenge:       "", // TODO
-		CodeChallengeMethod: "", // TODO
+		Code:                "", // only set on short-lived
+		CodeChallenge:       "", // authorization code tokens,
+		CodeChallengeMethod: "", // not on access tokens.
 		CodeCreateAt:        exampleTime,
 		CodeExpiresAt:       exampleTime,
 		Access:              exampleID + exampleID,
 		AccessCreateAt:      exampleTime,
 		AccessExpiresAt:     exampleTime,
-		Refresh:             "", // TODO: clients don't really support this very well yet
+		Refresh:             exampleID + exampleID,
 		RefreshCreateAt:     exampleTime,
 		RefreshExpiresAt:    exampleTime,
 	}))
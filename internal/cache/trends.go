@@ -0,0 +1,171 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cache
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+)
+
+// TrendsCache provides access to the most recently
+// calculated trending tags, statuses and links, as
+// (re)calculated periodically by the trends job.
+//
+// Slices returned from this cache MUST be treated
+// as read-only, they are swapped out wholesale on
+// update rather than being modified in place.
+type TrendsCache struct {
+	tags     atomic.Pointer[[]*CachedTrend]
+	statuses atomic.Pointer[[]*CachedTrend]
+	links    atomic.Pointer[[]*CachedTrend]
+
+	// mutex protects
+	// read-modify-writes.
+	mutex sync.Mutex
+}
+
+// CachedTrend represents one
+// calculated, cached trend.
+type CachedTrend struct {
+
+	// TargetID is the tag ID, status
+	// ID, or link URL of trending item.
+	TargetID string
+
+	// ReviewID is the ID of the trend
+	// review model for trending item.
+	ReviewID string
+
+	// State is the review
+	// state of this trend.
+	State gtsmodel.TrendReviewState
+
+	// Score is the time-decayed
+	// trending score of this item,
+	// by which trends are sorted.
+	Score float64
+
+	// History contains daily usage
+	// of the trending item, newest
+	// day first. Empty for statuses.
+	History []TrendHistory
+}
+
+// TrendHistory contains usage
+// of a trending item on one day.
+type TrendHistory struct {
+
+	// Day is midnight (UTC)
+	// on the day in question.
+	Day time.Time
+
+	// Uses is the count of uses
+	// of the item on this day.
+	Uses int
+
+	// Accounts is the count of
+	// distinct accounts using
+	// the item on this day.
+	Accounts int
+}
+
+// Get returns the current calculated trends of given type, ordered by
+// score, highest first. Approved will limit returned trends to those
+// approved by an admin, else all trends will be returned.
+func (c *TrendsCache) Get(trendType gtsmodel.TrendType, approved bool) []*CachedTrend {
+	ptr := c.pointer(trendType).Load()
+	if ptr == nil {
+		return nil
+	}
+
+	if !approved {
+		return *ptr
+	}
+
+	trends := make([]*CachedTrend, 0, len(*ptr))
+	for _, trend := range *ptr {
+		if trend.State == gtsmodel.TrendReviewStateApproved {
+			trends = append(trends, trend)
+		}
+	}
+
+	return trends
+}
+
+// Set replaces the current calculated trends of given
+// type. Trends are expected to be ordered by score.
+func (c *TrendsCache) Set(trendType gtsmodel.TrendType, trends []*CachedTrend) {
+	c.mutex.Lock()
+	c.pointer(trendType).Store(&trends)
+	c.mutex.Unlock()
+}
+
+// SetState updates the review state of any currently
+// cached trend of given type, with given review ID.
+func (c *TrendsCache) SetState(trendType gtsmodel.TrendType, reviewID string, state gtsmodel.TrendReviewState) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	ptr := c.pointer(trendType)
+	current := ptr.Load()
+	if current == nil {
+		return
+	}
+
+	// Copy-on-write so readers
+	// of current are unaffected.
+	trends := make([]*CachedTrend, len(*current))
+	for i, trend := range *current {
+		if trend.ReviewID == reviewID {
+			trend2 := new(CachedTrend)
+			*trend2 = *trend
+			trend2.State = state
+			trend = trend2
+		}
+		trends[i] = trend
+	}
+
+	ptr.Store(&trends)
+}
+
+// Clear will drop all currently cached trends.
+func (c *TrendsCache) Clear() {
+	c.mutex.Lock()
+	c.tags.Store(nil)
+	c.statuses.Store(nil)
+	c.links.Store(nil)
+	c.mutex.Unlock()
+}
+
+// pointer returns the relevant atomic
+// pointer for given trend type.
+func (c *TrendsCache) pointer(trendType gtsmodel.TrendType) *atomic.Pointer[[]*CachedTrend] {
+	switch trendType {
+	case gtsmodel.TrendTypeTag:
+		return &c.tags
+	case gtsmodel.TrendTypeStatus:
+		return &c.statuses
+	case gtsmodel.TrendTypeLink:
+		return &c.links
+	default:
+		panic("undefined TrendType")
+	}
+}
@@ -0,0 +1,317 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cards
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/media"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"code.superseriousbusiness.org/gotosocial/internal/transport"
+	"code.superseriousbusiness.org/gotosocial/internal/trends"
+	"codeberg.org/gruf/go-mutexes"
+)
+
+// refreshAfter is the age after which a stored
+// card will be refetched, when it's next linked to.
+const refreshAfter = 7 * 24 * time.Hour
+
+// Fetcher wraps functionality for fetching
+// link preview cards for statuses, storing
+// them in the database, and caching their
+// thumbnails via the media manager.
+type Fetcher struct {
+	state        *state.State
+	transport    transport.Controller
+	mediaManager *media.Manager
+
+	// per-URL fetch locks,
+	// to deduplicate fetches.
+	locks mutexes.MutexMap
+}
+
+// New returns a new card fetcher, using
+// the given transport controller to fetch
+// remote pages (and therefore the wrapped
+// httpclient with its IP range sanitizer).
+func New(
+	state *state.State,
+	transport transport.Controller,
+	mediaManager *media.Manager,
+) *Fetcher {
+	return &Fetcher{
+		state:        state,
+		transport:    transport,
+		mediaManager: mediaManager,
+	}
+}
+
+// FetchForStatus returns a link preview card for the
+// first eligible link in the given status' content,
+// fetching it if not yet stored (or out-of-date).
+//
+// A nil card (and nil error) is returned if preview
+// cards are disabled, the status has no eligible
+// link, or no useful metadata could be found at it.
+func (f *Fetcher) FetchForStatus(ctx context.Context, status *gtsmodel.Status) (*gtsmodel.Card, error) {
+	if !config.GetMediaPreviewCardsEnabled() {
+		return nil, nil
+	}
+
+	// Statuses with media attachments or polls
+	// show those instead, like Mastodon does.
+	if len(status.AttachmentIDs) > 0 || status.PollID != "" {
+		return nil, nil
+	}
+
+	for _, link := range trends.ExtractLinks(status.Content) {
+		u, err := url.Parse(link)
+		if err != nil {
+			continue
+		}
+
+		blocked, err := f.isBlocked(ctx, u)
+		if err != nil {
+			return nil, err
+		}
+
+		if blocked {
+			continue
+		}
+
+		// Only the first
+		// link gets a card.
+		return f.GetCard(ctx, u)
+	}
+
+	return nil, nil
+}
+
+// GetCard returns the link preview card for the given
+// (normalized) URL. If the card is already stored and
+// up-to-date it is returned directly, else metadata
+// is (re)fetched from the URL. Concurrent calls for
+// the same URL are deduplicated.
+func (f *Fetcher) GetCard(ctx context.Context, u *url.URL) (*gtsmodel.Card, error) {
+	link := u.String()
+
+	// Acquire per-URL lock.
+	unlock := f.locks.Lock(link)
+	defer unlock()
+
+	// Look for an existing card for this URL.
+	card, err := f.state.DB.GetCardByURL(ctx, link)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting card %s: %w", link, err)
+	}
+
+	if card != nil && time.Since(card.FetchedAt) < refreshAfter {
+		// Card is up-to-date.
+		return card, nil
+	}
+
+	// Fetch transport for the instance account.
+	tsport, err := f.transport.NewTransportForUsername(ctx, "")
+	if err != nil {
+		return nil, gtserror.Newf("error getting instance transport: %w", err)
+	}
+
+	meta, err := f.fetchMetadata(ctx, tsport, u)
+	if err != nil {
+		if card != nil {
+			// Keep using the
+			// stale card for now.
+			log.Warnf(ctx, "error refreshing card %s: %v", link, err)
+			return card, nil
+		}
+		return nil, err
+	}
+
+	if meta == nil || meta.Title == "" {
+		// Nothing useful
+		// to show here.
+		return card, nil
+	}
+
+	var cols []string
+	if card == nil {
+		// Prepare new card for URL.
+		card = &gtsmodel.Card{
+			ID:  id.NewULID(),
+			URL: link,
+		}
+	} else {
+		// Only update columns we set.
+		cols = []string{
+			"fetched_at",
+			"type",
+			"title",
+			"description",
+			"author_name",
+			"author_url",
+			"provider_name",
+			"provider_url",
+			"embed_url",
+			"width",
+			"height",
+			"image_id",
+		}
+	}
+
+	// Set latest metadata on card.
+	card.FetchedAt = time.Now()
+	card.Type = meta.Type
+	card.Title = truncate(meta.Title, maxTitleBytes)
+	card.Description = truncate(meta.Description, maxDescriptionBytes)
+	card.AuthorName = meta.AuthorName
+	card.AuthorURL = meta.AuthorURL
+	card.ProviderName = meta.ProviderName
+	card.ProviderURL = meta.ProviderURL
+	card.EmbedURL = meta.EmbedURL
+	card.Width = meta.Width
+	card.Height = meta.Height
+
+	// Cache any card thumbnail, logging
+	// errors as the card is still usable.
+	if err := f.cacheImage(ctx, tsport, card, meta.Image); err != nil {
+		log.Warnf(ctx, "error caching card %s image: %v", link, err)
+	}
+
+	if cols == nil {
+		err = f.state.DB.PutCard(ctx, card)
+	} else {
+		err = f.state.DB.UpdateCard(ctx, card, cols...)
+	}
+
+	if err != nil {
+		return nil, gtserror.Newf("db error storing card %s: %w", link, err)
+	}
+
+	return card, nil
+}
+
+// cacheImage ensures the card's thumbnail media
+// is cached from the given image URL, reusing
+// the existing media if the URL is unchanged.
+func (f *Fetcher) cacheImage(
+	ctx context.Context,
+	tsport transport.Transport,
+	card *gtsmodel.Card,
+	imageURL string,
+) error {
+	if imageURL == "" {
+		// No thumbnail.
+		card.ImageID = ""
+		card.Image = nil
+		return nil
+	}
+
+	u, err := url.Parse(imageURL)
+	if err != nil {
+		return gtserror.Newf("invalid image url %s: %w", imageURL, err)
+	}
+
+	if blocked, err := f.isBlocked(ctx, u); err != nil {
+		return err
+	} else if blocked {
+		return nil
+	}
+
+	// Get maximum supported remote media size.
+	maxsz := int64(config.GetMediaRemoteMaxSize()) // #nosec G115 -- Already validated.
+
+	data := func(ctx context.Context) (io.ReadCloser, error) {
+		return tsport.DereferenceMedia(ctx, u, maxsz)
+	}
+
+	var processing *media.ProcessingMedia
+
+	if card.Image != nil && card.Image.RemoteURL == imageURL {
+		if *card.Image.Cached {
+			// Already cached.
+			return nil
+		}
+
+		// Same image, just recache it.
+		processing = f.mediaManager.CacheMedia(card.Image, data)
+	} else {
+		// Card thumbnails are owned
+		// by the instance account.
+		instanceAcc, err := f.state.DB.GetInstanceAccount(ctx, "")
+		if err != nil {
+			return gtserror.Newf("db error getting instance account: %w", err)
+		}
+
+		// New image, create new media for it. Old
+		// media will be pruned by the media cleaner.
+		processing, err = f.mediaManager.CreateMedia(ctx,
+			instanceAcc.ID,
+			data,
+			media.AdditionalMediaInfo{
+				RemoteURL:   &imageURL,
+				Description: &card.Title,
+			},
+		)
+		if err != nil {
+			return gtserror.Newf("error creating media: %w", err)
+		}
+	}
+
+	// Set card image, even if loading
+	// fails, as it acts as placeholder.
+	image, err := processing.Load(ctx)
+	card.ImageID = processing.ID()
+	card.Image = image
+	return err
+}
+
+// isBlocked returns whether fetching card
+// data from the given URL is blocked, either
+// via configured blocked preview card domains,
+// or via a domain block on the URL host.
+func (f *Fetcher) isBlocked(ctx context.Context, u *url.URL) (bool, error) {
+	host := strings.ToLower(u.Hostname())
+	if host == "" {
+		return true, nil
+	}
+
+	for _, domain := range config.GetMediaPreviewCardsBlockedDomains() {
+		domain = strings.ToLower(domain)
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true, nil
+		}
+	}
+
+	blocked, err := f.state.DB.IsDomainBlocked(ctx, host)
+	if err != nil {
+		return false, gtserror.Newf("db error checking domain block %s: %w", host, err)
+	}
+
+	return blocked, nil
+}
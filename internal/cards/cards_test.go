@@ -0,0 +1,261 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cards_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"code.superseriousbusiness.org/gotosocial/internal/cards"
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/media"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"code.superseriousbusiness.org/gotosocial/testrig"
+	"github.com/stretchr/testify/suite"
+)
+
+const testPage = `<!DOCTYPE html>
+<html>
+<head>
+<title>Fallback title</title>
+<meta name="description" content="Fallback description">
+<meta property="og:title" content="Is Water Wet?">
+<meta property="og:description" content="We asked an expert.">
+<meta property="og:site_name" content="Example News">
+<link rel="alternate" type="application/json+oembed" href="/oembed?url=article">
+</head>
+<body><p>Some article text.</p></body>
+</html>`
+
+const testOEmbed = `{
+	"type": "link",
+	"version": "1.0",
+	"author_name": "Some Expert",
+	"author_url": "https://example.org/authors/expert",
+	"provider_url": "https://example.org"
+}`
+
+const testUnsafeOEmbed = `{
+	"type": "link",
+	"version": "1.0",
+	"author_name": "Some Expert",
+	"author_url": "javascript:alert(1)",
+	"provider_name": "Example News",
+	"provider_url": "data:text/html,hello"
+}`
+
+type CardsTestSuite struct {
+	state   state.State
+	fetcher *cards.Fetcher
+
+	// number of http requests made.
+	requests atomic.Int32
+
+	suite.Suite
+}
+
+func TestCardsTestSuite(t *testing.T) {
+	suite.Run(t, &CardsTestSuite{})
+}
+
+func (suite *CardsTestSuite) SetupSuite() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+}
+
+func (suite *CardsTestSuite) SetupTest() {
+	config.SetMediaPreviewCardsEnabled(true)
+	config.SetMediaPreviewCardsBlockedDomains(nil)
+
+	suite.state.Caches.Init()
+	testrig.StartNoopWorkers(&suite.state)
+
+	_ = testrig.NewTestDB(&suite.state)
+	testrig.StandardDBSetup(suite.state.DB, nil)
+
+	suite.requests.Store(0)
+	client := testrig.NewMockHTTPClient(suite.do, "")
+	suite.fetcher = cards.New(
+		&suite.state,
+		testrig.NewTestTransportController(&suite.state, client),
+		media.NewManager(&suite.state),
+	)
+}
+
+func (suite *CardsTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.state.DB)
+	testrig.StopWorkers(&suite.state)
+}
+
+// do serves the test page and
+// oEmbed data, counting requests.
+func (suite *CardsTestSuite) do(req *http.Request) (*http.Response, error) {
+	suite.requests.Add(1)
+
+	var (
+		code        = http.StatusNotFound
+		body        = "not found"
+		contentType = "text/plain"
+	)
+
+	switch req.URL.String() {
+	case "https://example.org/article", "https://news.example.org/article":
+		code = http.StatusOK
+		body = testPage
+		contentType = "text/html; charset=utf-8"
+	case "https://example.org/oembed?url=article":
+		code = http.StatusOK
+		body = testOEmbed
+		contentType = "application/json"
+	case "https://example.org/unsafe":
+		code = http.StatusOK
+		body = strings.Replace(testPage, "url=article", "url=unsafe", 1)
+		contentType = "text/html; charset=utf-8"
+	case "https://example.org/oembed?url=unsafe":
+		code = http.StatusOK
+		body = testUnsafeOEmbed
+		contentType = "application/json"
+	}
+
+	return &http.Response{
+		Request:       req,
+		StatusCode:    code,
+		Status:        http.StatusText(code),
+		Header:        http.Header{"Content-Type": {contentType}},
+		Body:          io.NopCloser(bytes.NewReader([]byte(body))),
+		ContentLength: int64(len(body)),
+	}, nil
+}
+
+func (suite *CardsTestSuite) status(content string) *gtsmodel.Status {
+	return &gtsmodel.Status{
+		ID:      "01F8MHAMCHF6Y650WCRSCP4WMY",
+		Content: content,
+	}
+}
+
+func (suite *CardsTestSuite) TestFetchForStatus() {
+	ctx := suite.T().Context()
+
+	status := suite.status(`<p>hey <span class="h-card"><a href="http://localhost:8080/@the_mighty_zork" class="u-url mention">@<span>the_mighty_zork</span></a></span> look at this: <a href="https://example.org/article" rel="nofollow noreferrer noopener" target="_blank">https://example.org/article</a></p>`)
+
+	card, err := suite.fetcher.FetchForStatus(ctx, status)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.NotNil(card)
+	suite.Equal("https://example.org/article", card.URL)
+	suite.Equal(gtsmodel.CardTypeLink, card.Type)
+	suite.Equal("Is Water Wet?", card.Title)
+	suite.Equal("We asked an expert.", card.Description)
+	suite.Equal("Example News", card.ProviderName)
+	suite.Equal("Some Expert", card.AuthorName)
+	suite.Equal("https://example.org/authors/expert", card.AuthorURL)
+	suite.Empty(card.ImageID)
+	suite.False(card.FetchedAt.IsZero())
+
+	// Page + oEmbed.
+	suite.EqualValues(2, suite.requests.Load())
+
+	// Card should be stored.
+	dbCard, err := suite.state.DB.GetCardByURL(ctx, card.URL)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(card.ID, dbCard.ID)
+
+	// Fetching for another status linking to the same
+	// URL should reuse the card without any new requests.
+	card2, err := suite.fetcher.FetchForStatus(ctx, suite.status(
+		`<p><a href="https://example.org/article#comments">https://example.org/article#comments</a></p>`,
+	))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(card.ID, card2.ID)
+	suite.EqualValues(2, suite.requests.Load())
+}
+
+func (suite *CardsTestSuite) TestFetchForStatusUnsafeOEmbedURLs() {
+	ctx := suite.T().Context()
+
+	status := suite.status(`<p><a href="https://example.org/unsafe">https://example.org/unsafe</a></p>`)
+
+	card, err := suite.fetcher.FetchForStatus(ctx, status)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Names are kept, but non-http(s)
+	// URLs from oEmbed are dropped.
+	suite.NotNil(card)
+	suite.Equal("Some Expert", card.AuthorName)
+	suite.Empty(card.AuthorURL)
+	suite.Equal("Example News", card.ProviderName)
+	suite.Empty(card.ProviderURL)
+}
+
+func (suite *CardsTestSuite) TestFetchForStatusNoLinks() {
+	card, err := suite.fetcher.FetchForStatus(
+		suite.T().Context(),
+		suite.status(`<p>hello <a href="http://localhost:8080/tags/welcome" class="mention hashtag" rel="tag">#<span>welcome</span></a></p>`),
+	)
+	suite.NoError(err)
+	suite.Nil(card)
+	suite.Zero(suite.requests.Load())
+}
+
+func (suite *CardsTestSuite) TestFetchForStatusNoMetadata() {
+	card, err := suite.fetcher.FetchForStatus(
+		suite.T().Context(),
+		suite.status(`<p><a href="https://example.org/missing">https://example.org/missing</a></p>`),
+	)
+	suite.Error(err)
+	suite.Nil(card)
+}
+
+func (suite *CardsTestSuite) TestFetchForStatusBlockedDomain() {
+	config.SetMediaPreviewCardsBlockedDomains([]string{"example.org"})
+
+	card, err := suite.fetcher.FetchForStatus(
+		suite.T().Context(),
+		suite.status(`<p><a href="https://news.example.org/article">https://news.example.org/article</a></p>`),
+	)
+	suite.NoError(err)
+	suite.Nil(card)
+	suite.Zero(suite.requests.Load())
+}
+
+func (suite *CardsTestSuite) TestFetchForStatusDisabled() {
+	config.SetMediaPreviewCardsEnabled(false)
+
+	card, err := suite.fetcher.FetchForStatus(
+		suite.T().Context(),
+		suite.status(`<p><a href="https://example.org/article">https://example.org/article</a></p>`),
+	)
+	suite.NoError(err)
+	suite.Nil(card)
+	suite.Zero(suite.requests.Load())
+}
@@ -0,0 +1,366 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cards
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/text"
+	"code.superseriousbusiness.org/gotosocial/internal/transport"
+	"codeberg.org/gruf/go-bytesize"
+	"codeberg.org/gruf/go-iotools"
+	"golang.org/x/net/html"
+)
+
+const (
+	// maxBodySize is the maximum number of bytes
+	// read from a linked page or oEmbed response.
+	maxBodySize = int64(1 * bytesize.MiB)
+
+	// maximum stored lengths of card text fields.
+	maxTitleBytes       = 256
+	maxDescriptionBytes = 1024
+)
+
+// metadata is the link preview metadata
+// parsed from a page and its oEmbed data.
+type metadata struct {
+	Type         gtsmodel.CardType
+	Title        string
+	Description  string
+	AuthorName   string
+	AuthorURL    string
+	ProviderName string
+	ProviderURL  string
+	EmbedURL     string
+	Width        int
+	Height       int
+	Image        string
+
+	// oEmbed discovery link.
+	oEmbedURL string
+}
+
+// oEmbed models the subset of an oEmbed
+// JSON response that is used for cards.
+//
+// See: https://oembed.com/#section2.3
+type oEmbed struct {
+	Type         string      `json:"type"`
+	Title        string      `json:"title"`
+	AuthorName   string      `json:"author_name"`
+	AuthorURL    string      `json:"author_url"`
+	ProviderName string      `json:"provider_name"`
+	ProviderURL  string      `json:"provider_url"`
+	URL          string      `json:"url"`
+	ThumbnailURL string      `json:"thumbnail_url"`
+	Width        json.Number `json:"width"`
+	Height       json.Number `json:"height"`
+}
+
+// fetchMetadata fetches the page at given URL, and
+// parses link preview metadata from its OpenGraph
+// tags, supplemented by any discovered oEmbed data.
+// Returns nil metadata if the page is not HTML.
+func (f *Fetcher) fetchMetadata(
+	ctx context.Context,
+	tsport transport.Transport,
+	u *url.URL,
+) (*metadata, error) {
+	body, contentType, err := get(ctx, tsport, u,
+		"text/html,application/xhtml+xml",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	if contentType != "text/html" &&
+		contentType != "application/xhtml+xml" {
+		// Not a page,
+		// no metadata.
+		return nil, nil
+	}
+
+	meta := parseHTML(body, u)
+	if meta.oEmbedURL == "" {
+		return meta, nil
+	}
+
+	oURL, err := url.Parse(meta.oEmbedURL)
+	if err != nil {
+		return meta, nil
+	}
+
+	if blocked, err := f.isBlocked(ctx, oURL); err != nil {
+		return nil, err
+	} else if blocked {
+		return meta, nil
+	}
+
+	// Supplement metadata with oEmbed data,
+	// just logging errors as we have OpenGraph.
+	if err := fetchOEmbed(ctx, tsport, oURL, meta); err != nil {
+		log.Debugf(ctx, "error fetching oembed %s: %v", oURL, err)
+	}
+
+	return meta, nil
+}
+
+// fetchOEmbed fetches the oEmbed JSON at given URL,
+// and uses it to fill in or override given metadata.
+//
+// Note that oEmbed "html" is intentionally not used,
+// as embedding third-party HTML in clients is unsafe.
+func fetchOEmbed(
+	ctx context.Context,
+	tsport transport.Transport,
+	u *url.URL,
+	meta *metadata,
+) error {
+	body, _, err := get(ctx, tsport, u, "application/json")
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	var o oEmbed
+	if err := json.NewDecoder(body).Decode(&o); err != nil {
+		return gtserror.Newf("error decoding oembed: %w", err)
+	}
+
+	if o.Title != "" {
+		meta.Title = o.Title
+	}
+
+	if o.AuthorName != "" {
+		meta.AuthorName = o.AuthorName
+		meta.AuthorURL = ""
+		if o.AuthorURL != "" {
+			meta.AuthorURL = resolve(u, o.AuthorURL)
+		}
+	}
+
+	if o.ProviderName != "" {
+		meta.ProviderName = o.ProviderName
+		meta.ProviderURL = ""
+		if o.ProviderURL != "" {
+			meta.ProviderURL = resolve(u, o.ProviderURL)
+		}
+	}
+
+	if meta.Image == "" && o.ThumbnailURL != "" {
+		meta.Image = resolve(u, o.ThumbnailURL)
+	}
+
+	if w, err := o.Width.Int64(); err == nil {
+		meta.Width = int(w)
+	}
+
+	if h, err := o.Height.Int64(); err == nil {
+		meta.Height = int(h)
+	}
+
+	if o.Type == "photo" && o.URL != "" {
+		meta.Type = gtsmodel.CardTypePhoto
+		meta.EmbedURL = resolve(u, o.URL)
+	}
+
+	return nil
+}
+
+// get performs a GET request at given URL, returning
+// the size-limited response body and media type.
+func get(
+	ctx context.Context,
+	tsport transport.Transport,
+	u *url.URL,
+	accept string,
+) (io.ReadCloser, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Accept", accept)
+
+	rsp, err := tsport.GET(req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if rsp.StatusCode != http.StatusOK {
+		return nil, "", gtserror.NewFromResponse(rsp)
+	}
+
+	// Parse media type, ignoring params (e.g. charset).
+	contentType, _, _ := mime.ParseMediaType(rsp.Header.Get("Content-Type"))
+
+	// Limit response body to a sensible maximum size.
+	rsp.Body, _, _ = iotools.UpdateReadCloserLimit(rsp.Body, maxBodySize)
+
+	return rsp.Body, contentType, nil
+}
+
+// parseHTML parses link preview metadata from the
+// OpenGraph tags in given page HTML, falling back
+// to standard title and description elements.
+func parseHTML(r io.Reader, pageURL *url.URL) *metadata {
+	var (
+		meta      = &metadata{Type: gtsmodel.CardTypeLink}
+		title     string
+		desc      string
+		inTitle   bool
+		tokenizer = html.NewTokenizer(r)
+	)
+
+loop:
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			// End of page.
+			break loop
+
+		case html.TextToken:
+			if inTitle {
+				title += string(tokenizer.Text())
+			}
+
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			switch string(name) {
+			case "title":
+				inTitle = false
+			case "head":
+				// Everything we
+				// need is in head.
+				break loop
+			}
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			switch token.Data {
+			case "title":
+				inTitle = (title == "")
+
+			case "body":
+				// Past the head.
+				break loop
+
+			case "meta":
+				parseMeta(meta, &desc, token.Attr, pageURL)
+
+			case "link":
+				attrs := attrMap(token.Attr)
+				if attrs["rel"] == "alternate" &&
+					attrs["type"] == "application/json+oembed" &&
+					attrs["href"] != "" {
+					meta.oEmbedURL = resolve(pageURL, attrs["href"])
+				}
+			}
+		}
+	}
+
+	// Fall back to standard
+	// title and description.
+	if meta.Title == "" {
+		meta.Title = strings.TrimSpace(title)
+	}
+	if meta.Description == "" {
+		meta.Description = desc
+	}
+
+	return meta
+}
+
+// parseMeta parses one meta element with given
+// attributes into metadata, setting any standard
+// (non-OpenGraph) description on given pointer.
+func parseMeta(meta *metadata, desc *string, attrs []html.Attribute, pageURL *url.URL) {
+	m := attrMap(attrs)
+	content := strings.TrimSpace(m["content"])
+	if content == "" {
+		return
+	}
+
+	// OpenGraph uses "property", though
+	// "name" is also often seen in the wild.
+	key := m["property"]
+	if key == "" {
+		key = m["name"]
+	}
+
+	switch strings.ToLower(key) {
+	case "og:title":
+		meta.Title = content
+	case "og:description":
+		meta.Description = content
+	case "og:site_name":
+		meta.ProviderName = content
+	case "og:image", "og:image:url", "og:image:secure_url":
+		if meta.Image == "" {
+			meta.Image = resolve(pageURL, content)
+		}
+	case "description":
+		*desc = content
+	case "author":
+		meta.AuthorName = content
+	}
+}
+
+// attrMap converts html attributes to a map
+// of (lower-cased) attribute keys to values.
+func attrMap(attrs []html.Attribute) map[string]string {
+	m := make(map[string]string, len(attrs))
+	for _, attr := range attrs {
+		m[strings.ToLower(attr.Key)] = attr.Val
+	}
+	return m
+}
+
+// resolve resolves given reference against base
+// URL, only returning the result if it's http(s).
+func resolve(base *url.URL, ref string) string {
+	u, err := base.Parse(ref)
+	if err != nil {
+		return ""
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return ""
+	}
+
+	return u.String()
+}
+
+// truncate truncates given string to at most
+// n bytes (plus ellipsis), on a word boundary.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return strings.TrimSpace(text.FirstNBytesByWords(s, n)) + "…"
+}
@@ -25,6 +25,7 @@
 	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/media"
 	"code.superseriousbusiness.org/gotosocial/internal/state"
 	"code.superseriousbusiness.org/gotosocial/internal/storage"
 )
@@ -117,6 +118,18 @@ func (c *Cleaner) removeFiles(ctx context.Context, files ...string) (int, error)
 	return count, nil
 }
 
+// removeMediaFiles removes the provided files of media attachment or emoji with ID,
+// skipping content-addressed files still in use by other media, returning the number
+// of them removed.
+func (c *Cleaner) removeMediaFiles(ctx context.Context, id string, files ...string) (int, error) {
+	files, unlock, err := media.UnusedFiles(ctx, c.state, id, files...)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+	return c.removeFiles(ctx, files...)
+}
+
 // ScheduleJobs schedules cleaning
 // jobs using configured parameters.
 //
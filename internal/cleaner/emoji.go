@@ -28,6 +28,7 @@
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
 	"code.superseriousbusiness.org/gotosocial/internal/log"
 	"code.superseriousbusiness.org/gotosocial/internal/paging"
+	"code.superseriousbusiness.org/gotosocial/internal/regexes"
 )
 
 // Emoji encompasses a set of
@@ -337,13 +338,16 @@ func (e *Emoji) fixCacheState(ctx context.Context, emoji *gtsmodel.Emoji) (bool,
 		return true, e.uncache(ctx, emoji)
 
 	case !*emoji.Cached && exist:
-		// Remove files if we don't expect them to exist.
-		l.Debug("cached=false exists=true => removing files")
-		_, err := e.removeFiles(ctx,
+		// Remove files if we don't expect them to exist
+		// (unless they're shared files still in use).
+		n, err := e.removeMediaFiles(ctx, emoji.ID,
 			emoji.ImageStaticPath,
 			emoji.ImagePath,
 		)
-		return true, err
+		if n > 0 {
+			l.Debug("cached=false exists=true => removed files")
+		}
+		return (n > 0), err
 
 	default:
 		return false, nil
@@ -475,7 +479,7 @@ func (e *Emoji) uncache(ctx context.Context, emoji *gtsmodel.Emoji) error {
 	}
 
 	// Remove emoji and static.
-	_, err := e.removeFiles(ctx,
+	_, err := e.removeMediaFiles(ctx, emoji.ID,
 		emoji.ImagePath,
 		emoji.ImageStaticPath,
 	)
@@ -486,7 +490,20 @@ func (e *Emoji) uncache(ctx context.Context, emoji *gtsmodel.Emoji) error {
 	// Update emoji to reflect that we no longer have it cached.
 	log.Debugf(ctx, "marking emoji as uncached: %s", emoji.ID)
 	emoji.Cached = func() *bool { i := false; return &i }()
-	if err := e.state.DB.UpdateEmoji(ctx, emoji, "cached"); err != nil {
+	columns := []string{"cached"}
+
+	// Drop references to any shared files,
+	// so they aren't kept around for this.
+	if regexes.BlobPath.MatchString(emoji.ImagePath) {
+		emoji.ImagePath = ""
+		columns = append(columns, "image_path")
+	}
+	if regexes.BlobPath.MatchString(emoji.ImageStaticPath) {
+		emoji.ImageStaticPath = ""
+		columns = append(columns, "image_static_path")
+	}
+
+	if err := e.state.DB.UpdateEmoji(ctx, emoji, columns...); err != nil {
 		return gtserror.Newf("error updating emoji: %w", err)
 	}
 
@@ -500,7 +517,7 @@ func (e *Emoji) delete(ctx context.Context, emoji *gtsmodel.Emoji) error {
 	}
 
 	// Remove emoji and static files.
-	_, err := e.removeFiles(ctx,
+	_, err := e.removeMediaFiles(ctx, emoji.ID,
 		emoji.ImageStaticPath,
 		emoji.ImagePath,
 	)
@@ -91,26 +91,44 @@ func (m *Media) LogFixCacheStates(ctx context.Context) {
 // PruneOrphaned will delete orphaned files from storage (i.e. media missing a database entry).
 // Context will be checked for `gtscontext.DryRun()` in order to actually perform the action.
 func (m *Media) PruneOrphaned(ctx context.Context) (int, error) {
-	var files []string
+	var files, blobs []string
 
-	// All media in storage will have path: {$account}/{$type}/{$size}/{$id}.{$ext}
+	// All media in storage will have path: {$account}/{$type}/{$size}/{$id}.{$ext},
+	// or if deduplicated, a content-addressed path: blob/{$prefix}/{$checksum}.{$ext}.
+	// Account data archives are also stored at: {$account}/archive/{$id}.zip
 	if err := m.state.Storage.WalkKeys(ctx, func(path string) error {
+		switch {
+		case regexes.FilePath.MatchString(path):
+			// Check whether this entry is orphaned.
+			orphaned, err := m.isOrphaned(ctx, path)
+			if err != nil {
+				return gtserror.Newf("error checking orphaned status: %w", err)
+			}
 
-		// Check for expected fileserver path format.
-		if !regexes.FilePath.MatchString(path) {
-			log.Warnf(ctx, "unexpected storage item: %s", path)
-			return nil
-		}
+			if orphaned {
+				// Add this orphaned entry.
+				files = append(files, path)
+			}
 
-		// Check whether this entry is orphaned.
-		orphaned, err := m.isOrphaned(ctx, path)
-		if err != nil {
-			return gtserror.Newf("error checking orphaned status: %w", err)
-		}
+		case regexes.BlobPath.MatchString(path):
+			// Shared entries are checked
+			// for references on removal.
+			blobs = append(blobs, path)
 
-		if orphaned {
-			// Add this orphaned entry.
-			files = append(files, path)
+		case regexes.ArchivePath.MatchString(path):
+			// Check whether this archive is orphaned.
+			orphaned, err := m.isOrphanedArchive(ctx, path)
+			if err != nil {
+				return gtserror.Newf("error checking orphaned status: %w", err)
+			}
+
+			if orphaned {
+				// Add this orphaned entry.
+				files = append(files, path)
+			}
+
+		default:
+			log.Warnf(ctx, "unexpected storage item: %s", path)
 		}
 
 		return nil
@@ -119,7 +137,22 @@ func (m *Media) PruneOrphaned(ctx context.Context) (int, error) {
 	}
 
 	// Delete all orphaned files from storage.
-	return m.removeFiles(ctx, files...)
+	total, err := m.removeFiles(ctx, files...)
+	if err != nil {
+		return total, err
+	}
+
+	// Delete all shared files no longer used by any media or
+	// emoji, one at a time as each is locked against reuse.
+	for _, path := range blobs {
+		n, err := m.removeMediaFiles(ctx, "", path)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+
+	return total, nil
 }
 
 // PruneUnused will delete all unused media attachments from the database and storage driver.
@@ -265,6 +298,36 @@ func (m *Media) FixCacheStates(ctx context.Context) (int, error) {
 	return total, nil
 }
 
+func (m *Media) isOrphanedArchive(ctx context.Context, path string) (bool, error) {
+	pathParts := regexes.ArchivePath.FindStringSubmatch(path)
+	if len(pathParts) != 3 {
+		// This doesn't match our expectations so
+		// it wasn't created by gts; ignore it.
+		return false, nil
+	}
+
+	// 0th -> whole match
+	// 1st -> account ID
+	archiveID := pathParts[2]
+
+	// Look for archive in database stored by ID. Note an archive
+	// still being built has no key set yet, so only the presence
+	// of the archive entry itself is checked.
+	archive, err := m.state.DB.GetArchiveByID(ctx, archiveID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, gtserror.Newf("error fetching archive by id %s: %w", archiveID, err)
+	}
+
+	if archive == nil {
+		log.WithContext(ctx).
+			WithField("archive", archiveID).
+			Debug("missing db entry for archive")
+		return true, nil
+	}
+
+	return false, nil
+}
+
 func (m *Media) isOrphaned(ctx context.Context, path string) (bool, error) {
 	pathParts := regexes.FilePath.FindStringSubmatch(path)
 	if len(pathParts) != 6 {
@@ -356,6 +419,15 @@ func (m *Media) pruneUnused(ctx context.Context, media *gtsmodel.MediaAttachment
 		}
 	}
 
+	// Check whether media is used as a preview card thumbnail.
+	card, err := m.getRelatedCard(ctx, media)
+	if err != nil {
+		return false, err
+	} else if card != nil {
+		l.Debug("skipping as preview card media in use")
+		return false, nil
+	}
+
 	// Check whether we have the required status for media.
 	status, missing, err := m.getRelatedStatus(ctx, media)
 	if err != nil {
@@ -438,13 +510,16 @@ func (m *Media) fixCacheState(ctx context.Context, media *gtsmodel.MediaAttachme
 		return true, m.uncache(ctx, media)
 
 	case !*media.Cached && exist:
-		// Remove files if we don't expect them to exist.
-		l.Debug("cached=false exists=true => deleting")
-		_, err := m.removeFiles(ctx,
+		// Remove files if we don't expect them to exist
+		// (unless they're shared files still in use).
+		n, err := m.removeMediaFiles(ctx, media.ID,
 			media.Thumbnail.Path,
 			media.File.Path,
 		)
-		return true, err
+		if n > 0 {
+			l.Debug("cached=false exists=true => deleted")
+		}
+		return (n > 0), err
 
 	default:
 		return false, nil
@@ -461,13 +536,25 @@ func (m *Media) uncacheRemote(ctx context.Context, after time.Time, media *gtsmo
 	l := log.WithContext(ctx).
 		WithField("media", media.ID)
 
-	// There are two possibilities here:
+	// There are three possibilities here:
 	//
 	//   1. Media is an avatar or header; we should uncache
 	//      it if we haven't seen the account recently.
-	//   2. Media is attached to a status; we should uncache
+	//   2. Media is a preview card thumbnail; we should
+	//      uncache it if the card wasn't fetched recently.
+	//   3. Media is attached to a status; we should uncache
 	//      it if we haven't seen the status recently.
-	if *media.Avatar || *media.Header {
+	card, err := m.getRelatedCard(ctx, media)
+	if err != nil {
+		return false, err
+	}
+
+	if card != nil {
+		if card.FetchedAt.After(after) {
+			l.Debug("skipping due to recently fetched card")
+			return false, nil
+		}
+	} else if *media.Avatar || *media.Header {
 		// Check whether we have the account that owns the media.
 		account, missing, err := m.getOwningAccount(ctx, media)
 		if err != nil {
@@ -539,6 +626,26 @@ func (m *Media) getOwningAccount(ctx context.Context, media *gtsmodel.MediaAttac
 	return account, false, nil
 }
 
+func (m *Media) getRelatedCard(ctx context.Context, media *gtsmodel.MediaAttachment) (*gtsmodel.Card, error) {
+	if media.StatusID != "" ||
+		media.ScheduledStatusID != "" ||
+		*media.Avatar || *media.Header {
+		// can't be a card thumbnail.
+		return nil, nil
+	}
+
+	// Load any card using this media as thumbnail.
+	card, err := m.state.DB.GetCardByImageID(
+		gtscontext.SetBarebones(ctx),
+		media.ID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("error fetching card by image id %s: %w", media.ID, err)
+	}
+
+	return card, nil
+}
+
 func (m *Media) getRelatedStatus(ctx context.Context, media *gtsmodel.MediaAttachment) (*gtsmodel.Status, bool, error) {
 	if media.StatusID == "" {
 		// no related status.
@@ -592,7 +699,7 @@ func (m *Media) uncache(ctx context.Context, media *gtsmodel.MediaAttachment) er
 	}
 
 	// Remove media and thumbnail.
-	_, err := m.removeFiles(ctx,
+	_, err := m.removeMediaFiles(ctx, media.ID,
 		media.File.Path,
 		media.Thumbnail.Path,
 	)
@@ -603,7 +710,20 @@ func (m *Media) uncache(ctx context.Context, media *gtsmodel.MediaAttachment) er
 	// Update attachment to reflect that we no longer have it cached.
 	log.Debugf(ctx, "marking media attachment as uncached: %s", media.ID)
 	media.Cached = func() *bool { i := false; return &i }()
-	if err := m.state.DB.UpdateAttachment(ctx, media, "cached"); err != nil {
+	columns := []string{"cached"}
+
+	// Drop references to any shared files,
+	// so they aren't kept around for this.
+	if regexes.BlobPath.MatchString(media.File.Path) {
+		media.File.Path = ""
+		columns = append(columns, "file_path")
+	}
+	if regexes.BlobPath.MatchString(media.Thumbnail.Path) {
+		media.Thumbnail.Path = ""
+		columns = append(columns, "thumbnail_path")
+	}
+
+	if err := m.state.DB.UpdateAttachment(ctx, media, columns...); err != nil {
 		return gtserror.Newf("error updating media: %w", err)
 	}
 
@@ -617,7 +737,7 @@ func (m *Media) delete(ctx context.Context, media *gtsmodel.MediaAttachment) err
 	}
 
 	// Remove media and thumbnail.
-	_, err := m.removeFiles(ctx,
+	_, err := m.removeMediaFiles(ctx, media.ID,
 		media.File.Path,
 		media.Thumbnail.Path,
 	)
@@ -138,6 +138,46 @@ func (suite *MediaTestSuite) TearDownTest() {
 // 	suite.False(hasKey)
 // }
 
+func (suite *MediaTestSuite) TestPruneOrphanedArchives() {
+	var (
+		ctx     = suite.T().Context()
+		account = suite.testAccounts["local_account_1"]
+		archive = &gtsmodel.Archive{
+			ID:        "01JB5Y1NSX4VF9XBPDKQJY7ZJ4",
+			AccountID: account.ID,
+			State:     gtsmodel.ArchiveStateReady,
+			Key:       account.ID + "/archive/01JB5Y1NSX4VF9XBPDKQJY7ZJ4.zip",
+			Size:      4,
+		}
+		orphanPath = account.ID + "/archive/01JB5Y3HQ0AXN1G9K3T3DZ5TRM.zip"
+	)
+
+	if err := suite.db.PutArchive(ctx, archive); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Store the archive file, and
+	// one with no database entry.
+	for _, path := range []string{archive.Key, orphanPath} {
+		if _, err := suite.storage.Put(ctx, path, []byte("zip!")); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Only the orphaned archive should be pruned.
+	totalPruned, err := suite.cleaner.Media().PruneOrphaned(ctx)
+	suite.NoError(err)
+	suite.Equal(1, totalPruned)
+
+	hasKey, err := suite.storage.Has(ctx, orphanPath)
+	suite.NoError(err)
+	suite.False(hasKey)
+
+	hasKey, err = suite.storage.Has(ctx, archive.Key)
+	suite.NoError(err)
+	suite.True(hasKey)
+}
+
 // func (suite *MediaTestSuite) TestPruneUnusedLocal() {
 // 	testAttachment := suite.testAttachments["local_account_1_unattached_1"]
 // 	suite.True(*testAttachment.Cached)
@@ -309,6 +349,75 @@ func (suite *MediaTestSuite) TestUncacheRemote() {
 	suite.False(*uncachedAttachment.Cached)
 }
 
+func (suite *MediaTestSuite) TestUncacheRemoteSharedBlob() {
+	ctx := suite.T().Context()
+
+	// Store a content-addressed file shared by a remote
+	// attachment that will be uncached and a local one.
+	const blobPath = "blob/9f/9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08.jpg"
+	if _, err := suite.storage.Put(ctx, blobPath, []byte("some jpeg data")); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	for _, key := range []string{
+		"remote_account_1_status_1_attachment_1",
+		"admin_account_status_1_attachment_1",
+	} {
+		attachment := suite.testAttachments[key]
+		attachment.File.Path = blobPath
+		suite.NoError(suite.db.UpdateAttachment(ctx, attachment, "file_path"))
+	}
+
+	after := time.Now().Add(-24 * time.Hour)
+	totalUncached, err := suite.cleaner.Media().UncacheRemote(ctx, after)
+	suite.NoError(err)
+	suite.Equal(3, totalUncached)
+
+	// The shared file should still be in storage.
+	hasKey, err := suite.storage.Has(ctx, blobPath)
+	suite.NoError(err)
+	suite.True(hasKey)
+
+	// But the uncached attachment should no longer reference it.
+	uncached, err := suite.db.GetAttachmentByID(ctx, suite.testAttachments["remote_account_1_status_1_attachment_1"].ID)
+	suite.NoError(err)
+	suite.False(*uncached.Cached)
+	suite.Empty(uncached.File.Path)
+
+	// Nor should it be considered orphaned.
+	_, err = suite.cleaner.Media().PruneOrphaned(ctx)
+	suite.NoError(err)
+
+	hasKey, err = suite.storage.Has(ctx, blobPath)
+	suite.NoError(err)
+	suite.True(hasKey)
+}
+
+func (suite *MediaTestSuite) TestPruneOrphanedBlob() {
+	ctx := suite.T().Context()
+
+	// Store a content-addressed file not used by any media.
+	const blobPath = "blob/9f/9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08.jpg"
+	if _, err := suite.storage.Put(ctx, blobPath, []byte("some jpeg data")); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Dry run should leave it in storage.
+	_, err := suite.cleaner.Media().PruneOrphaned(gtscontext.SetDryRun(ctx))
+	suite.NoError(err)
+
+	hasKey, err := suite.storage.Has(ctx, blobPath)
+	suite.NoError(err)
+	suite.True(hasKey)
+
+	_, err = suite.cleaner.Media().PruneOrphaned(ctx)
+	suite.NoError(err)
+
+	hasKey, err = suite.storage.Has(ctx, blobPath)
+	suite.NoError(err)
+	suite.False(hasKey)
+}
+
 func (suite *MediaTestSuite) TestUncacheRemoteDry() {
 	ctx := suite.T().Context()
 
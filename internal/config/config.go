@@ -105,26 +105,31 @@ type Configuration struct {
 	InstanceSubscriptionsProcessEvery time.Duration      `name:"instance-subscriptions-process-every" usage:"Period to elapse between instance subscriptions processing jobs, starting from instance-subscriptions-process-from."`
 	InstanceStatsMode                 string             `name:"instance-stats-mode" usage:"Allows you to customize the way stats are served to crawlers: one of '', 'serve', 'zero', 'baffle'. Home page stats remain unchanged."`
 	InstanceAllowBackdatingStatuses   bool               `name:"instance-allow-backdating-statuses" usage:"Allow local accounts to backdate statuses using the scheduled_at param to /api/v1/statuses"`
+	InstanceTrendsEnabled             bool               `name:"instance-trends-enabled" usage:"Periodically calculate trending hashtags, statuses and links, and expose admin-approved trends at /api/v1/trends"`
 
-	AccountsRegistrationOpen         bool `name:"accounts-registration-open" usage:"Allow anyone to submit an account signup request. If false, server will be invite-only."`
-	AccountsReasonRequired           bool `name:"accounts-reason-required" usage:"Do new account signups require a reason to be submitted on registration?"`
-	AccountsRegistrationDailyLimit   int  `name:"accounts-registration-daily-limit" usage:"Limit amount of approved account sign-ups allowed per 24hrs before registration is closed. 0 or less = no limit."`
-	AccountsRegistrationBacklogLimit int  `name:"accounts-registration-backlog-limit" usage:"Limit how big the 'accounts pending approval' queue can grow before registration is closed. 0 or less = no limit."`
-	AccountsAllowCustomCSS           bool `name:"accounts-allow-custom-css" usage:"Allow accounts to enable custom CSS for their profile pages and statuses."`
-	AccountsCustomCSSLength          int  `name:"accounts-custom-css-length" usage:"Maximum permitted length (characters) of custom CSS for accounts."`
-	AccountsMaxProfileFields         int  `name:"accounts-max-profile-fields" usage:"Maximum number of profile fields allowed for each account."`
+	AccountsRegistrationOpen         bool          `name:"accounts-registration-open" usage:"Allow anyone to submit an account signup request. If false, server will be invite-only."`
+	AccountsReasonRequired           bool          `name:"accounts-reason-required" usage:"Do new account signups require a reason to be submitted on registration?"`
+	AccountsRegistrationDailyLimit   int           `name:"accounts-registration-daily-limit" usage:"Limit amount of approved account sign-ups allowed per 24hrs before registration is closed. 0 or less = no limit."`
+	AccountsRegistrationBacklogLimit int           `name:"accounts-registration-backlog-limit" usage:"Limit how big the 'accounts pending approval' queue can grow before registration is closed. 0 or less = no limit."`
+	AccountsInvitesEnabled           bool          `name:"accounts-invites-enabled" usage:"Allow invite links to be generated, which let new users sign up even when registration is closed, without waiting for approval."`
+	AccountsInvitesAdminOnly         bool          `name:"accounts-invites-admin-only" usage:"Only allow admins and moderators to generate invite links. If false, any local user can generate them."`
+	AccountsAllowCustomCSS           bool          `name:"accounts-allow-custom-css" usage:"Allow accounts to enable custom CSS for their profile pages and statuses."`
+	AccountsCustomCSSLength          int           `name:"accounts-custom-css-length" usage:"Maximum permitted length (characters) of custom CSS for accounts."`
+	AccountsMaxProfileFields         int           `name:"accounts-max-profile-fields" usage:"Maximum number of profile fields allowed for each account."`
+	AccountsArchiveJSONMaxSize       bytesize.Size `name:"accounts-archive-json-max-size" usage:"Max size in bytes of the actor.json and outbox.json entries read from an imported account archive."`
 
-	StorageBackend        string `name:"storage-backend" usage:"Storage backend to use for media attachments"`
-	StorageLocalBasePath  string `name:"storage-local-base-path" usage:"Full path to an already-created directory where gts should store/retrieve media files. Subfolders will be created within this dir."`
-	StorageS3Endpoint     string `name:"storage-s3-endpoint" usage:"S3 Endpoint URL (e.g 'minio.example.org:9000')"`
-	StorageS3AccessKey    string `name:"storage-s3-access-key" usage:"S3 Access Key"`
-	StorageS3SecretKey    string `name:"storage-s3-secret-key" usage:"S3 Secret Key"`
-	StorageS3UseSSL       bool   `name:"storage-s3-use-ssl" usage:"Use SSL for S3 connections. Only set this to 'false' when testing locally"`
-	StorageS3BucketName   string `name:"storage-s3-bucket" usage:"Place blobs in this bucket"`
-	StorageS3Proxy        bool   `name:"storage-s3-proxy" usage:"Proxy S3 contents through GoToSocial instead of redirecting to a presigned URL"`
-	StorageS3RedirectURL  string `name:"storage-s3-redirect-url" usage:"Custom URL to use for redirecting S3 media links. If set, this will be used instead of the S3 bucket URL."`
-	StorageS3BucketLookup string `name:"storage-s3-bucket-lookup" usage:"S3 bucket lookup type to use. Can be 'auto', 'dns' or 'path'. Defaults to 'auto'."`
-	StorageS3KeyPrefix    string `name:"storage-s3-key-prefix" usage:"Prefix to use for S3 keys. This is useful for separating multiple instances sharing the same S3 bucket."`
+	StorageBackend         string `name:"storage-backend" usage:"Storage backend to use for media attachments"`
+	StorageLocalBasePath   string `name:"storage-local-base-path" usage:"Full path to an already-created directory where gts should store/retrieve media files. Subfolders will be created within this dir."`
+	StorageS3Endpoint      string `name:"storage-s3-endpoint" usage:"S3 Endpoint URL (e.g 'minio.example.org:9000')"`
+	StorageS3AccessKey     string `name:"storage-s3-access-key" usage:"S3 Access Key"`
+	StorageS3SecretKey     string `name:"storage-s3-secret-key" usage:"S3 Secret Key"`
+	StorageS3UseSSL        bool   `name:"storage-s3-use-ssl" usage:"Use SSL for S3 connections. Only set this to 'false' when testing locally"`
+	StorageS3BucketName    string `name:"storage-s3-bucket" usage:"Place blobs in this bucket"`
+	StorageS3Proxy         bool   `name:"storage-s3-proxy" usage:"Proxy S3 contents through GoToSocial instead of redirecting to a presigned URL"`
+	StorageS3RedirectURL   string `name:"storage-s3-redirect-url" usage:"Custom URL to use for redirecting S3 media links. If set, this will be used instead of the S3 bucket URL."`
+	StorageS3BucketLookup  string `name:"storage-s3-bucket-lookup" usage:"S3 bucket lookup type to use. Can be 'auto', 'dns' or 'path'. Defaults to 'auto'."`
+	StorageS3KeyPrefix     string `name:"storage-s3-key-prefix" usage:"Prefix to use for S3 keys. This is useful for separating multiple instances sharing the same S3 bucket."`
+	StorageFallbackBackend string `name:"storage-fallback-backend" usage:"Storage backend to read media from when not found in storage-backend, eg., while migrating between backends. Can be 'local', 's3' or empty to disable."`
 
 	StatusesMaxChars           int `name:"statuses-max-chars" usage:"Max permitted characters for posted statuses, including content warning"`
 	StatusesPollMaxOptions     int `name:"statuses-poll-max-options" usage:"Max amount of options permitted on a poll"`
@@ -142,6 +147,9 @@ type Configuration struct {
 	TLSCertificateChain string `name:"tls-certificate-chain" usage:"Filesystem path to the certificate chain including any intermediate CAs and the TLS public key"`
 	TLSCertificateKey   string `name:"tls-certificate-key" usage:"Filesystem path to the TLS private key"`
 
+	OAuthAccessTokenExpiry  time.Duration `name:"oauth-access-token-expiry" usage:"Lifetime of user-level OAuth access tokens. If greater than 0, refresh tokens will also be issued, which clients can use to obtain a new access token. 0 = access tokens never expire."`
+	OAuthRefreshTokenExpiry time.Duration `name:"oauth-refresh-token-expiry" usage:"Lifetime of OAuth refresh tokens, counted from the last time the refresh token was used. 0 = refresh tokens never expire."`
+
 	OIDCEnabled          bool     `name:"oidc-enabled" usage:"Enabled OIDC authorization for this instance. If set to true, then the other OIDC flags must also be set."`
 	OIDCIdpName          string   `name:"oidc-idp-name" usage:"Name of the OIDC identity provider. Will be shown to the user when logging in."`
 	OIDCSkipVerification bool     `name:"oidc-skip-verification" usage:"Skip verification of tokens returned by the OIDC provider. Should only be set to 'true' for testing purposes, never in a production environment!"`
@@ -186,6 +194,7 @@ type Configuration struct {
 	AdminMediaPruneDryRun    bool   `name:"dry-run" usage:"perform a dry run and only log number of items eligible for pruning" ephemeral:"yes"`
 	AdminMediaListLocalOnly  bool   `name:"local-only" usage:"list only local attachments/emojis; if specified then remote-only cannot also be true" ephemeral:"yes"`
 	AdminMediaListRemoteOnly bool   `name:"remote-only" usage:"list only remote attachments/emojis; if specified then local-only cannot also be true" ephemeral:"yes"`
+	AdminStorageMigrateTo    string `name:"to" usage:"storage backend to migrate media to: 'local' or 's3'" ephemeral:"yes"`
 	TestrigSkipDBSetup       bool   `name:"skip-db-setup" usage:"skip testrig database setup with population of test models" ephemeral:"yes"`
 	TestrigSkipDBTeardown    bool   `name:"skip-db-teardown" usage:"skip testrig database teardown (i.e. data deletion and tables dropped)" ephemeral:"yes"`
 }
@@ -199,19 +208,27 @@ type HTTPClientConfiguration struct {
 }
 
 type MediaConfiguration struct {
-	DescriptionMinChars int           `name:"description-min-chars" usage:"Min required chars for an image description"`
-	DescriptionMaxChars int           `name:"description-max-chars" usage:"Max permitted chars for an image description"`
-	RemoteCacheDays     int           `name:"remote-cache-days" usage:"Number of days to locally cache media from remote instances. If set to 0, remote media will be kept indefinitely."`
-	EmojiLocalMaxSize   bytesize.Size `name:"emoji-local-max-size" usage:"Max size in bytes of emojis uploaded to this instance via the admin API."`
-	EmojiRemoteMaxSize  bytesize.Size `name:"emoji-remote-max-size" usage:"Max size in bytes of emojis to download from other instances."`
-	ImageSizeHint       bytesize.Size `name:"image-size-hint" usage:"Size in bytes of max image size referred to on /api/v_/instance endpoints (else, local max size)"`
-	VideoSizeHint       bytesize.Size `name:"video-size-hint" usage:"Size in bytes of max video size referred to on /api/v_/instance endpoints (else, local max size)"`
-	LocalMaxSize        bytesize.Size `name:"local-max-size" usage:"Max size in bytes of media uploaded to this instance via API"`
-	RemoteMaxSize       bytesize.Size `name:"remote-max-size" usage:"Max size in bytes of media to download from other instances"`
-	CleanupFrom         string        `name:"cleanup-from" usage:"Time of day from which to start running media cleanup/prune jobs. Should be in the format 'hh:mm:ss', eg., '15:04:05'."`
-	CleanupEvery        time.Duration `name:"cleanup-every" usage:"Period to elapse between cleanups, starting from media-cleanup-at."`
-	FfmpegPoolSize      int           `name:"ffmpeg-pool-size" usage:"Number of instances of the embedded ffmpeg WASM binary to add to the media processing pool. 0 or less uses GOMAXPROCS."`
-	ThumbMaxPixels      int           `name:"thumb-max-pixels" usage:"Max size in pixels of any one dimension of a thumbnail (as input media ratio is preserved)."`
+	DescriptionMinChars        int           `name:"description-min-chars" usage:"Min required chars for an image description"`
+	DescriptionMaxChars        int           `name:"description-max-chars" usage:"Max permitted chars for an image description"`
+	RemoteCacheDays            int           `name:"remote-cache-days" usage:"Number of days to locally cache media from remote instances. If set to 0, remote media will be kept indefinitely."`
+	EmojiLocalMaxSize          bytesize.Size `name:"emoji-local-max-size" usage:"Max size in bytes of emojis uploaded to this instance via the admin API."`
+	EmojiRemoteMaxSize         bytesize.Size `name:"emoji-remote-max-size" usage:"Max size in bytes of emojis to download from other instances."`
+	ImageSizeHint              bytesize.Size `name:"image-size-hint" usage:"Size in bytes of max image size referred to on /api/v_/instance endpoints (else, local max size)"`
+	VideoSizeHint              bytesize.Size `name:"video-size-hint" usage:"Size in bytes of max video size referred to on /api/v_/instance endpoints (else, local max size)"`
+	LocalMaxSize               bytesize.Size `name:"local-max-size" usage:"Max size in bytes of media uploaded to this instance via API"`
+	RemoteMaxSize              bytesize.Size `name:"remote-max-size" usage:"Max size in bytes of media to download from other instances"`
+	CleanupFrom                string        `name:"cleanup-from" usage:"Time of day from which to start running media cleanup/prune jobs. Should be in the format 'hh:mm:ss', eg., '15:04:05'."`
+	CleanupEvery               time.Duration `name:"cleanup-every" usage:"Period to elapse between cleanups, starting from media-cleanup-at."`
+	FfmpegPoolSize             int           `name:"ffmpeg-pool-size" usage:"Number of instances of the embedded ffmpeg WASM binary to add to the media processing pool. 0 or less uses GOMAXPROCS."`
+	ThumbMaxPixels             int           `name:"thumb-max-pixels" usage:"Max size in pixels of any one dimension of a thumbnail (as input media ratio is preserved)."`
+	Dedupe                     bool          `name:"dedupe" usage:"Store identical processed media files and thumbnails only once in storage, under a path derived from their SHA256 checksum."`
+	TranscodeEnabled           bool          `name:"transcode-enabled" usage:"Transcode locally uploaded video and audio which is not web-playable, or which exceeds configured limits, into a web-playable format."`
+	TranscodeFormat            string        `name:"transcode-format" usage:"Format to transcode local video and audio uploads to, one of: mp4 (H.264 / AAC), webm (VP9 / Opus)."`
+	TranscodeMaxPixels         int           `name:"transcode-max-pixels" usage:"Max size in pixels of any one dimension of transcoded video (as input media ratio is preserved)."`
+	TranscodeVideoBitrate      int           `name:"transcode-video-bitrate" usage:"Max bitrate in kilobits per second of transcoded video streams."`
+	TranscodeAudioBitrate      int           `name:"transcode-audio-bitrate" usage:"Bitrate in kilobits per second of transcoded audio streams."`
+	PreviewCardsEnabled        bool          `name:"preview-cards-enabled" usage:"Fetch OpenGraph / oEmbed metadata for the first link in statuses, to show link preview cards."`
+	PreviewCardsBlockedDomains []string      `name:"preview-cards-blocked-domains" usage:"Domains (including their subdomains) from which link preview cards will never be fetched."`
 }
 
 type CacheConfiguration struct {
@@ -224,6 +241,7 @@ type CacheConfiguration struct {
 	BlockMemRatio                         float64       `name:"block-mem-ratio"`
 	BlockIDsMemRatio                      float64       `name:"block-ids-mem-ratio"`
 	BoostOfIDsMemRatio                    float64       `name:"boost-of-ids-mem-ratio"`
+	CardMemRatio                          float64       `name:"card-mem-ratio"`
 	ClientMemRatio                        float64       `name:"client-mem-ratio"`
 	ConversationMemRatio                  float64       `name:"conversation-mem-ratio"`
 	ConversationLastStatusIDsMemRatio     float64       `name:"conversation-last-status-ids-mem-ratio"`
@@ -270,6 +288,7 @@ type CacheConfiguration struct {
 	UserMemRatio                          float64       `name:"user-mem-ratio"`
 	UserMuteMemRatio                      float64       `name:"user-mute-mem-ratio"`
 	UserMuteIDsMemRatio                   float64       `name:"user-mute-ids-mem-ratio"`
+	UserDomainBlocksMemRatio              float64       `name:"user-domain-blocks-mem-ratio"`
 	WebfingerMemRatio                     float64       `name:"webfinger-mem-ratio"`
 	WebPushSubscriptionMemRatio           float64       `name:"web-push-subscription-mem-ratio"`
 	WebPushSubscriptionIDsMemRatio        float64       `name:"web-push-subscription-ids-mem-ratio"`
@@ -42,3 +42,11 @@
 	InstanceStatsModeZero    = "zero"
 	InstanceStatsModeBaffle  = "baffle"
 )
+
+// Media transcode format determines the container
+// format and codecs that local video and audio
+// uploads will be transcoded to (if enabled).
+const (
+	MediaTranscodeFormatMP4  = "mp4"
+	MediaTranscodeFormatWebM = "webm"
+)
@@ -70,27 +70,36 @@
 	InstanceSubscriptionsProcessFrom:  "23:00",        // 11pm,
 	InstanceSubscriptionsProcessEvery: 24 * time.Hour, // 1/day.
 	InstanceAllowBackdatingStatuses:   true,
+	InstanceTrendsEnabled:             true,
 
 	AccountsRegistrationOpen:         false,
 	AccountsReasonRequired:           true,
 	AccountsRegistrationDailyLimit:   10,
 	AccountsRegistrationBacklogLimit: 20,
+	AccountsInvitesEnabled:           true,
+	AccountsInvitesAdminOnly:         true,
 	AccountsAllowCustomCSS:           false,
 	AccountsCustomCSSLength:          10000,
 	AccountsMaxProfileFields:         6,
+	AccountsArchiveJSONMaxSize:       100 * bytesize.MiB,
 
 	Media: MediaConfiguration{
-		DescriptionMinChars: 0,
-		DescriptionMaxChars: 1500,
-		RemoteCacheDays:     7,
-		LocalMaxSize:        40 * bytesize.MiB,
-		RemoteMaxSize:       40 * bytesize.MiB,
-		EmojiLocalMaxSize:   50 * bytesize.KiB,
-		EmojiRemoteMaxSize:  100 * bytesize.KiB,
-		CleanupFrom:         "00:00",        // Midnight.
-		CleanupEvery:        24 * time.Hour, // 1/day.
-		FfmpegPoolSize:      1,
-		ThumbMaxPixels:      512,
+		DescriptionMinChars:   0,
+		DescriptionMaxChars:   1500,
+		RemoteCacheDays:       7,
+		LocalMaxSize:          40 * bytesize.MiB,
+		RemoteMaxSize:         40 * bytesize.MiB,
+		EmojiLocalMaxSize:     50 * bytesize.KiB,
+		EmojiRemoteMaxSize:    100 * bytesize.KiB,
+		CleanupFrom:           "00:00",        // Midnight.
+		CleanupEvery:          24 * time.Hour, // 1/day.
+		FfmpegPoolSize:        1,
+		ThumbMaxPixels:        512,
+		TranscodeFormat:       MediaTranscodeFormatMP4,
+		TranscodeMaxPixels:    1920,
+		TranscodeVideoBitrate: 4000,
+		TranscodeAudioBitrate: 128,
+		PreviewCardsEnabled:   true,
 	},
 
 	StorageBackend:        "local",
@@ -116,6 +125,9 @@
 	TLSCertificateChain: "",
 	TLSCertificateKey:   "",
 
+	OAuthAccessTokenExpiry:  0,                   // never expire
+	OAuthRefreshTokenExpiry: 30 * 24 * time.Hour, // 30 days
+
 	OIDCEnabled:          false,
 	OIDCIdpName:          "",
 	OIDCSkipVerification: false,
@@ -184,6 +196,7 @@
 		BlockMemRatio:                         2,
 		BlockIDsMemRatio:                      3,
 		BoostOfIDsMemRatio:                    3,
+		CardMemRatio:                          1,
 		ClientMemRatio:                        0.1,
 		ConversationMemRatio:                  1,
 		ConversationLastStatusIDsMemRatio:     2,
@@ -230,6 +243,7 @@
 		UserMemRatio:                          0.25,
 		UserMuteMemRatio:                      2,
 		UserMuteIDsMemRatio:                   3,
+		UserDomainBlocksMemRatio:              0.5,
 		WebfingerMemRatio:                     0.1,
 		WebPushSubscriptionMemRatio:           1,
 		WebPushSubscriptionIDsMemRatio:        1,
@@ -85,6 +85,16 @@ func AddAdminMediaPrune(cmd *cobra.Command) {
 	cmd.Flags().Bool(name, true, usage)
 }
 
+// AddAdminStorageMigrate attaches flags pertaining to storage migrate commands.
+func AddAdminStorageMigrate(cmd *cobra.Command) {
+	name := AdminStorageMigrateToFlag
+	usage := fieldtag("AdminStorageMigrateTo", "usage")
+	cmd.Flags().String(name, "", usage) // REQUIRED
+	if err := cmd.MarkFlagRequired(name); err != nil {
+		panic(err)
+	}
+}
+
 // AddTestrig attaches flags pertaining to testrig commands.
 func AddTestrig(cmd *cobra.Command) {
 	skipDBSetup := TestrigSkipDBSetupFlag
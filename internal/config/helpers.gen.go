@@ -77,13 +77,17 @@
 	InstanceSubscriptionsProcessEveryFlag          = "instance-subscriptions-process-every"
 	InstanceStatsModeFlag                          = "instance-stats-mode"
 	InstanceAllowBackdatingStatusesFlag            = "instance-allow-backdating-statuses"
+	InstanceTrendsEnabledFlag                      = "instance-trends-enabled"
 	AccountsRegistrationOpenFlag                   = "accounts-registration-open"
 	AccountsReasonRequiredFlag                     = "accounts-reason-required"
 	AccountsRegistrationDailyLimitFlag             = "accounts-registration-daily-limit"
 	AccountsRegistrationBacklogLimitFlag           = "accounts-registration-backlog-limit"
+	AccountsInvitesEnabledFlag                     = "accounts-invites-enabled"
+	AccountsInvitesAdminOnlyFlag                   = "accounts-invites-admin-only"
 	AccountsAllowCustomCSSFlag                     = "accounts-allow-custom-css"
 	AccountsCustomCSSLengthFlag                    = "accounts-custom-css-length"
 	AccountsMaxProfileFieldsFlag                   = "accounts-max-profile-fields"
+	AccountsArchiveJSONMaxSizeFlag                 = "accounts-archive-json-max-size"
 	StorageBackendFlag                             = "storage-backend"
 	StorageLocalBasePathFlag                       = "storage-local-base-path"
 	StorageS3EndpointFlag                          = "storage-s3-endpoint"
@@ -95,6 +99,7 @@
 	StorageS3RedirectURLFlag                       = "storage-s3-redirect-url"
 	StorageS3BucketLookupFlag                      = "storage-s3-bucket-lookup"
 	StorageS3KeyPrefixFlag                         = "storage-s3-key-prefix"
+	StorageFallbackBackendFlag                     = "storage-fallback-backend"
 	StatusesMaxCharsFlag                           = "statuses-max-chars"
 	StatusesPollMaxOptionsFlag                     = "statuses-poll-max-options"
 	StatusesPollOptionMaxCharsFlag                 = "statuses-poll-option-max-chars"
@@ -107,6 +112,8 @@
 	LetsEncryptEmailAddressFlag                    = "letsencrypt-email-address"
 	TLSCertificateChainFlag                        = "tls-certificate-chain"
 	TLSCertificateKeyFlag                          = "tls-certificate-key"
+	OAuthAccessTokenExpiryFlag                     = "oauth-access-token-expiry"
+	OAuthRefreshTokenExpiryFlag                    = "oauth-refresh-token-expiry"
 	OIDCEnabledFlag                                = "oidc-enabled"
 	OIDCIdpNameFlag                                = "oidc-idp-name"
 	OIDCSkipVerificationFlag                       = "oidc-skip-verification"
@@ -154,6 +161,14 @@
 	MediaCleanupEveryFlag                          = "media-cleanup-every"
 	MediaFfmpegPoolSizeFlag                        = "media-ffmpeg-pool-size"
 	MediaThumbMaxPixelsFlag                        = "media-thumb-max-pixels"
+	MediaDedupeFlag                                = "media-dedupe"
+	MediaTranscodeEnabledFlag                      = "media-transcode-enabled"
+	MediaTranscodeFormatFlag                       = "media-transcode-format"
+	MediaTranscodeMaxPixelsFlag                    = "media-transcode-max-pixels"
+	MediaTranscodeVideoBitrateFlag                 = "media-transcode-video-bitrate"
+	MediaTranscodeAudioBitrateFlag                 = "media-transcode-audio-bitrate"
+	MediaPreviewCardsEnabledFlag                   = "media-preview-cards-enabled"
+	MediaPreviewCardsBlockedDomainsFlag            = "media-preview-cards-blocked-domains"
 	CacheMemoryTargetFlag                          = "cache-memory-target"
 	CacheAccountMemRatioFlag                       = "cache-account-mem-ratio"
 	CacheAccountNoteMemRatioFlag                   = "cache-account-note-mem-ratio"
@@ -163,6 +178,7 @@
 	CacheBlockMemRatioFlag                         = "cache-block-mem-ratio"
 	CacheBlockIDsMemRatioFlag                      = "cache-block-ids-mem-ratio"
 	CacheBoostOfIDsMemRatioFlag                    = "cache-boost-of-ids-mem-ratio"
+	CacheCardMemRatioFlag                          = "cache-card-mem-ratio"
 	CacheClientMemRatioFlag                        = "cache-client-mem-ratio"
 	CacheConversationMemRatioFlag                  = "cache-conversation-mem-ratio"
 	CacheConversationLastStatusIDsMemRatioFlag     = "cache-conversation-last-status-ids-mem-ratio"
@@ -209,6 +225,7 @@
 	CacheUserMemRatioFlag                          = "cache-user-mem-ratio"
 	CacheUserMuteMemRatioFlag                      = "cache-user-mute-mem-ratio"
 	CacheUserMuteIDsMemRatioFlag                   = "cache-user-mute-ids-mem-ratio"
+	CacheUserDomainBlocksMemRatioFlag              = "cache-user-domain-blocks-mem-ratio"
 	CacheWebfingerMemRatioFlag                     = "cache-webfinger-mem-ratio"
 	CacheWebPushSubscriptionMemRatioFlag           = "cache-web-push-subscription-mem-ratio"
 	CacheWebPushSubscriptionIDsMemRatioFlag        = "cache-web-push-subscription-ids-mem-ratio"
@@ -222,6 +239,7 @@
 	AdminMediaPruneDryRunFlag                      = "dry-run"
 	AdminMediaListLocalOnlyFlag                    = "local-only"
 	AdminMediaListRemoteOnlyFlag                   = "remote-only"
+	AdminStorageMigrateToFlag                      = "to"
 	TestrigSkipDBSetupFlag                         = "skip-db-setup"
 	TestrigSkipDBTeardownFlag                      = "skip-db-teardown"
 )
@@ -275,13 +293,17 @@ func (cfg *Configuration) RegisterFlags(flags *pflag.FlagSet) {
 	flags.Duration("instance-subscriptions-process-every", cfg.InstanceSubscriptionsProcessEvery, "Period to elapse between instance subscriptions processing jobs, starting from instance-subscriptions-process-from.")
 	flags.String("instance-stats-mode", cfg.InstanceStatsMode, "Allows you to customize the way stats are served to crawlers: one of '', 'serve', 'zero', 'baffle'. Home page stats remain unchanged.")
 	flags.Bool("instance-allow-backdating-statuses", cfg.InstanceAllowBackdatingStatuses, "Allow local accounts to backdate statuses using the scheduled_at param to /api/v1/statuses")
+	flags.Bool("instance-trends-enabled", cfg.InstanceTrendsEnabled, "Periodically calculate trending hashtags, statuses and links, and expose admin-approved trends at /api/v1/trends")
 	flags.Bool("accounts-registration-open", cfg.AccountsRegistrationOpen, "Allow anyone to submit an account signup request. If false, server will be invite-only.")
 	flags.Bool("accounts-reason-required", cfg.AccountsReasonRequired, "Do new account signups require a reason to be submitted on registration?")
 	flags.Int("accounts-registration-daily-limit", cfg.AccountsRegistrationDailyLimit, "Limit amount of approved account sign-ups allowed per 24hrs before registration is closed. 0 or less = no limit.")
 	flags.Int("accounts-registration-backlog-limit", cfg.AccountsRegistrationBacklogLimit, "Limit how big the 'accounts pending approval' queue can grow before registration is closed. 0 or less = no limit.")
+	flags.Bool("accounts-invites-enabled", cfg.AccountsInvitesEnabled, "Allow invite links to be generated, which let new users sign up even when registration is closed, without waiting for approval.")
+	flags.Bool("accounts-invites-admin-only", cfg.AccountsInvitesAdminOnly, "Only allow admins and moderators to generate invite links. If false, any local user can generate them.")
 	flags.Bool("accounts-allow-custom-css", cfg.AccountsAllowCustomCSS, "Allow accounts to enable custom CSS for their profile pages and statuses.")
 	flags.Int("accounts-custom-css-length", cfg.AccountsCustomCSSLength, "Maximum permitted length (characters) of custom CSS for accounts.")
 	flags.Int("accounts-max-profile-fields", cfg.AccountsMaxProfileFields, "Maximum number of profile fields allowed for each account.")
+	flags.String("accounts-archive-json-max-size", cfg.AccountsArchiveJSONMaxSize.String(), "Max size in bytes of the actor.json and outbox.json entries read from an imported account archive.")
 	flags.String("storage-backend", cfg.StorageBackend, "Storage backend to use for media attachments")
 	flags.String("storage-local-base-path", cfg.StorageLocalBasePath, "Full path to an already-created directory where gts should store/retrieve media files. Subfolders will be created within this dir.")
 	flags.String("storage-s3-endpoint", cfg.StorageS3Endpoint, "S3 Endpoint URL (e.g 'minio.example.org:9000')")
@@ -293,6 +315,7 @@ func (cfg *Configuration) RegisterFlags(flags *pflag.FlagSet) {
 	flags.String("storage-s3-redirect-url", cfg.StorageS3RedirectURL, "Custom URL to use for redirecting S3 media links. If set, this will be used instead of the S3 bucket URL.")
 	flags.String("storage-s3-bucket-lookup", cfg.StorageS3BucketLookup, "S3 bucket lookup type to use. Can be 'auto', 'dns' or 'path'. Defaults to 'auto'.")
 	flags.String("storage-s3-key-prefix", cfg.StorageS3KeyPrefix, "Prefix to use for S3 keys. This is useful for separating multiple instances sharing the same S3 bucket.")
+	flags.String("storage-fallback-backend", cfg.StorageFallbackBackend, "Storage backend to read media from when not found in storage-backend, eg., while migrating between backends. Can be 'local', 's3' or empty to disable.")
 	flags.Int("statuses-max-chars", cfg.StatusesMaxChars, "Max permitted characters for posted statuses, including content warning")
 	flags.Int("statuses-poll-max-options", cfg.StatusesPollMaxOptions, "Max amount of options permitted on a poll")
 	flags.Int("statuses-poll-option-max-chars", cfg.StatusesPollOptionMaxChars, "Max amount of characters for a poll option")
@@ -305,6 +328,8 @@ func (cfg *Configuration) RegisterFlags(flags *pflag.FlagSet) {
 	flags.String("letsencrypt-email-address", cfg.LetsEncryptEmailAddress, "Email address to use when requesting letsencrypt certs. Will receive updates on cert expiry etc.")
 	flags.String("tls-certificate-chain", cfg.TLSCertificateChain, "Filesystem path to the certificate chain including any intermediate CAs and the TLS public key")
 	flags.String("tls-certificate-key", cfg.TLSCertificateKey, "Filesystem path to the TLS private key")
+	flags.Duration("oauth-access-token-expiry", cfg.OAuthAccessTokenExpiry, "Lifetime of user-level OAuth access tokens. If greater than 0, refresh tokens will also be issued, which clients can use to obtain a new access token. 0 = access tokens never expire.")
+	flags.Duration("oauth-refresh-token-expiry", cfg.OAuthRefreshTokenExpiry, "Lifetime of OAuth refresh tokens, counted from the last time the refresh token was used. 0 = refresh tokens never expire.")
 	flags.Bool("oidc-enabled", cfg.OIDCEnabled, "Enabled OIDC authorization for this instance. If set to true, then the other OIDC flags must also be set.")
 	flags.String("oidc-idp-name", cfg.OIDCIdpName, "Name of the OIDC identity provider. Will be shown to the user when logging in.")
 	flags.Bool("oidc-skip-verification", cfg.OIDCSkipVerification, "Skip verification of tokens returned by the OIDC provider. Should only be set to 'true' for testing purposes, never in a production environment!")
@@ -352,6 +377,14 @@ func (cfg *Configuration) RegisterFlags(flags *pflag.FlagSet) {
 	flags.Duration("media-cleanup-every", cfg.Media.CleanupEvery, "Period to elapse between cleanups, starting from media-cleanup-at.")
 	flags.Int("media-ffmpeg-pool-size", cfg.Media.FfmpegPoolSize, "Number of instances of the embedded ffmpeg WASM binary to add to the media processing pool. 0 or less uses GOMAXPROCS.")
 	flags.Int("media-thumb-max-pixels", cfg.Media.ThumbMaxPixels, "Max size in pixels of any one dimension of a thumbnail (as input media ratio is preserved).")
+	flags.Bool("media-dedupe", cfg.Media.Dedupe, "Store identical processed media files and thumbnails only once in storage, under a path derived from their SHA256 checksum.")
+	flags.Bool("media-transcode-enabled", cfg.Media.TranscodeEnabled, "Transcode locally uploaded video and audio which is not web-playable, or which exceeds configured limits, into a web-playable format.")
+	flags.String("media-transcode-format", cfg.Media.TranscodeFormat, "Format to transcode local video and audio uploads to, one of: mp4 (H.264 / AAC), webm (VP9 / Opus).")
+	flags.Int("media-transcode-max-pixels", cfg.Media.TranscodeMaxPixels, "Max size in pixels of any one dimension of transcoded video (as input media ratio is preserved).")
+	flags.Int("media-transcode-video-bitrate", cfg.Media.TranscodeVideoBitrate, "Max bitrate in kilobits per second of transcoded video streams.")
+	flags.Int("media-transcode-audio-bitrate", cfg.Media.TranscodeAudioBitrate, "Bitrate in kilobits per second of transcoded audio streams.")
+	flags.Bool("media-preview-cards-enabled", cfg.Media.PreviewCardsEnabled, "Fetch OpenGraph / oEmbed metadata for the first link in statuses, to show link preview cards.")
+	flags.StringSlice("media-preview-cards-blocked-domains", cfg.Media.PreviewCardsBlockedDomains, "Domains (including their subdomains) from which link preview cards will never be fetched.")
 	flags.String("cache-memory-target", cfg.Cache.MemoryTarget.String(), "")
 	flags.Float64("cache-account-mem-ratio", cfg.Cache.AccountMemRatio, "")
 	flags.Float64("cache-account-note-mem-ratio", cfg.Cache.AccountNoteMemRatio, "")
@@ -361,6 +394,7 @@ func (cfg *Configuration) RegisterFlags(flags *pflag.FlagSet) {
 	flags.Float64("cache-block-mem-ratio", cfg.Cache.BlockMemRatio, "")
 	flags.Float64("cache-block-ids-mem-ratio", cfg.Cache.BlockIDsMemRatio, "")
 	flags.Float64("cache-boost-of-ids-mem-ratio", cfg.Cache.BoostOfIDsMemRatio, "")
+	flags.Float64("cache-card-mem-ratio", cfg.Cache.CardMemRatio, "")
 	flags.Float64("cache-client-mem-ratio", cfg.Cache.ClientMemRatio, "")
 	flags.Float64("cache-conversation-mem-ratio", cfg.Cache.ConversationMemRatio, "")
 	flags.Float64("cache-conversation-last-status-ids-mem-ratio", cfg.Cache.ConversationLastStatusIDsMemRatio, "")
@@ -407,6 +441,7 @@ func (cfg *Configuration) RegisterFlags(flags *pflag.FlagSet) {
 	flags.Float64("cache-user-mem-ratio", cfg.Cache.UserMemRatio, "")
 	flags.Float64("cache-user-mute-mem-ratio", cfg.Cache.UserMuteMemRatio, "")
 	flags.Float64("cache-user-mute-ids-mem-ratio", cfg.Cache.UserMuteIDsMemRatio, "")
+	flags.Float64("cache-user-domain-blocks-mem-ratio", cfg.Cache.UserDomainBlocksMemRatio, "")
 	flags.Float64("cache-webfinger-mem-ratio", cfg.Cache.WebfingerMemRatio, "")
 	flags.Float64("cache-web-push-subscription-mem-ratio", cfg.Cache.WebPushSubscriptionMemRatio, "")
 	flags.Float64("cache-web-push-subscription-ids-mem-ratio", cfg.Cache.WebPushSubscriptionIDsMemRatio, "")
@@ -416,7 +451,7 @@ func (cfg *Configuration) RegisterFlags(flags *pflag.FlagSet) {
 }
 
 func (cfg *Configuration) MarshalMap() map[string]any {
-	cfgmap := make(map[string]any, 195)
+	cfgmap := make(map[string]any, 213)
 	cfgmap["log-level"] = cfg.LogLevel
 	cfgmap["log-format"] = cfg.LogFormat
 	cfgmap["log-timestamp-format"] = cfg.LogTimestampFormat
@@ -465,13 +500,17 @@ func (cfg *Configuration) MarshalMap() map[string]any {
 	cfgmap["instance-subscriptions-process-every"] = cfg.InstanceSubscriptionsProcessEvery
 	cfgmap["instance-stats-mode"] = cfg.InstanceStatsMode
 	cfgmap["instance-allow-backdating-statuses"] = cfg.InstanceAllowBackdatingStatuses
+	cfgmap["instance-trends-enabled"] = cfg.InstanceTrendsEnabled
 	cfgmap["accounts-registration-open"] = cfg.AccountsRegistrationOpen
 	cfgmap["accounts-reason-required"] = cfg.AccountsReasonRequired
 	cfgmap["accounts-registration-daily-limit"] = cfg.AccountsRegistrationDailyLimit
 	cfgmap["accounts-registration-backlog-limit"] = cfg.AccountsRegistrationBacklogLimit
+	cfgmap["accounts-invites-enabled"] = cfg.AccountsInvitesEnabled
+	cfgmap["accounts-invites-admin-only"] = cfg.AccountsInvitesAdminOnly
 	cfgmap["accounts-allow-custom-css"] = cfg.AccountsAllowCustomCSS
 	cfgmap["accounts-custom-css-length"] = cfg.AccountsCustomCSSLength
 	cfgmap["accounts-max-profile-fields"] = cfg.AccountsMaxProfileFields
+	cfgmap["accounts-archive-json-max-size"] = cfg.AccountsArchiveJSONMaxSize.String()
 	cfgmap["storage-backend"] = cfg.StorageBackend
 	cfgmap["storage-local-base-path"] = cfg.StorageLocalBasePath
 	cfgmap["storage-s3-endpoint"] = cfg.StorageS3Endpoint
@@ -483,6 +522,7 @@ func (cfg *Configuration) MarshalMap() map[string]any {
 	cfgmap["storage-s3-redirect-url"] = cfg.StorageS3RedirectURL
 	cfgmap["storage-s3-bucket-lookup"] = cfg.StorageS3BucketLookup
 	cfgmap["storage-s3-key-prefix"] = cfg.StorageS3KeyPrefix
+	cfgmap["storage-fallback-backend"] = cfg.StorageFallbackBackend
 	cfgmap["statuses-max-chars"] = cfg.StatusesMaxChars
 	cfgmap["statuses-poll-max-options"] = cfg.StatusesPollMaxOptions
 	cfgmap["statuses-poll-option-max-chars"] = cfg.StatusesPollOptionMaxChars
@@ -495,6 +535,8 @@ func (cfg *Configuration) MarshalMap() map[string]any {
 	cfgmap["letsencrypt-email-address"] = cfg.LetsEncryptEmailAddress
 	cfgmap["tls-certificate-chain"] = cfg.TLSCertificateChain
 	cfgmap["tls-certificate-key"] = cfg.TLSCertificateKey
+	cfgmap["oauth-access-token-expiry"] = cfg.OAuthAccessTokenExpiry
+	cfgmap["oauth-refresh-token-expiry"] = cfg.OAuthRefreshTokenExpiry
 	cfgmap["oidc-enabled"] = cfg.OIDCEnabled
 	cfgmap["oidc-idp-name"] = cfg.OIDCIdpName
 	cfgmap["oidc-skip-verification"] = cfg.OIDCSkipVerification
@@ -542,6 +584,14 @@ func (cfg *Configuration) MarshalMap() map[string]any {
 	cfgmap["media-cleanup-every"] = cfg.Media.CleanupEvery
 	cfgmap["media-ffmpeg-pool-size"] = cfg.Media.FfmpegPoolSize
 	cfgmap["media-thumb-max-pixels"] = cfg.Media.ThumbMaxPixels
+	cfgmap["media-dedupe"] = cfg.Media.Dedupe
+	cfgmap["media-transcode-enabled"] = cfg.Media.TranscodeEnabled
+	cfgmap["media-transcode-format"] = cfg.Media.TranscodeFormat
+	cfgmap["media-transcode-max-pixels"] = cfg.Media.TranscodeMaxPixels
+	cfgmap["media-transcode-video-bitrate"] = cfg.Media.TranscodeVideoBitrate
+	cfgmap["media-transcode-audio-bitrate"] = cfg.Media.TranscodeAudioBitrate
+	cfgmap["media-preview-cards-enabled"] = cfg.Media.PreviewCardsEnabled
+	cfgmap["media-preview-cards-blocked-domains"] = cfg.Media.PreviewCardsBlockedDomains
 	cfgmap["cache-memory-target"] = cfg.Cache.MemoryTarget.String()
 	cfgmap["cache-account-mem-ratio"] = cfg.Cache.AccountMemRatio
 	cfgmap["cache-account-note-mem-ratio"] = cfg.Cache.AccountNoteMemRatio
@@ -551,6 +601,7 @@ func (cfg *Configuration) MarshalMap() map[string]any {
 	cfgmap["cache-block-mem-ratio"] = cfg.Cache.BlockMemRatio
 	cfgmap["cache-block-ids-mem-ratio"] = cfg.Cache.BlockIDsMemRatio
 	cfgmap["cache-boost-of-ids-mem-ratio"] = cfg.Cache.BoostOfIDsMemRatio
+	cfgmap["cache-card-mem-ratio"] = cfg.Cache.CardMemRatio
 	cfgmap["cache-client-mem-ratio"] = cfg.Cache.ClientMemRatio
 	cfgmap["cache-conversation-mem-ratio"] = cfg.Cache.ConversationMemRatio
 	cfgmap["cache-conversation-last-status-ids-mem-ratio"] = cfg.Cache.ConversationLastStatusIDsMemRatio
@@ -597,6 +648,7 @@ func (cfg *Configuration) MarshalMap() map[string]any {
 	cfgmap["cache-user-mem-ratio"] = cfg.Cache.UserMemRatio
 	cfgmap["cache-user-mute-mem-ratio"] = cfg.Cache.UserMuteMemRatio
 	cfgmap["cache-user-mute-ids-mem-ratio"] = cfg.Cache.UserMuteIDsMemRatio
+	cfgmap["cache-user-domain-blocks-mem-ratio"] = cfg.Cache.UserDomainBlocksMemRatio
 	cfgmap["cache-webfinger-mem-ratio"] = cfg.Cache.WebfingerMemRatio
 	cfgmap["cache-web-push-subscription-mem-ratio"] = cfg.Cache.WebPushSubscriptionMemRatio
 	cfgmap["cache-web-push-subscription-ids-mem-ratio"] = cfg.Cache.WebPushSubscriptionIDsMemRatio
@@ -610,6 +662,7 @@ func (cfg *Configuration) MarshalMap() map[string]any {
 	cfgmap["dry-run"] = cfg.AdminMediaPruneDryRun
 	cfgmap["local-only"] = cfg.AdminMediaListLocalOnly
 	cfgmap["remote-only"] = cfg.AdminMediaListRemoteOnly
+	cfgmap["to"] = cfg.AdminStorageMigrateTo
 	cfgmap["skip-db-setup"] = cfg.TestrigSkipDBSetup
 	cfgmap["skip-db-teardown"] = cfg.TestrigSkipDBTeardown
 	return cfgmap
@@ -1013,6 +1066,14 @@ func (cfg *Configuration) UnmarshalMap(cfgmap map[string]any) error {
 		}
 	}
 
+	if ival, ok := cfgmap["instance-trends-enabled"]; ok {
+		var err error
+		cfg.InstanceTrendsEnabled, err = cast.ToBoolE(ival)
+		if err != nil {
+			return fmt.Errorf("error casting %#v -> bool for 'instance-trends-enabled': %w", ival, err)
+		}
+	}
+
 	if ival, ok := cfgmap["accounts-registration-open"]; ok {
 		var err error
 		cfg.AccountsRegistrationOpen, err = cast.ToBoolE(ival)
@@ -1045,6 +1106,22 @@ func (cfg *Configuration) UnmarshalMap(cfgmap map[string]any) error {
 		}
 	}
 
+	if ival, ok := cfgmap["accounts-invites-enabled"]; ok {
+		var err error
+		cfg.AccountsInvitesEnabled, err = cast.ToBoolE(ival)
+		if err != nil {
+			return fmt.Errorf("error casting %#v -> bool for 'accounts-invites-enabled': %w", ival, err)
+		}
+	}
+
+	if ival, ok := cfgmap["accounts-invites-admin-only"]; ok {
+		var err error
+		cfg.AccountsInvitesAdminOnly, err = cast.ToBoolE(ival)
+		if err != nil {
+			return fmt.Errorf("error casting %#v -> bool for 'accounts-invites-admin-only': %w", ival, err)
+		}
+	}
+
 	if ival, ok := cfgmap["accounts-allow-custom-css"]; ok {
 		var err error
 		cfg.AccountsAllowCustomCSS, err = cast.ToBoolE(ival)
@@ -1069,6 +1146,17 @@ func (cfg *Configuration) UnmarshalMap(cfgmap map[string]any) error {
 		}
 	}
 
+	if ival, ok := cfgmap["accounts-archive-json-max-size"]; ok {
+		t, err := cast.ToStringE(ival)
+		if err != nil {
+			return fmt.Errorf("error casting %#v -> string for 'accounts-archive-json-max-size': %w", ival, err)
+		}
+		cfg.AccountsArchiveJSONMaxSize = 0x0
+		if err := cfg.AccountsArchiveJSONMaxSize.Set(t); err != nil {
+			return fmt.Errorf("error parsing %#v for 'accounts-archive-json-max-size': %w", ival, err)
+		}
+	}
+
 	if ival, ok := cfgmap["storage-backend"]; ok {
 		var err error
 		cfg.StorageBackend, err = cast.ToStringE(ival)
@@ -1157,6 +1245,14 @@ func (cfg *Configuration) UnmarshalMap(cfgmap map[string]any) error {
 		}
 	}
 
+	if ival, ok := cfgmap["storage-fallback-backend"]; ok {
+		var err error
+		cfg.StorageFallbackBackend, err = cast.ToStringE(ival)
+		if err != nil {
+			return fmt.Errorf("error casting %#v -> string for 'storage-fallback-backend': %w", ival, err)
+		}
+	}
+
 	if ival, ok := cfgmap["statuses-max-chars"]; ok {
 		var err error
 		cfg.StatusesMaxChars, err = cast.ToIntE(ival)
@@ -1253,6 +1349,22 @@ func (cfg *Configuration) UnmarshalMap(cfgmap map[string]any) error {
 		}
 	}
 
+	if ival, ok := cfgmap["oauth-access-token-expiry"]; ok {
+		var err error
+		cfg.OAuthAccessTokenExpiry, err = cast.ToDurationE(ival)
+		if err != nil {
+			return fmt.Errorf("error casting %#v -> time.Duration for 'oauth-access-token-expiry': %w", ival, err)
+		}
+	}
+
+	if ival, ok := cfgmap["oauth-refresh-token-expiry"]; ok {
+		var err error
+		cfg.OAuthRefreshTokenExpiry, err = cast.ToDurationE(ival)
+		if err != nil {
+			return fmt.Errorf("error casting %#v -> time.Duration for 'oauth-refresh-token-expiry': %w", ival, err)
+		}
+	}
+
 	if ival, ok := cfgmap["oidc-enabled"]; ok {
 		var err error
 		cfg.OIDCEnabled, err = cast.ToBoolE(ival)
@@ -1652,6 +1764,70 @@ func (cfg *Configuration) UnmarshalMap(cfgmap map[string]any) error {
 		}
 	}
 
+	if ival, ok := cfgmap["media-dedupe"]; ok {
+		var err error
+		cfg.Media.Dedupe, err = cast.ToBoolE(ival)
+		if err != nil {
+			return fmt.Errorf("error casting %#v -> bool for 'media-dedupe': %w", ival, err)
+		}
+	}
+
+	if ival, ok := cfgmap["media-transcode-enabled"]; ok {
+		var err error
+		cfg.Media.TranscodeEnabled, err = cast.ToBoolE(ival)
+		if err != nil {
+			return fmt.Errorf("error casting %#v -> bool for 'media-transcode-enabled': %w", ival, err)
+		}
+	}
+
+	if ival, ok := cfgmap["media-transcode-format"]; ok {
+		var err error
+		cfg.Media.TranscodeFormat, err = cast.ToStringE(ival)
+		if err != nil {
+			return fmt.Errorf("error casting %#v -> string for 'media-transcode-format': %w", ival, err)
+		}
+	}
+
+	if ival, ok := cfgmap["media-transcode-max-pixels"]; ok {
+		var err error
+		cfg.Media.TranscodeMaxPixels, err = cast.ToIntE(ival)
+		if err != nil {
+			return fmt.Errorf("error casting %#v -> int for 'media-transcode-max-pixels': %w", ival, err)
+		}
+	}
+
+	if ival, ok := cfgmap["media-transcode-video-bitrate"]; ok {
+		var err error
+		cfg.Media.TranscodeVideoBitrate, err = cast.ToIntE(ival)
+		if err != nil {
+			return fmt.Errorf("error casting %#v -> int for 'media-transcode-video-bitrate': %w", ival, err)
+		}
+	}
+
+	if ival, ok := cfgmap["media-transcode-audio-bitrate"]; ok {
+		var err error
+		cfg.Media.TranscodeAudioBitrate, err = cast.ToIntE(ival)
+		if err != nil {
+			return fmt.Errorf("error casting %#v -> int for 'media-transcode-audio-bitrate': %w", ival, err)
+		}
+	}
+
+	if ival, ok := cfgmap["media-preview-cards-enabled"]; ok {
+		var err error
+		cfg.Media.PreviewCardsEnabled, err = cast.ToBoolE(ival)
+		if err != nil {
+			return fmt.Errorf("error casting %#v -> bool for 'media-preview-cards-enabled': %w", ival, err)
+		}
+	}
+
+	if ival, ok := cfgmap["media-preview-cards-blocked-domains"]; ok {
+		var err error
+		cfg.Media.PreviewCardsBlockedDomains, err = toStringSlice(ival)
+		if err != nil {
+			return fmt.Errorf("error casting %#v -> []string for 'media-preview-cards-blocked-domains': %w", ival, err)
+		}
+	}
+
 	if ival, ok := cfgmap["cache-memory-target"]; ok {
 		t, err := cast.ToStringE(ival)
 		if err != nil {
@@ -1727,6 +1903,14 @@ func (cfg *Configuration) UnmarshalMap(cfgmap map[string]any) error {
 		}
 	}
 
+	if ival, ok := cfgmap["cache-card-mem-ratio"]; ok {
+		var err error
+		cfg.Cache.CardMemRatio, err = cast.ToFloat64E(ival)
+		if err != nil {
+			return fmt.Errorf("error casting %#v -> float64 for 'cache-card-mem-ratio': %w", ival, err)
+		}
+	}
+
 	if ival, ok := cfgmap["cache-client-mem-ratio"]; ok {
 		var err error
 		cfg.Cache.ClientMemRatio, err = cast.ToFloat64E(ival)
@@ -2095,6 +2279,14 @@ func (cfg *Configuration) UnmarshalMap(cfgmap map[string]any) error {
 		}
 	}
 
+	if ival, ok := cfgmap["cache-user-domain-blocks-mem-ratio"]; ok {
+		var err error
+		cfg.Cache.UserDomainBlocksMemRatio, err = cast.ToFloat64E(ival)
+		if err != nil {
+			return fmt.Errorf("error casting %#v -> float64 for 'cache-user-domain-blocks-mem-ratio': %w", ival, err)
+		}
+	}
+
 	if ival, ok := cfgmap["cache-webfinger-mem-ratio"]; ok {
 		var err error
 		cfg.Cache.WebfingerMemRatio, err = cast.ToFloat64E(ival)
@@ -2199,6 +2391,14 @@ func (cfg *Configuration) UnmarshalMap(cfgmap map[string]any) error {
 		}
 	}
 
+	if ival, ok := cfgmap["to"]; ok {
+		var err error
+		cfg.AdminStorageMigrateTo, err = cast.ToStringE(ival)
+		if err != nil {
+			return fmt.Errorf("error casting %#v -> string for 'to': %w", ival, err)
+		}
+	}
+
 	if ival, ok := cfgmap["skip-db-setup"]; ok {
 		var err error
 		cfg.TestrigSkipDBSetup, err = cast.ToBoolE(ival)
@@ -3280,6 +3480,28 @@ func GetInstanceAllowBackdatingStatuses() bool { return global.GetInstanceAllowB
 // SetInstanceAllowBackdatingStatuses safely sets the value for global configuration 'InstanceAllowBackdatingStatuses' field
 func SetInstanceAllowBackdatingStatuses(v bool) { global.SetInstanceAllowBackdatingStatuses(v) }
 
+// GetInstanceTrendsEnabled safely fetches the Configuration value for state's 'InstanceTrendsEnabled' field
+func (st *ConfigState) GetInstanceTrendsEnabled() (v bool) {
+	st.mutex.RLock()
+	v = st.config.InstanceTrendsEnabled
+	st.mutex.RUnlock()
+	return v
+}
+
+// SetInstanceTrendsEnabled safely sets the Configuration value for state's 'InstanceTrendsEnabled' field
+func (st *ConfigState) SetInstanceTrendsEnabled(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceTrendsEnabled = v
+	st.reloadToViper()
+}
+
+// GetInstanceTrendsEnabled safely fetches the value for global configuration 'InstanceTrendsEnabled' field
+func GetInstanceTrendsEnabled() bool { return global.GetInstanceTrendsEnabled() }
+
+// SetInstanceTrendsEnabled safely sets the value for global configuration 'InstanceTrendsEnabled' field
+func SetInstanceTrendsEnabled(v bool) { global.SetInstanceTrendsEnabled(v) }
+
 // GetAccountsRegistrationOpen safely fetches the Configuration value for state's 'AccountsRegistrationOpen' field
 func (st *ConfigState) GetAccountsRegistrationOpen() (v bool) {
 	st.mutex.RLock()
@@ -3368,6 +3590,50 @@ func GetAccountsRegistrationBacklogLimit() int { return global.GetAccountsRegist
 // SetAccountsRegistrationBacklogLimit safely sets the value for global configuration 'AccountsRegistrationBacklogLimit' field
 func SetAccountsRegistrationBacklogLimit(v int) { global.SetAccountsRegistrationBacklogLimit(v) }
 
+// GetAccountsInvitesEnabled safely fetches the Configuration value for state's 'AccountsInvitesEnabled' field
+func (st *ConfigState) GetAccountsInvitesEnabled() (v bool) {
+	st.mutex.RLock()
+	v = st.config.AccountsInvitesEnabled
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsInvitesEnabled safely sets the Configuration value for state's 'AccountsInvitesEnabled' field
+func (st *ConfigState) SetAccountsInvitesEnabled(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsInvitesEnabled = v
+	st.reloadToViper()
+}
+
+// GetAccountsInvitesEnabled safely fetches the value for global configuration 'AccountsInvitesEnabled' field
+func GetAccountsInvitesEnabled() bool { return global.GetAccountsInvitesEnabled() }
+
+// SetAccountsInvitesEnabled safely sets the value for global configuration 'AccountsInvitesEnabled' field
+func SetAccountsInvitesEnabled(v bool) { global.SetAccountsInvitesEnabled(v) }
+
+// GetAccountsInvitesAdminOnly safely fetches the Configuration value for state's 'AccountsInvitesAdminOnly' field
+func (st *ConfigState) GetAccountsInvitesAdminOnly() (v bool) {
+	st.mutex.RLock()
+	v = st.config.AccountsInvitesAdminOnly
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAccountsInvitesAdminOnly safely sets the Configuration value for state's 'AccountsInvitesAdminOnly' field
+func (st *ConfigState) SetAccountsInvitesAdminOnly(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsInvitesAdminOnly = v
+	st.reloadToViper()
+}
+
+// GetAccountsInvitesAdminOnly safely fetches the value for global configuration 'AccountsInvitesAdminOnly' field
+func GetAccountsInvitesAdminOnly() bool { return global.GetAccountsInvitesAdminOnly() }
+
+// SetAccountsInvitesAdminOnly safely sets the value for global configuration 'AccountsInvitesAdminOnly' field
+func SetAccountsInvitesAdminOnly(v bool) { global.SetAccountsInvitesAdminOnly(v) }
+
 // GetAccountsAllowCustomCSS safely fetches the Configuration value for state's 'AccountsAllowCustomCSS' field
 func (st *ConfigState) GetAccountsAllowCustomCSS() (v bool) {
 	st.mutex.RLock()
@@ -3434,6 +3700,28 @@ func GetAccountsMaxProfileFields() int { return global.GetAccountsMaxProfileFiel
 // SetAccountsMaxProfileFields safely sets the value for global configuration 'AccountsMaxProfileFields' field
 func SetAccountsMaxProfileFields(v int) { global.SetAccountsMaxProfileFields(v) }
 
+// GetAccountsArchiveJSONMaxSize safely fetches the Configuration value for state's 'AccountsArchiveJSONMaxSize' field
+func (st *ConfigState) GetAccountsArchiveJSONMaxSize() (v bytesize.Size) {
+	st.mutex.RLock()
+	v = st.config.AccountsArchiveJSONMaxSize
+	st.mutex.RUnlock()
+	return v
+}
+
+// SetAccountsArchiveJSONMaxSize safely sets the Configuration value for state's 'AccountsArchiveJSONMaxSize' field
+func (st *ConfigState) SetAccountsArchiveJSONMaxSize(v bytesize.Size) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AccountsArchiveJSONMaxSize = v
+	st.reloadToViper()
+}
+
+// GetAccountsArchiveJSONMaxSize safely fetches the value for global configuration 'AccountsArchiveJSONMaxSize' field
+func GetAccountsArchiveJSONMaxSize() bytesize.Size { return global.GetAccountsArchiveJSONMaxSize() }
+
+// SetAccountsArchiveJSONMaxSize safely sets the value for global configuration 'AccountsArchiveJSONMaxSize' field
+func SetAccountsArchiveJSONMaxSize(v bytesize.Size) { global.SetAccountsArchiveJSONMaxSize(v) }
+
 // GetStorageBackend safely fetches the Configuration value for state's 'StorageBackend' field
 func (st *ConfigState) GetStorageBackend() (v string) {
 	st.mutex.RLock()
@@ -3676,6 +3964,28 @@ func GetStorageS3KeyPrefix() string { return global.GetStorageS3KeyPrefix() }
 // SetStorageS3KeyPrefix safely sets the value for global configuration 'StorageS3KeyPrefix' field
 func SetStorageS3KeyPrefix(v string) { global.SetStorageS3KeyPrefix(v) }
 
+// GetStorageFallbackBackend safely fetches the Configuration value for state's 'StorageFallbackBackend' field
+func (st *ConfigState) GetStorageFallbackBackend() (v string) {
+	st.mutex.RLock()
+	v = st.config.StorageFallbackBackend
+	st.mutex.RUnlock()
+	return v
+}
+
+// SetStorageFallbackBackend safely sets the Configuration value for state's 'StorageFallbackBackend' field
+func (st *ConfigState) SetStorageFallbackBackend(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.StorageFallbackBackend = v
+	st.reloadToViper()
+}
+
+// GetStorageFallbackBackend safely fetches the value for global configuration 'StorageFallbackBackend' field
+func GetStorageFallbackBackend() string { return global.GetStorageFallbackBackend() }
+
+// SetStorageFallbackBackend safely sets the value for global configuration 'StorageFallbackBackend' field
+func SetStorageFallbackBackend(v string) { global.SetStorageFallbackBackend(v) }
+
 // GetStatusesMaxChars safely fetches the Configuration value for state's 'StatusesMaxChars' field
 func (st *ConfigState) GetStatusesMaxChars() (v int) {
 	st.mutex.RLock()
@@ -3940,6 +4250,50 @@ func GetTLSCertificateKey() string { return global.GetTLSCertificateKey() }
 // SetTLSCertificateKey safely sets the value for global configuration 'TLSCertificateKey' field
 func SetTLSCertificateKey(v string) { global.SetTLSCertificateKey(v) }
 
+// GetOAuthAccessTokenExpiry safely fetches the Configuration value for state's 'OAuthAccessTokenExpiry' field
+func (st *ConfigState) GetOAuthAccessTokenExpiry() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.OAuthAccessTokenExpiry
+	st.mutex.RUnlock()
+	return v
+}
+
+// SetOAuthAccessTokenExpiry safely sets the Configuration value for state's 'OAuthAccessTokenExpiry' field
+func (st *ConfigState) SetOAuthAccessTokenExpiry(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.OAuthAccessTokenExpiry = v
+	st.reloadToViper()
+}
+
+// GetOAuthAccessTokenExpiry safely fetches the value for global configuration 'OAuthAccessTokenExpiry' field
+func GetOAuthAccessTokenExpiry() time.Duration { return global.GetOAuthAccessTokenExpiry() }
+
+// SetOAuthAccessTokenExpiry safely sets the value for global configuration 'OAuthAccessTokenExpiry' field
+func SetOAuthAccessTokenExpiry(v time.Duration) { global.SetOAuthAccessTokenExpiry(v) }
+
+// GetOAuthRefreshTokenExpiry safely fetches the Configuration value for state's 'OAuthRefreshTokenExpiry' field
+func (st *ConfigState) GetOAuthRefreshTokenExpiry() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.OAuthRefreshTokenExpiry
+	st.mutex.RUnlock()
+	return v
+}
+
+// SetOAuthRefreshTokenExpiry safely sets the Configuration value for state's 'OAuthRefreshTokenExpiry' field
+func (st *ConfigState) SetOAuthRefreshTokenExpiry(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.OAuthRefreshTokenExpiry = v
+	st.reloadToViper()
+}
+
+// GetOAuthRefreshTokenExpiry safely fetches the value for global configuration 'OAuthRefreshTokenExpiry' field
+func GetOAuthRefreshTokenExpiry() time.Duration { return global.GetOAuthRefreshTokenExpiry() }
+
+// SetOAuthRefreshTokenExpiry safely sets the value for global configuration 'OAuthRefreshTokenExpiry' field
+func SetOAuthRefreshTokenExpiry(v time.Duration) { global.SetOAuthRefreshTokenExpiry(v) }
+
 // GetOIDCEnabled safely fetches the Configuration value for state's 'OIDCEnabled' field
 func (st *ConfigState) GetOIDCEnabled() (v bool) {
 	st.mutex.RLock()
@@ -4974,6 +5328,184 @@ func GetMediaThumbMaxPixels() int { return global.GetMediaThumbMaxPixels() }
 // SetMediaThumbMaxPixels safely sets the value for global configuration 'Media.ThumbMaxPixels' field
 func SetMediaThumbMaxPixels(v int) { global.SetMediaThumbMaxPixels(v) }
 
+// GetMediaDedupe safely fetches the Configuration value for state's 'Media.Dedupe' field
+func (st *ConfigState) GetMediaDedupe() (v bool) {
+	st.mutex.RLock()
+	v = st.config.Media.Dedupe
+	st.mutex.RUnlock()
+	return v
+}
+
+// SetMediaDedupe safely sets the Configuration value for state's 'Media.Dedupe' field
+func (st *ConfigState) SetMediaDedupe(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Media.Dedupe = v
+	st.reloadToViper()
+}
+
+// GetMediaDedupe safely fetches the value for global configuration 'Media.Dedupe' field
+func GetMediaDedupe() bool { return global.GetMediaDedupe() }
+
+// SetMediaDedupe safely sets the value for global configuration 'Media.Dedupe' field
+func SetMediaDedupe(v bool) { global.SetMediaDedupe(v) }
+
+// GetMediaTranscodeEnabled safely fetches the Configuration value for state's 'Media.TranscodeEnabled' field
+func (st *ConfigState) GetMediaTranscodeEnabled() (v bool) {
+	st.mutex.RLock()
+	v = st.config.Media.TranscodeEnabled
+	st.mutex.RUnlock()
+	return v
+}
+
+// SetMediaTranscodeEnabled safely sets the Configuration value for state's 'Media.TranscodeEnabled' field
+func (st *ConfigState) SetMediaTranscodeEnabled(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Media.TranscodeEnabled = v
+	st.reloadToViper()
+}
+
+// GetMediaTranscodeEnabled safely fetches the value for global configuration 'Media.TranscodeEnabled' field
+func GetMediaTranscodeEnabled() bool { return global.GetMediaTranscodeEnabled() }
+
+// SetMediaTranscodeEnabled safely sets the value for global configuration 'Media.TranscodeEnabled' field
+func SetMediaTranscodeEnabled(v bool) { global.SetMediaTranscodeEnabled(v) }
+
+// GetMediaTranscodeFormat safely fetches the Configuration value for state's 'Media.TranscodeFormat' field
+func (st *ConfigState) GetMediaTranscodeFormat() (v string) {
+	st.mutex.RLock()
+	v = st.config.Media.TranscodeFormat
+	st.mutex.RUnlock()
+	return v
+}
+
+// SetMediaTranscodeFormat safely sets the Configuration value for state's 'Media.TranscodeFormat' field
+func (st *ConfigState) SetMediaTranscodeFormat(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Media.TranscodeFormat = v
+	st.reloadToViper()
+}
+
+// GetMediaTranscodeFormat safely fetches the value for global configuration 'Media.TranscodeFormat' field
+func GetMediaTranscodeFormat() string { return global.GetMediaTranscodeFormat() }
+
+// SetMediaTranscodeFormat safely sets the value for global configuration 'Media.TranscodeFormat' field
+func SetMediaTranscodeFormat(v string) { global.SetMediaTranscodeFormat(v) }
+
+// GetMediaTranscodeMaxPixels safely fetches the Configuration value for state's 'Media.TranscodeMaxPixels' field
+func (st *ConfigState) GetMediaTranscodeMaxPixels() (v int) {
+	st.mutex.RLock()
+	v = st.config.Media.TranscodeMaxPixels
+	st.mutex.RUnlock()
+	return v
+}
+
+// SetMediaTranscodeMaxPixels safely sets the Configuration value for state's 'Media.TranscodeMaxPixels' field
+func (st *ConfigState) SetMediaTranscodeMaxPixels(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Media.TranscodeMaxPixels = v
+	st.reloadToViper()
+}
+
+// GetMediaTranscodeMaxPixels safely fetches the value for global configuration 'Media.TranscodeMaxPixels' field
+func GetMediaTranscodeMaxPixels() int { return global.GetMediaTranscodeMaxPixels() }
+
+// SetMediaTranscodeMaxPixels safely sets the value for global configuration 'Media.TranscodeMaxPixels' field
+func SetMediaTranscodeMaxPixels(v int) { global.SetMediaTranscodeMaxPixels(v) }
+
+// GetMediaTranscodeVideoBitrate safely fetches the Configuration value for state's 'Media.TranscodeVideoBitrate' field
+func (st *ConfigState) GetMediaTranscodeVideoBitrate() (v int) {
+	st.mutex.RLock()
+	v = st.config.Media.TranscodeVideoBitrate
+	st.mutex.RUnlock()
+	return v
+}
+
+// SetMediaTranscodeVideoBitrate safely sets the Configuration value for state's 'Media.TranscodeVideoBitrate' field
+func (st *ConfigState) SetMediaTranscodeVideoBitrate(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Media.TranscodeVideoBitrate = v
+	st.reloadToViper()
+}
+
+// GetMediaTranscodeVideoBitrate safely fetches the value for global configuration 'Media.TranscodeVideoBitrate' field
+func GetMediaTranscodeVideoBitrate() int { return global.GetMediaTranscodeVideoBitrate() }
+
+// SetMediaTranscodeVideoBitrate safely sets the value for global configuration 'Media.TranscodeVideoBitrate' field
+func SetMediaTranscodeVideoBitrate(v int) { global.SetMediaTranscodeVideoBitrate(v) }
+
+// GetMediaTranscodeAudioBitrate safely fetches the Configuration value for state's 'Media.TranscodeAudioBitrate' field
+func (st *ConfigState) GetMediaTranscodeAudioBitrate() (v int) {
+	st.mutex.RLock()
+	v = st.config.Media.TranscodeAudioBitrate
+	st.mutex.RUnlock()
+	return v
+}
+
+// SetMediaTranscodeAudioBitrate safely sets the Configuration value for state's 'Media.TranscodeAudioBitrate' field
+func (st *ConfigState) SetMediaTranscodeAudioBitrate(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Media.TranscodeAudioBitrate = v
+	st.reloadToViper()
+}
+
+// GetMediaTranscodeAudioBitrate safely fetches the value for global configuration 'Media.TranscodeAudioBitrate' field
+func GetMediaTranscodeAudioBitrate() int { return global.GetMediaTranscodeAudioBitrate() }
+
+// SetMediaTranscodeAudioBitrate safely sets the value for global configuration 'Media.TranscodeAudioBitrate' field
+func SetMediaTranscodeAudioBitrate(v int) { global.SetMediaTranscodeAudioBitrate(v) }
+
+// GetMediaPreviewCardsEnabled safely fetches the Configuration value for state's 'Media.PreviewCardsEnabled' field
+func (st *ConfigState) GetMediaPreviewCardsEnabled() (v bool) {
+	st.mutex.RLock()
+	v = st.config.Media.PreviewCardsEnabled
+	st.mutex.RUnlock()
+	return v
+}
+
+// SetMediaPreviewCardsEnabled safely sets the Configuration value for state's 'Media.PreviewCardsEnabled' field
+func (st *ConfigState) SetMediaPreviewCardsEnabled(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Media.PreviewCardsEnabled = v
+	st.reloadToViper()
+}
+
+// GetMediaPreviewCardsEnabled safely fetches the value for global configuration 'Media.PreviewCardsEnabled' field
+func GetMediaPreviewCardsEnabled() bool { return global.GetMediaPreviewCardsEnabled() }
+
+// SetMediaPreviewCardsEnabled safely sets the value for global configuration 'Media.PreviewCardsEnabled' field
+func SetMediaPreviewCardsEnabled(v bool) { global.SetMediaPreviewCardsEnabled(v) }
+
+// GetMediaPreviewCardsBlockedDomains safely fetches the Configuration value for state's 'Media.PreviewCardsBlockedDomains' field
+func (st *ConfigState) GetMediaPreviewCardsBlockedDomains() (v []string) {
+	st.mutex.RLock()
+	v = st.config.Media.PreviewCardsBlockedDomains
+	st.mutex.RUnlock()
+	return v
+}
+
+// SetMediaPreviewCardsBlockedDomains safely sets the Configuration value for state's 'Media.PreviewCardsBlockedDomains' field
+func (st *ConfigState) SetMediaPreviewCardsBlockedDomains(v []string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Media.PreviewCardsBlockedDomains = v
+	st.reloadToViper()
+}
+
+// GetMediaPreviewCardsBlockedDomains safely fetches the value for global configuration 'Media.PreviewCardsBlockedDomains' field
+func GetMediaPreviewCardsBlockedDomains() []string {
+	return global.GetMediaPreviewCardsBlockedDomains()
+}
+
+// SetMediaPreviewCardsBlockedDomains safely sets the value for global configuration 'Media.PreviewCardsBlockedDomains' field
+func SetMediaPreviewCardsBlockedDomains(v []string) { global.SetMediaPreviewCardsBlockedDomains(v) }
+
 // GetCacheMemoryTarget safely fetches the Configuration value for state's 'Cache.MemoryTarget' field
 func (st *ConfigState) GetCacheMemoryTarget() (v bytesize.Size) {
 	st.mutex.RLock()
@@ -5172,6 +5704,28 @@ func GetCacheBoostOfIDsMemRatio() float64 { return global.GetCacheBoostOfIDsMemR
 // SetCacheBoostOfIDsMemRatio safely sets the value for global configuration 'Cache.BoostOfIDsMemRatio' field
 func SetCacheBoostOfIDsMemRatio(v float64) { global.SetCacheBoostOfIDsMemRatio(v) }
 
+// GetCacheCardMemRatio safely fetches the Configuration value for state's 'Cache.CardMemRatio' field
+func (st *ConfigState) GetCacheCardMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.CardMemRatio
+	st.mutex.RUnlock()
+	return v
+}
+
+// SetCacheCardMemRatio safely sets the Configuration value for state's 'Cache.CardMemRatio' field
+func (st *ConfigState) SetCacheCardMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.CardMemRatio = v
+	st.reloadToViper()
+}
+
+// GetCacheCardMemRatio safely fetches the value for global configuration 'Cache.CardMemRatio' field
+func GetCacheCardMemRatio() float64 { return global.GetCacheCardMemRatio() }
+
+// SetCacheCardMemRatio safely sets the value for global configuration 'Cache.CardMemRatio' field
+func SetCacheCardMemRatio(v float64) { global.SetCacheCardMemRatio(v) }
+
 // GetCacheClientMemRatio safely fetches the Configuration value for state's 'Cache.ClientMemRatio' field
 func (st *ConfigState) GetCacheClientMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -6196,6 +6750,28 @@ func GetCacheUserMuteIDsMemRatio() float64 { return global.GetCacheUserMuteIDsMe
 // SetCacheUserMuteIDsMemRatio safely sets the value for global configuration 'Cache.UserMuteIDsMemRatio' field
 func SetCacheUserMuteIDsMemRatio(v float64) { global.SetCacheUserMuteIDsMemRatio(v) }
 
+// GetCacheUserDomainBlocksMemRatio safely fetches the Configuration value for state's 'Cache.UserDomainBlocksMemRatio' field
+func (st *ConfigState) GetCacheUserDomainBlocksMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.UserDomainBlocksMemRatio
+	st.mutex.RUnlock()
+	return v
+}
+
+// SetCacheUserDomainBlocksMemRatio safely sets the Configuration value for state's 'Cache.UserDomainBlocksMemRatio' field
+func (st *ConfigState) SetCacheUserDomainBlocksMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.UserDomainBlocksMemRatio = v
+	st.reloadToViper()
+}
+
+// GetCacheUserDomainBlocksMemRatio safely fetches the value for global configuration 'Cache.UserDomainBlocksMemRatio' field
+func GetCacheUserDomainBlocksMemRatio() float64 { return global.GetCacheUserDomainBlocksMemRatio() }
+
+// SetCacheUserDomainBlocksMemRatio safely sets the value for global configuration 'Cache.UserDomainBlocksMemRatio' field
+func SetCacheUserDomainBlocksMemRatio(v float64) { global.SetCacheUserDomainBlocksMemRatio(v) }
+
 // GetCacheWebfingerMemRatio safely fetches the Configuration value for state's 'Cache.WebfingerMemRatio' field
 func (st *ConfigState) GetCacheWebfingerMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -6488,6 +7064,28 @@ func GetAdminMediaListRemoteOnly() bool { return global.GetAdminMediaListRemoteO
 // SetAdminMediaListRemoteOnly safely sets the value for global configuration 'AdminMediaListRemoteOnly' field
 func SetAdminMediaListRemoteOnly(v bool) { global.SetAdminMediaListRemoteOnly(v) }
 
+// GetAdminStorageMigrateTo safely fetches the Configuration value for state's 'AdminStorageMigrateTo' field
+func (st *ConfigState) GetAdminStorageMigrateTo() (v string) {
+	st.mutex.RLock()
+	v = st.config.AdminStorageMigrateTo
+	st.mutex.RUnlock()
+	return v
+}
+
+// SetAdminStorageMigrateTo safely sets the Configuration value for state's 'AdminStorageMigrateTo' field
+func (st *ConfigState) SetAdminStorageMigrateTo(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminStorageMigrateTo = v
+	st.reloadToViper()
+}
+
+// GetAdminStorageMigrateTo safely fetches the value for global configuration 'AdminStorageMigrateTo' field
+func GetAdminStorageMigrateTo() string { return global.GetAdminStorageMigrateTo() }
+
+// SetAdminStorageMigrateTo safely sets the value for global configuration 'AdminStorageMigrateTo' field
+func SetAdminStorageMigrateTo(v string) { global.SetAdminStorageMigrateTo(v) }
+
 // GetTestrigSkipDBSetup safely fetches the Configuration value for state's 'TestrigSkipDBSetup' field
 func (st *ConfigState) GetTestrigSkipDBSetup() (v bool) {
 	st.mutex.RLock()
@@ -6543,6 +7141,7 @@ func (st *ConfigState) GetTotalOfMemRatios() (total float64) {
 	total += st.config.Cache.BlockMemRatio
 	total += st.config.Cache.BlockIDsMemRatio
 	total += st.config.Cache.BoostOfIDsMemRatio
+	total += st.config.Cache.CardMemRatio
 	total += st.config.Cache.ClientMemRatio
 	total += st.config.Cache.ConversationMemRatio
 	total += st.config.Cache.ConversationLastStatusIDsMemRatio
@@ -6589,6 +7188,7 @@ func (st *ConfigState) GetTotalOfMemRatios() (total float64) {
 	total += st.config.Cache.UserMemRatio
 	total += st.config.Cache.UserMuteMemRatio
 	total += st.config.Cache.UserMuteIDsMemRatio
+	total += st.config.Cache.UserDomainBlocksMemRatio
 	total += st.config.Cache.WebfingerMemRatio
 	total += st.config.Cache.WebPushSubscriptionMemRatio
 	total += st.config.Cache.WebPushSubscriptionIDsMemRatio
@@ -6894,6 +7494,94 @@ func flattenConfigMap(cfgmap map[string]any) {
 		}
 	}
 
+	for _, key := range [][]string{
+		{"media", "dedupe"},
+	} {
+		ival, ok := mapGet(cfgmap, key...)
+		if ok {
+			cfgmap["media-dedupe"] = ival
+			nestedKeys[key[0]] = struct{}{}
+			break
+		}
+	}
+
+	for _, key := range [][]string{
+		{"media", "transcode-enabled"},
+	} {
+		ival, ok := mapGet(cfgmap, key...)
+		if ok {
+			cfgmap["media-transcode-enabled"] = ival
+			nestedKeys[key[0]] = struct{}{}
+			break
+		}
+	}
+
+	for _, key := range [][]string{
+		{"media", "transcode-format"},
+	} {
+		ival, ok := mapGet(cfgmap, key...)
+		if ok {
+			cfgmap["media-transcode-format"] = ival
+			nestedKeys[key[0]] = struct{}{}
+			break
+		}
+	}
+
+	for _, key := range [][]string{
+		{"media", "transcode-max-pixels"},
+	} {
+		ival, ok := mapGet(cfgmap, key...)
+		if ok {
+			cfgmap["media-transcode-max-pixels"] = ival
+			nestedKeys[key[0]] = struct{}{}
+			break
+		}
+	}
+
+	for _, key := range [][]string{
+		{"media", "transcode-video-bitrate"},
+	} {
+		ival, ok := mapGet(cfgmap, key...)
+		if ok {
+			cfgmap["media-transcode-video-bitrate"] = ival
+			nestedKeys[key[0]] = struct{}{}
+			break
+		}
+	}
+
+	for _, key := range [][]string{
+		{"media", "transcode-audio-bitrate"},
+	} {
+		ival, ok := mapGet(cfgmap, key...)
+		if ok {
+			cfgmap["media-transcode-audio-bitrate"] = ival
+			nestedKeys[key[0]] = struct{}{}
+			break
+		}
+	}
+
+	for _, key := range [][]string{
+		{"media", "preview-cards-enabled"},
+	} {
+		ival, ok := mapGet(cfgmap, key...)
+		if ok {
+			cfgmap["media-preview-cards-enabled"] = ival
+			nestedKeys[key[0]] = struct{}{}
+			break
+		}
+	}
+
+	for _, key := range [][]string{
+		{"media", "preview-cards-blocked-domains"},
+	} {
+		ival, ok := mapGet(cfgmap, key...)
+		if ok {
+			cfgmap["media-preview-cards-blocked-domains"] = ival
+			nestedKeys[key[0]] = struct{}{}
+			break
+		}
+	}
+
 	for _, key := range [][]string{
 		{"cache", "memory-target"},
 	} {
@@ -6993,6 +7681,17 @@ func flattenConfigMap(cfgmap map[string]any) {
 		}
 	}
 
+	for _, key := range [][]string{
+		{"cache", "card-mem-ratio"},
+	} {
+		ival, ok := mapGet(cfgmap, key...)
+		if ok {
+			cfgmap["cache-card-mem-ratio"] = ival
+			nestedKeys[key[0]] = struct{}{}
+			break
+		}
+	}
+
 	for _, key := range [][]string{
 		{"cache", "client-mem-ratio"},
 	} {
@@ -7499,6 +8198,17 @@ func flattenConfigMap(cfgmap map[string]any) {
 		}
 	}
 
+	for _, key := range [][]string{
+		{"cache", "user-domain-blocks-mem-ratio"},
+	} {
+		ival, ok := mapGet(cfgmap, key...)
+		if ok {
+			cfgmap["cache-user-domain-blocks-mem-ratio"] = ival
+			nestedKeys[key[0]] = struct{}{}
+			break
+		}
+	}
+
 	for _, key := range [][]string{
 		{"cache", "webfinger-mem-ratio"},
 	} {
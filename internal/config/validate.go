@@ -182,5 +182,31 @@ func Validate() error {
 		log.Warnf(nil, "%s larger than max recommended thumbsize %d", MediaThumbMaxPixelsFlag, maxThumbRecc)
 	}
 
+	// Media transcode settings,
+	// only checked when enabled.
+	if GetMediaTranscodeEnabled() {
+		switch format := GetMediaTranscodeFormat(); format {
+		case MediaTranscodeFormatMP4, MediaTranscodeFormatWebM:
+			// No problem.
+
+		default:
+			errf("%s must be set to either mp4 or webm, provided value was %s",
+				MediaTranscodeFormatFlag, format,
+			)
+		}
+
+		if GetMediaTranscodeMaxPixels() < minThumb {
+			errf("%s < 32 is not a useable video size", MediaTranscodeMaxPixelsFlag)
+		}
+
+		if GetMediaTranscodeVideoBitrate() <= 0 {
+			errf("%s must be greater than 0", MediaTranscodeVideoBitrateFlag)
+		}
+
+		if GetMediaTranscodeAudioBitrate() <= 0 {
+			errf("%s must be greater than 0", MediaTranscodeAudioBitrateFlag)
+		}
+	}
+
 	return errs.Combine()
 }
@@ -49,6 +49,9 @@ type Account interface {
 	// GetAccountByPubkeyID returns one account with the given public key URI (ID).
 	GetAccountByPubkeyID(ctx context.Context, id string) (*gtsmodel.Account, error)
 
+	// GetAccountByEd25519PubkeyID returns one account with the given Ed25519 public key URI (ID).
+	GetAccountByEd25519PubkeyID(ctx context.Context, id string) (*gtsmodel.Account, error)
+
 	// GetOneAccountByInboxURI returns one account with the given inbox_uri.
 	// If more than one account has the given URL, ErrMultipleEntries will be returned.
 	GetOneAccountByInboxURI(ctx context.Context, uri string) (*gtsmodel.Account, error)
@@ -136,6 +139,10 @@ type Account interface {
 	// Update local account settings.
 	UpdateAccountSettings(ctx context.Context, settings *gtsmodel.AccountSettings, columns ...string) error
 
+	// GetEmailDigestAccountSettings gets settings of all local
+	// accounts that have daily or weekly email digests enabled.
+	GetEmailDigestAccountSettings(ctx context.Context) ([]*gtsmodel.AccountSettings, error)
+
 	// PopulateAccountStats either creates account stats for the given
 	// account by performing COUNT(*) database queries, or retrieves
 	// existing stats from the database, and attaches stats to account.
@@ -0,0 +1,76 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+)
+
+// Announcement contains functions for managing
+// instance announcements, and accounts' reads
+// of and reactions to them, in the database.
+type Announcement interface {
+	// GetAnnouncementByID returns the announcement with the given ID.
+	GetAnnouncementByID(ctx context.Context, id string) (*gtsmodel.Announcement, error)
+
+	// GetAnnouncements returns all announcements,
+	// including unpublished ones, newest first.
+	GetAnnouncements(ctx context.Context) ([]*gtsmodel.Announcement, error)
+
+	// GetActiveAnnouncements returns all published announcements
+	// which have not yet ended, in order of publication.
+	GetActiveAnnouncements(ctx context.Context) ([]*gtsmodel.Announcement, error)
+
+	// GetScheduledAnnouncements returns all announcements
+	// which are scheduled for, but not yet, published.
+	GetScheduledAnnouncements(ctx context.Context) ([]*gtsmodel.Announcement, error)
+
+	// PutAnnouncement puts the given announcement in the database.
+	PutAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement) error
+
+	// UpdateAnnouncement updates the given announcement in the database.
+	// If no columns are specified, every column is updated.
+	UpdateAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement, columns ...string) error
+
+	// DeleteAnnouncementByID deletes the announcement with
+	// the given ID, along with any reads of and reactions to it.
+	DeleteAnnouncementByID(ctx context.Context, id string) error
+
+	// GetReadAnnouncementIDs returns the IDs of all
+	// announcements read by the given account ID.
+	GetReadAnnouncementIDs(ctx context.Context, accountID string) ([]string, error)
+
+	// PutAnnouncementRead puts the given announcement read in the database.
+	PutAnnouncementRead(ctx context.Context, read *gtsmodel.AnnouncementRead) error
+
+	// GetAnnouncementReactions returns all reactions
+	// to the given announcement ID, oldest first.
+	GetAnnouncementReactions(ctx context.Context, announcementID string) ([]*gtsmodel.AnnouncementReaction, error)
+
+	// GetAnnouncementReaction returns the reaction to the given
+	// announcement ID from the given account ID with the given name.
+	GetAnnouncementReaction(ctx context.Context, announcementID string, accountID string, name string) (*gtsmodel.AnnouncementReaction, error)
+
+	// PutAnnouncementReaction puts the given announcement reaction in the database.
+	PutAnnouncementReaction(ctx context.Context, reaction *gtsmodel.AnnouncementReaction) error
+
+	// DeleteAnnouncementReactionByID deletes the announcement reaction with the given ID.
+	DeleteAnnouncementReactionByID(ctx context.Context, id string) error
+}
@@ -19,6 +19,7 @@
 
 import (
 	"context"
+	"time"
 
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
 	"code.superseriousbusiness.org/gotosocial/internal/paging"
@@ -79,4 +80,25 @@ type Application interface {
 	// DeleteTokensByClientID deletes all tokens
 	// with the given clientID from the database.
 	DeleteTokensByClientID(ctx context.Context, clientID string) error
+
+	// GetDeviceAuthorizationByDeviceCode fetches the device authorization with the given device code.
+	GetDeviceAuthorizationByDeviceCode(ctx context.Context, deviceCode string) (*gtsmodel.DeviceAuthorization, error)
+
+	// GetDeviceAuthorizationByUserCode fetches the device authorization with the given user code.
+	GetDeviceAuthorizationByUserCode(ctx context.Context, userCode string) (*gtsmodel.DeviceAuthorization, error)
+
+	// PutDeviceAuthorization puts the given device authorization in the database.
+	PutDeviceAuthorization(ctx context.Context, deviceAuth *gtsmodel.DeviceAuthorization) error
+
+	// UpdateDeviceAuthorization updates the given device authorization. Update all columns if no specific columns given.
+	UpdateDeviceAuthorization(ctx context.Context, deviceAuth *gtsmodel.DeviceAuthorization, columns ...string) error
+
+	// DeleteDeviceAuthorizationByID deletes the device authorization with the given ID.
+	// Returns db.ErrNoEntries if there was no such device authorization to delete, which
+	// lets callers consuming a device authorization know whether it was them who did so.
+	DeleteDeviceAuthorizationByID(ctx context.Context, id string) error
+
+	// DeleteExpiredDeviceAuthorizations deletes all device
+	// authorizations that expired before the given time.
+	DeleteExpiredDeviceAuthorizations(ctx context.Context, before time.Time) error
 }
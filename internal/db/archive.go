@@ -0,0 +1,48 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+)
+
+// Archive contains functions for managing
+// account data archives in the database.
+type Archive interface {
+	// GetArchiveByID returns the archive with the given ID.
+	GetArchiveByID(ctx context.Context, id string) (*gtsmodel.Archive, error)
+
+	// GetLatestArchive returns the most recently
+	// requested archive of the given account.
+	GetLatestArchive(ctx context.Context, accountID string) (*gtsmodel.Archive, error)
+
+	// GetArchives returns all archives of the given account, newest first.
+	GetArchives(ctx context.Context, accountID string) ([]*gtsmodel.Archive, error)
+
+	// PutArchive puts the given archive in the database.
+	PutArchive(ctx context.Context, archive *gtsmodel.Archive) error
+
+	// UpdateArchive updates the given archive in the database. If no
+	// columns are specified, every column is updated.
+	UpdateArchive(ctx context.Context, archive *gtsmodel.Archive, columns ...string) error
+
+	// DeleteArchiveByID deletes the archive with the given ID.
+	DeleteArchiveByID(ctx context.Context, id string) error
+}
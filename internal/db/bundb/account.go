@@ -212,6 +212,20 @@ func(account *gtsmodel.Account) error {
 	)
 }
 
+func (a *accountDB) GetAccountByEd25519PubkeyID(ctx context.Context, id string) (*gtsmodel.Account, error) {
+	return a.getAccount(
+		ctx,
+		"Ed25519PublicKeyURI",
+		func(account *gtsmodel.Account) error {
+			return a.db.NewSelect().
+				Model(account).
+				Where("? = ?", bun.Ident("account.ed25519_public_key_uri"), id).
+				Scan(ctx)
+		},
+		id,
+	)
+}
+
 func (a *accountDB) GetOneAccountByInboxURI(ctx context.Context, inboxURI string) (*gtsmodel.Account, error) {
 	// Select IDs of all accounts
 	// with this inbox_uri.
@@ -483,7 +497,24 @@ func (a *accountDB) GetAccounts(
 		useAccountIDIn = true
 	}
 
-	// TODO: invitedBy
+	if invitedBy != "" {
+		// Get only accounts that signed up
+		// with an invite created by invitedBy.
+		inviteIDs, err := a.getInviteIDs(ctx, invitedBy)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := lazyLoadUsers(); err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			if slices.Contains(inviteIDs, user.InviteID) {
+				accountIDIn = append(accountIDIn, user.AccountID)
+			}
+		}
+		useAccountIDIn = true
+	}
 
 	if username != "" {
 		q = q.Where("? = ?", bun.Ident("account.username"), username)
@@ -605,6 +636,21 @@ func (a *accountDB) GetAccounts(
 	return a.state.DB.GetAccountsByIDs(ctx, accountIDs)
 }
 
+// getInviteIDs returns the IDs of all
+// invites created by the given account.
+func (a *accountDB) getInviteIDs(ctx context.Context, accountID string) ([]string, error) {
+	var inviteIDs []string
+	if err := a.db.
+		NewSelect().
+		Table("invites").
+		Column("id").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Scan(ctx, &inviteIDs); err != nil {
+		return nil, fmt.Errorf("error getting invite IDs: %w", err)
+	}
+	return inviteIDs, nil
+}
+
 func (a *accountDB) getAccount(
 	ctx context.Context,
 	lookup string,
@@ -761,8 +807,12 @@ func (a *accountDB) PutAccount(ctx context.Context, account *gtsmodel.Account) e
 			}
 
 			// insert the account
-			_, err := tx.NewInsert().Model(account).Exec(ctx)
-			return err
+			if _, err := tx.NewInsert().Model(account).Exec(ctx); err != nil {
+				return err
+			}
+
+			// index the account for search
+			return indexAccount(ctx, tx, account)
 		})
 	})
 }
@@ -802,12 +852,24 @@ func (a *accountDB) UpdateAccount(ctx context.Context, account *gtsmodel.Account
 			}
 
 			// update the account
-			_, err := tx.NewUpdate().
+			if _, err := tx.NewUpdate().
 				Model(account).
 				Where("? = ?", bun.Ident("account.id"), account.ID).
 				Column(columns...).
-				Exec(ctx)
-			return err
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// reindex the account for search
+			// if searchable text changed
+			if len(columns) == 0 ||
+				slices.Contains(columns, "username") ||
+				slices.Contains(columns, "display_name") ||
+				slices.Contains(columns, "note") {
+				return indexAccount(ctx, tx, account)
+			}
+
+			return nil
 		})
 	})
 }
@@ -830,6 +892,11 @@ func (a *accountDB) DeleteAccount(ctx context.Context, id string) error {
 			return err
 		}
 
+		// clear out search index entry
+		if err := unindexAccount(ctx, tx, id); err != nil {
+			return err
+		}
+
 		// delete the account
 		_, err := tx.
 			NewDelete().
@@ -1191,6 +1258,32 @@ func (a *accountDB) UpdateAccountSettings(
 	})
 }
 
+func (a *accountDB) GetEmailDigestAccountSettings(ctx context.Context) ([]*gtsmodel.AccountSettings, error) {
+	var accountIDs []string
+	if err := a.db.
+		NewSelect().
+		Table("account_settings").
+		Column("account_id").
+		Where("? IN (?)", bun.Ident("email_notifications"), bun.In([]gtsmodel.EmailNotifications{
+			gtsmodel.EmailNotificationsDaily,
+			gtsmodel.EmailNotificationsWeekly,
+		})).
+		Scan(ctx, &accountIDs); err != nil {
+		return nil, err
+	}
+
+	settings := make([]*gtsmodel.AccountSettings, 0, len(accountIDs))
+	for _, accountID := range accountIDs {
+		s, err := a.GetAccountSettings(ctx, accountID)
+		if err != nil {
+			return nil, err
+		}
+		settings = append(settings, s)
+	}
+
+	return settings, nil
+}
+
 func (a *accountDB) PopulateAccountStats(ctx context.Context, account *gtsmodel.Account) error {
 	if account.Stats != nil {
 		// Already populated!
@@ -19,6 +19,7 @@
 
 import (
 	"context"
+	"crypto/ed25519"
 	"crypto/rand"
 	"crypto/rsa"
 	"errors"
@@ -114,6 +115,12 @@ func (a *adminDB) NewSignup(ctx context.Context, newSignup gtsmodel.NewSignup) (
 			return nil, err
 		}
 
+		edPubKey, edPrivKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			err := gtserror.Newf("error creating new ed25519 private key: %w", err)
+			return nil, err
+		}
+
 		account = &gtsmodel.Account{
 			ID:                           accountID,
 			Username:                     newSignup.Username,
@@ -125,10 +132,14 @@ func (a *adminDB) NewSignup(ctx context.Context, newSignup gtsmodel.NewSignup) (
 			FollowingURI:                 uris.FollowingURI,
 			FollowersURI:                 uris.FollowersURI,
 			FeaturedCollectionURI:        uris.FeaturedCollectionURI,
+			FeaturedTagsURI:              uris.FeaturedTagsURI,
 			ActorType:                    gtsmodel.AccountActorTypePerson,
 			PrivateKey:                   privKey,
 			PublicKey:                    &privKey.PublicKey,
 			PublicKeyURI:                 uris.PublicKeyURI,
+			Ed25519PrivateKey:            edPrivKey,
+			Ed25519PublicKey:             edPubKey,
+			Ed25519PublicKeyURI:          uris.Ed25519PublicKeyURI,
 			HidesCcPublicFromUnauthedWeb: util.Ptr(true), // GtS default to hide unlisted.
 		}
 
@@ -207,6 +218,7 @@ func (a *adminDB) NewSignup(ctx context.Context, newSignup gtsmodel.NewSignup) (
 		Account:                account,
 		EncryptedPassword:      string(encryptedPassword),
 		SignUpIP:               newSignup.SignUpIP.To4(),
+		InviteID:               newSignup.InviteID,
 		Reason:                 newSignup.Reason,
 		Locale:                 newSignup.Locale,
 		UnconfirmedEmail:       newSignup.Email,
@@ -265,6 +277,12 @@ func (a *adminDB) CreateInstanceAccount(ctx context.Context) error {
 		return err
 	}
 
+	edPubKey, edPrivKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		log.Errorf(ctx, "error creating new ed25519 key: %s", err)
+		return err
+	}
+
 	newAccountURIs := uris.GenerateURIsForAccount(username)
 	acct := &gtsmodel.Account{
 		ID:                    id.NewRandomULID(),
@@ -274,6 +292,9 @@ func (a *adminDB) CreateInstanceAccount(ctx context.Context) error {
 		PrivateKey:            key,
 		PublicKey:             &key.PublicKey,
 		PublicKeyURI:          newAccountURIs.PublicKeyURI,
+		Ed25519PrivateKey:     edPrivKey,
+		Ed25519PublicKey:      edPubKey,
+		Ed25519PublicKeyURI:   newAccountURIs.Ed25519PublicKeyURI,
 		ActorType:             gtsmodel.AccountActorTypeService,
 		URI:                   newAccountURIs.UserURI,
 		InboxURI:              newAccountURIs.InboxURI,
@@ -281,6 +302,7 @@ func (a *adminDB) CreateInstanceAccount(ctx context.Context) error {
 		FollowersURI:          newAccountURIs.FollowersURI,
 		FollowingURI:          newAccountURIs.FollowingURI,
 		FeaturedCollectionURI: newAccountURIs.FeaturedCollectionURI,
+		FeaturedTagsURI:       newAccountURIs.FeaturedTagsURI,
 	}
 
 	// insert the new account!
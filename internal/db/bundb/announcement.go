@@ -0,0 +1,301 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type announcementDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (a *announcementDB) GetAnnouncementByID(ctx context.Context, id string) (*gtsmodel.Announcement, error) {
+	announcement := new(gtsmodel.Announcement)
+
+	if err := a.db.
+		NewSelect().
+		Model(announcement).
+		Where("? = ?", bun.Ident("announcement.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return announcement, nil
+	}
+
+	if err := a.populateAnnouncement(ctx, announcement); err != nil {
+		return nil, err
+	}
+
+	return announcement, nil
+}
+
+func (a *announcementDB) GetAnnouncements(ctx context.Context) ([]*gtsmodel.Announcement, error) {
+	return a.getAnnouncements(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Order("announcement.id DESC")
+	})
+}
+
+func (a *announcementDB) GetActiveAnnouncements(ctx context.Context) ([]*gtsmodel.Announcement, error) {
+	now := time.Now()
+	return a.getAnnouncements(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.
+			Where("? IS NOT NULL", bun.Ident("announcement.published_at")).
+			WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+				return q.
+					Where("? IS NULL", bun.Ident("announcement.ends_at")).
+					WhereOr("? > ?", bun.Ident("announcement.ends_at"), now)
+			}).
+			Order("announcement.published_at ASC")
+	})
+}
+
+func (a *announcementDB) GetScheduledAnnouncements(ctx context.Context) ([]*gtsmodel.Announcement, error) {
+	return a.getAnnouncements(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.
+			Where("? IS NULL", bun.Ident("announcement.published_at")).
+			Where("? IS NOT NULL", bun.Ident("announcement.scheduled_at")).
+			Order("announcement.scheduled_at ASC")
+	})
+}
+
+func (a *announcementDB) getAnnouncements(ctx context.Context, where func(*bun.SelectQuery) *bun.SelectQuery) ([]*gtsmodel.Announcement, error) {
+	var announcements []*gtsmodel.Announcement
+
+	q := a.db.
+		NewSelect().
+		Model(&announcements)
+
+	if err := where(q).Scan(ctx); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return announcements, nil
+	}
+
+	for _, announcement := range announcements {
+		if err := a.populateAnnouncement(ctx, announcement); err != nil {
+			return nil, err
+		}
+	}
+
+	return announcements, nil
+}
+
+func (a *announcementDB) populateAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement) error {
+	if len(announcement.EmojiIDs) == 0 ||
+		len(announcement.Emojis) == len(announcement.EmojiIDs) {
+		// Nothing to populate.
+		return nil
+	}
+
+	var err error
+	announcement.Emojis, err = a.state.DB.GetEmojisByIDs(
+		gtscontext.SetBarebones(ctx),
+		announcement.EmojiIDs,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error populating announcement emojis: %w", err)
+	}
+
+	return nil
+}
+
+func (a *announcementDB) PutAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement) error {
+	_, err := a.db.
+		NewInsert().
+		Model(announcement).
+		Exec(ctx)
+	return err
+}
+
+func (a *announcementDB) UpdateAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement, columns ...string) error {
+	announcement.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := a.db.
+		NewUpdate().
+		Model(announcement).
+		Column(columns...).
+		Where("? = ?", bun.Ident("announcement.id"), announcement.ID).
+		Exec(ctx)
+	return err
+}
+
+func (a *announcementDB) DeleteAnnouncementByID(ctx context.Context, id string) error {
+	return a.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Delete all reads of
+		// and reactions to the
+		// announcement first.
+		for _, table := range []string{
+			"announcement_reads",
+			"announcement_reactions",
+		} {
+			if _, err := tx.
+				NewDelete().
+				Table(table).
+				Where("? = ?", bun.Ident("announcement_id"), id).
+				Exec(ctx); err != nil &&
+				!errors.Is(err, db.ErrNoEntries) {
+				return err
+			}
+		}
+
+		if _, err := tx.
+			NewDelete().
+			Table("announcements").
+			Where("? = ?", bun.Ident("id"), id).
+			Exec(ctx); err != nil &&
+			!errors.Is(err, db.ErrNoEntries) {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (a *announcementDB) GetReadAnnouncementIDs(ctx context.Context, accountID string) ([]string, error) {
+	var ids []string
+
+	if err := a.db.
+		NewSelect().
+		Table("announcement_reads").
+		Column("announcement_id").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Scan(ctx, &ids); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+func (a *announcementDB) PutAnnouncementRead(ctx context.Context, read *gtsmodel.AnnouncementRead) error {
+	_, err := a.db.
+		NewInsert().
+		Model(read).
+		Exec(ctx)
+	return err
+}
+
+func (a *announcementDB) GetAnnouncementReactions(ctx context.Context, announcementID string) ([]*gtsmodel.AnnouncementReaction, error) {
+	var reactions []*gtsmodel.AnnouncementReaction
+
+	if err := a.db.
+		NewSelect().
+		Model(&reactions).
+		Where("? = ?", bun.Ident("announcement_reaction.announcement_id"), announcementID).
+		Order("announcement_reaction.id ASC").
+		Scan(ctx); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return nil, err
+	}
+
+	for _, reaction := range reactions {
+		if err := a.populateAnnouncementReaction(ctx, reaction); err != nil {
+			return nil, err
+		}
+	}
+
+	return reactions, nil
+}
+
+func (a *announcementDB) GetAnnouncementReaction(
+	ctx context.Context,
+	announcementID string,
+	accountID string,
+	name string,
+) (*gtsmodel.AnnouncementReaction, error) {
+	reaction := new(gtsmodel.AnnouncementReaction)
+
+	if err := a.db.
+		NewSelect().
+		Model(reaction).
+		Where("? = ?", bun.Ident("announcement_reaction.announcement_id"), announcementID).
+		Where("? = ?", bun.Ident("announcement_reaction.account_id"), accountID).
+		Where("? = ?", bun.Ident("announcement_reaction.name"), name).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := a.populateAnnouncementReaction(ctx, reaction); err != nil {
+		return nil, err
+	}
+
+	return reaction, nil
+}
+
+func (a *announcementDB) populateAnnouncementReaction(ctx context.Context, reaction *gtsmodel.AnnouncementReaction) error {
+	if gtscontext.Barebones(ctx) ||
+		reaction.EmojiID == "" ||
+		reaction.Emoji != nil {
+		// Nothing to populate.
+		return nil
+	}
+
+	var err error
+	reaction.Emoji, err = a.state.DB.GetEmojiByID(
+		gtscontext.SetBarebones(ctx),
+		reaction.EmojiID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error populating announcement reaction emoji: %w", err)
+	}
+
+	return nil
+}
+
+func (a *announcementDB) PutAnnouncementReaction(ctx context.Context, reaction *gtsmodel.AnnouncementReaction) error {
+	_, err := a.db.
+		NewInsert().
+		Model(reaction).
+		Exec(ctx)
+	return err
+}
+
+func (a *announcementDB) DeleteAnnouncementReactionByID(ctx context.Context, id string) error {
+	if _, err := a.db.
+		NewDelete().
+		Table("announcement_reactions").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+	return nil
+}
@@ -21,6 +21,7 @@
 	"context"
 	"errors"
 	"slices"
+	"time"
 
 	"code.superseriousbusiness.org/gotosocial/internal/db"
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
@@ -499,3 +500,79 @@ func (a *applicationDB) DeleteTokensByClientID(ctx context.Context, clientID str
 
 	return nil
 }
+
+func (a *applicationDB) GetDeviceAuthorizationByDeviceCode(ctx context.Context, deviceCode string) (*gtsmodel.DeviceAuthorization, error) {
+	return a.getDeviceAuthorization(ctx, "device_code", deviceCode)
+}
+
+func (a *applicationDB) GetDeviceAuthorizationByUserCode(ctx context.Context, userCode string) (*gtsmodel.DeviceAuthorization, error) {
+	return a.getDeviceAuthorization(ctx, "user_code", userCode)
+}
+
+func (a *applicationDB) getDeviceAuthorization(ctx context.Context, column string, value string) (*gtsmodel.DeviceAuthorization, error) {
+	deviceAuth := new(gtsmodel.DeviceAuthorization)
+
+	if err := a.db.
+		NewSelect().
+		Model(deviceAuth).
+		Where("? = ?", bun.Ident(column), value).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return deviceAuth, nil
+}
+
+func (a *applicationDB) PutDeviceAuthorization(ctx context.Context, deviceAuth *gtsmodel.DeviceAuthorization) error {
+	_, err := a.db.
+		NewInsert().
+		Model(deviceAuth).
+		Exec(ctx)
+	return err
+}
+
+func (a *applicationDB) UpdateDeviceAuthorization(ctx context.Context, deviceAuth *gtsmodel.DeviceAuthorization, columns ...string) error {
+	_, err := a.db.
+		NewUpdate().
+		Model(deviceAuth).
+		Column(columns...).
+		Where("? = ?", bun.Ident("id"), deviceAuth.ID).
+		Exec(ctx)
+	return err
+}
+
+func (a *applicationDB) DeleteDeviceAuthorizationByID(ctx context.Context, id string) error {
+	res, err := a.db.
+		NewDelete().
+		Table("device_authorizations").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		// Already deleted,
+		// e.g. by a concurrent call.
+		return db.ErrNoEntries
+	}
+
+	return nil
+}
+
+func (a *applicationDB) DeleteExpiredDeviceAuthorizations(ctx context.Context, before time.Time) error {
+	if _, err := a.db.
+		NewDelete().
+		Table("device_authorizations").
+		Where("? < ?", bun.Ident("expires_at"), before).
+		Exec(ctx); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+	return nil
+}
@@ -22,9 +22,11 @@
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	"code.superseriousbusiness.org/gotosocial/internal/db"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -154,6 +156,32 @@ func (suite *ApplicationTestSuite) TestDeleteTokensByUnknownClientID() {
 	}
 }
 
+func (suite *ApplicationTestSuite) TestDeleteDeviceAuthorizationTwice() {
+	ctx := suite.T().Context()
+
+	deviceAuth := &gtsmodel.DeviceAuthorization{
+		ID:         id.NewULID(),
+		ExpiresAt:  time.Now().Add(time.Minute),
+		ClientID:   suite.testApplications["application_1"].ClientID,
+		Scope:      "read",
+		DeviceCode: "some-device-code",
+		UserCode:   "BCDF-GHJK",
+	}
+	if err := suite.state.DB.PutDeviceAuthorization(ctx, deviceAuth); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// First delete consumes the device authorization.
+	if err := suite.state.DB.DeleteDeviceAuthorizationByID(ctx, deviceAuth.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Second delete should report there
+	// was nothing left for it to delete.
+	err := suite.state.DB.DeleteDeviceAuthorizationByID(ctx, deviceAuth.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
 func TestApplicationTestSuite(t *testing.T) {
 	suite.Run(t, new(ApplicationTestSuite))
 }
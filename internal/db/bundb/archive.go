@@ -0,0 +1,113 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type archiveDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (a *archiveDB) GetArchiveByID(ctx context.Context, id string) (*gtsmodel.Archive, error) {
+	archive := new(gtsmodel.Archive)
+
+	if err := a.db.
+		NewSelect().
+		Model(archive).
+		Where("? = ?", bun.Ident("archive.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return archive, nil
+}
+
+func (a *archiveDB) GetLatestArchive(ctx context.Context, accountID string) (*gtsmodel.Archive, error) {
+	archive := new(gtsmodel.Archive)
+
+	if err := a.db.
+		NewSelect().
+		Model(archive).
+		Where("? = ?", bun.Ident("archive.account_id"), accountID).
+		Order("archive.id DESC").
+		Limit(1).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return archive, nil
+}
+
+func (a *archiveDB) GetArchives(ctx context.Context, accountID string) ([]*gtsmodel.Archive, error) {
+	archives := []*gtsmodel.Archive{}
+
+	if err := a.db.
+		NewSelect().
+		Model(&archives).
+		Where("? = ?", bun.Ident("archive.account_id"), accountID).
+		Order("archive.id DESC").
+		Scan(ctx); err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, err
+	}
+
+	return archives, nil
+}
+
+func (a *archiveDB) PutArchive(ctx context.Context, archive *gtsmodel.Archive) error {
+	_, err := a.db.
+		NewInsert().
+		Model(archive).
+		Exec(ctx)
+	return err
+}
+
+func (a *archiveDB) UpdateArchive(ctx context.Context, archive *gtsmodel.Archive, columns ...string) error {
+	archive.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := a.db.
+		NewUpdate().
+		Model(archive).
+		Column(columns...).
+		Where("? = ?", bun.Ident("archive.id"), archive.ID).
+		Exec(ctx)
+	return err
+}
+
+func (a *archiveDB) DeleteArchiveByID(ctx context.Context, id string) error {
+	_, err := a.db.
+		NewDelete().
+		Table("archives").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx)
+	return err
+}
@@ -57,14 +57,22 @@ type DBService struct {
 	db.Account
 	db.Admin
 	db.AdvancedMigration
+	db.Announcement
 	db.Application
+	db.Archive
 	db.Basic
+	db.Card
 	db.Conversation
 	db.Domain
+	db.EmailDomainBlock
 	db.Emoji
+	db.FeaturedTag
 	db.HeaderFilter
+	db.Import
 	db.Instance
 	db.Interaction
+	db.Invite
+	db.IPBlock
 	db.Filter
 	db.List
 	db.Marker
@@ -72,8 +80,10 @@ type DBService struct {
 	db.Mention
 	db.Move
 	db.Notification
+	db.NotificationPolicy
 	db.Poll
 	db.Relationship
+	db.Relay
 	db.Report
 	db.Rule
 	db.ScheduledStatus
@@ -84,10 +94,14 @@ type DBService struct {
 	db.StatusBookmark
 	db.StatusEdit
 	db.StatusFave
+	db.StatusReaction
+	db.Suggestion
 	db.Tag
 	db.Thread
 	db.Timeline
+	db.Trend
 	db.User
+	db.UserDomainBlock
 	db.Tombstone
 	db.WebPush
 	db.WorkerTask
@@ -187,13 +201,25 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		Announcement: &announcementDB{
+			db:    db,
+			state: state,
+		},
 		Application: &applicationDB{
 			db:    db,
 			state: state,
 		},
+		Archive: &archiveDB{
+			db:    db,
+			state: state,
+		},
 		Basic: &basicDB{
 			db: db,
 		},
+		Card: &cardDB{
+			db:    db,
+			state: state,
+		},
 		Conversation: &conversationDB{
 			db:    db,
 			state: state,
@@ -202,6 +228,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		EmailDomainBlock: &emailDomainBlockDB{
+			db:    db,
+			state: state,
+		},
 		Emoji: &emojiDB{
 			db:    db,
 			state: state,
@@ -210,6 +240,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		Import: &importDB{
+			db:    db,
+			state: state,
+		},
 		Instance: &instanceDB{
 			db:    db,
 			state: state,
@@ -218,6 +252,18 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		Invite: &inviteDB{
+			db:    db,
+			state: state,
+		},
+		IPBlock: &ipBlockDB{
+			db:    db,
+			state: state,
+		},
+		FeaturedTag: &featuredTagDB{
+			db:    db,
+			state: state,
+		},
 		Filter: &filterDB{
 			db:    db,
 			state: state,
@@ -246,6 +292,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		NotificationPolicy: &notificationPolicyDB{
+			db:    db,
+			state: state,
+		},
 		Poll: &pollDB{
 			db:    db,
 			state: state,
@@ -254,6 +304,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		Relay: &relayDB{
+			db:    db,
+			state: state,
+		},
 		Report: &reportDB{
 			db:    db,
 			state: state,
@@ -293,6 +347,14 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		StatusReaction: &statusReactionDB{
+			db:    db,
+			state: state,
+		},
+		Suggestion: &suggestionDB{
+			db:    db,
+			state: state,
+		},
 		Tag: &tagDB{
 			db:    db,
 			state: state,
@@ -305,10 +367,18 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		Trend: &trendDB{
+			db:    db,
+			state: state,
+		},
 		User: &userDB{
 			db:    db,
 			state: state,
 		},
+		UserDomainBlock: &userDomainBlockDB{
+			db:    db,
+			state: state,
+		},
 		Tombstone: &tombstoneDB{
 			db:    db,
 			state: state,
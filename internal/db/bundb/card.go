@@ -0,0 +1,150 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type cardDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (c *cardDB) GetCardByID(ctx context.Context, id string) (*gtsmodel.Card, error) {
+	return c.getCard(
+		ctx,
+		"ID",
+		func(card *gtsmodel.Card) error {
+			return c.db.NewSelect().
+				Model(card).
+				Where("? = ?", bun.Ident("card.id"), id).
+				Scan(ctx)
+		},
+		id,
+	)
+}
+
+func (c *cardDB) GetCardByURL(ctx context.Context, url string) (*gtsmodel.Card, error) {
+	return c.getCard(
+		ctx,
+		"URL",
+		func(card *gtsmodel.Card) error {
+			return c.db.NewSelect().
+				Model(card).
+				Where("? = ?", bun.Ident("card.url"), url).
+				Scan(ctx)
+		},
+		url,
+	)
+}
+
+func (c *cardDB) GetCardByImageID(ctx context.Context, imageID string) (*gtsmodel.Card, error) {
+	return c.getCard(
+		ctx,
+		"ImageID",
+		func(card *gtsmodel.Card) error {
+			return c.db.NewSelect().
+				Model(card).
+				Where("? = ?", bun.Ident("card.image_id"), imageID).
+				Scan(ctx)
+		},
+		imageID,
+	)
+}
+
+func (c *cardDB) getCard(ctx context.Context, lookup string, dbQuery func(*gtsmodel.Card) error, keyParts ...any) (*gtsmodel.Card, error) {
+	// Fetch card from database cache with loader callback
+	card, err := c.state.Caches.DB.Card.LoadOne(lookup, func() (*gtsmodel.Card, error) {
+		var card gtsmodel.Card
+
+		// Not cached! Perform database query.
+		if err := dbQuery(&card); err != nil {
+			return nil, err
+		}
+
+		return &card, nil
+	}, keyParts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return card, nil
+	}
+
+	// Further populate the card fields where applicable.
+	if err := c.PopulateCard(ctx, card); err != nil {
+		return nil, err
+	}
+
+	return card, nil
+}
+
+func (c *cardDB) PopulateCard(ctx context.Context, card *gtsmodel.Card) error {
+	var (
+		err  error
+		errs gtserror.MultiError
+	)
+
+	if card.ImageID != "" && card.Image == nil {
+		// Card thumbnail is not set, fetch from database.
+		card.Image, err = c.state.DB.GetAttachmentByID(
+			gtscontext.SetBarebones(ctx),
+			card.ImageID,
+		)
+		if err != nil {
+			errs.Appendf("error populating card image: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (c *cardDB) PutCard(ctx context.Context, card *gtsmodel.Card) error {
+	return c.state.Caches.DB.Card.Store(card, func() error {
+		_, err := c.db.NewInsert().Model(card).Exec(ctx)
+		return err
+	})
+}
+
+func (c *cardDB) UpdateCard(ctx context.Context, card *gtsmodel.Card, cols ...string) error {
+	card.UpdatedAt = time.Now()
+	if len(cols) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		cols = append(cols, "updated_at")
+	}
+
+	return c.state.Caches.DB.Card.Store(card, func() error {
+		_, err := c.db.NewUpdate().
+			Model(card).
+			Column(cols...).
+			Where("? = ?", bun.Ident("card.id"), card.ID).
+			Exec(ctx)
+		return err
+	})
+}
@@ -0,0 +1,198 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+	"github.com/uptrace/bun"
+)
+
+func (d *domainDB) PutDomainLimit(ctx context.Context, limit *gtsmodel.DomainLimit) (err error) {
+	// Normalize the domain as punycode, note the extra
+	// validation step for domain name write operations.
+	limit.Domain, err = util.PunifySafely(limit.Domain)
+	if err != nil {
+		return gtserror.Newf("error punifying domain %s: %w", limit.Domain, err)
+	}
+
+	// Attempt to store domain limit in DB
+	if _, err := d.db.NewInsert().
+		Model(limit).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Clear the domain limit cache (for later reload)
+	d.state.Caches.DB.DomainLimit.Clear()
+
+	return nil
+}
+
+func (d *domainDB) GetDomainLimitByID(ctx context.Context, id string) (*gtsmodel.DomainLimit, error) {
+	var limit gtsmodel.DomainLimit
+
+	q := d.db.
+		NewSelect().
+		Model(&limit).
+		Where("? = ?", bun.Ident("domain_limit.id"), id)
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &limit, nil
+}
+
+func (d *domainDB) GetDomainLimits(ctx context.Context) ([]*gtsmodel.DomainLimit, error) {
+	limits := []*gtsmodel.DomainLimit{}
+
+	if err := d.db.
+		NewSelect().
+		Model(&limits).
+		Order("domain_limit.domain ASC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return limits, nil
+}
+
+func (d *domainDB) MatchDomainLimit(ctx context.Context, domain string) (*gtsmodel.DomainLimit, error) {
+	// Normalize domain as punycode for lookup.
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return nil, gtserror.Newf("error punifying domain %s: %w", domain, err)
+	}
+
+	// Domain referencing *us* cannot be limited.
+	if domain == "" || domain == config.GetAccountDomain() ||
+		domain == config.GetHost() {
+		return nil, db.ErrNoEntries
+	}
+
+	// Check the cache for a domain limit (hydrating the cache with callback if
+	// necessary), to avoid hitting the database for the majority of domains.
+	limited, err := d.state.Caches.DB.DomainLimit.Matches(domain, func() ([]string, error) {
+		var domains []string
+
+		// Scan list of all limited domains from DB
+		q := d.db.NewSelect().
+			Table("domain_limits").
+			Column("domain")
+		if err := q.Scan(ctx, &domains); err != nil {
+			return nil, err
+		}
+
+		return domains, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !limited {
+		return nil, db.ErrNoEntries
+	}
+
+	// Gather the domain and each of its parent
+	// domains, eg. "a.b.c" => ["a.b.c", "b.c", "c"].
+	candidates := []string{domain}
+	for rest := domain; ; {
+		i := strings.IndexByte(rest, '.')
+		if i == -1 {
+			break
+		}
+		rest = rest[i+1:]
+		candidates = append(candidates, rest)
+	}
+
+	var limits []*gtsmodel.DomainLimit
+	if err := d.db.
+		NewSelect().
+		Model(&limits).
+		Where("? IN (?)", bun.Ident("domain_limit.domain"), bun.In(candidates)).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if len(limits) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	// Return the most specific
+	// (ie., longest) matching limit.
+	match := limits[0]
+	for _, limit := range limits[1:] {
+		if len(limit.Domain) > len(match.Domain) {
+			match = limit
+		}
+	}
+
+	return match, nil
+}
+
+func (d *domainDB) UpdateDomainLimit(ctx context.Context, limit *gtsmodel.DomainLimit, columns ...string) (err error) {
+	// Normalize the domain as punycode, note the extra
+	// validation step for domain name write operations.
+	limit.Domain, err = util.PunifySafely(limit.Domain)
+	if err != nil {
+		return gtserror.Newf("error punifying domain %s: %w", limit.Domain, err)
+	}
+
+	// Ensure updated_at is set.
+	limit.UpdatedAt = time.Now()
+	if len(columns) != 0 {
+		columns = append(columns, "updated_at")
+	}
+
+	// Attempt to update domain limit.
+	if _, err := d.db.
+		NewUpdate().
+		Model(limit).
+		Column(columns...).
+		Where("? = ?", bun.Ident("domain_limit.id"), limit.ID).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Clear the domain limit cache (for later reload)
+	d.state.Caches.DB.DomainLimit.Clear()
+
+	return nil
+}
+
+func (d *domainDB) DeleteDomainLimitByID(ctx context.Context, id string) error {
+	// Attempt to delete domain limit
+	if _, err := d.db.NewDelete().
+		Model((*gtsmodel.DomainLimit)(nil)).
+		Where("? = ?", bun.Ident("domain_limit.id"), id).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Clear the domain limit cache (for later reload)
+	d.state.Caches.DB.DomainLimit.Clear()
+
+	return nil
+}
@@ -0,0 +1,178 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/paging"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+	"github.com/uptrace/bun"
+)
+
+type emailDomainBlockDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (e *emailDomainBlockDB) GetEmailDomainBlockByID(ctx context.Context, id string) (*gtsmodel.EmailDomainBlock, error) {
+	return e.getEmailDomainBlock(ctx, "id", id)
+}
+
+func (e *emailDomainBlockDB) GetEmailDomainBlockByDomain(ctx context.Context, domain string) (*gtsmodel.EmailDomainBlock, error) {
+	// Normalize domain as punycode for lookup.
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return nil, gtserror.Newf("error punifying domain %s: %w", domain, err)
+	}
+
+	return e.getEmailDomainBlock(ctx, "domain", domain)
+}
+
+func (e *emailDomainBlockDB) getEmailDomainBlock(ctx context.Context, column string, value string) (*gtsmodel.EmailDomainBlock, error) {
+	block := new(gtsmodel.EmailDomainBlock)
+
+	if err := e.db.
+		NewSelect().
+		Model(block).
+		Where("? = ?", bun.Ident("email_domain_block."+column), value).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
+func (e *emailDomainBlockDB) GetEmailDomainBlocks(ctx context.Context, page *paging.Page) ([]*gtsmodel.EmailDomainBlock, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		blocks = make([]*gtsmodel.EmailDomainBlock, 0, limit)
+	)
+
+	q := e.db.
+		NewSelect().
+		Model(&blocks)
+
+	if maxID != "" {
+		// Return only blocks LOWER (ie., older) than maxID.
+		q = q.Where("? < ?", bun.Ident("email_domain_block.id"), maxID)
+	}
+
+	if minID != "" {
+		// Return only blocks HIGHER (ie., newer) than minID.
+		q = q.Where("? > ?", bun.Ident("email_domain_block.id"), minID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.Order("email_domain_block.id ASC")
+	} else {
+		// Page down.
+		q = q.Order("email_domain_block.id DESC")
+	}
+
+	if err := q.Scan(ctx); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return nil, err
+	}
+
+	// If we're paging up, we still want blocks
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(blocks)
+	}
+
+	return blocks, nil
+}
+
+func (e *emailDomainBlockDB) PutEmailDomainBlock(ctx context.Context, block *gtsmodel.EmailDomainBlock) error {
+	// Normalize the domain as punycode
+	var err error
+	block.Domain, err = util.Punify(block.Domain)
+	if err != nil {
+		return gtserror.Newf("error punifying domain %s: %w", block.Domain, err)
+	}
+
+	if _, err := e.db.
+		NewInsert().
+		Model(block).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Clear the email domain block cache.
+	e.state.Caches.DB.EmailDomainBlock.Clear()
+	return nil
+}
+
+func (e *emailDomainBlockDB) DeleteEmailDomainBlockByID(ctx context.Context, id string) error {
+	if _, err := e.db.
+		NewDelete().
+		Table("email_domain_blocks").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Clear the email domain block cache.
+	e.state.Caches.DB.EmailDomainBlock.Clear()
+	return nil
+}
+
+func (e *emailDomainBlockDB) IsEmailDomainBlocked(ctx context.Context, domain string) (bool, error) {
+	// Normalize domain as punycode for lookup.
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return false, gtserror.Newf("error punifying domain %s: %w", domain, err)
+	}
+
+	if domain == "" {
+		return false, nil
+	}
+
+	// Check the cache for a block (hydrating the cache with callback if necessary).
+	return e.state.Caches.DB.EmailDomainBlock.Matches(domain, func() ([]string, error) {
+		var domains []string
+
+		// Scan list of all blocked email domains from DB
+		q := e.db.NewSelect().
+			Table("email_domain_blocks").
+			Column("domain")
+		if err := q.Scan(ctx, &domains); err != nil {
+			return nil, err
+		}
+
+		return domains, nil
+	})
+}
@@ -0,0 +1,243 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type featuredTagDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (f *featuredTagDB) GetFeaturedTagByID(ctx context.Context, id string) (*gtsmodel.FeaturedTag, error) {
+	return f.getFeaturedTag(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("? = ?", bun.Ident("featured_tag.id"), id)
+	})
+}
+
+func (f *featuredTagDB) GetFeaturedTag(ctx context.Context, accountID string, tagID string) (*gtsmodel.FeaturedTag, error) {
+	return f.getFeaturedTag(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.
+			Where("? = ?", bun.Ident("featured_tag.account_id"), accountID).
+			Where("? = ?", bun.Ident("featured_tag.tag_id"), tagID)
+	})
+}
+
+func (f *featuredTagDB) getFeaturedTag(ctx context.Context, where func(*bun.SelectQuery) *bun.SelectQuery) (*gtsmodel.FeaturedTag, error) {
+	featuredTag := new(gtsmodel.FeaturedTag)
+
+	q := f.db.
+		NewSelect().
+		Model(featuredTag)
+
+	if err := where(q).Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return featuredTag, nil
+	}
+
+	if err := f.PopulateFeaturedTag(ctx, featuredTag); err != nil {
+		return nil, err
+	}
+
+	return featuredTag, nil
+}
+
+func (f *featuredTagDB) GetAccountFeaturedTags(ctx context.Context, accountID string) ([]*gtsmodel.FeaturedTag, error) {
+	var featuredTags []*gtsmodel.FeaturedTag
+
+	if err := f.db.
+		NewSelect().
+		Model(&featuredTags).
+		Where("? = ?", bun.Ident("featured_tag.account_id"), accountID).
+		Order("featured_tag.id ASC").
+		Scan(ctx); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return featuredTags, nil
+	}
+
+	for _, featuredTag := range featuredTags {
+		if err := f.PopulateFeaturedTag(ctx, featuredTag); err != nil {
+			return nil, err
+		}
+	}
+
+	return featuredTags, nil
+}
+
+func (f *featuredTagDB) GetFeaturedTagSuggestions(ctx context.Context, accountID string, limit int) ([]*gtsmodel.Tag, error) {
+	var tagIDs []string
+
+	// Select IDs of tags used by this account,
+	// ordered by most recent status using them.
+	if err := f.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_to_tags"), bun.Ident("status_to_tag")).
+		Column("status_to_tag.tag_id").
+		Join(
+			"JOIN ? AS ? ON ? = ?",
+			bun.Ident("statuses"), bun.Ident("status"),
+			bun.Ident("status.id"), bun.Ident("status_to_tag.status_id"),
+		).
+		Where("? = ?", bun.Ident("status.account_id"), accountID).
+		Group("status_to_tag.tag_id").
+		OrderExpr("MAX(?) DESC", bun.Ident("status_to_tag.status_id")).
+		Limit(limit).
+		Scan(ctx, &tagIDs); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return nil, err
+	}
+
+	if len(tagIDs) == 0 {
+		return nil, nil
+	}
+
+	return f.state.DB.GetTags(ctx, tagIDs)
+}
+
+func (f *featuredTagDB) PopulateFeaturedTag(ctx context.Context, featuredTag *gtsmodel.FeaturedTag) error {
+	var (
+		err  error
+		errs = gtserror.NewMultiError(2)
+	)
+
+	if featuredTag.Account == nil {
+		// Featured tag account is not set, fetch from database.
+		featuredTag.Account, err = f.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			featuredTag.AccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating featured tag account: %w", err)
+		}
+	}
+
+	if featuredTag.Tag == nil {
+		// Featured tag tag is not set, fetch from database.
+		featuredTag.Tag, err = f.state.DB.GetTag(
+			gtscontext.SetBarebones(ctx),
+			featuredTag.TagID,
+		)
+		if err != nil {
+			errs.Appendf("error populating featured tag tag: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (f *featuredTagDB) PutFeaturedTag(ctx context.Context, featuredTag *gtsmodel.FeaturedTag) error {
+	_, err := f.db.
+		NewInsert().
+		Model(featuredTag).
+		Exec(ctx)
+	return err
+}
+
+func (f *featuredTagDB) UpdateFeaturedTagStats(ctx context.Context, featuredTag *gtsmodel.FeaturedTag) error {
+	// Only public statuses of the account
+	// (so no boosts) count towards stats.
+	newQ := func() *bun.SelectQuery {
+		return f.db.
+			NewSelect().
+			TableExpr("? AS ?", bun.Ident("status_to_tags"), bun.Ident("status_to_tag")).
+			Join(
+				"JOIN ? AS ? ON ? = ?",
+				bun.Ident("statuses"), bun.Ident("status"),
+				bun.Ident("status.id"), bun.Ident("status_to_tag.status_id"),
+			).
+			Where("? = ?", bun.Ident("status_to_tag.tag_id"), featuredTag.TagID).
+			Where("? = ?", bun.Ident("status.account_id"), featuredTag.AccountID).
+			Where("? IN (?)", bun.Ident("status.visibility"), bun.In([]gtsmodel.Visibility{
+				gtsmodel.VisibilityPublic,
+				gtsmodel.VisibilityUnlocked,
+			})).
+			Where("? IS NULL", bun.Ident("status.boost_of_id"))
+	}
+
+	count, err := newQ().Count(ctx)
+	if err != nil {
+		return err
+	}
+
+	var lastStatusAt time.Time
+	if count > 0 {
+		// Get creation time of the most recent status.
+		if err := newQ().
+			Column("status.created_at").
+			Order("status.created_at DESC").
+			Limit(1).
+			Scan(ctx, &lastStatusAt); err != nil {
+			return err
+		}
+	}
+
+	featuredTag.StatusesCount = count
+	featuredTag.LastStatusAt = lastStatusAt
+
+	_, err = f.db.
+		NewUpdate().
+		Model(featuredTag).
+		Column("statuses_count", "last_status_at").
+		Where("? = ?", bun.Ident("featured_tag.id"), featuredTag.ID).
+		Exec(ctx)
+	return err
+}
+
+func (f *featuredTagDB) DeleteFeaturedTagByID(ctx context.Context, id string) error {
+	if _, err := f.db.
+		NewDelete().
+		Table("featured_tags").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+	return nil
+}
+
+func (f *featuredTagDB) DeleteAccountFeaturedTags(ctx context.Context, accountID string) error {
+	if _, err := f.db.
+		NewDelete().
+		Table("featured_tags").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Exec(ctx); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+	return nil
+}
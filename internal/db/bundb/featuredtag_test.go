@@ -0,0 +1,112 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/testrig"
+	"github.com/stretchr/testify/suite"
+)
+
+type FeaturedTagTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *FeaturedTagTestSuite) TestGetAccountFeaturedTags() {
+	ctx := suite.T().Context()
+	testAccount := suite.testAccounts["admin_account"]
+
+	featuredTags, err := suite.db.GetAccountFeaturedTags(ctx, testAccount.ID)
+	suite.NoError(err)
+	suite.Len(featuredTags, 1)
+	suite.Equal("welcome", featuredTags[0].Tag.Name)
+	suite.Equal(testAccount.ID, featuredTags[0].Account.ID)
+}
+
+func (suite *FeaturedTagTestSuite) TestUpdateFeaturedTagStats() {
+	ctx := suite.T().Context()
+	testFeaturedTag := testrig.NewTestFeaturedTags()["admin_account_welcome"]
+
+	// Zero out the stats, they
+	// should be recalculated.
+	testFeaturedTag.StatusesCount = 0
+	testFeaturedTag.LastStatusAt = time.Time{}
+
+	err := suite.db.UpdateFeaturedTagStats(ctx, testFeaturedTag)
+	suite.NoError(err)
+
+	dbFeaturedTag, err := suite.db.GetFeaturedTagByID(ctx, testFeaturedTag.ID)
+	suite.NoError(err)
+	suite.Equal(1, dbFeaturedTag.StatusesCount)
+	suite.Equal(
+		testrig.TimeMustParse("2021-10-20T11:36:45Z"),
+		dbFeaturedTag.LastStatusAt.UTC(),
+	)
+}
+
+func (suite *FeaturedTagTestSuite) TestGetFeaturedTagSuggestions() {
+	ctx := suite.T().Context()
+
+	tags, err := suite.db.GetFeaturedTagSuggestions(ctx, suite.testAccounts["admin_account"].ID, 10)
+	suite.NoError(err)
+	suite.Len(tags, 1)
+	suite.Equal("welcome", tags[0].Name)
+
+	tags, err = suite.db.GetFeaturedTagSuggestions(ctx, suite.testAccounts["local_account_2"].ID, 10)
+	suite.NoError(err)
+	suite.Empty(tags)
+}
+
+func (suite *FeaturedTagTestSuite) TestPutDeleteFeaturedTag() {
+	ctx := suite.T().Context()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	featuredTag := &gtsmodel.FeaturedTag{
+		ID:        id.NewULID(),
+		AccountID: testAccount.ID,
+		TagID:     suite.testTags["Hashtag"].ID,
+	}
+	suite.NoError(suite.db.PutFeaturedTag(ctx, featuredTag))
+
+	// Featuring the same tag twice should fail.
+	err := suite.db.PutFeaturedTag(ctx, &gtsmodel.FeaturedTag{
+		ID:        id.NewULID(),
+		AccountID: testAccount.ID,
+		TagID:     suite.testTags["Hashtag"].ID,
+	})
+	suite.ErrorIs(err, db.ErrAlreadyExists)
+
+	dbFeaturedTag, err := suite.db.GetFeaturedTag(ctx, testAccount.ID, featuredTag.TagID)
+	suite.NoError(err)
+	suite.Equal(featuredTag.ID, dbFeaturedTag.ID)
+
+	suite.NoError(suite.db.DeleteAccountFeaturedTags(ctx, testAccount.ID))
+
+	_, err = suite.db.GetFeaturedTagByID(ctx, featuredTag.ID)
+	suite.True(errors.Is(err, db.ErrNoEntries))
+}
+
+func TestFeaturedTagTestSuite(t *testing.T) {
+	suite.Run(t, new(FeaturedTagTestSuite))
+}
@@ -0,0 +1,178 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/paging"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type importDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (i *importDB) GetImportByID(ctx context.Context, id string) (*gtsmodel.Import, error) {
+	imp := new(gtsmodel.Import)
+
+	if err := i.db.
+		NewSelect().
+		Model(imp).
+		Where("? = ?", bun.Ident("import.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return imp, nil
+}
+
+func (i *importDB) GetImports(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.Import, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		imports = make([]*gtsmodel.Import, 0, limit)
+	)
+
+	q := i.db.
+		NewSelect().
+		Model(&imports).
+		Where("? = ?", bun.Ident("import.account_id"), accountID)
+
+	if maxID != "" {
+		// Return only imports LOWER (ie., older) than maxID.
+		q = q.Where("? < ?", bun.Ident("import.id"), maxID)
+	}
+
+	if minID != "" {
+		// Return only imports HIGHER (ie., newer) than minID.
+		q = q.Where("? > ?", bun.Ident("import.id"), minID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.Order("import.id ASC")
+	} else {
+		// Page down.
+		q = q.Order("import.id DESC")
+	}
+
+	if err := q.Scan(ctx); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return nil, err
+	}
+
+	// If we're paging up, we still want imports
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(imports)
+	}
+
+	return imports, nil
+}
+
+func (i *importDB) PutImport(ctx context.Context, imp *gtsmodel.Import) error {
+	_, err := i.db.
+		NewInsert().
+		Model(imp).
+		Exec(ctx)
+	return err
+}
+
+func (i *importDB) UpdateImport(ctx context.Context, imp *gtsmodel.Import, columns ...string) error {
+	imp.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := i.db.
+		NewUpdate().
+		Model(imp).
+		Column(columns...).
+		Where("? = ?", bun.Ident("import.id"), imp.ID).
+		Exec(ctx)
+	return err
+}
+
+func (i *importDB) DeleteImportsByAccountID(ctx context.Context, accountID string) error {
+	return i.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Delete failures of the account's imports.
+		if _, err := tx.
+			NewDelete().
+			Table("import_failures").
+			Where("? IN (?)",
+				bun.Ident("import_id"),
+				tx.NewSelect().
+					Table("imports").
+					Column("id").
+					Where("? = ?", bun.Ident("account_id"), accountID),
+			).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		// Then the imports themselves.
+		_, err := tx.
+			NewDelete().
+			Table("imports").
+			Where("? = ?", bun.Ident("account_id"), accountID).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (i *importDB) GetImportFailures(ctx context.Context, importID string) ([]*gtsmodel.ImportFailure, error) {
+	failures := []*gtsmodel.ImportFailure{}
+
+	if err := i.db.
+		NewSelect().
+		Model(&failures).
+		Where("? = ?", bun.Ident("import_failure.import_id"), importID).
+		Order("import_failure.id ASC").
+		Scan(ctx); err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, err
+	}
+
+	return failures, nil
+}
+
+func (i *importDB) PutImportFailure(ctx context.Context, failure *gtsmodel.ImportFailure) error {
+	_, err := i.db.
+		NewInsert().
+		Model(failure).
+		Exec(ctx)
+	return err
+}
@@ -130,6 +130,7 @@ func (suite *InteractionTestSuite) TestGetPending() {
 		likes      = true
 		replies    = true
 		boosts     = true
+		quotes     = true
 		page       = &paging.Page{
 			Max:   paging.MaxID(id.Highest),
 			Limit: 20,
@@ -148,6 +149,7 @@ func (suite *InteractionTestSuite) TestGetPending() {
 		likes,
 		replies,
 		boosts,
+		quotes,
 		page,
 	)
 	suite.NoError(err)
@@ -178,6 +180,7 @@ func (suite *InteractionTestSuite) TestGetPendingRepliesOnly() {
 		likes      = false
 		replies    = true
 		boosts     = false
+		quotes     = false
 		page       = &paging.Page{
 			Max:   paging.MaxID(id.Highest),
 			Limit: 20,
@@ -196,6 +199,7 @@ func (suite *InteractionTestSuite) TestGetPendingRepliesOnly() {
 		likes,
 		replies,
 		boosts,
+		quotes,
 		page,
 	)
 	suite.NoError(err)
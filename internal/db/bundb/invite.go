@@ -0,0 +1,249 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/paging"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type inviteDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (i *inviteDB) GetInviteByID(ctx context.Context, id string) (*gtsmodel.Invite, error) {
+	return i.getInvite(ctx, "id", id)
+}
+
+func (i *inviteDB) GetInviteByCode(ctx context.Context, code string) (*gtsmodel.Invite, error) {
+	return i.getInvite(ctx, "code", code)
+}
+
+func (i *inviteDB) getInvite(ctx context.Context, column string, value string) (*gtsmodel.Invite, error) {
+	invite := new(gtsmodel.Invite)
+
+	if err := i.db.
+		NewSelect().
+		Model(invite).
+		Where("? = ?", bun.Ident("invite."+column), value).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return invite, nil
+	}
+
+	if err := i.populateInvite(ctx, invite); err != nil {
+		return nil, err
+	}
+
+	return invite, nil
+}
+
+func (i *inviteDB) GetInvites(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.Invite, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		invites = make([]*gtsmodel.Invite, 0, limit)
+	)
+
+	q := i.db.
+		NewSelect().
+		Model(&invites)
+
+	if accountID != "" {
+		// Return only invites created by this account.
+		q = q.Where("? = ?", bun.Ident("invite.account_id"), accountID)
+	}
+
+	if maxID != "" {
+		// Return only invites LOWER (ie., older) than maxID.
+		q = q.Where("? < ?", bun.Ident("invite.id"), maxID)
+	}
+
+	if minID != "" {
+		// Return only invites HIGHER (ie., newer) than minID.
+		q = q.Where("? > ?", bun.Ident("invite.id"), minID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.Order("invite.id ASC")
+	} else {
+		// Page down.
+		q = q.Order("invite.id DESC")
+	}
+
+	if err := q.Scan(ctx); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return nil, err
+	}
+
+	// If we're paging up, we still want invites
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(invites)
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return invites, nil
+	}
+
+	for _, invite := range invites {
+		if err := i.populateInvite(ctx, invite); err != nil {
+			return nil, err
+		}
+	}
+
+	return invites, nil
+}
+
+func (i *inviteDB) populateInvite(ctx context.Context, invite *gtsmodel.Invite) error {
+	if invite.Account != nil {
+		// Nothing to populate.
+		return nil
+	}
+
+	// Account may have since been deleted,
+	// in which case leave it unpopulated.
+	var err error
+	invite.Account, err = i.state.DB.GetAccountByID(
+		gtscontext.SetBarebones(ctx),
+		invite.AccountID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error populating invite account: %w", err)
+	}
+
+	return nil
+}
+
+func (i *inviteDB) PutInvite(ctx context.Context, invite *gtsmodel.Invite) error {
+	_, err := i.db.
+		NewInsert().
+		Model(invite).
+		Exec(ctx)
+	return err
+}
+
+func (i *inviteDB) UpdateInvite(ctx context.Context, invite *gtsmodel.Invite, columns ...string) error {
+	invite.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := i.db.
+		NewUpdate().
+		Model(invite).
+		Column(columns...).
+		Where("? = ?", bun.Ident("invite.id"), invite.ID).
+		Exec(ctx)
+	return err
+}
+
+func (i *inviteDB) IncrementInviteUses(ctx context.Context, invite *gtsmodel.Invite) error {
+	now := time.Now()
+
+	// Increment uses in a single statement,
+	// only if the invite is still valid, so
+	// that concurrent sign-ups can't go over
+	// the invite's maximum number of uses.
+	res, err := i.db.
+		NewUpdate().
+		Table("invites").
+		Set("? = ? + 1", bun.Ident("uses"), bun.Ident("uses")).
+		Set("? = ?", bun.Ident("updated_at"), now).
+		Where("? = ?", bun.Ident("id"), invite.ID).
+		WhereGroup(" AND ", func(q *bun.UpdateQuery) *bun.UpdateQuery {
+			return q.
+				Where("? IS NULL", bun.Ident("max_uses")).
+				WhereOr("? < ?", bun.Ident("uses"), bun.Ident("max_uses"))
+		}).
+		WhereGroup(" AND ", func(q *bun.UpdateQuery) *bun.UpdateQuery {
+			return q.
+				Where("? IS NULL", bun.Ident("expires_at")).
+				WhereOr("? > ?", bun.Ident("expires_at"), now)
+		}).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		// Invite no longer usable.
+		return db.ErrNoEntries
+	}
+
+	// Update passed model to match.
+	invite.Uses++
+	invite.UpdatedAt = now
+	return nil
+}
+
+func (i *inviteDB) DecrementInviteUses(ctx context.Context, invite *gtsmodel.Invite) error {
+	now := time.Now()
+
+	// Decrement uses in a single statement,
+	// never going below zero, so concurrent
+	// sign-ups using the invite are unaffected.
+	if _, err := i.db.
+		NewUpdate().
+		Table("invites").
+		Set("? = ? - 1", bun.Ident("uses"), bun.Ident("uses")).
+		Set("? = ?", bun.Ident("updated_at"), now).
+		Where("? = ?", bun.Ident("id"), invite.ID).
+		Where("? > 0", bun.Ident("uses")).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Update passed model to match.
+	invite.Uses = max(invite.Uses-1, 0)
+	invite.UpdatedAt = now
+	return nil
+}
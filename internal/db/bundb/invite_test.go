@@ -0,0 +1,94 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"testing"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+	"github.com/stretchr/testify/suite"
+)
+
+type InviteTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *InviteTestSuite) TestIncrementDecrementInviteUses() {
+	var (
+		ctx    = suite.T().Context()
+		invite = &gtsmodel.Invite{
+			ID:         "01JCT3JVTK4QVKBK1ZQHZ9E8NY",
+			Code:       "K5B3ZNCQ7WBVC4BXMQAW6DZN5Y",
+			AccountID:  suite.testAccounts["admin_account"].ID,
+			MaxUses:    1,
+			Autofollow: util.Ptr(false),
+		}
+	)
+
+	if err := suite.db.PutInvite(ctx, invite); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Use up the invite.
+	if err := suite.db.IncrementInviteUses(ctx, invite); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(1, invite.Uses)
+
+	// Invite should now be exhausted.
+	err := suite.db.IncrementInviteUses(ctx, invite)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Give back the use, as
+	// when a sign-up fails.
+	if err := suite.db.DecrementInviteUses(ctx, invite); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(0, invite.Uses)
+
+	dbInvite, err := suite.db.GetInviteByID(ctx, invite.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(0, dbInvite.Uses)
+	suite.True(dbInvite.Valid(time.Now()))
+
+	// Decrementing again should
+	// never take uses below zero.
+	if err := suite.db.DecrementInviteUses(ctx, invite); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbInvite, err = suite.db.GetInviteByID(ctx, invite.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(0, dbInvite.Uses)
+
+	// Invite should be usable again.
+	if err := suite.db.IncrementInviteUses(ctx, invite); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func TestInviteTestSuite(t *testing.T) {
+	suite.Run(t, new(InviteTestSuite))
+}
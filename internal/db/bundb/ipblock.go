@@ -0,0 +1,178 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"slices"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/paging"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type ipBlockDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (i *ipBlockDB) GetIPBlockByID(ctx context.Context, id string) (*gtsmodel.IPBlock, error) {
+	return i.getIPBlock(ctx, "id", id)
+}
+
+func (i *ipBlockDB) GetIPBlockByIP(ctx context.Context, ip string) (*gtsmodel.IPBlock, error) {
+	return i.getIPBlock(ctx, "ip", ip)
+}
+
+func (i *ipBlockDB) getIPBlock(ctx context.Context, column string, value string) (*gtsmodel.IPBlock, error) {
+	block := new(gtsmodel.IPBlock)
+
+	if err := i.db.
+		NewSelect().
+		Model(block).
+		Where("? = ?", bun.Ident("ip_block."+column), value).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return block, nil
+}
+
+func (i *ipBlockDB) GetIPBlocks(ctx context.Context, page *paging.Page) ([]*gtsmodel.IPBlock, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		blocks = make([]*gtsmodel.IPBlock, 0, limit)
+	)
+
+	q := i.db.
+		NewSelect().
+		Model(&blocks)
+
+	if maxID != "" {
+		// Return only blocks LOWER (ie., older) than maxID.
+		q = q.Where("? < ?", bun.Ident("ip_block.id"), maxID)
+	}
+
+	if minID != "" {
+		// Return only blocks HIGHER (ie., newer) than minID.
+		q = q.Where("? > ?", bun.Ident("ip_block.id"), minID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.Order("ip_block.id ASC")
+	} else {
+		// Page down.
+		q = q.Order("ip_block.id DESC")
+	}
+
+	if err := q.Scan(ctx); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return nil, err
+	}
+
+	// If we're paging up, we still want blocks
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(blocks)
+	}
+
+	return blocks, nil
+}
+
+func (i *ipBlockDB) PutIPBlock(ctx context.Context, block *gtsmodel.IPBlock) error {
+	if _, err := i.db.
+		NewInsert().
+		Model(block).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Clear the IP block cache.
+	i.state.Caches.IPBlocks.Clear()
+	return nil
+}
+
+func (i *ipBlockDB) UpdateIPBlock(ctx context.Context, block *gtsmodel.IPBlock, columns ...string) error {
+	block.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	if _, err := i.db.
+		NewUpdate().
+		Model(block).
+		Column(columns...).
+		Where("? = ?", bun.Ident("ip_block.id"), block.ID).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Clear the IP block cache.
+	i.state.Caches.IPBlocks.Clear()
+	return nil
+}
+
+func (i *ipBlockDB) DeleteIPBlockByID(ctx context.Context, id string) error {
+	if _, err := i.db.
+		NewDelete().
+		Table("ip_blocks").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Clear the IP block cache.
+	i.state.Caches.IPBlocks.Clear()
+	return nil
+}
+
+func (i *ipBlockDB) MatchIPBlock(ctx context.Context, addr netip.Addr) (*gtsmodel.IPBlock, error) {
+	return i.state.Caches.IPBlocks.Match(addr, func() ([]*gtsmodel.IPBlock, error) {
+		var blocks []*gtsmodel.IPBlock
+
+		// Scan list of all IP blocks from DB. Expired
+		// blocks are left in, and skipped when matching,
+		// so the cache doesn't need clearing on expiry.
+		if err := i.db.
+			NewSelect().
+			Model(&blocks).
+			Scan(ctx); err != nil {
+			return nil, err
+		}
+
+		return blocks, nil
+	})
+}
@@ -299,3 +299,33 @@ func (m *mediaDB) GetCachedAttachmentsOlderThan(ctx context.Context, olderThan t
 
 	return m.GetAttachmentsByIDs(ctx, attachmentIDs)
 }
+
+func (m *mediaDB) CountBlobReferences(ctx context.Context, path string, excludeID string) (int, error) {
+	var total int
+
+	for table, columns := range map[string][2]string{
+		"media_attachments": {"file_path", "thumbnail_path"},
+		"emojis":            {"image_path", "image_static_path"},
+	} {
+		q := m.db.NewSelect().
+			Table(table).
+			WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+				return q.
+					Where("? = ?", bun.Ident(columns[0]), path).
+					WhereOr("? = ?", bun.Ident(columns[1]), path)
+			})
+
+		if excludeID != "" {
+			q = q.Where("? != ?", bun.Ident("id"), excludeID)
+		}
+
+		n, err := q.Count(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		total += n
+	}
+
+	return total, nil
+}
@@ -0,0 +1,289 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/text"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+)
+
+// Full-text search indexes are stored in separate tables keyed
+// by account / status ID, containing the searchable text of each
+// item already run through text.SearchText(). These are kept in
+// sync with the accounts and statuses tables by the bundb code.
+//
+// On SQLite, each search table is backed by an external-content
+// FTS5 virtual table, which is kept in sync using triggers. On
+// Postgres, each search table has a generated tsvector column,
+// with a GIN index over it.
+
+var sqliteSearchIndexes = []string{
+	`CREATE TABLE IF NOT EXISTS "status_search" (
+		"id" INTEGER PRIMARY KEY,
+		"status_id" CHAR(26) NOT NULL UNIQUE,
+		"text" TEXT NOT NULL DEFAULT ''
+	)`,
+	`CREATE VIRTUAL TABLE IF NOT EXISTS "status_search_fts" USING fts5(
+		"text",
+		content='status_search',
+		content_rowid='id',
+		tokenize='unicode61 remove_diacritics 2'
+	)`,
+	`CREATE TRIGGER IF NOT EXISTS "status_search_ai" AFTER INSERT ON "status_search" BEGIN
+		INSERT INTO "status_search_fts" ("rowid", "text") VALUES (new."id", new."text");
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS "status_search_ad" AFTER DELETE ON "status_search" BEGIN
+		INSERT INTO "status_search_fts" ("status_search_fts", "rowid", "text") VALUES ('delete', old."id", old."text");
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS "status_search_au" AFTER UPDATE ON "status_search" BEGIN
+		INSERT INTO "status_search_fts" ("status_search_fts", "rowid", "text") VALUES ('delete', old."id", old."text");
+		INSERT INTO "status_search_fts" ("rowid", "text") VALUES (new."id", new."text");
+	END`,
+	`CREATE TABLE IF NOT EXISTS "account_search" (
+		"id" INTEGER PRIMARY KEY,
+		"account_id" CHAR(26) NOT NULL UNIQUE,
+		"name" TEXT NOT NULL DEFAULT '',
+		"note" TEXT NOT NULL DEFAULT ''
+	)`,
+	`CREATE VIRTUAL TABLE IF NOT EXISTS "account_search_fts" USING fts5(
+		"name",
+		"note",
+		content='account_search',
+		content_rowid='id',
+		tokenize='unicode61 remove_diacritics 2'
+	)`,
+	`CREATE TRIGGER IF NOT EXISTS "account_search_ai" AFTER INSERT ON "account_search" BEGIN
+		INSERT INTO "account_search_fts" ("rowid", "name", "note") VALUES (new."id", new."name", new."note");
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS "account_search_ad" AFTER DELETE ON "account_search" BEGIN
+		INSERT INTO "account_search_fts" ("account_search_fts", "rowid", "name", "note") VALUES ('delete', old."id", old."name", old."note");
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS "account_search_au" AFTER UPDATE ON "account_search" BEGIN
+		INSERT INTO "account_search_fts" ("account_search_fts", "rowid", "name", "note") VALUES ('delete', old."id", old."name", old."note");
+		INSERT INTO "account_search_fts" ("rowid", "name", "note") VALUES (new."id", new."name", new."note");
+	END`,
+}
+
+var pgSearchIndexes = []string{
+	`CREATE TABLE IF NOT EXISTS "status_search" (
+		"status_id" CHAR(26) NOT NULL PRIMARY KEY,
+		"text" TEXT NOT NULL DEFAULT '',
+		"document" TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple', "text")) STORED
+	)`,
+	`CREATE INDEX IF NOT EXISTS "status_search_document_idx" ON "status_search" USING GIN ("document")`,
+	`CREATE TABLE IF NOT EXISTS "account_search" (
+		"account_id" CHAR(26) NOT NULL PRIMARY KEY,
+		"name" TEXT NOT NULL DEFAULT '',
+		"note" TEXT NOT NULL DEFAULT '',
+		"document" TSVECTOR GENERATED ALWAYS AS (
+			setweight(to_tsvector('simple', "name"), 'A') ||
+			setweight(to_tsvector('simple', "note"), 'B')
+		) STORED
+	)`,
+	`CREATE INDEX IF NOT EXISTS "account_search_document_idx" ON "account_search" USING GIN ("document")`,
+}
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		if err := db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Add the indexable flag to accounts.
+			exists, err := doesColumnExist(ctx, tx, "accounts", "indexable")
+			if err != nil {
+				return err
+			}
+
+			if !exists {
+				log.Info(ctx, "adding accounts.indexable column...")
+				if _, err := tx.ExecContext(
+					ctx,
+					"ALTER TABLE ? ADD COLUMN ? BOOLEAN NOT NULL DEFAULT FALSE",
+					bun.Ident("accounts"),
+					bun.Ident("indexable"),
+				); err != nil {
+					return fmt.Errorf("error adding column: %w", err)
+				}
+			}
+
+			var stmts []string
+			switch d := tx.Dialect().Name(); d {
+			case dialect.SQLite:
+				stmts = sqliteSearchIndexes
+			case dialect.PG:
+				stmts = pgSearchIndexes
+			default:
+				panic("dialect " + d.String() + " was neither pg nor sqlite")
+			}
+
+			log.Info(ctx, "creating full-text search index tables...")
+			for _, stmt := range stmts {
+				if _, err := tx.ExecContext(ctx, stmt); err != nil {
+					return fmt.Errorf("error creating search index: %w", err)
+				}
+			}
+
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		// Populate the new indexes from existing
+		// accounts and statuses, batch by batch.
+		if err := backfillAccountSearch(ctx, db); err != nil {
+			return err
+		}
+
+		return backfillStatusSearch(ctx, db)
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
+
+// searchBackfillBatch is the number of
+// rows to index in each backfill insert.
+const searchBackfillBatch = 500
+
+type accountSearchEntry struct {
+	bun.BaseModel `bun:"table:account_search"`
+	AccountID     string
+	Name          string
+	Note          string
+}
+
+type statusSearchEntry struct {
+	bun.BaseModel `bun:"table:status_search"`
+	StatusID      string
+	Text          string
+}
+
+func backfillAccountSearch(ctx context.Context, db *bun.DB) error {
+	var (
+		maxID string
+		total int
+	)
+
+	log.Info(ctx, "indexing accounts for full-text search, this may take a while...")
+	for {
+		var accounts []struct {
+			ID          string
+			Username    string
+			DisplayName string
+			Note        string
+		}
+
+		if err := db.NewSelect().
+			Table("accounts").
+			Column("id", "username", "display_name", "note").
+			Where("? > ?", bun.Ident("id"), maxID).
+			Order("id ASC").
+			Limit(searchBackfillBatch).
+			Scan(ctx, &accounts); err != nil {
+			return fmt.Errorf("error selecting accounts: %w", err)
+		}
+
+		if len(accounts) == 0 {
+			break
+		}
+
+		entries := make([]*accountSearchEntry, 0, len(accounts))
+		for _, account := range accounts {
+			entries = append(entries, &accountSearchEntry{
+				AccountID: account.ID,
+				Name:      text.SearchText(account.Username, account.DisplayName),
+				Note:      text.SearchText(account.Note),
+			})
+		}
+
+		if _, err := db.NewInsert().
+			Model(&entries).
+			On("CONFLICT (?) DO NOTHING", bun.Ident("account_id")).
+			Exec(ctx); err != nil {
+			return fmt.Errorf("error indexing accounts: %w", err)
+		}
+
+		maxID = accounts[len(accounts)-1].ID
+		total += len(accounts)
+		log.Infof(ctx, "[~%d accounts indexed so far]", total)
+	}
+
+	return nil
+}
+
+func backfillStatusSearch(ctx context.Context, db *bun.DB) error {
+	var (
+		maxID string
+		total int
+	)
+
+	log.Info(ctx, "indexing statuses for full-text search, this may take a while...")
+	for {
+		var statuses []struct {
+			ID             string
+			Content        string
+			ContentWarning string
+		}
+
+		if err := db.NewSelect().
+			Table("statuses").
+			Column("id", "content", "content_warning").
+			Where("? IS NULL", bun.Ident("boost_of_id")).
+			Where("? > ?", bun.Ident("id"), maxID).
+			Order("id ASC").
+			Limit(searchBackfillBatch).
+			Scan(ctx, &statuses); err != nil {
+			return fmt.Errorf("error selecting statuses: %w", err)
+		}
+
+		if len(statuses) == 0 {
+			break
+		}
+
+		entries := make([]*statusSearchEntry, 0, len(statuses))
+		for _, status := range statuses {
+			entries = append(entries, &statusSearchEntry{
+				StatusID: status.ID,
+				Text:     text.SearchText(status.ContentWarning, status.Content),
+			})
+		}
+
+		if _, err := db.NewInsert().
+			Model(&entries).
+			On("CONFLICT (?) DO NOTHING", bun.Ident("status_id")).
+			Exec(ctx); err != nil {
+			return fmt.Errorf("error indexing statuses: %w", err)
+		}
+
+		maxID = statuses[len(statuses)-1].ID
+		total += len(statuses)
+		log.Infof(ctx, "[~%d statuses indexed so far]", total)
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	gtsmodel "code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			statusType := reflect.TypeOf((*gtsmodel.Status)(nil))
+
+			// Add new quote columns to statuses
+			// table if they don't exist already.
+			for _, new := range []struct {
+				dbCol     string
+				fieldName string
+			}{
+				{
+					dbCol:     "quote_of_id",
+					fieldName: "QuoteOfID",
+				},
+				{
+					dbCol:     "quote_of_uri",
+					fieldName: "QuoteOfURI",
+				},
+				{
+					dbCol:     "quote_of_account_id",
+					fieldName: "QuoteOfAccountID",
+				},
+				{
+					dbCol:     "quote_pending_approval",
+					fieldName: "QuotePendingApproval",
+				},
+				{
+					dbCol:     "quote_approved_by_uri",
+					fieldName: "QuoteApprovedByURI",
+				},
+			} {
+				exists, err := doesColumnExist(
+					ctx,
+					tx,
+					"statuses",
+					new.dbCol,
+				)
+				if err != nil {
+					return err
+				}
+
+				if exists {
+					// Column already exists.
+					continue
+				}
+
+				// Column doesn't exist yet, add it.
+				colDef, err := getBunColumnDef(tx, statusType, new.fieldName)
+				if err != nil {
+					return fmt.Errorf("error making column def: %w", err)
+				}
+
+				log.Infof(ctx, "adding statuses.%s column...", new.dbCol)
+				if _, err := tx.
+					NewAddColumn().
+					Table("statuses").
+					ColumnExpr(colDef).
+					Exec(ctx); err != nil {
+					return fmt.Errorf("error adding column: %w", err)
+				}
+			}
+
+			// Index quote_of_id, used when looking
+			// up quotes of a status (eg., on deletion).
+			if _, err := tx.
+				NewCreateIndex().
+				Table("statuses").
+				Index("statuses_quote_of_id_idx").
+				Column("quote_of_id").
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,99 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	gtsmodel "code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			if _, err := tx.
+				NewCreateTable().
+				Model(&gtsmodel.FeaturedTag{}).
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// Add featured tags URI
+			// column to accounts table.
+			exists, err := doesColumnExist(
+				ctx,
+				tx,
+				"accounts",
+				"featured_tags_uri",
+			)
+			if err != nil {
+				return err
+			}
+
+			if exists {
+				// Column already
+				// exists, we're done.
+				return nil
+			}
+
+			accountType := reflect.TypeOf((*gtsmodel.Account)(nil))
+			colDef, err := getBunColumnDef(tx, accountType, "FeaturedTagsURI")
+			if err != nil {
+				return fmt.Errorf("error making column def: %w", err)
+			}
+
+			log.Info(ctx, "adding accounts.featured_tags_uri column...")
+			if _, err := tx.
+				NewAddColumn().
+				Table("accounts").
+				ColumnExpr(colDef).
+				Exec(ctx); err != nil {
+				return fmt.Errorf("error adding column: %w", err)
+			}
+
+			// Set featured tags URI for local accounts,
+			// remote ones will have it set on next fetch.
+			log.Info(ctx, "setting featured tags uri for local accounts...")
+			if _, err := tx.
+				NewUpdate().
+				Table("accounts").
+				Set("? = ? || ?", bun.Ident("featured_tags_uri"), bun.Ident("uri"), "/collections/tags").
+				Where("? IS NULL", bun.Ident("domain")).
+				Exec(ctx); err != nil {
+				return fmt.Errorf("error setting featured tags uri: %w", err)
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
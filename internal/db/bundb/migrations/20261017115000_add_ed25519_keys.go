@@ -0,0 +1,132 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"reflect"
+
+	gtsmodel "code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/uris"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// Add new columns to accounts + instances tables.
+			for _, col := range []struct {
+				table string
+				model any
+				field string
+				name  string
+			}{
+				{"accounts", (*gtsmodel.Account)(nil), "Ed25519PrivateKey", "ed25519_private_key"},
+				{"accounts", (*gtsmodel.Account)(nil), "Ed25519PublicKey", "ed25519_public_key"},
+				{"accounts", (*gtsmodel.Account)(nil), "Ed25519PublicKeyURI", "ed25519_public_key_uri"},
+				{"instances", (*gtsmodel.Instance)(nil), "SignatureScheme", "signature_scheme"},
+			} {
+				exists, err := doesColumnExist(ctx, tx, col.table, col.name)
+				if err != nil {
+					return err
+				}
+
+				if exists {
+					continue
+				}
+
+				colDef, err := getBunColumnDef(tx, reflect.TypeOf(col.model), col.field)
+				if err != nil {
+					return fmt.Errorf("error making column def: %w", err)
+				}
+
+				log.Infof(ctx, "adding %s.%s column...", col.table, col.name)
+				if _, err := tx.
+					NewAddColumn().
+					Table(col.table).
+					ColumnExpr(colDef).
+					Exec(ctx); err != nil {
+					return fmt.Errorf("error adding column: %w", err)
+				}
+			}
+
+			// Unique constraints can't be added along
+			// with a new column in SQLite, so index it.
+			if _, err := tx.
+				NewCreateIndex().
+				Table("accounts").
+				Index("accounts_ed25519_public_key_uri_idx").
+				Column("ed25519_public_key_uri").
+				Unique().
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// Generate Ed25519 keys for any local
+			// accounts that don't yet have one.
+			var accounts []*gtsmodel.Account
+			if err := tx.
+				NewSelect().
+				Model(&accounts).
+				Column("id", "uri").
+				Where("? IS NULL", bun.Ident("domain")).
+				Where("? IS NULL", bun.Ident("ed25519_private_key")).
+				Scan(ctx); err != nil {
+				return err
+			}
+
+			log.Infof(ctx, "generating ed25519 keys for %d local accounts...", len(accounts))
+			for _, account := range accounts {
+				pub, priv, err := ed25519.GenerateKey(rand.Reader)
+				if err != nil {
+					return fmt.Errorf("error generating ed25519 key: %w", err)
+				}
+
+				account.Ed25519PrivateKey = priv
+				account.Ed25519PublicKey = pub
+				account.Ed25519PublicKeyURI = account.URI + "/" + uris.PublicKeyPath + "#" + uris.Ed25519KeyFragment
+
+				if _, err := tx.
+					NewUpdate().
+					Model(account).
+					Column("ed25519_private_key", "ed25519_public_key", "ed25519_public_key_uri").
+					Where("? = ?", bun.Ident("id"), account.ID).
+					Exec(ctx); err != nil {
+					return fmt.Errorf("error updating account %s: %w", account.ID, err)
+				}
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
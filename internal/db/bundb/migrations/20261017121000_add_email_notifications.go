@@ -0,0 +1,82 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	gtsmodel "code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			settingsType := reflect.TypeOf((*gtsmodel.AccountSettings)(nil))
+
+			// Add new email notification columns to account settings table.
+			for _, col := range []struct {
+				column    string
+				fieldName string
+			}{
+				{"email_notifications", "EmailNotifications"},
+				{"email_notification_types", "EmailNotificationTypes"},
+				{"email_digest_sent_at", "EmailDigestSentAt"},
+			} {
+				column := col.column
+				exists, err := doesColumnExist(ctx, tx, "account_settings", column)
+				if err != nil {
+					return err
+				}
+
+				if exists {
+					continue
+				}
+
+				colDef, err := getBunColumnDef(tx, settingsType, col.fieldName)
+				if err != nil {
+					return fmt.Errorf("error making column def: %w", err)
+				}
+
+				log.Infof(ctx, "adding account_settings.%s column...", column)
+				if _, err := tx.
+					NewAddColumn().
+					Table("account_settings").
+					ColumnExpr(colDef).
+					Exec(ctx); err != nil {
+					return fmt.Errorf("error adding column: %w", err)
+				}
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -214,6 +214,8 @@ func (n *notificationDB) GetAccountNotifications(
 	page *paging.Page,
 	types []gtsmodel.NotificationType,
 	excludeTypes []gtsmodel.NotificationType,
+	originAccountID string,
+	includeFiltered bool,
 ) ([]*gtsmodel.Notification, error) {
 	var (
 		// Get paging params.
@@ -254,6 +256,98 @@ func (n *notificationDB) GetAccountNotifications(
 	// Return only notifs for this account.
 	q = q.Where("? = ?", bun.Ident("notification.target_account_id"), accountID)
 
+	if originAccountID != "" {
+		// Return only notifs from this origin account.
+		q = q.Where("? = ?", bun.Ident("notification.origin_account_id"), originAccountID)
+	}
+
+	if !includeFiltered {
+		// Filter out notifs held back
+		// by the notification policy.
+		q = q.Where("? = ?", bun.Ident("notification.filtered"), false)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.Order("notification.id ASC")
+	} else {
+		// Page down.
+		q = q.Order("notification.id DESC")
+	}
+
+	if err := q.Scan(ctx, &notifIDs); err != nil {
+		return nil, err
+	}
+
+	if len(notifIDs) == 0 {
+		return nil, nil
+	}
+
+	// If we're paging up, we still want notifications
+	// to be sorted by ID desc, so reverse ids slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(notifIDs)
+	}
+
+	// Fetch notification models by their IDs.
+	return n.GetNotificationsByIDs(ctx, notifIDs)
+}
+
+func (n *notificationDB) GetAccountStatusNotifications(
+	ctx context.Context,
+	accountID string,
+	notifType gtsmodel.NotificationType,
+	statusID string,
+	page *paging.Page,
+) ([]*gtsmodel.Notification, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		notifIDs = make([]string, 0, limit)
+	)
+
+	q := n.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("notifications"), bun.Ident("notification")).
+		Column("notification.id")
+
+	if maxID != "" {
+		// Return only notifs LOWER (ie., older) than maxID.
+		q = q.Where("? < ?", bun.Ident("notification.id"), maxID)
+	}
+
+	if minID != "" {
+		// Return only notifs HIGHER (ie., newer) than minID.
+		q = q.Where("? > ?", bun.Ident("notification.id"), minID)
+	}
+
+	// Return only notifs for this account of given type,
+	// excluding any held back by the notification policy.
+	q = q.Where("? = ?", bun.Ident("notification.target_account_id"), accountID)
+	q = q.Where("? = ?", bun.Ident("notification.notification_type"), notifType)
+	q = q.Where("? = ?", bun.Ident("notification.filtered"), false)
+
+	// Return only notifs about status, or boosts of it.
+	q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.
+			Where("? = ?", bun.Ident("notification.status_id"), statusID).
+			WhereOr("? IN (?)", bun.Ident("notification.status_id"), n.db.
+				NewSelect().
+				Table("statuses").
+				Column("id").
+				Where("? = ?", bun.Ident("boost_of_id"), statusID),
+			)
+	})
+
 	if limit > 0 {
 		q = q.Limit(limit)
 	}
@@ -342,6 +436,45 @@ func (n *notificationDB) DeleteNotifications(ctx context.Context, types []gtsmod
 	return nil
 }
 
+func (n *notificationDB) UnfilterNotifications(ctx context.Context, targetAccountID string, originAccountID string) error {
+	var notifIDs []string
+
+	if _, err := n.db.
+		NewUpdate().
+		Table("notifications").
+		Set("? = ?", bun.Ident("filtered"), false).
+		Where("? = ?", bun.Ident("target_account_id"), targetAccountID).
+		Where("? = ?", bun.Ident("origin_account_id"), originAccountID).
+		Where("? = ?", bun.Ident("filtered"), true).
+		Returning("?", bun.Ident("id")).
+		Exec(ctx, &notifIDs); err != nil {
+		return err
+	}
+
+	// Invalidate all updated notifications by IDs.
+	n.state.Caches.DB.Notification.InvalidateIDs("ID", notifIDs)
+	return nil
+}
+
+func (n *notificationDB) DeleteFilteredNotifications(ctx context.Context, targetAccountID string, originAccountID string) error {
+	var notifIDs []string
+
+	if _, err := n.db.
+		NewDelete().
+		Table("notifications").
+		Where("? = ?", bun.Ident("target_account_id"), targetAccountID).
+		Where("? = ?", bun.Ident("origin_account_id"), originAccountID).
+		Where("? = ?", bun.Ident("filtered"), true).
+		Returning("?", bun.Ident("id")).
+		Exec(ctx, &notifIDs); err != nil {
+		return err
+	}
+
+	// Invalidate all deleted notifications by IDs.
+	n.state.Caches.DB.Notification.InvalidateIDs("ID", notifIDs)
+	return nil
+}
+
 func (n *notificationDB) DeleteNotificationsForStatus(ctx context.Context, statusID string) error {
 	var notifIDs []string
 
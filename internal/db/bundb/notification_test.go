@@ -85,6 +85,8 @@ func (suite *NotificationTestSuite) TestGetAccountNotificationsWithSpam() {
 		},
 		nil,
 		nil,
+		"",
+		false,
 	)
 	suite.NoError(err)
 	timeTaken := time.Since(before)
@@ -109,6 +111,8 @@ func (suite *NotificationTestSuite) TestGetAccountNotificationsWithoutSpam() {
 		},
 		nil,
 		nil,
+		"",
+		false,
 	)
 	suite.NoError(err)
 	timeTaken := time.Since(before)
@@ -135,6 +139,8 @@ func (suite *NotificationTestSuite) TestDeleteNotificationsWithSpam() {
 		},
 		nil,
 		nil,
+		"",
+		false,
 	)
 	if err != nil {
 		suite.FailNow(err.Error())
@@ -157,6 +163,8 @@ func (suite *NotificationTestSuite) TestDeleteNotificationsWithSpam() {
 		},
 		nil,
 		nil,
+		"",
+		false,
 	)
 	if err != nil {
 		suite.FailNow(err.Error())
@@ -180,6 +188,8 @@ func (suite *NotificationTestSuite) TestDeleteNotificationsWithTwoAccounts() {
 		},
 		nil,
 		nil,
+		"",
+		false,
 	)
 	suite.NoError(err)
 	suite.Nil(notifications)
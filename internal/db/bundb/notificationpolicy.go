@@ -0,0 +1,280 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/paging"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type notificationPolicyDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (n *notificationPolicyDB) GetNotificationPolicy(ctx context.Context, accountID string) (*gtsmodel.NotificationPolicy, error) {
+	policy := new(gtsmodel.NotificationPolicy)
+
+	if err := n.db.
+		NewSelect().
+		Model(policy).
+		Where("? = ?", bun.Ident("notification_policy.account_id"), accountID).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func (n *notificationPolicyDB) PutNotificationPolicy(ctx context.Context, policy *gtsmodel.NotificationPolicy) error {
+	policy.UpdatedAt = time.Now()
+	_, err := n.db.
+		NewInsert().
+		Model(policy).
+		On("CONFLICT (?) DO UPDATE", bun.Ident("account_id")).
+		Set("? = EXCLUDED.?", bun.Ident("updated_at"), bun.Ident("updated_at")).
+		Set("? = EXCLUDED.?", bun.Ident("for_not_following"), bun.Ident("for_not_following")).
+		Set("? = EXCLUDED.?", bun.Ident("for_not_followers"), bun.Ident("for_not_followers")).
+		Set("? = EXCLUDED.?", bun.Ident("for_new_accounts"), bun.Ident("for_new_accounts")).
+		Set("? = EXCLUDED.?", bun.Ident("for_private_mentions"), bun.Ident("for_private_mentions")).
+		Set("? = EXCLUDED.?", bun.Ident("for_limited_accounts"), bun.Ident("for_limited_accounts")).
+		Exec(ctx)
+	return err
+}
+
+func (n *notificationPolicyDB) GetNotificationRequestByID(ctx context.Context, id string) (*gtsmodel.NotificationRequest, error) {
+	return n.getNotificationRequest(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("? = ?", bun.Ident("notification_request.id"), id)
+	})
+}
+
+func (n *notificationPolicyDB) GetNotificationRequest(ctx context.Context, accountID string, fromAccountID string) (*gtsmodel.NotificationRequest, error) {
+	return n.getNotificationRequest(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.
+			Where("? = ?", bun.Ident("notification_request.account_id"), accountID).
+			Where("? = ?", bun.Ident("notification_request.from_account_id"), fromAccountID)
+	})
+}
+
+func (n *notificationPolicyDB) getNotificationRequest(ctx context.Context, where func(*bun.SelectQuery) *bun.SelectQuery) (*gtsmodel.NotificationRequest, error) {
+	request := new(gtsmodel.NotificationRequest)
+
+	q := n.db.
+		NewSelect().
+		Model(request)
+
+	if err := where(q).Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return request, nil
+	}
+
+	if err := n.populateNotificationRequest(ctx, request); err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+func (n *notificationPolicyDB) GetAccountNotificationRequests(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.NotificationRequest, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		requests = make([]*gtsmodel.NotificationRequest, 0, limit)
+	)
+
+	q := n.db.
+		NewSelect().
+		Model(&requests).
+		Where("? = ?", bun.Ident("notification_request.account_id"), accountID)
+
+	if maxID != "" {
+		// Return only requests LOWER (ie., older) than maxID.
+		q = q.Where("? < ?", bun.Ident("notification_request.id"), maxID)
+	}
+
+	if minID != "" {
+		// Return only requests HIGHER (ie., newer) than minID.
+		q = q.Where("? > ?", bun.Ident("notification_request.id"), minID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.Order("notification_request.id ASC")
+	} else {
+		// Page down.
+		q = q.Order("notification_request.id DESC")
+	}
+
+	if err := q.Scan(ctx); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return nil, err
+	}
+
+	// If we're paging up, we still want requests
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(requests)
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return requests, nil
+	}
+
+	for _, request := range requests {
+		if err := n.populateNotificationRequest(ctx, request); err != nil {
+			return nil, err
+		}
+	}
+
+	return requests, nil
+}
+
+func (n *notificationPolicyDB) populateNotificationRequest(ctx context.Context, request *gtsmodel.NotificationRequest) error {
+	var (
+		err  error
+		errs gtserror.MultiError
+	)
+
+	if request.Account == nil {
+		// Request target account is not set, fetch from database.
+		request.Account, err = n.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			request.AccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating request account: %w", err)
+		}
+	}
+
+	if request.FromAccount == nil {
+		// Request origin account is not set, fetch from database.
+		request.FromAccount, err = n.state.DB.GetAccountByID(ctx,
+			request.FromAccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating request from account: %w", err)
+		}
+	}
+
+	if request.LastStatusID != "" && request.LastStatus == nil {
+		// Request last status is not set, fetch from database.
+		request.LastStatus, err = n.state.DB.GetStatusByID(ctx,
+			request.LastStatusID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			errs.Appendf("error populating request last status: %w", err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (n *notificationPolicyDB) CountAccountNotificationRequests(ctx context.Context, accountID string) (int, int, error) {
+	var requests, notifications int
+
+	if err := n.db.
+		NewSelect().
+		Table("notification_requests").
+		ColumnExpr("COUNT(*)").
+		ColumnExpr("COALESCE(SUM(?), 0)", bun.Ident("notifications_count")).
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Scan(ctx, &requests, &notifications); err != nil {
+		return 0, 0, err
+	}
+
+	return requests, notifications, nil
+}
+
+func (n *notificationPolicyDB) PutNotificationRequest(ctx context.Context, request *gtsmodel.NotificationRequest) error {
+	_, err := n.db.
+		NewInsert().
+		Model(request).
+		Exec(ctx)
+	return err
+}
+
+func (n *notificationPolicyDB) UpdateNotificationRequest(ctx context.Context, request *gtsmodel.NotificationRequest, columns ...string) error {
+	request.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := n.db.
+		NewUpdate().
+		Model(request).
+		Column(columns...).
+		Where("? = ?", bun.Ident("notification_request.id"), request.ID).
+		Exec(ctx)
+	return err
+}
+
+func (n *notificationPolicyDB) DeleteNotificationRequestByID(ctx context.Context, id string) error {
+	if _, err := n.db.
+		NewDelete().
+		Table("notification_requests").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+	return nil
+}
+
+func (n *notificationPolicyDB) IsNotificationPermitted(ctx context.Context, accountID string, fromAccountID string) (bool, error) {
+	return exists(ctx, n.db.
+		NewSelect().
+		Table("notification_permissions").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Where("? = ?", bun.Ident("from_account_id"), fromAccountID),
+	)
+}
+
+func (n *notificationPolicyDB) PutNotificationPermission(ctx context.Context, permission *gtsmodel.NotificationPermission) error {
+	_, err := n.db.
+		NewInsert().
+		Model(permission).
+		On("CONFLICT (?, ?) DO NOTHING", bun.Ident("account_id"), bun.Ident("from_account_id")).
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,171 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type relayDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (r *relayDB) GetRelayByID(ctx context.Context, id string) (*gtsmodel.Relay, error) {
+	return r.getRelay(ctx, "id", id)
+}
+
+func (r *relayDB) GetRelayByInboxURI(ctx context.Context, inboxURI string) (*gtsmodel.Relay, error) {
+	return r.getRelay(ctx, "inbox_uri", inboxURI)
+}
+
+func (r *relayDB) GetRelayByFollowURI(ctx context.Context, followURI string) (*gtsmodel.Relay, error) {
+	return r.getRelay(ctx, "follow_uri", followURI)
+}
+
+func (r *relayDB) GetRelayByAccountID(ctx context.Context, accountID string) (*gtsmodel.Relay, error) {
+	return r.getRelay(ctx, "account_id", accountID)
+}
+
+func (r *relayDB) getRelay(ctx context.Context, column string, value string) (*gtsmodel.Relay, error) {
+	relay := new(gtsmodel.Relay)
+
+	if err := r.db.
+		NewSelect().
+		Model(relay).
+		Where("? = ?", bun.Ident("relay."+column), value).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return relay, nil
+	}
+
+	if err := r.populateRelay(ctx, relay); err != nil {
+		return nil, err
+	}
+
+	return relay, nil
+}
+
+func (r *relayDB) GetRelays(ctx context.Context) ([]*gtsmodel.Relay, error) {
+	return r.getRelays(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q
+	})
+}
+
+func (r *relayDB) GetPublishRelays(ctx context.Context) ([]*gtsmodel.Relay, error) {
+	return r.getRelays(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.
+			Where("? = ?", bun.Ident("relay.state"), gtsmodel.RelayStateAccepted).
+			Where("? = ?", bun.Ident("relay.publish"), true)
+	})
+}
+
+func (r *relayDB) getRelays(ctx context.Context, where func(*bun.SelectQuery) *bun.SelectQuery) ([]*gtsmodel.Relay, error) {
+	var relays []*gtsmodel.Relay
+
+	q := r.db.
+		NewSelect().
+		Model(&relays).
+		Order("relay.id DESC")
+
+	if err := where(q).Scan(ctx); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return nil, err
+	}
+
+	if gtscontext.Barebones(ctx) {
+		// no need to fully populate.
+		return relays, nil
+	}
+
+	for _, relay := range relays {
+		if err := r.populateRelay(ctx, relay); err != nil {
+			return nil, err
+		}
+	}
+
+	return relays, nil
+}
+
+func (r *relayDB) populateRelay(ctx context.Context, relay *gtsmodel.Relay) error {
+	if relay.AccountID == "" || relay.Account != nil {
+		// Nothing to populate.
+		return nil
+	}
+
+	var err error
+	relay.Account, err = r.state.DB.GetAccountByID(
+		gtscontext.SetBarebones(ctx),
+		relay.AccountID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error populating relay account: %w", err)
+	}
+
+	return nil
+}
+
+func (r *relayDB) PutRelay(ctx context.Context, relay *gtsmodel.Relay) error {
+	_, err := r.db.
+		NewInsert().
+		Model(relay).
+		Exec(ctx)
+	return err
+}
+
+func (r *relayDB) UpdateRelay(ctx context.Context, relay *gtsmodel.Relay, columns ...string) error {
+	relay.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := r.db.
+		NewUpdate().
+		Model(relay).
+		Column(columns...).
+		Where("? = ?", bun.Ident("relay.id"), relay.ID).
+		Exec(ctx)
+	return err
+}
+
+func (r *relayDB) DeleteRelayByID(ctx context.Context, id string) error {
+	if _, err := r.db.
+		NewDelete().
+		Table("relays").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+	return nil
+}
@@ -21,54 +21,63 @@
 	"context"
 	"strings"
 
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
 	"code.superseriousbusiness.org/gotosocial/internal/id"
 	"code.superseriousbusiness.org/gotosocial/internal/log"
 	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"code.superseriousbusiness.org/gotosocial/internal/text"
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/dialect"
 )
 
-// todo: currently we pass an 'offset' parameter into functions owned by this struct,
-// which is ignored.
+// Free text queries are answered from full-text search indexes,
+// which are stored in the "account_search" and "status_search"
+// tables alongside the accounts and statuses they refer to (see
+// the add_search_indexes migration). On SQLite, these tables are
+// backed by FTS5 virtual tables and results are ranked by bm25;
+// on Postgres, they carry a GIN-indexed tsvector column and results
+// are ranked by ts_rank.
 //
-// The idea of 'offset' is to allow callers to page through results without supplying
-// maxID or minID params; they simply use the offset as more or less a 'page number'.
-// This works fine when you're dealing with something like Elasticsearch, but for
-// SQLite or Postgres 'LIKE' queries it doesn't really, because for each higher offset
-// you have to calculate the value of all the previous offsets as well *within the
-// execution time of the query*. It's MUCH more efficient to page using maxID and
-// minID for queries like this. For now, then, we just ignore the offset and hope that
-// the caller will page using maxID and minID instead.
-//
-// In future, however, it would be good to support offset in a way that doesn't totally
-// destroy database queries. One option would be to cache previous offsets when paging
-// down (which is the most common use case).
-//
-// For example, say a caller makes a call with offset 0: we run the query as normal,
-// and in a 10 minute cache or something, store the next maxID value as it would be for
-// offset 1, for the supplied query, limit, following, etc. Then when they call for
-// offset 1, instead of supplying 'offset' in the query and causing slowdown, we check
-// the cache to see if we have the next maxID value stored for that query, and use that
-// instead. If a caller out of the blue requests offset 4 or something, on an empty cache,
-// we could run the previous 4 queries and store the offsets for those before making the
-// 5th call for page 4.
-//
-// This isn't ideal, of course, but at least we could cover the most common use case of
-// a caller paging down through results.
+// Since results of a free text query are ordered by relevance rather
+// than by ID, callers can page through them using 'offset'. maxID and
+// minID are still respected, but only as bounds on the result set.
 type searchDB struct {
 	db    *bun.DB
 	state *state.State
 }
 
+// maxSearchTerms is the maximum number of
+// terms from a query that will be passed
+// through to the full-text search index.
+const maxSearchTerms = 16
+
+// accountSearchEntry models a
+// row in the "account_search" table.
+type accountSearchEntry struct {
+	bun.BaseModel `bun:"table:account_search"`
+	AccountID     string
+	Name          string
+	Note          string
+}
+
+// statusSearchEntry models a
+// row in the "status_search" table.
+type statusSearchEntry struct {
+	bun.BaseModel `bun:"table:status_search"`
+	StatusID      string
+	Text          string
+}
+
 // Query example (SQLite):
 //
-//	SELECT "account"."id" FROM "accounts" AS "account"
-//	WHERE (("account"."domain" IS NULL) OR ("account"."domain" != "account"."username"))
+//	SELECT "account"."id" FROM "account_search_fts"
+//	JOIN "account_search" AS "account_search" ON "account_search"."id" = "account_search_fts"."rowid"
+//	JOIN "accounts" AS "account" ON "account"."id" = "account_search"."account_id"
+//	WHERE ("account_search_fts" MATCH '{name} : ("turtle"*)')
+//	AND (("account"."domain" IS NULL) OR ("account"."domain" != "account"."username"))
 //	AND ("account"."id" < 'ZZZZZZZZZZZZZZZZZZZZZZZZZZ')
-//	AND ("account"."id" IN (SELECT "target_account_id" FROM "follows" WHERE ("account_id" = '016T5Q3SQKBT337DAKVSKNXXW1')))
-//	AND ((SELECT "account"."username" || COALESCE("account"."display_name", '') || COALESCE("account"."note", '') AS "account_text") LIKE '%turtle%' ESCAPE '\')
-//	ORDER BY "account"."id" DESC LIMIT 10
+//	ORDER BY bm25("account_search_fts", 4.0, 1.0) ASC, "account"."id" DESC LIMIT 10
 func (s *searchDB) SearchForAccounts(
 	ctx context.Context,
 	accountID string,
@@ -83,16 +92,42 @@ func (s *searchDB) SearchForAccounts(
 	if limit < 0 {
 		limit = 0
 	}
+	if offset < 0 {
+		offset = 0
+	}
 
 	// Make educated guess for slice size
 	var (
 		accountIDs  = make([]string, 0, limit)
 		frontToBack = true
+		ranked      = false
 	)
 
-	q := s.db.
-		NewSelect().
-		TableExpr("? AS ?", bun.Ident("accounts"), bun.Ident("account")).
+	q := s.db.NewSelect()
+
+	if strings.HasPrefix(query, "@") {
+		// Query looks a bit like a username.
+		// Normalize it and just look for
+		// usernames that start with query.
+		query = query[1:]
+		q = q.TableExpr("? AS ?", bun.Ident("accounts"), bun.Ident("account"))
+		q = whereStartsLike(q, bun.Ident("account.username"), query)
+	} else {
+		// Query looks like arbitrary string.
+		// Split it into terms and search
+		// for these in the full-text index.
+		terms := searchTerms(query)
+		if len(terms) == 0 {
+			return nil, nil
+		}
+
+		// Only include account notes when
+		// searching through accounts we follow.
+		q = s.matchAccounts(q, terms, following)
+		ranked = true
+	}
+
+	q = q.
 		// Select only IDs from table.
 		Column("account.id").
 		// Try to ignore instance accounts. Account domain must
@@ -128,29 +163,28 @@ func (s *searchDB) SearchForAccounts(
 		)
 	}
 
-	if strings.HasPrefix(query, "@") {
-		// Query looks a bit like a username.
-		// Normalize it and just look for
-		// usernames that start with query.
-		query = query[1:]
-		q = whereStartsLike(q, bun.Ident("account.username"), query)
-	} else {
-		// Query looks like arbitrary string.
-		// Search using LIKE for matches of query
-		// string within accountText subquery.
-		subQ := s.accountText(following)
-		q = whereLike(q, subQ, query)
-	}
-
 	if limit > 0 {
 		// Limit amount of accounts returned.
 		q = q.Limit(limit)
 	}
 
-	if frontToBack {
+	if offset > 0 {
+		// Skip previous pages.
+		q = q.Offset(offset)
+	}
+
+	switch {
+	case ranked:
+		// Already ordered by relevance,
+		// break any ties by newest first.
+		q = q.Order("account.id DESC")
+		frontToBack = true
+
+	case frontToBack:
 		// Page down.
 		q = q.Order("account.id DESC")
-	} else {
+
+	default:
 		// Page up.
 		q = q.Order("account.id ASC")
 	}
@@ -198,77 +232,81 @@ func (s *searchDB) followedAccounts(accountID string) *bun.SelectQuery {
 		Where("? = ?", bun.Ident("follow.account_id"), accountID)
 }
 
-// accountText returns a subquery that selects a concatenation
-// of account username and display name as "account_text". If
-// `following` is true, then account note will also be included
-// in the concatenation.
-func (s *searchDB) accountText(following bool) *bun.SelectQuery {
-	var (
-		accountText = s.db.NewSelect()
-		query       string
-		args        []interface{}
-	)
+// matchAccounts sets the given query to select from the
+// account full-text search index joined with "accounts"
+// AS "account", matching the given search terms against
+// account username and display name, ordered by relevance.
+// If includeNote is true, account note is searched as well.
+func (s *searchDB) matchAccounts(
+	q *bun.SelectQuery,
+	terms []string,
+	includeNote bool,
+) *bun.SelectQuery {
+	switch d := s.db.Dialect().Name(); d {
 
-	if following {
-		// If querying for accounts we follow,
-		// include note in text search params.
-		args = []interface{}{
-			bun.Ident("account.username"),
-			bun.Ident("account.display_name"), "",
-			bun.Ident("account.note"), "",
-			bun.Ident("account_text"),
-		}
-	} else {
-		// If querying for accounts we're not following,
-		// don't include note in text search params.
-		args = []interface{}{
-			bun.Ident("account.username"),
-			bun.Ident("account.display_name"), "",
-			bun.Ident("account_text"),
+	case dialect.SQLite:
+		match := ftsMatch(terms)
+		if !includeNote {
+			// Restrict match to name column.
+			match = "{name} : (" + match + ")"
 		}
-	}
 
-	// SQLite and Postgres use different syntaxes for
-	// concatenation, and we also need to use a
-	// different number of placeholders depending on
-	// following/not following. COALESCE calls ensure
-	// that we're not trying to concatenate null values.
+		return q.
+			TableExpr("?", bun.Ident("account_search_fts")).
+			Join("JOIN ? AS ? ON ? = ?",
+				bun.Ident("account_search"), bun.Ident("account_search"),
+				bun.Ident("account_search.id"), bun.Ident("account_search_fts.rowid"),
+			).
+			Join("JOIN ? AS ? ON ? = ?",
+				bun.Ident("accounts"), bun.Ident("account"),
+				bun.Ident("account.id"), bun.Ident("account_search.account_id"),
+			).
+			Where("? MATCH ?", bun.Ident("account_search_fts"), match).
+			// Matches on name weigh more than matches on note.
+			OrderExpr("bm25(?, 4.0, 1.0) ASC", bun.Ident("account_search_fts"))
 
-	switch d := s.db.Dialect().Name(); {
-
-	case d == dialect.SQLite && following:
-		query = "? || COALESCE(?, ?) || COALESCE(?, ?) AS ?"
-
-	case d == dialect.SQLite && !following:
-		query = "? || COALESCE(?, ?) AS ?"
-
-	case d == dialect.PG && following:
-		query = "CONCAT(?, COALESCE(?, ?), COALESCE(?, ?)) AS ?"
+	case dialect.PG:
+		// Name is indexed with weight A, and
+		// note with weight B, so restrict terms
+		// to weight A to search on name only.
+		weights := "AB"
+		if !includeNote {
+			weights = "A"
+		}
 
-	case d == dialect.PG && !following:
-		query = "CONCAT(?, COALESCE(?, ?)) AS ?"
+		tsquery := tsQuery(terms, weights)
+		return q.
+			TableExpr("? AS ?", bun.Ident("account_search"), bun.Ident("account_search")).
+			Join("JOIN ? AS ? ON ? = ?",
+				bun.Ident("accounts"), bun.Ident("account"),
+				bun.Ident("account.id"), bun.Ident("account_search.account_id"),
+			).
+			Where("? @@ to_tsquery('simple', ?)", bun.Ident("account_search.document"), tsquery).
+			OrderExpr("ts_rank(?, to_tsquery('simple', ?)) DESC", bun.Ident("account_search.document"), tsquery)
 
 	default:
 		log.Panicf(nil, "db conn %s was neither pg nor sqlite", d)
+		return nil
 	}
-
-	return accountText.ColumnExpr(query, args...)
 }
 
 // Query example (SQLite):
 //
-//	SELECT "status"."id"
-//	FROM "statuses" AS "status"
-//	WHERE ("status"."boost_of_id" IS NULL)
-//	AND (("status"."account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF') OR ("status"."in_reply_to_account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF'))
+//	SELECT "status"."id" FROM "status_search_fts"
+//	JOIN "status_search" AS "status_search" ON "status_search"."id" = "status_search_fts"."rowid"
+//	JOIN "statuses" AS "status" ON "status"."id" = "status_search"."status_id"
+//	JOIN "accounts" AS "account" ON "account"."id" = "status"."account_id"
+//	WHERE ("status_search_fts" MATCH '"hello"*')
+//	AND ("status"."boost_of_id" IS NULL)
+//	AND (("status"."account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF') OR ("status"."in_reply_to_account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF') OR (("status"."visibility" = 2) AND ("account"."indexable" = TRUE)))
 //	AND ("status"."id" < 'ZZZZZZZZZZZZZZZZZZZZZZZZZZ')
-//	AND ((SELECT "status"."content" || COALESCE("status"."content_warning", '') AS "status_text") LIKE '%hello%' ESCAPE '\')
-//	ORDER BY "status"."id" DESC LIMIT 10
+//	ORDER BY bm25("status_search_fts") ASC, "status"."id" DESC LIMIT 10
 func (s *searchDB) SearchForStatuses(
 	ctx context.Context,
 	requestingAccountID string,
 	query string,
 	fromAccountID string,
+	hasMedia bool,
 	maxID string,
 	minID string,
 	limit int,
@@ -278,31 +316,69 @@ func (s *searchDB) SearchForStatuses(
 	if limit < 0 {
 		limit = 0
 	}
+	if offset < 0 {
+		offset = 0
+	}
 
 	// Make educated guess for slice size
 	var (
 		statusIDs   = make([]string, 0, limit)
 		frontToBack = true
+		terms       = searchTerms(query)
+		ranked      = len(terms) > 0
 	)
 
-	q := s.db.
-		NewSelect().
-		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+	q := s.db.NewSelect()
+
+	if ranked {
+		// Search for query
+		// terms in the index.
+		q = s.matchStatuses(q, terms)
+	} else if fromAccountID != "" || hasMedia {
+		// No terms to search for, but
+		// caller gave other criteria, so
+		// just return statuses that match
+		// those, newest first.
+		q = q.TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status"))
+	} else {
+		// Nothing to
+		// search for.
+		return nil, nil
+	}
+
+	q = q.
 		// Select only IDs from table
 		Column("status.id").
+		// Join on status author to
+		// check indexable preference.
+		Join("JOIN ? AS ? ON ? = ?",
+			bun.Ident("accounts"), bun.Ident("account"),
+			bun.Ident("account.id"), bun.Ident("status.account_id"),
+		).
 		// Ignore boosts.
 		Where("? IS NULL", bun.Ident("status.boost_of_id")).
-		// Select only statuses created by
-		// accountID or replying to accountID.
+		// Select only statuses created by accountID,
+		// replying to accountID, or public statuses by
+		// accounts that have opted in to being indexed.
 		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
 			return q.
 				Where("? = ?", bun.Ident("status.account_id"), requestingAccountID).
-				WhereOr("? = ?", bun.Ident("status.in_reply_to_account_id"), requestingAccountID)
+				WhereOr("? = ?", bun.Ident("status.in_reply_to_account_id"), requestingAccountID).
+				WhereGroup(" OR ", func(q *bun.SelectQuery) *bun.SelectQuery {
+					return q.
+						Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
+						Where("? = ?", bun.Ident("account.indexable"), true)
+				})
 		})
+
 	if fromAccountID != "" {
 		q = q.Where("? = ?", bun.Ident("status.account_id"), fromAccountID)
 	}
 
+	if hasMedia {
+		q = selectOnlyWithMedia(q)
+	}
+
 	// Return only items with a LOWER id than maxID.
 	if maxID == "" {
 		maxID = id.Highest
@@ -317,22 +393,28 @@ func (s *searchDB) SearchForStatuses(
 		frontToBack = false
 	}
 
-	// Select status text as subquery.
-	statusTextSubq := s.statusText()
-
-	// Search using LIKE for matches of query
-	// string within statusText subquery.
-	q = whereLike(q, statusTextSubq, query)
-
 	if limit > 0 {
 		// Limit amount of statuses returned.
 		q = q.Limit(limit)
 	}
 
-	if frontToBack {
+	if offset > 0 {
+		// Skip previous pages.
+		q = q.Offset(offset)
+	}
+
+	switch {
+	case ranked:
+		// Already ordered by relevance,
+		// break any ties by newest first.
+		q = q.Order("status.id DESC")
+		frontToBack = true
+
+	case frontToBack:
 		// Page down.
 		q = q.Order("status.id DESC")
-	} else {
+
+	default:
 		// Page up.
 		q = q.Order("status.id ASC")
 	}
@@ -370,32 +452,235 @@ func (s *searchDB) SearchForStatuses(
 	return statuses, nil
 }
 
-// statusText returns a subquery that selects a concatenation
-// of status content and content warning as "status_text".
-func (s *searchDB) statusText() *bun.SelectQuery {
-	statusText := s.db.NewSelect()
-
-	// SQLite and Postgres use different
-	// syntaxes for concatenation.
+// matchStatuses sets the given query to select from the
+// status full-text search index joined with "statuses"
+// AS "status", matching the given search terms against
+// status content and content warning, ordered by relevance.
+func (s *searchDB) matchStatuses(
+	q *bun.SelectQuery,
+	terms []string,
+) *bun.SelectQuery {
 	switch d := s.db.Dialect().Name(); d {
 
 	case dialect.SQLite:
-		statusText = statusText.ColumnExpr(
-			"? || COALESCE(?, ?) AS ?",
-			bun.Ident("status.content"), bun.Ident("status.content_warning"), "",
-			bun.Ident("status_text"))
+		return q.
+			TableExpr("?", bun.Ident("status_search_fts")).
+			Join("JOIN ? AS ? ON ? = ?",
+				bun.Ident("status_search"), bun.Ident("status_search"),
+				bun.Ident("status_search.id"), bun.Ident("status_search_fts.rowid"),
+			).
+			Join("JOIN ? AS ? ON ? = ?",
+				bun.Ident("statuses"), bun.Ident("status"),
+				bun.Ident("status.id"), bun.Ident("status_search.status_id"),
+			).
+			Where("? MATCH ?", bun.Ident("status_search_fts"), ftsMatch(terms)).
+			OrderExpr("bm25(?) ASC", bun.Ident("status_search_fts"))
 
 	case dialect.PG:
-		statusText = statusText.ColumnExpr(
-			"CONCAT(?, COALESCE(?, ?)) AS ?",
-			bun.Ident("status.content"), bun.Ident("status.content_warning"), "",
-			bun.Ident("status_text"))
+		tsquery := tsQuery(terms, "")
+		return q.
+			TableExpr("? AS ?", bun.Ident("status_search"), bun.Ident("status_search")).
+			Join("JOIN ? AS ? ON ? = ?",
+				bun.Ident("statuses"), bun.Ident("status"),
+				bun.Ident("status.id"), bun.Ident("status_search.status_id"),
+			).
+			Where("? @@ to_tsquery('simple', ?)", bun.Ident("status_search.document"), tsquery).
+			OrderExpr("ts_rank(?, to_tsquery('simple', ?)) DESC", bun.Ident("status_search.document"), tsquery)
 
 	default:
 		log.Panicf(nil, "db conn %s was neither pg nor sqlite", d)
+		return nil
+	}
+}
+
+// searchTerms splits the given query into
+// search terms, the same way that indexed
+// text is split, capped at maxSearchTerms.
+func searchTerms(query string) []string {
+	terms := text.SearchTerms(query)
+	if len(terms) > maxSearchTerms {
+		terms = terms[:maxSearchTerms]
+	}
+	return terms
+}
+
+// ftsMatch returns an FTS5 query expression
+// matching all of the given terms as prefixes.
+// Terms are quoted, so that words like "OR" or
+// "NEAR" aren't parsed as query syntax.
+//
+// Terms from searchTerms() never contain quotes.
+func ftsMatch(terms []string) string {
+	var b strings.Builder
+	for i, term := range terms {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteByte('"')
+		b.WriteString(term)
+		b.WriteString(`"*`)
+	}
+	return b.String()
+}
+
+// tsQuery returns a Postgres tsquery matching all
+// of the given terms as prefixes, optionally
+// restricted to lexemes of the given weights.
+//
+// Terms from searchTerms() only contain letters,
+// numbers and marks, so don't need escaping.
+func tsQuery(terms []string, weights string) string {
+	var b strings.Builder
+	for i, term := range terms {
+		if i > 0 {
+			b.WriteString(" & ")
+		}
+		b.WriteString(term)
+		b.WriteString(":*")
+		b.WriteString(weights)
+	}
+	return b.String()
+}
+
+// indexAccount inserts or updates the
+// full-text search index entry for account.
+func indexAccount(ctx context.Context, tx bun.IDB, account *gtsmodel.Account) error {
+	_, err := tx.NewInsert().
+		Model(&accountSearchEntry{
+			AccountID: account.ID,
+			Name:      text.SearchText(account.Username, account.DisplayName),
+			Note:      text.SearchText(account.Note),
+		}).
+		On("CONFLICT (?) DO UPDATE", bun.Ident("account_id")).
+		Set("? = EXCLUDED.?", bun.Ident("name"), bun.Ident("name")).
+		Set("? = EXCLUDED.?", bun.Ident("note"), bun.Ident("note")).
+		Exec(ctx)
+	return err
+}
+
+// unindexAccount deletes the full-text
+// search index entry for account ID.
+func unindexAccount(ctx context.Context, tx bun.IDB, accountID string) error {
+	_, err := tx.NewDelete().
+		Table("account_search").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Exec(ctx)
+	return err
+}
+
+// indexStatus inserts or updates the full-text
+// search index entry for status. Boosts are not
+// indexed, as they have no content of their own.
+func indexStatus(ctx context.Context, tx bun.IDB, status *gtsmodel.Status) error {
+	if status.BoostOfID != "" {
+		return nil
+	}
+
+	_, err := tx.NewInsert().
+		Model(&statusSearchEntry{
+			StatusID: status.ID,
+			Text:     text.SearchText(status.ContentWarning, status.Content),
+		}).
+		On("CONFLICT (?) DO UPDATE", bun.Ident("status_id")).
+		Set("? = EXCLUDED.?", bun.Ident("text"), bun.Ident("text")).
+		Exec(ctx)
+	return err
+}
+
+// unindexStatus deletes the full-text
+// search index entry for status ID.
+func unindexStatus(ctx context.Context, tx bun.IDB, statusID string) error {
+	_, err := tx.NewDelete().
+		Table("status_search").
+		Where("? = ?", bun.Ident("status_id"), statusID).
+		Exec(ctx)
+	return err
+}
+
+func (s *searchDB) RebuildSearchIndexes(ctx context.Context) error {
+	// Batch size to
+	// page through with.
+	const batchsz = 500
+
+	// Clear out existing index entries.
+	for _, table := range []string{
+		"account_search",
+		"status_search",
+	} {
+		if _, err := s.db.NewDelete().
+			Table(table).
+			Where("TRUE").
+			Exec(ctx); err != nil {
+			return gtserror.Newf("error clearing %s: %w", table, err)
+		}
+	}
+
+	// Reindex accounts, paging up through IDs.
+	for minID := id.Lowest; ; {
+		var accounts []*gtsmodel.Account
+
+		if err := s.db.NewSelect().
+			Model(&accounts).
+			Column("id", "username", "display_name", "note").
+			Where("? > ?", bun.Ident("id"), minID).
+			Order("id ASC").
+			Limit(batchsz).
+			Scan(ctx); err != nil {
+			return gtserror.Newf("error selecting accounts: %w", err)
+		}
+
+		if len(accounts) == 0 {
+			break
+		}
+
+		if err := s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			for _, account := range accounts {
+				if err := indexAccount(ctx, tx, account); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return gtserror.Newf("error indexing accounts: %w", err)
+		}
+
+		minID = accounts[len(accounts)-1].ID
+	}
+
+	// Reindex statuses, paging up through IDs.
+	for minID := id.Lowest; ; {
+		var statuses []*gtsmodel.Status
+
+		if err := s.db.NewSelect().
+			Model(&statuses).
+			Column("id", "content", "content_warning").
+			Where("? IS NULL", bun.Ident("boost_of_id")).
+			Where("? > ?", bun.Ident("id"), minID).
+			Order("id ASC").
+			Limit(batchsz).
+			Scan(ctx); err != nil {
+			return gtserror.Newf("error selecting statuses: %w", err)
+		}
+
+		if len(statuses) == 0 {
+			break
+		}
+
+		if err := s.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			for _, status := range statuses {
+				if err := indexStatus(ctx, tx, status); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return gtserror.Newf("error indexing statuses: %w", err)
+		}
+
+		minID = statuses[len(statuses)-1].ID
 	}
 
-	return statusText
+	return nil
 }
 
 // Query example (SQLite):
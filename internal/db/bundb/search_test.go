@@ -21,6 +21,8 @@
 	"testing"
 
 	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -106,7 +108,7 @@ func (suite *SearchTestSuite) TestSearchAccountsFossAny() {
 func (suite *SearchTestSuite) TestSearchStatuses() {
 	testAccount := suite.testAccounts["local_account_1"]
 
-	statuses, err := suite.db.SearchForStatuses(suite.T().Context(), testAccount.ID, "hello", "", "", "", 10, 0)
+	statuses, err := suite.db.SearchForStatuses(suite.T().Context(), testAccount.ID, "hello", "", false, "", "", 10, 0)
 	suite.NoError(err)
 	suite.Len(statuses, 1)
 }
@@ -115,13 +117,130 @@ func (suite *SearchTestSuite) TestSearchStatusesFromAccount() {
 	testAccount := suite.testAccounts["local_account_1"]
 	fromAccount := suite.testAccounts["local_account_2"]
 
-	statuses, err := suite.db.SearchForStatuses(suite.T().Context(), testAccount.ID, "hi", fromAccount.ID, "", "", 10, 0)
+	statuses, err := suite.db.SearchForStatuses(suite.T().Context(), testAccount.ID, "hi", fromAccount.ID, false, "", "", 10, 0)
 	suite.NoError(err)
 	if suite.Len(statuses, 1) {
 		suite.Equal(fromAccount.ID, statuses[0].AccountID)
 	}
 }
 
+func (suite *SearchTestSuite) TestSearchStatusesIndexable() {
+	ctx := suite.T().Context()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	// Turtle's public status isn't by or in reply to
+	// zork, so zork shouldn't find it by default.
+	statuses, err := suite.db.SearchForStatuses(ctx, testAccount.ID, "turtles", "", false, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+
+	// Turtle opts in to search indexing.
+	turtle := new(gtsmodel.Account)
+	*turtle = *suite.testAccounts["local_account_2"]
+	turtle.Indexable = util.Ptr(true)
+	if err := suite.db.UpdateAccount(ctx, turtle, "indexable"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Now zork should be able to find it, but
+	// not turtle's followers-only status.
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, "turtles", "", false, "", "", 10, 0)
+	suite.NoError(err)
+	if suite.Len(statuses, 1) {
+		suite.Equal(suite.testStatuses["local_account_2_status_1"].ID, statuses[0].ID)
+	}
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesHasMedia() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	// No text terms, just operators.
+	statuses, err := suite.db.SearchForStatuses(suite.T().Context(), testAccount.ID, "", testAccount.ID, true, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 2)
+	for _, status := range statuses {
+		suite.NotEmpty(status.AttachmentIDs)
+	}
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesOffset() {
+	ctx := suite.T().Context()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	all, err := suite.db.SearchForStatuses(ctx, testAccount.ID, "this", "", false, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(all, 3)
+
+	page1, err := suite.db.SearchForStatuses(ctx, testAccount.ID, "this", "", false, "", "", 2, 0)
+	suite.NoError(err)
+	suite.Len(page1, 2)
+
+	page2, err := suite.db.SearchForStatuses(ctx, testAccount.ID, "this", "", false, "", "", 2, 2)
+	suite.NoError(err)
+	if suite.Len(page2, 1) {
+		suite.Equal(all[2].ID, page2[0].ID)
+	}
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesQuerySyntax() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	// Words and characters that mean something to
+	// the full-text query parsers are just searched.
+	statuses, err := suite.db.SearchForStatuses(suite.T().Context(), testAccount.ID, `NOT "hello" OR * AND ( ':&|!`, "", false, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesUpdatedAndDeleted() {
+	ctx := suite.T().Context()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	status := new(gtsmodel.Status)
+	*status = *suite.testStatuses["local_account_1_status_1"]
+	status.Content = "<p>goodbye everyone!</p>"
+	if err := suite.db.UpdateStatus(ctx, status, "content"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Old content no longer matches.
+	statuses, err := suite.db.SearchForStatuses(ctx, testAccount.ID, "hello", "", false, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+
+	// New content does.
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, "goodbye", "", false, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+
+	// Deleted status can't be found.
+	if err := suite.db.DeleteStatusByID(ctx, status.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, "goodbye", "", false, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+}
+
+func (suite *SearchTestSuite) TestSearchAccountsUpdated() {
+	ctx := suite.T().Context()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	account := new(gtsmodel.Account)
+	*account = *suite.testAccounts["local_account_2"]
+	account.DisplayName = "tortoise enthusiast"
+	if err := suite.db.UpdateAccount(ctx, account, "display_name"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	accounts, err := suite.db.SearchForAccounts(ctx, testAccount.ID, "tortoise", "", "", 10, false, 0)
+	suite.NoError(err)
+	if suite.Len(accounts, 1) {
+		suite.Equal(account.ID, accounts[0].ID)
+	}
+}
+
 func (suite *SearchTestSuite) TestSearchTags() {
 	// Search with full tag string.
 	tags, err := suite.db.SearchForTags(suite.T().Context(), "welcome", "", "", 10, 0)
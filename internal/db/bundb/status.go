@@ -244,6 +244,30 @@ func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status)
 		}
 	}
 
+	if status.QuoteOfID != "" {
+		if status.QuoteOf == nil {
+			// Quoted status is not set, fetch from database.
+			status.QuoteOf, err = s.GetStatusByID(
+				gtscontext.SetBarebones(ctx),
+				status.QuoteOfID,
+			)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				errs.Appendf("error populating quoted status: %w", err)
+			}
+		}
+
+		if status.QuoteOfAccount == nil {
+			// Quoted status author is not set, fetch from database.
+			status.QuoteOfAccount, err = s.state.DB.GetAccountByID(
+				gtscontext.SetBarebones(ctx),
+				status.QuoteOfAccountID,
+			)
+			if err != nil {
+				errs.Appendf("error populating quoted status author: %w", err)
+			}
+		}
+	}
+
 	if status.PollID != "" && status.Poll == nil {
 		// Status poll is not set, fetch from database.
 		status.Poll, err = s.state.DB.GetPollByID(
@@ -255,6 +279,17 @@ func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status)
 		}
 	}
 
+	if status.CardID != "" && status.Card == nil {
+		// Status card is not set, fetch from database.
+		status.Card, err = s.state.DB.GetCardByID(
+			ctx, // card image also needed
+			status.CardID,
+		)
+		if err != nil {
+			errs.Appendf("error populating status card: %w", err)
+		}
+	}
+
 	if !status.AttachmentsPopulated() {
 		// Status attachments are out-of-date with IDs, repopulate.
 		status.Attachments, err = s.state.DB.GetAttachmentsByIDs(
@@ -579,6 +614,11 @@ func insertStatus(ctx context.Context, tx bun.Tx, status *gtsmodel.Status) error
 		return gtserror.Newf("error inserting status: %w", err)
 	}
 
+	// And index it for search.
+	if err := indexStatus(ctx, tx, status); err != nil {
+		return gtserror.Newf("error indexing status: %w", err)
+	}
+
 	return nil
 }
 
@@ -634,12 +674,25 @@ func (s *statusDB) UpdateStatus(ctx context.Context, status *gtsmodel.Status, co
 			}
 
 			// Finally, update the status
-			_, err := tx.NewUpdate().
+			if _, err := tx.NewUpdate().
 				Model(status).
 				Column(columns...).
 				Where("? = ?", bun.Ident("status.id"), status.ID).
-				Exec(ctx)
-			return err
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// Reindex status for search
+			// if searchable text changed.
+			if len(columns) == 0 ||
+				slices.Contains(columns, "content") ||
+				slices.Contains(columns, "content_warning") {
+				if err := indexStatus(ctx, tx, status); err != nil {
+					return gtserror.Newf("error indexing status: %w", err)
+				}
+			}
+
+			return nil
 		})
 	})
 }
@@ -673,6 +726,11 @@ func (s *statusDB) DeleteStatusByID(ctx context.Context, id string) error {
 			return err
 		}
 
+		// delete the status search index entry
+		if err := unindexStatus(ctx, tx, id); err != nil {
+			return err
+		}
+
 		// delete the status itself
 		if _, err := tx.
 			NewDelete().
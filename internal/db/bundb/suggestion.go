@@ -0,0 +1,250 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type suggestionDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (s *suggestionDB) GetFriendsOfFriendsSuggestionIDs(ctx context.Context, accountID string, limit int) ([]string, error) {
+	var accountIDs []string
+
+	q := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("follows"), bun.Ident("follow")).
+		Column("follow.target_account_id").
+		// Select follows owned by accounts that the account follows.
+		Where("? IN (?)", bun.Ident("follow.account_id"), s.followedQ(accountID)).
+		GroupExpr("?", bun.Ident("follow.target_account_id")).
+		// Order by number of followed accounts following, tie-break newest.
+		OrderExpr("COUNT(*) DESC").
+		OrderExpr("? DESC", bun.Ident("follow.target_account_id")).
+		Limit(limit)
+
+	q = s.excludeForAccount(q, "follow.target_account_id", accountID)
+
+	if err := q.Scan(ctx, &accountIDs); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("error selecting friends of friends: %w", err)
+	}
+
+	return accountIDs, nil
+}
+
+func (s *suggestionDB) GetInteractionsSuggestionIDs(ctx context.Context, accountID string, since time.Time, limit int) ([]string, error) {
+	// interactions models one
+	// account's interaction count.
+	type interactions struct {
+		AccountID string `bun:"account_id"`
+		Count     int    `bun:"count"`
+	}
+
+	var faves []interactions
+
+	// Select faves of statuses by followed accounts.
+	faveQ := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_faves"), bun.Ident("status_fave")).
+		ColumnExpr("? AS ?", bun.Ident("status_fave.account_id"), bun.Ident("account_id")).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+		Where("? IN (?)", bun.Ident("status_fave.target_account_id"), s.followedQ(accountID)).
+		Where("? > ?", bun.Ident("status_fave.created_at"), since).
+		GroupExpr("?", bun.Ident("status_fave.account_id")).
+		OrderExpr("COUNT(*) DESC").
+		Limit(limit)
+
+	faveQ = s.excludeForAccount(faveQ, "status_fave.account_id", accountID)
+
+	if err := faveQ.Scan(ctx, &faves); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("error selecting fave interactions: %w", err)
+	}
+
+	var statuses []interactions
+
+	// Select boosts of and replies to statuses by followed accounts.
+	statusQ := s.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		ColumnExpr("? AS ?", bun.Ident("status.account_id"), bun.Ident("account_id")).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? IN (?)", bun.Ident("status.boost_of_account_id"), s.followedQ(accountID)).
+				WhereOr("? IN (?)", bun.Ident("status.in_reply_to_account_id"), s.followedQ(accountID))
+		}).
+		Where("? > ?", bun.Ident("status.created_at"), since).
+		GroupExpr("?", bun.Ident("status.account_id")).
+		OrderExpr("COUNT(*) DESC").
+		Limit(limit)
+
+	statusQ = s.excludeForAccount(statusQ, "status.account_id", accountID)
+
+	if err := statusQ.Scan(ctx, &statuses); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("error selecting status interactions: %w", err)
+	}
+
+	// Sum interactions per account.
+	counts := make(map[string]int, len(faves)+len(statuses))
+	for _, i := range append(faves, statuses...) {
+		counts[i.AccountID] += i.Count
+	}
+
+	accountIDs := make([]string, 0, len(counts))
+	for id := range counts {
+		accountIDs = append(accountIDs, id)
+	}
+
+	// Order by number of interactions, tie-break newest.
+	slices.SortFunc(accountIDs, func(a, b string) int {
+		if c := cmp.Compare(counts[b], counts[a]); c != 0 {
+			return c
+		}
+		return cmp.Compare(b, a)
+	})
+
+	if len(accountIDs) > limit {
+		accountIDs = accountIDs[:limit]
+	}
+
+	return accountIDs, nil
+}
+
+// followedQ returns a subquery selecting the
+// IDs of all accounts followed by given account.
+func (s *suggestionDB) followedQ(accountID string) *bun.SelectQuery {
+	return s.db.
+		NewSelect().
+		Table("follows").
+		Column("follows.target_account_id").
+		Where("? = ?", bun.Ident("follows.account_id"), accountID)
+}
+
+// excludeForAccount adds clauses to the given query excluding from
+// results where the given column is the given account itself, an
+// account it already follows, or one it has dismissed from suggestions.
+func (s *suggestionDB) excludeForAccount(q *bun.SelectQuery, column string, accountID string) *bun.SelectQuery {
+	dismissedQ := s.db.
+		NewSelect().
+		Table("suggestion_dismissals").
+		Column("suggestion_dismissals.target_account_id").
+		Where("? = ?", bun.Ident("suggestion_dismissals.account_id"), accountID)
+
+	return q.
+		Where("? != ?", bun.Ident(column), accountID).
+		Where("? NOT IN (?)", bun.Ident(column), s.followedQ(accountID)).
+		Where("? NOT IN (?)", bun.Ident(column), dismissedQ)
+}
+
+func (s *suggestionDB) GetStaffPicks(ctx context.Context) ([]*gtsmodel.StaffPick, error) {
+	var picks []*gtsmodel.StaffPick
+
+	if err := s.db.
+		NewSelect().
+		Model(&picks).
+		Order("staff_pick.id DESC").
+		Scan(ctx); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return nil, err
+	}
+
+	return picks, nil
+}
+
+func (s *suggestionDB) GetStaffPickByAccountID(ctx context.Context, accountID string) (*gtsmodel.StaffPick, error) {
+	pick := new(gtsmodel.StaffPick)
+
+	if err := s.db.
+		NewSelect().
+		Model(pick).
+		Where("? = ?", bun.Ident("staff_pick.account_id"), accountID).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return pick, nil
+}
+
+func (s *suggestionDB) PutStaffPick(ctx context.Context, pick *gtsmodel.StaffPick) error {
+	_, err := s.db.
+		NewInsert().
+		Model(pick).
+		Exec(ctx)
+	return err
+}
+
+func (s *suggestionDB) DeleteStaffPickByAccountID(ctx context.Context, accountID string) error {
+	if _, err := s.db.
+		NewDelete().
+		Table("staff_picks").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Exec(ctx); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+	return nil
+}
+
+func (s *suggestionDB) IsSuggestionDismissed(ctx context.Context, accountID string, targetAccountID string) (bool, error) {
+	q := s.db.
+		NewSelect().
+		Table("suggestion_dismissals").
+		Column("id").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Where("? = ?", bun.Ident("target_account_id"), targetAccountID)
+
+	return exists(ctx, q)
+}
+
+func (s *suggestionDB) PutSuggestionDismissal(ctx context.Context, dismissal *gtsmodel.SuggestionDismissal) error {
+	_, err := s.db.
+		NewInsert().
+		Model(dismissal).
+		On("CONFLICT (?, ?) DO NOTHING", bun.Ident("account_id"), bun.Ident("target_account_id")).
+		Exec(ctx)
+	return err
+}
+
+func (s *suggestionDB) DeleteAccountSuggestionDismissals(ctx context.Context, accountID string) error {
+	if _, err := s.db.
+		NewDelete().
+		Table("suggestion_dismissals").
+		WhereOr("? = ?", bun.Ident("account_id"), accountID).
+		WhereOr("? = ?", bun.Ident("target_account_id"), accountID).
+		Exec(ctx); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"testing"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"github.com/stretchr/testify/suite"
+)
+
+type SuggestionTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *SuggestionTestSuite) TestGetFriendsOfFriendsSuggestionIDs() {
+	var (
+		ctx     = suite.T().Context()
+		account = suite.testAccounts["local_account_2"]
+		admin   = suite.testAccounts["admin_account"]
+	)
+
+	// local_account_2 follows zork, and zork
+	// follows admin, so admin should be suggested.
+	ids, err := suite.db.GetFriendsOfFriendsSuggestionIDs(ctx, account.ID, 10)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal([]string{admin.ID}, ids)
+
+	// Dismiss the suggestion.
+	if err := suite.db.PutSuggestionDismissal(ctx, &gtsmodel.SuggestionDismissal{
+		ID:              id.NewULID(),
+		AccountID:       account.ID,
+		TargetAccountID: admin.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dismissed, err := suite.db.IsSuggestionDismissed(ctx, account.ID, admin.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(dismissed)
+
+	// Admin should no longer be suggested.
+	ids, err = suite.db.GetFriendsOfFriendsSuggestionIDs(ctx, account.ID, 10)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(ids)
+
+	// Dismissing again should be a no-op.
+	if err := suite.db.PutSuggestionDismissal(ctx, &gtsmodel.SuggestionDismissal{
+		ID:              id.NewULID(),
+		AccountID:       account.ID,
+		TargetAccountID: admin.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Clearing dismissals should bring admin back.
+	if err := suite.db.DeleteAccountSuggestionDismissals(ctx, account.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ids, err = suite.db.GetFriendsOfFriendsSuggestionIDs(ctx, account.ID, 10)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal([]string{admin.ID}, ids)
+}
+
+func (suite *SuggestionTestSuite) TestGetInteractionsSuggestionIDs() {
+	var (
+		ctx     = suite.T().Context()
+		account = suite.testAccounts["admin_account"]
+	)
+
+	// Ensure call works and never suggests
+	// self or accounts already followed.
+	ids, err := suite.db.GetInteractionsSuggestionIDs(ctx, account.ID, time.Time{}, 10)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	for _, id := range ids {
+		suite.NotEqual(account.ID, id)
+		suite.NotEqual(suite.testAccounts["local_account_1"].ID, id)
+	}
+}
+
+func (suite *SuggestionTestSuite) TestStaffPicks() {
+	var (
+		ctx     = suite.T().Context()
+		admin   = suite.testAccounts["admin_account"]
+		account = suite.testAccounts["local_account_2"]
+	)
+
+	picks, err := suite.db.GetStaffPicks(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(picks)
+
+	if err := suite.db.PutStaffPick(ctx, &gtsmodel.StaffPick{
+		ID:                 id.NewULID(),
+		AccountID:          account.ID,
+		CreatedByAccountID: admin.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	pick, err := suite.db.GetStaffPickByAccountID(ctx, account.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(admin.ID, pick.CreatedByAccountID)
+
+	picks, err = suite.db.GetStaffPicks(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(picks, 1)
+
+	if err := suite.db.DeleteStaffPickByAccountID(ctx, account.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	picks, err = suite.db.GetStaffPicks(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(picks)
+}
+
+func TestSuggestionTestSuite(t *testing.T) {
+	suite.Run(t, new(SuggestionTestSuite))
+}
@@ -0,0 +1,215 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+// maxLinkTrendSamples is the maximum number
+// of link samples selected per recalculation.
+const maxLinkTrendSamples = 50000
+
+type trendDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (t *trendDB) GetTrendReviewByID(ctx context.Context, id string) (*gtsmodel.TrendReview, error) {
+	review := new(gtsmodel.TrendReview)
+
+	if err := t.db.
+		NewSelect().
+		Model(review).
+		Where("? = ?", bun.Ident("trend_review.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (t *trendDB) GetTrendReviewByTarget(ctx context.Context, trendType gtsmodel.TrendType, targetID string) (*gtsmodel.TrendReview, error) {
+	review := new(gtsmodel.TrendReview)
+
+	if err := t.db.
+		NewSelect().
+		Model(review).
+		Where("? = ?", bun.Ident("trend_review.trend_type"), trendType).
+		Where("? = ?", bun.Ident("trend_review.target_id"), targetID).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (t *trendDB) PutTrendReview(ctx context.Context, review *gtsmodel.TrendReview) error {
+	_, err := t.db.
+		NewInsert().
+		Model(review).
+		Exec(ctx)
+	return err
+}
+
+func (t *trendDB) UpdateTrendReview(ctx context.Context, review *gtsmodel.TrendReview, columns ...string) error {
+	review.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := t.db.
+		NewUpdate().
+		Model(review).
+		Column(columns...).
+		Where("? = ?", bun.Ident("trend_review.id"), review.ID).
+		Exec(ctx)
+	return err
+}
+
+func (t *trendDB) DeletePendingTrendReviewsOlderThan(ctx context.Context, olderThan time.Time) error {
+	if _, err := t.db.
+		NewDelete().
+		Table("trend_reviews").
+		Where("? = ?", bun.Ident("state"), gtsmodel.TrendReviewStatePending).
+		Where("? < ?", bun.Ident("updated_at"), olderThan).
+		Exec(ctx); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+	return nil
+}
+
+func (t *trendDB) GetTagTrendSamples(ctx context.Context, since time.Time) ([]*gtsmodel.TrendSample, error) {
+	var samples []*gtsmodel.TrendSample
+
+	if err := t.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_to_tags"), bun.Ident("status_to_tag")).
+		ColumnExpr("? AS ?", bun.Ident("status_to_tag.tag_id"), bun.Ident("target_id")).
+		ColumnExpr("? AS ?", bun.Ident("status.account_id"), bun.Ident("account_id")).
+		ColumnExpr("? AS ?", bun.Ident("status.created_at"), bun.Ident("created_at")).
+		Join(
+			"JOIN ? AS ? ON ? = ?",
+			bun.Ident("statuses"), bun.Ident("status"),
+			bun.Ident("status.id"), bun.Ident("status_to_tag.status_id"),
+		).
+		Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
+		Where("? = ?", bun.Ident("status.pending_approval"), false).
+		Where("? > ?", bun.Ident("status.created_at"), since).
+		Scan(ctx, &samples); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("error selecting tag samples: %w", err)
+	}
+
+	return samples, nil
+}
+
+func (t *trendDB) GetStatusTrendSamples(ctx context.Context, since time.Time) ([]*gtsmodel.TrendSample, error) {
+	var boosts []*gtsmodel.TrendSample
+
+	// Select boosts of recent public statuses.
+	if err := t.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("boost")).
+		ColumnExpr("? AS ?", bun.Ident("boost.boost_of_id"), bun.Ident("target_id")).
+		ColumnExpr("? AS ?", bun.Ident("boost.account_id"), bun.Ident("account_id")).
+		ColumnExpr("? AS ?", bun.Ident("boost.created_at"), bun.Ident("created_at")).
+		Join(
+			"JOIN ? AS ? ON ? = ?",
+			bun.Ident("statuses"), bun.Ident("status"),
+			bun.Ident("status.id"), bun.Ident("boost.boost_of_id"),
+		).
+		Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
+		Where("? = ?", bun.Ident("status.pending_approval"), false).
+		Where("? > ?", bun.Ident("status.created_at"), since).
+		Where("? = ?", bun.Ident("boost.pending_approval"), false).
+		Where("? > ?", bun.Ident("boost.created_at"), since).
+		Scan(ctx, &boosts); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("error selecting boost samples: %w", err)
+	}
+
+	var faves []*gtsmodel.TrendSample
+
+	// Select faves of recent public statuses.
+	if err := t.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_faves"), bun.Ident("status_fave")).
+		ColumnExpr("? AS ?", bun.Ident("status_fave.status_id"), bun.Ident("target_id")).
+		ColumnExpr("? AS ?", bun.Ident("status_fave.account_id"), bun.Ident("account_id")).
+		ColumnExpr("? AS ?", bun.Ident("status_fave.created_at"), bun.Ident("created_at")).
+		Join(
+			"JOIN ? AS ? ON ? = ?",
+			bun.Ident("statuses"), bun.Ident("status"),
+			bun.Ident("status.id"), bun.Ident("status_fave.status_id"),
+		).
+		Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
+		Where("? = ?", bun.Ident("status.pending_approval"), false).
+		Where("? > ?", bun.Ident("status.created_at"), since).
+		Where("? = ?", bun.Ident("status_fave.pending_approval"), false).
+		Where("? > ?", bun.Ident("status_fave.created_at"), since).
+		Scan(ctx, &faves); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("error selecting fave samples: %w", err)
+	}
+
+	return append(boosts, faves...), nil
+}
+
+func (t *trendDB) GetLinkTrendSamples(ctx context.Context, since time.Time) ([]*gtsmodel.TrendSample, error) {
+	var samples []*gtsmodel.TrendSample
+
+	// Select the preview card URL of recent
+	// public statuses, newest first, up to a
+	// bound so busy instances don't load an
+	// unbounded number of samples at once.
+	if err := t.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		ColumnExpr("? AS ?", bun.Ident("card.url"), bun.Ident("target_id")).
+		ColumnExpr("? AS ?", bun.Ident("status.account_id"), bun.Ident("account_id")).
+		ColumnExpr("? AS ?", bun.Ident("status.created_at"), bun.Ident("created_at")).
+		Join(
+			"JOIN ? AS ? ON ? = ?",
+			bun.Ident("cards"), bun.Ident("card"),
+			bun.Ident("card.id"), bun.Ident("status.card_id"),
+		).
+		Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
+		Where("? = ?", bun.Ident("status.pending_approval"), false).
+		Where("? IS NULL", bun.Ident("status.boost_of_id")).
+		Where("? > ?", bun.Ident("status.created_at"), since).
+		OrderExpr("? DESC", bun.Ident("status.id")).
+		Limit(maxLinkTrendSamples).
+		Scan(ctx, &samples); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("error selecting link samples: %w", err)
+	}
+
+	return samples, nil
+}
@@ -0,0 +1,194 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/paging"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+	"github.com/uptrace/bun"
+)
+
+type userDomainBlockDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (u *userDomainBlockDB) GetUserDomainBlocks(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.UserDomainBlock, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		blocks = make([]*gtsmodel.UserDomainBlock, 0, limit)
+	)
+
+	q := u.db.
+		NewSelect().
+		Model(&blocks).
+		Where("? = ?", bun.Ident("user_domain_block.account_id"), accountID)
+
+	if maxID != "" {
+		// Return only blocks LOWER (ie., older) than maxID.
+		q = q.Where("? < ?", bun.Ident("user_domain_block.id"), maxID)
+	}
+
+	if minID != "" {
+		// Return only blocks HIGHER (ie., newer) than minID.
+		q = q.Where("? > ?", bun.Ident("user_domain_block.id"), minID)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.Order("user_domain_block.id ASC")
+	} else {
+		// Page down.
+		q = q.Order("user_domain_block.id DESC")
+	}
+
+	if err := q.Scan(ctx); err != nil &&
+		!errors.Is(err, db.ErrNoEntries) {
+		return nil, err
+	}
+
+	// If we're paging up, we still want blocks
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(blocks)
+	}
+
+	return blocks, nil
+}
+
+func (u *userDomainBlockDB) PutUserDomainBlock(ctx context.Context, block *gtsmodel.UserDomainBlock) error {
+	// Normalize the domain as punycode
+	var err error
+	block.Domain, err = util.Punify(block.Domain)
+	if err != nil {
+		return gtserror.Newf("error punifying domain %s: %w", block.Domain, err)
+	}
+
+	if _, err := u.db.
+		NewInsert().
+		Model(block).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	u.invalidate(block.AccountID)
+	return nil
+}
+
+func (u *userDomainBlockDB) DeleteUserDomainBlock(ctx context.Context, accountID string, domain string) error {
+	// Normalize domain as punycode for lookup.
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return gtserror.Newf("error punifying domain %s: %w", domain, err)
+	}
+
+	if _, err := u.db.
+		NewDelete().
+		Table("user_domain_blocks").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Where("? = ?", bun.Ident("domain"), domain).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	u.invalidate(accountID)
+	return nil
+}
+
+func (u *userDomainBlockDB) DeleteUserDomainBlocksByAccountID(ctx context.Context, accountID string) error {
+	if _, err := u.db.
+		NewDelete().
+		Table("user_domain_blocks").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	u.invalidate(accountID)
+	return nil
+}
+
+func (u *userDomainBlockDB) IsUserDomainBlocked(ctx context.Context, accountID string, domain string) (bool, error) {
+	if domain == "" {
+		// Local accounts
+		// can't be blocked.
+		return false, nil
+	}
+
+	// Normalize domain as punycode for lookup.
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return false, gtserror.Newf("error punifying domain %s: %w", domain, err)
+	}
+
+	// Load the account's blocked domains (hydrating the cache with callback if necessary).
+	domains, err := u.state.Caches.DB.UserDomainBlocks.Load(accountID, func() ([]string, error) {
+		var domains []string
+
+		if err := u.db.
+			NewSelect().
+			Table("user_domain_blocks").
+			Column("domain").
+			Where("? = ?", bun.Ident("account_id"), accountID).
+			Scan(ctx, &domains); err != nil {
+			return nil, err
+		}
+
+		return domains, nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	for _, blocked := range domains {
+		if domain == blocked ||
+			strings.HasSuffix(domain, "."+blocked) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// invalidate clears cached blocked domains of the given account,
+// and all cached visibility results, as user domain blocks affect
+// visibility in both directions (i.e. also of the account's own
+// statuses as seen by accounts on the blocked domain).
+func (u *userDomainBlockDB) invalidate(accountID string) {
+	u.state.Caches.DB.UserDomainBlocks.Invalidate(accountID)
+	u.state.Caches.Visibility.Clear()
+}
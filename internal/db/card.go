@@ -0,0 +1,44 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+)
+
+type Card interface {
+	// GetCardByID fetches the Card with given ID from the database.
+	GetCardByID(ctx context.Context, id string) (*gtsmodel.Card, error)
+
+	// GetCardByURL fetches the Card with given (normalized) URL from the database.
+	GetCardByURL(ctx context.Context, url string) (*gtsmodel.Card, error)
+
+	// GetCardByImageID fetches the Card using the media attachment with given ID as thumbnail.
+	GetCardByImageID(ctx context.Context, imageID string) (*gtsmodel.Card, error)
+
+	// PopulateCard ensures the given Card is fully populated with all other related database models.
+	PopulateCard(ctx context.Context, card *gtsmodel.Card) error
+
+	// PutCard puts the given Card in the database.
+	PutCard(ctx context.Context, card *gtsmodel.Card) error
+
+	// UpdateCard updates the Card in the database, only on selected columns if provided (else, all).
+	UpdateCard(ctx context.Context, card *gtsmodel.Card, cols ...string) error
+}
@@ -27,14 +27,22 @@ type DB interface {
 	Account
 	Admin
 	AdvancedMigration
+	Announcement
 	Application
+	Archive
 	Basic
+	Card
 	Conversation
 	Domain
+	EmailDomainBlock
 	Emoji
+	FeaturedTag
 	HeaderFilter
+	Import
 	Instance
 	Interaction
+	Invite
+	IPBlock
 	Filter
 	List
 	Marker
@@ -42,8 +50,10 @@ type DB interface {
 	Mention
 	Move
 	Notification
+	NotificationPolicy
 	Poll
 	Relationship
+	Relay
 	Report
 	Rule
 	ScheduledStatus
@@ -54,10 +64,14 @@ type DB interface {
 	StatusBookmark
 	StatusEdit
 	StatusFave
+	StatusReaction
+	Suggestion
 	Tag
 	Thread
 	Timeline
+	Trend
 	User
+	UserDomainBlock
 	Tombstone
 	WebPush
 	WorkerTask
@@ -178,4 +178,28 @@ type Domain interface {
 	// CountDomainPermissionSubscriptionPerms counts the number of permissions
 	// currently managed by the domain permission subscription of the given ID.
 	CountDomainPermissionSubscriptionPerms(ctx context.Context, id string) (int, error)
+
+	/*
+		Domain limit stuff.
+	*/
+
+	// PutDomainLimit puts the given instance-level domain limit into the database.
+	PutDomainLimit(ctx context.Context, limit *gtsmodel.DomainLimit) error
+
+	// GetDomainLimitByID returns one instance-level domain limit with the given id, if it exists.
+	GetDomainLimitByID(ctx context.Context, id string) (*gtsmodel.DomainLimit, error)
+
+	// GetDomainLimits returns all instance-level domain limits currently enforced by this instance.
+	GetDomainLimits(ctx context.Context) ([]*gtsmodel.DomainLimit, error)
+
+	// MatchDomainLimit returns the most specific instance-level domain limit
+	// matching the given domain, ie., a limit on the domain itself, or else
+	// a limit on its closest parent domain. Returns ErrNoEntries if none match.
+	MatchDomainLimit(ctx context.Context, domain string) (*gtsmodel.DomainLimit, error)
+
+	// UpdateDomainLimit updates the given domain limit, setting the provided columns (empty for all).
+	UpdateDomainLimit(ctx context.Context, limit *gtsmodel.DomainLimit, columns ...string) error
+
+	// DeleteDomainLimitByID deletes one instance-level domain limit with the given id, if it exists.
+	DeleteDomainLimitByID(ctx context.Context, id string) error
 }
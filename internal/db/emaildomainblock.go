@@ -0,0 +1,48 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/paging"
+)
+
+// EmailDomainBlock contains functions for managing
+// blocked sign-up e-mail domains in the database.
+type EmailDomainBlock interface {
+	// GetEmailDomainBlockByID returns the email domain block with the given ID.
+	GetEmailDomainBlockByID(ctx context.Context, id string) (*gtsmodel.EmailDomainBlock, error)
+
+	// GetEmailDomainBlockByDomain returns the email domain block for the given domain.
+	GetEmailDomainBlockByDomain(ctx context.Context, domain string) (*gtsmodel.EmailDomainBlock, error)
+
+	// GetEmailDomainBlocks returns a page of email domain blocks, newest first.
+	GetEmailDomainBlocks(ctx context.Context, page *paging.Page) ([]*gtsmodel.EmailDomainBlock, error)
+
+	// PutEmailDomainBlock puts the given email domain block in the database.
+	PutEmailDomainBlock(ctx context.Context, block *gtsmodel.EmailDomainBlock) error
+
+	// DeleteEmailDomainBlockByID deletes the email domain block with the given ID.
+	DeleteEmailDomainBlockByID(ctx context.Context, id string) error
+
+	// IsEmailDomainBlocked checks whether the given e-mail
+	// domain, or any of its parent domains, is blocked.
+	IsEmailDomainBlocked(ctx context.Context, domain string) (bool, error)
+}
@@ -0,0 +1,57 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+)
+
+type FeaturedTag interface {
+	// GetFeaturedTagByID gets one featured tag with the given id.
+	GetFeaturedTagByID(ctx context.Context, id string) (*gtsmodel.FeaturedTag, error)
+
+	// GetFeaturedTag gets one featured tag of the given accountID, with the given tagID.
+	GetFeaturedTag(ctx context.Context, accountID string, tagID string) (*gtsmodel.FeaturedTag, error)
+
+	// GetAccountFeaturedTags returns all featured tags of the given account, oldest first.
+	GetAccountFeaturedTags(ctx context.Context, accountID string) ([]*gtsmodel.FeaturedTag, error)
+
+	// GetFeaturedTagSuggestions returns up to limit tags most
+	// recently used by the given account in its statuses,
+	// most recently used first.
+	GetFeaturedTagSuggestions(ctx context.Context, accountID string, limit int) ([]*gtsmodel.Tag, error)
+
+	// PopulateFeaturedTag ensures that all sub-models of a featured tag are populated (account, tag).
+	PopulateFeaturedTag(ctx context.Context, featuredTag *gtsmodel.FeaturedTag) error
+
+	// PutFeaturedTag inserts the given featured tag into the database.
+	PutFeaturedTag(ctx context.Context, featuredTag *gtsmodel.FeaturedTag) error
+
+	// UpdateFeaturedTagStats recalculates the statuses count and last
+	// status time of the given featured tag from the public statuses of
+	// its account, and stores the updated values in the database.
+	UpdateFeaturedTagStats(ctx context.Context, featuredTag *gtsmodel.FeaturedTag) error
+
+	// DeleteFeaturedTagByID deletes one featured tag with the given id.
+	DeleteFeaturedTagByID(ctx context.Context, id string) error
+
+	// DeleteAccountFeaturedTags deletes all featured tags of the given account.
+	DeleteAccountFeaturedTags(ctx context.Context, accountID string) error
+}
@@ -0,0 +1,52 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/paging"
+)
+
+// Import contains functions for tracking the
+// progress of account data imports in the database.
+type Import interface {
+	// GetImportByID returns the import with the given ID.
+	GetImportByID(ctx context.Context, id string) (*gtsmodel.Import, error)
+
+	// GetImports returns a page of imports of the given account, newest first.
+	GetImports(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.Import, error)
+
+	// PutImport puts the given import in the database.
+	PutImport(ctx context.Context, imp *gtsmodel.Import) error
+
+	// UpdateImport updates the given import in the database. If no
+	// columns are specified, every column is updated.
+	UpdateImport(ctx context.Context, imp *gtsmodel.Import, columns ...string) error
+
+	// DeleteImportsByAccountID deletes all imports
+	// (and their failures) of the given account.
+	DeleteImportsByAccountID(ctx context.Context, accountID string) error
+
+	// GetImportFailures returns all failures of the given import, oldest first.
+	GetImportFailures(ctx context.Context, importID string) ([]*gtsmodel.ImportFailure, error)
+
+	// PutImportFailure puts the given import failure in the database.
+	PutImportFailure(ctx context.Context, failure *gtsmodel.ImportFailure) error
+}
@@ -0,0 +1,56 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/paging"
+)
+
+// Invite contains functions for managing
+// registration invite links in the database.
+type Invite interface {
+	// GetInviteByID returns the invite with the given ID.
+	GetInviteByID(ctx context.Context, id string) (*gtsmodel.Invite, error)
+
+	// GetInviteByCode returns the invite with the given code.
+	GetInviteByCode(ctx context.Context, code string) (*gtsmodel.Invite, error)
+
+	// GetInvites returns a page of invites, newest first. If accountID
+	// is set, only invites created by that account are returned.
+	GetInvites(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.Invite, error)
+
+	// PutInvite puts the given invite in the database.
+	PutInvite(ctx context.Context, invite *gtsmodel.Invite) error
+
+	// UpdateInvite updates the given invite in the database. If no
+	// columns are specified, every column is updated.
+	UpdateInvite(ctx context.Context, invite *gtsmodel.Invite, columns ...string) error
+
+	// IncrementInviteUses atomically increments the uses count of the
+	// given invite, provided it has not yet expired or been exhausted.
+	// If the invite can no longer be used, ErrNoEntries is returned.
+	IncrementInviteUses(ctx context.Context, invite *gtsmodel.Invite) error
+
+	// DecrementInviteUses atomically decrements the uses count of
+	// the given invite, giving back a use previously taken with
+	// IncrementInviteUses, eg., when the sign-up using it failed.
+	DecrementInviteUses(ctx context.Context, invite *gtsmodel.Invite) error
+}
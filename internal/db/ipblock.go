@@ -0,0 +1,54 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"net/netip"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/paging"
+)
+
+// IPBlock contains functions for managing
+// admin-created IP range blocks in the database.
+type IPBlock interface {
+	// GetIPBlockByID returns the IP block with the given ID.
+	GetIPBlockByID(ctx context.Context, id string) (*gtsmodel.IPBlock, error)
+
+	// GetIPBlockByIP returns the IP block for the given IP range.
+	GetIPBlockByIP(ctx context.Context, ip string) (*gtsmodel.IPBlock, error)
+
+	// GetIPBlocks returns a page of IP blocks, newest first.
+	GetIPBlocks(ctx context.Context, page *paging.Page) ([]*gtsmodel.IPBlock, error)
+
+	// PutIPBlock puts the given IP block in the database.
+	PutIPBlock(ctx context.Context, block *gtsmodel.IPBlock) error
+
+	// UpdateIPBlock updates the given IP block in the database. If no
+	// columns are specified, every column is updated.
+	UpdateIPBlock(ctx context.Context, block *gtsmodel.IPBlock, columns ...string) error
+
+	// DeleteIPBlockByID deletes the IP block with the given ID.
+	DeleteIPBlockByID(ctx context.Context, id string) error
+
+	// MatchIPBlock returns the most severe unexpired IP block containing
+	// the given address, or nil if there is none. Blocks are cached in
+	// memory, so this is safe to call on every incoming request.
+	MatchIPBlock(ctx context.Context, addr netip.Addr) (*gtsmodel.IPBlock, error)
+}
@@ -51,4 +51,9 @@ type Media interface {
 	// GetCachedAttachmentsOlderThan gets limit n remote attachments (including avatars and headers) older than
 	// the given time. These will be returned in order of attachment.created_at descending (i.e. newest to oldest).
 	GetCachedAttachmentsOlderThan(ctx context.Context, olderThan time.Time, limit int) ([]*gtsmodel.MediaAttachment, error)
+
+	// CountBlobReferences counts the media attachments and emojis (other than the one with excludeID,
+	// if set) that use the given content-addressed storage path for any of their files, whether
+	// or not they're currently cached, as media still processing are not yet marked as cached.
+	CountBlobReferences(ctx context.Context, path string, excludeID string) (int, error)
 }
@@ -29,8 +29,17 @@ type Notification interface {
 	// GetAccountNotifications returns a slice of notifications that pertain to the given accountID.
 	//
 	// Returned notifications will be ordered ID descending (ie., highest/newest to lowest/oldest).
-	// If types is empty, *all* notification types will be included.
-	GetAccountNotifications(ctx context.Context, accountID string, page *paging.Page, types []gtsmodel.NotificationType, excludeTypes []gtsmodel.NotificationType) ([]*gtsmodel.Notification, error)
+	// If types is empty, *all* notification types will be included. If originAccountID is set,
+	// only notifications from that account will be included. Notifications filtered by the
+	// account's notification policy are only included if includeFiltered is true.
+	GetAccountNotifications(ctx context.Context, accountID string, page *paging.Page, types []gtsmodel.NotificationType, excludeTypes []gtsmodel.NotificationType, originAccountID string, includeFiltered bool) ([]*gtsmodel.Notification, error)
+
+	// GetAccountStatusNotifications returns a slice of notifications of the given type that pertain
+	// to the given accountID and statusID, including notifications about boosts of the status.
+	// Notifications filtered by the account's notification policy are not included.
+	//
+	// Returned notifications will be ordered ID descending (ie., highest/newest to lowest/oldest).
+	GetAccountStatusNotifications(ctx context.Context, accountID string, notifType gtsmodel.NotificationType, statusID string, page *paging.Page) ([]*gtsmodel.Notification, error)
 
 	// GetNotificationByID returns one notification according to its id.
 	GetNotificationByID(ctx context.Context, id string) (*gtsmodel.Notification, error)
@@ -73,6 +82,14 @@ type Notification interface {
 	// At least one parameter must not be an empty string.
 	DeleteNotifications(ctx context.Context, types []gtsmodel.NotificationType, targetAccountID string, originAccountID string) error
 
+	// UnfilterNotifications marks all filtered notifications targeting
+	// targetAccountID and originating from originAccountID as not filtered.
+	UnfilterNotifications(ctx context.Context, targetAccountID string, originAccountID string) error
+
+	// DeleteFilteredNotifications deletes all filtered notifications
+	// targeting targetAccountID and originating from originAccountID.
+	DeleteFilteredNotifications(ctx context.Context, targetAccountID string, originAccountID string) error
+
 	// DeleteNotificationsForStatus deletes all notifications that relate to
 	// the given statusID. This function is useful when a status has been deleted,
 	// and so notifications relating to that status must also be deleted.
@@ -0,0 +1,70 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/paging"
+)
+
+// NotificationPolicy contains functions for getting and setting
+// notification policies, and the notification requests and
+// permissions resulting from them.
+type NotificationPolicy interface {
+	// GetNotificationPolicy gets the notification policy of the given account ID.
+	GetNotificationPolicy(ctx context.Context, accountID string) (*gtsmodel.NotificationPolicy, error)
+
+	// PutNotificationPolicy inserts the given notification policy,
+	// or updates all its columns if the account already has one.
+	PutNotificationPolicy(ctx context.Context, policy *gtsmodel.NotificationPolicy) error
+
+	// GetNotificationRequestByID gets one notification request with the given id.
+	GetNotificationRequestByID(ctx context.Context, id string) (*gtsmodel.NotificationRequest, error)
+
+	// GetNotificationRequest gets the notification request targeting
+	// accountID, for notifications triggered by fromAccountID.
+	GetNotificationRequest(ctx context.Context, accountID string, fromAccountID string) (*gtsmodel.NotificationRequest, error)
+
+	// GetAccountNotificationRequests returns a page of notification requests targeting accountID,
+	// ordered by ID descending (ie., highest/newest to lowest/oldest).
+	GetAccountNotificationRequests(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.NotificationRequest, error)
+
+	// CountAccountNotificationRequests returns the number of notification requests
+	// targeting accountID, along with the total number of notifications they hold.
+	CountAccountNotificationRequests(ctx context.Context, accountID string) (requests int, notifications int, err error)
+
+	// PutNotificationRequest inserts the given notification request.
+	PutNotificationRequest(ctx context.Context, request *gtsmodel.NotificationRequest) error
+
+	// UpdateNotificationRequest updates the given notification request. If
+	// no columns are specified, all will be updated; updated_at is always set.
+	UpdateNotificationRequest(ctx context.Context, request *gtsmodel.NotificationRequest, columns ...string) error
+
+	// DeleteNotificationRequestByID deletes one notification request with the given id.
+	DeleteNotificationRequestByID(ctx context.Context, id string) error
+
+	// IsNotificationPermitted returns whether accountID has
+	// accepted notifications from fromAccountID, ie., whether
+	// a NotificationPermission exists for the pair of accounts.
+	IsNotificationPermitted(ctx context.Context, accountID string, fromAccountID string) (bool, error)
+
+	// PutNotificationPermission inserts the given notification permission.
+	PutNotificationPermission(ctx context.Context, permission *gtsmodel.NotificationPermission) error
+}
@@ -0,0 +1,58 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+)
+
+// Relay contains functions for managing
+// ActivityPub relay subscriptions in the database.
+type Relay interface {
+	// GetRelayByID returns the relay with the given ID.
+	GetRelayByID(ctx context.Context, id string) (*gtsmodel.Relay, error)
+
+	// GetRelayByInboxURI returns the relay with the given inbox URI.
+	GetRelayByInboxURI(ctx context.Context, inboxURI string) (*gtsmodel.Relay, error)
+
+	// GetRelayByFollowURI returns the relay
+	// subscribed to with the given Follow URI.
+	GetRelayByFollowURI(ctx context.Context, followURI string) (*gtsmodel.Relay, error)
+
+	// GetRelayByAccountID returns the relay with the given actor account ID.
+	GetRelayByAccountID(ctx context.Context, accountID string) (*gtsmodel.Relay, error)
+
+	// GetRelays returns all relays, newest first.
+	GetRelays(ctx context.Context) ([]*gtsmodel.Relay, error)
+
+	// GetPublishRelays returns all relays that have accepted
+	// our Follow, and which we deliver our public posts to.
+	GetPublishRelays(ctx context.Context) ([]*gtsmodel.Relay, error)
+
+	// PutRelay puts the given relay in the database.
+	PutRelay(ctx context.Context, relay *gtsmodel.Relay) error
+
+	// UpdateRelay updates the given relay in the database. If no
+	// columns are specified, every column is updated.
+	UpdateRelay(ctx context.Context, relay *gtsmodel.Relay, columns ...string) error
+
+	// DeleteRelayByID deletes the relay with the given ID.
+	DeleteRelayByID(ctx context.Context, id string) error
+}
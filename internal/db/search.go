@@ -24,13 +24,20 @@
 )
 
 type Search interface {
-	// SearchForAccounts uses the given query text to search for accounts that accountID follows.
+	// SearchForAccounts uses the given query text to search for accounts, ordered by relevance.
+	// If following is true, results are restricted to accounts that accountID follows.
 	SearchForAccounts(ctx context.Context, accountID string, query string, maxID string, minID string, limit int, following bool, offset int) ([]*gtsmodel.Account, error)
 
-	// SearchForStatuses uses the given query text to search for statuses created by requestingAccountID, or in reply to requestingAccountID.
+	// SearchForStatuses uses the given query text to search for statuses created by requestingAccountID, in reply to
+	// requestingAccountID, or public statuses created by accounts that have opted in to search indexing, ordered by relevance.
 	// If fromAccountID is used, the results are restricted to statuses created by fromAccountID.
-	SearchForStatuses(ctx context.Context, requestingAccountID string, query string, fromAccountID string, maxID string, minID string, limit int, offset int) ([]*gtsmodel.Status, error)
+	// If hasMedia is true, the results are restricted to statuses with media attachments.
+	SearchForStatuses(ctx context.Context, requestingAccountID string, query string, fromAccountID string, hasMedia bool, maxID string, minID string, limit int, offset int) ([]*gtsmodel.Status, error)
 
 	// SearchForTags searches for tags that start with the given query text (case insensitive).
 	SearchForTags(ctx context.Context, query string, maxID string, minID string, limit int, offset int) ([]*gtsmodel.Tag, error)
+
+	// RebuildSearchIndexes drops and recreates the full-text search
+	// index entries for all accounts and (non-boost) statuses.
+	RebuildSearchIndexes(ctx context.Context) error
 }
@@ -0,0 +1,65 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+)
+
+// Suggestion contains functions for gathering
+// follow suggestions, and managing staff picks
+// and suggestion dismissals in the database.
+type Suggestion interface {
+	// GetFriendsOfFriendsSuggestionIDs returns the IDs of accounts followed by accounts
+	// that the given account follows, excluding the given account itself, accounts it
+	// already follows, and accounts it has dismissed from suggestions. Results are
+	// ordered by the number of followed accounts following them, most first.
+	GetFriendsOfFriendsSuggestionIDs(ctx context.Context, accountID string, limit int) ([]string, error)
+
+	// GetInteractionsSuggestionIDs returns the IDs of accounts that have faved, boosted,
+	// or replied to statuses of accounts that the given account follows since the given
+	// time, with the same exclusions as GetFriendsOfFriendsSuggestionIDs. Results are
+	// ordered by the number of such interactions, most first.
+	GetInteractionsSuggestionIDs(ctx context.Context, accountID string, since time.Time, limit int) ([]string, error)
+
+	// GetStaffPicks returns all staff picks, newest first.
+	GetStaffPicks(ctx context.Context) ([]*gtsmodel.StaffPick, error)
+
+	// GetStaffPickByAccountID returns the staff pick for the given account ID.
+	GetStaffPickByAccountID(ctx context.Context, accountID string) (*gtsmodel.StaffPick, error)
+
+	// PutStaffPick puts the given staff pick in the database.
+	PutStaffPick(ctx context.Context, pick *gtsmodel.StaffPick) error
+
+	// DeleteStaffPickByAccountID deletes the staff pick for the given account ID.
+	DeleteStaffPickByAccountID(ctx context.Context, accountID string) error
+
+	// IsSuggestionDismissed returns whether the given account
+	// has dismissed target account from its follow suggestions.
+	IsSuggestionDismissed(ctx context.Context, accountID string, targetAccountID string) (bool, error)
+
+	// PutSuggestionDismissal puts the given suggestion dismissal in the database.
+	PutSuggestionDismissal(ctx context.Context, dismissal *gtsmodel.SuggestionDismissal) error
+
+	// DeleteAccountSuggestionDismissals deletes all suggestion
+	// dismissals made by, or targeting, the given account ID.
+	DeleteAccountSuggestionDismissals(ctx context.Context, accountID string) error
+}
@@ -0,0 +1,62 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+)
+
+// Trend contains functions for gathering trend usage
+// samples and managing trend reviews in the database.
+type Trend interface {
+	// GetTrendReviewByID gets one trend review with the given id.
+	GetTrendReviewByID(ctx context.Context, id string) (*gtsmodel.TrendReview, error)
+
+	// GetTrendReviewByTarget gets one trend review for the given trend type and target.
+	GetTrendReviewByTarget(ctx context.Context, trendType gtsmodel.TrendType, targetID string) (*gtsmodel.TrendReview, error)
+
+	// PutTrendReview puts the given trend review in the database.
+	PutTrendReview(ctx context.Context, review *gtsmodel.TrendReview) error
+
+	// UpdateTrendReview updates the given trend review in the database,
+	// optionally limiting the update to the given column names.
+	UpdateTrendReview(ctx context.Context, review *gtsmodel.TrendReview, columns ...string) error
+
+	// DeletePendingTrendReviewsOlderThan deletes all trend reviews still pending
+	// review which were last updated before the given time, ie., the reviews of
+	// items which have since stopped trending without being reviewed.
+	DeletePendingTrendReviewsOlderThan(ctx context.Context, olderThan time.Time) error
+
+	// GetTagTrendSamples returns one sample per use of a tag in a
+	// public status, for all public statuses created after since.
+	GetTagTrendSamples(ctx context.Context, since time.Time) ([]*gtsmodel.TrendSample, error)
+
+	// GetStatusTrendSamples returns one sample per boost or fave of a
+	// public status, for all public statuses created after since, for
+	// all boosts and faves also created after since.
+	GetStatusTrendSamples(ctx context.Context, since time.Time) ([]*gtsmodel.TrendSample, error)
+
+	// GetLinkTrendSamples returns one sample per original, non-boost, public
+	// status with a link preview card created after since, targeting the
+	// card URL. Only the most recent samples up to an internal bound are
+	// returned.
+	GetLinkTrendSamples(ctx context.Context, since time.Time) ([]*gtsmodel.TrendSample, error)
+}
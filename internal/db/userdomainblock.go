@@ -0,0 +1,45 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/paging"
+)
+
+// UserDomainBlock contains functions for managing
+// user-level domain blocks in the database.
+type UserDomainBlock interface {
+	// GetUserDomainBlocks returns a page of the given account's domain blocks, newest first.
+	GetUserDomainBlocks(ctx context.Context, accountID string, page *paging.Page) ([]*gtsmodel.UserDomainBlock, error)
+
+	// PutUserDomainBlock puts the given user domain block in the database.
+	PutUserDomainBlock(ctx context.Context, block *gtsmodel.UserDomainBlock) error
+
+	// DeleteUserDomainBlock deletes the given account's block of the given domain.
+	DeleteUserDomainBlock(ctx context.Context, accountID string, domain string) error
+
+	// DeleteUserDomainBlocksByAccountID deletes all domain blocks of the given account.
+	DeleteUserDomainBlocksByAccountID(ctx context.Context, accountID string) error
+
+	// IsUserDomainBlocked checks whether the given account
+	// blocks the given domain, or any of its parent domains.
+	IsUserDomainBlocked(ctx context.Context, accountID string, domain string) (bool, error)
+}
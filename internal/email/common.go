@@ -35,12 +35,18 @@
 )
 
 func (s *sender) sendTemplate(template string, subject string, data any, toAddresses ...string) error {
+	return s.sendUnsubscribableTemplate(template, subject, data, "", toAddresses...)
+}
+
+// sendUnsubscribableTemplate is like sendTemplate, but additionally
+// sets headers for one-click unsubscribe using the given link, if any.
+func (s *sender) sendUnsubscribableTemplate(template string, subject string, data any, unsubscribeURL string, toAddresses ...string) error {
 	buf := &bytes.Buffer{}
 	if err := s.template.ExecuteTemplate(buf, template, data); err != nil {
 		return err
 	}
 
-	msg, err := assembleMessage(subject, buf.String(), s.from, s.msgIDHost, toAddresses...)
+	msg, err := assembleMessage(subject, buf.String(), s.from, s.msgIDHost, unsubscribeURL, toAddresses...)
 	if err != nil {
 		return err
 	}
@@ -68,11 +74,18 @@ func loadTemplates(templateBaseDir string) (*template.Template, error) {
 // assembleMessage assembles a valid email message following:
 //   - https://datatracker.ietf.org/doc/html/rfc2822
 //   - https://pkg.go.dev/net/smtp#SendMail
-func assembleMessage(mailSubject string, mailBody string, mailFrom string, msgIDHost string, mailTo ...string) ([]byte, error) {
+//
+// If unsubscribeURL is set, one-click unsubscribe headers are added following:
+//   - https://datatracker.ietf.org/doc/html/rfc8058
+func assembleMessage(mailSubject string, mailBody string, mailFrom string, msgIDHost string, unsubscribeURL string, mailTo ...string) ([]byte, error) {
 	if strings.ContainsAny(mailSubject, "\r\n") {
 		return nil, errors.New("email subject must not contain newline characters")
 	}
 
+	if strings.ContainsAny(unsubscribeURL, "\r\n<>") {
+		return nil, errors.New("email unsubscribe url must not contain newline or angle bracket characters")
+	}
+
 	if strings.ContainsAny(mailFrom, "\r\n") {
 		return nil, errors.New("email from address must not contain newline characters")
 	}
@@ -110,6 +123,10 @@ func assembleMessage(mailSubject string, mailBody string, mailFrom string, msgID
 	msg.WriteString("From: " + mailFrom + CRLF)
 	msg.WriteString("Message-ID: <" + uuid.New().String() + "@" + msgIDHost + ">" + CRLF)
 	msg.WriteString("Subject: " + mailSubject + CRLF)
+	if unsubscribeURL != "" {
+		msg.WriteString("List-Unsubscribe: <" + unsubscribeURL + ">" + CRLF)
+		msg.WriteString("List-Unsubscribe-Post: List-Unsubscribe=One-Click" + CRLF)
+	}
 	msg.WriteString("MIME-Version: 1.0" + CRLF)
 	msg.WriteString("Content-Transfer-Encoding: 8bit" + CRLF)
 	msg.WriteString("Content-Type: text/plain; charset=\"UTF-8\"" + CRLF)
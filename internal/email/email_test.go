@@ -220,6 +220,78 @@ func (suite *EmailTestSuite) TestTemplateReportClosedLocalAccountNoComment() {
 	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Report Closed\r\nMIME-Version: 1.0\r\nContent-Transfer-Encoding: 8bit\r\nContent-Type: text/plain; charset=\"UTF-8\"\r\n\r\nHello !\r\n\r\nYou recently reported the account @1happyturtle to the moderator(s) of Test Instance (https://example.org).\r\n\r\nThe report you submitted has now been closed.\r\n\r\nThe moderator who closed the report did not leave a comment.\r\n\r\n---\r\n\r\nIf you believe you've been sent this email in error, feel free to ignore it, or contact the administrator of https://example.org.\r\n\r\n", suite.sentEmails["user@example.org"])
 }
 
+func (suite *EmailTestSuite) TestTemplateNotification() {
+	notificationData := email.NotificationData{
+		Username:     "test",
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+		Notification: email.NotificationItem{
+			Type:          "mention",
+			AccountName:   "Some User",
+			AccountHandle: "@some_user@fossbros-anonymous.io",
+			AccountURL:    "https://fossbros-anonymous.io/@some_user",
+			StatusText:    "hey @test, how's it going?",
+			StatusURL:     "https://fossbros-anonymous.io/@some_user/statuses/01GVJHN1RTYZCZTCXVPPPKBX6R",
+		},
+		UnsubscribeURL: "https://example.org/unsubscribe?account=01F8MH1H7YV1Z7D2C8K2730QBF&token=abc",
+	}
+
+	if err := suite.sender.SendNotificationEmail("user@example.org", notificationData); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.stripHeaders()
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial New Notification\r\nList-Unsubscribe: <https://example.org/unsubscribe?account=01F8MH1H7YV1Z7D2C8K2730QBF&token=abc>\r\nList-Unsubscribe-Post: List-Unsubscribe=One-Click\r\nMIME-Version: 1.0\r\nContent-Transfer-Encoding: 8bit\r\nContent-Type: text/plain; charset=\"UTF-8\"\r\n\r\nHello test!\r\n\r\nYou have a new notification on Test Instance (https://example.org):\r\n\r\nSome User (@some_user@fossbros-anonymous.io) mentioned you.\r\n\r\nhey @test, how's it going?\r\n\r\nhttps://fossbros-anonymous.io/@some_user/statuses/01GVJHN1RTYZCZTCXVPPPKBX6R\r\n\r\n---\r\n\r\nYou are receiving this mail because you enabled email notifications for your account on https://example.org. To stop receiving notifications by email, paste the following link into your browser: https://example.org/unsubscribe?account=01F8MH1H7YV1Z7D2C8K2730QBF&token=abc\r\n\r\n", suite.sentEmails["user@example.org"])
+}
+
+func (suite *EmailTestSuite) TestTemplateNotificationDigest() {
+	notificationDigestData := email.NotificationDigestData{
+		Username:     "test",
+		InstanceURL:  "https://example.org",
+		InstanceName: "Test Instance",
+		Period:       "weekly",
+		Notifications: []email.NotificationItem{
+			{
+				Type:                 "favourite",
+				AccountName:          "Some User",
+				AccountHandle:        "@some_user@fossbros-anonymous.io",
+				AccountURL:           "https://fossbros-anonymous.io/@some_user",
+				StatusContentWarning: "spoilers",
+				StatusURL:            "https://example.org/@test/statuses/01GVJHN1RTYZCZTCXVPPPKBX6R",
+			},
+			{
+				Type:          "follow",
+				AccountName:   "other_user",
+				AccountHandle: "@other_user",
+				AccountURL:    "https://example.org/@other_user",
+			},
+		},
+		More:           3,
+		UnsubscribeURL: "https://example.org/unsubscribe?account=01F8MH1H7YV1Z7D2C8K2730QBF&token=abc",
+	}
+
+	if err := suite.sender.SendNotificationDigestEmail("user@example.org", notificationDigestData); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.stripHeaders()
+	suite.Len(suite.sentEmails, 1)
+	suite.Equal("To: user@example.org\r\nFrom: test@example.org\r\nSubject: GoToSocial Notifications Digest\r\nList-Unsubscribe: <https://example.org/unsubscribe?account=01F8MH1H7YV1Z7D2C8K2730QBF&token=abc>\r\nList-Unsubscribe-Post: List-Unsubscribe=One-Click\r\nMIME-Version: 1.0\r\nContent-Transfer-Encoding: 8bit\r\nContent-Type: text/plain; charset=\"UTF-8\"\r\n\r\nHello test!\r\n\r\nHere's your weekly digest of notifications from Test Instance (https://example.org).\r\n\r\n---\r\n\r\nSome User (@some_user@fossbros-anonymous.io) favourited your post.\r\n\r\nContent warning: spoilers\r\n\r\nhttps://example.org/@test/statuses/01GVJHN1RTYZCZTCXVPPPKBX6R\r\n\r\n---\r\n\r\nother_user (@other_user) followed you.\r\n\r\nhttps://example.org/@other_user\r\n\r\n---\r\n\r\n...and 3 more. To see all your notifications, log in at https://example.org.\r\n\r\n---\r\n\r\nYou are receiving this mail because you enabled email notification digests for your account on https://example.org. To stop receiving notifications by email, paste the following link into your browser: https://example.org/unsubscribe?account=01F8MH1H7YV1Z7D2C8K2730QBF&token=abc\r\n\r\n", suite.sentEmails["user@example.org"])
+}
+
+func (suite *EmailTestSuite) TestUnsubscribeToken() {
+	var (
+		key       = []byte("01234567890123456789012345678901")
+		otherKey  = []byte("abcdefghijabcdefghijabcdefghijab")
+		accountID = "01F8MH1H7YV1Z7D2C8K2730QBF"
+		token     = email.UnsubscribeToken(key, accountID)
+	)
+
+	suite.True(email.ValidUnsubscribeToken(key, accountID, token))
+	suite.False(email.ValidUnsubscribeToken(key, "01F8MH5NBDF2MV7CTC4Q5128HF", token))
+	suite.False(email.ValidUnsubscribeToken(otherKey, accountID, token))
+	suite.False(email.ValidUnsubscribeToken(key, accountID, ""))
+}
+
 func TestEmailTestSuite(t *testing.T) {
 	suite.Run(t, new(EmailTestSuite))
 }
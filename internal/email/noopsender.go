@@ -83,13 +83,25 @@ func (s *noopSender) SendSignupRejectedEmail(toAddress string, data SignupReject
 	return s.sendTemplate(signupRejectedTemplate, signupRejectedSubject, data, toAddress)
 }
 
+func (s *noopSender) SendNotificationEmail(toAddress string, data NotificationData) error {
+	return s.sendUnsubscribableTemplate(notificationTemplate, notificationSubject, data, data.UnsubscribeURL, toAddress)
+}
+
+func (s *noopSender) SendNotificationDigestEmail(toAddress string, data NotificationDigestData) error {
+	return s.sendUnsubscribableTemplate(notificationDigestTemplate, notificationDigestSubject, data, data.UnsubscribeURL, toAddress)
+}
+
 func (s *noopSender) sendTemplate(template string, subject string, data any, toAddresses ...string) error {
+	return s.sendUnsubscribableTemplate(template, subject, data, "", toAddresses...)
+}
+
+func (s *noopSender) sendUnsubscribableTemplate(template string, subject string, data any, unsubscribeURL string, toAddresses ...string) error {
 	buf := &bytes.Buffer{}
 	if err := s.template.ExecuteTemplate(buf, template, data); err != nil {
 		return err
 	}
 
-	msg, err := assembleMessage(subject, buf.String(), "test@example.org", s.msgIDHost, toAddresses...)
+	msg, err := assembleMessage(subject, buf.String(), "test@example.org", s.msgIDHost, unsubscribeURL, toAddresses...)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,113 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package email
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+const (
+	notificationTemplate       = "email_notification.tmpl"
+	notificationSubject        = "GoToSocial New Notification"
+	notificationDigestTemplate = "email_notification_digest.tmpl"
+	notificationDigestSubject  = "GoToSocial Notifications Digest"
+)
+
+type NotificationData struct {
+	// Username to be addressed.
+	Username string
+	// URL of the instance to present to the receiver.
+	InstanceURL string
+	// Name of the instance to present to the receiver.
+	InstanceName string
+	// The notification being emailed.
+	Notification NotificationItem
+	// One-click link to stop receiving
+	// notifications by email.
+	UnsubscribeURL string
+}
+
+func (s *sender) SendNotificationEmail(toAddress string, data NotificationData) error {
+	return s.sendUnsubscribableTemplate(notificationTemplate, notificationSubject, data, data.UnsubscribeURL, toAddress)
+}
+
+type NotificationDigestData struct {
+	// Username to be addressed.
+	Username string
+	// URL of the instance to present to the receiver.
+	InstanceURL string
+	// Name of the instance to present to the receiver.
+	InstanceName string
+	// Period covered by the digest, "daily" or "weekly".
+	Period string
+	// Notifications received during the digest period,
+	// newest first. May be truncated, see More.
+	Notifications []NotificationItem
+	// Number of notifications received during
+	// the digest period not in Notifications.
+	More int
+	// One-click link to stop receiving
+	// notifications by email.
+	UnsubscribeURL string
+}
+
+func (s *sender) SendNotificationDigestEmail(toAddress string, data NotificationDigestData) error {
+	return s.sendUnsubscribableTemplate(notificationDigestTemplate, notificationDigestSubject, data, data.UnsubscribeURL, toAddress)
+}
+
+// NotificationItem contains details of one
+// notification, for notification emails.
+type NotificationItem struct {
+	// Type of the notification, as
+	// in the client API, eg "mention".
+	Type string
+	// Display name (or username) of the account
+	// that performed the notification's action.
+	AccountName string
+	// Handle of that account, eg "@someone@example.org".
+	AccountHandle string
+	// Web URL of that account.
+	AccountURL string
+	// Content warning of the status
+	// the notification is about, if any.
+	StatusContentWarning string
+	// Plain text content of the status the
+	// notification is about, if any. Not set
+	// if the status has a content warning.
+	StatusText string
+	// Web URL of the status the
+	// notification is about, if any.
+	StatusURL string
+}
+
+// UnsubscribeToken returns a token for accountID, signed with the
+// given secret key, to be included in one-click unsubscribe links.
+func UnsubscribeToken(key []byte, accountID string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("unsubscribe:" + accountID))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// ValidUnsubscribeToken returns whether token is a valid
+// unsubscribe token for accountID, signed with the given key.
+func ValidUnsubscribeToken(key []byte, accountID string, token string) bool {
+	expect := UnsubscribeToken(key, accountID)
+	return hmac.Equal([]byte(expect), []byte(token))
+}
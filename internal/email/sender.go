@@ -61,6 +61,14 @@ type Sender interface {
 	// SendSignupRejectedEmail sends an email to the given address
 	// that their sign-up request has been rejected by a moderator.
 	SendSignupRejectedEmail(toAddress string, data SignupRejectedData) error
+
+	// SendNotificationEmail sends an email to the given address
+	// about one notification they've received on the instance.
+	SendNotificationEmail(toAddress string, data NotificationData) error
+
+	// SendNotificationDigestEmail sends an email to the given address
+	// summarizing notifications they've received during the digest period.
+	SendNotificationDigestEmail(toAddress string, data NotificationDigestData) error
 }
 
 // NewSender returns a new email Sender interface with the given configuration, or an error if something goes wrong.
@@ -19,6 +19,7 @@
 
 import (
 	"context"
+	"crypto/ed25519"
 	"crypto/rsa"
 	"encoding/json"
 	"errors"
@@ -37,6 +38,7 @@
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
 	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/rfc9421"
 	"code.superseriousbusiness.org/httpsig"
 	"codeberg.org/gruf/go-kv/v2"
 )
@@ -62,6 +64,13 @@ type PubKeyAuth struct {
 	// was found in our database, but was expired.
 	FetchedPubKey *rsa.PublicKey
 
+	// CachedEd25519PubKey and FetchedEd25519PubKey are
+	// as above, but set instead of the RSA keys when the
+	// request was signed using an Actor's Ed25519 key
+	// from their FEP-521a assertionMethod property.
+	CachedEd25519PubKey  ed25519.PublicKey
+	FetchedEd25519PubKey ed25519.PublicKey
+
 	// OwnerURI is the ActivityPub id of the owner of
 	// the public key used to sign the request we're
 	// now authenticating. This will always be set.
@@ -106,10 +115,12 @@ type PubKeyAuth struct {
 func (f *Federator) AuthenticateFederatedRequest(ctx context.Context, requestedUsername string) (*PubKeyAuth, gtserror.WithCode) {
 	// Thanks to the signature check middleware,
 	// we should already have an http signature
-	// verifier set on the context. If we don't,
-	// this is an unsigned request.
+	// verifier set on the context, of either the
+	// RFC 9421 or draft-cavage variety. If we
+	// don't, this is an unsigned request.
 	verifier := gtscontext.HTTPSignatureVerifier(ctx)
-	if verifier == nil {
+	msgVerifier := gtscontext.HTTPMessageSignatureVerifier(ctx)
+	if verifier == nil && msgVerifier == nil {
 		err := gtserror.Newf("%w", errUnsigned)
 		errWithCode := gtserror.NewErrorUnauthorized(err, errUnsigned.Error(), "(verifier)")
 		return nil, errWithCode
@@ -169,13 +180,26 @@ func (f *Federator) AuthenticateFederatedRequest(ctx context.Context, requestedU
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	// Attempt to verify auth with both fetched and cached keys.
-	if !verifyAuth(&l, verifier, pubKeyAuth.CachedPubKey) &&
-		!verifyAuth(&l, verifier, pubKeyAuth.FetchedPubKey) {
+	if msgVerifier != nil {
+		// Attempt to verify RFC 9421 signature with all fetched and cached keys.
+		if !verifyMessageAuth(&l, msgVerifier, pubKeyAuth.CachedEd25519PubKey) &&
+			!verifyMessageAuth(&l, msgVerifier, pubKeyAuth.FetchedEd25519PubKey) &&
+			!verifyMessageAuth(&l, msgVerifier, pubKeyAuth.CachedPubKey) &&
+			!verifyMessageAuth(&l, msgVerifier, pubKeyAuth.FetchedPubKey) {
 
-		const format = "authentication NOT PASSED for public key %s; tried algorithms %+v; signature value was '%s'"
-		text := fmt.Sprintf(format, pubKeyIDStr, signingAlgorithms, signature)
-		return nil, gtserror.NewErrorUnauthorized(errors.New(text), text)
+			const format = "rfc9421 authentication NOT PASSED for public key %s; algorithm was '%s'; signature value was '%s'"
+			text := fmt.Sprintf(format, pubKeyIDStr, msgVerifier.Algorithm(), signature)
+			return nil, gtserror.NewErrorUnauthorized(errors.New(text), text)
+		}
+	} else {
+		// Attempt to verify auth with both fetched and cached keys.
+		if !verifyAuth(&l, verifier, pubKeyAuth.CachedPubKey) &&
+			!verifyAuth(&l, verifier, pubKeyAuth.FetchedPubKey) {
+
+			const format = "authentication NOT PASSED for public key %s; tried algorithms %+v; signature value was '%s'"
+			text := fmt.Sprintf(format, pubKeyIDStr, signingAlgorithms, signature)
+			return nil, gtserror.NewErrorUnauthorized(errors.New(text), text)
+		}
 	}
 
 	if pubKeyAuth.Owner == nil {
@@ -222,7 +246,7 @@ func (f *Federator) AuthenticateFederatedRequest(ctx context.Context, requestedU
 		// Catch a possible (but very rare) race condition where
 		// we've fetched a key, then fetched the Actor who owns the
 		// key, but the Key of the Actor has changed in the meantime.
-		if !pubKeyAuth.Owner.PublicKey.Equal(pubKeyAuth.FetchedPubKey) {
+		if keyMismatch(pubKeyAuth) {
 			err := gtserror.Newf(
 				"key mismatch: fetched key %s does not match pubkey of fetched Actor %s",
 				pubKeyID, pubKeyAuth.Owner.URI,
@@ -260,6 +284,16 @@ func (f *Federator) derefPubKeyDBOnly(
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	if owner == nil {
+		// Key may instead be an Ed25519
+		// key from an assertionMethod.
+		owner, err = f.db.GetAccountByEd25519PubkeyID(ctx, pubKeyIDStr)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err = gtserror.Newf("db error getting account with ed25519 pubKeyID %s: %w", pubKeyIDStr, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
 	if owner == nil {
 		// We don't have this
 		// account stored (yet).
@@ -273,6 +307,15 @@ func (f *Federator) derefPubKeyDBOnly(
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	if owner.PublicKeyURI != pubKeyIDStr {
+		// Request signed with Ed25519 key.
+		return &PubKeyAuth{
+			CachedEd25519PubKey: owner.Ed25519PublicKey,
+			OwnerURI:            ownerURI,
+			Owner:               owner,
+		}, nil
+	}
+
 	return &PubKeyAuth{
 		CachedPubKey: owner.PublicKey,
 		OwnerURI:     ownerURI,
@@ -353,7 +396,7 @@ func (f *Federator) derefPubKey(
 	}
 
 	// Extract the key and the owner from the response.
-	pubKey, pubKeyOwner, err := parsePubKeyBytes(ctx, pubKeyBytes, pubKeyID)
+	pubKey, edPubKey, pubKeyOwner, err := parsePubKeyBytes(ctx, pubKeyBytes, pubKeyID)
 	if err != nil {
 		err := gtserror.Newf("error parsing public key (%s): %w", pubKeyID, err)
 		return nil, gtserror.NewErrorUnauthorized(err)
@@ -364,26 +407,32 @@ func (f *Federator) derefPubKey(
 		// we had nothing cached; return the key
 		// we just fetched, and nothing else.
 		return &PubKeyAuth{
-			FetchedPubKey: pubKey,
-			OwnerURI:      pubKeyOwner,
+			FetchedPubKey:        pubKey,
+			FetchedEd25519PubKey: edPubKey,
+			OwnerURI:             pubKeyOwner,
 		}, nil
 	}
 
-	// Add newly-fetched key to response.
-	pubKeyAuth.FetchedPubKey = pubKey
-
 	// If key was expired, that means we already
 	// had an owner stored for it locally. Since
 	// we now successfully refreshed the pub key,
 	// we should update the account to reflect that.
 	owner := pubKeyAuth.Owner
-	owner.PublicKey = pubKeyAuth.FetchedPubKey
+	columns := []string{"public_key_expires_at"}
+	if edPubKey != nil {
+		pubKeyAuth.FetchedEd25519PubKey = edPubKey
+		owner.Ed25519PublicKey = edPubKey
+		columns = append(columns, "ed25519_public_key")
+	} else {
+		pubKeyAuth.FetchedPubKey = pubKey
+		owner.PublicKey = pubKey
+		columns = append(columns, "public_key")
+	}
 	owner.PublicKeyExpiresAt = time.Time{}
 	if err := f.db.UpdateAccount(
 		ctx,
 		owner,
-		"public_key",
-		"public_key_expires_at",
+		columns...,
 	); err != nil {
 		err := gtserror.Newf("db error updating account with refreshed public key (%s): %w", pubKeyIDStr, err)
 		return nil, gtserror.NewErrorInternalError(err)
@@ -494,18 +543,20 @@ func (f *Federator) fetchAccountInstance(
 	return nil
 }
 
-// parsePubKeyBytes extracts an rsa public key from the
-// given pubKeyBytes by trying to parse the pubKeyBytes
-// as an ActivityPub type. It will return the public key
-// itself, and the URI of the public key owner.
+// parsePubKeyBytes extracts a public key from the given
+// pubKeyBytes by trying to parse the pubKeyBytes as an
+// ActivityPub type. It will return the public key itself,
+// and the URI of the public key owner. If pubKeyID is that
+// of an Ed25519 assertionMethod Multikey, the Ed25519 key
+// is returned instead of the rsa key.
 func parsePubKeyBytes(
 	ctx context.Context,
 	pubKeyBytes []byte,
 	pubKeyID *url.URL,
-) (*rsa.PublicKey, *url.URL, error) {
+) (*rsa.PublicKey, ed25519.PublicKey, *url.URL, error) {
 	m := make(map[string]interface{})
 	if err := json.Unmarshal(pubKeyBytes, &m); err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	var (
@@ -514,10 +565,19 @@ func parsePubKeyBytes(
 	)
 
 	if t, err := streams.ToType(ctx, m); err == nil {
+		// See if Actor with an Ed25519 assertionMethod
+		// matching the key ID that signed the request.
+		if wup, ok := t.(ap.WithUnknownProperties); ok {
+			edPubKey, _, edOwnerURI := ap.GetEd25519AssertionMethod(wup, pubKeyID)
+			if edPubKey != nil {
+				return nil, edPubKey, edOwnerURI, nil
+			}
+		}
+
 		// See if Actor with a PublicKey attached.
 		wpk, ok := t.(ap.WithPublicKey)
 		if !ok {
-			return nil, nil, gtserror.Newf(
+			return nil, nil, nil, gtserror.Newf(
 				"resource at %s with type %T did not contain recognizable public key",
 				pubKeyID, t,
 			)
@@ -525,7 +585,7 @@ func parsePubKeyBytes(
 
 		pubKey, _, ownerURI, err = ap.ExtractPubKeyFromActor(wpk)
 		if err != nil {
-			return nil, nil, gtserror.Newf(
+			return nil, nil, nil, gtserror.Newf(
 				"error extracting public key from %T at %s: %w",
 				t, pubKeyID, err,
 			)
@@ -534,19 +594,31 @@ func parsePubKeyBytes(
 		// Bare PublicKey.
 		pubKey, _, ownerURI, err = ap.ExtractPubKeyFromKey(pk)
 		if err != nil {
-			return nil, nil, gtserror.Newf(
+			return nil, nil, nil, gtserror.Newf(
 				"error extracting public key at %s: %w",
 				pubKeyID, err,
 			)
 		}
+	} else if edPubKey, _, edOwnerURI, err := ap.ParseEd25519Multikey(m); err == nil {
+		// Bare Ed25519 Multikey.
+		return nil, edPubKey, edOwnerURI, nil
 	} else {
-		return nil, nil, gtserror.Newf(
+		return nil, nil, nil, gtserror.Newf(
 			"resource at %s did not contain recognizable public key",
 			pubKeyID,
 		)
 	}
 
-	return pubKey, ownerURI, nil
+	return pubKey, nil, ownerURI, nil
+}
+
+// keyMismatch returns whether the fetched public key
+// in pubKeyAuth differs from that of the fetched owner.
+func keyMismatch(pubKeyAuth *PubKeyAuth) bool {
+	if pubKeyAuth.FetchedEd25519PubKey != nil {
+		return !pubKeyAuth.Owner.Ed25519PublicKey.Equal(pubKeyAuth.FetchedEd25519PubKey)
+	}
+	return !pubKeyAuth.Owner.PublicKey.Equal(pubKeyAuth.FetchedPubKey)
 }
 
 var signingAlgorithms = []httpsig.Algorithm{
@@ -615,3 +687,23 @@ func verifyAuth(
 
 	return false
 }
+
+// verifyMessageAuth verifies RFC 9421 message
+// signature auth using the given public key.
+func verifyMessageAuth[K *rsa.PublicKey | ed25519.PublicKey](
+	l *log.Entry,
+	verifier *rfc9421.Verifier,
+	pubKey K,
+) bool {
+	if pubKey == nil {
+		return false
+	}
+
+	if err := verifier.Verify(pubKey); err != nil {
+		l.Tracef("rfc9421 authentication NOT PASSED with %T: %v", pubKey, err)
+		return false
+	}
+
+	l.Tracef("rfc9421 authentication PASSED with %T", pubKey)
+	return true
+}
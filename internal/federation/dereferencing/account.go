@@ -22,6 +22,7 @@
 	"context"
 	"errors"
 	"net/url"
+	"slices"
 	"time"
 
 	"code.superseriousbusiness.org/activity/pub"
@@ -125,6 +126,10 @@ func (d *Dereferencer) GetAccountByURI(
 				log.Errorf(ctx, "error fetching account featured collection: %v", err)
 			}
 
+			if err := d.dereferenceAccountFeaturedTags(ctx, requestUser, account); err != nil {
+				log.Errorf(ctx, "error fetching account featured tags collection: %v", err)
+			}
+
 			if err := d.dereferenceAccountStats(ctx, requestUser, account); err != nil {
 				log.Errorf(ctx, "error fetching account stats: %v", err)
 			}
@@ -250,6 +255,10 @@ func (d *Dereferencer) GetAccountByUsernameDomain(ctx context.Context, requestUs
 				log.Errorf(ctx, "error fetching account featured collection: %v", err)
 			}
 
+			if err := d.dereferenceAccountFeaturedTags(ctx, requestUser, account); err != nil {
+				log.Errorf(ctx, "error fetching account featured tags collection: %v", err)
+			}
+
 			if err := d.dereferenceAccountStats(ctx, requestUser, account); err != nil {
 				log.Errorf(ctx, "error fetching account stats: %v", err)
 			}
@@ -381,6 +390,10 @@ func (d *Dereferencer) RefreshAccount(
 				log.Errorf(ctx, "error fetching account featured collection: %v", err)
 			}
 
+			if err := d.dereferenceAccountFeaturedTags(ctx, requestUser, latest); err != nil {
+				log.Errorf(ctx, "error fetching account featured tags collection: %v", err)
+			}
+
 			if err := d.dereferenceAccountStats(ctx, requestUser, latest); err != nil {
 				log.Errorf(ctx, "error fetching account stats: %v", err)
 			}
@@ -433,6 +446,10 @@ func (d *Dereferencer) RefreshAccountAsync(
 				log.Errorf(ctx, "error fetching account featured collection: %v", err)
 			}
 
+			if err := d.dereferenceAccountFeaturedTags(ctx, requestUser, latest); err != nil {
+				log.Errorf(ctx, "error fetching account featured tags collection: %v", err)
+			}
+
 			if err := d.dereferenceAccountStats(ctx, requestUser, latest); err != nil {
 				log.Errorf(ctx, "error fetching account stats: %v", err)
 			}
@@ -1288,3 +1305,123 @@ func (d *Dereferencer) dereferenceAccountFeatured(ctx context.Context, requestUs
 
 	return nil
 }
+
+// maxFeaturedTags is the maximum number of hashtags
+// from an account's featured tags collection we'll store.
+const maxFeaturedTags = 10
+
+// dereferenceAccountFeaturedTags dereferences an account's featuredTagsURI (if not empty), and
+// replaces the account's stored featured tags with the hashtags found in the collection.
+func (d *Dereferencer) dereferenceAccountFeaturedTags(ctx context.Context, requestUser string, account *gtsmodel.Account) error {
+	if account.FeaturedTagsURI == "" {
+		// No featured tags (anymore), make
+		// sure we don't keep any stale ones.
+		return d.state.DB.DeleteAccountFeaturedTags(ctx, account.ID)
+	}
+
+	uri, err := url.Parse(account.FeaturedTagsURI)
+	if err != nil {
+		return err
+	}
+
+	collect, err := d.dereferenceCollection(ctx, requestUser, uri)
+	if err != nil {
+		return err
+	}
+
+	// Get previous featured tags (we'll need these later).
+	wasFeatured, err := d.state.DB.GetAccountFeaturedTags(
+		gtscontext.SetBarebones(ctx),
+		account.ID,
+	)
+	if err != nil {
+		return gtserror.Newf("error getting account featured tags: %w", err)
+	}
+
+	// IDs of the tags featured in the collection.
+	tagIDs := make([]string, 0, maxFeaturedTags)
+
+	for len(tagIDs) < maxFeaturedTags {
+		// Get next collect item.
+		item := collect.NextItem()
+		if item == nil {
+			break
+		}
+
+		// Featured tags are always
+		// embedded, never just an IRI.
+		hashtaggable, ok := item.GetType().(ap.Hashtaggable)
+		if !ok {
+			continue
+		}
+
+		tag, err := ap.ExtractHashtag(hashtaggable)
+		if err != nil {
+			log.Debugf(ctx, "error extracting hashtag from featured tags collection %s: %v", uri, err)
+			continue
+		}
+
+		// Look for existing tag with name in the database.
+		existing, err := d.state.DB.GetTagByName(ctx, tag.Name)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting tag %s: %w", tag.Name, err)
+		}
+
+		if existing != nil {
+			tag = existing
+		} else {
+			// Insert this tag with new name into the database.
+			tag.ID = id.NewULID()
+			if err := d.state.DB.PutTag(ctx, tag); err != nil {
+				log.Errorf(ctx, "db error putting tag %s: %v", tag.Name, err)
+				continue
+			}
+		}
+
+		if slices.Contains(tagIDs, tag.ID) {
+			// Already seen.
+			continue
+		}
+		tagIDs = append(tagIDs, tag.ID)
+
+		if slices.ContainsFunc(wasFeatured, func(f *gtsmodel.FeaturedTag) bool {
+			return f.TagID == tag.ID
+		}) {
+			// Already featured, we
+			// don't need to do anything.
+			continue
+		}
+
+		featuredTag := &gtsmodel.FeaturedTag{
+			ID:        id.NewULID(),
+			AccountID: account.ID,
+			TagID:     tag.ID,
+		}
+
+		if err := d.state.DB.PutFeaturedTag(ctx, featuredTag); err != nil {
+			log.Errorf(ctx, "error putting featured tag %s: %v", tag.Name, err)
+			continue
+		}
+
+		// Count statuses by this account we know of that use the tag.
+		if err := d.state.DB.UpdateFeaturedTagStats(ctx, featuredTag); err != nil {
+			log.Errorf(ctx, "error updating featured tag stats %s: %v", tag.Name, err)
+			continue
+		}
+	}
+
+	// Now that we know which tags are featured, we should
+	// remove previously featured tags that aren't included.
+	for _, featuredTag := range wasFeatured {
+		if slices.Contains(tagIDs, featuredTag.TagID) {
+			continue
+		}
+
+		if err := d.state.DB.DeleteFeaturedTagByID(ctx, featuredTag.ID); err != nil {
+			log.Errorf(ctx, "error deleting featured tag %s: %v", featuredTag.ID, err)
+			continue
+		}
+	}
+
+	return nil
+}
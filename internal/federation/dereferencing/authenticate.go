@@ -41,9 +41,13 @@ func verifyAccountKeysOnUpdate(existing, latest *gtsmodel.Account, now time.Time
 		return true
 	}
 
-	// Ensure that public keys have not changed.
+	// Ensure that public keys have not changed. An
+	// Ed25519 key may be newly added, but not changed.
 	if existing.PublicKey.Equal(latest.PublicKey) &&
-		existing.PublicKeyURI == latest.PublicKeyURI {
+		existing.PublicKeyURI == latest.PublicKeyURI &&
+		(existing.Ed25519PublicKey == nil ||
+			(existing.Ed25519PublicKey.Equal(latest.Ed25519PublicKey) &&
+				existing.Ed25519PublicKeyURI == latest.Ed25519PublicKeyURI)) {
 		return true
 	}
 
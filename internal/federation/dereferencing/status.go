@@ -522,6 +522,8 @@ func (d *Dereferencer) enrichStatus(
 	// over some values from "old" status.
 	latestStatus.FetchedAt = time.Now()
 	latestStatus.PinnedAt = status.PinnedAt
+	latestStatus.CardID = status.CardID
+	latestStatus.Card = status.Card
 
 	// These will always be remote.
 	latestStatus.Local = new(bool)
@@ -554,6 +556,14 @@ func (d *Dereferencer) enrichStatus(
 		return nil, nil, gtserror.SetNotPermitted(err)
 	}
 
+	// Check whether the status is permitted to quote
+	// its quoted status, if any. Function sets the
+	// "QuotePendingApproval" bool as necessary, and
+	// unlinks the quote if it's not permitted.
+	if err := d.checkStatusQuote(ctx, requestUser, latestStatus); err != nil {
+		return nil, nil, gtserror.Newf("error checking quote for status %s: %w", uri, err)
+	}
+
 	// Insert / update any attached status poll.
 	pollChanged, err := d.handleStatusPoll(ctx,
 		status,
@@ -636,6 +646,14 @@ func (d *Dereferencer) enrichStatus(
 		}
 	}
 
+	if latestStatus.QuoteOfURI != "" && latestStatus.QuoteOfID == "" {
+		// We don't have the quoted status stored yet.
+		// Now the quoting status is stored, try to
+		// dereference it async, as we can't do it inline
+		// while we (might) hold the lock for this status URI.
+		d.dereferenceQuoteOf(requestUser, latestStatus)
+	}
+
 	return latestStatus, statusable, nil
 }
 
@@ -1068,7 +1086,7 @@ func (d *Dereferencer) handleStatusEdit(
 	status.Edits = existing.Edits
 
 	// Preallocate max slice length.
-	cols = make([]string, 1, 13)
+	cols = make([]string, 1, 18)
 
 	// Always update `fetched_at`.
 	cols[0] = "fetched_at"
@@ -1141,6 +1159,26 @@ func (d *Dereferencer) handleStatusEdit(
 		// been previously populated properly.
 	}
 
+	if existing.QuoteOfURI != status.QuoteOfURI ||
+		existing.QuoteOfID != status.QuoteOfID ||
+		util.PtrOrZero(existing.QuotePendingApproval) != util.PtrOrZero(status.QuotePendingApproval) ||
+		existing.QuoteApprovedByURI != status.QuoteApprovedByURI {
+		// Quote was changed, or its approval
+		// status changed. Either way, update
+		// all quote columns together.
+		cols = append(cols,
+			"quote_of_id",
+			"quote_of_uri",
+			"quote_of_account_id",
+			"quote_pending_approval",
+			"quote_approved_by_uri",
+		)
+
+		// Quote changed doesn't necessarily
+		// indicate an edit, it may just have
+		// been dereferenced in the meantime.
+	}
+
 	if edited {
 		// Get previous-most-recent modified time,
 		// which will be this edit's creation time.
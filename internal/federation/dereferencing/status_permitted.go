@@ -24,6 +24,7 @@
 	"time"
 
 	"code.superseriousbusiness.org/gotosocial/internal/ap"
+	"code.superseriousbusiness.org/gotosocial/internal/config"
 	"code.superseriousbusiness.org/gotosocial/internal/db"
 	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
@@ -640,6 +641,278 @@ func (d *Dereferencer) isPermittedBoost(
 	return true, nil
 }
 
+// checkStatusQuote checks whether the given status is
+// permitted to quote the status at its QuoteOfURI (if
+// any), setting "QuotePendingApproval" as appropriate.
+//
+// Unlike replies and boosts, a status is never dropped
+// for an unpermitted quote: the quote is just unlinked
+// from the status, leaving the status content intact.
+//
+// If the quoted status isn't stored locally yet, this
+// is a noop; see dereferenceQuoteOf, which is called
+// once the status has been stored to link it afterwards.
+func (d *Dereferencer) checkStatusQuote(
+	ctx context.Context,
+	requestUser string,
+	status *gtsmodel.Status,
+) error {
+	if status.QuoteOfURI == "" {
+		// Not a quote,
+		// nothing to do.
+		return nil
+	}
+
+	// Assume not pending
+	// until we know otherwise.
+	status.QuotePendingApproval = util.Ptr(false)
+
+	quoteOf := status.QuoteOf
+	if quoteOf == nil {
+		// We don't have the quoted
+		// status stored yet, it'll
+		// be checked once we do.
+		return nil
+	}
+
+	if !quoteOf.IsLocal() {
+		return d.checkRemoteStatusQuote(ctx, requestUser, status)
+	}
+
+	// Approval of quotes of local statuses is only
+	// taken from our own stored interaction request,
+	// not from any quoteAuthorization the remote sent.
+	status.QuoteApprovedByURI = ""
+
+	// Check if we have a stored interaction request for this quote.
+	req, err := d.state.DB.GetInteractionRequestByInteractionURI(
+		gtscontext.SetBarebones(ctx),
+		status.URI,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting interaction request: %w", err)
+	}
+
+	if req != nil && req.InteractionType == gtsmodel.InteractionQuote {
+		switch {
+		case req.IsAccepted():
+			// Quote was already approved.
+			status.QuoteApprovedByURI = req.ResponseURI
+
+		case req.IsRejected():
+			// Quote was already rejected.
+			unlinkQuote(status)
+
+		default:
+			// Quote is still pending.
+			status.QuotePendingApproval = util.Ptr(true)
+		}
+
+		return nil
+	}
+
+	// Check visibility of local
+	// quoted status to quoting account.
+	visible, err := d.visFilter.StatusVisible(ctx,
+		status.Account,
+		quoteOf,
+	)
+	if err != nil {
+		return gtserror.Newf("error checking quoteOf visibility: %w", err)
+	}
+
+	if !visible {
+		// Our status is not visible to
+		// the account trying to quote it.
+		unlinkQuote(status)
+		return nil
+	}
+
+	// Check interaction policy of quoteOf.
+	quoteable, err := d.intFilter.StatusQuoteable(ctx,
+		status.Account,
+		quoteOf,
+	)
+	if err != nil {
+		return gtserror.Newf("error checking status quoteability: %w", err)
+	}
+
+	switch {
+	case quoteable.Forbidden():
+		// Quoter is not permitted
+		// to do this interaction.
+		unlinkQuote(status)
+
+	case quoteable.ManualApproval():
+		// Quoter is permitted to do
+		// this interaction pending
+		// approval by the quotee.
+		status.QuotePendingApproval = util.Ptr(true)
+	}
+
+	return nil
+}
+
+// checkRemoteStatusQuote checks whether the given status
+// is permitted to quote its remote QuoteOf, according to
+// the quoted status' interaction policy. Where the quote
+// requires manual approval, it's only considered approved
+// if its quoteAuthorization can be dereferenced + verified,
+// otherwise it's marked as pending approval.
+func (d *Dereferencer) checkRemoteStatusQuote(
+	ctx context.Context,
+	requestUser string,
+	status *gtsmodel.Status,
+) error {
+	quoteOf := status.QuoteOf
+
+	quoteable, err := d.intFilter.StatusQuoteable(ctx,
+		status.Account,
+		quoteOf,
+	)
+	if err != nil {
+		return gtserror.Newf("error checking status quoteability: %w", err)
+	}
+
+	switch {
+	case quoteable.Forbidden():
+		// Quoter is not permitted
+		// to do this interaction.
+		unlinkQuote(status)
+		return nil
+
+	case quoteable.AutomaticApproval():
+		// Quoter is permitted to do this
+		// interaction without approval.
+		return nil
+	}
+
+	if status.QuoteApprovedByURI != "" {
+		// Quote claims to be approved, check
+		// this by dereferencing the authorization
+		// and inspecting the return value.
+		permitted, err := d.isValidAuthURI(
+			ctx,
+			gtsmodel.InteractionQuote,
+			requestUser,
+			status.QuoteApprovedByURI, // approval uri
+			quoteOf.AccountURI,        // actor
+			status.URI,                // object
+			quoteOf.URI,               // target
+		)
+		if err != nil {
+			// Unlike replies, don't drop the status
+			// for this, just leave the quote pending.
+			log.Debugf(ctx, "undereferencable quote authorization %s: %v", status.QuoteApprovedByURI, err)
+		}
+
+		if permitted {
+			// Quote has
+			// been approved.
+			return nil
+		}
+	}
+
+	// Quote needs approval by
+	// quotee that we can't verify.
+	status.QuotePendingApproval = util.Ptr(true)
+	status.QuoteApprovedByURI = ""
+	return nil
+}
+
+// dereferenceQuoteOf asynchronously dereferences the
+// status quoted by the given status, and links the
+// given status to it once it's been dereferenced.
+// The given status must already be stored.
+func (d *Dereferencer) dereferenceQuoteOf(
+	requestUser string,
+	status *gtsmodel.Status,
+) {
+	quoteURI, err := url.Parse(status.QuoteOfURI)
+	if err != nil {
+		// Invalid quote URI,
+		// just leave it be.
+		return
+	}
+
+	if quoteURI.Host == config.GetHost() ||
+		quoteURI.Host == config.GetAccountDomain() {
+		// Local status we don't have stored,
+		// most likely it's been deleted.
+		return
+	}
+
+	statusID := status.ID
+	d.state.Workers.Dereference.Queue.Push(func(ctx context.Context) {
+		quoteOf, _, err := d.GetStatusByURI(ctx, requestUser, quoteURI)
+		if err != nil {
+			log.Debugf(ctx, "error dereferencing quoted status %s: %v", quoteURI, err)
+			return
+		}
+
+		// Refetch the quoting status, as
+		// it may have changed in the meantime.
+		status, err := d.state.DB.GetStatusByID(
+			gtscontext.SetBarebones(ctx),
+			statusID,
+		)
+		if err != nil {
+			log.Errorf(ctx, "db error getting quoting status %s: %v", statusID, err)
+			return
+		}
+
+		if status.QuoteOfURI != quoteURI.String() ||
+			status.QuoteOfID != "" {
+			// Quote changed or
+			// was already linked.
+			return
+		}
+
+		// Quote checks need the quoting account.
+		status.Account, err = d.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			status.AccountID,
+		)
+		if err != nil {
+			log.Errorf(ctx, "db error getting quoting account %s: %v", status.AccountID, err)
+			return
+		}
+
+		// Check whether the quote is permitted.
+		status.QuoteOfURI = quoteOf.URI
+		status.QuoteOfID = quoteOf.ID
+		status.QuoteOf = quoteOf
+		status.QuoteOfAccountID = quoteOf.AccountID
+		status.QuoteOfAccount = quoteOf.Account
+		if err := d.checkStatusQuote(ctx, requestUser, status); err != nil {
+			log.Errorf(ctx, "error checking quote for status %s: %v", statusID, err)
+			return
+		}
+
+		if err := d.state.DB.UpdateStatus(ctx, status,
+			"quote_of_id",
+			"quote_of_uri",
+			"quote_of_account_id",
+			"quote_pending_approval",
+			"quote_approved_by_uri",
+		); err != nil {
+			log.Errorf(ctx, "db error updating quoting status %s: %v", statusID, err)
+		}
+	})
+}
+
+// unlinkQuote removes the quote from the given
+// status, leaving the rest of the status intact.
+func unlinkQuote(status *gtsmodel.Status) {
+	status.QuoteOfID = ""
+	status.QuoteOfURI = ""
+	status.QuoteOfAccountID = ""
+	status.QuoteOf = nil
+	status.QuoteOfAccount = nil
+	status.QuotePendingApproval = util.Ptr(false)
+	status.QuoteApprovedByURI = ""
+}
+
 // isValidAuthURI dereferences the activitystreams Accept or authorization
 // at the specified IRI, and checks it for validity against the provided
 // expectedActor, expectedObject, and expectedTarget.
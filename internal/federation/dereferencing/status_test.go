@@ -19,10 +19,12 @@
 
 import (
 	"fmt"
+	"net/url"
 	"testing"
 	"time"
 
 	"code.superseriousbusiness.org/activity/streams"
+	"code.superseriousbusiness.org/activity/streams/vocab"
 	"code.superseriousbusiness.org/gotosocial/internal/ap"
 	"code.superseriousbusiness.org/gotosocial/internal/db"
 	"code.superseriousbusiness.org/gotosocial/internal/federation/dereferencing"
@@ -440,6 +442,141 @@ func (suite *StatusTestSuite) TestDereferencerRefreshStatusRace() {
 
 // editStatusable updates the given statusable attributes.
 // note that this acts on the original object, no copying.
+func (suite *StatusTestSuite) TestDereferenceRemoteQuoteManualApproval() {
+	var (
+		ctx             = suite.T().Context()
+		fetchingAccount = suite.testAccounts["local_account_1"]
+	)
+
+	// Remote status whose author requires
+	// manual approval for anyone to quote it.
+	quoteOf, err := suite.db.GetStatusByURI(ctx, "http://fossbros-anonymous.io/users/foss_satan/statuses/01FVW7JHQFSFK166WWKR8CBA6M")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	quoteOf.InteractionPolicy = &gtsmodel.InteractionPolicy{
+		CanQuote: gtsmodel.DefaultCanQuoteFor(gtsmodel.VisibilityPublic),
+	}
+	if err := suite.db.UpdateStatus(ctx, quoteOf, "interaction_policy"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	for _, testCase := range []struct {
+		uri       string
+		quoteAuth *url.URL
+	}{
+		{
+			// No quote authorization at all.
+			uri: "https://unknown-instance.com/users/brand_new_person/statuses/01JBZ6QJ8F5W7DC4Y5M2X3Q1RA",
+		},
+		{
+			// Quote authorization that
+			// can't be dereferenced.
+			uri:       "https://unknown-instance.com/users/brand_new_person/statuses/01JBZ6QZ1D3EGB6Y3C7E4V8N2K",
+			quoteAuth: testrig.URLMustParse("http://fossbros-anonymous.io/users/foss_satan/quote_authorizations/01JBZ6RAWN6W1TQ0K7G2M3PZ5S"),
+		},
+	} {
+		suite.client.TestRemoteStatuses[testCase.uri] = suite.quotingStatusable(
+			testCase.uri,
+			quoteOf.URI,
+			testCase.quoteAuth,
+		)
+
+		status, _, err := suite.dereferencer.GetStatusByURI(ctx,
+			fetchingAccount.Username,
+			testrig.URLMustParse(testCase.uri),
+		)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		// Quoting needs approval, which hasn't been
+		// verifiably given: the quote should be pending.
+		suite.Equal(quoteOf.ID, status.QuoteOfID)
+		suite.True(*status.QuotePendingApproval)
+		suite.Empty(status.QuoteApprovedByURI)
+	}
+}
+
+func (suite *StatusTestSuite) TestDereferenceQuoteOfNotStored() {
+	var (
+		ctx             = suite.T().Context()
+		fetchingAccount = suite.testAccounts["local_account_1"]
+		uri             = "https://unknown-instance.com/users/brand_new_person/statuses/01JBZ6SK7R9HGN2B0QF4T6W8YE"
+		quoteOfURI      = "https://turnip.farm/users/turniplover6969/statuses/70c53e54-3146-42d5-a630-83c8b6c7c042"
+	)
+
+	suite.client.TestRemoteStatuses[uri] = suite.quotingStatusable(uri, quoteOfURI, nil)
+
+	status, _, err := suite.dereferencer.GetStatusByURI(ctx,
+		fetchingAccount.Username,
+		testrig.URLMustParse(uri),
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Quoted status isn't stored
+	// yet, so can't be linked yet.
+	suite.Equal(quoteOfURI, status.QuoteOfURI)
+	suite.Empty(status.QuoteOfID)
+
+	// Run the queued dereference jobs,
+	// which should find the quoting
+	// status stored and link the quote.
+	for {
+		fn, ok := suite.state.Workers.Dereference.Queue.Pop()
+		if !ok {
+			break
+		}
+		fn(ctx)
+	}
+
+	quoteOf, err := suite.db.GetStatusByURI(ctx, quoteOfURI)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbStatus, err := suite.db.GetStatusByID(ctx, status.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(quoteOf.ID, dbStatus.QuoteOfID)
+	suite.Equal(quoteOf.AccountID, dbStatus.QuoteOfAccountID)
+
+	// Quoted status has no canQuote
+	// policy, so quote is permitted.
+	suite.False(*dbStatus.QuotePendingApproval)
+}
+
+// quotingStatusable returns a new public status
+// by brand_new_person with the given URI, quoting
+// quoteOfURI, optionally with a quoteAuthorization.
+func (suite *StatusTestSuite) quotingStatusable(
+	uri string,
+	quoteOfURI string,
+	quoteAuth *url.URL,
+) vocab.ActivityStreamsNote {
+	note := testrig.NewAPNote(&testrig.NewAPNoteParams{
+		ID:           testrig.URLMustParse(uri),
+		CreatedAt:    testrig.TimeMustParse("2024-11-01T10:00:00Z"),
+		Content:      "look at this!",
+		AttributedTo: testrig.URLMustParse("https://unknown-instance.com/users/brand_new_person"),
+		To:           []*url.URL{ap.PublicIRI()},
+	})
+
+	ap.NormalizeIncomingQuote(note, map[string]interface{}{
+		"quoteUri": quoteOfURI,
+	})
+
+	if quoteAuth != nil {
+		ap.SetQuoteAuthorization(note, quoteAuth)
+	}
+
+	return note
+}
+
 func (suite *StatusTestSuite) editStatusable(
 	statusable ap.Statusable,
 	content string,
@@ -30,7 +30,6 @@
 	"code.superseriousbusiness.org/gotosocial/internal/ap"
 	apiutil "code.superseriousbusiness.org/gotosocial/internal/api/util"
 	"code.superseriousbusiness.org/gotosocial/internal/config"
-	"code.superseriousbusiness.org/gotosocial/internal/db"
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"code.superseriousbusiness.org/gotosocial/internal/log"
 	"code.superseriousbusiness.org/gotosocial/internal/uris"
@@ -43,6 +42,7 @@
 type federatingActor struct {
 	sideEffectActor pub.DelegateActor
 	wrapped         pub.FederatingActor
+	federator       *Federator
 }
 
 func deliveryRecipientPreSort(actorAndCollectionIRIs []*url.URL) []*url.URL {
@@ -94,8 +94,8 @@ func(a *url.URL, b *url.URL) int {
 }
 
 // newFederatingActor returns a federatingActor.
-func newFederatingActor(c pub.CommonBehavior, s2s pub.FederatingProtocol, db pub.Database, clock pub.Clock) pub.FederatingActor {
-	sideEffectActor := pub.NewSideEffectActor(c, s2s, nil, db, clock)
+func newFederatingActor(f *Federator, db pub.Database, clock pub.Clock) pub.FederatingActor {
+	sideEffectActor := pub.NewSideEffectActor(f, f, nil, db, clock)
 
 	// Hook in our own custom Serialize function.
 	sideEffectActor.Serialize = ap.Serialize
@@ -106,6 +106,7 @@ func newFederatingActor(c pub.CommonBehavior, s2s pub.FederatingProtocol, db pub
 	return &federatingActor{
 		sideEffectActor: sideEffectActor,
 		wrapped:         pub.NewCustomActor(sideEffectActor, false, true, clock),
+		federator:       f,
 	}
 }
 
@@ -220,6 +221,16 @@ func (f *federatingActor) PostInboxScheme(ctx context.Context, w http.ResponseWr
 		return u
 	}()
 
+	// Determine whether this activity should be forwarded
+	// before processing it, as side effects (eg., of a Delete)
+	// may remove the models needed to determine this, and the
+	// activity ID will be marked as seen once it's processed.
+	forwardTo, err := f.federator.forwardingTargets(ctx, activity)
+	if err != nil {
+		// Failed inbox forwarding is not a show-stopper.
+		l.Warnf("error determining inbox forwarding targets: %v", err)
+	}
+
 	// At this point we have everything we need, and have verified that
 	// the POST request is authentic (properly signed) and authorized
 	// (permitted to interact with the target inbox).
@@ -254,34 +265,11 @@ func (f *federatingActor) PostInboxScheme(ctx context.Context, w http.ResponseWr
 		return false, gtserror.NewErrorInternalError(err)
 	}
 
-	// Side effects are complete. Now delegate determining whether
-	// to do inbox forwarding, as well as the action to do it.
-	if err := f.sideEffectActor.InboxForwarding(ctx, inboxID, activity); err != nil {
-		// As a not-ideal side-effect, InboxForwarding will try
-		// to create entries if the federatingDB returns `false`
-		// when calling `Exists()` to determine whether the Activity
-		// is in the database.
-		//
-		// Since our `Exists()` function currently *always*
-		// returns false, it will *always* attempt to parse
-		// out and insert the Activity, trying to fetch other
-		// items from the DB in the process, which may or may
-		// not exist yet. Therefore, we should expect some
-		// errors coming from this function, and only warn log
-		// on certain ones.
-		//
-		// This check may be removed when the `Exists()` func
-		// is updated, and/or federating callbacks are handled
-		// properly.
-		if !errorsv2.IsV2(
-			err,
-			db.ErrAlreadyExists,
-			db.ErrNoEntries,
-		) {
-			// Failed inbox forwarding is not a show-stopper,
-			// and doesn't even necessarily denote a real error.
-			l.Warnf("error calling sideEffectActor.InboxForwarding: %v", err)
-		}
+	// Side effects are complete, forward the activity
+	// on to the followers of any local thread owners.
+	if err := f.federator.forwardActivity(ctx, activity, forwardTo); err != nil {
+		// Failed inbox forwarding is not a show-stopper.
+		l.Warnf("error forwarding activity: %v", err)
 	}
 
 	// Request is now undergoing processing. Caller
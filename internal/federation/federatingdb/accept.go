@@ -182,6 +182,22 @@ func (f *DB) Accept(ctx context.Context, accept vocab.ActivityStreamsAccept) err
 			}
 
 		} else if object.IsIRI() {
+			// Check if this is a response to our relay
+			// Follow, which may not match a follow path.
+			isRelay, err := f.relayFollowResponse(ctx,
+				object.GetIRI().String(),
+				receivingAcct,
+				requestingAcct,
+				gtsmodel.RelayStateAccepted,
+			)
+			if err != nil {
+				return err
+			}
+
+			if isRelay {
+				continue
+			}
+
 			// Check and handle any
 			// IRI type objects.
 			switch objIRI := object.GetIRI(); {
@@ -238,6 +254,19 @@ func (f *DB) acceptFollowType(
 	receivingAcct *gtsmodel.Account,
 	requestingAcct *gtsmodel.Account,
 ) error {
+	// Check if this is a response
+	// to our relay Follow instead.
+	if followIRI := ap.GetJSONLDId(asType); followIRI != nil {
+		if isRelay, err := f.relayFollowResponse(ctx,
+			followIRI.String(),
+			receivingAcct,
+			requestingAcct,
+			gtsmodel.RelayStateAccepted,
+		); isRelay || err != nil {
+			return err
+		}
+	}
+
 	// Cast the vocab.Type object to known AS type.
 	asFollow := asType.(vocab.ActivityStreamsFollow)
 
@@ -450,6 +479,33 @@ func (f *DB) acceptStoredStatus(
 	unlock := f.state.FedLocks.Lock(status.URI)
 	defer unlock()
 
+	// If the status quotes a status of the
+	// requester and is pending approval of
+	// the quote, mark the quote as approved.
+	if util.PtrOrValue(status.QuotePendingApproval, false) &&
+		status.QuoteOfAccountID == requestingAcct.ID {
+		status.QuotePendingApproval = util.Ptr(false)
+		status.QuoteApprovedByURI = acceptID.String()
+		if err := f.state.DB.UpdateStatus(ctx,
+			status,
+			"quote_pending_approval",
+			"quote_approved_by_uri",
+		); err != nil {
+			err := gtserror.Newf("db error accepting quote: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		// Send the now-approved quote through to the
+		// fedi worker again to process side effects.
+		f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+			APObjectType:   ap.ActivityQuoteRequest,
+			APActivityType: ap.ActivityAccept,
+			GTSModel:       status,
+			Receiving:      receivingAcct,
+			Requesting:     requestingAcct,
+		})
+	}
+
 	pendingApproval := util.PtrOrValue(status.PendingApproval, false)
 	if !pendingApproval {
 		// Status doesn't need approval or it's
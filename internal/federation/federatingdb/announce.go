@@ -60,6 +60,23 @@ func (f *DB) Announce(ctx context.Context, announce vocab.ActivityStreamsAnnounc
 		)
 	}
 
+	// Check if this Announce was sent
+	// by a relay we're subscribed to.
+	isRelay, err := f.isAcceptedRelay(ctx, requestingAcct)
+	if err != nil {
+		return err
+	}
+
+	if isRelay {
+		// Handle relayed posts.
+		f.relayAnnounce(ctx,
+			announce,
+			receivingAcct,
+			requestingAcct,
+		)
+		return nil
+	}
+
 	boost, isNew, err := f.converter.ASAnnounceToStatus(ctx, announce)
 	if err != nil {
 		return gtserror.Newf("error converting announce to boost: %w", err)
@@ -281,18 +281,32 @@ func (f *DB) createStatusable(
 	statusable ap.Statusable,
 	forwarded bool,
 ) error {
-	// Check for spam / relevance.
-	ok, err := f.statusableOK(ctx, receiver, requester, statusable)
-	if err != nil {
-		// Error already
-		// wrapped.
-		return err
+	// Posts forwarded by a relay we're subscribed to
+	// are public posts shared with the relay, so won't
+	// usually be relevant to the receiver: don't drop them.
+	var relayed bool
+	if forwarded {
+		var err error
+		relayed, err = f.isAcceptedRelay(ctx, requester)
+		if err != nil {
+			return err
+		}
 	}
 
-	if !ok {
-		// Not relevant / spam.
-		// Already logged.
-		return nil
+	if !relayed {
+		// Check for spam / relevance.
+		ok, err := f.statusableOK(ctx, receiver, requester, statusable)
+		if err != nil {
+			// Error already
+			// wrapped.
+			return err
+		}
+
+		if !ok {
+			// Not relevant / spam.
+			// Already logged.
+			return nil
+		}
 	}
 
 	// If we do have a forward, we should ignore the content
@@ -50,6 +50,7 @@
 	Announce(context.Context, vocab.ActivityStreamsAnnounce) error
 	Move(context.Context, vocab.ActivityStreamsMove) error
 	Flag(context.Context, vocab.ActivityStreamsFlag) error
+	EmojiReact(context.Context, vocab.LitePubEmojiReact) error
 
 	/*
 		Extra/convenience functionality.
@@ -48,10 +48,8 @@ func (f *DB) Delete(ctx context.Context, id *url.URL) error {
 	requesting := activityContext.requestingAcct
 	receiving := activityContext.receivingAcct
 
-	// Serialize deleted ID URI.
-	// (may be status OR account)
-	uriStr := id.String()
-
+	// NOTE: deleted ID URI may
+	// be status OR account URI.
 	var (
 		ok  bool
 		err error
@@ -61,7 +59,7 @@ func (f *DB) Delete(ctx context.Context, id *url.URL) error {
 	ok, err = f.deleteAccount(ctx,
 		requesting,
 		receiving,
-		uriStr,
+		id,
 	)
 	if err != nil {
 		return err
@@ -74,7 +72,7 @@ func (f *DB) Delete(ctx context.Context, id *url.URL) error {
 	ok, err = f.deleteStatus(ctx,
 		requesting,
 		receiving,
-		uriStr,
+		id,
 	)
 	if err != nil {
 		return err
@@ -83,7 +81,7 @@ func (f *DB) Delete(ctx context.Context, id *url.URL) error {
 		return nil
 	}
 
-	log.Debugf(ctx, "unknown iri: %s", uriStr)
+	log.Debugf(ctx, "unknown iri: %s", id)
 	return nil
 }
 
@@ -91,23 +89,42 @@ func (f *DB) deleteAccount(
 	ctx context.Context,
 	requesting *gtsmodel.Account,
 	receiving *gtsmodel.Account,
-	uri string, // target account
+	id *url.URL, // target account
 ) (
 	bool, // success?
 	error, // any error
 ) {
-	account, err := f.state.DB.GetAccountByURI(ctx, uri)
+	account, err := f.state.DB.GetAccountByURI(ctx, id.String())
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
 		return false, gtserror.Newf("error getting account: %w", err)
 	}
 
 	if account != nil {
-		// Ensure requesting account is
+		// A remote actor can never legitimately
+		// delete one of our own local accounts.
+		if account.IsLocal() {
+			log.Warnf(ctx, "dropping delete of local account %s by %s", account.URI, requesting.URI)
+			return true, nil
+		}
+
+		// Check if requesting account is
 		// only trying to delete itself.
 		if account.ID != requesting.ID {
 
-			// TODO: handled forwarded deletes,
-			// for now we silently drop this.
+			// This is a forwarded delete, which we can't
+			// trust on its own. Pass the account URI into
+			// the processor worker to verify with origin
+			// server that the account is really gone.
+			log.Debugf(ctx, "verifying forwarded account delete: %s", account.URI)
+			f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+				APObjectType:   ap.ActorPerson,
+				APActivityType: ap.ActivityDelete,
+				APIRI:          id,
+				GTSModel:       account,
+				Receiving:      receiving,
+				Requesting:     requesting,
+			})
+
 			return true, nil
 		}
 
@@ -130,23 +147,42 @@ func (f *DB) deleteStatus(
 	ctx context.Context,
 	requesting *gtsmodel.Account,
 	receiving *gtsmodel.Account,
-	uri string, // target status
+	id *url.URL, // target status
 ) (
 	bool, // success?
 	error, // any error
 ) {
-	status, err := f.state.DB.GetStatusByURI(ctx, uri)
+	status, err := f.state.DB.GetStatusByURI(ctx, id.String())
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
 		return false, gtserror.Newf("error getting status: %w", err)
 	}
 
 	if status != nil {
-		// Ensure requesting account is only
+		// A remote actor can never legitimately
+		// delete one of our own local statuses.
+		if status.IsLocal() {
+			log.Warnf(ctx, "dropping delete of local status %s by %s", status.URI, requesting.URI)
+			return true, nil
+		}
+
+		// Check if requesting account is only
 		// trying to delete its own statuses.
 		if status.AccountID != requesting.ID {
 
-			// TODO: handled forwarded deletes,
-			// for now we silently drop this.
+			// This is a forwarded delete, which we can't
+			// trust on its own. Pass the status URI into
+			// the processor worker to verify with origin
+			// server that the status is really gone.
+			log.Debugf(ctx, "verifying forwarded status delete: %s", status.URI)
+			f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+				APObjectType:   ap.ObjectNote,
+				APActivityType: ap.ActivityDelete,
+				APIRI:          id,
+				GTSModel:       status,
+				Receiving:      receiving,
+				Requesting:     requesting,
+			})
+
 			return true, nil
 		}
 
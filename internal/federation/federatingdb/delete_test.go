@@ -0,0 +1,84 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb_test
+
+import (
+	"testing"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/ap"
+	"code.superseriousbusiness.org/gotosocial/testrig"
+	"github.com/stretchr/testify/suite"
+)
+
+type DeleteTestSuite struct {
+	FederatingDBTestSuite
+}
+
+func (suite *DeleteTestSuite) TestDeleteForwardedRemoteStatus() {
+	receivingAccount := suite.testAccounts["local_account_1"]
+	requestingAccount := suite.testAccounts["remote_account_2"]
+	status := suite.testStatuses["remote_account_1_status_1"]
+
+	ctx := createTestContext(suite.T(), receivingAccount, requestingAccount)
+
+	err := suite.federatingDB.Delete(ctx, testrig.URLMustParse(status.URI))
+	suite.NoError(err)
+
+	// Forwarded delete should be passed to
+	// the processor for verification with origin.
+	msg, ok := suite.getFederatorMsg(5 * time.Second)
+	suite.True(ok)
+	suite.Equal(ap.ObjectNote, msg.APObjectType)
+	suite.Equal(ap.ActivityDelete, msg.APActivityType)
+	suite.Equal(status.URI, msg.APIRI.String())
+}
+
+func (suite *DeleteTestSuite) TestDeleteLocalStatus() {
+	receivingAccount := suite.testAccounts["local_account_2"]
+	requestingAccount := suite.testAccounts["remote_account_1"]
+	status := suite.testStatuses["local_account_1_status_1"]
+
+	ctx := createTestContext(suite.T(), receivingAccount, requestingAccount)
+
+	err := suite.federatingDB.Delete(ctx, testrig.URLMustParse(status.URI))
+	suite.NoError(err)
+
+	// Delete of local status should be dropped.
+	_, ok := suite.getFederatorMsg(time.Second)
+	suite.False(ok)
+}
+
+func (suite *DeleteTestSuite) TestDeleteLocalAccount() {
+	receivingAccount := suite.testAccounts["local_account_2"]
+	requestingAccount := suite.testAccounts["remote_account_1"]
+	account := suite.testAccounts["local_account_1"]
+
+	ctx := createTestContext(suite.T(), receivingAccount, requestingAccount)
+
+	err := suite.federatingDB.Delete(ctx, testrig.URLMustParse(account.URI))
+	suite.NoError(err)
+
+	// Delete of local account should be dropped.
+	_, ok := suite.getFederatorMsg(time.Second)
+	suite.False(ok)
+}
+
+func TestDeleteTestSuite(t *testing.T) {
+	suite.Run(t, &DeleteTestSuite{})
+}
@@ -0,0 +1,190 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"code.superseriousbusiness.org/activity/streams/vocab"
+	"code.superseriousbusiness.org/gotosocial/internal/ap"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/messages"
+	"code.superseriousbusiness.org/gotosocial/internal/validate"
+)
+
+func (f *DB) EmojiReact(ctx context.Context, react vocab.LitePubEmojiReact) error {
+	log.DebugKV(ctx, "emojireact", serialize{react})
+
+	// Mark activity as handled.
+	f.storeActivityID(react)
+
+	// Extract relevant values from passed ctx.
+	activityContext := getActivityContext(ctx)
+	if activityContext.internal {
+		return nil // Already processed.
+	}
+
+	requesting := activityContext.requestingAcct
+	receiving := activityContext.receivingAcct
+
+	if requesting.IsMoving() {
+		// A Moving account
+		// can't do this.
+		return nil
+	}
+
+	return f.emojiReact(ctx, react, requesting, receiving)
+}
+
+// isEmojiReaction returns whether the given
+// Like is actually an emoji reaction, as sent
+// by eg., Misskey, ie., it has content set.
+func isEmojiReaction(like vocab.ActivityStreamsLike) bool {
+	return ap.ExtractContent(like).Content != ""
+}
+
+// emojiReact handles an emoji reaction received either
+// as an EmojiReact activity, or as a Like with content.
+func (f *DB) emojiReact(
+	ctx context.Context,
+	reactable ap.EmojiReactable,
+	requesting *gtsmodel.Account,
+	receiving *gtsmodel.Account,
+) error {
+	// Convert received AS type to internal reaction model.
+	reaction, err := f.converter.ASEmojiReactToStatusReaction(ctx, reactable)
+	if err != nil {
+		err := gtserror.Newf("error converting from AS type: %w", err)
+		return gtserror.WrapWithCode(http.StatusBadRequest, err)
+	}
+
+	// Ensure reaction enacted by correct account.
+	if reaction.AccountID != requesting.ID {
+		return gtserror.NewfWithCode(http.StatusForbidden, "requester %s is not expected actor %s",
+			requesting.URI, reaction.Account.URI)
+	}
+
+	// Ensure reaction received by correct account.
+	if reaction.TargetAccountID != receiving.ID {
+		return gtserror.NewfWithCode(http.StatusForbidden, "receiver %s is not expected object %s",
+			receiving.URI, reaction.TargetAccount.URI)
+	}
+
+	if !*reaction.Status.Local {
+		// Only process reactions to local statuses.
+		return nil
+	}
+
+	if reaction.Emoji == nil {
+		// Ensure unicode reactions are a single emoji.
+		if err := validate.EmojiReaction(reaction.Name); err != nil {
+			return gtserror.WrapWithCode(http.StatusBadRequest, err)
+		}
+	}
+
+	// Ensure valid reaction target for requester;
+	// reactions are treated in the same way as
+	// likes, but can't be pending approval.
+	policyResult, err := f.intFilter.StatusLikeable(ctx,
+		requesting,
+		reaction.Status,
+	)
+	if err != nil {
+		return gtserror.Newf("error seeing if status %s is likeable: %w", reaction.Status.URI, err)
+	}
+
+	if policyResult.Forbidden() || policyResult.ManualApproval() {
+		return gtserror.NewWithCode(http.StatusForbidden, "requester does not have permission to react to status")
+	}
+
+	// Set new ID, and pass the reaction
+	// to the processor to dereference any
+	// custom emoji and store it in the db.
+	reaction.ID = id.NewULID()
+
+	f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+		APObjectType:   ap.ActivityEmojiReact,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       reaction,
+		Receiving:      receiving,
+		Requesting:     requesting,
+	})
+
+	return nil
+}
+
+func (f *DB) undoEmojiReact(
+	ctx context.Context,
+	requestingAcct *gtsmodel.Account,
+	undo vocab.ActivityStreamsUndo,
+	reactable ap.EmojiReactable,
+) error {
+	// Make sure the Undo
+	// actor owns the target.
+	if !sameActor(
+		undo.GetActivityStreamsActor(),
+		reactable.GetActivityStreamsActor(),
+	) {
+		// Ignore this Activity.
+		return nil
+	}
+
+	uri := ap.GetJSONLDId(reactable)
+	if uri == nil {
+		err := gtserror.New("unusable iri property")
+		return gtserror.SetMalformed(err)
+	}
+
+	// Fetch reaction from the DB by its URI.
+	reaction, err := f.state.DB.GetStatusReactionByURI(
+		gtscontext.SetBarebones(ctx),
+		uri.String(),
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting reaction %s: %w", uri, err)
+	}
+
+	if reaction == nil {
+		// We didn't have this reaction
+		// stored anyway, so we can't
+		// Undo it, just ignore.
+		return nil
+	}
+
+	// Ensure requester is reaction origin.
+	if reaction.AccountID != requestingAcct.ID {
+		const text = "requestingAcct was not reaction origin"
+		return gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	// Delete the reaction. No other side
+	// effects are necessary, so there's
+	// no need to pass this to the processor.
+	if err := f.state.DB.DeleteStatusReactionByID(ctx, reaction.ID); err != nil {
+		return gtserror.Newf("db error deleting reaction %s: %w", reaction.ID, err)
+	}
+
+	return nil
+}
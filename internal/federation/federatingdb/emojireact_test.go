@@ -0,0 +1,149 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"code.superseriousbusiness.org/activity/streams"
+	"code.superseriousbusiness.org/gotosocial/internal/ap"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/testrig"
+	"github.com/stretchr/testify/suite"
+)
+
+type EmojiReactTestSuite struct {
+	FederatingDBTestSuite
+}
+
+func (suite *EmojiReactTestSuite) TestEmojiReact() {
+	var (
+		receivingAccount  = suite.testAccounts["local_account_1"]
+		requestingAccount = suite.testAccounts["remote_account_1"]
+		targetStatus      = suite.testStatuses["local_account_1_status_1"]
+		ctx               = createTestContext(suite.T(), receivingAccount, requestingAccount)
+	)
+
+	react := streams.NewLitePubEmojiReact()
+	ap.SetJSONLDIdStr(react, "http://fossbros-anonymous.io/reactions/01JTGB3KM0P8NS6VWS3RNVXG5B")
+	ap.AppendActorIRIs(react, testrig.URLMustParse(requestingAccount.URI))
+	ap.AppendObjectIRIs(react, testrig.URLMustParse(targetStatus.URI))
+	ap.AppendTo(react, testrig.URLMustParse(receivingAccount.URI))
+	ap.AppendContent(react, "🐢")
+
+	if err := suite.federatingDB.EmojiReact(ctx, react); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Should be a message heading to the processor now.
+	msg, ok := suite.getFederatorMsg(5 * time.Second)
+	if !ok {
+		suite.FailNow("no message queued")
+	}
+	suite.Equal(ap.ActivityEmojiReact, msg.APObjectType)
+	suite.Equal(ap.ActivityCreate, msg.APActivityType)
+
+	reaction, ok := msg.GTSModel.(*gtsmodel.StatusReaction)
+	if !ok {
+		suite.FailNow("", "%T not *gtsmodel.StatusReaction", msg.GTSModel)
+	}
+	suite.Equal("🐢", reaction.Name)
+	suite.Equal(requestingAccount.ID, reaction.AccountID)
+	suite.Equal(receivingAccount.ID, reaction.TargetAccountID)
+	suite.Equal(targetStatus.ID, reaction.StatusID)
+	suite.Nil(reaction.Emoji)
+}
+
+func (suite *EmojiReactTestSuite) TestLikeWithContent() {
+	var (
+		receivingAccount  = suite.testAccounts["local_account_1"]
+		requestingAccount = suite.testAccounts["remote_account_1"]
+		targetStatus      = suite.testStatuses["local_account_1_status_1"]
+		ctx               = createTestContext(suite.T(), receivingAccount, requestingAccount)
+	)
+
+	// Misskey-style Like with a custom emoji reaction.
+	like := streams.NewActivityStreamsLike()
+	ap.SetJSONLDIdStr(like, "http://fossbros-anonymous.io/likes/01JTGB3KM0P8NS6VWS3RNVXG5B")
+	ap.AppendActorIRIs(like, testrig.URLMustParse(requestingAccount.URI))
+	ap.AppendObjectIRIs(like, testrig.URLMustParse(targetStatus.URI))
+	ap.AppendContent(like, ":blobcat:")
+
+	emoji := streams.NewTootEmoji()
+	ap.SetJSONLDIdStr(emoji, "http://fossbros-anonymous.io/emoji/blobcat")
+	ap.AppendName(emoji, ":blobcat:")
+	icon := streams.NewActivityStreamsImage()
+	iconURL := streams.NewActivityStreamsUrlProperty()
+	iconURL.AppendIRI(&url.URL{Scheme: "http", Host: "fossbros-anonymous.io", Path: "/emoji/blobcat.png"})
+	icon.SetActivityStreamsUrl(iconURL)
+	iconProp := streams.NewActivityStreamsIconProperty()
+	iconProp.AppendActivityStreamsImage(icon)
+	emoji.SetActivityStreamsIcon(iconProp)
+	tagProp := streams.NewActivityStreamsTagProperty()
+	tagProp.AppendTootEmoji(emoji)
+	like.SetActivityStreamsTag(tagProp)
+
+	if err := suite.federatingDB.Like(ctx, like); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Should be a reaction heading to the processor, not a fave.
+	msg, ok := suite.getFederatorMsg(5 * time.Second)
+	if !ok {
+		suite.FailNow("no message queued")
+	}
+	suite.Equal(ap.ActivityEmojiReact, msg.APObjectType)
+
+	reaction, ok := msg.GTSModel.(*gtsmodel.StatusReaction)
+	if !ok {
+		suite.FailNow("", "%T not *gtsmodel.StatusReaction", msg.GTSModel)
+	}
+	suite.Equal("blobcat@fossbros-anonymous.io", reaction.Name)
+	suite.NotNil(reaction.Emoji)
+	suite.Equal("blobcat", reaction.Emoji.Shortcode)
+	suite.Equal("fossbros-anonymous.io", reaction.Emoji.Domain)
+	suite.Equal("http://fossbros-anonymous.io/emoji/blobcat.png", reaction.Emoji.ImageRemoteURL)
+}
+
+func (suite *EmojiReactTestSuite) TestEmojiReactInvalid() {
+	var (
+		receivingAccount  = suite.testAccounts["local_account_1"]
+		requestingAccount = suite.testAccounts["remote_account_1"]
+		targetStatus      = suite.testStatuses["local_account_1_status_1"]
+		ctx               = createTestContext(suite.T(), receivingAccount, requestingAccount)
+	)
+
+	// Not a single emoji.
+	react := streams.NewLitePubEmojiReact()
+	ap.SetJSONLDIdStr(react, "http://fossbros-anonymous.io/reactions/01JTGB3KM0P8NS6VWS3RNVXG5C")
+	ap.AppendActorIRIs(react, testrig.URLMustParse(requestingAccount.URI))
+	ap.AppendObjectIRIs(react, testrig.URLMustParse(targetStatus.URI))
+	ap.AppendContent(react, "lol 🐢🐢")
+
+	err := suite.federatingDB.EmojiReact(ctx, react)
+	suite.ErrorContains(err, "did not pass validation")
+
+	_, ok := suite.getFederatorMsg(time.Second)
+	suite.False(ok)
+}
+
+func TestEmojiReactTestSuite(t *testing.T) {
+	suite.Run(t, &EmojiReactTestSuite{})
+}
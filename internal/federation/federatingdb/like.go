@@ -52,6 +52,12 @@ func (f *DB) Like(ctx context.Context, likeable vocab.ActivityStreamsLike) error
 		return nil
 	}
 
+	if isEmojiReaction(likeable) {
+		// Likes with content are emoji
+		// reactions, handle them as such.
+		return f.emojiReact(ctx, likeable, requesting, receiving)
+	}
+
 	// Convert received AS like type to internal fave model.
 	fave, err := f.converter.ASLikeToFave(ctx, likeable)
 	if err != nil {
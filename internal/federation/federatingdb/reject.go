@@ -74,6 +74,22 @@ func (f *DB) Reject(ctx context.Context, reject vocab.ActivityStreamsReject) err
 			}
 
 		} else if object.IsIRI() {
+			// Check if this is a response to our relay
+			// Follow, which may not match a follow path.
+			isRelay, err := f.relayFollowResponse(ctx,
+				object.GetIRI().String(),
+				receivingAcct,
+				requestingAcct,
+				gtsmodel.RelayStateRejected,
+			)
+			if err != nil {
+				return err
+			}
+
+			if isRelay {
+				continue
+			}
+
 			// Check and handle any
 			// IRI type objects.
 			switch objIRI := object.GetIRI(); {
@@ -129,6 +145,19 @@ func (f *DB) rejectFollowType(
 	receivingAcct *gtsmodel.Account,
 	requestingAcct *gtsmodel.Account,
 ) error {
+	// Check if this is a response
+	// to our relay Follow instead.
+	if followIRI := ap.GetJSONLDId(asType); followIRI != nil {
+		if isRelay, err := f.relayFollowResponse(ctx,
+			followIRI.String(),
+			receivingAcct,
+			requestingAcct,
+			gtsmodel.RelayStateRejected,
+		); isRelay || err != nil {
+			return err
+		}
+	}
+
 	// Cast the vocab.Type object to known AS type.
 	asFollow := asType.(vocab.ActivityStreamsFollow)
 
@@ -260,6 +289,21 @@ func (f *DB) rejectStatusIRI(
 		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
 	}
 
+	// Check if the requester is Rejecting a quote
+	// of one of their statuses, rather than a reply
+	// to or boost of one of their statuses.
+	if status.QuoteOfAccountID == requestingAcct.ID &&
+		status.InReplyToAccountID != requestingAcct.ID &&
+		status.BoostOfAccountID != requestingAcct.ID {
+		return f.rejectQuote(
+			ctx,
+			activityID,
+			status,
+			receivingAcct,
+			requestingAcct,
+		)
+	}
+
 	// Check if we're dealing with a reply
 	// or an announce, and make sure the
 	// requester is permitted to Reject.
@@ -371,6 +415,84 @@ func (f *DB) rejectStatusIRI(
 	return nil
 }
 
+// rejectQuote handles a Reject of the given local status'
+// quote of a status belonging to the requesting account.
+//
+// Caller should already hold the lock on the status URI.
+func (f *DB) rejectQuote(
+	ctx context.Context,
+	activityID string,
+	status *gtsmodel.Status,
+	receivingAcct *gtsmodel.Account,
+	requestingAcct *gtsmodel.Account,
+) error {
+	// Check if there's an interaction request in the db for this status.
+	req, err := f.state.DB.GetInteractionRequestByInteractionURI(ctx, status.URI)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting interaction request: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	switch {
+	case req == nil:
+		// No interaction request existed yet for this
+		// quote, create a pre-rejected request now.
+		req = &gtsmodel.InteractionRequest{
+			ID:                    id.NewULID(),
+			TargetStatusID:        status.QuoteOfID,
+			TargetStatus:          status.QuoteOf,
+			TargetAccountID:       requestingAcct.ID,
+			TargetAccount:         requestingAcct,
+			InteractingAccountID:  receivingAcct.ID,
+			InteractingAccount:    receivingAcct,
+			InteractionRequestURI: gtsmodel.ForwardCompatibleInteractionRequestURI(status.URI, gtsmodel.QuoteRequestSuffix),
+			InteractionURI:        status.URI,
+			InteractionType:       gtsmodel.InteractionQuote,
+			Polite:                util.Ptr(false),
+			Quote:                 status,
+			ResponseURI:           activityID,
+			RejectedAt:            time.Now(),
+		}
+
+		if err := f.state.DB.PutInteractionRequest(ctx, req); err != nil {
+			err := gtserror.Newf("db error inserting interaction request: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+
+	case req.InteractionType != gtsmodel.InteractionQuote:
+		// There's already a request for some
+		// other interaction by this status, so
+		// don't touch it, just drop the quote.
+
+	default:
+		// Mark existing interaction request as
+		// Rejected, even if previously Accepted.
+		req.AcceptedAt = time.Time{}
+		req.RejectedAt = time.Now()
+		req.ResponseURI = activityID
+		if err := f.state.DB.UpdateInteractionRequest(ctx, req,
+			"accepted_at",
+			"rejected_at",
+			"response_uri",
+		); err != nil {
+			err := gtserror.Newf("db error updating interaction request: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	// Send the rejected quote through to the
+	// fedi worker to process side effects.
+	f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+		APObjectType:   ap.ActivityQuoteRequest,
+		APActivityType: ap.ActivityReject,
+		GTSModel:       req,
+		Receiving:      receivingAcct,
+		Requesting:     requestingAcct,
+	})
+
+	return nil
+}
+
 func (f *DB) rejectLikeIRI(
 	ctx context.Context,
 	activityID string,
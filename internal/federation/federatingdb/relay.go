@@ -0,0 +1,150 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"code.superseriousbusiness.org/activity/streams/vocab"
+	"code.superseriousbusiness.org/gotosocial/internal/ap"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/messages"
+)
+
+// relayFollowResponse checks whether followIRI is that of our
+// instance actor's Follow of a relay, and if so updates the
+// relay's state from the Accept / Reject sent by requestingAcct.
+//
+// The returned bool indicates whether followIRI was a relay
+// Follow, in which case callers should not process it further.
+func (f *DB) relayFollowResponse(
+	ctx context.Context,
+	followIRI string,
+	receivingAcct *gtsmodel.Account,
+	requestingAcct *gtsmodel.Account,
+	state gtsmodel.RelayState,
+) (bool, error) {
+	if !receivingAcct.IsInstance() {
+		// Relay Follows are only
+		// sent by instance actor.
+		return false, nil
+	}
+
+	relay, err := f.state.DB.GetRelayByFollowURI(
+		gtscontext.SetBarebones(ctx),
+		followIRI,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting relay: %w", err)
+		return true, gtserror.NewErrorInternalError(err)
+	}
+
+	if relay == nil {
+		// Not a relay Follow.
+		return false, nil
+	}
+
+	// Make sure the response comes from the relay
+	// we followed, ie., the one hosting the inbox.
+	inboxURI, err := url.Parse(relay.InboxURI)
+	if err != nil {
+		err := gtserror.Newf("error parsing relay inbox uri: %w", err)
+		return true, gtserror.NewErrorInternalError(err)
+	}
+
+	if requestingAcct.Domain != inboxURI.Hostname() ||
+		(relay.AccountID != "" && relay.AccountID != requestingAcct.ID) {
+		const text = "relay Follow target and requesting account were not the same"
+		return true, gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	// Store the relay actor
+	// along with new state.
+	relay.AccountID = requestingAcct.ID
+	relay.State = state
+	if err := f.state.DB.UpdateRelay(ctx,
+		relay,
+		"account_id",
+		"state",
+	); err != nil {
+		err := gtserror.Newf("db error updating relay: %w", err)
+		return true, gtserror.NewErrorInternalError(err)
+	}
+
+	log.Infof(ctx, "relay %s is now %s", relay.InboxURI, state)
+	return true, nil
+}
+
+// isAcceptedRelay returns whether the given account is
+// the actor of a relay that has accepted our Follow.
+func (f *DB) isAcceptedRelay(ctx context.Context, account *gtsmodel.Account) (bool, error) {
+	if account.IsLocal() {
+		// Can't be a relay.
+		return false, nil
+	}
+
+	relay, err := f.state.DB.GetRelayByAccountID(
+		gtscontext.SetBarebones(ctx),
+		account.ID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, gtserror.Newf("db error getting relay: %w", err)
+	}
+
+	return relay != nil && relay.IsAccepted(), nil
+}
+
+// relayAnnounce handles an Announce from a relay. Relays
+// Announce public posts shared with them by other instances,
+// so rather than storing a boost by the relay actor, we pass
+// the URIs of announced posts to the processor to dereference
+// them from their origin, same as for forwarded posts.
+func (f *DB) relayAnnounce(
+	ctx context.Context,
+	announce vocab.ActivityStreamsAnnounce,
+	receivingAcct *gtsmodel.Account,
+	requestingAcct *gtsmodel.Account,
+) {
+	for _, object := range ap.ExtractObjects(announce) {
+		var objectIRI *url.URL
+		if object.IsIRI() {
+			objectIRI = object.GetIRI()
+		} else if t := object.GetType(); t != nil {
+			objectIRI = ap.GetJSONLDId(t)
+		}
+
+		if objectIRI == nil {
+			log.Debug(ctx, "relay Announce object had no id")
+			continue
+		}
+
+		f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityCreate,
+			APIRI:          objectIRI,
+			Receiving:      receivingAcct,
+			Requesting:     requestingAcct,
+		})
+	}
+}
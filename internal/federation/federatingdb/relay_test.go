@@ -0,0 +1,233 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"code.superseriousbusiness.org/activity/streams/vocab"
+	"code.superseriousbusiness.org/gotosocial/internal/ap"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+	"code.superseriousbusiness.org/gotosocial/testrig"
+	"github.com/stretchr/testify/suite"
+)
+
+type RelayTestSuite struct {
+	FederatingDBTestSuite
+}
+
+// putRelay puts a relay hosted on
+// foss_satan's instance in the db.
+func (suite *RelayTestSuite) putRelay(state gtsmodel.RelayState, accountID string) *gtsmodel.Relay {
+	relay := &gtsmodel.Relay{
+		ID:                 "01K7P8Y3AVB3Z5C7ECF2KQ7B8X",
+		InboxURI:           "http://fossbros-anonymous.io/inbox",
+		FollowURI:          "http://localhost:8080/users/localhost:8080/follow/01K7P8Y3AVB3Z5C7ECF2KQ7B8X",
+		AccountID:          accountID,
+		State:              state,
+		Publish:            util.Ptr(false),
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}
+
+	if err := suite.state.DB.PutRelay(suite.T().Context(), relay); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return relay
+}
+
+func (suite *RelayTestSuite) decode(json string) vocab.Type {
+	t, err := ap.DecodeType(suite.T().Context(), io.NopCloser(bytes.NewBufferString(json)))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return t
+}
+
+func (suite *RelayTestSuite) TestRelayAccept() {
+	var (
+		instanceAcct = suite.testAccounts["instance_account"]
+		relayAcct    = suite.testAccounts["remote_account_1"]
+		relay        = suite.putRelay(gtsmodel.RelayStatePending, "")
+		ctx          = createTestContext(suite.T(), instanceAcct, relayAcct)
+	)
+
+	accept := suite.decode(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "type": "Accept",
+  "id": "http://fossbros-anonymous.io/activities/accept/1",
+  "actor": "http://fossbros-anonymous.io/users/foss_satan",
+  "object": {
+    "type": "Follow",
+    "id": "http://localhost:8080/users/localhost:8080/follow/01K7P8Y3AVB3Z5C7ECF2KQ7B8X",
+    "actor": "http://localhost:8080/users/localhost:8080",
+    "object": "https://www.w3.org/ns/activitystreams#Public"
+  }
+}`).(vocab.ActivityStreamsAccept)
+
+	if err := suite.federatingDB.Accept(ctx, accept); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	relay, err := suite.state.DB.GetRelayByID(ctx, relay.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(gtsmodel.RelayStateAccepted, relay.State)
+	suite.Equal(relayAcct.ID, relay.AccountID)
+
+	// Nothing should be sent to the processor.
+	_, ok := suite.getFederatorMsg(time.Second)
+	suite.False(ok)
+}
+
+func (suite *RelayTestSuite) TestRelayReject() {
+	var (
+		instanceAcct = suite.testAccounts["instance_account"]
+		relayAcct    = suite.testAccounts["remote_account_1"]
+		relay        = suite.putRelay(gtsmodel.RelayStatePending, "")
+		ctx          = createTestContext(suite.T(), instanceAcct, relayAcct)
+	)
+
+	reject := suite.decode(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "type": "Reject",
+  "id": "http://fossbros-anonymous.io/activities/reject/1",
+  "actor": "http://fossbros-anonymous.io/users/foss_satan",
+  "object": "http://localhost:8080/users/localhost:8080/follow/01K7P8Y3AVB3Z5C7ECF2KQ7B8X"
+}`).(vocab.ActivityStreamsReject)
+
+	if err := suite.federatingDB.Reject(ctx, reject); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	relay, err := suite.state.DB.GetRelayByID(ctx, relay.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(gtsmodel.RelayStateRejected, relay.State)
+	suite.Equal(relayAcct.ID, relay.AccountID)
+}
+
+func (suite *RelayTestSuite) TestRelayAcceptWrongDomain() {
+	var (
+		instanceAcct = suite.testAccounts["instance_account"]
+		otherAcct    = suite.testAccounts["remote_account_2"]
+		relay        = suite.putRelay(gtsmodel.RelayStatePending, "")
+		ctx          = createTestContext(suite.T(), instanceAcct, otherAcct)
+	)
+
+	accept := suite.decode(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "type": "Accept",
+  "id": "http://example.org/activities/accept/1",
+  "actor": "http://example.org/users/Some_User",
+  "object": "http://localhost:8080/users/localhost:8080/follow/01K7P8Y3AVB3Z5C7ECF2KQ7B8X"
+}`).(vocab.ActivityStreamsAccept)
+
+	err := suite.federatingDB.Accept(ctx, accept)
+	suite.ErrorContains(err, "relay Follow target and requesting account were not the same")
+
+	// Relay should still be pending.
+	relay, err = suite.state.DB.GetRelayByID(ctx, relay.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(gtsmodel.RelayStatePending, relay.State)
+}
+
+func (suite *RelayTestSuite) TestRelayAnnounce() {
+	var (
+		instanceAcct = suite.testAccounts["instance_account"]
+		relayAcct    = suite.testAccounts["remote_account_1"]
+		_            = suite.putRelay(gtsmodel.RelayStateAccepted, relayAcct.ID)
+		ctx          = createTestContext(suite.T(), instanceAcct, relayAcct)
+	)
+
+	announce := suite.decode(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "type": "Announce",
+  "id": "http://fossbros-anonymous.io/activities/announce/1",
+  "actor": "http://fossbros-anonymous.io/users/foss_satan",
+  "to": "https://www.w3.org/ns/activitystreams#Public",
+  "object": "http://example.org/users/Some_User/statuses/afaba698-5740-4e32-a702-af61aa543bc1"
+}`).(vocab.ActivityStreamsAnnounce)
+
+	if err := suite.federatingDB.Announce(ctx, announce); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Announced status should be passed to the
+	// processor to dereference, not as a boost.
+	msg, ok := suite.getFederatorMsg(5 * time.Second)
+	if !ok {
+		suite.FailNow("no message in queue")
+	}
+
+	suite.Equal(ap.ObjectNote, msg.APObjectType)
+	suite.Equal(ap.ActivityCreate, msg.APActivityType)
+	suite.Nil(msg.GTSModel)
+	suite.Equal(
+		testrig.URLMustParse("http://example.org/users/Some_User/statuses/afaba698-5740-4e32-a702-af61aa543bc1"),
+		msg.APIRI,
+	)
+}
+
+func (suite *RelayTestSuite) TestPendingRelayAnnounce() {
+	var (
+		instanceAcct = suite.testAccounts["instance_account"]
+		relayAcct    = suite.testAccounts["remote_account_1"]
+		_            = suite.putRelay(gtsmodel.RelayStatePending, relayAcct.ID)
+		ctx          = createTestContext(suite.T(), instanceAcct, relayAcct)
+	)
+
+	announce := suite.decode(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "type": "Announce",
+  "id": "http://fossbros-anonymous.io/activities/announce/1",
+  "actor": "http://fossbros-anonymous.io/users/foss_satan",
+  "to": "https://www.w3.org/ns/activitystreams#Public",
+  "object": "http://example.org/users/Some_User/statuses/afaba698-5740-4e32-a702-af61aa543bc1"
+}`).(vocab.ActivityStreamsAnnounce)
+
+	if err := suite.federatingDB.Announce(ctx, announce); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Relay isn't accepted, so this
+	// should be handled as a normal boost.
+	msg, ok := suite.getFederatorMsg(5 * time.Second)
+	if !ok {
+		suite.FailNow("no message in queue")
+	}
+
+	suite.Equal(ap.ActivityAnnounce, msg.APObjectType)
+	suite.Equal(ap.ActivityCreate, msg.APActivityType)
+	_, ok = msg.GTSModel.(*gtsmodel.Status)
+	suite.True(ok)
+}
+
+func TestRelayTestSuite(t *testing.T) {
+	suite.Run(t, new(RelayTestSuite))
+}
@@ -66,8 +66,40 @@ func (f *DB) Undo(ctx context.Context, undo vocab.ActivityStreamsUndo) error {
 				return err
 			}
 
+		// UNDO EMOJI REACTION
+		case ap.ActivityEmojiReact:
+			reactable, ok := asType.(ap.EmojiReactable)
+			if !ok {
+				err := fmt.Errorf("%T not parseable as ap.EmojiReactable", asType)
+				return gtserror.SetMalformed(err)
+			}
+
+			if err := f.undoEmojiReact(
+				ctx,
+				requestingAcct,
+				undo,
+				reactable,
+			); err != nil {
+				return err
+			}
+
 		// UNDO LIKE
 		case ap.ActivityLike:
+			if like, ok := asType.(vocab.ActivityStreamsLike); ok &&
+				isEmojiReaction(like) {
+				// Likes with content are
+				// emoji reactions, undo them.
+				if err := f.undoEmojiReact(
+					ctx,
+					requestingAcct,
+					undo,
+					like,
+				); err != nil {
+					return err
+				}
+				continue
+			}
+
 			if err := f.undoLike(
 				ctx,
 				receivingAcct,
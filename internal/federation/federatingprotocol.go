@@ -502,9 +502,89 @@ func (f *Federator) MaxDeliveryRecursionDepth(ctx context.Context) int {
 //
 // The activity is provided as a reference for more intelligent
 // logic to be used, but the implementation must not modify it.
+//
+// Recipients here are the followers collections of local accounts that own
+// the thread that the activity concerns. They are dropped if the requester's
+// domain is blocked, if requester and collection owner block one another,
+// or if a Created / Updated status does not pass the spam filter.
 func (f *Federator) FilterForwarding(ctx context.Context, potentialRecipients []*url.URL, a pub.Activity) ([]*url.URL, error) {
-	// TODO
-	return []*url.URL{}, nil
+	requesting := gtscontext.RequestingAccount(ctx)
+	if requesting == nil {
+		return []*url.URL{}, nil
+	}
+
+	blocked, err := f.db.IsDomainBlocked(ctx, requesting.Domain)
+	if err != nil {
+		return nil, gtserror.Newf("db error checking domain block: %w", err)
+	}
+
+	if blocked {
+		return []*url.URL{}, nil
+	}
+
+	// Gather any statusables to run through spam filter.
+	var statusables []ap.Statusable
+	switch a.GetTypeName() {
+	case ap.ActivityCreate, ap.ActivityUpdate:
+		for _, object := range ap.ExtractObjects(a) {
+			if statusable, ok := ap.ToStatusable(object.GetType()); ok {
+				statusables = append(statusables, statusable)
+			}
+		}
+	}
+
+	recipients := make([]*url.URL, 0, len(potentialRecipients))
+
+outer:
+	for _, recipient := range potentialRecipients {
+		if recipient.Host != config.GetHost() ||
+			!uris.IsFollowersPath(recipient) {
+			// Only forward to local followers.
+			continue
+		}
+
+		username, err := uris.ParseFollowersPath(recipient)
+		if err != nil {
+			continue
+		}
+
+		owner, err := f.db.GetAccountByUsernameDomain(ctx, username, "")
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.Newf("db error getting account %s: %w", username, err)
+		}
+
+		if owner == nil {
+			continue
+		}
+
+		blocked, err := f.db.IsEitherBlocked(ctx, owner.ID, requesting.ID)
+		if err != nil {
+			return nil, gtserror.Newf("db error checking block: %w", err)
+		}
+
+		if blocked {
+			continue
+		}
+
+		for _, statusable := range statusables {
+			err := f.spamFilter.StatusableOK(ctx, owner, requesting, statusable)
+			switch {
+			case err == nil:
+				// Fine.
+
+			case gtserror.IsSpam(err) || gtserror.IsNotRelevant(err):
+				log.Debugf(ctx, "not forwarding to %s: %v", recipient, err)
+				continue outer
+
+			default:
+				return nil, err
+			}
+		}
+
+		recipients = append(recipients, recipient)
+	}
+
+	return recipients, nil
 }
 
 // GetInbox returns the OrderedCollection inbox of the actor for this
@@ -20,6 +20,7 @@
 import (
 	"bytes"
 	"context"
+	"crypto"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -31,6 +32,7 @@
 	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/rfc9421"
 	"code.superseriousbusiness.org/gotosocial/testrig"
 	"code.superseriousbusiness.org/httpsig"
 	errorsv2 "codeberg.org/gruf/go-errors/v2"
@@ -99,6 +101,52 @@ func (suite *FederatingProtocolTestSuite) authenticatePostInbox(
 	ctx = gtscontext.SetHTTPSignature(ctx, activity.SignatureHeader)
 	ctx = gtscontext.SetHTTPSignaturePubKeyID(ctx, testrig.URLMustParse(verifier.KeyId()))
 
+	return suite.authenticate(ctx, request)
+}
+
+func (suite *FederatingProtocolTestSuite) authenticatePostInboxRFC9421(
+	ctx context.Context,
+	receivingAccount *gtsmodel.Account,
+	activity testrig.ActivityWithSignature,
+	keyID string,
+	key crypto.PrivateKey,
+) (context.Context, bool, []byte, int) {
+	raw, err := ap.Serialize(activity.Activity)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	b, err := json.Marshal(raw)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	request := httptest.NewRequest(http.MethodPost, receivingAccount.InboxURI, bytes.NewBuffer(b))
+	if err := rfc9421.Sign(request, b, keyID, key); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	verifier, err := rfc9421.NewVerifier(request, "http")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := verifier.VerifyContentDigest(request); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ctx = gtscontext.SetReceivingAccount(ctx, receivingAccount)
+	ctx = gtscontext.SetHTTPMessageSignatureVerifier(ctx, verifier)
+	ctx = gtscontext.SetHTTPSignature(ctx, request.Header.Get(rfc9421.SignatureHeader))
+	ctx = gtscontext.SetHTTPSignaturePubKeyID(ctx, testrig.URLMustParse(verifier.KeyID()))
+
+	return suite.authenticate(ctx, request)
+}
+
+func (suite *FederatingProtocolTestSuite) authenticate(
+	ctx context.Context,
+	request *http.Request,
+) (context.Context, bool, []byte, int) {
 	recorder := httptest.NewRecorder()
 	newContext, authed, err := suite.federator.AuthenticatePostInbox(ctx, recorder, request)
 	if withCode := errorsv2.AsV2[gtserror.WithCode](err); // nocollapse
@@ -115,7 +163,7 @@ func (suite *FederatingProtocolTestSuite) authenticatePostInbox(
 	res := recorder.Result()
 	defer res.Body.Close()
 
-	b, err = io.ReadAll(res.Body)
+	b, err := io.ReadAll(res.Body)
 	if err != nil {
 		suite.FailNow(err.Error())
 	}
@@ -266,6 +314,68 @@ func (suite *FederatingProtocolTestSuite) TestAuthenticatePostInbox() {
 	suite.Equal(http.StatusOK, code)
 }
 
+func (suite *FederatingProtocolTestSuite) TestAuthenticatePostInboxRFC9421RSA() {
+	var (
+		activity         = suite.testActivities["dm_for_zork"]
+		receivingAccount = suite.testAccounts["local_account_1"]
+		signingAccount   = suite.testAccounts["remote_account_1"]
+	)
+
+	ctx, authed, resp, code := suite.authenticatePostInboxRFC9421(
+		suite.T().Context(),
+		receivingAccount,
+		activity,
+		signingAccount.PublicKeyURI,
+		signingAccount.PrivateKey,
+	)
+
+	suite.Equal(signingAccount.ID, gtscontext.RequestingAccount(ctx).ID)
+	suite.True(authed)
+	suite.Equal([]byte{}, resp)
+	suite.Equal(http.StatusOK, code)
+}
+
+func (suite *FederatingProtocolTestSuite) TestAuthenticatePostInboxRFC9421Ed25519() {
+	var (
+		activity         = suite.testActivities["dm_for_zork"]
+		receivingAccount = suite.testAccounts["local_account_1"]
+		signingAccount   = suite.testAccounts["local_account_2"]
+	)
+
+	ctx, authed, resp, code := suite.authenticatePostInboxRFC9421(
+		suite.T().Context(),
+		receivingAccount,
+		activity,
+		signingAccount.Ed25519PublicKeyURI,
+		signingAccount.Ed25519PrivateKey,
+	)
+
+	suite.Equal(signingAccount.ID, gtscontext.RequestingAccount(ctx).ID)
+	suite.True(authed)
+	suite.Equal([]byte{}, resp)
+	suite.Equal(http.StatusOK, code)
+}
+
+func (suite *FederatingProtocolTestSuite) TestAuthenticatePostInboxRFC9421WrongKey() {
+	var (
+		activity         = suite.testActivities["dm_for_zork"]
+		receivingAccount = suite.testAccounts["local_account_1"]
+	)
+
+	// Sign with key ID of one
+	// account but key of another.
+	_, authed, _, code := suite.authenticatePostInboxRFC9421(
+		suite.T().Context(),
+		receivingAccount,
+		activity,
+		suite.testAccounts["local_account_2"].Ed25519PublicKeyURI,
+		suite.testAccounts["admin_account"].Ed25519PrivateKey,
+	)
+
+	suite.False(authed)
+	suite.Equal(http.StatusUnauthorized, code)
+}
+
 func (suite *FederatingProtocolTestSuite) TestAuthenticatePostInboxKeyExpired() {
 	var (
 		ctx              = suite.T().Context()
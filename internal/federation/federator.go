@@ -23,6 +23,7 @@
 	"code.superseriousbusiness.org/gotosocial/internal/federation/dereferencing"
 	"code.superseriousbusiness.org/gotosocial/internal/federation/federatingdb"
 	"code.superseriousbusiness.org/gotosocial/internal/filter/interaction"
+	"code.superseriousbusiness.org/gotosocial/internal/filter/spam"
 	"code.superseriousbusiness.org/gotosocial/internal/filter/visibility"
 	"code.superseriousbusiness.org/gotosocial/internal/media"
 	"code.superseriousbusiness.org/gotosocial/internal/state"
@@ -36,12 +37,14 @@
 } = (*Federator)(nil)
 
 type Federator struct {
+	state        *state.State
 	db           db.DB
 	federatingDB *federatingdb.DB
 	clock        pub.Clock
 	converter    *typeutils.Converter
 	transport    transport.Controller
 	mediaManager *media.Manager
+	spamFilter   *spam.Filter
 	actor        pub.FederatingActor
 	dereferencing.Dereferencer
 
@@ -63,12 +66,14 @@ func NewFederator(
 ) *Federator {
 	clock := &Clock{}
 	f := &Federator{
+		state:        state,
 		db:           state.DB,
 		federatingDB: federatingDB,
 		clock:        clock,
 		converter:    converter,
 		transport:    transportController,
 		mediaManager: mediaManager,
+		spamFilter:   spam.NewFilter(state),
 
 		Dereferencer: dereferencing.NewDereferencer(
 			state,
@@ -99,12 +104,13 @@ func NewFederator(
 			federatingDB.Announce,
 			federatingDB.Move,
 			federatingDB.Flag,
+			federatingDB.EmojiReact,
 			federatingDB.LikeRequest,
 			federatingDB.ReplyRequest,
 			federatingDB.AnnounceRequest,
 		},
 	}
-	actor := newFederatingActor(f, f, federatingDB, clock)
+	actor := newFederatingActor(f, federatingDB, clock)
 	f.actor = actor
 	return f
 }
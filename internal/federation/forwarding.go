@@ -0,0 +1,373 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federation
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"slices"
+
+	"code.superseriousbusiness.org/activity/pub"
+	"code.superseriousbusiness.org/gotosocial/internal/ap"
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/uris"
+)
+
+// forwardingTargets returns the followers collection IRIs of local accounts to
+// which the given incoming activity should be forwarded, following the inbox
+// forwarding rules at https://www.w3.org/TR/activitypub/#inbox-forwarding:
+//
+//  1. This is the first time we've seen this activity.
+//  2. The activity addresses the followers collection of a local account.
+//  3. The activity concerns a thread owned by that same local account.
+//
+// For Create and Update this means a reply by the requester in a local thread.
+// For Delete this means deletion of such a reply, which is forwarded as long
+// as the reply was public / unlisted, since the Delete need not be addressed
+// to the same followers collection that the reply was.
+//
+// This must be called before processing side effects of the activity, as
+// these can remove models that this relies upon, eg., in case of Delete.
+func (f *Federator) forwardingTargets(ctx context.Context, activity pub.Activity) ([]*url.URL, error) {
+	requesting := gtscontext.RequestingAccount(ctx)
+	if requesting == nil {
+		return nil, nil
+	}
+
+	// Check whether we've seen this before.
+	if id := ap.GetJSONLDId(activity); id != nil {
+		seen, err := f.federatingDB.Exists(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if seen {
+			return nil, nil
+		}
+	}
+
+	var (
+		addressed []string
+		owners    []*gtsmodel.Account
+	)
+
+	if addressable, ok := activity.(ap.Addressable); ok {
+		addressed = addressedFollowers(addressable)
+	}
+
+	switch activity.GetTypeName() {
+	case ap.ActivityCreate, ap.ActivityUpdate:
+		for _, object := range ap.ExtractObjects(activity) {
+			statusable, ok := ap.ToStatusable(object.GetType())
+			if !ok {
+				continue
+			}
+
+			// Don't forward statuses on behalf
+			// of anyone other than the author.
+			author, err := ap.ExtractAttributedToURI(statusable)
+			if err != nil || author.String() != requesting.URI {
+				continue
+			}
+
+			inReplyTo := ap.ExtractInReplyToURI(statusable)
+			if inReplyTo == nil {
+				continue
+			}
+
+			owner, err := f.threadOwner(ctx, inReplyTo.String())
+			if err != nil {
+				return nil, err
+			}
+
+			// Include addressees of object itself.
+			addressed = append(addressed, addressedFollowers(statusable)...)
+
+			if owner != nil && slices.Contains(addressed, owner.FollowersURI) {
+				owners = append(owners, owner)
+			}
+		}
+
+	case ap.ActivityDelete:
+		objectIDs, _ := ap.ExtractObjectURIs(activity)
+		for _, objectID := range objectIDs {
+			status, err := f.db.GetStatusByURI(
+				gtscontext.SetBarebones(ctx),
+				objectID.String(),
+			)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				return nil, gtserror.Newf("db error getting status %s: %w", objectID, err)
+			}
+
+			// Only forward deletes of the requester's
+			// own visible replies, as these would have
+			// been forwarded to followers too.
+			if status == nil ||
+				status.AccountID != requesting.ID ||
+				status.InReplyToURI == "" ||
+				(status.Visibility != gtsmodel.VisibilityPublic &&
+					status.Visibility != gtsmodel.VisibilityUnlocked) {
+				continue
+			}
+
+			owner, err := f.threadOwner(ctx, status.InReplyToURI)
+			if err != nil {
+				return nil, err
+			}
+
+			if owner != nil {
+				owners = append(owners, owner)
+			}
+		}
+	}
+
+	targets := make([]*url.URL, 0, len(owners))
+	seen := make(map[string]struct{}, len(owners))
+	for _, owner := range owners {
+		if _, ok := seen[owner.FollowersURI]; ok {
+			continue
+		}
+
+		followersIRI, err := url.Parse(owner.FollowersURI)
+		if err != nil {
+			return nil, gtserror.Newf("invalid followers uri %s: %w", owner.FollowersURI, err)
+		}
+
+		seen[owner.FollowersURI] = struct{}{}
+		targets = append(targets, followersIRI)
+	}
+
+	return targets, nil
+}
+
+// threadOwner walks up the thread from the status with given
+// uri, up to the max inbox forwarding recursion depth, returning
+// the account of the first local status found (if any).
+func (f *Federator) threadOwner(ctx context.Context, uri string) (*gtsmodel.Account, error) {
+	for depth := 0; depth < f.MaxInboxForwardingRecursionDepth(ctx); depth++ {
+		status, err := f.db.GetStatusByURI(gtscontext.SetBarebones(ctx), uri)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.Newf("db error getting status %s: %w", uri, err)
+		}
+
+		if status == nil {
+			// Thread unknown
+			// from here on.
+			return nil, nil
+		}
+
+		if status.IsLocal() {
+			owner, err := f.db.GetAccountByID(gtscontext.SetBarebones(ctx), status.AccountID)
+			if err != nil {
+				return nil, gtserror.Newf("db error getting account %s: %w", status.AccountID, err)
+			}
+			return owner, nil
+		}
+
+		if status.InReplyToURI == "" {
+			// Top of the
+			// thread.
+			return nil, nil
+		}
+
+		uri = status.InReplyToURI
+	}
+
+	return nil, nil
+}
+
+// addressedFollowers returns the local followers
+// collection IRIs addressed in to / cc of given type.
+func addressedFollowers(t ap.Addressable) []string {
+	var followers []string
+	host := config.GetHost()
+	for _, iri := range append(ap.ExtractToURIs(t), ap.ExtractCcURIs(t)...) {
+		if iri.Host == host && uris.IsFollowersPath(iri) {
+			followers = append(followers, iri.String())
+		}
+	}
+	return followers
+}
+
+// forwardActivity forwards the given incoming activity on behalf of the
+// requester to the followers contained in given targets, after filtering
+// via FilterForwarding. The activity is serialized here, but gathering
+// follower inboxes and delivering is pushed to the processing workers,
+// so as not to hold up the inbox request for accounts with many followers.
+func (f *Federator) forwardActivity(ctx context.Context, activity pub.Activity, targets []*url.URL) error {
+	requesting := gtscontext.RequestingAccount(ctx)
+	if requesting == nil || len(targets) == 0 {
+		return nil
+	}
+
+	targets, err := f.FilterForwarding(ctx, targets, activity)
+	if err != nil {
+		return err
+	}
+
+	if len(targets) == 0 {
+		return nil
+	}
+
+	// Serialize activity to forward as-is.
+	data, err := ap.Serialize(activity)
+	if err != nil {
+		return gtserror.Newf("error serializing activity: %w", err)
+	}
+
+	f.state.Workers.Processing.Queue.Push(func(ctx context.Context) {
+		if err := f.forwardToFollowers(ctx, requesting, data, targets); err != nil {
+			log.Warnf(ctx, "error forwarding activity: %v", err)
+		}
+	})
+
+	return nil
+}
+
+// forwardToFollowers delivers the serialized activity data
+// from requesting to the followers in each of given targets.
+func (f *Federator) forwardToFollowers(
+	ctx context.Context,
+	requesting *gtsmodel.Account,
+	data map[string]any,
+	targets []*url.URL,
+) error {
+	var errs gtserror.MultiError
+
+	for _, target := range targets {
+		username, err := uris.ParseFollowersPath(target)
+		if err != nil {
+			errs.Appendf("invalid followers uri %s: %w", target, err)
+			continue
+		}
+
+		owner, err := f.db.GetAccountByUsernameDomain(ctx, username, "")
+		if err != nil {
+			errs.Appendf("db error getting account %s: %w", username, err)
+			continue
+		}
+
+		inboxes, err := f.forwardingInboxes(ctx, owner, requesting)
+		if err != nil {
+			errs.Append(err)
+			continue
+		}
+
+		if len(inboxes) == 0 {
+			continue
+		}
+
+		tsport, err := f.transport.NewTransportForUsername(ctx, owner.Username)
+		if err != nil {
+			errs.Appendf("error getting transport for %s: %w", owner.Username, err)
+			continue
+		}
+
+		if err := tsport.BatchDeliver(ctx, data, inboxes); err != nil {
+			errs.Appendf("error forwarding to followers of %s: %w", owner.Username, err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+// forwardingInboxes returns the remote inboxes of followers of owner that a
+// forwarded activity from requester may be delivered to, skipping followers
+// on the requester's own instance, on blocked domains, and followers that
+// either block, or are blocked by, the requester.
+func (f *Federator) forwardingInboxes(
+	ctx context.Context,
+	owner *gtsmodel.Account,
+	requesting *gtsmodel.Account,
+) ([]*url.URL, error) {
+	follows, err := f.db.GetAccountFollowers(ctx, owner.ID, nil)
+	if err != nil {
+		return nil, gtserror.Newf("db error getting followers of %s: %w", owner.Username, err)
+	}
+
+	var (
+		inboxes []*url.URL
+
+		// Inboxes already added, and domain
+		// block results, so each is only
+		// looked at once for many followers.
+		seen    = make(map[string]struct{}, len(follows))
+		domains = make(map[string]bool)
+	)
+
+	for _, follow := range follows {
+		follower := follow.Account
+		if follower == nil ||
+			follower.IsLocal() ||
+			follower.Domain == requesting.Domain {
+			// Origin server already
+			// has the activity.
+			continue
+		}
+
+		blocked, ok := domains[follower.Domain]
+		if !ok {
+			blocked, err = f.db.IsDomainBlocked(ctx, follower.Domain)
+			if err != nil {
+				return nil, gtserror.Newf("db error checking domain block: %w", err)
+			}
+			domains[follower.Domain] = blocked
+		}
+
+		if blocked {
+			continue
+		}
+
+		// Deliver to shared inbox if we have that option.
+		inbox := follower.InboxURI
+		if config.GetInstanceDeliverToSharedInboxes() &&
+			follower.SharedInboxURI != nil && *follower.SharedInboxURI != "" {
+			inbox = *follower.SharedInboxURI
+		}
+
+		if _, ok := seen[inbox]; ok {
+			continue
+		}
+
+		blocked, err = f.db.IsEitherBlocked(ctx, follower.ID, requesting.ID)
+		if err != nil {
+			return nil, gtserror.Newf("db error checking block: %w", err)
+		}
+
+		if blocked {
+			continue
+		}
+
+		inboxIRI, err := url.Parse(inbox)
+		if err != nil {
+			log.Warnf(ctx, "invalid inbox uri %s: %v", inbox, err)
+			continue
+		}
+
+		seen[inbox] = struct{}{}
+		inboxes = append(inboxes, inboxIRI)
+	}
+
+	return inboxes, nil
+}
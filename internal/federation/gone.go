@@ -19,8 +19,12 @@
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
 	"net/url"
 
+	"code.superseriousbusiness.org/gotosocial/internal/ap"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
 	"code.superseriousbusiness.org/gotosocial/internal/id"
 )
@@ -39,3 +43,33 @@ func (f *Federator) HandleGone(ctx context.Context, uri *url.URL) error {
 	}
 	return f.db.PutTombstone(ctx, tombstone)
 }
+
+// DereferenceGone dereferences the AP Actor or Object with the given uri as requestUser,
+// returning true if the remote indicates that it is gone, ie., by responding with 404 or
+// 410, or with a Tombstone. This is used to verify forwarded Deletes from a third party.
+func (f *Federator) DereferenceGone(ctx context.Context, requestUser string, uri *url.URL) (bool, error) {
+	tsport, err := f.transport.NewTransportForUsername(ctx, requestUser)
+	if err != nil {
+		return false, gtserror.Newf("error getting transport for %s: %w", requestUser, err)
+	}
+
+	rsp, err := tsport.Dereference(ctx, uri)
+	switch code := gtserror.StatusCode(err); {
+	case code == http.StatusNotFound || code == http.StatusGone:
+		return true, nil
+	case err != nil:
+		return false, gtserror.Newf("error dereferencing %s: %w", uri, err)
+	}
+
+	defer rsp.Body.Close()
+
+	// Check whether the resource
+	// was replaced by a Tombstone.
+	var raw map[string]any
+	if err := json.NewDecoder(rsp.Body).Decode(&raw); err != nil {
+		return false, gtserror.Newf("error decoding %s: %w", uri, err)
+	}
+
+	typeName, _ := raw["type"].(string)
+	return typeName == ap.ObjectTombstone, nil
+}
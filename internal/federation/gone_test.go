@@ -0,0 +1,51 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federation_test
+
+import (
+	"testing"
+
+	"code.superseriousbusiness.org/gotosocial/testrig"
+	"github.com/stretchr/testify/suite"
+)
+
+type GoneTestSuite struct {
+	FederatorStandardTestSuite
+}
+
+func (suite *GoneTestSuite) TestDereferenceGoneStatusExists() {
+	ctx := suite.T().Context()
+	uri := testrig.URLMustParse("http://example.org/users/Some_User/statuses/afaba698-5740-4e32-a702-af61aa543bc1")
+
+	gone, err := suite.federator.DereferenceGone(ctx, "the_mighty_zork", uri)
+	suite.NoError(err)
+	suite.False(gone)
+}
+
+func (suite *GoneTestSuite) TestDereferenceGoneStatusNotFound() {
+	ctx := suite.T().Context()
+	uri := testrig.URLMustParse("http://example.org/users/Some_User/statuses/01JZ5D2K8VQ3W6N0X1T4B7C9ME")
+
+	gone, err := suite.federator.DereferenceGone(ctx, "the_mighty_zork", uri)
+	suite.NoError(err)
+	suite.True(gone)
+}
+
+func TestGoneTestSuite(t *testing.T) {
+	suite.Run(t, &GoneTestSuite{})
+}
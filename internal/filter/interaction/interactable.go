@@ -334,6 +334,75 @@ func (f *Filter) StatusBoostable(
 	}
 }
 
+// StatusQuoteable checks if the given status
+// is quoteable by the requester account.
+//
+// Callers to this function should have already
+// checked the visibility of status to requester,
+// including taking account of blocks, as this
+// function does not do visibility checks, only
+// interaction policy checks.
+func (f *Filter) StatusQuoteable(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	status *gtsmodel.Status,
+) (*gtsmodel.PolicyCheckResult, error) {
+	if status.Visibility == gtsmodel.VisibilityDirect {
+		log.Trace(ctx, "direct statuses are not quoteable")
+		return &gtsmodel.PolicyCheckResult{
+			Permission: gtsmodel.PolicyPermissionForbidden,
+		}, nil
+	}
+
+	if requester.ID == status.AccountID {
+		// Status author themself can
+		// always quote non-directs,
+		// no need for further checks.
+		return &gtsmodel.PolicyCheckResult{
+			Permission:          gtsmodel.PolicyPermissionAutomaticApproval,
+			PermissionMatchedOn: util.Ptr(gtsmodel.PolicyValueAuthor),
+		}, nil
+	}
+
+	switch {
+	// If status has canQuote sub-policy set, check against that.
+	case status.InteractionPolicy != nil && status.InteractionPolicy.CanQuote != nil:
+		return f.checkPolicy(
+			ctx,
+			requester,
+			status,
+			status.InteractionPolicy.CanQuote,
+		)
+
+	// If status is local and has no policy set,
+	// check against the default canQuote for this
+	// visibility, as we're canQuote sub-policy aware.
+	case *status.Local:
+		return f.checkPolicy(
+			ctx,
+			requester,
+			status,
+			gtsmodel.DefaultCanQuoteFor(status.Visibility),
+		)
+
+	// Status is from an instance that does not use
+	// or does not care about canQuote sub-policy.
+	// We can quote it if it's unlisted or public.
+	case status.Visibility == gtsmodel.VisibilityPublic ||
+		status.Visibility == gtsmodel.VisibilityUnlocked:
+		return &gtsmodel.PolicyCheckResult{
+			Permission: gtsmodel.PolicyPermissionAutomaticApproval,
+		}, nil
+
+	// Not permitted by any of the
+	// above checks, so it's forbidden.
+	default:
+		return &gtsmodel.PolicyCheckResult{
+			Permission: gtsmodel.PolicyPermissionForbidden,
+		}, nil
+	}
+}
+
 func (f *Filter) checkPolicy(
 	ctx context.Context,
 	requester *gtsmodel.Account,
@@ -105,9 +105,38 @@ func (f *Filter) isAccountVisibleTo(ctx context.Context, requester *gtsmodel.Acc
 		return false, nil
 	}
 
+	// Check whether either (local) account
+	// blocks the domain of the other account.
+	blocked, err = f.isEitherDomainBlocked(ctx,
+		requester,
+		account,
+	)
+	if err != nil {
+		return false, gtserror.Newf("error checking user domain blocks: %w", err)
+	}
+
+	if blocked {
+		log.Trace(ctx, "user domain block exists between accounts")
+		return false, nil
+	}
+
 	return true, nil
 }
 
+// isEitherDomainBlocked checks whether either account is local
+// and has blocked the domain of the other (remote) account.
+func (f *Filter) isEitherDomainBlocked(ctx context.Context, account1 *gtsmodel.Account, account2 *gtsmodel.Account) (bool, error) {
+	if account1.IsLocal() && !account2.IsLocal() {
+		return f.state.DB.IsUserDomainBlocked(ctx, account1.ID, account2.Domain)
+	}
+
+	if account2.IsLocal() && !account1.IsLocal() {
+		return f.state.DB.IsUserDomainBlocked(ctx, account2.ID, account1.Domain)
+	}
+
+	return false, nil
+}
+
 // isAccountVisible will check if given account should be visible at all, e.g. it may not be if suspended or disabled.
 func (f *Filter) isAccountVisible(ctx context.Context, account *gtsmodel.Account) (bool, error) {
 	if account.IsLocal() {
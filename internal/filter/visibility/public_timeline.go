@@ -91,6 +91,13 @@ func (f *Filter) isStatusPublicTimelineable(ctx context.Context, requester *gtsm
 		return false, nil
 	}
 
+	if status.Account.IsSilenced() {
+		// Silenced accounts never
+		// appear on this timeline.
+		log.Trace(ctx, "status author is silenced")
+		return false, nil
+	}
+
 	for parent := status; parent.InReplyToURI != ""; {
 		// Fetch next parent to lookup.
 		parentID := parent.InReplyToID
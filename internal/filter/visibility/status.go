@@ -44,8 +44,8 @@ func (f *Filter) StatusesVisible(ctx context.Context, requester *gtsmodel.Accoun
 
 // StatusVisible will check if status is visible to requester,
 // accounting for requester with no auth (i.e is nil), suspensions,
-// disabled local users, pending approvals, account blocks,
-// and status visibility settings.
+// silenced accounts, disabled local users, pending approvals, account
+// blocks, and status visibility settings.
 func (f *Filter) StatusVisible(
 	ctx context.Context,
 	requester *gtsmodel.Account,
@@ -112,6 +112,16 @@ func (f *Filter) isStatusVisible(
 		), nil
 	}
 
+	// Check whether status (or boosted status)
+	// author is silenced, and hidden from requester.
+	silenced, err := f.isStatusSilenced(ctx, requester, status)
+	if err != nil {
+		return false, gtserror.Newf("error checking status %s silencing: %w", status.ID, err)
+	} else if silenced {
+		log.Trace(ctx, "silenced status not visible to requester")
+		return false, nil
+	}
+
 	if requester == nil {
 		// Use a different visibility
 		// heuristic for unauthed requests.
@@ -269,6 +279,61 @@ func (f *Filter) isStatusVisibleUnauthed(status *gtsmodel.Status) bool {
 	}
 }
 
+// isStatusSilenced returns whether the author of status, or the author of
+// the status it boosts, is silenced and hidden from the requester. Statuses
+// by silenced accounts are only visible to the silenced account's followers,
+// and to any accounts explicitly mentioned in the status.
+func (f *Filter) isStatusSilenced(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status) (bool, error) {
+	if requester != nil && status.MentionsAccount(requester.ID) {
+		// Silenced accounts can still
+		// reach anyone they mention.
+		return false, nil
+	}
+
+	silenced, err := f.isAccountSilencedFrom(ctx, requester, status.Account)
+	if err != nil || silenced {
+		return silenced, err
+	}
+
+	if status.BoostOfAccount != nil {
+		// Also check the author of the boosted status.
+		return f.isAccountSilencedFrom(ctx, requester, status.BoostOfAccount)
+	}
+
+	return false, nil
+}
+
+// isAccountSilencedFrom returns whether account is silenced, and the
+// requester is neither the silenced account itself nor one of its followers.
+func (f *Filter) isAccountSilencedFrom(ctx context.Context, requester *gtsmodel.Account, account *gtsmodel.Account) (bool, error) {
+	if !account.IsSilenced() {
+		return false, nil
+	}
+
+	if requester == nil {
+		// Silenced accounts are
+		// always hidden from unauthed.
+		return true, nil
+	}
+
+	if requester.ID == account.ID {
+		// Silenced accounts can
+		// always see themselves.
+		return false, nil
+	}
+
+	// Only followers may see silenced accounts.
+	follows, err := f.state.DB.IsFollowing(ctx,
+		requester.ID,
+		account.ID,
+	)
+	if err != nil {
+		return false, gtserror.Newf("error checking follow %s->%s: %w", requester.ID, account.ID, err)
+	}
+
+	return !follows, nil
+}
+
 // areStatusAccountsVisible calls Filter{}.AccountVisible() on status author and the status boost-of (if set) author, returning visibility of status (and boost-of) to requester.
 func (f *Filter) areStatusAccountsVisible(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status) (bool, error) {
 	// Check whether status author's account is visible to requester.
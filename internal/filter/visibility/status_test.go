@@ -19,8 +19,10 @@
 
 import (
 	"testing"
+	"time"
 
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
 	"code.superseriousbusiness.org/gotosocial/internal/util"
 	"github.com/stretchr/testify/suite"
 )
@@ -276,6 +278,102 @@ func (suite *StatusVisibleTestSuite) TestVisibleLocalOnly() {
 	}
 }
 
+func (suite *StatusVisibleTestSuite) TestVisibleSilenced() {
+	ctx := suite.T().Context()
+
+	// Silence the author of a Public status.
+	author := new(gtsmodel.Account)
+	*author = *suite.testAccounts["local_account_2"]
+	author.SilencedAt = time.Now()
+	if err := suite.db.UpdateAccount(ctx, author, "silenced_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	testStatus, err := suite.db.GetStatusByID(ctx, suite.testStatuses["local_account_2_status_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	for _, testCase := range []struct {
+		acct    *gtsmodel.Account
+		visible bool
+	}{
+		{
+			acct:    suite.testAccounts["local_account_2"],
+			visible: true, // Own status, always visible.
+		},
+		{
+			acct:    nil,
+			visible: false, // No auth, should not be visible.
+		},
+		{
+			acct:    suite.testAccounts["local_account_1"],
+			visible: true, // Follower, should be visible.
+		},
+		{
+			acct:    suite.testAccounts["admin_account"],
+			visible: false, // Not a follower, should not be visible.
+		},
+	} {
+		visible, err := suite.filter.StatusVisible(ctx, testCase.acct, testStatus)
+		suite.NoError(err)
+		suite.Equal(testCase.visible, visible)
+	}
+
+	// Even for followers, silenced statuses
+	// should not appear on the public timeline.
+	timelineable, err := suite.filter.StatusPublicTimelineable(ctx, suite.testAccounts["local_account_1"], testStatus)
+	suite.NoError(err)
+	suite.False(timelineable)
+}
+
+func (suite *StatusVisibleTestSuite) TestStatusNotVisibleIfDomainBlocked() {
+	ctx := suite.T().Context()
+
+	var (
+		localAccount  = suite.testAccounts["local_account_1"]
+		localStatus   = suite.testStatuses["local_account_1_status_1"]
+		remoteAccount = suite.testAccounts["remote_account_1"]
+		remoteStatus  = suite.testStatuses["remote_account_1_status_1"]
+	)
+
+	// Statuses should be visible both ways to begin with.
+	visible, err := suite.filter.StatusVisible(ctx, localAccount, remoteStatus)
+	suite.NoError(err)
+	suite.True(visible)
+
+	visible, err = suite.filter.StatusVisible(ctx, remoteAccount, localStatus)
+	suite.NoError(err)
+	suite.True(visible)
+
+	// Block the remote account's domain for the local account.
+	if err := suite.db.PutUserDomainBlock(ctx, &gtsmodel.UserDomainBlock{
+		ID:        id.NewULID(),
+		AccountID: localAccount.ID,
+		Domain:    remoteAccount.Domain,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Now neither should be visible to the other.
+	visible, err = suite.filter.StatusVisible(ctx, localAccount, remoteStatus)
+	suite.NoError(err)
+	suite.False(visible)
+
+	visible, err = suite.filter.StatusVisible(ctx, remoteAccount, localStatus)
+	suite.NoError(err)
+	suite.False(visible)
+
+	// Unblock the domain again.
+	if err := suite.db.DeleteUserDomainBlock(ctx, localAccount.ID, remoteAccount.Domain); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	visible, err = suite.filter.StatusVisible(ctx, localAccount, remoteStatus)
+	suite.NoError(err)
+	suite.True(visible)
+}
+
 func TestStatusVisibleTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusVisibleTestSuite))
 }
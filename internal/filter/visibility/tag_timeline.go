@@ -55,6 +55,13 @@ func (f *Filter) StatusTagTimelineable(
 		return false, nil
 	}
 
+	if status.Account.IsSilenced() {
+		// Silenced accounts never
+		// appear on this timeline.
+		log.Trace(ctx, "status author is silenced")
+		return false, nil
+	}
+
 	// Looks good!
 	return true, nil
 }
@@ -23,6 +23,7 @@
 	"net/url"
 
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/rfc9421"
 	"code.superseriousbusiness.org/httpsig"
 )
 
@@ -44,6 +45,8 @@
 	httpSigPubKeyIDKey
 	dryRunKey
 	httpClientSignFnKey
+	httpClientKnockFnKey
+	httpMsgSigVerifierKey
 )
 
 // DryRun returns whether the "dryrun" context key has been set. This can be
@@ -223,6 +226,32 @@ func (ctx httpClientSignFuncContext) Value(key any) any {
 	return ctx.Context.Value(key)
 }
 
+// HTTPClientKnockFunc returns an httpclient "double-knock" function for the current
+// client request context. Given a signed request and its response, this returns an
+// alternative signing function to retry the request with, or nil to accept the response.
+func HTTPClientKnockFunc(ctx context.Context) func(*http.Request, *http.Response) func(*http.Request) error {
+	fn, _ := ctx.Value(httpClientKnockFnKey).(func(*http.Request, *http.Response) func(*http.Request) error)
+	return fn
+}
+
+// SetHTTPClientKnockFunc stores the given httpclient double-knock function and returns the
+// wrapped context. See HTTPClientKnockFunc() for further information on the knock function.
+func SetHTTPClientKnockFunc(ctx context.Context, fn func(*http.Request, *http.Response) func(*http.Request) error) context.Context {
+	return httpClientKnockFuncContext{Context: ctx, knockfn: fn}
+}
+
+type httpClientKnockFuncContext struct {
+	context.Context
+	knockfn func(*http.Request, *http.Response) func(*http.Request) error
+}
+
+func (ctx httpClientKnockFuncContext) Value(key any) any {
+	if key == httpClientKnockFnKey {
+		return ctx.knockfn
+	}
+	return ctx.Context.Value(key)
+}
+
 // HTTPSignatureVerifier returns an http signature verifier for the current ActivityPub
 // request chain. This verifier can be called to authenticate the current request.
 func HTTPSignatureVerifier(ctx context.Context) httpsig.VerifierWithOptions {
@@ -248,6 +277,32 @@ func (ctx httpSignatureVerifierContext) Value(key any) any {
 	return ctx.Context.Value(key)
 }
 
+// HTTPMessageSignatureVerifier returns an RFC 9421 http message signature verifier for the
+// current ActivityPub request chain. This will be set instead of HTTPSignatureVerifier()
+// when the request was signed using RFC 9421 rather than draft-cavage http signatures.
+func HTTPMessageSignatureVerifier(ctx context.Context) *rfc9421.Verifier {
+	verifier, _ := ctx.Value(httpMsgSigVerifierKey).(*rfc9421.Verifier)
+	return verifier
+}
+
+// SetHTTPMessageSignatureVerifier stores the given RFC 9421 verifier and returns the wrapped
+// context. See HTTPMessageSignatureVerifier() for further information on the verifier value.
+func SetHTTPMessageSignatureVerifier(ctx context.Context, verifier *rfc9421.Verifier) context.Context {
+	return httpMessageSignatureVerifierContext{Context: ctx, verifier: verifier}
+}
+
+type httpMessageSignatureVerifierContext struct {
+	context.Context
+	verifier *rfc9421.Verifier
+}
+
+func (ctx httpMessageSignatureVerifierContext) Value(key any) any {
+	if key == httpMsgSigVerifierKey {
+		return ctx.verifier
+	}
+	return ctx.Context.Value(key)
+}
+
 // HTTPSignature returns the http signature string
 // value for the current ActivityPub request chain.
 func HTTPSignature(ctx context.Context) string {
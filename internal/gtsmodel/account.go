@@ -22,6 +22,7 @@
 package gtsmodel
 
 import (
+	"crypto/ed25519"
 	"crypto/rsa"
 	"slices"
 	"strings"
@@ -161,6 +162,12 @@ type Account struct {
 	// Corresponds to the toot `discoverable` property.
 	Discoverable *bool `bun:",nullzero,notnull,default:false"`
 
+	// True if account has opted in to having its
+	// public statuses indexed for full-text search.
+	//
+	// Corresponds to the toot `indexable` property.
+	Indexable *bool `bun:",nullzero,notnull,default:false"`
+
 	// ActivityPub URI/ID for this account.
 	//
 	// Must be set, must be unique.
@@ -219,6 +226,11 @@ type Account struct {
 	// Corresponds to the Toot `featured` property.
 	FeaturedCollectionURI string `bun:",nullzero"`
 
+	// URI/ID of the actor's featured tags collection.
+	//
+	// Corresponds to the Toot `featuredTags` property.
+	FeaturedTagsURI string `bun:",nullzero"`
+
 	// ActivityStreams type of the actor.
 	//
 	// Application, Group, Organization, Person, or Service.
@@ -245,6 +257,23 @@ type Account struct {
 	// Only ever set for remote accounts.
 	PublicKeyExpiresAt time.Time `bun:"type:timestamptz,nullzero"`
 
+	// Ed25519 private key for signing http requests
+	// using RFC 9421 http message signatures.
+	//
+	// Only defined for local accounts.
+	Ed25519PrivateKey ed25519.PrivateKey `bun:",nullzero"`
+
+	// Ed25519 public key for authorizing signed http requests.
+	//
+	// Corresponds to FEP-521a `assertionMethod` Multikey.
+	// Only defined for remote accounts if they provide it.
+	Ed25519PublicKey ed25519.PublicKey `bun:",nullzero"`
+
+	// Dereferenceable location of this actor's Ed25519 public key.
+	//
+	// Corresponds to FEP-521a `assertionMethod` Multikey `id`.
+	Ed25519PublicKeyURI string `bun:",nullzero,unique"`
+
 	// Datetime at which account was marked as a "memorial",
 	// ie., user owning the account has passed away.
 	MemorializedAt time.Time `bun:"type:timestamptz,nullzero"`
@@ -253,6 +282,10 @@ type Account struct {
 	// have all its media shown as sensitive.
 	SensitizedAt time.Time `bun:"type:timestamptz,nullzero"`
 
+	// Content warning to prepend to all incoming statuses
+	// from this account, set by an admin when sensitizing.
+	SensitizedContentWarning string `bun:",nullzero"`
+
 	// Datetime at which account was silenced.
 	SilencedAt time.Time `bun:"type:timestamptz,nullzero"`
 
@@ -386,6 +419,18 @@ func (a *Account) IsAliasedTo(uri string) bool {
 	return slices.Contains(a.AlsoKnownAsURIs, uri)
 }
 
+// IsSensitized returns true if account has been
+// set to have all its content shown as sensitive.
+func (a *Account) IsSensitized() bool {
+	return !a.SensitizedAt.IsZero()
+}
+
+// IsSilenced returns true if account
+// has been silenced on this instance.
+func (a *Account) IsSilenced() bool {
+	return !a.SilencedAt.IsZero()
+}
+
 // IsSuspended returns true if account
 // has been suspended from this instance.
 func (a *Account) IsSuspended() bool {
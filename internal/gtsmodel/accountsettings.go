@@ -41,6 +41,9 @@ type AccountSettings struct {
 	InteractionPolicyFollowersOnly *InteractionPolicy `bun:""`                                                            // Interaction policy to use for new followers only visibility statuses. If null, assume default policy.
 	InteractionPolicyUnlocked      *InteractionPolicy `bun:""`                                                            // Interaction policy to use for new unlocked visibility statuses. If null, assume default policy.
 	InteractionPolicyPublic        *InteractionPolicy `bun:""`                                                            // Interaction policy to use for new public visibility statuses. If null, assume default policy.
+	EmailNotifications             EmailNotifications `bun:",nullzero,notnull,default:1"`                                 // Whether (and how often) to email notifications to this account.
+	EmailNotificationTypes         NotificationFlags  `bun:",notnull,default:0"`                                          // Types of notifications to email to this account.
+	EmailDigestSentAt              time.Time          `bun:"type:timestamptz,nullzero"`                                   // When a notifications digest was last emailed to this account.
 }
 
 // WebLayout represents an account owner's
@@ -83,3 +86,80 @@ func ParseWebLayout(in string) WebLayout {
 		return WebLayoutUnknown
 	}
 }
+
+// EmailNotifications represents an account owner's
+// choice for whether, and how often, notifications
+// should be delivered to them by email.
+type EmailNotifications enumType
+
+const (
+	EmailNotificationsUnknown EmailNotifications = 0
+
+	// Don't email notifications (default).
+	EmailNotificationsNone EmailNotifications = 1
+
+	// Email each notification as it arrives.
+	EmailNotificationsImmediate EmailNotifications = 2
+
+	// Email a digest of notifications once a day.
+	EmailNotificationsDaily EmailNotifications = 3
+
+	// Email a digest of notifications once a week.
+	EmailNotificationsWeekly EmailNotifications = 4
+)
+
+// EmailNotificationsDefaultTypes are the notification
+// types emailed when email notifications are enabled
+// without selecting any notification types.
+var EmailNotificationsDefaultTypes = []NotificationType{
+	NotificationMention,
+	NotificationFollowRequest,
+}
+
+// String returns a stringified, frontend
+// API compatible form of EmailNotifications.
+func (en EmailNotifications) String() string {
+	switch en {
+	case EmailNotificationsNone:
+		return "none"
+	case EmailNotificationsImmediate:
+		return "immediate"
+	case EmailNotificationsDaily:
+		return "daily"
+	case EmailNotificationsWeekly:
+		return "weekly"
+	default:
+		panic("invalid email notifications")
+	}
+}
+
+// DigestPeriod returns the period covered by
+// each notifications digest, or 0 if digests
+// are not enabled.
+func (en EmailNotifications) DigestPeriod() time.Duration {
+	switch en {
+	case EmailNotificationsDaily:
+		return 24 * time.Hour
+	case EmailNotificationsWeekly:
+		return 7 * 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// ParseEmailNotifications returns an
+// email notifications value from the given value.
+func ParseEmailNotifications(in string) EmailNotifications {
+	switch strings.ToLower(in) {
+	case "none":
+		return EmailNotificationsNone
+	case "immediate":
+		return EmailNotificationsImmediate
+	case "daily":
+		return EmailNotificationsDaily
+	case "weekly":
+		return EmailNotificationsWeekly
+	default:
+		return EmailNotificationsUnknown
+	}
+}
@@ -75,6 +75,8 @@ func ParseAdminActionCategory(in string) AdminActionCategory {
 	AdminActionUnsuspend
 	AdminActionExpireKeys
 	AdminActionUnallow
+	AdminActionSensitive
+	AdminActionUnsensitive
 )
 
 func (t AdminActionType) String() string {
@@ -95,6 +97,10 @@ func (t AdminActionType) String() string {
 		return "expire-keys"
 	case AdminActionUnallow:
 		return "unallow"
+	case AdminActionSensitive:
+		return "sensitive"
+	case AdminActionUnsensitive:
+		return "unsensitive"
 	default:
 		return "unknown"
 	}
@@ -118,6 +124,10 @@ func ParseAdminActionType(in string) AdminActionType {
 		return AdminActionExpireKeys
 	case "unallow":
 		return AdminActionUnallow
+	case "sensitive":
+		return AdminActionSensitive
+	case "unsensitive":
+		return AdminActionUnsensitive
 	default:
 		return AdminActionUnknown
 	}
@@ -0,0 +1,75 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Announcement represents an instance-wide announcement
+// created by an admin, which is shown to all local users
+// in their client until it ends or they dismiss it.
+type Announcement struct {
+	ID                 string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // ID of this item in the database.
+	CreatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // Time when this item was created.
+	UpdatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // Time when this item was last updated.
+	Text               string    `bun:",nullzero,notnull"`                                           // Markdown source text of the announcement.
+	Content            string    `bun:",nullzero,notnull"`                                           // HTML content of the announcement, parsed from Text.
+	EmojiIDs           []string  `bun:"emojis,array"`                                                // Database IDs of any emojis used in the announcement.
+	Emojis             []*Emoji  `bun:"-"`                                                           // Emojis corresponding to EmojiIDs.
+	StartsAt           time.Time `bun:"type:timestamptz,nullzero"`                                   // Start time of the event being announced, if any.
+	EndsAt             time.Time `bun:"type:timestamptz,nullzero"`                                   // Time after which the announcement is no longer shown, if any.
+	AllDay             *bool     `bun:",nullzero,notnull,default:false"`                             // StartsAt and EndsAt should be shown as dates rather than times.
+	ScheduledAt        time.Time `bun:"type:timestamptz,nullzero"`                                   // Time at which the announcement is to be published, if scheduled.
+	PublishedAt        time.Time `bun:"type:timestamptz,nullzero"`                                   // Time at which the announcement was published; zero if not yet published.
+	CreatedByAccountID string    `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the admin account that created this announcement.
+}
+
+// IsPublished returns whether the
+// announcement has been published.
+func (a *Announcement) IsPublished() bool {
+	return !a.PublishedAt.IsZero()
+}
+
+// IsActive returns whether the announcement is
+// published and not yet ended at the given time,
+// ie., whether it should be shown to users.
+func (a *Announcement) IsActive(now time.Time) bool {
+	return a.IsPublished() &&
+		(a.EndsAt.IsZero() || a.EndsAt.After(now))
+}
+
+// AnnouncementRead represents an announcement
+// having been read (dismissed) by an account.
+type AnnouncementRead struct {
+	ID             string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                                 // ID of this item in the database.
+	CreatedAt      time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                              // Time when this item was created.
+	AnnouncementID string    `bun:"type:CHAR(26),unique:announcement_reads_announcement_id_account_id_uniq,nullzero,notnull"` // ID of the announcement that was read.
+	AccountID      string    `bun:"type:CHAR(26),unique:announcement_reads_announcement_id_account_id_uniq,nullzero,notnull"` // ID of the account that read the announcement.
+}
+
+// AnnouncementReaction represents an emoji reaction
+// to an announcement from an account. Name is either
+// a unicode emoji, or the shortcode of a local emoji.
+type AnnouncementReaction struct {
+	ID             string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                                          // ID of this item in the database.
+	CreatedAt      time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                                       // Time when this item was created.
+	AnnouncementID string    `bun:"type:CHAR(26),unique:announcement_reactions_announcement_id_account_id_name_uniq,nullzero,notnull"` // ID of the announcement reacted to.
+	AccountID      string    `bun:"type:CHAR(26),unique:announcement_reactions_announcement_id_account_id_name_uniq,nullzero,notnull"` // ID of the account that reacted.
+	Name           string    `bun:",unique:announcement_reactions_announcement_id_account_id_name_uniq,nullzero,notnull"`              // Unicode emoji or local emoji shortcode reacted with.
+	EmojiID        string    `bun:"type:CHAR(26),nullzero"`                                                                            // ID of the custom emoji used, if any.
+	Emoji          *Emoji    `bun:"-"`                                                                                                 // Custom emoji corresponding to EmojiID.
+}
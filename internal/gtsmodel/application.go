@@ -17,7 +17,10 @@
 
 package gtsmodel
 
-import "strings"
+import (
+	"crypto/subtle"
+	"strings"
+)
 
 // Application represents an application that
 // can perform actions on behalf of a user.
@@ -32,6 +35,7 @@ type Application struct {
 	ClientSecret    string   `bun:",nullzero,notnull"`                        // secret of the associated oauth client entity in the db
 	Scopes          string   `bun:",notnull"`                                 // scopes requested when this app was created
 	ManagedByUserID string   `bun:"type:CHAR(26),nullzero"`                   // id of the user that manages this application, if it was created through the settings panel
+	Public          *bool    `bun:",nullzero,notnull,default:false"`          // application is a public client (eg., a mobile app), which can't keep its client secret confidential
 }
 
 // Implements oauth2.ClientInfo.
@@ -56,8 +60,19 @@ func (a *Application) GetUserID() string {
 
 // Implements oauth2.IsPublic.
 func (a *Application) IsPublic() bool {
+	return a.Public != nil && *a.Public
+}
 
-	// this maintains behaviour with the
-	// previous version of oauth2 lib.
-	return false
+// Implements oauth2.ClientPasswordVerifier.
+func (a *Application) VerifyPassword(secret string) bool {
+	if a.IsPublic() {
+		// Public clients can't keep
+		// a secret, so they're not
+		// expected to provide one.
+		return true
+	}
+	return subtle.ConstantTimeCompare(
+		[]byte(a.ClientSecret),
+		[]byte(secret),
+	) == 1
 }
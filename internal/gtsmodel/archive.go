@@ -0,0 +1,67 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Archive represents a downloadable archive of an
+// account's data (statuses, media, profile, likes
+// and bookmarks), built in the background at the
+// request of the account owner.
+type Archive struct {
+	ID        string       `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt time.Time    `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt time.Time    `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID string       `bun:"type:CHAR(26),nullzero,notnull"`                              // Account this archive belongs to.
+	State     ArchiveState `bun:",nullzero,notnull"`                                           // State of building this archive.
+	Key       string       `bun:",nullzero"`                                                   // Storage key of the archive file, once ready.
+	Size      int64        `bun:",nullzero"`                                                   // Size of the archive file in bytes, once ready.
+}
+
+// ArchiveState represents the
+// state of building an archive.
+type ArchiveState enumType
+
+const (
+	ArchiveStateUnknown ArchiveState = 0
+
+	// Archive is queued to be built.
+	ArchiveStatePending ArchiveState = 1
+
+	// Archive has been built and
+	// can be downloaded.
+	ArchiveStateReady ArchiveState = 2
+
+	// Building the archive failed.
+	ArchiveStateFailed ArchiveState = 3
+)
+
+// String returns a stringified, frontend
+// API compatible form of ArchiveState.
+func (s ArchiveState) String() string {
+	switch s {
+	case ArchiveStatePending:
+		return "pending"
+	case ArchiveStateReady:
+		return "ready"
+	case ArchiveStateFailed:
+		return "failed"
+	default:
+		panic("invalid archive state")
+	}
+}
@@ -0,0 +1,72 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// CardType represents the type
+// of a link preview card.
+type CardType enumType
+
+const (
+	CardTypeLink  CardType = 1 // Generic link preview.
+	CardTypePhoto CardType = 2 // Link to a photo.
+	CardTypeVideo CardType = 3 // Link to a video.
+	CardTypeRich  CardType = 4 // Link to some other rich content.
+)
+
+// String returns a stringified,
+// frontend API compatible form
+// of CardType.
+func (t CardType) String() string {
+	switch t {
+	case CardTypeLink:
+		return "link"
+	case CardTypePhoto:
+		return "photo"
+	case CardTypeVideo:
+		return "video"
+	case CardTypeRich:
+		return "rich"
+	default:
+		panic("undefined CardType")
+	}
+}
+
+// Card represents a link preview card, generated from
+// OpenGraph and / or oEmbed metadata at the given URL.
+// Cards are shared between all statuses linking to URL.
+type Card struct {
+	ID           string           `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // ID of this item in the database.
+	CreatedAt    time.Time        `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // Time when this item was created.
+	UpdatedAt    time.Time        `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // Time when this item was last updated.
+	FetchedAt    time.Time        `bun:"type:timestamptz,nullzero"`                                   // Time when metadata at URL was last fetched.
+	URL          string           `bun:",nullzero,notnull,unique"`                                    // Normalized URL of the linked resource.
+	Type         CardType         `bun:",nullzero,notnull,default:1"`                                 // Type of this preview card.
+	Title        string           `bun:""`                                                            // Title of the linked resource.
+	Description  string           `bun:""`                                                            // Description of the linked resource.
+	AuthorName   string           `bun:""`                                                            // Name of the author of the linked resource.
+	AuthorURL    string           `bun:""`                                                            // URL of the author of the linked resource.
+	ProviderName string           `bun:""`                                                            // Name of the provider of the linked resource.
+	ProviderURL  string           `bun:""`                                                            // URL of the provider of the linked resource.
+	EmbedURL     string           `bun:""`                                                            // URL of embeddable media, for photo cards.
+	Width        int              `bun:",nullzero"`                                                   // Width of the linked resource, in pixels.
+	Height       int              `bun:",nullzero"`                                                   // Height of the linked resource, in pixels.
+	ImageID      string           `bun:"type:CHAR(26),nullzero"`                                      // ID of the cached thumbnail media attachment.
+	Image        *MediaAttachment `bun:"-"`                                                           // Cached thumbnail corresponding to ImageID.
+}
@@ -0,0 +1,52 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// DeviceAuthorization represents an OAuth 2.0 device
+// authorization request, as per RFC 8628, made by a
+// client that can't easily drive a browser itself,
+// such as a CLI application, or an app on a TV.
+//
+// The client polls the token endpoint with DeviceCode,
+// while the user enters UserCode on a web page of this
+// instance to sign in and approve the request.
+type DeviceAuthorization struct {
+	ID           string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt    time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	ExpiresAt    time.Time `bun:"type:timestamptz,nullzero,notnull"`                           // time after which device and user code can no longer be used
+	ClientID     string    `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the client who requested device authorization
+	Scope        string    `bun:",nullzero,notnull,default:'read'"`                            // Oauth scope requested by the client
+	DeviceCode   string    `bun:",nullzero,notnull,unique"`                                    // code used by the client to poll for an access token
+	UserCode     string    `bun:",nullzero,notnull,unique"`                                    // short code entered by the user to approve the request
+	UserID       string    `bun:"type:CHAR(26),nullzero"`                                      // ID of the user who approved the request, if approved
+	LastPolledAt time.Time `bun:"type:timestamptz,nullzero"`                                   // time when the client last polled for an access token
+}
+
+// IsApproved returns true if a
+// user has approved the request.
+func (d *DeviceAuthorization) IsApproved() bool {
+	return d.UserID != ""
+}
+
+// IsExpired returns true if the
+// device + user codes have expired.
+func (d *DeviceAuthorization) IsExpired() bool {
+	return !d.ExpiresAt.After(time.Now())
+}
@@ -0,0 +1,75 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Import represents a user's import of a data file
+// (eg., a Mastodon-compatible CSV file) to their account,
+// processed in the background, along with its progress.
+type Import struct {
+	ID             string      `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt      time.Time   `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt      time.Time   `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID      string      `bun:"type:CHAR(26),nullzero,notnull"`                              // Account this import belongs to.
+	Type           string      `bun:",nullzero,notnull"`                                           // Type of data imported, eg., "following".
+	Overwrite      *bool       `bun:",nullzero,notnull,default:false"`                             // Whether existing entries are overwritten.
+	State          ImportState `bun:",nullzero,notnull"`                                           // State of processing this import.
+	TotalItems     int         `bun:",notnull,default:0"`                                          // Number of entries found in the data file.
+	ProcessedItems int         `bun:",notnull,default:0"`                                          // Number of entries processed so far.
+	FailedItems    int         `bun:",notnull,default:0"`                                          // Number of processed entries that failed.
+}
+
+// ImportState represents the
+// state of processing an import.
+type ImportState enumType
+
+const (
+	ImportStateUnknown ImportState = 0
+
+	// Import entries are
+	// being processed.
+	ImportStateInProgress ImportState = 1
+
+	// All import entries
+	// have been processed.
+	ImportStateFinished ImportState = 2
+)
+
+// String returns a stringified, frontend
+// API compatible form of ImportState.
+func (s ImportState) String() string {
+	switch s {
+	case ImportStateInProgress:
+		return "in_progress"
+	case ImportStateFinished:
+		return "finished"
+	default:
+		panic("invalid import state")
+	}
+}
+
+// ImportFailure represents one entry of
+// an import that could not be processed.
+type ImportFailure struct {
+	ID        string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	ImportID  string    `bun:"type:CHAR(26),nullzero,notnull"`                              // Import this failure belongs to.
+	Entry     string    `bun:",nullzero,notnull"`                                           // The failed entry, eg., an account address.
+	Error     string    `bun:",nullzero,notnull"`                                           // Why the entry failed.
+}
@@ -21,27 +21,61 @@
 
 // Instance represents a federated instance, either local or remote.
 type Instance struct {
-	ID                     string       `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
-	CreatedAt              time.Time    `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
-	UpdatedAt              time.Time    `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
-	Domain                 string       `bun:",nullzero,notnull,unique"`                                    // Instance domain eg example.org
-	Title                  string       `bun:""`                                                            // Title of this instance as it would like to be displayed.
-	URI                    string       `bun:",nullzero,notnull,unique"`                                    // base URI of this instance eg https://example.org
-	SuspendedAt            time.Time    `bun:"type:timestamptz,nullzero"`                                   // When was this instance suspended, if at all?
-	DomainBlockID          string       `bun:"type:CHAR(26),nullzero"`                                      // ID of any existing domain block for this instance in the database
-	DomainBlock            *DomainBlock `bun:"rel:belongs-to"`                                              // Domain block corresponding to domainBlockID
-	ShortDescription       string       `bun:""`                                                            // Short description of this instance
-	ShortDescriptionText   string       `bun:""`                                                            // Raw text version of short description (before parsing).
-	Description            string       `bun:""`                                                            // Longer description of this instance.
-	DescriptionText        string       `bun:""`                                                            // Raw text version of long description (before parsing).
-	CustomCSS              string       `bun:",nullzero"`                                                   // Custom CSS for the instance.
-	Terms                  string       `bun:""`                                                            // Terms and conditions of this instance.
-	TermsText              string       `bun:""`                                                            // Raw text version of terms (before parsing).
-	ContactEmail           string       `bun:""`                                                            // Contact email address for this instance
-	ContactAccountUsername string       `bun:",nullzero"`                                                   // Username of the contact account for this instance
-	ContactAccountID       string       `bun:"type:CHAR(26),nullzero"`                                      // Contact account ID in the database for this instance
-	ContactAccount         *Account     `bun:"rel:belongs-to"`                                              // account corresponding to contactAccountID
-	Reputation             int64        `bun:",notnull,default:0"`                                          // Reputation score of this instance
-	Version                string       `bun:",nullzero"`                                                   // Version of the software used on this instance
-	Rules                  []Rule       `bun:"-"`                                                           // List of instance rules
+	ID                     string          `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt              time.Time       `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt              time.Time       `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Domain                 string          `bun:",nullzero,notnull,unique"`                                    // Instance domain eg example.org
+	Title                  string          `bun:""`                                                            // Title of this instance as it would like to be displayed.
+	URI                    string          `bun:",nullzero,notnull,unique"`                                    // base URI of this instance eg https://example.org
+	SuspendedAt            time.Time       `bun:"type:timestamptz,nullzero"`                                   // When was this instance suspended, if at all?
+	DomainBlockID          string          `bun:"type:CHAR(26),nullzero"`                                      // ID of any existing domain block for this instance in the database
+	DomainBlock            *DomainBlock    `bun:"rel:belongs-to"`                                              // Domain block corresponding to domainBlockID
+	ShortDescription       string          `bun:""`                                                            // Short description of this instance
+	ShortDescriptionText   string          `bun:""`                                                            // Raw text version of short description (before parsing).
+	Description            string          `bun:""`                                                            // Longer description of this instance.
+	DescriptionText        string          `bun:""`                                                            // Raw text version of long description (before parsing).
+	CustomCSS              string          `bun:",nullzero"`                                                   // Custom CSS for the instance.
+	Terms                  string          `bun:""`                                                            // Terms and conditions of this instance.
+	TermsText              string          `bun:""`                                                            // Raw text version of terms (before parsing).
+	ContactEmail           string          `bun:""`                                                            // Contact email address for this instance
+	ContactAccountUsername string          `bun:",nullzero"`                                                   // Username of the contact account for this instance
+	ContactAccountID       string          `bun:"type:CHAR(26),nullzero"`                                      // Contact account ID in the database for this instance
+	ContactAccount         *Account        `bun:"rel:belongs-to"`                                              // account corresponding to contactAccountID
+	Reputation             int64           `bun:",notnull,default:0"`                                          // Reputation score of this instance
+	Version                string          `bun:",nullzero"`                                                   // Version of the software used on this instance
+	Rules                  []Rule          `bun:"-"`                                                           // List of instance rules
+	SignatureScheme        SignatureScheme `bun:",notnull,default:0"`                                          // HTTP signature scheme this instance is known to accept, if any.
+}
+
+// SignatureScheme is an HTTP signature scheme with
+// which we can sign outgoing requests to an instance.
+type SignatureScheme enumType
+
+const (
+	// SignatureSchemeUnknown means we haven't
+	// (yet) successfully signed a request to
+	// this instance, so should try each in turn.
+	SignatureSchemeUnknown SignatureScheme = 0
+
+	// SignatureSchemeCavage is the legacy
+	// draft-cavage-http-signatures scheme.
+	SignatureSchemeCavage SignatureScheme = 1
+
+	// SignatureSchemeRFC9421 is the RFC 9421
+	// HTTP message signatures scheme.
+	SignatureSchemeRFC9421 SignatureScheme = 2
+)
+
+// String returns a stringified form of SignatureScheme.
+func (s SignatureScheme) String() string {
+	switch s {
+	case SignatureSchemeUnknown:
+		return "unknown"
+	case SignatureSchemeCavage:
+		return "draft-cavage"
+	case SignatureSchemeRFC9421:
+		return "rfc9421"
+	default:
+		panic("invalid signature scheme")
+	}
 }
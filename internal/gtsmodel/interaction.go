@@ -19,7 +19,7 @@
 
 import "time"
 
-// Like / Reply / Announce
+// Like / Reply / Announce / Quote
 type InteractionType enumType
 
 const (
@@ -32,6 +32,7 @@
 	InteractionLike     InteractionType = 0
 	InteractionReply    InteractionType = 1
 	InteractionAnnounce InteractionType = 2
+	InteractionQuote    InteractionType = 3
 )
 
 const (
@@ -46,6 +47,10 @@
 	// Suffix to append to the URI of impolite
 	// Announces to mock an AnnounceRequest.
 	AnnounceRequestSuffix = "#AnnounceRequest"
+
+	// Suffix to append to the URI of
+	// impolite quotes to mock a QuoteRequest.
+	QuoteRequestSuffix = "#QuoteRequest"
 )
 
 // A useless function that appends two strings, this exists largely
@@ -70,6 +75,9 @@ func (i InteractionType) String() string {
 	case InteractionAnnounce:
 		const text = "reblog"
 		return text
+	case InteractionQuote:
+		const text = "quote"
+		return text
 	default:
 		panic("undefined InteractionType")
 	}
@@ -134,6 +142,10 @@ type InteractionRequest struct {
 	// Column not stored in DB.
 	Announce *Status `bun:"-"`
 
+	// Set if InteractionType = InteractionQuote.
+	// Column not stored in DB.
+	Quote *Status `bun:"-"`
+
 	// If interaction request was accepted, time at which this occurred.
 	AcceptedAt time.Time `bun:"type:timestamptz,nullzero"`
 
@@ -204,7 +216,7 @@ func (ir *InteractionRequest) IsPolite() bool {
 
 // Interaction abstractly represents
 // one interaction with a status, via
-// liking, replying to, or boosting it.
+// liking, replying to, boosting or quoting it.
 type Interaction interface {
 	GetAccount() *Account
 }
@@ -208,6 +208,10 @@ type InteractionPolicy struct {
 	// interaction will be accepted
 	// for an item with this policy.
 	CanAnnounce *PolicyRules
+	// Conditions in which a Quote
+	// interaction will be accepted
+	// for an item with this policy.
+	CanQuote *PolicyRules
 }
 
 // PolicyRules represents the rules according
@@ -371,10 +375,51 @@ func DefaultCanAnnounceFor(v Visibility) *PolicyRules {
 	}
 }
 
+// DefaultCanQuoteFor returns the default
+// policy rules for the canQuote sub-policy.
+func DefaultCanQuoteFor(v Visibility) *PolicyRules {
+	switch v {
+
+	// Anyone can quote,
+	// pending approval.
+	case VisibilityPublic, VisibilityUnlocked:
+		return &PolicyRules{
+			AutomaticApproval: PolicyValues{
+				PolicyValueAuthor,
+			},
+			ManualApproval: PolicyValues{
+				PolicyValuePublic,
+			},
+		}
+
+	// Only self can quote.
+	case VisibilityFollowersOnly, VisibilityMutualsOnly:
+		return &PolicyRules{
+			AutomaticApproval: PolicyValues{
+				PolicyValueAuthor,
+			},
+			ManualApproval: make(PolicyValues, 0),
+		}
+
+	// Only self can quote.
+	case VisibilityDirect:
+		return &PolicyRules{
+			AutomaticApproval: PolicyValues{
+				PolicyValueAuthor,
+			},
+			ManualApproval: make(PolicyValues, 0),
+		}
+
+	default:
+		panic("invalid visibility")
+	}
+}
+
 var defaultPolicyPublic = &InteractionPolicy{
 	CanLike:     DefaultCanLikeFor(VisibilityPublic),
 	CanReply:    DefaultCanReplyFor(VisibilityPublic),
 	CanAnnounce: DefaultCanAnnounceFor(VisibilityPublic),
+	CanQuote:    DefaultCanQuoteFor(VisibilityPublic),
 }
 
 // Returns a default interaction policy
@@ -397,6 +442,7 @@ func DefaultInteractionPolicyUnlocked() *InteractionPolicy {
 	CanLike:     DefaultCanLikeFor(VisibilityFollowersOnly),
 	CanReply:    DefaultCanReplyFor(VisibilityFollowersOnly),
 	CanAnnounce: DefaultCanAnnounceFor(VisibilityFollowersOnly),
+	CanQuote:    DefaultCanQuoteFor(VisibilityFollowersOnly),
 }
 
 // Returns a default interaction policy for
@@ -412,6 +458,7 @@ func DefaultInteractionPolicyFollowersOnly() *InteractionPolicy {
 	CanLike:     DefaultCanLikeFor(VisibilityDirect),
 	CanReply:    DefaultCanReplyFor(VisibilityDirect),
 	CanAnnounce: DefaultCanAnnounceFor(VisibilityDirect),
+	CanQuote:    DefaultCanQuoteFor(VisibilityDirect),
 }
 
 // Returns a default interaction policy
@@ -0,0 +1,55 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Invite represents an invite link generated by a local
+// account, which lets new users sign up to the instance
+// even when registration is closed, without needing to
+// wait in the sign-up approval queue.
+type Invite struct {
+	ID         string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // ID of this item in the database.
+	CreatedAt  time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // Time when this item was created.
+	UpdatedAt  time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // Time when this item was last updated.
+	Code       string    `bun:",nullzero,notnull,unique"`                                    // Random code used in the invite link.
+	AccountID  string    `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the local account that created this invite.
+	Account    *Account  `bun:"-"`                                                           // Account corresponding to AccountID.
+	MaxUses    int       `bun:",nullzero"`                                                   // Maximum number of sign-ups allowed with this invite. 0 = no limit.
+	Uses       int       `bun:",notnull,default:0"`                                          // Number of sign-ups made with this invite so far.
+	ExpiresAt  time.Time `bun:"type:timestamptz,nullzero"`                                   // Time after which this invite can no longer be used. Zero = never expires.
+	Autofollow *bool     `bun:",nullzero,notnull,default:false"`                             // Make new users signing up with this invite follow the inviting account.
+}
+
+// Expired returns whether the invite
+// has passed its expiry time, if set.
+func (i *Invite) Expired(now time.Time) bool {
+	return !i.ExpiresAt.IsZero() && !now.Before(i.ExpiresAt)
+}
+
+// Exhausted returns whether the invite has
+// been used its maximum number of times, if set.
+func (i *Invite) Exhausted() bool {
+	return i.MaxUses > 0 && i.Uses >= i.MaxUses
+}
+
+// Valid returns whether the invite can
+// still be used to sign up at given time.
+func (i *Invite) Valid(now time.Time) bool {
+	return !i.Expired(now) && !i.Exhausted()
+}
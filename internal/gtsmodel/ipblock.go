@@ -0,0 +1,98 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import (
+	"net/netip"
+	"time"
+)
+
+// IPBlock represents a range of IP addresses
+// from which sign-ups and / or access to this
+// instance are restricted by an admin.
+type IPBlock struct {
+	ID                 string          `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time       `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt          time.Time       `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	IP                 string          `bun:",nullzero,notnull,unique"`                                    // Blocked IP range in CIDR notation, eg., '192.0.2.0/24'.
+	Severity           IPBlockSeverity `bun:",nullzero,notnull"`                                           // What the block applies to.
+	Comment            string          `bun:",nullzero"`                                                   // Optional admin comment explaining the block.
+	ExpiresAt          time.Time       `bun:"type:timestamptz,nullzero"`                                   // When does this block expire (if ever).
+	CreatedByAccountID string          `bun:"type:CHAR(26),nullzero,notnull"`                              // Account ID of the creator of this block
+}
+
+// Expired returns whether this IP block
+// has expired as of the given time.
+func (b *IPBlock) Expired(now time.Time) bool {
+	return !b.ExpiresAt.IsZero() && !b.ExpiresAt.After(now)
+}
+
+// Prefix returns the parsed IP range of this block.
+func (b *IPBlock) Prefix() (netip.Prefix, error) {
+	return netip.ParsePrefix(b.IP)
+}
+
+// IPBlockSeverity represents what an IP block applies to.
+// Higher severities are strictly more restrictive than
+// lower ones, so they can be compared with < and >.
+type IPBlockSeverity enumType
+
+const (
+	IPBlockSeverityUnknown IPBlockSeverity = 0
+
+	// Sign-ups from the range are allowed,
+	// but always require admin approval.
+	IPBlockSeveritySignUpRequiresApproval IPBlockSeverity = 1
+
+	// Sign-ups from the range are rejected.
+	IPBlockSeveritySignUpBlock IPBlockSeverity = 2
+
+	// All requests from the range are rejected.
+	IPBlockSeverityNoAccess IPBlockSeverity = 3
+)
+
+// String returns a stringified, frontend
+// API compatible form of IPBlockSeverity.
+func (s IPBlockSeverity) String() string {
+	switch s {
+	case IPBlockSeveritySignUpRequiresApproval:
+		return "sign_up_requires_approval"
+	case IPBlockSeveritySignUpBlock:
+		return "sign_up_block"
+	case IPBlockSeverityNoAccess:
+		return "no_access"
+	default:
+		panic("invalid ip block severity")
+	}
+}
+
+// ParseIPBlockSeverity returns an IP block
+// severity from the given value, or
+// IPBlockSeverityUnknown if not recognized.
+func ParseIPBlockSeverity(in string) IPBlockSeverity {
+	switch in {
+	case "sign_up_requires_approval":
+		return IPBlockSeveritySignUpRequiresApproval
+	case "sign_up_block":
+		return IPBlockSeveritySignUpBlock
+	case "no_access":
+		return IPBlockSeverityNoAccess
+	default:
+		return IPBlockSeverityUnknown
+	}
+}
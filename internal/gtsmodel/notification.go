@@ -18,6 +18,7 @@
 package gtsmodel
 
 import (
+	"slices"
 	"strings"
 	"time"
 )
@@ -35,6 +36,7 @@ type Notification struct {
 	StatusOrEditID   string           `bun:"status_id,type:CHAR(26),nullzero"`                            // If the notification pertains to a status or a status edit event, what is the database ID of the status or status edit?
 	Status           *Status          `bun:"-"`                                                           // Status corresponding to StatusOrEditID. Can be nil, always check first + select using ID if necessary.
 	Read             *bool            `bun:",nullzero,notnull,default:false"`                             // Notification has been seen/read
+	Filtered         *bool            `bun:",nullzero,notnull,default:false"`                             // Notification was filtered by the target account's notification policy, see NotificationRequest.
 }
 
 // NotificationType describes the
@@ -57,7 +59,10 @@ type Notification struct {
 	NotificationPendingReblog NotificationType = 11 // NotificationPendingReblog -- Someone has boosted a status of yours, which requires approval by you.
 	NotificationAdminReport   NotificationType = 12 // NotificationAdminReport -- someone has submitted a new report to the instance.
 	NotificationUpdate        NotificationType = 13 // NotificationUpdate -- someone has edited their status.
-	NotificationTypeNumValues NotificationType = 14 // NotificationTypeNumValues -- 1 + number of max notification type
+	NotificationEmojiReaction NotificationType = 14 // NotificationEmojiReaction -- someone reacted to one of your statuses with an emoji.
+	NotificationQuote         NotificationType = 15 // NotificationQuote -- someone quoted one of your statuses.
+	NotificationPendingQuote  NotificationType = 16 // NotificationPendingQuote -- Someone has quoted a status of yours, which requires approval by you.
+	NotificationTypeNumValues NotificationType = 17 // NotificationTypeNumValues -- 1 + number of max notification type
 )
 
 // String returns a stringified, frontend API compatible form of NotificationType.
@@ -89,6 +94,12 @@ func (t NotificationType) String() string {
 		return "admin.report"
 	case NotificationUpdate:
 		return "update"
+	case NotificationEmojiReaction:
+		return "pleroma:emoji_reaction"
+	case NotificationQuote:
+		return "quote"
+	case NotificationPendingQuote:
+		return "pending.quote"
 	default:
 		panic("invalid notification type")
 	}
@@ -123,7 +134,85 @@ func ParseNotificationType(in string) NotificationType {
 		return NotificationAdminReport
 	case "update":
 		return NotificationUpdate
+	case "pleroma:emoji_reaction":
+		return NotificationEmojiReaction
+	case "quote":
+		return NotificationQuote
+	case "pending.quote":
+		return NotificationPendingQuote
 	default:
 		return NotificationUnknown
 	}
 }
+
+// NotificationFlags is a bitfield representation of a set of NotificationType.
+type NotificationFlags int64
+
+// NotificationFlagsFromSlice packs a slice of NotificationType into NotificationFlags.
+func NotificationFlagsFromSlice(notificationTypes []NotificationType) NotificationFlags {
+	var n NotificationFlags
+	for _, notificationType := range notificationTypes {
+		n.Set(notificationType, true)
+	}
+	return n
+}
+
+// ToSlice unpacks NotificationFlags into a slice of NotificationType.
+func (n *NotificationFlags) ToSlice() []NotificationType {
+	notificationTypes := make([]NotificationType, 0, NotificationTypeNumValues)
+	for notificationType := NotificationUnknown; notificationType < NotificationTypeNumValues; notificationType++ {
+		if n.Get(notificationType) {
+			notificationTypes = append(notificationTypes, notificationType)
+		}
+	}
+	return notificationTypes
+}
+
+// Get tests to see if a given NotificationType is included in this set of flags.
+func (n *NotificationFlags) Get(notificationType NotificationType) bool {
+	return *n&(1<<notificationType) != 0
+}
+
+// Set adds or removes a given NotificationType to or from this set of flags.
+func (n *NotificationFlags) Set(notificationType NotificationType, value bool) {
+	if value {
+		*n |= 1 << notificationType
+	} else {
+		*n &= ^(1 << notificationType)
+	}
+}
+
+// NotificationGroupedTypes are the notification
+// types that are grouped together by default
+// by the grouped notifications (v2) API.
+var NotificationGroupedTypes = []NotificationType{
+	NotificationFavourite,
+	NotificationReblog,
+	NotificationFollow,
+}
+
+// GroupKey returns the key of the notification group this notification
+// belongs to, when grouping the given notification types.
+//
+// Notifications of grouped types about a status are grouped by type and
+// status (the boosted status, in case of reblogs), while other grouped
+// notifications are grouped by type and UTC day. Notifications of types
+// that are not grouped each get their own "ungrouped-" prefixed key.
+//
+// Status must be populated for reblog notifications.
+func (n *Notification) GroupKey(groupedTypes []NotificationType) string {
+	if !slices.Contains(groupedTypes, n.NotificationType) {
+		return "ungrouped-" + n.ID
+	}
+
+	statusID := n.StatusOrEditID
+	if n.Status != nil && n.Status.BoostOfID != "" {
+		statusID = n.Status.BoostOfID
+	}
+
+	if statusID != "" {
+		return n.NotificationType.String() + "-" + statusID
+	}
+
+	return n.NotificationType.String() + "-" + n.CreatedAt.UTC().Format("20060102")
+}
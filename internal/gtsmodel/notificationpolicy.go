@@ -0,0 +1,117 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import (
+	"strings"
+	"time"
+)
+
+// NotificationPolicy models a local account's choices
+// for handling notifications from accounts that match
+// certain conditions, eg., accounts they don't follow.
+type NotificationPolicy struct {
+	AccountID          string                  `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // AccountID that owns this policy.
+	CreatedAt          time.Time               `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created.
+	UpdatedAt          time.Time               `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item was last updated.
+	ForNotFollowing    NotificationPolicyValue `bun:",nullzero,notnull,default:1"`                                 // Handling of notifications from accounts the owner doesn't follow.
+	ForNotFollowers    NotificationPolicyValue `bun:",nullzero,notnull,default:1"`                                 // Handling of notifications from accounts that don't follow the owner.
+	ForNewAccounts     NotificationPolicyValue `bun:",nullzero,notnull,default:1"`                                 // Handling of notifications from accounts created within the past 30 days.
+	ForPrivateMentions NotificationPolicyValue `bun:",nullzero,notnull,default:2"`                                 // Handling of unsolicited private mentions from accounts the owner doesn't follow.
+	ForLimitedAccounts NotificationPolicyValue `bun:",nullzero,notnull,default:2"`                                 // Handling of notifications from silenced accounts the owner doesn't follow.
+}
+
+// DefaultNotificationPolicy returns the notification
+// policy used for accounts that haven't set one yet.
+func DefaultNotificationPolicy(accountID string) *NotificationPolicy {
+	return &NotificationPolicy{
+		AccountID:          accountID,
+		ForNotFollowing:    NotificationPolicyAccept,
+		ForNotFollowers:    NotificationPolicyAccept,
+		ForNewAccounts:     NotificationPolicyAccept,
+		ForPrivateMentions: NotificationPolicyFilter,
+		ForLimitedAccounts: NotificationPolicyFilter,
+	}
+}
+
+// NotificationPolicyValue describes how notifications
+// matching a notification policy condition are handled.
+type NotificationPolicyValue enumType
+
+const (
+	NotificationPolicyUnknown NotificationPolicyValue = 0 // NotificationPolicyUnknown -- unknown value.
+	NotificationPolicyAccept  NotificationPolicyValue = 1 // NotificationPolicyAccept -- notify as normal.
+	NotificationPolicyFilter  NotificationPolicyValue = 2 // NotificationPolicyFilter -- store notification, but only show it in notification requests.
+	NotificationPolicyDrop    NotificationPolicyValue = 3 // NotificationPolicyDrop -- don't create a notification at all.
+)
+
+// String returns a stringified, frontend
+// API compatible form of NotificationPolicyValue.
+func (v NotificationPolicyValue) String() string {
+	switch v {
+	case NotificationPolicyAccept:
+		return "accept"
+	case NotificationPolicyFilter:
+		return "filter"
+	case NotificationPolicyDrop:
+		return "drop"
+	default:
+		panic("invalid notification policy value")
+	}
+}
+
+// ParseNotificationPolicyValue returns a
+// notification policy value from the given value.
+func ParseNotificationPolicyValue(in string) NotificationPolicyValue {
+	switch strings.ToLower(in) {
+	case "accept":
+		return NotificationPolicyAccept
+	case "filter":
+		return NotificationPolicyFilter
+	case "drop":
+		return NotificationPolicyDrop
+	default:
+		return NotificationPolicyUnknown
+	}
+}
+
+// NotificationRequest models a collection of notifications
+// for an account that were filtered by their notification
+// policy, grouped by the account that triggered them.
+type NotificationRequest struct {
+	ID                 string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                                    // ID of this item in the database.
+	CreatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                                 // Time when this item was created.
+	UpdatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                                 // Time when this item was last updated.
+	AccountID          string    `bun:"type:CHAR(26),unique:notification_requests_account_id_from_account_id_uniq,nullzero,notnull"` // ID of the account that the filtered notifications target.
+	Account            *Account  `bun:"-"`                                                                                           // Account corresponding to AccountID.
+	FromAccountID      string    `bun:"type:CHAR(26),unique:notification_requests_account_id_from_account_id_uniq,nullzero,notnull"` // ID of the account that triggered the filtered notifications.
+	FromAccount        *Account  `bun:"-"`                                                                                           // Account corresponding to FromAccountID.
+	LastStatusID       string    `bun:"type:CHAR(26),nullzero"`                                                                      // ID of the most recent status of filtered notifications, if any.
+	LastStatus         *Status   `bun:"-"`                                                                                           // Status corresponding to LastStatusID.
+	NotificationsCount int       `bun:",nullzero,notnull,default:0"`                                                                 // Number of filtered notifications collected by this request.
+}
+
+// NotificationPermission models an account having accepted
+// a notification request, so that further notifications from
+// FromAccountID bypass the account's notification policy.
+type NotificationPermission struct {
+	ID            string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                                       // ID of this item in the database.
+	CreatedAt     time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                                    // Time when this item was created.
+	AccountID     string    `bun:"type:CHAR(26),unique:notification_permissions_account_id_from_account_id_uniq,nullzero,notnull"` // ID of the account that gave permission.
+	FromAccountID string    `bun:"type:CHAR(26),unique:notification_permissions_account_id_from_account_id_uniq,nullzero,notnull"` // ID of the account permitted to notify AccountID.
+}
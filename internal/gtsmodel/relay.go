@@ -0,0 +1,70 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// RelayState represents the state of
+// our subscription to an ActivityPub relay.
+type RelayState enumType
+
+const (
+	RelayStatePending  RelayState = 1 // Follow sent to relay, no response yet.
+	RelayStateAccepted RelayState = 2 // Relay Accepted our Follow.
+	RelayStateRejected RelayState = 3 // Relay Rejected our Follow.
+)
+
+// String returns a stringified,
+// frontend API compatible form
+// of RelayState.
+func (s RelayState) String() string {
+	switch s {
+	case RelayStatePending:
+		return "pending"
+	case RelayStateAccepted:
+		return "accepted"
+	case RelayStateRejected:
+		return "rejected"
+	default:
+		panic("undefined RelayState")
+	}
+}
+
+// Relay represents a subscription of this instance to an
+// ActivityPub relay, made by the instance actor following
+// the relay. Once accepted, the relay Announces public
+// posts from other subscribers to us, and we may in turn
+// deliver our own public posts to the relay's inbox.
+type Relay struct {
+	ID                 string     `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // ID of this item in the database.
+	CreatedAt          time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // Time when this item was created.
+	UpdatedAt          time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // Time when this item was last updated.
+	InboxURI           string     `bun:",nullzero,notnull,unique"`                                    // Inbox URI of the relay, to which we deliver.
+	FollowURI          string     `bun:",nullzero,notnull,unique"`                                    // URI of the Follow sent from our instance actor to the relay.
+	AccountID          string     `bun:"type:CHAR(26),nullzero"`                                      // ID of the relay actor account, set once the relay responds to our Follow.
+	Account            *Account   `bun:"-"`                                                           // Relay actor account corresponding to AccountID.
+	State              RelayState `bun:",nullzero,notnull,default:1"`                                 // State of our subscription to the relay.
+	Publish            *bool      `bun:",nullzero,notnull,default:false"`                             // Deliver our local public posts to the relay.
+	CreatedByAccountID string     `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the admin account that added this relay.
+}
+
+// IsAccepted returns whether the
+// relay has accepted our Follow.
+func (r *Relay) IsAccepted() bool {
+	return r.State == RelayStateAccepted
+}
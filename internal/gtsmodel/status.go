@@ -57,11 +57,20 @@ type Status struct {
 	BoostOfAccountID         string             `bun:"type:CHAR(26),nullzero"`                                              // id of the account that owns the boosted status
 	BoostOf                  *Status            `bun:"-"`                                                                   // status that corresponds to boostOfID
 	BoostOfAccount           *Account           `bun:"rel:belongs-to"`                                                      // account that corresponds to boostOfAccountID
+	QuoteOfID                string             `bun:"type:CHAR(26),nullzero"`                                              // id of the status this status quotes
+	QuoteOfURI               string             `bun:",nullzero"`                                                           // activitypub uri of the status this status quotes
+	QuoteOfAccountID         string             `bun:"type:CHAR(26),nullzero"`                                              // id of the account that owns the quoted status
+	QuoteOf                  *Status            `bun:"-"`                                                                   // status that corresponds to quoteOfID
+	QuoteOfAccount           *Account           `bun:"-"`                                                                   // account that corresponds to quoteOfAccountID
+	QuotePendingApproval     *bool              `bun:",nullzero,default:false"`                                             // If true then the quote of quoteOfID must be Approved by the quotee before the quoted status is shown.
+	QuoteApprovedByURI       string             `bun:",nullzero"`                                                           // URI of an Accept Activity which approves the quote of quoteOfID.
 	ThreadID                 string             `bun:"type:CHAR(26),nullzero,notnull,default:'00000000000000000000000000'"` // id of the thread to which this status belongs
 	EditIDs                  []string           `bun:"edits,array"`                                                         // IDs of status edits for this status, ordered from smallest (oldest) -> largest (newest) ID.
 	Edits                    []*StatusEdit      `bun:"-"`                                                                   // Edits of this status, ordered from oldest -> newest edit.
 	PollID                   string             `bun:"type:CHAR(26),nullzero"`                                              //
 	Poll                     *Poll              `bun:"-"`                                                                   //
+	CardID                   string             `bun:"type:CHAR(26),nullzero"`                                              // ID of the link preview card for this status, if any.
+	Card                     *Card              `bun:"-"`                                                                   // Link preview card corresponding to cardID.
 	ContentWarning           string             `bun:",nullzero"`                                                           // Content warning HTML for this status.
 	ContentWarningText       string             `bun:""`                                                                    // Original text of the content warning without formatting
 	Visibility               Visibility         `bun:",nullzero,notnull"`                                                   // visibility entry for this status
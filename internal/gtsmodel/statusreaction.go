@@ -0,0 +1,45 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// StatusReaction refers to an emoji reaction in the database, from one
+// account, targeting the status of another account. Reactions are
+// federated as EmojiReact activities, or Likes with content.
+type StatusReaction struct {
+	ID              string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                              // id of this item in the database
+	CreatedAt       time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`           // when was item created
+	AccountID       string    `bun:"type:CHAR(26),unique:statusreactionaccountstatusname,nullzero,notnull"` // id of the account that created ('did') the reaction
+	Account         *Account  `bun:"-"`                                                                     // account that created the reaction
+	TargetAccountID string    `bun:"type:CHAR(26),nullzero,notnull"`                                        // id the account owning the reacted-to status
+	TargetAccount   *Account  `bun:"-"`                                                                     // account owning the reacted-to status
+	StatusID        string    `bun:"type:CHAR(26),unique:statusreactionaccountstatusname,nullzero,notnull"` // database id of the status that has been reacted to
+	Status          *Status   `bun:"-"`                                                                     // the reacted-to status
+	Name            string    `bun:",unique:statusreactionaccountstatusname,nullzero,notnull"`              // unicode emoji, or custom emoji shortcode (with @domain if remote), of this reaction
+	EmojiID         string    `bun:"type:CHAR(26),nullzero"`                                                // id of the custom emoji used, if any
+	Emoji           *Emoji    `bun:"-"`                                                                     // custom emoji used, if any
+	URI             string    `bun:",nullzero,notnull,unique"`                                              // ActivityPub URI of this reaction
+}
+
+// GetAccount returns the account that owns
+// this reaction. May be nil if reaction not populated.
+// Fulfils Interaction interface.
+func (r *StatusReaction) GetAccount() *Account {
+	return r.Account
+}
@@ -0,0 +1,65 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// SuggestionSource represents a reason
+// for which an account was suggested.
+type SuggestionSource enumType
+
+const (
+	SuggestionSourceStaffPick        SuggestionSource = 1 // Account picked by instance staff.
+	SuggestionSourceFriendsOfFriends SuggestionSource = 2 // Account followed by accounts the requester follows.
+	SuggestionSourceInteractions     SuggestionSource = 3 // Account often interacting with accounts the requester follows.
+)
+
+// String returns a stringified,
+// frontend API compatible form
+// of SuggestionSource.
+func (s SuggestionSource) String() string {
+	switch s {
+	case SuggestionSourceStaffPick:
+		return "featured"
+	case SuggestionSourceFriendsOfFriends:
+		return "friends_of_friends"
+	case SuggestionSourceInteractions:
+		return "most_interactions"
+	default:
+		panic("undefined SuggestionSource")
+	}
+}
+
+// StaffPick marks an account as being picked by instance
+// staff to be suggested to other accounts to follow.
+type StaffPick struct {
+	ID                 string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // ID of this item in the database.
+	CreatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // Time when this item was created.
+	AccountID          string    `bun:"type:CHAR(26),nullzero,notnull,unique"`                       // ID of the picked account.
+	Account            *Account  `bun:"-"`                                                           // Picked account corresponding to AccountID.
+	CreatedByAccountID string    `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the admin account that picked this account.
+}
+
+// SuggestionDismissal marks an account as no longer
+// to be suggested to another account to follow.
+type SuggestionDismissal struct {
+	ID              string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                                      // ID of this item in the database.
+	CreatedAt       time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                                   // Time when this item was created.
+	AccountID       string    `bun:"type:CHAR(26),nullzero,notnull,unique:suggestion_dismissals_account_id_target_account_id_uniq"` // ID of the account that dismissed the suggestion.
+	TargetAccountID string    `bun:"type:CHAR(26),nullzero,notnull,unique:suggestion_dismissals_account_id_target_account_id_uniq"` // ID of the dismissed account.
+}
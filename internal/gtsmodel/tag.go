@@ -38,3 +38,15 @@ type FollowedTag struct {
 	// ID of the tag.
 	TagID string `bun:"type:CHAR(26),pk,nullzero"`
 }
+
+// FeaturedTag represents a tag featured on the profile of an account.
+type FeaturedTag struct {
+	ID            string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt     time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	AccountID     string    `bun:"type:CHAR(26),unique:featuredtagaccounttag,nullzero,notnull"` // id of the account featuring the tag
+	Account       *Account  `bun:"-"`                                                           // account featuring the tag
+	TagID         string    `bun:"type:CHAR(26),unique:featuredtagaccounttag,nullzero,notnull"` // id of the featured tag
+	Tag           *Tag      `bun:"-"`                                                           // the featured tag
+	StatusesCount int       `bun:",notnull,default:0"`                                          // number of public/unlisted statuses by the account using the tag
+	LastStatusAt  time.Time `bun:"type:timestamptz,nullzero"`                                   // creation time of the most recent public/unlisted status by the account using the tag
+}
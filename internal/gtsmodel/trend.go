@@ -0,0 +1,109 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// TrendType represents the
+// type of a trending item.
+type TrendType enumType
+
+const (
+	TrendTypeTag    TrendType = 1 // Trending hashtag.
+	TrendTypeStatus TrendType = 2 // Trending status.
+	TrendTypeLink   TrendType = 3 // Trending link.
+)
+
+// String returns a stringified,
+// frontend API compatible form
+// of TrendType.
+func (t TrendType) String() string {
+	switch t {
+	case TrendTypeTag:
+		return "tag"
+	case TrendTypeStatus:
+		return "status"
+	case TrendTypeLink:
+		return "link"
+	default:
+		panic("undefined TrendType")
+	}
+}
+
+// TrendReviewState represents the
+// admin review state of a trend.
+type TrendReviewState enumType
+
+const (
+	TrendReviewStatePending  TrendReviewState = 1 // Not yet reviewed, don't expose.
+	TrendReviewStateApproved TrendReviewState = 2 // Approved by an admin, expose.
+	TrendReviewStateRejected TrendReviewState = 3 // Rejected by an admin, never expose.
+)
+
+// String returns a stringified,
+// frontend API compatible form
+// of TrendReviewState.
+func (s TrendReviewState) String() string {
+	switch s {
+	case TrendReviewStatePending:
+		return "pending"
+	case TrendReviewStateApproved:
+		return "approved"
+	case TrendReviewStateRejected:
+		return "rejected"
+	default:
+		panic("undefined TrendReviewState")
+	}
+}
+
+// TrendReview represents the admin review state of one
+// item (tag, status, or link) that has at some point been
+// found to be trending. Trends are only exposed publicly
+// once their corresponding review has been approved.
+type TrendReview struct {
+	ID                  string           `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // ID of this item in the database.
+	CreatedAt           time.Time        `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // Time when this item was created.
+	UpdatedAt           time.Time        `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // Time when this item was last updated.
+	TrendType           TrendType        `bun:",nullzero,notnull,unique:trend_reviews_type_target_uniq"`     // Type of the trending item.
+	TargetID            string           `bun:",nullzero,notnull,unique:trend_reviews_type_target_uniq"`     // Tag ID, status ID, or link URL of the trending item.
+	State               TrendReviewState `bun:",nullzero,notnull,default:1"`                                 // Current review state of this trend.
+	ReviewedAt          time.Time        `bun:"type:timestamptz,nullzero"`                                   // Time at which this trend was approved or rejected.
+	ReviewedByAccountID string           `bun:"type:CHAR(26),nullzero"`                                      // ID of the admin account that approved or rejected this trend.
+}
+
+// IsPending returns true if the
+// trend has not yet been reviewed.
+func (t *TrendReview) IsPending() bool {
+	return t.State == TrendReviewStatePending
+}
+
+// IsApproved returns true if the trend has been
+// approved by an admin, and may be exposed.
+func (t *TrendReview) IsApproved() bool {
+	return t.State == TrendReviewStateApproved
+}
+
+// TrendSample represents one recorded use of a
+// potentially trending item, gathered from the
+// database when (re)calculating trends. This
+// is a query result only, it isn't stored.
+type TrendSample struct {
+	TargetID  string    `bun:"target_id"`  // ID of the used item (tag ID, status ID, link URL).
+	AccountID string    `bun:"account_id"` // ID of the account that used the item.
+	CreatedAt time.Time `bun:"created_at"` // Time at which the item was used.
+}
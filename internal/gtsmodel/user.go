@@ -206,6 +206,7 @@ type NewSignup struct {
 	Reason        string // Reason given by the user when submitting a sign up request (optional).
 	PreApproved   bool   // Mark the new user/account as preapproved (optional)
 	SignUpIP      net.IP // IP address from which the sign up request occurred (optional).
+	InviteID      string // ID of the invite used to sign up (optional).
 	Locale        string // Locale code for the new account/user (optional).
 	AppID         string // ID of the application used to create this account (optional).
 	EmailVerified bool   // Mark submitted email address as already verified (optional).
@@ -24,8 +24,10 @@
 	"net/http"
 	"net/http/httptest"
 	"net/netip"
+	"slices"
 	"testing"
 
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
 	"code.superseriousbusiness.org/gotosocial/internal/httpclient"
 )
 
@@ -116,3 +118,80 @@ func TestHTTPClientPrivateIP(t *testing.T) {
 		}
 	}
 }
+
+func TestHTTPClientDoubleKnock(t *testing.T) {
+	const body = "hello world!"
+
+	// Create new HTTP client allowing loopback.
+	client := httpclient.New(httpclient.Config{
+		AllowRanges: []netip.Prefix{
+			// Loopback (used by server)
+			netip.MustParsePrefix("127.0.0.1/8"),
+		},
+	})
+
+	// Set test handler only accepting
+	// requests signed with scheme "b".
+	var signatures []string
+	handler := http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		signatures = append(signatures, r.Header.Get("Signature"))
+		b, _ := io.ReadAll(r.Body)
+		if string(b) != body {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.Header.Get("Signature") != "b" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		rw.WriteHeader(http.StatusOK)
+	})
+
+	// Start the test server
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+
+	signWith := func(sig string) func(*http.Request) error {
+		return func(r *http.Request) error {
+			r.Header.Set("Signature", sig)
+			return nil
+		}
+	}
+
+	// Knock func retries with scheme
+	// "b" on 401, recording all results.
+	var results []int
+	knock := func(r *http.Request, rsp *http.Response) func(*http.Request) error {
+		results = append(results, rsp.StatusCode)
+		if rsp.StatusCode == http.StatusUnauthorized {
+			return signWith("b")
+		}
+		return nil
+	}
+
+	// Prepare request context with signing funcs.
+	ctx := gtscontext.SetHTTPClientSignFunc(t.Context(), signWith("a"))
+	ctx = gtscontext.SetHTTPClientKnockFunc(ctx, knock)
+
+	// Create the test HTTP request
+	req, _ := http.NewRequestWithContext(ctx, "POST", srv.URL, bytes.NewReader([]byte(body)))
+
+	// Perform the test request
+	rsp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("error performing client request: %v", err)
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		t.Errorf("unexpected response status: %d", rsp.StatusCode)
+	}
+
+	if !slices.Equal(signatures, []string{"a", "b"}) {
+		t.Errorf("unexpected request signatures: %v", signatures)
+	}
+
+	if !slices.Equal(results, []int{http.StatusUnauthorized, http.StatusOK}) {
+		t.Errorf("unexpected knock results: %v", results)
+	}
+}
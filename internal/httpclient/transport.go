@@ -37,19 +37,75 @@ func (t *signingtransport) RoundTrip(r *http.Request) (*http.Response, error) {
 	// Ensure updated host always set.
 	r.Header.Set("Host", r.URL.Host)
 
-	if sign := gtscontext.HTTPClientSignFunc(r.Context()); sign != nil {
-		// Reset signing header fields
-		now := time.Now().UTC()
-		r.Header.Set("Date", now.Format("Mon, 02 Jan 2006 15:04:05")+" GMT")
-		r.Header.Del("Signature")
-		r.Header.Del("Digest")
-
+	ctx := r.Context()
+	if sign := gtscontext.HTTPClientSignFunc(ctx); sign != nil {
 		// Sign the outgoing request.
-		if err := sign(r); err != nil {
+		if err := resign(r, sign); err != nil {
 			return nil, err
 		}
 	}
 
 	// Pass to underlying transport.
-	return t.Transport.RoundTrip(r)
+	rsp, err := t.Transport.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+
+	knock := gtscontext.HTTPClientKnockFunc(ctx)
+	if knock == nil {
+		return rsp, nil
+	}
+
+	// Check whether the response calls for
+	// a retry with an alternative signature.
+	sign := knock(r, rsp)
+	if sign == nil {
+		return rsp, nil
+	}
+
+	// Take a copy of request for retry.
+	r2 := r.Clone(ctx)
+	if r.Body != nil {
+		if r.GetBody == nil {
+			// Body can't be rewound,
+			// accept first response.
+			return rsp, nil
+		}
+
+		r2.Body, err = r.GetBody()
+		if err != nil {
+			return rsp, nil
+		}
+	}
+
+	// Done with first response.
+	_ = rsp.Body.Close()
+
+	// Sign using alternative scheme.
+	if err := resign(r2, sign); err != nil {
+		return nil, err
+	}
+
+	rsp, err = t.Transport.RoundTrip(r2)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pass the retry result to knock func
+	// so it can record negotiation outcome.
+	_ = knock(r2, rsp)
+
+	return rsp, nil
+}
+
+// resign resets signing header fields
+// on request and signs it with given func.
+func resign(r *http.Request, sign func(*http.Request) error) error {
+	now := time.Now().UTC()
+	r.Header.Set("Date", now.Format("Mon, 02 Jan 2006 15:04:05")+" GMT")
+	r.Header.Del("Signature")
+	r.Header.Del("Signature-Input")
+	r.Header.Del("Digest")
+	r.Header.Del("Content-Digest")
+	return sign(r)
 }
@@ -0,0 +1,186 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"slices"
+
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/regexes"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"code.superseriousbusiness.org/gotosocial/internal/storage"
+	"code.superseriousbusiness.org/gotosocial/internal/uris"
+)
+
+// putFile copies the file at filepath into storage under given key, or if
+// media deduplication is enabled, under a content-addressed key calculated
+// from the file's checksum (skipping the copy if it's already in storage).
+// When deduplicating, the content-addressed key is passed to claim, which
+// should store it on the owning media or emoji in the database, while the key
+// is still locked against removal. Returns the final storage key and file size.
+func (m *Manager) putFile(
+	ctx context.Context,
+	key, ext, filepath, contentType string,
+	claim func(ctx context.Context, key string) error,
+) (string, int64, error) {
+	if !config.GetMediaDedupe() {
+		sz, err := m.state.Storage.PutFile(ctx, key, filepath, contentType)
+		return key, sz, err
+	}
+
+	// Calculate checksum of file contents.
+	sum, sz, err := checksumFile(filepath)
+	if err != nil {
+		return "", 0, gtserror.Newf("error calculating checksum: %w", err)
+	}
+
+	// Get content-addressed key for file.
+	key = uris.StoragePathForBlob(sum, ext)
+
+	// Lock the key so it can't be removed by another
+	// media or emoji's cleanup between us checking it
+	// exists and our own reference being stored in db.
+	unlock := m.state.ProcessingLocks.Lock(key)
+	defer unlock()
+
+	// Check whether already stored
+	// for other media or emoji.
+	has, err := m.state.Storage.Has(ctx, key)
+	if err != nil {
+		return "", 0, gtserror.Newf("error checking storage for %s: %w", key, err)
+	}
+
+	if !has {
+		// Copy file into storage at content-addressed key.
+		sz, err = m.state.Storage.PutFile(ctx, key, filepath, contentType)
+		if err != nil {
+			return "", 0, err
+		}
+	}
+
+	// Store reference to key
+	// before anyone else can
+	// check whether it's used.
+	if err := claim(ctx, key); err != nil {
+		return "", 0, gtserror.Newf("error storing reference to %s: %w", key, err)
+	}
+
+	return key, sz, nil
+}
+
+// removeFiles removes the given storage paths of media or emoji with ID from
+// storage, skipping content-addressed files still in use. Errors are logged.
+func (m *Manager) removeFiles(ctx context.Context, id string, paths ...string) {
+	paths, unlock, err := UnusedFiles(ctx, m.state, id, paths...)
+	if err != nil {
+		log.Errorf(ctx, "error checking files in use: %v", err)
+		return
+	}
+	defer unlock()
+
+	for _, path := range paths {
+		err := m.state.Storage.Delete(ctx, path)
+		if err != nil && !storage.IsNotFound(err) {
+			log.Errorf(ctx, "error deleting %s: %v", path, err)
+		}
+	}
+}
+
+// UnusedFiles filters the given storage paths of the media attachment or emoji with
+// ID, down to those which can be removed from storage. That is, all non-empty paths
+// except content-addressed files still referenced by other media or emojis.
+//
+// Content-addressed files are locked against reuse until the returned unlock
+// function is called, which callers should do once they have removed the files.
+func UnusedFiles(ctx context.Context, state *state.State, id string, paths ...string) ([]string, func(), error) {
+	var blobs []string
+
+	for _, path := range paths {
+		if regexes.BlobPath.MatchString(path) {
+			blobs = append(blobs, path)
+		}
+	}
+
+	// Lock content-addressed files in
+	// a consistent order, so concurrent
+	// callers can't deadlock each other.
+	slices.Sort(blobs)
+	blobs = slices.Compact(blobs)
+	unlocks := make([]func(), len(blobs))
+	for i, blob := range blobs {
+		unlocks[i] = state.ProcessingLocks.Lock(blob)
+	}
+
+	unlock := func() {
+		for _, unlock := range unlocks {
+			unlock()
+		}
+	}
+
+	unused := make([]string, 0, len(paths))
+
+	for _, path := range paths {
+		if path == "" {
+			// not stored.
+			continue
+		}
+
+		if regexes.BlobPath.MatchString(path) {
+			// Content-addressed file, check
+			// whether in use by other media.
+			n, err := state.DB.CountBlobReferences(ctx, path, id)
+			if err != nil {
+				unlock()
+				return nil, nil, gtserror.Newf("error counting references to %s: %w", path, err)
+			}
+
+			if n > 0 {
+				// still in use.
+				continue
+			}
+		}
+
+		unused = append(unused, path)
+	}
+
+	return unused, unlock, nil
+}
+
+// checksumFile returns the hex-encoded SHA256
+// checksum and size of the file at filepath.
+func checksumFile(filepath string) (string, int64, error) {
+	file, err := openRead(filepath)
+	if err != nil {
+		return "", 0, err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	sz, err := io.Copy(hash, file)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), sz, nil
+}
@@ -21,8 +21,10 @@
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	"strconv"
 	"strings"
+	"time"
 
 	"codeberg.org/gruf/go-byteutil"
 
@@ -147,10 +149,62 @@ func ffmpegGenerateStatic(ctx context.Context, inpath string) (string, error) {
 	return outpath, nil
 }
 
+// ffmpegTranscode transcodes input media into output media, with the output
+// container format and codecs determined by given transcode arguments. Progress
+// is passed as encoded output duration to the given func as transcoding goes.
+func ffmpegTranscode(ctx context.Context, inpath, outpath string, args []string, progress func(time.Duration)) error {
+	return ffmpegStdout(ctx, inpath, outpath, &progressWriter{fn: progress},
+		append([]string{
+
+			// Only log errors.
+			"-loglevel", "error",
+
+			// Write machine readable
+			// progress data to stdout,
+			// without the usual stats.
+			"-progress", "pipe:1",
+			"-nostats",
+
+			// Input file.
+			"-i", inpath,
+		}, append(args,
+
+			// Overwrite.
+			"-y",
+
+			// Output.
+			outpath,
+		)...)...,
+	)
+}
+
+// ffmpegEncoders calls `ffmpeg -encoders` (WASM), returning the
+// names of all the encoders available in the ffmpeg binary.
+func ffmpegEncoders(ctx context.Context) (map[string]bool, error) {
+	var stdout, stderr byteutil.Buffer
+	rc, err := _ffmpeg.Ffmpeg(ctx, _ffmpeg.Args{
+		Stdout: &stdout,
+		Stderr: &stderr,
+		Args:   []string{"-hide_banner", "-encoders"},
+	})
+	if err != nil {
+		return nil, gtserror.Newf("error running: %w", err)
+	} else if rc != 0 {
+		return nil, gtserror.Newf("non-zero return code %d (%s)", rc, stderr.B)
+	}
+	return parseEncoders(stdout.B), nil
+}
+
 // ffmpeg calls `ffmpeg [args...]` (WASM) with in + out paths mounted in runtime.
 func ffmpeg(ctx context.Context, inpath string, outpath string, args ...string) error {
+	return ffmpegStdout(ctx, inpath, outpath, nil, args...)
+}
+
+// ffmpegStdout is as ffmpeg(), but also passes any output on stdout to given writer.
+func ffmpegStdout(ctx context.Context, inpath string, outpath string, stdout io.Writer, args ...string) error {
 	var stderr byteutil.Buffer
 	rc, err := _ffmpeg.Ffmpeg(ctx, _ffmpeg.Args{
+		Stdout: stdout,
 		Stderr: &stderr,
 		Args:   args,
 		Config: func(modcfg wazero.ModuleConfig) wazero.ModuleConfig {
@@ -21,6 +21,7 @@
 	"context"
 	"io"
 	"strings"
+	"sync"
 	"time"
 
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
@@ -28,7 +29,6 @@
 	"code.superseriousbusiness.org/gotosocial/internal/id"
 	"code.superseriousbusiness.org/gotosocial/internal/log"
 	"code.superseriousbusiness.org/gotosocial/internal/state"
-	"code.superseriousbusiness.org/gotosocial/internal/storage"
 	"code.superseriousbusiness.org/gotosocial/internal/uris"
 	"code.superseriousbusiness.org/gotosocial/internal/util"
 	"codeberg.org/gruf/go-iotools"
@@ -84,6 +84,17 @@
 
 type Manager struct {
 	state *state.State
+
+	// encoders contains the names of
+	// encoders available in ffmpeg,
+	// checked on first transcode.
+	encoders     map[string]bool
+	encodersOnce sync.Once
+
+	// transcoding contains media
+	// currently being transcoded,
+	// by ID, to look up progress.
+	transcoding sync.Map
 }
 
 // NewManager returns a media manager with given state.
@@ -91,6 +102,35 @@ func NewManager(state *state.State) *Manager {
 	return &Manager{state: state}
 }
 
+// TranscodeProgress returns the transcoding progress of the media
+// attachment with given ID as a percentage, and whether it is
+// currently being transcoded by this manager at all.
+func (m *Manager) TranscodeProgress(id string) (int, bool) {
+	v, ok := m.transcoding.Load(id)
+	if !ok {
+		return 0, false
+	}
+	return v.(*ProcessingMedia).Progress(), true
+}
+
+// availableEncoders returns the names of the encoders
+// available in ffmpeg, checking them on first call.
+func (m *Manager) availableEncoders(ctx context.Context) map[string]bool {
+	m.encodersOnce.Do(func() {
+		var err error
+
+		// Don't let one caller's context cancel
+		// the check for everyone that follows.
+		ctx = context.WithoutCancel(ctx)
+
+		m.encoders, err = ffmpegEncoders(ctx)
+		if err != nil {
+			log.Errorf(ctx, "error checking ffmpeg encoders, media will not be transcoded: %v", err)
+		}
+	})
+	return m.encoders
+}
+
 // CreateMedia creates a new media attachment entry
 // in the database for given owning account ID and
 // extra information, and prepares a new processing
@@ -172,9 +212,10 @@ func (m *Manager) CacheMedia(
 	data DataFunc,
 ) *ProcessingMedia {
 	return &ProcessingMedia{
-		media:  media,
-		dataFn: data,
-		mgr:    m,
+		media:       media,
+		dataFn:      data,
+		mgr:         m,
+		transcoding: make(chan struct{}),
 	}
 }
 
@@ -274,17 +315,10 @@ func (m *Manager) UpdateEmoji(
 		// Wrap underlying io.Closer type to cleanup old data.
 		rct.Closer = iotools.CloserCallback(rct.Closer, func() {
 
-			// Remove any *old* emoji image file path now stream is closed.
-			if err := m.state.Storage.Delete(ctx, oldPath); err != nil &&
-				!storage.IsNotFound(err) {
-				log.Errorf(ctx, "error deleting old emoji %s from storage: %v", shortcodeDomain, err)
-			}
-
-			// Remove any *old* emoji static image file path now stream is closed.
-			if err := m.state.Storage.Delete(ctx, oldStaticPath); err != nil &&
-				!storage.IsNotFound(err) {
-				log.Errorf(ctx, "error deleting old static emoji %s from storage: %v", shortcodeDomain, err)
-			}
+			// Remove any *old* emoji image file paths now stream is
+			// closed (unless still in use by other media / emojis).
+			log.Debugf(ctx, "removing old files of emoji %s", shortcodeDomain)
+			m.removeFiles(ctx, emoji.ID, oldPath, oldStaticPath)
 		})
 
 		return rct, nil
@@ -332,7 +366,8 @@ func (m *Manager) CacheEmoji(
 	// Look for an emoji path ID that differs from its actual ID, this indicates
 	// a previous 'refresh'. We need to be sure to set this on the ProcessingEmoji{}
 	// so it knows to store the emoji under this path, and not default to emoji.ID.
-	if id := extractEmojiPathID(emoji.ImagePath); id != emoji.ID {
+	// This is checked on the URL, as the image path may be content-addressed.
+	if id := extractEmojiPathID(emoji.ImageURL); id != emoji.ID {
 		pathID = id
 	}
 
@@ -27,8 +27,10 @@
 	"testing"
 	"time"
 
+	"code.superseriousbusiness.org/gotosocial/internal/config"
 	gtsmodel "code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
 	"code.superseriousbusiness.org/gotosocial/internal/media"
+	"code.superseriousbusiness.org/gotosocial/internal/regexes"
 	"code.superseriousbusiness.org/gotosocial/internal/state"
 	"code.superseriousbusiness.org/gotosocial/internal/storage"
 	gtsstorage "code.superseriousbusiness.org/gotosocial/internal/storage"
@@ -287,6 +289,153 @@ func (suite *ManagerTestSuite) TestSimpleJpegProcess() {
 	equalFiles(suite.T(), suite.state.Storage, dbAttachment.Thumbnail.Path, "./test/test-jpeg-thumbnail.jpeg")
 }
 
+func (suite *ManagerTestSuite) TestSimpleJpegProcessDedupe() {
+	ctx := suite.T().Context()
+
+	config.SetMediaDedupe(true)
+
+	data := func(_ context.Context) (io.ReadCloser, error) {
+		// load bytes from a test image
+		b, err := os.ReadFile("./test/test-jpeg.jpg")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), nil
+	}
+
+	// process the same media for two different accounts
+	attachments := make([]*gtsmodel.MediaAttachment, 2)
+	for i, accountID := range []string{
+		"01FS1X72SK9ZPW0J1QQ68BD264",
+		"01F8MH1H7YV1Z7D2C8K2730QBF",
+	} {
+		processing, err := suite.manager.CreateMedia(ctx,
+			accountID,
+			data,
+			media.AdditionalMediaInfo{},
+		)
+		suite.NoError(err)
+
+		attachments[i], err = processing.Load(ctx)
+		suite.NoError(err)
+	}
+
+	// both should be stored under the same content-addressed paths
+	suite.True(regexes.BlobPath.MatchString(attachments[0].File.Path))
+	suite.True(regexes.BlobPath.MatchString(attachments[0].Thumbnail.Path))
+	suite.Equal(attachments[0].File.Path, attachments[1].File.Path)
+	suite.Equal(attachments[0].Thumbnail.Path, attachments[1].Thumbnail.Path)
+	suite.NotEqual(attachments[0].URL, attachments[1].URL)
+	suite.Equal(269739, attachments[1].File.FileSize)
+	equalFiles(suite.T(), suite.state.Storage, attachments[1].File.Path, "./test/test-jpeg-processed.jpg")
+
+	// files are still in use by the other attachment
+	unused, unlock, err := media.UnusedFiles(ctx, &suite.state, attachments[0].ID,
+		attachments[0].File.Path,
+		attachments[0].Thumbnail.Path,
+	)
+	suite.NoError(err)
+	suite.Empty(unused)
+	unlock()
+
+	// files are still in use by the other attachment while it's
+	// uncached, as it may be in the middle of being (re)processed
+	attachments[1].Cached = util.Ptr(false)
+	suite.NoError(suite.db.UpdateAttachment(ctx, attachments[1], "cached"))
+	unused, unlock, err = media.UnusedFiles(ctx, &suite.state, attachments[0].ID,
+		attachments[0].File.Path,
+		attachments[0].Thumbnail.Path,
+	)
+	suite.NoError(err)
+	suite.Empty(unused)
+	unlock()
+
+	// once the other attachment is deleted, the files are unused
+	suite.NoError(suite.db.DeleteAttachment(ctx, attachments[1].ID))
+	unused, unlock, err = media.UnusedFiles(ctx, &suite.state, attachments[0].ID,
+		attachments[0].File.Path,
+		attachments[0].Thumbnail.Path,
+	)
+	suite.NoError(err)
+	suite.Equal([]string{
+		attachments[0].File.Path,
+		attachments[0].Thumbnail.Path,
+	}, unused)
+	unlock()
+}
+
+func (suite *ManagerTestSuite) TestSimpleJpegProcessDedupeReuseDuringDelete() {
+	ctx := suite.T().Context()
+
+	config.SetMediaDedupe(true)
+
+	data := func(_ context.Context) (io.ReadCloser, error) {
+		// load bytes from a test image
+		b, err := os.ReadFile("./test/test-jpeg.jpg")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), nil
+	}
+
+	// process media for the first account
+	processing, err := suite.manager.CreateMedia(ctx,
+		"01FS1X72SK9ZPW0J1QQ68BD264",
+		data,
+		media.AdditionalMediaInfo{},
+	)
+	suite.NoError(err)
+	first, err := processing.Load(ctx)
+	suite.NoError(err)
+
+	// start deleting the first attachment, its
+	// files are unused by anything else so far
+	unused, unlock, err := media.UnusedFiles(ctx, &suite.state, first.ID,
+		first.File.Path,
+		first.Thumbnail.Path,
+	)
+	suite.NoError(err)
+	suite.Len(unused, 2)
+
+	// meanwhile process the same media for another
+	// account, reusing the files about to be deleted
+	done := make(chan *gtsmodel.MediaAttachment)
+	go func() {
+		processing, err := suite.manager.CreateMedia(ctx,
+			"01F8MH1H7YV1Z7D2C8K2730QBF",
+			data,
+			media.AdditionalMediaInfo{},
+		)
+		suite.NoError(err)
+		second, err := processing.Load(ctx)
+		suite.NoError(err)
+		done <- second
+	}()
+
+	// the second attachment can't reference the files
+	// while they're locked for deletion of the first
+	suite.Never(func() bool {
+		n, err := suite.db.CountBlobReferences(ctx, first.File.Path, first.ID)
+		return err != nil || n > 0
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// finish deleting the first attachment
+	for _, path := range unused {
+		suite.NoError(suite.state.Storage.Delete(ctx, path))
+	}
+	suite.NoError(suite.db.DeleteAttachment(ctx, first.ID))
+	unlock()
+
+	// the second attachment's files must still be in storage
+	second := <-done
+	suite.Equal(first.File.Path, second.File.Path)
+	suite.Equal(first.Thumbnail.Path, second.Thumbnail.Path)
+	equalFiles(suite.T(), suite.state.Storage, second.File.Path, "./test/test-jpeg-processed.jpg")
+	hasKey, err := suite.state.Storage.Has(ctx, second.Thumbnail.Path)
+	suite.NoError(err)
+	suite.True(hasKey)
+}
+
 func (suite *ManagerTestSuite) TestSimpleJpegProcessTooLarge() {
 	ctx := suite.T().Context()
 
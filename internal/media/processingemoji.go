@@ -24,7 +24,6 @@
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
 	"code.superseriousbusiness.org/gotosocial/internal/log"
-	"code.superseriousbusiness.org/gotosocial/internal/storage"
 	"code.superseriousbusiness.org/gotosocial/internal/uris"
 	"code.superseriousbusiness.org/gotosocial/internal/util"
 	errorsv2 "codeberg.org/gruf/go-errors/v2"
@@ -220,21 +219,35 @@ func (p *ProcessingEmoji) store(ctx context.Context) error {
 	// Set the known emoji static content type.
 	p.emoji.ImageStaticContentType = "image/png"
 
-	// Copy temporary file into storage at path.
-	filesz, err := p.mgr.state.Storage.PutFile(ctx,
+	// Copy temporary file into storage at path
+	// (which may get updated if deduplicating).
+	var filesz int64
+	p.emoji.ImagePath, filesz, err = p.mgr.putFile(ctx,
 		p.emoji.ImagePath,
+		ext,
 		temppath,
 		p.emoji.ImageContentType,
+		func(ctx context.Context, key string) error {
+			p.emoji.ImagePath = key
+			return p.mgr.state.DB.UpdateEmoji(ctx, p.emoji, "image_path")
+		},
 	)
 	if err != nil {
 		return gtserror.Newf("error writing emoji to storage: %w", err)
 	}
 
-	// Copy static emoji file into storage at path.
-	staticsz, err := p.mgr.state.Storage.PutFile(ctx,
+	// Copy static emoji file into storage at path
+	// (which may get updated if deduplicating).
+	var staticsz int64
+	p.emoji.ImageStaticPath, staticsz, err = p.mgr.putFile(ctx,
 		p.emoji.ImageStaticPath,
+		"png",
 		staticpath,
 		p.emoji.ImageStaticContentType,
+		func(ctx context.Context, key string) error {
+			p.emoji.ImageStaticPath = key
+			return p.mgr.state.DB.UpdateEmoji(ctx, p.emoji, "image_static_path")
+		},
 	)
 	if err != nil {
 		return gtserror.Newf("error writing static to storage: %w", err)
@@ -273,21 +286,12 @@ func (p *ProcessingEmoji) store(ctx context.Context) error {
 func (p *ProcessingEmoji) cleanup(ctx context.Context) {
 	log.Debugf(ctx, "running cleanup of emoji %s", p.emoji.ID)
 
-	if p.emoji.ImagePath != "" {
-		// Ensure emoji file at path is deleted from storage.
-		err := p.mgr.state.Storage.Delete(ctx, p.emoji.ImagePath)
-		if err != nil && !storage.IsNotFound(err) {
-			log.Errorf(ctx, "error deleting %s: %v", p.emoji.ImagePath, err)
-		}
-	}
-
-	if p.emoji.ImageStaticPath != "" {
-		// Ensure emoji static file at path is deleted from storage.
-		err := p.mgr.state.Storage.Delete(ctx, p.emoji.ImageStaticPath)
-		if err != nil && !storage.IsNotFound(err) {
-			log.Errorf(ctx, "error deleting %s: %v", p.emoji.ImageStaticPath, err)
-		}
-	}
+	// Ensure emoji file and static file at paths are deleted
+	// from storage (unless still in use by other media).
+	p.mgr.removeFiles(ctx, p.emoji.ID,
+		p.emoji.ImagePath,
+		p.emoji.ImageStaticPath,
+	)
 
 	// Unset processor-calculated fields.
 	p.emoji.ImageStaticContentType = ""
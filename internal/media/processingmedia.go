@@ -20,6 +20,9 @@
 import (
 	"context"
 	"os"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	errorsv2 "codeberg.org/gruf/go-errors/v2"
 	"codeberg.org/gruf/go-kv/v2"
@@ -29,7 +32,6 @@
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
 	"code.superseriousbusiness.org/gotosocial/internal/log"
-	"code.superseriousbusiness.org/gotosocial/internal/storage"
 	"code.superseriousbusiness.org/gotosocial/internal/uris"
 	"code.superseriousbusiness.org/gotosocial/internal/util"
 )
@@ -44,6 +46,9 @@ type ProcessingMedia struct {
 	proc   runners.Processor         // proc helps synchronize only a singular running processing instance
 	err    error                     // error stores permanent error value when done
 	mgr    *Manager                  // mgr instance (access to db / storage)
+
+	progress    atomic.Uint32 // progress stores processing progress as percentage
+	transcoding chan struct{} // transcoding is closed when transcoding begins
 }
 
 // ID returns the ID of the underlying media.
@@ -51,6 +56,22 @@ func (p *ProcessingMedia) ID() string {
 	return p.media.ID // immutable, safe outside mutex.
 }
 
+// Progress returns the current processing progress of
+// the media as a percentage. This is only updated as it
+// goes while transcoding, otherwise it will jump straight
+// to 100 once processing is complete.
+func (p *ProcessingMedia) Progress() int {
+	return int(p.progress.Load())
+}
+
+// Transcoding returns a channel that is closed if and when
+// processing begins transcoding the media. At this point the
+// attachment will have been marked in the database as still
+// processing, and Progress() can be used to follow along.
+func (p *ProcessingMedia) Transcoding() <-chan struct{} {
+	return p.transcoding
+}
+
 // LoadAttachment blocks until the thumbnail and
 // fullsize content has been processed, and then
 // returns the attachment.
@@ -111,6 +132,9 @@ func (p *ProcessingMedia) load(ctx context.Context) (
 					log.Errorf(ctx, "error updating media in db: %v", e)
 				}
 
+				// No longer transcoding, if we were.
+				p.mgr.transcoding.Delete(p.media.ID)
+
 				// Store values.
 				p.done = true
 				p.err = err
@@ -202,10 +226,35 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 	// AFTER successful.
 	temppath = newpath
 
+	// Check whether this is local media that needs
+	// transcoding to be playable in browsers / clients.
+	transcode := p.media.IsLocal() &&
+		config.GetMediaTranscodeEnabled() &&
+		needsTranscode(result, p.media.Type, ext)
+
+	var enc encoders
+	if transcode {
+		// Check ffmpeg actually has the encoders we
+		// need, else fall back to storing media as-is.
+		format := config.GetMediaTranscodeFormat()
+		enc = pickEncoders(p.mgr.availableEncoders(ctx), format)
+		if !enc.canTranscode(result, p.media.Type) {
+			log.Warnf(ctx, "ffmpeg has no encoders to transcode %s %s to %s, storing as uploaded",
+				ext, p.media.Type, format)
+			transcode = false
+		}
+	}
+
 	switch p.media.Type {
 	case gtsmodel.FileTypeImage,
 		gtsmodel.FileTypeVideo,
 		gtsmodel.FileTypeGifv:
+		if transcode {
+			// Metadata gets dropped
+			// when transcoding anyway.
+			break
+		}
+
 		// Attempt to clean as metadata from file as possible.
 		if err := clearMetadata(ctx, temppath); err != nil {
 			return gtserror.Newf("error cleaning metadata: %w", err)
@@ -261,6 +310,43 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 		}
 	}
 
+	if transcode {
+		// Transcode media to a web-playable format,
+		// (the thumbnail is generated from the source).
+		transpath, err := p.transcode(ctx, temppath, result, width, height, enc)
+		if err != nil {
+			return err
+		}
+
+		// Transcoded file replaces source.
+		if err := remove(temppath); err != nil {
+			log.Errorf(ctx, "error cleaning up files: %v", err)
+		}
+		temppath = transpath
+
+		// Probe transcoded media for updated details.
+		result, err = probe(ctx, temppath)
+		if err != nil {
+			return gtserror.Newf("ffprobe error: %w", err)
+		}
+
+		// Update media type, mimetype and extension from transcoded.
+		p.media.Type, p.media.File.ContentType, ext = result.GetFileType()
+
+		if len(result.video) > 0 {
+			// Update video stream metadata from transcoded media,
+			// (transcoded audio does not include any album art).
+			width, height, framerate := result.ImageMeta()
+			p.media.FileMeta.Original.Width = width
+			p.media.FileMeta.Original.Height = height
+			p.media.FileMeta.Original.Size = (width * height)
+			p.media.FileMeta.Original.Aspect = util.Div(float32(width), float32(height))
+			p.media.FileMeta.Original.Framerate = util.PtrIf(framerate)
+		}
+		p.media.FileMeta.Original.Duration = util.PtrIf(float32(result.duration))
+		p.media.FileMeta.Original.Bitrate = util.PtrIf(result.bitrate)
+	}
+
 	// Calculate final media attachment file path.
 	p.media.File.Path = uris.StoragePathForAttachment(
 		p.media.AccountID,
@@ -270,11 +356,18 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 		ext,
 	)
 
-	// Copy temporary file into storage at path.
-	filesz, err := p.mgr.state.Storage.PutFile(ctx,
+	// Copy temporary file into storage at path
+	// (which may get updated if deduplicating).
+	var filesz int64
+	p.media.File.Path, filesz, err = p.mgr.putFile(ctx,
 		p.media.File.Path,
+		ext,
 		temppath,
 		p.media.File.ContentType,
+		func(ctx context.Context, key string) error {
+			p.media.File.Path = key
+			return p.mgr.state.DB.UpdateAttachment(ctx, p.media, "file_path")
+		},
 	)
 	if err != nil {
 		return gtserror.Newf("error writing media to storage: %w", err)
@@ -296,11 +389,18 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 			thumbExt,
 		)
 
-		// Copy thumbnail file into storage at path.
-		thumbsz, err := p.mgr.state.Storage.PutFile(ctx,
+		// Copy thumbnail file into storage at path
+		// (which may get updated if deduplicating).
+		var thumbsz int64
+		p.media.Thumbnail.Path, thumbsz, err = p.mgr.putFile(ctx,
 			p.media.Thumbnail.Path,
+			thumbExt,
 			thumbpath,
 			p.media.Thumbnail.ContentType,
+			func(ctx context.Context, key string) error {
+				p.media.Thumbnail.Path = key
+				return p.mgr.state.DB.UpdateAttachment(ctx, p.media, "thumbnail_path")
+			},
 		)
 		if err != nil {
 			return gtserror.Newf("error writing thumb to storage: %w", err)
@@ -333,29 +433,100 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 
 	// Finally set the attachment as finished processing.
 	p.media.Processing = gtsmodel.ProcessingStatusProcessed
+	p.progress.Store(100)
 
 	return nil
 }
 
-// cleanup will remove any traces of processing media from storage.
-// and perform any other necessary cleanup steps after failure.
-func (p *ProcessingMedia) cleanup(ctx context.Context) {
-	if p.media.File.Path != "" {
-		// Ensure media file at path is deleted from storage.
-		err := p.mgr.state.Storage.Delete(ctx, p.media.File.Path)
-		if err != nil && !storage.IsNotFound(err) {
-			log.Errorf(ctx, "error deleting %s: %v", p.media.File.Path, err)
-		}
+// transcode transcodes the media at given temporary file path to the
+// configured transcode format, returning the path of the transcoded file.
+// The media is marked as processing in the database before beginning,
+// and transcoding progress is stored as it goes.
+func (p *ProcessingMedia) transcode(
+	ctx context.Context,
+	inpath string,
+	res *result,
+	width, height int,
+	enc encoders,
+) (
+	string,
+	error,
+) {
+	// Mark media as still processing in the database,
+	// so that any clients polling it know to wait.
+	p.media.Processing = gtsmodel.ProcessingStatusProcessing
+	if err := p.mgr.state.DB.UpdateAttachment(ctx, p.media, "processing"); err != nil {
+		return "", gtserror.Newf("error updating media in db: %w", err)
 	}
 
-	if p.media.Thumbnail.Path != "" {
-		// Ensure media thumbnail at path is deleted from storage.
-		err := p.mgr.state.Storage.Delete(ctx, p.media.Thumbnail.Path)
-		if err != nil && !storage.IsNotFound(err) {
-			log.Errorf(ctx, "error deleting %s: %v", p.media.Thumbnail.Path, err)
-		}
+	select {
+	case <-p.transcoding:
+		// Already closed on
+		// previous attempt.
+	default:
+		close(p.transcoding)
+	}
+
+	// Get output extension and args for transcode.
+	ext, args := transcodeArgs(p.media.Type, width, height, enc)
+
+	// Generate transcode output path REPLACING extension.
+	i := strings.IndexByte(inpath, '.')
+	if i == -1 {
+		return "", gtserror.New("input file missing extension")
+	}
+	outpath := inpath[:i] + "_transcoded." + ext
+
+	// Media duration used to
+	// calculate the progress.
+	duration := res.duration
+
+	log.Debugf(ctx, "transcoding media %s to %s", p.media.ID, ext)
+
+	// Make progress available to clients
+	// polling the media while transcoding,
+	// (removed once processing is done).
+	p.mgr.transcoding.Store(p.media.ID, p)
+
+	// Transcode media, updating progress as we go.
+	if err := ffmpegTranscode(ctx, inpath, outpath, args,
+		func(t time.Duration) {
+			p.setProgress(t, duration)
+		},
+	); err != nil {
+		_ = remove(outpath)
+		return "", gtserror.Newf("error transcoding media: %w", err)
+	}
+
+	return outpath, nil
+}
+
+// setProgress updates stored progress percentage from
+// given transcoded output time and total media duration.
+func (p *ProcessingMedia) setProgress(t time.Duration, duration float64) {
+	if duration <= 0 {
+		// Unknown.
+		return
 	}
 
+	// Calculate percent, never reporting
+	// completion until processing is done.
+	pct := int(100 * t.Seconds() / duration)
+	pct = min(max(pct, 0), 99)
+
+	p.progress.Store(uint32(pct)) // #nosec G115 -- Bounded above.
+}
+
+// cleanup will remove any traces of processing media from storage.
+// and perform any other necessary cleanup steps after failure.
+func (p *ProcessingMedia) cleanup(ctx context.Context) {
+	// Ensure media file and thumbnail at paths are deleted
+	// from storage (unless still in use by other media).
+	p.mgr.removeFiles(ctx, p.media.ID,
+		p.media.File.Path,
+		p.media.Thumbnail.Path,
+	)
+
 	// Unset all processor-calculated media fields.
 	p.media.FileMeta.Original = gtsmodel.Original{}
 	p.media.FileMeta.Small = gtsmodel.Small{}
@@ -0,0 +1,368 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"bytes"
+	"strconv"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+)
+
+// needsTranscode returns whether media with given ffprobe result data, file type
+// and extension needs transcoding, either as it is not in a format widely playable
+// by browsers and clients, or because it exceeds the configured transcode limits.
+func needsTranscode(res *result, typ gtsmodel.FileType, ext string) bool {
+	switch typ {
+	case gtsmodel.FileTypeVideo,
+		gtsmodel.FileTypeGifv:
+		if !isPlayableVideo(res, ext) {
+			return true
+		}
+
+		// Check video dimensions are within configured max.
+		width, height, _ := res.ImageMeta()
+		max := config.GetMediaTranscodeMaxPixels()
+		if width > max || height > max {
+			return true
+		}
+
+		// Check total bitrate is within configured max (given in kbit/s).
+		kbps := config.GetMediaTranscodeVideoBitrate() + config.GetMediaTranscodeAudioBitrate()
+		return res.bitrate > uint64(kbps)*1000 // #nosec G115 -- Already validated.
+
+	case gtsmodel.FileTypeAudio:
+		switch ext {
+		case "mp3", "m4a", "opus", "ogg", "flac":
+			// Widely supported.
+			return false
+		default:
+			return true
+		}
+
+	default:
+		return false
+	}
+}
+
+// isPlayableVideo returns whether video with given
+// ffprobe result data and extension is widely playable.
+func isPlayableVideo(res *result, ext string) bool {
+	switch ext {
+	case "webm":
+		// GetFileType() only returns
+		// webm for supported codecs.
+		return true
+
+	case "mp4":
+		if len(res.video) == 0 ||
+			res.video[0].codec != "h264" {
+			return false
+		}
+		if len(res.audio) > 0 {
+			switch res.audio[0].codec {
+			case "aac", "mp3":
+			default:
+				return false
+			}
+		}
+		return true
+
+	default:
+		return false
+	}
+}
+
+// Encoders to use when transcoding to each format, in
+// order of preference. The first one that is available
+// in the running ffmpeg binary is used, as ffmpeg builds
+// (including the embedded WebAssembly build) may not
+// include all of the external encoder libraries.
+var (
+	mp4VideoEncoders  = []string{"libx264", "libopenh264"}
+	mp4AudioEncoders  = []string{"aac", "libfdk_aac"}
+	webmVideoEncoders = []string{"libvpx-vp9", "libvpx"}
+	webmAudioEncoders = []string{"libopus", "opus"}
+)
+
+// encoders holds the names of the ffmpeg
+// encoders to use when transcoding media,
+// each empty where none are available.
+type encoders struct {
+	video string
+	audio string
+}
+
+// pickEncoders returns the encoders to use when transcoding
+// to given format, from the given available ffmpeg encoders.
+func pickEncoders(available map[string]bool, format string) encoders {
+	videos, audios := mp4VideoEncoders, mp4AudioEncoders
+	if format == config.MediaTranscodeFormatWebM {
+		videos, audios = webmVideoEncoders, webmAudioEncoders
+	}
+
+	var enc encoders
+
+	for _, name := range videos {
+		if available[name] {
+			enc.video = name
+			break
+		}
+	}
+
+	for _, name := range audios {
+		if available[name] {
+			enc.audio = name
+			break
+		}
+	}
+
+	return enc
+}
+
+// canTranscode returns whether these encoders are able to transcode
+// media of given type, with given ffprobe result data. Video needs
+// an audio encoder as well as a video encoder, if it contains audio.
+func (e encoders) canTranscode(res *result, typ gtsmodel.FileType) bool {
+	switch typ {
+	case gtsmodel.FileTypeAudio:
+		return e.audio != ""
+
+	case gtsmodel.FileTypeVideo,
+		gtsmodel.FileTypeGifv:
+		return e.video != "" &&
+			(len(res.audio) == 0 || e.audio != "")
+
+	default:
+		return false
+	}
+}
+
+// encoderArgs returns any additional ffmpeg
+// arguments to use alongside given encoder.
+func encoderArgs(encoder string) []string {
+	switch encoder {
+	case "libx264":
+		return []string{"-preset", "veryfast"}
+
+	case "libvpx-vp9":
+		return []string{"-deadline", "good", "-cpu-used", "4", "-row-mt", "1"}
+
+	case "libvpx":
+		return []string{"-deadline", "good", "-cpu-used", "4"}
+
+	case "opus":
+		// ffmpeg's native opus
+		// encoder is experimental.
+		return []string{"-strict", "experimental"}
+
+	default:
+		return nil
+	}
+}
+
+// parseEncoders parses the names of all encoders
+// listed in given output of `ffmpeg -encoders`.
+func parseEncoders(b []byte) map[string]bool {
+	encoders := make(map[string]bool)
+
+	// Encoders are listed one per line following a
+	// legend, which is separated by a line of dashes,
+	// in the form: ' V....D libx264   H.264 / AVC ...'
+	var listed bool
+	for _, line := range bytes.Split(b, []byte{'\n'}) {
+		fields := bytes.Fields(line)
+
+		if !listed {
+			listed = (len(fields) == 1 &&
+				bytes.HasPrefix(fields[0], []byte("---")))
+			continue
+		}
+
+		if len(fields) < 2 {
+			continue
+		}
+
+		encoders[string(fields[1])] = true
+	}
+
+	return encoders
+}
+
+// transcodeArgs returns the output file extension and ffmpeg arguments
+// to use when transcoding media of given type to the configured transcode
+// format with given encoders, scaling any video to fit within given width
+// and height. The encoders must be able to transcode media of given type.
+func transcodeArgs(typ gtsmodel.FileType, width, height int, enc encoders) (string, []string) {
+	var (
+		format = config.GetMediaTranscodeFormat()
+		vkbps  = strconv.Itoa(config.GetMediaTranscodeVideoBitrate()) + "k"
+		akbps  = strconv.Itoa(config.GetMediaTranscodeAudioBitrate()) + "k"
+	)
+
+	if typ == gtsmodel.FileTypeAudio {
+		// Only include first audio stream,
+		// dropping any embedded album art
+		// (thumbnail is taken from source).
+		args := []string{"-map", "0:a:0", "-codec:a", enc.audio}
+		args = append(args, encoderArgs(enc.audio)...)
+		args = append(args, "-b:a", akbps)
+
+		switch format {
+		case config.MediaTranscodeFormatWebM:
+			// Encode as opus (in ogg).
+			return "opus", args
+
+		default:
+			// Encode as aac (in m4a).
+			return "m4a", append(args,
+				"-movflags", "+faststart",
+			)
+		}
+	}
+
+	// Scale to even dimensions within configured
+	// max, as required by the video encoders.
+	width, height = transcodeSize(
+		config.GetMediaTranscodeMaxPixels(),
+		width,
+		height,
+	)
+
+	args := []string{
+		// Drop all metadata.
+		"-map_metadata", "-1",
+
+		// Only include first video
+		// stream, and the first audio
+		// stream if there is one.
+		"-map", "0:v:0",
+		"-map", "0:a:0?",
+
+		// Scale to dimensions
+		// (scale filter: https://ffmpeg.org/ffmpeg-filters.html#scale)
+		"-filter:v", "scale=" + strconv.Itoa(width) + ":" + strconv.Itoa(height),
+
+		// Use the most widely
+		// supported pixel format.
+		"-pix_fmt", "yuv420p",
+
+		// Cap video bitrate.
+		"-b:v", vkbps,
+		"-maxrate", vkbps,
+		"-bufsize", strconv.Itoa(2*config.GetMediaTranscodeVideoBitrate()) + "k",
+
+		// Set audio bitrate.
+		"-b:a", akbps,
+
+		// Set video encoder.
+		"-codec:v", enc.video,
+	}
+	args = append(args, encoderArgs(enc.video)...)
+
+	if enc.audio != "" {
+		// Set audio encoder, if
+		// any (there may be no
+		// audio in the source).
+		args = append(args, "-codec:a", enc.audio)
+		args = append(args, encoderArgs(enc.audio)...)
+	}
+
+	switch format {
+	case config.MediaTranscodeFormatWebM:
+		// Encode as vp9 / vp8 + opus (in webm).
+		return "webm", args
+
+	default:
+		// Encode as h264 + aac (in mp4).
+		return "mp4", append(args,
+			"-movflags", "+faststart",
+		)
+	}
+}
+
+// transcodeSize returns the dimensions to use for transcoded
+// video, fitting within given max while preserving aspect ratio,
+// and rounded down to even values as required by encoders.
+func transcodeSize(max, width, height int) (int, int) {
+	if width > max || height > max {
+		// Scale down to max, as with thumbnails.
+		aspect := float32(width) / float32(height)
+		width, height = thumbSize(max, width, height, aspect)
+	}
+
+	// Round down to even,
+	// (with a minimum of 2).
+	width = max2(width &^ 1)
+	height = max2(height &^ 1)
+
+	return width, height
+}
+
+// max2 returns the greater of 2 and i.
+func max2(i int) int {
+	if i < 2 {
+		return 2
+	}
+	return i
+}
+
+// progressWriter is an io.Writer that parses
+// ffmpeg '-progress' output written to it,
+// passing each encoded output time to func.
+type progressWriter struct {
+	buf []byte
+	fn  func(time.Duration)
+}
+
+func (w *progressWriter) Write(b []byte) (int, error) {
+	w.buf = append(w.buf, b...)
+
+	for {
+		// Look for next full line.
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+
+		// Split line from buffer.
+		line := w.buf[:i]
+		w.buf = w.buf[i+1:]
+
+		// Look for output time in microseconds,
+		// which is given as 'N/A' at the start.
+		str, ok := bytes.CutPrefix(line, []byte("out_time_us="))
+		if !ok {
+			continue
+		}
+		us, err := strconv.ParseInt(string(bytes.TrimSpace(str)), 10, 64)
+		if err != nil || us < 0 {
+			continue
+		}
+
+		w.fn(time.Duration(us) * time.Microsecond)
+	}
+
+	// Move any partial line
+	// to start of buffer.
+	w.buf = append(w.buf[:0], w.buf...)
+
+	return len(b), nil
+}
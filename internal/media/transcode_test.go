@@ -0,0 +1,292 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+)
+
+func TestNeedsTranscode(t *testing.T) {
+	config.SetMediaTranscodeMaxPixels(1920)
+	config.SetMediaTranscodeVideoBitrate(4000)
+	config.SetMediaTranscodeAudioBitrate(128)
+
+	h264 := func(width, height int, audio string, bitrate uint64) *result {
+		res := &result{
+			format:  "mov,mp4,m4a,3gp,3g2,mj2",
+			bitrate: bitrate,
+			video: []videoStream{{
+				stream: stream{codec: "h264"},
+				width:  width,
+				height: height,
+			}},
+		}
+		if audio != "" {
+			res.audio = []audioStream{{stream: stream{codec: audio}}}
+		}
+		return res
+	}
+
+	tests := []struct {
+		name   string
+		res    *result
+		typ    gtsmodel.FileType
+		ext    string
+		expect bool
+	}{
+		{name: "mp4", res: h264(1280, 720, "aac", 2_000_000), typ: gtsmodel.FileTypeVideo, ext: "mp4", expect: false},
+		{name: "mp4 gifv", res: h264(640, 480, "", 500_000), typ: gtsmodel.FileTypeGifv, ext: "mp4", expect: false},
+		{name: "mp4 too large", res: h264(3840, 2160, "aac", 2_000_000), typ: gtsmodel.FileTypeVideo, ext: "mp4", expect: true},
+		{name: "mp4 too high bitrate", res: h264(1280, 720, "aac", 20_000_000), typ: gtsmodel.FileTypeVideo, ext: "mp4", expect: true},
+		{name: "mp4 unplayable audio", res: h264(1280, 720, "pcm_s16le", 2_000_000), typ: gtsmodel.FileTypeVideo, ext: "mp4", expect: true},
+		{name: "mp4 hevc", res: &result{video: []videoStream{{stream: stream{codec: "hevc"}}}}, typ: gtsmodel.FileTypeVideo, ext: "mp4", expect: true},
+		{name: "webm", res: &result{video: []videoStream{{stream: stream{codec: "vp9"}}}}, typ: gtsmodel.FileTypeVideo, ext: "webm", expect: false},
+		{name: "mkv", res: h264(1280, 720, "aac", 2_000_000), typ: gtsmodel.FileTypeVideo, ext: "mkv", expect: true},
+		{name: "avi", res: &result{video: []videoStream{{stream: stream{codec: "mpeg4"}}}}, typ: gtsmodel.FileTypeVideo, ext: "avi", expect: true},
+		{name: "wmv", res: &result{video: []videoStream{{stream: stream{codec: "wmv2"}}}}, typ: gtsmodel.FileTypeVideo, ext: "wmv", expect: true},
+		{name: "mp3", res: &result{audio: []audioStream{{stream: stream{codec: "mp3"}}}}, typ: gtsmodel.FileTypeAudio, ext: "mp3", expect: false},
+		{name: "opus", res: &result{audio: []audioStream{{stream: stream{codec: "opus"}}}}, typ: gtsmodel.FileTypeAudio, ext: "opus", expect: false},
+		{name: "wma", res: &result{audio: []audioStream{{stream: stream{codec: "wmav2"}}}}, typ: gtsmodel.FileTypeAudio, ext: "wma", expect: true},
+		{name: "mka", res: &result{audio: []audioStream{{stream: stream{codec: "ac3"}}}}, typ: gtsmodel.FileTypeAudio, ext: "mka", expect: true},
+		{name: "jpeg", res: &result{video: []videoStream{{stream: stream{codec: "mjpeg"}, width: 4000, height: 3000}}}, typ: gtsmodel.FileTypeImage, ext: "jpeg", expect: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := needsTranscode(tt.res, tt.typ, tt.ext); got != tt.expect {
+				t.Errorf("expected %v, got %v", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestTranscodeSize(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		expectW       int
+		expectH       int
+	}{
+		{name: "within bounds", width: 1280, height: 720, expectW: 1280, expectH: 720},
+		{name: "odd within bounds", width: 641, height: 361, expectW: 640, expectH: 360},
+		{name: "landscape", width: 3840, height: 2160, expectW: 1920, expectH: 1080},
+		{name: "portrait", width: 2160, height: 3840, expectW: 1080, expectH: 1920},
+		{name: "square", width: 4000, height: 4000, expectW: 1920, expectH: 1920},
+		{name: "tiny", width: 1, height: 1, expectW: 2, expectH: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			width, height := transcodeSize(1920, tt.width, tt.height)
+			if width != tt.expectW || height != tt.expectH {
+				t.Errorf("expected %dx%d, got %dx%d", tt.expectW, tt.expectH, width, height)
+			}
+		})
+	}
+}
+
+func TestParseEncoders(t *testing.T) {
+	out := []byte(`Encoders:
+ V..... = Video
+ A..... = Audio
+ S..... = Subtitle
+ .F.... = Frame-level multithreading
+ ..S... = Slice-level multithreading
+ ...X.. = Codec is experimental
+ ....B. = Supports draw_horiz_band
+ .....D = Supports direct rendering method 1
+ ------
+ V....D libwebp              libwebp WebP image (codec webp)
+ V....D png                  PNG (Portable Network Graphics) image
+ A....D aac                  AAC (Advanced Audio Coding)
+ A..X.D opus                 Opus
+`)
+
+	encoders := parseEncoders(out)
+	if len(encoders) != 4 {
+		t.Fatalf("expected 4 encoders, got %v", encoders)
+	}
+
+	for _, name := range []string{"libwebp", "png", "aac", "opus"} {
+		if !encoders[name] {
+			t.Errorf("expected encoder %s in %v", name, encoders)
+		}
+	}
+}
+
+func TestPickEncoders(t *testing.T) {
+	all := map[string]bool{
+		"libx264": true, "libopenh264": true, "aac": true,
+		"libvpx-vp9": true, "libvpx": true, "libopus": true, "opus": true,
+	}
+
+	tests := []struct {
+		name      string
+		available map[string]bool
+		format    string
+		expect    encoders
+	}{
+		{name: "mp4", available: all, format: config.MediaTranscodeFormatMP4, expect: encoders{video: "libx264", audio: "aac"}},
+		{name: "webm", available: all, format: config.MediaTranscodeFormatWebM, expect: encoders{video: "libvpx-vp9", audio: "libopus"}},
+		{name: "mp4 fallback", available: map[string]bool{"libopenh264": true, "aac": true}, format: config.MediaTranscodeFormatMP4, expect: encoders{video: "libopenh264", audio: "aac"}},
+		{name: "webm fallback", available: map[string]bool{"libvpx": true, "opus": true}, format: config.MediaTranscodeFormatWebM, expect: encoders{video: "libvpx", audio: "opus"}},
+		{name: "mp4 audio only", available: map[string]bool{"aac": true, "opus": true}, format: config.MediaTranscodeFormatMP4, expect: encoders{audio: "aac"}},
+		{name: "none", available: nil, format: config.MediaTranscodeFormatMP4, expect: encoders{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pickEncoders(tt.available, tt.format); got != tt.expect {
+				t.Errorf("expected %+v, got %+v", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestCanTranscode(t *testing.T) {
+	var (
+		video      = &result{video: []videoStream{{}}}
+		videoAudio = &result{video: []videoStream{{}}, audio: []audioStream{{}}}
+		audio      = &result{audio: []audioStream{{}}}
+	)
+
+	tests := []struct {
+		name   string
+		enc    encoders
+		res    *result
+		typ    gtsmodel.FileType
+		expect bool
+	}{
+		{name: "video", enc: encoders{video: "libx264"}, res: video, typ: gtsmodel.FileTypeVideo, expect: true},
+		{name: "video with audio", enc: encoders{video: "libx264", audio: "aac"}, res: videoAudio, typ: gtsmodel.FileTypeVideo, expect: true},
+		{name: "video with audio no audio encoder", enc: encoders{video: "libx264"}, res: videoAudio, typ: gtsmodel.FileTypeVideo, expect: false},
+		{name: "video no video encoder", enc: encoders{audio: "aac"}, res: video, typ: gtsmodel.FileTypeVideo, expect: false},
+		{name: "audio", enc: encoders{audio: "aac"}, res: audio, typ: gtsmodel.FileTypeAudio, expect: true},
+		{name: "audio no audio encoder", enc: encoders{video: "libx264"}, res: audio, typ: gtsmodel.FileTypeAudio, expect: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.enc.canTranscode(tt.res, tt.typ); got != tt.expect {
+				t.Errorf("expected %v, got %v", tt.expect, got)
+			}
+		})
+	}
+}
+
+func TestTranscodeArgs(t *testing.T) {
+	config.SetMediaTranscodeMaxPixels(1920)
+	config.SetMediaTranscodeVideoBitrate(4000)
+	config.SetMediaTranscodeAudioBitrate(128)
+
+	tests := []struct {
+		format     string
+		typ        gtsmodel.FileType
+		enc        encoders
+		expectExt  string
+		expectArgs []string
+	}{
+		{format: config.MediaTranscodeFormatMP4, typ: gtsmodel.FileTypeVideo, enc: encoders{video: "libx264", audio: "aac"}, expectExt: "mp4", expectArgs: []string{"libx264", "veryfast", "aac"}},
+		{format: config.MediaTranscodeFormatMP4, typ: gtsmodel.FileTypeVideo, enc: encoders{video: "libopenh264"}, expectExt: "mp4", expectArgs: []string{"libopenh264"}},
+		{format: config.MediaTranscodeFormatMP4, typ: gtsmodel.FileTypeAudio, enc: encoders{audio: "aac"}, expectExt: "m4a", expectArgs: []string{"aac"}},
+		{format: config.MediaTranscodeFormatWebM, typ: gtsmodel.FileTypeVideo, enc: encoders{video: "libvpx-vp9", audio: "libopus"}, expectExt: "webm", expectArgs: []string{"libvpx-vp9", "-row-mt", "libopus"}},
+		{format: config.MediaTranscodeFormatWebM, typ: gtsmodel.FileTypeAudio, enc: encoders{audio: "opus"}, expectExt: "opus", expectArgs: []string{"opus", "experimental"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format+" "+tt.typ.String()+" "+tt.enc.video+" "+tt.enc.audio, func(t *testing.T) {
+			config.SetMediaTranscodeFormat(tt.format)
+
+			ext, args := transcodeArgs(tt.typ, 3840, 2160, tt.enc)
+			if ext != tt.expectExt {
+				t.Errorf("expected ext %s, got %s", tt.expectExt, ext)
+			}
+
+			for _, expect := range tt.expectArgs {
+				if !slices.Contains(args, expect) {
+					t.Errorf("expected %s in args %v", expect, args)
+				}
+			}
+		})
+	}
+}
+
+func TestTranscodeProgress(t *testing.T) {
+	const id = "01FC31DZT1AYWDZ8XTCRWRBYRK"
+
+	m := &Manager{}
+	p := &ProcessingMedia{
+		media: &gtsmodel.MediaAttachment{ID: id},
+		mgr:   m,
+	}
+
+	if _, ok := m.TranscodeProgress(id); ok {
+		t.Fatal("expected no progress before transcoding")
+	}
+
+	// Halfway through transcoding.
+	m.transcoding.Store(id, p)
+	p.setProgress(5*time.Second, 10)
+
+	progress, ok := m.TranscodeProgress(id)
+	if !ok || progress != 50 {
+		t.Errorf("expected progress 50, got %d (%v)", progress, ok)
+	}
+
+	// Transcoding finished.
+	m.transcoding.Delete(id)
+
+	if _, ok := m.TranscodeProgress(id); ok {
+		t.Error("expected no progress after transcoding")
+	}
+}
+
+func TestProgressWriter(t *testing.T) {
+	var times []time.Duration
+	pw := &progressWriter{fn: func(d time.Duration) {
+		times = append(times, d)
+	}}
+
+	// Write progress output in chunks
+	// that split across line boundaries.
+	for _, chunk := range []string{
+		"frame=0\nout_time_us=N/A\nprogress=cont",
+		"inue\nframe=30\nout_time_us=10000",
+		"00\nout_time=00:00:01.000000\nprogress=continue\n",
+		"out_time_us=2500000\nprogress=end\n",
+	} {
+		if _, err := pw.Write([]byte(chunk)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expect := []time.Duration{time.Second, 2500 * time.Millisecond}
+	if len(times) != len(expect) {
+		t.Fatalf("expected %v, got %v", expect, times)
+	}
+	for i := range expect {
+		if times[i] != expect[i] {
+			t.Errorf("expected %v, got %v", expect, times)
+		}
+	}
+}
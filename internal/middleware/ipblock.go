@@ -0,0 +1,69 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package middleware
+
+import (
+	"errors"
+	"net/netip"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"github.com/gin-gonic/gin"
+)
+
+// error set on gin context by ip block middleware.
+var errIPBlocked = errors.New("client ip matched no_access ip block")
+
+// IPBlock returns a gin middleware handler that rejects
+// requests from IP ranges blocked by an admin with
+// severity no_access. Less severe IP blocks only apply
+// to sign-ups, and are checked when processing those.
+func IPBlock(state *state.State) gin.HandlerFunc {
+	if state == nil {
+		panic(gtserror.New("nil check elimination"))
+	}
+
+	return func(c *gin.Context) {
+		// Use Gin's heuristic for determining
+		// clientIP, which accounts for reverse
+		// proxies and trusted proxies setting.
+		clientIP, err := netip.ParseAddr(c.ClientIP())
+		if err != nil {
+			// Can't block what
+			// we can't parse.
+			c.Next()
+			return
+		}
+
+		block, err := state.DB.MatchIPBlock(c.Request.Context(), clientIP)
+		if err != nil {
+			respondInternalServerError(c, err)
+			return
+		}
+
+		if block != nil && block.Severity == gtsmodel.IPBlockSeverityNoAccess {
+			_ = c.Error(errIPBlocked)
+			respondBlocked(c)
+			return
+		}
+
+		// Allowed!
+		c.Next()
+	}
+}
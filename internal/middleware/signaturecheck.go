@@ -22,8 +22,10 @@
 	"net/http"
 	"net/url"
 
+	"code.superseriousbusiness.org/gotosocial/internal/config"
 	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
 	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/rfc9421"
 
 	"code.superseriousbusiness.org/httpsig"
 	"github.com/gin-gonic/gin"
@@ -39,7 +41,8 @@
 // SignatureCheck returns a gin middleware for checking http signatures.
 //
 // The middleware first checks whether an incoming http request has been
-// http-signed with a well-formed signature. If so, it will check if the
+// http-signed with a well-formed signature (either an RFC 9421 http message
+// signature, or a draft-cavage http signature). If so, it will check if the
 // domain that signed the request is permitted to access the server, using
 // the provided uriBlocked function. If the domain is blocked, the middleware
 // will abort the request chain with http code 403 forbidden. If it is not
@@ -51,28 +54,50 @@ func SignatureCheck(uriBlocked func(context.Context, *url.URL) (bool, error)) fu
 	return func(c *gin.Context) {
 		ctx := c.Request.Context()
 
-		// Create the signature verifier from the request;
-		// this will error if the request wasn't signed.
-		verifier, err := httpsig.NewVerifier(c.Request)
-		if err != nil {
-			// Only actually *abort* the request with 401
-			// if a signature was present but malformed.
-			// Otherwise proceed with an unsigned request;
-			// it's up to other functions to reject this.
-			if err.Error() != noSigError {
-				log.Debugf(ctx, "http signature was present but invalid: %s", err)
+		var (
+			verifier    httpsig.VerifierWithOptions
+			msgVerifier *rfc9421.Verifier
+			pubKeyIDStr string
+			err         error
+		)
+
+		if rfc9421.Signed(c.Request) {
+			// Request was signed with RFC 9421 http message
+			// signature, create the verifier for this. Note
+			// we check this first as the headers overlap.
+			msgVerifier, err = rfc9421.NewVerifier(c.Request, config.GetProtocol())
+			if err != nil {
+				log.Debugf(ctx, "http message signature was present but invalid: %s", err)
 				c.AbortWithStatus(http.StatusUnauthorized)
+				return
 			}
 
-			return
+			pubKeyIDStr = msgVerifier.KeyID()
+		} else {
+			// Create the signature verifier from the request;
+			// this will error if the request wasn't signed.
+			verifier, err = httpsig.NewVerifier(c.Request)
+			if err != nil {
+				// Only actually *abort* the request with 401
+				// if a signature was present but malformed.
+				// Otherwise proceed with an unsigned request;
+				// it's up to other functions to reject this.
+				if err.Error() != noSigError {
+					log.Debugf(ctx, "http signature was present but invalid: %s", err)
+					c.AbortWithStatus(http.StatusUnauthorized)
+				}
+
+				return
+			}
+
+			pubKeyIDStr = verifier.KeyId()
 		}
 
 		// The request was signed! The key ID should be given
 		// in the signature so that we know where to fetch it
 		// from the remote server. This will be something like:
 		// https://example.org/users/some_remote_user#main-key
-		pubKeyIDStr := verifier.KeyId()
-
+		//
 		// Key can sometimes be nil, according to url parse
 		// func: 'Trying to parse a hostname and path without
 		// a scheme is invalid but may not necessarily return
@@ -99,6 +124,17 @@ func SignatureCheck(uriBlocked func(context.Context, *url.URL) (bool, error)) fu
 			return
 		}
 
+		if msgVerifier != nil {
+			// Only now the signer isn't blocked,
+			// read in the body to check it against
+			// the content digest in the signature.
+			if err := msgVerifier.VerifyContentDigest(c.Request); err != nil {
+				log.Debugf(ctx, "http message signature content digest invalid: %s", err)
+				c.AbortWithStatus(http.StatusUnauthorized)
+				return
+			}
+		}
+
 		// Assume signature was set on Signature header,
 		// but fall back to Authorization header if necessary.
 		signature := c.GetHeader(sigHeader)
@@ -108,7 +144,11 @@ func SignatureCheck(uriBlocked func(context.Context, *url.URL) (bool, error)) fu
 
 		// Set relevant values on the request context
 		// to save some work further down the line.
-		ctx = gtscontext.SetHTTPSignatureVerifier(ctx, verifier)
+		if msgVerifier != nil {
+			ctx = gtscontext.SetHTTPMessageSignatureVerifier(ctx, msgVerifier)
+		} else {
+			ctx = gtscontext.SetHTTPSignatureVerifier(ctx, verifier)
+		}
 		ctx = gtscontext.SetHTTPSignature(ctx, signature)
 		ctx = gtscontext.SetHTTPSignaturePubKeyID(ctx, pubKeyID)
 
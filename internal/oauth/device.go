@@ -0,0 +1,308 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+	"code.superseriousbusiness.org/oauth2/v4"
+	oautherr "code.superseriousbusiness.org/oauth2/v4/errors"
+)
+
+const (
+	// DeviceCodeGrantType is the grant type used by
+	// clients to poll for an access token using a
+	// device code, as per RFC 8628 section 3.4.
+	DeviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+	// DeviceCodeExpiry is how long a device
+	// code + user code pair can be used for.
+	DeviceCodeExpiry = 15 * time.Minute
+
+	// DevicePollInterval is the minimum amount of
+	// time clients should wait between polling
+	// the token endpoint with a device code.
+	DevicePollInterval = 5 * time.Second
+
+	// userCodeChars is the set of characters used to
+	// generate user codes, as suggested by RFC 8628
+	// section 6.1: consonants only, so that the code
+	// is easy to type and unlikely to spell words.
+	userCodeChars = "BCDFGHJKLMNPQRSTVWXZ"
+
+	// userCodeLen is the number of characters
+	// in a user code, excluding the hyphen.
+	userCodeLen = 8
+)
+
+// NormalizeUserCode returns the given user code in
+// the form it's stored in, ie., upper case with a
+// hyphen in the middle, ignoring any characters
+// that can't appear in a user code. Returns an
+// empty string if it can't be a valid user code.
+func NormalizeUserCode(userCode string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(userCode) {
+		if strings.ContainsRune(userCodeChars, r) {
+			b.WriteRune(r)
+		}
+	}
+
+	code := b.String()
+	if len(code) != userCodeLen {
+		return ""
+	}
+
+	return code[:userCodeLen/2] + "-" + code[userCodeLen/2:]
+}
+
+// newUserCode generates a new random user code
+// in the form returned by NormalizeUserCode.
+func newUserCode() (string, error) {
+	max := big.NewInt(int64(len(userCodeChars)))
+	code := make([]byte, 0, userCodeLen+1)
+	for i := 0; i < userCodeLen; i++ {
+		if i == userCodeLen/2 {
+			code = append(code, '-')
+		}
+
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code = append(code, userCodeChars[n.Int64()])
+	}
+	return string(code), nil
+}
+
+// CreateDeviceAuthorization authenticates the given client, checks
+// the requested scope, and creates a new device authorization with
+// a device code for the client to poll with, and a user code for the
+// user to enter in their browser to approve the request.
+func (s *s) CreateDeviceAuthorization(
+	ctx context.Context,
+	clientID string,
+	clientSecret string,
+	scope string,
+) (*gtsmodel.DeviceAuthorization, gtserror.WithCode) {
+	if _, errWithCode := s.authenticateClient(ctx, clientID, clientSecret); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Make sure requested scopes are all
+	// within scopes permitted by the client.
+	tgr := &oauth2.TokenGenerateRequest{
+		ClientID: clientID,
+		Scope:    scope,
+	}
+	allowed, err := s.server.ClientScopeHandler(tgr)
+	if err != nil {
+		err := gtserror.Newf("error checking client scope: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if !allowed {
+		help := "requested scope " + tgr.Scope + " was not covered by client scope"
+		return nil, gtserror.NewErrorForbidden(oautherr.ErrInvalidScope, help, HelpfulAdvice)
+	}
+
+	deviceAuth := &gtsmodel.DeviceAuthorization{
+		ID:         id.NewULID(),
+		ExpiresAt:  time.Now().Add(DeviceCodeExpiry),
+		ClientID:   clientID,
+		Scope:      tgr.Scope,
+		DeviceCode: rand.Text(),
+	}
+
+	// User codes are short enough that they could
+	// (very occasionally) clash, so try a few times.
+	for i := 0; ; i++ {
+		deviceAuth.UserCode, err = newUserCode()
+		if err != nil {
+			err := gtserror.Newf("error generating user code: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		err = s.state.DB.PutDeviceAuthorization(ctx, deviceAuth)
+		if err == nil {
+			break
+		}
+
+		if !errors.Is(err, db.ErrAlreadyExists) || i == 2 {
+			err := gtserror.Newf("db error putting device authorization: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return deviceAuth, nil
+}
+
+// handleDeviceCodeTokenRequest handles a client polling the token
+// endpoint with a device code, returning either a token (if the user
+// has approved the request), or one of the errors in RFC 8628 section
+// 3.5 indicating why the client doesn't get a token (yet).
+func (s *s) handleDeviceCodeTokenRequest(
+	ctx context.Context,
+	r *http.Request,
+) (map[string]interface{}, gtserror.WithCode) {
+	var (
+		clientID     = r.FormValue("client_id")
+		clientSecret = r.FormValue("client_secret")
+		deviceCode   = r.FormValue("device_code")
+	)
+
+	app, errWithCode := s.authenticateClient(ctx, clientID, clientSecret)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	deviceAuth, err := s.state.DB.GetDeviceAuthorizationByDeviceCode(ctx, deviceCode)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting device authorization: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if deviceAuth == nil || deviceAuth.ClientID != clientID {
+		// Device code never existed, has already
+		// been swapped for a token, or was issued
+		// to a different client than this one.
+		const help = "device code is invalid or has already been used"
+		return nil, gtserror.NewErrorBadRequest(oautherr.ErrInvalidGrant, help, HelpfulAdvice)
+	}
+
+	if deviceAuth.IsExpired() {
+		const help = "device code has expired, request a new one and try again"
+		return nil, gtserror.NewErrorBadRequest(ErrExpiredToken, help)
+	}
+
+	if !deviceAuth.IsApproved() {
+		// Check how long it's been since
+		// the client last polled with this
+		// device code, then update the time.
+		now := time.Now()
+		tooSoon := !deviceAuth.LastPolledAt.IsZero() &&
+			now.Sub(deviceAuth.LastPolledAt) < DevicePollInterval
+
+		deviceAuth.LastPolledAt = now
+		if err := s.state.DB.UpdateDeviceAuthorization(
+			ctx,
+			deviceAuth,
+			"last_polled_at",
+		); err != nil {
+			err := gtserror.Newf("db error updating device authorization: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if tooSoon {
+			const help = "polling too frequently, increase the polling interval by 5 seconds"
+			return nil, gtserror.NewErrorBadRequest(ErrSlowDown, help)
+		}
+
+		const help = "waiting for the user to approve the request"
+		return nil, gtserror.NewErrorBadRequest(ErrAuthorizationPending, help)
+	}
+
+	// Approved! Device codes are single use, so
+	// delete it before going on. Only the call
+	// that actually deletes it may get a token,
+	// in case of concurrent polls by the client.
+	err = s.state.DB.DeleteDeviceAuthorizationByID(ctx, deviceAuth.ID)
+	switch {
+	case errors.Is(err, db.ErrNoEntries):
+		const help = "device code is invalid or has already been used"
+		return nil, gtserror.NewErrorBadRequest(oautherr.ErrInvalidGrant, help, HelpfulAdvice)
+
+	case err != nil:
+		err := gtserror.Newf("db error deleting device authorization: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Go through the usual authorization code flow
+	// to issue the token, so that device tokens get
+	// the same expiry and refresh token settings.
+	//
+	// There's no redirect in the device flow, but
+	// tokens need one, so use the client's first.
+	authToken, err := s.server.Manager.GenerateAuthToken(ctx, oauth2.Code, &oauth2.TokenGenerateRequest{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		UserID:       deviceAuth.UserID,
+		RedirectURI:  app.RedirectURIs[0],
+		Scope:        deviceAuth.Scope,
+	})
+	if err != nil {
+		err := gtserror.Newf("error generating auth token: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	ti, err := s.server.Manager.GenerateAccessToken(ctx, oauth2.AuthorizationCode, &oauth2.TokenGenerateRequest{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURI:  authToken.GetRedirectURI(),
+		Scope:        authToken.GetScope(),
+		Code:         authToken.GetCode(),
+	})
+	if err != nil {
+		err := gtserror.Newf("error generating access token: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return s.tokenData(ti), nil
+}
+
+// authenticateClient checks that the client with the
+// given ID exists, and that the client secret is correct
+// for it (or that it's a public client without a secret).
+func (s *s) authenticateClient(
+	ctx context.Context,
+	clientID string,
+	clientSecret string,
+) (*gtsmodel.Application, gtserror.WithCode) {
+	client, err := s.server.Manager.GetClient(ctx, clientID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		// Real error.
+		err := gtserror.Newf("db error getting application with client id %s: %w", clientID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if util.IsNil(client) {
+		const help = "client authentication failed, make sure client_id is correct"
+		return nil, gtserror.NewErrorUnauthorized(oautherr.ErrInvalidClient, help, HelpfulAdvice)
+	}
+
+	// This will panic if client is not a
+	// *gtsmodel.Application, which is what we want.
+	app := client.(*gtsmodel.Application)
+	if !app.VerifyPassword(clientSecret) {
+		const help = "client authentication failed, make sure client_secret is correct"
+		return nil, gtserror.NewErrorUnauthorized(oautherr.ErrInvalidClient, help, HelpfulAdvice)
+	}
+
+	return app, nil
+}
@@ -21,3 +21,15 @@
 
 // ErrInvalidRequest is an oauth spec compliant 'invalid_request' error.
 var ErrInvalidRequest = errors.New("invalid_request")
+
+// ErrAuthorizationPending is an oauth spec compliant 'authorization_pending' error,
+// returned when polling for a device code that the user hasn't approved yet.
+var ErrAuthorizationPending = errors.New("authorization_pending")
+
+// ErrSlowDown is an oauth spec compliant 'slow_down' error, returned
+// when a client polls for a device code more often than it should.
+var ErrSlowDown = errors.New("slow_down")
+
+// ErrExpiredToken is an oauth spec compliant 'expired_token' error,
+// returned when polling for a device code that has expired.
+var ErrExpiredToken = errors.New("expired_token")
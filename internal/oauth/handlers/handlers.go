@@ -61,28 +61,44 @@ func GetClientScopeHandler(ctx context.Context, state *state.State) server.Clien
 
 		// Make sure requested scopes are all
 		// within scopes permitted by application.
-		hasScopes := strings.Split(application.Scopes, " ")
-		wantsScopes := strings.Split(tgr.Scope, " ")
-		for _, wantsScope := range wantsScopes {
-			thisOK := slices.ContainsFunc(
-				hasScopes,
-				func(hasScope string) bool {
-					has := apiutil.Scope(hasScope)
-					wants := apiutil.Scope(wantsScope)
-					return has.Permits(wants)
-				},
-			)
-
-			if !thisOK {
-				// Requested unpermitted
-				// scope for this app.
-				return false, nil
-			}
-		}
+		return scopesPermit(application.Scopes, tgr.Scope), nil
+	}
+}
+
+// GetRefreshingScopeHandler returns a handler for testing scope
+// on a TokenGenerateRequest using the refresh_token grant type.
+//
+// Clients may request a narrower scope when refreshing
+// a token, but never more than the token originally had.
+func GetRefreshingScopeHandler() server.RefreshingScopeHandler {
+	return func(tgr *oauth2.TokenGenerateRequest, oldScope string) (allowed bool, err error) {
+		return scopesPermit(oldScope, tgr.Scope), nil
+	}
+}
+
+// scopesPermit returns true if every one of the space-separated
+// wantsScopes is permitted by one of the space-separated hasScopes.
+func scopesPermit(hasScopes string, wantsScopes string) bool {
+	has := strings.Split(hasScopes, " ")
+	for _, wantsScope := range strings.Split(wantsScopes, " ") {
+		thisOK := slices.ContainsFunc(
+			has,
+			func(hasScope string) bool {
+				has := apiutil.Scope(hasScope)
+				wants := apiutil.Scope(wantsScope)
+				return has.Permits(wants)
+			},
+		)
 
-		// All OK.
-		return true, nil
+		if !thisOK {
+			// Requested
+			// unpermitted scope.
+			return false
+		}
 	}
+
+	// All OK.
+	return true
 }
 
 func GetValidateURIHandler(ctx context.Context) manage.ValidateURIHandler {
@@ -24,6 +24,7 @@
 	"net/http"
 	"strings"
 
+	"code.superseriousbusiness.org/gotosocial/internal/config"
 	"code.superseriousbusiness.org/gotosocial/internal/db"
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
@@ -61,7 +62,7 @@
 	// HelpfulAdvice is a handy hint to users;
 	// particularly important during the login flow
 	HelpfulAdvice      = "If you arrived at this error during a sign in/oauth flow, please try clearing your session cookies and signing in again; if problems persist, make sure you're using the correct credentials"
-	HelpfulAdviceGrant = "If you arrived at this error during a sign in/oauth flow, your client is trying to use an unsupported OAuth grant type. Supported grant types are: authorization_code, client_credentials; please reach out to developer of your client"
+	HelpfulAdviceGrant = "If you arrived at this error during a sign in/oauth flow, your client is trying to use an unsupported OAuth grant type. Supported grant types are: authorization_code, client_credentials, refresh_token, urn:ietf:params:oauth:grant-type:device_code; please reach out to developer of your client"
 )
 
 // Server wraps some oauth2 server functions
@@ -72,13 +73,16 @@ type Server interface {
 	ValidationBearerToken(r *http.Request) (oauth2.TokenInfo, error)
 	GenerateUserAccessToken(ctx context.Context, ti oauth2.TokenInfo, clientSecret string, userID string) (accessToken oauth2.TokenInfo, err error)
 	LoadAccessToken(ctx context.Context, access string) (accessToken oauth2.TokenInfo, err error)
-	RevokeAccessToken(ctx context.Context, clientID string, clientSecret string, access string) gtserror.WithCode
+	RevokeToken(ctx context.Context, clientID string, clientSecret string, token string) gtserror.WithCode
+	CreateDeviceAuthorization(ctx context.Context, clientID string, clientSecret string, scope string) (*gtsmodel.DeviceAuthorization, gtserror.WithCode)
 }
 
 // s fulfils the Server interface
 // using the underlying oauth2 server.
 type s struct {
-	server *server.Server
+	server     *server.Server
+	tokenStore oauth2.TokenStore
+	state      *state.State
 }
 
 // New returns a new oauth server that implements the Server interface
@@ -88,6 +92,7 @@ func New(
 	validateURIHandler manage.ValidateURIHandler,
 	clientScopeHandler server.ClientScopeHandler,
 	authorizeScopeHandler server.AuthorizeScopeHandler,
+	refreshingScopeHandler server.RefreshingScopeHandler,
 	internalErrorHandler server.InternalErrorHandler,
 	responseErrorHandler server.ResponseErrorHandler,
 	userAuthorizationHandler server.UserAuthorizationHandler,
@@ -100,13 +105,33 @@ func New(
 	manager.SetValidateURIHandler(validateURIHandler)
 	manager.MapTokenStorage(ts)
 	manager.MapClientStorage(cs)
+
+	// Following the Mastodon API, access tokens
+	// don't expire by default, but admins can
+	// choose to give them a limited lifetime.
+	accessTokenExp := config.GetOAuthAccessTokenExpiry()
+	refreshTokenExp := config.GetOAuthRefreshTokenExpiry()
 	manager.SetAuthorizeCodeTokenCfg(
 		&manage.Config{
-			// Following the Mastodon API,
-			// access tokens don't expire.
-			AccessTokenExp: 0,
-			// Don't use refresh tokens.
-			IsGenerateRefresh: false,
+			AccessTokenExp:  accessTokenExp,
+			RefreshTokenExp: refreshTokenExp,
+			// Only bother with refresh tokens
+			// if access tokens actually expire.
+			IsGenerateRefresh: accessTokenExp > 0,
+		},
+	)
+	manager.SetRefreshTokenCfg(
+		&manage.RefreshingConfig{
+			AccessTokenExp:  accessTokenExp,
+			RefreshTokenExp: refreshTokenExp,
+			// Rotate refresh tokens: each time one is
+			// used, issue a new access + refresh token
+			// pair (with a fresh refresh lifetime),
+			// and invalidate the old pair.
+			IsGenerateRefresh:  true,
+			IsResetRefreshTime: true,
+			IsRemoveAccess:     true,
+			IsRemoveRefreshing: true,
 		},
 	)
 
@@ -121,9 +146,11 @@ func New(
 			// Allow:
 			// - Authorization Code (for first & third parties)
 			// - Client Credentials (for applications)
+			// - Refreshing (for expired access tokens)
 			AllowedGrantTypes: []oauth2.GrantType{
 				oauth2.AuthorizationCode,
 				oauth2.ClientCredentials,
+				oauth2.Refreshing,
 			},
 			AllowedCodeChallengeMethods: []oauth2.CodeChallengeMethod{
 				oauth2.CodeChallengeS256,
@@ -134,12 +161,17 @@ func New(
 	)
 	srv.SetAuthorizeScopeHandler(authorizeScopeHandler)
 	srv.SetClientScopeHandler(clientScopeHandler)
+	srv.SetRefreshingScopeHandler(refreshingScopeHandler)
 	srv.SetInternalErrorHandler(internalErrorHandler)
 	srv.SetResponseErrorHandler(responseErrorHandler)
 	srv.SetUserAuthorizationHandler(userAuthorizationHandler)
 	srv.SetClientInfoHandler(server.ClientFormHandler)
 
-	return &s{srv}
+	return &s{
+		server:     srv,
+		tokenStore: ts,
+		state:      state,
+	}
 }
 
 // HandleTokenRequest wraps the oauth2 library's HandleTokenRequest function,
@@ -148,6 +180,12 @@ func New(
 func (s *s) HandleTokenRequest(r *http.Request) (map[string]interface{}, gtserror.WithCode) {
 	ctx := r.Context()
 
+	if r.FormValue("grant_type") == DeviceCodeGrantType {
+		// The oauth2 library doesn't know
+		// about device codes, so handle it.
+		return s.handleDeviceCodeTokenRequest(ctx, r)
+	}
+
 	gt, tgr, err := s.server.ValidationTokenRequest(r)
 	if err != nil {
 		help := fmt.Sprintf("could not validate token request: %s", err)
@@ -158,6 +196,14 @@ func (s *s) HandleTokenRequest(r *http.Request) (map[string]interface{}, gtserro
 		return nil, gtserror.NewErrorBadRequest(err, help, adv)
 	}
 
+	if gt == oauth2.Refreshing {
+		// The oauth2 library doesn't check
+		// who's refreshing a token, so do it.
+		if errWithCode := s.validateRefreshRequest(ctx, tgr); errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
 	// Get access token + do our own nicer error handling.
 	ti, err := s.server.GetAccessToken(ctx, gt, tgr)
 	switch {
@@ -173,11 +219,26 @@ func (s *s) HandleTokenRequest(r *http.Request) (map[string]interface{}, gtserro
 		help := fmt.Sprintf("requested redirect URI %s was not covered by client redirect URIs", tgr.RedirectURI)
 		return nil, gtserror.NewErrorForbidden(err, help, HelpfulAdvice)
 
+	case errors.Is(err, oautherr.ErrMissingCodeVerifier):
+		help := "code_verifier must be set if, and only if, a code_challenge was provided when requesting the authorization code"
+		return nil, gtserror.NewErrorBadRequest(oautherr.ErrInvalidRequest, help, HelpfulAdvice)
+
+	case errors.Is(err, oautherr.ErrInvalidClient):
+		help := "client authentication failed, make sure client_secret is correct"
+		return nil, gtserror.NewErrorUnauthorized(err, help, HelpfulAdvice)
+
 	default:
 		help := fmt.Sprintf("could not get access token: %v", err)
 		return nil, gtserror.NewErrorBadRequest(err, help, HelpfulAdvice)
 	}
 
+	return s.tokenData(ti), nil
+}
+
+// tokenData serializes the given token info in
+// the format expected by callers of the token
+// endpoint, in line with the Mastodon API.
+func (s *s) tokenData(ti oauth2.TokenInfo) map[string]interface{} {
 	// Wrangle data a bit.
 	data := s.server.GetTokenData(ti)
 
@@ -195,7 +256,43 @@ func (s *s) HandleTokenRequest(r *http.Request) (map[string]interface{}, gtserro
 		}
 	}
 
-	return data, nil
+	return data
+}
+
+// validateRefreshRequest checks that the refresh token in the given
+// request was issued to the client making the request, and that the
+// client knows its own secret (if it's not a public client).
+func (s *s) validateRefreshRequest(ctx context.Context, tgr *oauth2.TokenGenerateRequest) gtserror.WithCode {
+	token, err := s.server.Manager.LoadRefreshToken(ctx, tgr.Refresh)
+	switch {
+	case err == nil:
+		// Got the token,
+		// keep checking.
+
+	case errorsv2.IsV2(
+		err,
+		db.ErrNoEntries,
+		oautherr.ErrInvalidRefreshToken,
+		oautherr.ErrExpiredRefreshToken,
+	):
+		// Token already used + rotated,
+		// revoked, expired, or never existed.
+		const help = "refresh token is invalid, expired, or has already been used"
+		return gtserror.NewErrorBadRequest(oautherr.ErrInvalidGrant, help, HelpfulAdvice)
+
+	default:
+		// Real error.
+		err := gtserror.Newf("db error loading refresh token: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if token.GetClientID() != tgr.ClientID {
+		const help = "refresh token was not issued to this client"
+		return gtserror.NewErrorBadRequest(oautherr.ErrInvalidGrant, help, HelpfulAdvice)
+	}
+
+	_, errWithCode := s.authenticateClient(ctx, tgr.ClientID, tgr.ClientSecret)
+	return errWithCode
 }
 
 func (s *s) errorOrRedirect(err error, w http.ResponseWriter, req *server.AuthorizeRequest) gtserror.WithCode {
@@ -223,6 +320,23 @@ func (s *s) HandleAuthorizeRequest(w http.ResponseWriter, r *http.Request) gtser
 		return s.errorOrRedirect(err, w, req)
 	}
 
+	// Public clients can't authenticate themselves
+	// with a client secret when exchanging the code
+	// for a token, so PKCE is the only way of making
+	// sure the code wasn't intercepted. Require it.
+	if req.CodeChallenge == "" {
+		client, err := s.server.Manager.GetClient(ctx, req.ClientID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			// Real error.
+			err := gtserror.Newf("db error getting application with client id %s: %w", req.ClientID, err)
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		if !util.IsNil(client) && client.IsPublic() {
+			return s.errorOrRedirect(oautherr.ErrCodeChallengeRquired, w, req)
+		}
+	}
+
 	// user authorization
 	userID, err := s.server.UserAuthorizationHandler(w, r)
 	if err != nil {
@@ -341,38 +455,51 @@ func (s *s) LoadAccessToken(ctx context.Context, access string) (accessToken oau
 	return s.server.Manager.LoadAccessToken(ctx, access)
 }
 
-func (s *s) RevokeAccessToken(
+// RevokeToken revokes the given access or refresh token, provided
+// that it was issued to the client with the given ID + secret. As
+// access and refresh tokens are issued in pairs, revoking either
+// one of them also invalidates the other.
+func (s *s) RevokeToken(
 	ctx context.Context,
 	clientID string,
 	clientSecret string,
-	access string,
+	token string,
 ) gtserror.WithCode {
-	token, err := s.server.Manager.LoadAccessToken(ctx, access)
+	// Token may be either an access
+	// token or a refresh token.
+	//
+	// Use the token store directly
+	// rather than the manager, as
+	// the manager won't give us
+	// already-expired access tokens
+	// which may still be refreshable.
+	ti, err := s.tokenStore.GetByAccess(ctx, token)
+	if errors.Is(err, db.ErrNoEntries) {
+		ti, err = s.tokenStore.GetByRefresh(ctx, token)
+	}
+
 	switch {
 	case err == nil:
 		// Got the token, can
 		// proceed to invalidate.
 
-	case errorsv2.IsV2(
-		err,
-		db.ErrNoEntries,
-		oautherr.ErrExpiredAccessToken,
-	):
-		// Token already deleted, expired,
-		// or doesn't exist, nothing to do.
+	case errors.Is(err, db.ErrNoEntries):
+		// Token already deleted
+		// or doesn't exist,
+		// nothing to do.
 		return nil
 
 	default:
 		// Real error.
-		log.Errorf(ctx, "db error loading access token: %v", err)
+		log.Errorf(ctx, "db error loading token: %v", err)
 		return gtserror.NewErrorInternalError(
 			oautherr.ErrServerError,
-			"db error loading access token, check logs",
+			"db error loading token, check logs",
 		)
 	}
 
 	// Ensure token's client ID matches provided client ID.
-	if token.GetClientID() != clientID {
+	if ti.GetClientID() != clientID {
 		log.Debug(ctx, "client id of token does not match provided client_id")
 		return gtserror.NewErrorForbidden(
 			oautherr.ErrUnauthorizedClient,
@@ -392,7 +519,10 @@ func (s *s) RevokeAccessToken(
 
 	// Ensure requester also knows the client secret,
 	// which confirms that they indeed created the client.
-	if client.GetSecret() != clientSecret {
+	//
+	// This will panic if client is not a
+	// *gtsmodel.Application, which is what we want.
+	if !client.(*gtsmodel.Application).VerifyPassword(clientSecret) {
 		log.Debug(ctx, "secret of client does not match provided client_secret")
 		return gtserror.NewErrorForbidden(
 			oautherr.ErrUnauthorizedClient,
@@ -401,12 +531,17 @@ func (s *s) RevokeAccessToken(
 	}
 
 	// All good, invalidate the token.
-	err = s.server.Manager.RemoveAccessToken(ctx, access)
+	if ti.GetAccess() == token {
+		err = s.server.Manager.RemoveAccessToken(ctx, token)
+	} else {
+		err = s.server.Manager.RemoveRefreshToken(ctx, token)
+	}
+
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
-		log.Errorf(ctx, "db error removing access token: %v", err)
+		log.Errorf(ctx, "db error removing token: %v", err)
 		return gtserror.NewErrorInternalError(
 			oautherr.ErrServerError,
-			"db error removing access token, check logs",
+			"db error removing token, check logs",
 		)
 	}
 
@@ -94,7 +94,10 @@ func (ts *tokenStore) sweep(ctx context.Context) error {
 			log.Tracef(ctx, "refresh token %s is expired", token.ID)
 			expired = true
 
-		case !token.AccessExpiresAt.IsZero() && token.AccessExpiresAt.Before(now):
+		case !token.AccessExpiresAt.IsZero() && token.AccessExpiresAt.Before(now) && token.Refresh == "":
+			// Only sweep expired access tokens if
+			// there's no (unexpired) refresh token
+			// that could still be used to refresh it.
 			log.Tracef(ctx, "access token %s is expired", token.ID)
 			expired = true
 		}
@@ -111,6 +114,13 @@ func (ts *tokenStore) sweep(ctx context.Context) error {
 		}
 	}
 
+	// Remove any device authorizations whose
+	// device + user codes can no longer be used.
+	if err := ts.state.DB.DeleteExpiredDeviceAuthorizations(ctx, now); err != nil {
+		err := gtserror.Newf("db error expiring device authorizations: %w", err)
+		return err
+	}
+
 	return nil
 }
 
@@ -121,6 +131,17 @@ func (ts *tokenStore) Create(ctx context.Context, info oauth2.TokenInfo) error {
 		return errors.New("info param was not a models.Token")
 	}
 
+	// Only S256 PKCE challenges are supported, as
+	// plain challenges are no use if the request
+	// containing them is intercepted. The server
+	// should already have checked this, but make
+	// sure we never store a code that would allow
+	// a plain code_verifier to be exchanged.
+	if t.CodeChallenge != "" &&
+		t.CodeChallengeMethod != oauth2.CodeChallengeS256.String() {
+		return gtserror.Newf("unsupported code challenge method %q", t.CodeChallengeMethod)
+	}
+
 	dbt := TokenToDBToken(t)
 	if dbt.ID == "" {
 		dbt.ID = id.NewULID()
@@ -225,26 +246,15 @@ func (ts *tokenStore) getUpdateToken(
 
 // TokenToDBToken is a lil util function that takes a gotosocial token and gives back a token for inserting into a database.
 func TokenToDBToken(tkn *models.Token) *gtsmodel.Token {
-	now := time.Now()
-
-	// For the following, we want to make sure we're not adding a time.Now() to an *empty* ExpiresIn, otherwise that's
+	// For the following, we want to make sure we're not adding a created time to an *empty* ExpiresIn, otherwise that's
 	// going to cause all sorts of interesting problems. So check first to make sure that the ExpiresIn is not equal
 	// to the zero value of a time.Duration, which is 0s. If it *is* empty/nil, just leave the ExpiresAt at nil as well.
-
-	cea := time.Time{}
-	if tkn.CodeExpiresIn != 0*time.Second {
-		cea = now.Add(tkn.CodeExpiresIn)
-	}
-
-	aea := time.Time{}
-	if tkn.AccessExpiresIn != 0*time.Second {
-		aea = now.Add(tkn.AccessExpiresIn)
-	}
-
-	rea := time.Time{}
-	if tkn.RefreshExpiresIn != 0*time.Second {
-		rea = now.Add(tkn.RefreshExpiresIn)
-	}
+	//
+	// ExpiresIn is always relative to the corresponding CreateAt (this is how the oauth2 library checks for expiry),
+	// which isn't necessarily now, eg., when a refresh token is carried over to a new token without being reset.
+	cea := expiresAt(tkn.CodeCreateAt, tkn.CodeExpiresIn)
+	aea := expiresAt(tkn.AccessCreateAt, tkn.AccessExpiresIn)
+	rea := expiresAt(tkn.RefreshCreateAt, tkn.RefreshExpiresIn)
 
 	return &gtsmodel.Token{
 		ClientID:            tkn.ClientID,
@@ -267,22 +277,9 @@ func TokenToDBToken(tkn *models.Token) *gtsmodel.Token {
 
 // DBTokenToToken is a lil util function that takes a database token and gives back a gotosocial token
 func DBTokenToToken(dbt *gtsmodel.Token) *models.Token {
-	now := time.Now()
-
-	var codeExpiresIn time.Duration
-	if !dbt.CodeExpiresAt.IsZero() {
-		codeExpiresIn = dbt.CodeExpiresAt.Sub(now)
-	}
-
-	var accessExpiresIn time.Duration
-	if !dbt.AccessExpiresAt.IsZero() {
-		accessExpiresIn = dbt.AccessExpiresAt.Sub(now)
-	}
-
-	var refreshExpiresIn time.Duration
-	if !dbt.RefreshExpiresAt.IsZero() {
-		refreshExpiresIn = dbt.RefreshExpiresAt.Sub(now)
-	}
+	codeExpiresIn := expiresIn(dbt.CodeCreateAt, dbt.CodeExpiresAt)
+	accessExpiresIn := expiresIn(dbt.AccessCreateAt, dbt.AccessExpiresAt)
+	refreshExpiresIn := expiresIn(dbt.RefreshCreateAt, dbt.RefreshExpiresAt)
 
 	return &models.Token{
 		ClientID:            dbt.ClientID,
@@ -302,3 +299,38 @@ func DBTokenToToken(dbt *gtsmodel.Token) *models.Token {
 		RefreshExpiresIn:    refreshExpiresIn,
 	}
 }
+
+// expiresAt returns the time at which something created at
+// createdAt expires, given the duration it's valid for. Zero
+// duration means no expiry, so zero time will be returned.
+func expiresAt(createdAt time.Time, expiresIn time.Duration) time.Time {
+	if expiresIn == 0 {
+		return time.Time{}
+	}
+
+	if createdAt.IsZero() {
+		// Should never happen
+		// but just in case.
+		createdAt = time.Now()
+	}
+
+	return createdAt.Add(expiresIn)
+}
+
+// expiresIn is the inverse of expiresAt, returning the duration
+// for which something created at createdAt is valid. Zero time
+// means no expiry, so zero duration will be returned.
+func expiresIn(createdAt time.Time, expiresAt time.Time) time.Duration {
+	if expiresAt.IsZero() {
+		return 0
+	}
+
+	if createdAt.IsZero() {
+		// Should never happen but
+		// just in case, count from
+		// now like we used to do.
+		return time.Until(expiresAt)
+	}
+
+	return expiresAt.Sub(createdAt)
+}
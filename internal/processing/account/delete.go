@@ -271,6 +271,16 @@ func (p *Processor) deleteAccountRelations(
 		log.Errorf("error deleting mutes to / from account: %v", err)
 	}
 
+	// Delete all suggestion dismissals targetting / originating from account.
+	if err := p.state.DB.DeleteAccountSuggestionDismissals(ctx, account.ID); err != nil {
+		log.Errorf("error deleting suggestion dismissals to / from account: %v", err)
+	}
+
+	// Delete any staff pick of account.
+	if err := p.state.DB.DeleteStaffPickByAccountID(ctx, account.ID); err != nil {
+		log.Errorf("error deleting staff pick of account: %v", err)
+	}
+
 	if account.IsLocal() {
 		// Process side-effects for deleting
 		// of account follows from local user.
@@ -464,6 +474,32 @@ func (p *Processor) deleteAccountPeripheral(
 		if err := p.state.DB.DeleteScheduledStatusesByAccountID(ctx, account.ID); err != nil {
 			log.Errorf("error deleting scheduled statuses for account: %v", err)
 		}
+
+		// Delete data archives (and their files) of given account, only for local.
+		archives, err := p.state.DB.GetArchives(ctx, account.ID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			log.Errorf("error getting archives for account: %v", err)
+		}
+		for _, archive := range archives {
+			if archive.Key != "" {
+				if err := p.state.Storage.Delete(ctx, archive.Key); err != nil {
+					log.Errorf("error deleting archive %s from storage: %v", archive.Key, err)
+				}
+			}
+			if err := p.state.DB.DeleteArchiveByID(ctx, archive.ID); err != nil {
+				log.Errorf("error deleting archive %s: %v", archive.ID, err)
+			}
+		}
+
+		// Delete data imports (and their failures) of given account, only for local.
+		if err := p.state.DB.DeleteImportsByAccountID(ctx, account.ID); err != nil {
+			log.Errorf("error deleting imports for account: %v", err)
+		}
+
+		// Delete domain blocks owned by given account, only for local.
+		if err := p.state.DB.DeleteUserDomainBlocksByAccountID(ctx, account.ID); err != nil {
+			log.Errorf("error deleting domain blocks for account: %v", err)
+		}
 	}
 
 	// Delete all bookmarks targeting given account, local and remote.
@@ -478,6 +514,24 @@ func (p *Processor) deleteAccountPeripheral(
 		log.Errorf("error deleting faves targeting account: %v", err)
 	}
 
+	// Delete all reactions targeting given account, local and remote.
+	if err := p.state.DB.DeleteStatusReactions(ctx, account.ID, ""); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		log.Errorf("error deleting reactions targeting account: %v", err)
+	}
+
+	// Delete all reactions by given account, local and remote.
+	if err := p.state.DB.DeleteStatusReactions(ctx, "", account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		log.Errorf("error deleting reactions by account: %v", err)
+	}
+
+	// Delete all featured tags of given account, local and remote.
+	if err := p.state.DB.DeleteAccountFeaturedTags(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		log.Errorf("error deleting featured tags by account: %v", err)
+	}
+
 	// Delete all poll votes owned by given account, local and remote.
 	if err := p.state.DB.DeletePollVotesByAccountID(ctx, account.ID); // nocollapse
 	err != nil && !errors.Is(err, db.ErrNoEntries) {
@@ -558,6 +612,7 @@ func stubbifyAccount(account *gtsmodel.Account, origin string) []string {
 	account.AlsoKnownAsURIs = nil
 	account.MovedToURI = ""
 	account.Discoverable = util.Ptr(false)
+	account.Indexable = util.Ptr(false)
 	account.SuspendedAt = now
 	account.SuspensionOrigin = origin
 
@@ -576,6 +631,7 @@ func stubbifyAccount(account *gtsmodel.Account, origin string) []string {
 		"also_known_as_uris",
 		"moved_to_uri",
 		"discoverable",
+		"indexable",
 		"suspended_at",
 		"suspension_origin",
 	}
@@ -0,0 +1,183 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/paging"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+)
+
+// DomainBlocksGet returns a page of domains
+// blocked by the requesting account, newest first.
+func (p *Processor) DomainBlocksGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	blocks, err := p.state.DB.GetUserDomainBlocks(ctx, requester.ID, page)
+	if err != nil {
+		err := gtserror.Newf("db error getting user domain blocks: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(blocks)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	var (
+		items = make([]any, 0, count)
+
+		// Get the lowest and highest
+		// ID values, used for paging.
+		lo = blocks[count-1].ID
+		hi = blocks[0].ID
+	)
+
+	for _, block := range blocks {
+		// Domains are stored as punycode,
+		// show them as the user typed them.
+		domain, err := util.DePunify(block.Domain)
+		if err != nil {
+			log.Errorf(ctx, "error depunifying domain %s: %v", block.Domain, err)
+			domain = block.Domain
+		}
+
+		items = append(items, domain)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/domain_blocks",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// DomainBlockCreate blocks the given domain (and any
+// of its subdomains) for the requesting account only,
+// hiding accounts and statuses from it. Blocking an
+// already blocked domain is not an error.
+func (p *Processor) DomainBlockCreate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	domain string,
+) gtserror.WithCode {
+	punyDomain, errWithCode := validateUserDomainBlock(domain)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	block := &gtsmodel.UserDomainBlock{
+		ID:        id.NewULID(),
+		AccountID: requester.ID,
+		Domain:    punyDomain,
+	}
+
+	switch err := p.state.DB.PutUserDomainBlock(ctx, block); {
+	case err == nil:
+		// Block created, make sure
+		// timelines get re-filtered.
+		p.clearTimelines(ctx, requester)
+
+	case errors.Is(err, db.ErrAlreadyExists):
+		// Already blocked,
+		// nothing to do.
+
+	default:
+		err := gtserror.Newf("db error putting user domain block: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// DomainBlockRemove removes the requesting account's
+// block of the given domain, if there is one.
+func (p *Processor) DomainBlockRemove(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	domain string,
+) gtserror.WithCode {
+	punyDomain, errWithCode := validateUserDomainBlock(domain)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := p.state.DB.DeleteUserDomainBlock(ctx, requester.ID, punyDomain); err != nil {
+		err := gtserror.Newf("db error deleting user domain block: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Make sure timelines
+	// get re-filtered.
+	p.clearTimelines(ctx, requester)
+
+	return nil
+}
+
+// validateUserDomainBlock checks the given domain
+// may be blocked by a user, returning it as punycode.
+func validateUserDomainBlock(domain string) (string, gtserror.WithCode) {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+
+	punyDomain, err := util.PunifySafely(domain)
+	if err != nil || punyDomain == "" || strings.ContainsAny(punyDomain, "@/: ") {
+		text := fmt.Sprintf("domain %s is not a valid domain", domain)
+		return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if punyDomain == config.GetHost() ||
+		punyDomain == config.GetAccountDomain() {
+		const text = "you cannot block the domain of this instance"
+		return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	return punyDomain, nil
+}
+
+// clearTimelines clears the cached home and list timelines
+// of the given account, so that they get reloaded (and so
+// re-filtered) next time they're requested.
+func (p *Processor) clearTimelines(ctx context.Context, account *gtsmodel.Account) {
+	// Get list of list IDs created by this account.
+	listIDs, err := p.state.DB.GetListIDsByAccountID(ctx, account.ID)
+	if err != nil {
+		log.Errorf(ctx, "error getting account %s lists: %v", account.ID, err)
+	}
+
+	// Clear this account's home timeline.
+	p.state.Caches.Timelines.Home.Clear(account.ID)
+
+	// Clear list timelines.
+	for _, id := range listIDs {
+		p.state.Caches.Timelines.List.Clear(id)
+	}
+}
@@ -0,0 +1,273 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"code.superseriousbusiness.org/gotosocial/internal/ap"
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/messages"
+	"code.superseriousbusiness.org/gotosocial/internal/text"
+)
+
+const (
+	// maxFeaturedTags is the maximum number
+	// of hashtags an account can feature.
+	maxFeaturedTags = 10
+
+	// featuredTagSuggestionsLimit is the maximum
+	// number of featured tag suggestions returned.
+	featuredTagSuggestionsLimit = 10
+)
+
+// FeaturedTagsGet returns the hashtags featured on the profile of the requesting account.
+func (p *Processor) FeaturedTagsGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) ([]*apimodel.FeaturedTag, gtserror.WithCode) {
+	return p.featuredTagsGet(ctx, requester.ID)
+}
+
+// AccountFeaturedTagsGet returns the hashtags featured on the profile of the
+// target account, or an empty slice if requester and target block each other.
+func (p *Processor) AccountFeaturedTagsGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	targetAccountID string,
+) ([]*apimodel.FeaturedTag, gtserror.WithCode) {
+	targetAccount, err := p.state.DB.GetAccountByID(ctx, targetAccountID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.New("account not found")
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		err := gtserror.Newf("db error getting account: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	blocked, err := p.state.DB.IsEitherBlocked(ctx, requester.ID, targetAccount.ID)
+	if err != nil {
+		err := gtserror.Newf("db error checking blocks: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if blocked {
+		// Block exists between accounts.
+		// Just return empty featured tags.
+		return []*apimodel.FeaturedTag{}, nil
+	}
+
+	return p.featuredTagsGet(ctx, targetAccount.ID)
+}
+
+// WebFeaturedTagsGet returns the hashtags featured on
+// the profile of the target account, for the web view.
+func (p *Processor) WebFeaturedTagsGet(
+	ctx context.Context,
+	targetAccountID string,
+) ([]*apimodel.FeaturedTag, gtserror.WithCode) {
+	return p.featuredTagsGet(ctx, targetAccountID)
+}
+
+func (p *Processor) featuredTagsGet(
+	ctx context.Context,
+	accountID string,
+) ([]*apimodel.FeaturedTag, gtserror.WithCode) {
+	featuredTags, err := p.state.DB.GetAccountFeaturedTags(ctx, accountID)
+	if err != nil {
+		err := gtserror.Newf("db error getting featured tags: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiFeaturedTags := make([]*apimodel.FeaturedTag, 0, len(featuredTags))
+	for _, featuredTag := range featuredTags {
+		apiFeaturedTag, err := p.converter.FeaturedTagToAPIFeaturedTag(ctx, featuredTag)
+		if err != nil {
+			err := gtserror.Newf("error converting featured tag %s: %w", featuredTag.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		apiFeaturedTags = append(apiFeaturedTags, apiFeaturedTag)
+	}
+
+	return apiFeaturedTags, nil
+}
+
+// FeaturedTagCreate features the hashtag with the given
+// name on the profile of the requesting account, creating
+// the tag first if it doesn't exist yet.
+func (p *Processor) FeaturedTagCreate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	name string,
+) (*apimodel.FeaturedTag, gtserror.WithCode) {
+	// Normalize the name the same way
+	// we do when parsing it from a status.
+	normalized, ok := text.NormalizeHashtag(strings.TrimPrefix(name, "#"))
+	if !ok {
+		const text = "name must be a valid hashtag"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+	name = strings.ToLower(normalized)
+
+	featuredTags, err := p.state.DB.GetAccountFeaturedTags(ctx, requester.ID)
+	if err != nil {
+		err := gtserror.Newf("db error getting featured tags: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if len(featuredTags) >= maxFeaturedTags {
+		const text = "you cannot feature more hashtags"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	// Try to get an existing tag with that name.
+	tag, err := p.state.DB.GetTagByName(ctx, name)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting tag with name %s: %w", name, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// If there is no such tag, create it.
+	if tag == nil {
+		tag = &gtsmodel.Tag{
+			ID:   id.NewULID(),
+			Name: name,
+		}
+		if err := p.state.DB.PutTag(ctx, tag); err != nil {
+			err := gtserror.Newf("db error creating tag with name %s: %w", name, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	for _, featuredTag := range featuredTags {
+		if featuredTag.TagID == tag.ID {
+			const text = "you are already featuring this hashtag"
+			return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+	}
+
+	featuredTag := &gtsmodel.FeaturedTag{
+		ID:        id.NewULID(),
+		AccountID: requester.ID,
+		Account:   requester,
+		TagID:     tag.ID,
+		Tag:       tag,
+	}
+
+	if err := p.state.DB.PutFeaturedTag(ctx, featuredTag); err != nil {
+		err := gtserror.Newf("db error putting featured tag: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Count statuses that already use the tag.
+	if err := p.state.DB.UpdateFeaturedTagStats(ctx, featuredTag); err != nil {
+		err := gtserror.Newf("db error updating featured tag stats: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Let remotes know the featured tags changed.
+	p.federateFeaturedTagsUpdate(requester)
+
+	apiFeaturedTag, err := p.converter.FeaturedTagToAPIFeaturedTag(ctx, featuredTag)
+	if err != nil {
+		err := gtserror.Newf("error converting featured tag: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiFeaturedTag, nil
+}
+
+// FeaturedTagDelete stops featuring the featured tag
+// with the given ID on the requesting account's profile.
+func (p *Processor) FeaturedTagDelete(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	featuredTagID string,
+) gtserror.WithCode {
+	featuredTag, err := p.state.DB.GetFeaturedTagByID(ctx, featuredTagID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting featured tag: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if featuredTag == nil || featuredTag.AccountID != requester.ID {
+		// Don't leak existence of other accounts' featured tags.
+		err := gtserror.Newf("featured tag %s not found", featuredTagID)
+		return gtserror.NewErrorNotFound(err)
+	}
+
+	if err := p.state.DB.DeleteFeaturedTagByID(ctx, featuredTag.ID); err != nil {
+		err := gtserror.Newf("db error deleting featured tag: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Let remotes know the featured tags changed.
+	p.federateFeaturedTagsUpdate(requester)
+
+	return nil
+}
+
+// FeaturedTagSuggestionsGet returns hashtags recently used by
+// the requesting account, which it may want to feature.
+func (p *Processor) FeaturedTagSuggestionsGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) ([]*apimodel.Tag, gtserror.WithCode) {
+	tags, err := p.state.DB.GetFeaturedTagSuggestions(ctx, requester.ID, featuredTagSuggestionsLimit)
+	if err != nil {
+		err := gtserror.Newf("db error getting featured tag suggestions: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiTags := make([]*apimodel.Tag, 0, len(tags))
+	for _, tag := range tags {
+		following, err := p.state.DB.IsAccountFollowingTag(ctx, requester.ID, tag.ID)
+		if err != nil {
+			err := gtserror.Newf("db error checking whether account follows tag %s: %w", tag.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		apiTag, err := p.converter.TagToAPITag(ctx, tag, true, &following)
+		if err != nil {
+			err := gtserror.Newf("error converting tag %s: %w", tag.Name, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		apiTags = append(apiTags, &apiTag)
+	}
+
+	return apiTags, nil
+}
+
+// federateFeaturedTagsUpdate sends out an Update of the
+// given account's actor, so that remotes know to refetch
+// its featured tags collection.
+func (p *Processor) federateFeaturedTagsUpdate(account *gtsmodel.Account) {
+	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ActorPerson,
+		APActivityType: ap.ActivityUpdate,
+		GTSModel:       account,
+		Origin:         account,
+	})
+}
@@ -0,0 +1,104 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/ap"
+	"github.com/stretchr/testify/suite"
+)
+
+type FeaturedTagsTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *FeaturedTagsTestSuite) TestFeaturedTagsGet() {
+	ctx := suite.T().Context()
+	requester := suite.testAccounts["local_account_1"]
+	target := suite.testAccounts["admin_account"]
+
+	featuredTags, errWithCode := suite.accountProcessor.AccountFeaturedTagsGet(ctx, requester, target.ID)
+	suite.NoError(errWithCode)
+	suite.Len(featuredTags, 1)
+	suite.Equal("welcome", featuredTags[0].Name)
+	suite.Equal("http://localhost:8080/tags/welcome", featuredTags[0].URL)
+	suite.Equal("1", featuredTags[0].StatusesCount)
+	suite.Equal("2021-10-20", *featuredTags[0].LastStatusAt)
+}
+
+func (suite *FeaturedTagsTestSuite) TestFeaturedTagCreateDelete() {
+	ctx := suite.T().Context()
+	requester := suite.testAccounts["admin_account"]
+
+	// Feature a new tag, passing
+	// the name in a funny case.
+	featuredTag, errWithCode := suite.accountProcessor.FeaturedTagCreate(ctx, requester, "#SomethingNew")
+	suite.NoError(errWithCode)
+	suite.Equal("somethingnew", featuredTag.Name)
+	suite.Equal("0", featuredTag.StatusesCount)
+	suite.Nil(featuredTag.LastStatusAt)
+
+	// Account update should be federated.
+	msg, _ := suite.getClientMsg(5 * time.Second)
+	suite.Equal(ap.ActivityUpdate, msg.APActivityType)
+	suite.Equal(ap.ActorPerson, msg.APObjectType)
+
+	// Featuring it again should fail.
+	_, errWithCode = suite.accountProcessor.FeaturedTagCreate(ctx, requester, "somethingnew")
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	featuredTags, errWithCode := suite.accountProcessor.FeaturedTagsGet(ctx, requester)
+	suite.NoError(errWithCode)
+	suite.Len(featuredTags, 2)
+
+	// Other accounts can't remove it.
+	errWithCode = suite.accountProcessor.FeaturedTagDelete(ctx, suite.testAccounts["local_account_1"], featuredTag.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	errWithCode = suite.accountProcessor.FeaturedTagDelete(ctx, requester, featuredTag.ID)
+	suite.NoError(errWithCode)
+
+	featuredTags, errWithCode = suite.accountProcessor.FeaturedTagsGet(ctx, requester)
+	suite.NoError(errWithCode)
+	suite.Len(featuredTags, 1)
+}
+
+func (suite *FeaturedTagsTestSuite) TestFeaturedTagCreateInvalid() {
+	ctx := suite.T().Context()
+	requester := suite.testAccounts["admin_account"]
+
+	_, errWithCode := suite.accountProcessor.FeaturedTagCreate(ctx, requester, "not a hashtag")
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func (suite *FeaturedTagsTestSuite) TestFeaturedTagSuggestionsGet() {
+	ctx := suite.T().Context()
+	requester := suite.testAccounts["admin_account"]
+
+	tags, errWithCode := suite.accountProcessor.FeaturedTagSuggestionsGet(ctx, requester)
+	suite.NoError(errWithCode)
+	suite.Len(tags, 1)
+	suite.Equal("welcome", tags[0].Name)
+}
+
+func TestFeaturedTagsTestSuite(t *testing.T) {
+	suite.Run(t, new(FeaturedTagsTestSuite))
+}
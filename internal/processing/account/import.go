@@ -18,29 +18,44 @@
 package account
 
 import (
+	"cmp"
 	"context"
 	"encoding/csv"
 	"errors"
 	"fmt"
 	"mime/multipart"
+	"net/url"
 
 	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
 	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
 )
 
+// ImportData processes the given data file of the given
+// import type asynchronously, returning the new import
+// which can be used to follow progress of processing.
 func (p *Processor) ImportData(
 	ctx context.Context,
 	requester *gtsmodel.Account,
 	data *multipart.FileHeader,
 	importType string,
 	overwrite bool,
-) gtserror.WithCode {
+) (*apimodel.Import, gtserror.WithCode) {
+	var (
+		imp         *gtsmodel.Import
+		errWithCode gtserror.WithCode
+	)
+
 	switch importType {
 
 	case "following":
-		return p.importFollowing(
+		imp, errWithCode = p.importFollowing(
 			ctx,
 			requester,
 			data,
@@ -48,7 +63,7 @@ func (p *Processor) ImportData(
 		)
 
 	case "blocks":
-		return p.importBlocks(
+		imp, errWithCode = p.importBlocks(
 			ctx,
 			requester,
 			data,
@@ -56,7 +71,31 @@ func (p *Processor) ImportData(
 		)
 
 	case "mutes":
-		return p.importMutes(
+		imp, errWithCode = p.importMutes(
+			ctx,
+			requester,
+			data,
+			overwrite,
+		)
+
+	case "bookmarks":
+		imp, errWithCode = p.importBookmarks(
+			ctx,
+			requester,
+			data,
+			overwrite,
+		)
+
+	case "lists":
+		imp, errWithCode = p.importLists(
+			ctx,
+			requester,
+			data,
+			overwrite,
+		)
+
+	case "domain_blocks":
+		imp, errWithCode = p.importDomainBlocks(
 			ctx,
 			requester,
 			data,
@@ -65,8 +104,14 @@ func (p *Processor) ImportData(
 
 	default:
 		const text = "import type not yet supported"
-		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		errWithCode = gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	if errWithCode != nil {
+		return nil, errWithCode
 	}
+
+	return p.apiImport(ctx, imp, nil)
 }
 
 func (p *Processor) importFollowing(
@@ -74,11 +119,11 @@ func (p *Processor) importFollowing(
 	requester *gtsmodel.Account,
 	followingData *multipart.FileHeader,
 	overwrite bool,
-) gtserror.WithCode {
+) (*gtsmodel.Import, gtserror.WithCode) {
 	file, err := followingData.Open()
 	if err != nil {
 		err := fmt.Errorf("error opening following data file: %w", err)
-		return gtserror.NewErrorBadRequest(err, err.Error())
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
 	}
 	defer file.Close()
 
@@ -86,7 +131,7 @@ func (p *Processor) importFollowing(
 	records, err := csv.NewReader(file).ReadAll()
 	if err != nil {
 		err := fmt.Errorf("error reading following data file: %w", err)
-		return gtserror.NewErrorBadRequest(err, err.Error())
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
 	}
 
 	// Convert the records into a slice of barebones follows.
@@ -96,14 +141,26 @@ func (p *Processor) importFollowing(
 	follows, err := p.converter.CSVToFollowing(ctx, records)
 	if err != nil {
 		err := fmt.Errorf("error converting records to follows: %w", err)
-		return gtserror.NewErrorBadRequest(err, err.Error())
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Store the import so
+	// progress can be tracked.
+	imp, t, errWithCode := p.newImport(ctx,
+		requester,
+		"following",
+		overwrite,
+		len(follows),
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
 	}
 
 	// Do remaining processing of this import asynchronously.
-	f := importFollowingAsyncF(p, requester, follows, overwrite)
+	f := importFollowingAsyncF(p, requester, follows, overwrite, t)
 	p.state.Workers.Processing.Queue.Push(f)
 
-	return nil
+	return imp, nil
 }
 
 func importFollowingAsyncF(
@@ -111,8 +168,13 @@ func importFollowingAsyncF(
 	requester *gtsmodel.Account,
 	follows []*gtsmodel.Follow,
 	overwrite bool,
+	t *importTracker,
 ) func(context.Context) {
 	return func(ctx context.Context) {
+		// Mark the import as finished
+		// once we're done, however we exit.
+		defer t.finish(ctx)
+
 		// Map used to store wanted
 		// follow targets (if overwriting).
 		var wantedFollows map[string]struct{}
@@ -217,6 +279,7 @@ func importFollowingAsyncF(
 			)
 			if err != nil {
 				log.Errorf(ctx, "could not retrieve account: %v", err)
+				t.failed(ctx, importNamestring(username, domain), "account could not be retrieved")
 				continue
 			}
 
@@ -234,8 +297,11 @@ func importFollowingAsyncF(
 				},
 			); errWithCode != nil {
 				log.Errorf(ctx, "could not follow account: %v", errWithCode.Unwrap())
+				t.failed(ctx, importNamestring(username, domain), errWithCode.Safe())
 				continue
 			}
+
+			t.succeeded(ctx)
 		}
 	}
 }
@@ -245,11 +311,11 @@ func (p *Processor) importBlocks(
 	requester *gtsmodel.Account,
 	blocksData *multipart.FileHeader,
 	overwrite bool,
-) gtserror.WithCode {
+) (*gtsmodel.Import, gtserror.WithCode) {
 	file, err := blocksData.Open()
 	if err != nil {
 		err := fmt.Errorf("error opening blocks data file: %w", err)
-		return gtserror.NewErrorBadRequest(err, err.Error())
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
 	}
 	defer file.Close()
 
@@ -257,7 +323,7 @@ func (p *Processor) importBlocks(
 	records, err := csv.NewReader(file).ReadAll()
 	if err != nil {
 		err := fmt.Errorf("error reading blocks data file: %w", err)
-		return gtserror.NewErrorBadRequest(err, err.Error())
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
 	}
 
 	// Convert the records into a slice of barebones blocks.
@@ -267,14 +333,26 @@ func (p *Processor) importBlocks(
 	blocks, err := p.converter.CSVToBlocks(ctx, records)
 	if err != nil {
 		err := fmt.Errorf("error converting records to blocks: %w", err)
-		return gtserror.NewErrorBadRequest(err, err.Error())
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Store the import so
+	// progress can be tracked.
+	imp, t, errWithCode := p.newImport(ctx,
+		requester,
+		"blocks",
+		overwrite,
+		len(blocks),
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
 	}
 
 	// Do remaining processing of this import asynchronously.
-	f := importBlocksAsyncF(p, requester, blocks, overwrite)
+	f := importBlocksAsyncF(p, requester, blocks, overwrite, t)
 	p.state.Workers.Processing.Queue.Push(f)
 
-	return nil
+	return imp, nil
 }
 
 func importBlocksAsyncF(
@@ -282,8 +360,13 @@ func importBlocksAsyncF(
 	requester *gtsmodel.Account,
 	blocks []*gtsmodel.Block,
 	overwrite bool,
+	t *importTracker,
 ) func(context.Context) {
 	return func(ctx context.Context) {
+		// Mark the import as finished
+		// once we're done, however we exit.
+		defer t.finish(ctx)
+
 		// Map used to store wanted
 		// block targets (if overwriting).
 		var wantedBlocks map[string]struct{}
@@ -367,6 +450,7 @@ func importBlocksAsyncF(
 			)
 			if err != nil {
 				log.Errorf(ctx, "could not retrieve account: %v", err)
+				t.failed(ctx, importNamestring(username, domain), "account could not be retrieved")
 				continue
 			}
 
@@ -380,8 +464,11 @@ func importBlocksAsyncF(
 				targetAcct.ID,
 			); errWithCode != nil {
 				log.Errorf(ctx, "could not block account: %v", errWithCode.Unwrap())
+				t.failed(ctx, importNamestring(username, domain), errWithCode.Safe())
 				continue
 			}
+
+			t.succeeded(ctx)
 		}
 	}
 }
@@ -391,11 +478,11 @@ func (p *Processor) importMutes(
 	requester *gtsmodel.Account,
 	mutesData *multipart.FileHeader,
 	overwrite bool,
-) gtserror.WithCode {
+) (*gtsmodel.Import, gtserror.WithCode) {
 	file, err := mutesData.Open()
 	if err != nil {
 		err := fmt.Errorf("error opening mutes data file: %w", err)
-		return gtserror.NewErrorBadRequest(err, err.Error())
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
 	}
 	defer file.Close()
 
@@ -403,7 +490,7 @@ func (p *Processor) importMutes(
 	records, err := csv.NewReader(file).ReadAll()
 	if err != nil {
 		err := fmt.Errorf("error reading mutes data file: %w", err)
-		return gtserror.NewErrorBadRequest(err, err.Error())
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
 	}
 
 	// Convert the records into a slice of barebones mutes.
@@ -413,14 +500,26 @@ func (p *Processor) importMutes(
 	mutes, err := p.converter.CSVToMutes(ctx, records)
 	if err != nil {
 		err := fmt.Errorf("error converting records to mutes: %w", err)
-		return gtserror.NewErrorBadRequest(err, err.Error())
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Store the import so
+	// progress can be tracked.
+	imp, t, errWithCode := p.newImport(ctx,
+		requester,
+		"mutes",
+		overwrite,
+		len(mutes),
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
 	}
 
 	// Do remaining processing of this import asynchronously.
-	f := importMutesAsyncF(p, requester, mutes, overwrite)
+	f := importMutesAsyncF(p, requester, mutes, overwrite, t)
 	p.state.Workers.Processing.Queue.Push(f)
 
-	return nil
+	return imp, nil
 }
 
 func importMutesAsyncF(
@@ -428,8 +527,13 @@ func importMutesAsyncF(
 	requester *gtsmodel.Account,
 	mutes []*gtsmodel.UserMute,
 	overwrite bool,
+	t *importTracker,
 ) func(context.Context) {
 	return func(ctx context.Context) {
+		// Mark the import as finished
+		// once we're done, however we exit.
+		defer t.finish(ctx)
+
 		// Map used to store wanted
 		// mute targets (if overwriting).
 		var wantedMutes map[string]struct{}
@@ -513,6 +617,7 @@ func importMutesAsyncF(
 			)
 			if err != nil {
 				log.Errorf(ctx, "could not retrieve account: %v", err)
+				t.failed(ctx, importNamestring(username, domain), "account could not be retrieved")
 				continue
 			}
 
@@ -527,8 +632,676 @@ func importMutesAsyncF(
 				&apimodel.UserMuteCreateUpdateRequest{Notifications: mute.Notifications},
 			); errWithCode != nil {
 				log.Errorf(ctx, "could not mute account: %v", errWithCode.Unwrap())
+				t.failed(ctx, importNamestring(username, domain), errWithCode.Safe())
+				continue
+			}
+
+			t.succeeded(ctx)
+		}
+	}
+}
+
+func (p *Processor) importBookmarks(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	bookmarksData *multipart.FileHeader,
+	overwrite bool,
+) (*gtsmodel.Import, gtserror.WithCode) {
+	file, err := bookmarksData.Open()
+	if err != nil {
+		err := fmt.Errorf("error opening bookmarks data file: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+	defer file.Close()
+
+	// Parse records out of the file.
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		err := fmt.Errorf("error reading bookmarks data file: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Convert the records into a slice of barebones bookmarks.
+	//
+	// Only Status.URI will be set on each StatusBookmark.
+	bookmarks, err := p.converter.CSVToBookmarks(ctx, records)
+	if err != nil {
+		err := fmt.Errorf("error converting records to bookmarks: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Store the import so
+	// progress can be tracked.
+	imp, t, errWithCode := p.newImport(ctx,
+		requester,
+		"bookmarks",
+		overwrite,
+		len(bookmarks),
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Do remaining processing of this import asynchronously.
+	f := importBookmarksAsyncF(p, requester, bookmarks, overwrite, t)
+	p.state.Workers.Processing.Queue.Push(f)
+
+	return imp, nil
+}
+
+func importBookmarksAsyncF(
+	p *Processor,
+	requester *gtsmodel.Account,
+	bookmarks []*gtsmodel.StatusBookmark,
+	overwrite bool,
+	t *importTracker,
+) func(context.Context) {
+	return func(ctx context.Context) {
+		// Map used to store wanted
+		// bookmarked status IDs (if overwriting).
+		var wantedBookmarks map[string]struct{}
+
+		// Bookmarks owned by requester before
+		// the import began (if overwriting).
+		var prevBookmarks []*gtsmodel.StatusBookmark
+
+		if overwrite {
+			// If we're overwriting, we need to get current
+			// bookmarks owned by requester *before* making
+			// any changes, so that we can remove unwanted
+			// bookmarks after we've created new ones.
+			var err error
+			prevBookmarks, err = p.state.DB.GetStatusBookmarks(ctx, requester.ID, 0, "", "")
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				log.Errorf(ctx, "db error getting bookmarks: %v", err)
+				t.finish(ctx)
+				return
+			}
+
+			// Initialize new bookmarks map.
+			wantedBookmarks = make(map[string]struct{}, len(bookmarks))
+		}
+
+		// finish goes through previous bookmarks
+		// and removes unwanted ones (if overwriting),
+		// then marks the import as finished. Call once
+		// the required bookmarks have been created
+		// (or we've tried to create them).
+		finish := func(ctx context.Context) {
+			defer t.finish(ctx)
+
+			for _, prev := range prevBookmarks {
+				_, wanted := wantedBookmarks[prev.StatusID]
+				if wanted {
+					// Leave this
+					// one alone.
+					continue
+				}
+
+				if err := p.state.DB.DeleteStatusBookmarkByID(ctx, prev.ID); err != nil {
+					log.Errorf(ctx, "could not remove bookmark: %v", err)
+					continue
+				}
+
+				if err := p.c.InvalidateTimelinedStatus(ctx, requester.ID, prev.StatusID); err != nil {
+					log.Errorf(ctx, "error invalidating status from timelines: %v", err)
+				}
+			}
+		}
+
+		// URIs of bookmarked statuses
+		// we don't have stored yet.
+		var unknown []string
+
+		// Go through the bookmarks parsed from
+		// CSV file, and create each one whose
+		// status we already have stored.
+		for _, bookmark := range bookmarks {
+			uri := bookmark.Status.URI
+
+			status, err := p.getStoredImportStatus(ctx, uri)
+			if err != nil {
+				log.Errorf(ctx, "error getting status: %v", err)
+				t.failed(ctx, uri, "status could not be retrieved")
+				continue
+			}
+
+			if status == nil {
+				// Dereference later.
+				unknown = append(unknown, uri)
+				continue
+			}
+
+			p.importBookmark(ctx, requester, uri, status, wantedBookmarks, t)
+		}
+
+		if len(unknown) == 0 {
+			// Nothing to
+			// dereference.
+			finish(ctx)
+			return
+		}
+
+		// Dereference the remaining statuses one at a time
+		// in the background, rather than tying up processing
+		// workers with potentially very many remote fetches.
+		p.state.Workers.Dereference.Queue.Push(func(ctx context.Context) {
+			defer finish(ctx)
+
+			for _, uri := range unknown {
+				// Get the bookmarked status, only dereferencing
+				// if it wasn't stored in the meantime.
+				status, err := p.getImportStatus(ctx, requester, uri)
+				if err != nil {
+					log.Debugf(ctx, "could not retrieve status: %v", err)
+					t.failed(ctx, uri, "status could not be retrieved")
+					continue
+				}
+
+				p.importBookmark(ctx, requester, uri, status, wantedBookmarks, t)
+			}
+		})
+	}
+}
+
+// importBookmark creates a bookmark of the given status
+// (parsed from given URI) for requester if it's visible
+// and not already bookmarked, recording the outcome with
+// the tracker. If wanted is set, the status ID is added.
+func (p *Processor) importBookmark(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	uri string,
+	status *gtsmodel.Status,
+	wanted map[string]struct{},
+	t *importTracker,
+) {
+	if wanted != nil {
+		// We'll be overwriting, so store
+		// this bookmark in our handy map.
+		wanted[status.ID] = struct{}{}
+	}
+
+	visible, err := p.visFilter.StatusVisible(ctx, requester, status)
+	if err != nil {
+		log.Errorf(ctx, "error checking status visibility: %v", err)
+		t.failed(ctx, uri, "status visibility could not be checked")
+		return
+	}
+
+	if !visible {
+		t.failed(ctx, uri, "status is not visible to you")
+		return
+	}
+
+	existing, err := p.state.DB.GetStatusBookmark(ctx, requester.ID, status.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		log.Errorf(ctx, "db error checking existing bookmark: %v", err)
+		t.failed(ctx, uri, "existing bookmark could not be checked")
+		return
+	}
+
+	if existing != nil {
+		// Already bookmarked,
+		// nothing to do.
+		t.succeeded(ctx)
+		return
+	}
+
+	// Create and store a new bookmark.
+	if err := p.state.DB.PutStatusBookmark(ctx, &gtsmodel.StatusBookmark{
+		ID:              id.NewULID(),
+		AccountID:       requester.ID,
+		Account:         requester,
+		TargetAccountID: status.AccountID,
+		TargetAccount:   status.Account,
+		StatusID:        status.ID,
+		Status:          status,
+	}); err != nil {
+		log.Errorf(ctx, "db error putting bookmark: %v", err)
+		t.failed(ctx, uri, "bookmark could not be stored")
+		return
+	}
+
+	if err := p.c.InvalidateTimelinedStatus(ctx, requester.ID, status.ID); err != nil {
+		log.Errorf(ctx, "error invalidating status from timelines: %v", err)
+	}
+
+	t.succeeded(ctx)
+}
+
+// getStoredImportStatus gets the status with the given
+// URI (or URL) from the database, returning nil if
+// it isn't stored. It never dereferences the status.
+func (p *Processor) getStoredImportStatus(
+	ctx context.Context,
+	uriStr string,
+) (*gtsmodel.Status, error) {
+	status, err := p.state.DB.GetStatusByURI(ctx, uriStr)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting status by uri: %w", err)
+	}
+
+	if status != nil {
+		return status, nil
+	}
+
+	status, err = p.state.DB.GetStatusByURL(ctx, uriStr)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting status by url: %w", err)
+	}
+
+	return status, nil
+}
+
+// getImportStatus gets the status with the given URI
+// (or URL) from the database, only dereferencing it
+// if we don't have it yet. Statuses we already have
+// are used as-is, to avoid refetching each of them.
+func (p *Processor) getImportStatus(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	uriStr string,
+) (*gtsmodel.Status, error) {
+	status, err := p.getStoredImportStatus(ctx, uriStr)
+	if err != nil {
+		return nil, err
+	}
+
+	if status != nil {
+		return status, nil
+	}
+
+	uri, err := url.Parse(uriStr)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing status uri: %w", err)
+	}
+
+	status, _, err = p.federator.Dereferencer.GetStatusByURI(ctx,
+		requester.Username,
+		uri,
+	)
+	return status, err
+}
+
+func (p *Processor) importLists(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	listsData *multipart.FileHeader,
+	overwrite bool,
+) (*gtsmodel.Import, gtserror.WithCode) {
+	file, err := listsData.Open()
+	if err != nil {
+		err := fmt.Errorf("error opening lists data file: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+	defer file.Close()
+
+	// Parse records out of the file.
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		err := fmt.Errorf("error reading lists data file: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Convert the records into a slice of barebones list entries.
+	//
+	// Only List.Title, Follow.TargetAccount.Username and
+	// Follow.TargetAccount.Domain will be set on each ListEntry.
+	entries, err := p.converter.CSVToLists(ctx, records)
+	if err != nil {
+		err := fmt.Errorf("error converting records to lists: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Store the import so
+	// progress can be tracked.
+	imp, t, errWithCode := p.newImport(ctx,
+		requester,
+		"lists",
+		overwrite,
+		len(entries),
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Do remaining processing of this import asynchronously.
+	f := importListsAsyncF(p, requester, entries, overwrite, t)
+	p.state.Workers.Processing.Queue.Push(f)
+
+	return imp, nil
+}
+
+func importListsAsyncF(
+	p *Processor,
+	requester *gtsmodel.Account,
+	entries []*gtsmodel.ListEntry,
+	overwrite bool,
+	t *importTracker,
+) func(context.Context) {
+	return func(ctx context.Context) {
+		// Mark the import as finished
+		// once we're done, however we exit.
+		defer t.finish(ctx)
+
+		// Get requester's existing lists, keyed by
+		// title, so we can add entries to these.
+		lists, err := p.state.DB.GetListsByAccountID(ctx, requester.ID)
+		if err != nil {
+			log.Errorf(ctx, "db error getting lists: %v", err)
+			return
+		}
+		listsByTitle := util.KeyBy(lists, func(list *gtsmodel.List) string {
+			return list.Title
+		})
+
+		var (
+			// Follows currently in each list
+			// that entries are imported to,
+			// keyed by list ID then target
+			// account ID, loaded as needed.
+			inLists = make(map[string]map[string]*gtsmodel.Follow)
+
+			// Map used to store wanted target
+			// account IDs per list (if overwriting).
+			wantedEntries map[string]map[string]struct{}
+		)
+
+		if overwrite {
+			// Initialize new entries map.
+			wantedEntries = make(map[string]map[string]struct{})
+
+			// Once we've created (or tried to create)
+			// the required entries, go through previous
+			// entries of imported lists and remove
+			// unwanted ones. Lists not contained in
+			// the imported file are left alone.
+			defer func() {
+				for listID, wanted := range wantedEntries {
+					for targetAccountID, follow := range inLists[listID] {
+						if _, ok := wanted[targetAccountID]; ok {
+							// Leave this
+							// one alone.
+							continue
+						}
+
+						if err := p.state.DB.DeleteListEntry(ctx, listID, follow.ID); err != nil &&
+							!errors.Is(err, db.ErrNoEntries) {
+							log.Errorf(ctx, "could not remove list entry: %v", err)
+							continue
+						}
+					}
+				}
+			}()
+		}
+
+		// Go through the entries parsed from CSV
+		// file, and create (lists for) each one.
+		for _, entry := range entries {
+			var (
+				// Title of the list.
+				title = entry.List.Title
+
+				// Username of the target.
+				username = entry.Follow.TargetAccount.Username
+
+				// Domain of the target.
+				// Empty for our domain.
+				domain = entry.Follow.TargetAccount.Domain
+
+				// Entry as shown in failures.
+				failedEntry = title + ": " + importNamestring(username, domain)
+			)
+
+			// Get the list with this title,
+			// creating it if we don't have it.
+			list, ok := listsByTitle[title]
+			if !ok {
+				list = &gtsmodel.List{
+					ID:            id.NewULID(),
+					Title:         title,
+					AccountID:     requester.ID,
+					RepliesPolicy: gtsmodel.RepliesPolicyFollowed,
+					Exclusive:     util.Ptr(false),
+				}
+
+				if err := p.state.DB.PutList(ctx, list); err != nil {
+					log.Errorf(ctx, "db error putting list: %v", err)
+					t.failed(ctx, failedEntry, "list could not be created")
+					continue
+				}
+
+				listsByTitle[title] = list
+			}
+
+			// Get follows currently in the list.
+			inList, ok := inLists[list.ID]
+			if !ok {
+				follows, err := p.state.DB.GetFollowsInList(
+					gtscontext.SetBarebones(ctx),
+					list.ID,
+					nil,
+				)
+				if err != nil {
+					log.Errorf(ctx, "db error getting list follows: %v", err)
+					t.failed(ctx, failedEntry, "list entries could not be retrieved")
+					continue
+				}
+
+				inList = util.KeyBy(follows, func(follow *gtsmodel.Follow) string {
+					return follow.TargetAccountID
+				})
+				inLists[list.ID] = inList
+
+				if overwrite {
+					// We'll be overwriting, so
+					// track wanted list entries.
+					wantedEntries[list.ID] = make(map[string]struct{})
+				}
+			}
+
+			// Get the target account, dereferencing it if necessary.
+			targetAcct, _, err := p.federator.Dereferencer.GetAccountByUsernameDomain(
+				ctx,
+				requester.Username,
+				username,
+				domain,
+			)
+			if err != nil {
+				log.Errorf(ctx, "could not retrieve account: %v", err)
+				t.failed(ctx, failedEntry, "account could not be retrieved")
 				continue
 			}
+
+			if overwrite {
+				// We'll be overwriting, so store
+				// this entry in our handy map.
+				wantedEntries[list.ID][targetAcct.ID] = struct{}{}
+			}
+
+			if _, ok := inList[targetAcct.ID]; ok {
+				// Already in list,
+				// nothing to do.
+				t.succeeded(ctx)
+				continue
+			}
+
+			// Only followed accounts can be added to
+			// a list, so get the follow to the target.
+			follow, err := p.state.DB.GetFollow(
+				gtscontext.SetBarebones(ctx),
+				requester.ID,
+				targetAcct.ID,
+			)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				log.Errorf(ctx, "db error getting follow: %v", err)
+				t.failed(ctx, failedEntry, "follow could not be retrieved")
+				continue
+			}
+
+			if follow == nil {
+				t.failed(ctx, failedEntry, "you are not following this account")
+				continue
+			}
+
+			if err := p.state.DB.PutListEntries(ctx, []*gtsmodel.ListEntry{{
+				ID:       id.NewULID(),
+				ListID:   list.ID,
+				FollowID: follow.ID,
+			}}); err != nil {
+				log.Errorf(ctx, "db error putting list entry: %v", err)
+				t.failed(ctx, failedEntry, "list entry could not be stored")
+				continue
+			}
+
+			inList[targetAcct.ID] = follow
+			t.succeeded(ctx)
 		}
 	}
 }
+
+func (p *Processor) importDomainBlocks(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	domainBlocksData *multipart.FileHeader,
+	overwrite bool,
+) (*gtsmodel.Import, gtserror.WithCode) {
+	file, err := domainBlocksData.Open()
+	if err != nil {
+		err := fmt.Errorf("error opening domain blocks data file: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+	defer file.Close()
+
+	// Parse records out of the file.
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		err := fmt.Errorf("error reading domain blocks data file: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Convert the records into a slice of barebones domain blocks.
+	//
+	// Only Domain will be set on each UserDomainBlock.
+	blocks, err := p.converter.CSVToDomainBlocks(ctx, records)
+	if err != nil {
+		err := fmt.Errorf("error converting records to domain blocks: %w", err)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Store the import so
+	// progress can be tracked.
+	imp, t, errWithCode := p.newImport(ctx,
+		requester,
+		"domain_blocks",
+		overwrite,
+		len(blocks),
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Do remaining processing of this import asynchronously.
+	f := importDomainBlocksAsyncF(p, requester, blocks, overwrite, t)
+	p.state.Workers.Processing.Queue.Push(f)
+
+	return imp, nil
+}
+
+func importDomainBlocksAsyncF(
+	p *Processor,
+	requester *gtsmodel.Account,
+	blocks []*gtsmodel.UserDomainBlock,
+	overwrite bool,
+	t *importTracker,
+) func(context.Context) {
+	return func(ctx context.Context) {
+		// Mark the import as finished
+		// once we're done, however we exit.
+		defer t.finish(ctx)
+
+		// Make sure timelines get re-filtered
+		// once blocks have been changed.
+		defer p.clearTimelines(ctx, requester)
+
+		// Map used to store wanted
+		// blocked domains (if overwriting).
+		var wantedBlocks map[string]struct{}
+
+		if overwrite {
+			// If we're overwriting, we need to get current
+			// domain blocks owned by requester *before*
+			// making any changes, so that we can remove
+			// unwanted blocks after we've created new ones.
+			prevBlocks, err := p.state.DB.GetUserDomainBlocks(ctx, requester.ID, nil)
+			if err != nil {
+				log.Errorf(ctx, "db error getting domain blocks: %v", err)
+				return
+			}
+
+			// Initialize new blocks map.
+			wantedBlocks = make(map[string]struct{}, len(blocks))
+
+			// Once we've created (or tried to create)
+			// the required blocks, go through previous
+			// blocks and remove unwanted ones.
+			defer func() {
+				for _, prev := range prevBlocks {
+					_, wanted := wantedBlocks[prev.Domain]
+					if wanted {
+						// Leave this
+						// one alone.
+						continue
+					}
+
+					if err := p.state.DB.DeleteUserDomainBlock(ctx, requester.ID, prev.Domain); err != nil {
+						log.Errorf(ctx, "could not unblock domain: %v", err)
+						continue
+					}
+				}
+			}()
+		}
+
+		// Go through the domain blocks parsed
+		// from CSV file, and create each one.
+		for _, block := range blocks {
+			domain, errWithCode := validateUserDomainBlock(block.Domain)
+			if errWithCode != nil {
+				t.failed(ctx, block.Domain, errWithCode.Safe())
+				continue
+			}
+
+			if overwrite {
+				// We'll be overwriting, so store
+				// this new block in our handy map.
+				wantedBlocks[domain] = struct{}{}
+			}
+
+			if err := p.state.DB.PutUserDomainBlock(ctx, &gtsmodel.UserDomainBlock{
+				ID:        id.NewULID(),
+				AccountID: requester.ID,
+				Domain:    domain,
+			}); err != nil && !errors.Is(err, db.ErrAlreadyExists) {
+				log.Errorf(ctx, "db error putting domain block: %v", err)
+				t.failed(ctx, block.Domain, "domain block could not be stored")
+				continue
+			}
+
+			t.succeeded(ctx)
+		}
+	}
+}
+
+// importNamestring returns the given username and
+// domain as a namestring for showing import failures,
+// using our own domain for local accounts.
+func importNamestring(username string, domain string) string {
+	if domain == "" {
+		domain = cmp.Or(
+			config.GetAccountDomain(),
+			config.GetHost(),
+		)
+	}
+	return username + "@" + domain
+}
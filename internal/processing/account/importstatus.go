@@ -0,0 +1,194 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"context"
+	"errors"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/paging"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+)
+
+// ImportsGet returns a page of imports
+// of the requesting account, newest first.
+func (p *Processor) ImportsGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	imports, err := p.state.DB.GetImports(ctx, requester.ID, page)
+	if err != nil {
+		err := gtserror.Newf("db error getting imports: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(imports)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	var (
+		items = make([]any, 0, count)
+
+		// Get the lowest and highest
+		// ID values, used for paging.
+		lo = imports[count-1].ID
+		hi = imports[0].ID
+	)
+
+	for _, imp := range imports {
+		item, err := p.converter.ImportToAPIImport(ctx, imp, nil)
+		if err != nil {
+			log.Errorf(ctx, "error converting import %s: %v", imp.ID, err)
+			continue
+		}
+
+		items = append(items, item)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/import",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// ImportGet returns the import of the requesting
+// account with the given ID, including failures.
+func (p *Processor) ImportGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	id string,
+) (*apimodel.Import, gtserror.WithCode) {
+	imp, err := p.state.DB.GetImportByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting import: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if imp == nil || imp.AccountID != requester.ID {
+		const text = "import not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	failures, err := p.state.DB.GetImportFailures(ctx, imp.ID)
+	if err != nil {
+		err := gtserror.Newf("db error getting import failures: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiImport(ctx, imp, failures)
+}
+
+func (p *Processor) apiImport(
+	ctx context.Context,
+	imp *gtsmodel.Import,
+	failures []*gtsmodel.ImportFailure,
+) (*apimodel.Import, gtserror.WithCode) {
+	apiImport, err := p.converter.ImportToAPIImport(ctx, imp, failures)
+	if err != nil {
+		err := gtserror.Newf("error converting import to api: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiImport, nil
+}
+
+// importTracker records the progress of
+// processing an import, and any failures.
+type importTracker struct {
+	p   *Processor
+	imp *gtsmodel.Import
+}
+
+// newImport creates and stores a new in progress
+// import of the given type and number of entries,
+// returning it along with a tracker for processing
+// it. The tracker updates its own copy of the import,
+// so the returned import is safe to use concurrently.
+func (p *Processor) newImport(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	importType string,
+	overwrite bool,
+	total int,
+) (*gtsmodel.Import, *importTracker, gtserror.WithCode) {
+	imp := &gtsmodel.Import{
+		ID:         id.NewULID(),
+		AccountID:  requester.ID,
+		Type:       importType,
+		Overwrite:  util.Ptr(overwrite),
+		State:      gtsmodel.ImportStateInProgress,
+		TotalItems: total,
+	}
+
+	if err := p.state.DB.PutImport(ctx, imp); err != nil {
+		err := gtserror.Newf("db error putting import: %w", err)
+		return nil, nil, gtserror.NewErrorInternalError(err)
+	}
+
+	t := &importTracker{p: p, imp: util.Ptr(*imp)}
+	return imp, t, nil
+}
+
+// succeeded marks one more entry as processed.
+func (t *importTracker) succeeded(ctx context.Context) {
+	t.imp.ProcessedItems++
+	t.update(ctx, "processed_items")
+}
+
+// failed marks one more entry as processed but
+// failed, storing the entry and reason for failure.
+func (t *importTracker) failed(ctx context.Context, entry string, reason string) {
+	log.Debugf(ctx, "import %s entry %s failed: %s", t.imp.ID, entry, reason)
+
+	failure := &gtsmodel.ImportFailure{
+		ID:       id.NewULID(),
+		ImportID: t.imp.ID,
+		Entry:    entry,
+		Error:    reason,
+	}
+
+	if err := t.p.state.DB.PutImportFailure(ctx, failure); err != nil {
+		log.Errorf(ctx, "db error putting import failure: %v", err)
+	}
+
+	t.imp.ProcessedItems++
+	t.imp.FailedItems++
+	t.update(ctx, "processed_items", "failed_items")
+}
+
+// finish marks the import as finished.
+func (t *importTracker) finish(ctx context.Context) {
+	t.imp.State = gtsmodel.ImportStateFinished
+	t.update(ctx, "state")
+}
+
+func (t *importTracker) update(ctx context.Context, columns ...string) {
+	if err := t.p.state.DB.UpdateImport(ctx, t.imp, columns...); err != nil {
+		log.Errorf(ctx, "db error updating import %s: %v", t.imp.ID, err)
+	}
+}
@@ -38,10 +38,10 @@ func (p *Processor) DefaultInteractionPoliciesGet(
 
 	// Take set "direct" policy
 	// or global default.
-	direct := cmp.Or(
+	direct := withDefaultCanQuote(cmp.Or(
 		requester.Settings.InteractionPolicyDirect,
 		gtsmodel.DefaultInteractionPolicyDirect(),
-	)
+	), gtsmodel.VisibilityDirect)
 
 	directAPI, err := p.converter.InteractionPolicyToAPIInteractionPolicy(ctx, direct, nil, nil)
 	if err != nil {
@@ -51,10 +51,10 @@ func (p *Processor) DefaultInteractionPoliciesGet(
 
 	// Take set "private" policy
 	// or global default.
-	private := cmp.Or(
+	private := withDefaultCanQuote(cmp.Or(
 		requester.Settings.InteractionPolicyFollowersOnly,
 		gtsmodel.DefaultInteractionPolicyFollowersOnly(),
-	)
+	), gtsmodel.VisibilityFollowersOnly)
 
 	privateAPI, err := p.converter.InteractionPolicyToAPIInteractionPolicy(ctx, private, nil, nil)
 	if err != nil {
@@ -64,10 +64,10 @@ func (p *Processor) DefaultInteractionPoliciesGet(
 
 	// Take set "unlisted" policy
 	// or global default.
-	unlisted := cmp.Or(
+	unlisted := withDefaultCanQuote(cmp.Or(
 		requester.Settings.InteractionPolicyUnlocked,
 		gtsmodel.DefaultInteractionPolicyUnlocked(),
-	)
+	), gtsmodel.VisibilityUnlocked)
 
 	unlistedAPI, err := p.converter.InteractionPolicyToAPIInteractionPolicy(ctx, unlisted, nil, nil)
 	if err != nil {
@@ -77,10 +77,10 @@ func (p *Processor) DefaultInteractionPoliciesGet(
 
 	// Take set "public" policy
 	// or global default.
-	public := cmp.Or(
+	public := withDefaultCanQuote(cmp.Or(
 		requester.Settings.InteractionPolicyPublic,
 		gtsmodel.DefaultInteractionPolicyPublic(),
-	)
+	), gtsmodel.VisibilityPublic)
 
 	publicAPI, err := p.converter.InteractionPolicyToAPIInteractionPolicy(ctx, public, nil, nil)
 	if err != nil {
@@ -182,6 +182,27 @@ func (p *Processor) DefaultInteractionPoliciesUpdate(
 	return p.DefaultInteractionPoliciesGet(ctx, requester)
 }
 
+// withDefaultCanQuote returns the given policy with
+// CanQuote set to the default for the given visibility,
+// if it's not already set. This covers policies stored
+// before quote policies were introduced.
+func withDefaultCanQuote(
+	policy *gtsmodel.InteractionPolicy,
+	vis gtsmodel.Visibility,
+) *gtsmodel.InteractionPolicy {
+	if policy.CanQuote != nil {
+		// Already set.
+		return policy
+	}
+
+	// Copy policy so we don't
+	// modify the stored settings.
+	c := new(gtsmodel.InteractionPolicy)
+	*c = *policy
+	c.CanQuote = gtsmodel.DefaultCanQuoteFor(vis)
+	return c
+}
+
 // populateAccountSettings just ensures that
 // Settings is populated on the given account.
 func (p *Processor) populateAccountSettings(
@@ -23,6 +23,7 @@
 	"fmt"
 	"io"
 	"mime/multipart"
+	"time"
 
 	"code.superseriousbusiness.org/gotosocial/internal/ap"
 	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
@@ -77,6 +78,11 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 		acctColumns = append(acctColumns, "discoverable")
 	}
 
+	if form.Indexable != nil {
+		account.Indexable = form.Indexable
+		acctColumns = append(acctColumns, "indexable")
+	}
+
 	if bot := form.Bot; bot != nil {
 		if *bot {
 			// Mark account as an Application.
@@ -330,6 +336,51 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, form
 		settingsColumns = append(settingsColumns, "web_layout")
 	}
 
+	if form.EmailNotificationTypes != nil {
+		notificationTypes := make([]gtsmodel.NotificationType, 0, len(form.EmailNotificationTypes))
+		for _, in := range form.EmailNotificationTypes {
+			notificationType := gtsmodel.ParseNotificationType(in)
+			if notificationType == gtsmodel.NotificationUnknown {
+				text := fmt.Sprintf("email_notification_types contains unknown notification type %q", in)
+				return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+			}
+			notificationTypes = append(notificationTypes, notificationType)
+		}
+
+		account.Settings.EmailNotificationTypes = gtsmodel.NotificationFlagsFromSlice(notificationTypes)
+		settingsColumns = append(settingsColumns, "email_notification_types")
+	}
+
+	if form.EmailNotifications != nil {
+		emailNotifications := gtsmodel.ParseEmailNotifications(*form.EmailNotifications)
+		if emailNotifications == gtsmodel.EmailNotificationsUnknown {
+			const text = "email_notifications must be one of none, immediate, daily or weekly"
+			err := errors.New(text)
+			return nil, gtserror.NewErrorBadRequest(err, text)
+		}
+
+		if emailNotifications != gtsmodel.EmailNotificationsNone &&
+			account.Settings.EmailNotificationTypes == 0 {
+			// Enabling email notifications without
+			// any types selected, use default types.
+			account.Settings.EmailNotificationTypes = gtsmodel.NotificationFlagsFromSlice(
+				gtsmodel.EmailNotificationsDefaultTypes,
+			)
+			if form.EmailNotificationTypes == nil {
+				settingsColumns = append(settingsColumns, "email_notification_types")
+			}
+		}
+
+		if emailNotifications != account.Settings.EmailNotifications {
+			// Start digest period afresh.
+			account.Settings.EmailDigestSentAt = time.Now()
+			settingsColumns = append(settingsColumns, "email_digest_sent_at")
+		}
+
+		account.Settings.EmailNotifications = emailNotifications
+		settingsColumns = append(settingsColumns, "email_notifications")
+	}
+
 	// We've parsed + set everything, do
 	// necessary database updates now.
 
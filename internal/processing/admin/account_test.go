@@ -76,6 +76,185 @@ func (suite *AccountTestSuite) TestAccountActionSuspend() {
 	suite.NotZero(targetAcct.SuspendedAt)
 }
 
+func (suite *AccountTestSuite) TestAccountActionSilenceUnsilence() {
+	var (
+		ctx       = suite.T().Context()
+		adminAcct = suite.testAccounts["admin_account"]
+		targetID  = suite.testAccounts["remote_account_1"].ID
+	)
+
+	// Silence the account.
+	suite.runAccountAction(adminAcct, targetID, gtsmodel.AdminActionSilence)
+
+	targetAcct, err := suite.db.GetAccountByID(ctx, targetID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(targetAcct.IsSilenced())
+
+	// Silencing again should fail.
+	_, errWithCode := suite.adminProcessor.AccountAction(ctx, adminAcct, &apimodel.AdminActionRequest{
+		Type:     gtsmodel.AdminActionSilence.String(),
+		TargetID: targetID,
+	})
+	suite.EqualError(errWithCode, "account is already silenced")
+
+	// Unsilence the account.
+	suite.runAccountAction(adminAcct, targetID, gtsmodel.AdminActionUnsilence)
+
+	targetAcct, err = suite.db.GetAccountByID(ctx, targetID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(targetAcct.IsSilenced())
+}
+
+func (suite *AccountTestSuite) TestAccountActionDisableReenable() {
+	var (
+		ctx       = suite.T().Context()
+		adminAcct = suite.testAccounts["admin_account"]
+		targetID  = suite.testAccounts["local_account_1"].ID
+	)
+
+	// Disable the account.
+	suite.runAccountAction(adminAcct, targetID, gtsmodel.AdminActionDisable)
+
+	user, err := suite.db.GetUserByAccountID(ctx, targetID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(*user.Disabled)
+
+	// Reenable the account.
+	suite.runAccountAction(adminAcct, targetID, gtsmodel.AdminActionReenable)
+
+	user, err = suite.db.GetUserByAccountID(ctx, targetID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(*user.Disabled)
+}
+
+func (suite *AccountTestSuite) TestAccountActionDisableRemote() {
+	actionID, errWithCode := suite.adminProcessor.AccountAction(
+		suite.T().Context(),
+		suite.testAccounts["admin_account"],
+		&apimodel.AdminActionRequest{
+			Type:     gtsmodel.AdminActionDisable.String(),
+			TargetID: suite.testAccounts["remote_account_1"].ID,
+		},
+	)
+	suite.EqualError(errWithCode, "action can only be performed on local user accounts")
+	suite.Empty(actionID)
+}
+
+func (suite *AccountTestSuite) TestAccountActionUnsuspend() {
+	var (
+		ctx       = suite.T().Context()
+		adminAcct = suite.testAccounts["admin_account"]
+		targetID  = suite.testAccounts["local_account_1"].ID
+	)
+
+	// Account isn't suspended yet.
+	_, errWithCode := suite.adminProcessor.AccountAction(ctx, adminAcct, &apimodel.AdminActionRequest{
+		Type:     gtsmodel.AdminActionUnsuspend.String(),
+		TargetID: targetID,
+	})
+	suite.EqualError(errWithCode, "account is not suspended")
+
+	// Suspend, then unsuspend.
+	suite.runAccountAction(adminAcct, targetID, gtsmodel.AdminActionSuspend)
+	suite.runAccountAction(adminAcct, targetID, gtsmodel.AdminActionUnsuspend)
+
+	targetAcct, err := suite.db.GetAccountByID(ctx, targetID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(targetAcct.IsSuspended())
+	suite.Empty(targetAcct.SuspensionOrigin)
+}
+
+func (suite *AccountTestSuite) TestAccountActionSensitiveUnsensitive() {
+	var (
+		ctx       = suite.T().Context()
+		adminAcct = suite.testAccounts["admin_account"]
+		targetID  = suite.testAccounts["remote_account_1"].ID
+	)
+
+	// Sensitize the account with a content warning.
+	actionID, errWithCode := suite.adminProcessor.AccountAction(ctx, adminAcct, &apimodel.AdminActionRequest{
+		Type:           gtsmodel.AdminActionSensitive.String(),
+		TargetID:       targetID,
+		ContentWarning: "unmarked nsfw",
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.NotEmpty(actionID)
+
+	if !testrig.WaitFor(func() bool {
+		return suite.state.AdminActions.TotalRunning() == 0
+	}) {
+		suite.FailNow("timed out waiting for admin action(s) to finish")
+	}
+
+	targetAcct, err := suite.db.GetAccountByID(ctx, targetID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(targetAcct.IsSensitized())
+	suite.Equal("unmarked nsfw", targetAcct.SensitizedContentWarning)
+
+	// Unsensitize the account.
+	suite.runAccountAction(adminAcct, targetID, gtsmodel.AdminActionUnsensitive)
+
+	targetAcct, err = suite.db.GetAccountByID(ctx, targetID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(targetAcct.IsSensitized())
+	suite.Empty(targetAcct.SensitizedContentWarning)
+}
+
+// runAccountAction runs an admin action of the
+// given type on the target account, waits for it
+// to complete, and checks it completed without error.
+func (suite *AccountTestSuite) runAccountAction(
+	adminAcct *gtsmodel.Account,
+	targetID string,
+	actionType gtsmodel.AdminActionType,
+) {
+	ctx := suite.T().Context()
+
+	actionID, errWithCode := suite.adminProcessor.AccountAction(
+		ctx,
+		adminAcct,
+		&apimodel.AdminActionRequest{
+			Category: gtsmodel.AdminActionCategoryAccount.String(),
+			Type:     actionType.String(),
+			TargetID: targetID,
+		},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Wait for action to finish.
+	if !testrig.WaitFor(func() bool {
+		return suite.state.AdminActions.TotalRunning() == 0
+	}) {
+		suite.FailNow("timed out waiting for admin action(s) to finish")
+	}
+
+	adminAction, err := suite.db.GetAdminAction(ctx, actionID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.NotZero(adminAction.CompletedAt)
+	suite.Empty(adminAction.Errors)
+}
+
 func (suite *AccountTestSuite) TestAccountActionUnsupported() {
 	var (
 		ctx       = suite.T().Context()
@@ -93,7 +272,7 @@ func (suite *AccountTestSuite) TestAccountActionUnsupported() {
 		adminAcct,
 		request,
 	)
-	suite.EqualError(errWithCode, "admin action type pee pee poo poo is not supported for this endpoint, currently supported types are: [\"suspend\"]")
+	suite.EqualError(errWithCode, "admin action type pee pee poo poo is not supported for this endpoint, currently supported types are: [\"suspend\" \"unsuspend\" \"silence\" \"unsilence\" \"disable\" \"reenable\" \"sensitive\" \"unsensitive\"]")
 	suite.Empty(actionID)
 }
 
@@ -19,7 +19,9 @@
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"code.superseriousbusiness.org/gotosocial/internal/ap"
 	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
@@ -27,6 +29,7 @@
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
 	"code.superseriousbusiness.org/gotosocial/internal/id"
 	"code.superseriousbusiness.org/gotosocial/internal/messages"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
 )
 
 func (p *Processor) AccountAction(
@@ -44,11 +47,39 @@ func (p *Processor) AccountAction(
 	case gtsmodel.AdminActionSuspend:
 		return p.accountActionSuspend(ctx, adminAcct, targetAcct, request.Text)
 
+	case gtsmodel.AdminActionUnsuspend:
+		return p.accountActionUnsuspend(ctx, adminAcct, targetAcct, request.Text)
+
+	case gtsmodel.AdminActionSilence:
+		return p.accountActionSilence(ctx, adminAcct, targetAcct, request.Text)
+
+	case gtsmodel.AdminActionUnsilence:
+		return p.accountActionUnsilence(ctx, adminAcct, targetAcct, request.Text)
+
+	case gtsmodel.AdminActionDisable:
+		return p.accountActionDisable(ctx, adminAcct, targetAcct, request.Text)
+
+	case gtsmodel.AdminActionReenable:
+		return p.accountActionReenable(ctx, adminAcct, targetAcct, request.Text)
+
+	case gtsmodel.AdminActionSensitive:
+		return p.accountActionSensitive(ctx, adminAcct, targetAcct, request.Text, request.ContentWarning)
+
+	case gtsmodel.AdminActionUnsensitive:
+		return p.accountActionUnsensitive(ctx, adminAcct, targetAcct, request.Text)
+
 	default:
 		// TODO: add more types to this slice when adding
 		//       more types to the switch statement above.
 		supportedTypes := []string{
 			gtsmodel.AdminActionSuspend.String(),
+			gtsmodel.AdminActionUnsuspend.String(),
+			gtsmodel.AdminActionSilence.String(),
+			gtsmodel.AdminActionUnsilence.String(),
+			gtsmodel.AdminActionDisable.String(),
+			gtsmodel.AdminActionReenable.String(),
+			gtsmodel.AdminActionSensitive.String(),
+			gtsmodel.AdminActionUnsensitive.String(),
 		}
 
 		err := fmt.Errorf(
@@ -60,11 +91,16 @@ func (p *Processor) AccountAction(
 	}
 }
 
-func (p *Processor) accountActionSuspend(
+// runAccountAction runs the given function as a
+// tracked admin action of the given type, targeting
+// targetAcct, and returns the ID of the new action.
+func (p *Processor) runAccountAction(
 	ctx context.Context,
 	adminAcct *gtsmodel.Account,
 	targetAcct *gtsmodel.Account,
+	actionType gtsmodel.AdminActionType,
 	text string,
+	f func(context.Context) gtserror.MultiError,
 ) (string, gtserror.WithCode) {
 	actionID := id.NewULID()
 
@@ -75,10 +111,28 @@ func (p *Processor) accountActionSuspend(
 			TargetCategory: gtsmodel.AdminActionCategoryAccount,
 			TargetID:       targetAcct.ID,
 			Target:         targetAcct,
-			Type:           gtsmodel.AdminActionSuspend,
+			Type:           actionType,
 			AccountID:      adminAcct.ID,
 			Text:           text,
 		},
+		f,
+	)
+
+	return actionID, errWithCode
+}
+
+func (p *Processor) accountActionSuspend(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+	text string,
+) (string, gtserror.WithCode) {
+	return p.runAccountAction(
+		ctx,
+		adminAcct,
+		targetAcct,
+		gtsmodel.AdminActionSuspend,
+		text,
 		func(ctx context.Context) gtserror.MultiError {
 			if err := p.state.Workers.Client.Process(
 				ctx,
@@ -97,6 +151,289 @@ func(ctx context.Context) gtserror.MultiError {
 			return nil
 		},
 	)
+}
 
-	return actionID, errWithCode
+func (p *Processor) accountActionUnsuspend(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+	text string,
+) (string, gtserror.WithCode) {
+	if !targetAcct.IsSuspended() {
+		const text = "account is not suspended"
+		return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if targetAcct.IsRemote() {
+		// Don't allow unsuspending an account
+		// that's still caught by a domain block,
+		// it would just be suspended again.
+		blocked, err := p.state.DB.IsDomainBlocked(ctx, targetAcct.Domain)
+		if err != nil {
+			err := gtserror.Newf("db error checking domain block: %w", err)
+			return "", gtserror.NewErrorInternalError(err)
+		}
+
+		if blocked {
+			const text = "account domain is blocked; remove the domain block instead"
+			return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+	}
+
+	return p.runAccountAction(
+		ctx,
+		adminAcct,
+		targetAcct,
+		gtsmodel.AdminActionUnsuspend,
+		text,
+		func(ctx context.Context) gtserror.MultiError {
+			// Note that data removed by the suspension
+			// stays removed. Remote accounts will be
+			// dereferenced again on next access, while
+			// local users will need to reset their password.
+			targetAcct.SuspendedAt = time.Time{}
+			targetAcct.SuspensionOrigin = ""
+			return p.updateAccount(ctx, targetAcct,
+				"suspended_at",
+				"suspension_origin",
+			)
+		},
+	)
+}
+
+func (p *Processor) accountActionSilence(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+	text string,
+) (string, gtserror.WithCode) {
+	if targetAcct.IsSilenced() {
+		const text = "account is already silenced"
+		return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	return p.runAccountAction(
+		ctx,
+		adminAcct,
+		targetAcct,
+		gtsmodel.AdminActionSilence,
+		text,
+		func(ctx context.Context) gtserror.MultiError {
+			targetAcct.SilencedAt = time.Now()
+			errs := p.updateAccount(ctx, targetAcct, "silenced_at")
+
+			// Status visibility results are cached per
+			// status, not per author, so the only way to
+			// catch every status by this account is to
+			// drop all cached visibility results.
+			p.state.Caches.Visibility.Clear()
+			return errs
+		},
+	)
+}
+
+func (p *Processor) accountActionUnsilence(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+	text string,
+) (string, gtserror.WithCode) {
+	if !targetAcct.IsSilenced() {
+		const text = "account is not silenced"
+		return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	return p.runAccountAction(
+		ctx,
+		adminAcct,
+		targetAcct,
+		gtsmodel.AdminActionUnsilence,
+		text,
+		func(ctx context.Context) gtserror.MultiError {
+			targetAcct.SilencedAt = time.Time{}
+			errs := p.updateAccount(ctx, targetAcct, "silenced_at")
+
+			// See accountActionSilence().
+			p.state.Caches.Visibility.Clear()
+			return errs
+		},
+	)
+}
+
+func (p *Processor) accountActionDisable(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+	text string,
+) (string, gtserror.WithCode) {
+	user, errWithCode := p.accountActionUser(ctx, targetAcct)
+	if errWithCode != nil {
+		return "", errWithCode
+	}
+
+	if *user.Disabled {
+		const text = "account is already disabled"
+		return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	return p.runAccountAction(
+		ctx,
+		adminAcct,
+		targetAcct,
+		gtsmodel.AdminActionDisable,
+		text,
+		func(ctx context.Context) gtserror.MultiError {
+			user.Disabled = util.Ptr(true)
+			return p.updateUser(ctx, user, "disabled")
+		},
+	)
+}
+
+func (p *Processor) accountActionReenable(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+	text string,
+) (string, gtserror.WithCode) {
+	user, errWithCode := p.accountActionUser(ctx, targetAcct)
+	if errWithCode != nil {
+		return "", errWithCode
+	}
+
+	if !*user.Disabled {
+		const text = "account is not disabled"
+		return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	return p.runAccountAction(
+		ctx,
+		adminAcct,
+		targetAcct,
+		gtsmodel.AdminActionReenable,
+		text,
+		func(ctx context.Context) gtserror.MultiError {
+			user.Disabled = util.Ptr(false)
+			return p.updateUser(ctx, user, "disabled")
+		},
+	)
+}
+
+func (p *Processor) accountActionSensitive(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+	text string,
+	contentWarning string,
+) (string, gtserror.WithCode) {
+	if targetAcct.IsLocal() {
+		const text = "action can only be performed on remote accounts"
+		return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if errWithCode := validateForcedContentWarning(contentWarning); errWithCode != nil {
+		return "", errWithCode
+	}
+
+	return p.runAccountAction(
+		ctx,
+		adminAcct,
+		targetAcct,
+		gtsmodel.AdminActionSensitive,
+		text,
+		func(ctx context.Context) gtserror.MultiError {
+			// Note this only affects statuses
+			// received from now on, and this may
+			// also be used to change content warning
+			// for an already sensitized account.
+			if !targetAcct.IsSensitized() {
+				targetAcct.SensitizedAt = time.Now()
+			}
+			targetAcct.SensitizedContentWarning = contentWarning
+			return p.updateAccount(ctx, targetAcct,
+				"sensitized_at",
+				"sensitized_content_warning",
+			)
+		},
+	)
+}
+
+func (p *Processor) accountActionUnsensitive(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+	text string,
+) (string, gtserror.WithCode) {
+	if !targetAcct.IsSensitized() {
+		const text = "account is not sensitized"
+		return "", gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	return p.runAccountAction(
+		ctx,
+		adminAcct,
+		targetAcct,
+		gtsmodel.AdminActionUnsensitive,
+		text,
+		func(ctx context.Context) gtserror.MultiError {
+			targetAcct.SensitizedAt = time.Time{}
+			targetAcct.SensitizedContentWarning = ""
+			return p.updateAccount(ctx, targetAcct,
+				"sensitized_at",
+				"sensitized_content_warning",
+			)
+		},
+	)
+}
+
+// accountActionUser returns the user corresponding
+// to the given target account, or a 400 error if
+// the target account is not a local user account.
+func (p *Processor) accountActionUser(
+	ctx context.Context,
+	targetAcct *gtsmodel.Account,
+) (*gtsmodel.User, gtserror.WithCode) {
+	if targetAcct.IsRemote() || targetAcct.IsInstance() {
+		const text = "action can only be performed on local user accounts"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	user, err := p.state.DB.GetUserByAccountID(ctx, targetAcct.ID)
+	if err != nil {
+		err := gtserror.Newf("db error getting user for account %s: %w", targetAcct.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return user, nil
+}
+
+// updateAccount updates the given account
+// columns, wrapping any error as MultiError
+// for return from an admin action function.
+func (p *Processor) updateAccount(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	columns ...string,
+) gtserror.MultiError {
+	if err := p.state.DB.UpdateAccount(ctx, account, columns...); err != nil {
+		errs := gtserror.NewMultiError(1)
+		errs.Append(gtserror.Newf("db error updating account: %w", err))
+		return errs
+	}
+	return nil
+}
+
+// updateUser updates the given user columns,
+// wrapping any error as MultiError for return
+// from an admin action function.
+func (p *Processor) updateUser(
+	ctx context.Context,
+	user *gtsmodel.User,
+	columns ...string,
+) gtserror.MultiError {
+	if err := p.state.DB.UpdateUser(ctx, user, columns...); err != nil {
+		errs := gtserror.NewMultiError(1)
+		errs.Append(gtserror.Newf("db error updating user: %w", err))
+		return errs
+	}
+	return nil
 }
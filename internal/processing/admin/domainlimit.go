@@ -0,0 +1,222 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+)
+
+// maxContentWarningChars is the maximum length
+// of an admin-defined forced content warning.
+const maxContentWarningChars = 500
+
+// DomainLimitsGet returns all domain limits on this instance.
+func (p *Processor) DomainLimitsGet(ctx context.Context) ([]*apimodel.DomainLimit, gtserror.WithCode) {
+	limits, err := p.state.DB.GetDomainLimits(ctx)
+	if err != nil {
+		err := gtserror.Newf("db error getting domain limits: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiLimits := make([]*apimodel.DomainLimit, 0, len(limits))
+	for _, limit := range limits {
+		apiLimit, errWithCode := p.apiDomainLimit(ctx, limit)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		apiLimits = append(apiLimits, apiLimit)
+	}
+
+	return apiLimits, nil
+}
+
+// DomainLimitGet returns the domain limit with the given ID.
+func (p *Processor) DomainLimitGet(ctx context.Context, id string) (*apimodel.DomainLimit, gtserror.WithCode) {
+	limit, errWithCode := p.getDomainLimit(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiDomainLimit(ctx, limit)
+}
+
+// DomainLimitCreate creates a new limit on content
+// from the domain given in form, and its subdomains.
+func (p *Processor) DomainLimitCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	form *apimodel.DomainLimitRequest,
+) (*apimodel.DomainLimit, gtserror.WithCode) {
+	domain, err := util.PunifySafely(form.Domain)
+	if err != nil || domain == "" {
+		const text = "domain must be a valid domain name"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if domain == config.GetHost() ||
+		domain == config.GetAccountDomain() {
+		const text = "domain must not be this instance"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	limit := &gtsmodel.DomainLimit{
+		ID:                 id.NewULID(),
+		Domain:             domain,
+		CreatedByAccountID: adminAcct.ID,
+		PrivateComment:     util.PtrOrZero(form.PrivateComment),
+		ContentWarning:     util.PtrOrZero(form.ContentWarning),
+		Sensitive:          util.Ptr(util.PtrOrZero(form.Sensitive)),
+	}
+
+	if errWithCode := validateDomainLimit(limit); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.PutDomainLimit(ctx, limit); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			text := fmt.Sprintf("domain limit for %s already exists", form.Domain)
+			return nil, gtserror.NewErrorConflict(errors.New(text), text)
+		}
+		err := gtserror.Newf("db error putting domain limit: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiDomainLimit(ctx, limit)
+}
+
+// DomainLimitUpdate updates the domain limit with the given
+// ID, changing only those fields which are set in form.
+func (p *Processor) DomainLimitUpdate(
+	ctx context.Context,
+	id string,
+	form *apimodel.DomainLimitRequest,
+) (*apimodel.DomainLimit, gtserror.WithCode) {
+	limit, errWithCode := p.getDomainLimit(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	columns := make([]string, 0, 3)
+
+	if form.ContentWarning != nil {
+		limit.ContentWarning = *form.ContentWarning
+		columns = append(columns, "content_warning")
+	}
+
+	if form.Sensitive != nil {
+		limit.Sensitive = util.Ptr(*form.Sensitive)
+		columns = append(columns, "sensitive")
+	}
+
+	if form.PrivateComment != nil {
+		limit.PrivateComment = *form.PrivateComment
+		columns = append(columns, "private_comment")
+	}
+
+	if len(columns) == 0 {
+		// Nothing to do.
+		return p.apiDomainLimit(ctx, limit)
+	}
+
+	if errWithCode := validateDomainLimit(limit); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.UpdateDomainLimit(ctx, limit, columns...); err != nil {
+		err := gtserror.Newf("db error updating domain limit: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiDomainLimit(ctx, limit)
+}
+
+// DomainLimitDelete removes the domain limit with the given ID.
+// Statuses already limited by it are not changed.
+func (p *Processor) DomainLimitDelete(ctx context.Context, id string) (*apimodel.DomainLimit, gtserror.WithCode) {
+	limit, errWithCode := p.getDomainLimit(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteDomainLimitByID(ctx, limit.ID); err != nil {
+		err := gtserror.Newf("db error deleting domain limit: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiDomainLimit(ctx, limit)
+}
+
+// validateDomainLimit checks that the given domain limit
+// actually limits something, and has a sensible content warning.
+func validateDomainLimit(limit *gtsmodel.DomainLimit) gtserror.WithCode {
+	if limit.ContentWarning == "" && !*limit.Sensitive {
+		const text = "at least one of content_warning or sensitive must be set"
+		return gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	return validateForcedContentWarning(limit.ContentWarning)
+}
+
+// validateForcedContentWarning checks the length
+// of an admin-defined forced content warning.
+func validateForcedContentWarning(cw string) gtserror.WithCode {
+	if l := len([]rune(cw)); l > maxContentWarningChars {
+		text := fmt.Sprintf("content_warning must be %d characters or less, provided content_warning was %d characters", maxContentWarningChars, l)
+		return gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	return nil
+}
+
+// getDomainLimit fetches the domain limit with given ID.
+func (p *Processor) getDomainLimit(ctx context.Context, id string) (*gtsmodel.DomainLimit, gtserror.WithCode) {
+	limit, err := p.state.DB.GetDomainLimitByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting domain limit %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if limit == nil {
+		err := fmt.Errorf("domain limit %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	return limit, nil
+}
+
+// apiDomainLimit converts the given
+// domain limit to api model.
+func (p *Processor) apiDomainLimit(ctx context.Context, limit *gtsmodel.DomainLimit) (*apimodel.DomainLimit, gtserror.WithCode) {
+	apiLimit, err := p.converter.DomainLimitToAPIDomainLimit(ctx, limit)
+	if err != nil {
+		err := gtserror.Newf("error converting domain limit %s: %w", limit.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiLimit, nil
+}
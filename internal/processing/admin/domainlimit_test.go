@@ -0,0 +1,122 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"net/http"
+	"testing"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+	"github.com/stretchr/testify/suite"
+)
+
+type DomainLimitTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *DomainLimitTestSuite) TestDomainLimitCreateUpdateDelete() {
+	var (
+		ctx       = suite.T().Context()
+		adminAcct = suite.testAccounts["admin_account"]
+	)
+
+	// Create a new limit.
+	limit, errWithCode := suite.adminProcessor.DomainLimitCreate(ctx, adminAcct, &apimodel.DomainLimitRequest{
+		Domain:         "fossbros-anonymous.io",
+		ContentWarning: util.Ptr("unmarked nsfw"),
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("fossbros-anonymous.io", limit.Domain)
+	suite.Equal("unmarked nsfw", limit.ContentWarning)
+	suite.True(limit.Sensitive)
+
+	// Subdomains should match the limit.
+	dbLimit, err := suite.db.MatchDomainLimit(ctx, "sub.fossbros-anonymous.io")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(limit.ID, dbLimit.ID)
+
+	// Creating another limit on the same domain should conflict.
+	_, errWithCode = suite.adminProcessor.DomainLimitCreate(ctx, adminAcct, &apimodel.DomainLimitRequest{
+		Domain:    "fossbros-anonymous.io",
+		Sensitive: util.Ptr(true),
+	})
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	// Swap content warning for sensitive.
+	limit, errWithCode = suite.adminProcessor.DomainLimitUpdate(ctx, limit.ID, &apimodel.DomainLimitRequest{
+		ContentWarning: util.Ptr(""),
+		Sensitive:      util.Ptr(true),
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(limit.ContentWarning)
+	suite.True(limit.Sensitive)
+
+	// Unsetting everything isn't allowed.
+	_, errWithCode = suite.adminProcessor.DomainLimitUpdate(ctx, limit.ID, &apimodel.DomainLimitRequest{
+		Sensitive: util.Ptr(false),
+	})
+	suite.EqualError(errWithCode, "at least one of content_warning or sensitive must be set")
+
+	// Delete the limit.
+	if _, errWithCode := suite.adminProcessor.DomainLimitDelete(ctx, limit.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	_, err = suite.db.MatchDomainLimit(ctx, "sub.fossbros-anonymous.io")
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *DomainLimitTestSuite) TestDomainLimitCreateInvalid() {
+	var (
+		ctx       = suite.T().Context()
+		adminAcct = suite.testAccounts["admin_account"]
+	)
+
+	for _, test := range []struct {
+		form *apimodel.DomainLimitRequest
+		err  string
+	}{
+		{
+			form: &apimodel.DomainLimitRequest{Domain: "fossbros-anonymous.io"},
+			err:  "at least one of content_warning or sensitive must be set",
+		},
+		{
+			form: &apimodel.DomainLimitRequest{Domain: "localhost:8080", Sensitive: util.Ptr(true)},
+			err:  "domain must not be this instance",
+		},
+		{
+			form: &apimodel.DomainLimitRequest{Domain: "not a domain", Sensitive: util.Ptr(true)},
+			err:  "domain must be a valid domain name",
+		},
+	} {
+		_, errWithCode := suite.adminProcessor.DomainLimitCreate(ctx, adminAcct, test.form)
+		suite.EqualError(errWithCode, test.err)
+	}
+}
+
+func TestDomainLimitTestSuite(t *testing.T) {
+	suite.Run(t, new(DomainLimitTestSuite))
+}
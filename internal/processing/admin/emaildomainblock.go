@@ -0,0 +1,181 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/paging"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+)
+
+// EmailDomainBlocksGet returns a page of email domain blocks, newest first.
+func (p *Processor) EmailDomainBlocksGet(
+	ctx context.Context,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	blocks, err := p.state.DB.GetEmailDomainBlocks(ctx, page)
+	if err != nil {
+		err := gtserror.Newf("db error getting email domain blocks: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(blocks)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	var (
+		items = make([]any, 0, count)
+
+		// Get the lowest and highest
+		// ID values, used for paging.
+		lo = blocks[count-1].ID
+		hi = blocks[0].ID
+	)
+
+	for _, block := range blocks {
+		item, err := p.converter.EmailDomainBlockToAdminAPIEmailDomainBlock(ctx, block)
+		if err != nil {
+			log.Errorf(ctx, "error converting email domain block %s: %v", block.ID, err)
+			continue
+		}
+
+		items = append(items, item)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/admin/email_domain_blocks",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// EmailDomainBlockGet returns the email domain block with the given ID.
+func (p *Processor) EmailDomainBlockGet(ctx context.Context, id string) (*apimodel.AdminEmailDomainBlock, gtserror.WithCode) {
+	block, errWithCode := p.getEmailDomainBlock(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiEmailDomainBlock(ctx, block)
+}
+
+// EmailDomainBlockCreate blocks sign-ups using
+// e-mail addresses at the given domain, or
+// any of its subdomains.
+func (p *Processor) EmailDomainBlockCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	domain string,
+) (*apimodel.AdminEmailDomainBlock, gtserror.WithCode) {
+	// Allow domains to be given
+	// as they'd appear in an
+	// address, eg., "@example.org".
+	domain = strings.TrimPrefix(strings.TrimSpace(domain), "@")
+
+	punyDomain, err := util.PunifySafely(domain)
+	if err != nil || punyDomain == "" || strings.ContainsAny(punyDomain, "@/:") {
+		text := fmt.Sprintf("domain %s is not a valid e-mail domain", domain)
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	existing, err := p.state.DB.GetEmailDomainBlockByDomain(ctx, punyDomain)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error checking existing email domain block: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if existing != nil {
+		text := fmt.Sprintf("email domain block for %s already exists", domain)
+		return nil, gtserror.NewErrorConflict(errors.New(text), text)
+	}
+
+	block := &gtsmodel.EmailDomainBlock{
+		ID:                 id.NewULID(),
+		Domain:             punyDomain,
+		CreatedByAccountID: adminAcct.ID,
+	}
+
+	if err := p.state.DB.PutEmailDomainBlock(ctx, block); err != nil {
+		err := gtserror.Newf("db error putting email domain block: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiEmailDomainBlock(ctx, block)
+}
+
+// EmailDomainBlockDelete deletes the email domain block with the given ID.
+func (p *Processor) EmailDomainBlockDelete(ctx context.Context, id string) (*apimodel.AdminEmailDomainBlock, gtserror.WithCode) {
+	block, errWithCode := p.getEmailDomainBlock(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Convert to frontend model
+	// now, before we delete it.
+	apiBlock, errWithCode := p.apiEmailDomainBlock(ctx, block)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteEmailDomainBlockByID(ctx, block.ID); err != nil {
+		err := gtserror.Newf("db error deleting email domain block: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiBlock, nil
+}
+
+// getEmailDomainBlock fetches the email domain block with given ID.
+func (p *Processor) getEmailDomainBlock(ctx context.Context, id string) (*gtsmodel.EmailDomainBlock, gtserror.WithCode) {
+	block, err := p.state.DB.GetEmailDomainBlockByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting email domain block %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if block == nil {
+		err := fmt.Errorf("email domain block %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	return block, nil
+}
+
+// apiEmailDomainBlock converts the given
+// email domain block to admin api model.
+func (p *Processor) apiEmailDomainBlock(ctx context.Context, block *gtsmodel.EmailDomainBlock) (*apimodel.AdminEmailDomainBlock, gtserror.WithCode) {
+	apiBlock, err := p.converter.EmailDomainBlockToAdminAPIEmailDomainBlock(ctx, block)
+	if err != nil {
+		err := gtserror.Newf("error converting email domain block %s: %w", block.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiBlock, nil
+}
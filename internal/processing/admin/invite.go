@@ -0,0 +1,121 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	apiutil "code.superseriousbusiness.org/gotosocial/internal/api/util"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/paging"
+)
+
+// InvitesGet returns a page of invites created by
+// local accounts, optionally filtered to only invites
+// created by the account with the given ID.
+//
+// Users who signed up with an invite can be found
+// via the admin accounts API's invited_by parameter.
+func (p *Processor) InvitesGet(
+	ctx context.Context,
+	accountID string,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	invites, err := p.state.DB.GetInvites(ctx, accountID, page)
+	if err != nil {
+		err := gtserror.Newf("db error getting invites: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(invites)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	var (
+		items = make([]any, 0, count)
+
+		// Get the lowest and highest
+		// ID values, used for paging.
+		lo = invites[count-1].ID
+		hi = invites[0].ID
+	)
+
+	for _, invite := range invites {
+		item, err := p.converter.InviteToAdminAPIInvite(ctx, invite)
+		if err != nil {
+			log.Errorf(ctx, "error converting invite %s: %v", invite.ID, err)
+			continue
+		}
+
+		items = append(items, item)
+	}
+
+	var query url.Values
+	if accountID != "" {
+		query = url.Values{apiutil.AccountIDKey: []string{accountID}}
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/admin/invites",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+		Query: query,
+	}), nil
+}
+
+// InviteExpire expires the invite with the given ID,
+// so that it can no longer be used to sign up.
+func (p *Processor) InviteExpire(
+	ctx context.Context,
+	id string,
+) (*apimodel.Invite, gtserror.WithCode) {
+	invite, err := p.state.DB.GetInviteByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting invite: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if invite == nil {
+		const errText = "invite not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(errText), errText)
+	}
+
+	if now := time.Now(); !invite.Expired(now) {
+		invite.ExpiresAt = now
+		if err := p.state.DB.UpdateInvite(ctx, invite, "expires_at"); err != nil {
+			err := gtserror.Newf("db error updating invite: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	apiInvite, err := p.converter.InviteToAdminAPIInvite(ctx, invite)
+	if err != nil {
+		err := gtserror.Newf("error converting invite: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiInvite, nil
+}
@@ -0,0 +1,281 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/paging"
+)
+
+// IPBlocksGet returns a page of IP blocks, newest first.
+func (p *Processor) IPBlocksGet(
+	ctx context.Context,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	blocks, err := p.state.DB.GetIPBlocks(ctx, page)
+	if err != nil {
+		err := gtserror.Newf("db error getting ip blocks: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(blocks)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	var (
+		items = make([]any, 0, count)
+
+		// Get the lowest and highest
+		// ID values, used for paging.
+		lo = blocks[count-1].ID
+		hi = blocks[0].ID
+	)
+
+	for _, block := range blocks {
+		item, err := p.converter.IPBlockToAdminAPIIPBlock(ctx, block)
+		if err != nil {
+			log.Errorf(ctx, "error converting ip block %s: %v", block.ID, err)
+			continue
+		}
+
+		items = append(items, item)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/admin/ip_blocks",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// IPBlockGet returns the IP block with the given ID.
+func (p *Processor) IPBlockGet(ctx context.Context, id string) (*apimodel.AdminIPBlock, gtserror.WithCode) {
+	block, errWithCode := p.getIPBlock(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiIPBlock(ctx, block)
+}
+
+// IPBlockCreate creates a new IP block from the given form.
+func (p *Processor) IPBlockCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	form *apimodel.AdminIPBlockCreateRequest,
+) (*apimodel.AdminIPBlock, gtserror.WithCode) {
+	if form.IP == nil || *form.IP == "" {
+		const errText = "ip must be set"
+		return nil, gtserror.NewErrorBadRequest(errors.New(errText), errText)
+	}
+
+	if form.Severity == nil || *form.Severity == "" {
+		const errText = "severity must be set"
+		return nil, gtserror.NewErrorBadRequest(errors.New(errText), errText)
+	}
+
+	block := &gtsmodel.IPBlock{
+		ID:                 id.NewULID(),
+		CreatedByAccountID: adminAcct.ID,
+	}
+
+	if errWithCode := p.applyIPBlockForm(ctx, block, form); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.PutIPBlock(ctx, block); err != nil {
+		err := gtserror.Newf("db error putting ip block: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiIPBlock(ctx, block)
+}
+
+// IPBlockUpdate updates the IP block with the given
+// ID, changing only the fields set on the given form.
+func (p *Processor) IPBlockUpdate(
+	ctx context.Context,
+	id string,
+	form *apimodel.AdminIPBlockCreateRequest,
+) (*apimodel.AdminIPBlock, gtserror.WithCode) {
+	block, errWithCode := p.getIPBlock(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if errWithCode := p.applyIPBlockForm(ctx, block, form); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.UpdateIPBlock(ctx, block); err != nil {
+		err := gtserror.Newf("db error updating ip block: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiIPBlock(ctx, block)
+}
+
+// IPBlockDelete deletes the IP block with the given ID.
+func (p *Processor) IPBlockDelete(ctx context.Context, id string) (*apimodel.AdminIPBlock, gtserror.WithCode) {
+	block, errWithCode := p.getIPBlock(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Convert to frontend model
+	// now, before we delete it.
+	apiBlock, errWithCode := p.apiIPBlock(ctx, block)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteIPBlockByID(ctx, block.ID); err != nil {
+		err := gtserror.Newf("db error deleting ip block: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiBlock, nil
+}
+
+// applyIPBlockForm validates and sets
+// the fields present on form on block.
+func (p *Processor) applyIPBlockForm(
+	ctx context.Context,
+	block *gtsmodel.IPBlock,
+	form *apimodel.AdminIPBlockCreateRequest,
+) gtserror.WithCode {
+	if form.IP != nil {
+		ip, err := parseIPRange(*form.IP)
+		if err != nil {
+			text := fmt.Sprintf("ip %s is not a valid ip address or cidr range", *form.IP)
+			return gtserror.NewErrorBadRequest(err, text)
+		}
+
+		if ip != block.IP {
+			existing, err := p.state.DB.GetIPBlockByIP(ctx, ip)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				err := gtserror.Newf("db error checking existing ip block: %w", err)
+				return gtserror.NewErrorInternalError(err)
+			}
+
+			if existing != nil {
+				text := fmt.Sprintf("ip block for %s already exists", ip)
+				return gtserror.NewErrorConflict(errors.New(text), text)
+			}
+		}
+
+		block.IP = ip
+	}
+
+	if form.Severity != nil {
+		severity := gtsmodel.ParseIPBlockSeverity(*form.Severity)
+		if severity == gtsmodel.IPBlockSeverityUnknown {
+			const errText = "severity must be one of sign_up_requires_approval, sign_up_block, no_access"
+			return gtserror.NewErrorBadRequest(errors.New(errText), errText)
+		}
+
+		block.Severity = severity
+	}
+
+	if form.Comment != nil {
+		block.Comment = strings.TrimSpace(*form.Comment)
+	}
+
+	if form.ExpiresIn != nil {
+		switch expiresIn := *form.ExpiresIn; {
+		case expiresIn < 0:
+			const errText = "expires_in must not be negative"
+			return gtserror.NewErrorBadRequest(errors.New(errText), errText)
+
+		case expiresIn == 0:
+			// Never expires.
+			block.ExpiresAt = time.Time{}
+
+		default:
+			block.ExpiresAt = time.Now().Add(time.Duration(expiresIn) * time.Second)
+		}
+	}
+
+	return nil
+}
+
+// parseIPRange parses the given IP address or CIDR
+// range, returning it in normalized CIDR notation.
+// A single address is treated as a /32 or /128.
+func parseIPRange(in string) (string, error) {
+	in = strings.TrimSpace(in)
+
+	if !strings.Contains(in, "/") {
+		addr, err := netip.ParseAddr(in)
+		if err != nil {
+			return "", err
+		}
+
+		addr = addr.Unmap()
+		return netip.PrefixFrom(addr, addr.BitLen()).String(), nil
+	}
+
+	prefix, err := netip.ParsePrefix(in)
+	if err != nil {
+		return "", err
+	}
+
+	return prefix.Masked().String(), nil
+}
+
+// getIPBlock fetches the IP block with given ID.
+func (p *Processor) getIPBlock(ctx context.Context, id string) (*gtsmodel.IPBlock, gtserror.WithCode) {
+	block, err := p.state.DB.GetIPBlockByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting ip block %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if block == nil {
+		err := fmt.Errorf("ip block %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	return block, nil
+}
+
+// apiIPBlock converts the given
+// IP block to admin api model.
+func (p *Processor) apiIPBlock(ctx context.Context, block *gtsmodel.IPBlock) (*apimodel.AdminIPBlock, gtserror.WithCode) {
+	apiBlock, err := p.converter.IPBlockToAdminAPIIPBlock(ctx, block)
+	if err != nil {
+		err := gtserror.Newf("error converting ip block %s: %w", block.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiBlock, nil
+}
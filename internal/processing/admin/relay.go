@@ -0,0 +1,254 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"code.superseriousbusiness.org/activity/streams/vocab"
+	"code.superseriousbusiness.org/gotosocial/internal/ap"
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/uris"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+)
+
+// RelaysGet returns all relays this instance
+// is subscribed to, or trying to subscribe to.
+func (p *Processor) RelaysGet(ctx context.Context) ([]*apimodel.AdminRelay, gtserror.WithCode) {
+	relays, err := p.state.DB.GetRelays(ctx)
+	if err != nil {
+		err := gtserror.Newf("db error getting relays: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiRelays := make([]*apimodel.AdminRelay, 0, len(relays))
+	for _, relay := range relays {
+		apiRelay, errWithCode := p.apiRelay(ctx, relay)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		apiRelays = append(apiRelays, apiRelay)
+	}
+
+	return apiRelays, nil
+}
+
+// RelayGet returns the relay with the given ID.
+func (p *Processor) RelayGet(ctx context.Context, id string) (*apimodel.AdminRelay, gtserror.WithCode) {
+	relay, errWithCode := p.getRelay(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiRelay(ctx, relay)
+}
+
+// RelayCreate subscribes to the relay with the given inbox URL,
+// by sending a Follow to it from the instance actor. The relay
+// will be pending until the relay Accepts or Rejects the Follow.
+func (p *Processor) RelayCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	form *apimodel.AdminRelayCreateRequest,
+) (*apimodel.AdminRelay, gtserror.WithCode) {
+	inboxURI, err := url.Parse(form.InboxURL)
+	if err != nil ||
+		(inboxURI.Scheme != "https" && inboxURI.Scheme != "http") ||
+		inboxURI.Host == "" {
+		const text = "inbox_url must be a valid http(s) url"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if inboxURI.Host == config.GetHost() ||
+		inboxURI.Host == config.GetAccountDomain() {
+		const text = "inbox_url must not point to this instance"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	blocked, err := p.state.DB.IsDomainBlocked(ctx, inboxURI.Hostname())
+	if err != nil {
+		err := gtserror.Newf("db error checking domain block: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if blocked {
+		const text = "inbox_url domain is blocked"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	existing, err := p.state.DB.GetRelayByInboxURI(ctx, inboxURI.String())
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error checking existing relay: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if existing != nil {
+		text := fmt.Sprintf("relay with inbox_url %s already exists", inboxURI)
+		return nil, gtserror.NewErrorConflict(errors.New(text), text)
+	}
+
+	instanceAcct, err := p.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		err := gtserror.Newf("db error getting instance account: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	relayID := id.NewULID()
+	relay := &gtsmodel.Relay{
+		ID:                 relayID,
+		InboxURI:           inboxURI.String(),
+		FollowURI:          uris.GenerateURIForFollow(instanceAcct.Username, relayID),
+		State:              gtsmodel.RelayStatePending,
+		Publish:            util.Ptr(form.Publish),
+		CreatedByAccountID: adminAcct.ID,
+	}
+
+	if err := p.state.DB.PutRelay(ctx, relay); err != nil {
+		err := gtserror.Newf("db error putting relay: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	follow, err := p.converter.RelayToASFollow(ctx, relay)
+	if err != nil {
+		err := gtserror.Newf("error converting relay to follow: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.deliverToRelay(ctx, instanceAcct, relay, follow); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiRelay(ctx, relay)
+}
+
+// RelayDelete unsubscribes from the relay with the
+// given ID, by sending an Undo of our Follow to it.
+func (p *Processor) RelayDelete(ctx context.Context, id string) (*apimodel.AdminRelay, gtserror.WithCode) {
+	relay, errWithCode := p.getRelay(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Convert to frontend model
+	// now, before we delete it.
+	apiRelay, errWithCode := p.apiRelay(ctx, relay)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if relay.State != gtsmodel.RelayStateRejected {
+		// Relay may consider us subscribed,
+		// so make sure we tell it otherwise.
+		if err := p.undoRelayFollow(ctx, relay); err != nil {
+			// Log but don't return, relay will
+			// stop delivering once it fails to.
+			log.Errorf(ctx, "error unfollowing relay %s: %v", relay.InboxURI, err)
+		}
+	}
+
+	if err := p.state.DB.DeleteRelayByID(ctx, relay.ID); err != nil {
+		err := gtserror.Newf("db error deleting relay: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiRelay, nil
+}
+
+// undoRelayFollow sends an Undo of our
+// instance actor's Follow to the relay.
+func (p *Processor) undoRelayFollow(ctx context.Context, relay *gtsmodel.Relay) error {
+	instanceAcct, err := p.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		return gtserror.Newf("db error getting instance account: %w", err)
+	}
+
+	undo, err := p.converter.RelayToASUndo(ctx, relay)
+	if err != nil {
+		return gtserror.Newf("error converting relay to undo: %w", err)
+	}
+
+	return p.deliverToRelay(ctx, instanceAcct, relay, undo)
+}
+
+// deliverToRelay queues delivery of the given
+// Activity to the relay's inbox, on behalf of
+// the instance account.
+func (p *Processor) deliverToRelay(
+	ctx context.Context,
+	instanceAcct *gtsmodel.Account,
+	relay *gtsmodel.Relay,
+	t vocab.Type,
+) error {
+	inboxURI, err := url.Parse(relay.InboxURI)
+	if err != nil {
+		return gtserror.Newf("error parsing relay inbox uri: %w", err)
+	}
+
+	tsport, err := p.transport.NewTransportForUsername(ctx, instanceAcct.Username)
+	if err != nil {
+		return gtserror.Newf("error getting transport: %w", err)
+	}
+
+	m, err := ap.Serialize(t)
+	if err != nil {
+		return gtserror.Newf("error serializing %T: %w", t, err)
+	}
+
+	if err := tsport.Deliver(ctx, m, inboxURI); err != nil {
+		return gtserror.Newf("error delivering %T to relay %s: %w", t, relay.InboxURI, err)
+	}
+
+	return nil
+}
+
+// getRelay fetches the relay with given ID.
+func (p *Processor) getRelay(ctx context.Context, id string) (*gtsmodel.Relay, gtserror.WithCode) {
+	relay, err := p.state.DB.GetRelayByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting relay %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if relay == nil {
+		err := fmt.Errorf("relay %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	return relay, nil
+}
+
+// apiRelay converts the given
+// relay to admin api model.
+func (p *Processor) apiRelay(ctx context.Context, relay *gtsmodel.Relay) (*apimodel.AdminRelay, gtserror.WithCode) {
+	apiRelay, err := p.converter.RelayToAdminAPIRelay(ctx, relay)
+	if err != nil {
+		err := gtserror.Newf("error converting relay %s: %w", relay.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiRelay, nil
+}
@@ -0,0 +1,160 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/testrig"
+	"github.com/stretchr/testify/suite"
+)
+
+type RelayTestSuite struct {
+	AdminStandardTestSuite
+}
+
+// popRelayDelivery pops the next queued delivery,
+// checks it targets the given inbox, and returns it.
+func (suite *RelayTestSuite) popRelayDelivery(inbox string) map[string]any {
+	delivery, ok := suite.state.Workers.Delivery.Queue.Pop()
+	if !ok {
+		suite.FailNow("no delivery queued")
+	}
+
+	suite.True(testrig.EqualRequestURIs(delivery.Request.URL, inbox))
+
+	b, err := io.ReadAll(delivery.Request.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	var m map[string]any
+	if err := json.Unmarshal(b, &m); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return m
+}
+
+func (suite *RelayTestSuite) TestRelayCreate() {
+	var (
+		ctx          = suite.T().Context()
+		adminAcct    = suite.testAccounts["admin_account"]
+		instanceAcct = suite.testAccounts["instance_account"]
+		inbox        = "https://relay.example.org/inbox"
+	)
+
+	relay, errWithCode := suite.adminProcessor.RelayCreate(ctx, adminAcct, &apimodel.AdminRelayCreateRequest{
+		InboxURL: inbox,
+		Publish:  true,
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	suite.Equal(inbox, relay.InboxURL)
+	suite.Equal("pending", relay.State)
+	suite.True(relay.Publish)
+	suite.Equal(adminAcct.ID, relay.CreatedBy)
+
+	// A Follow of the Public collection should be
+	// queued for delivery from the instance actor.
+	follow := suite.popRelayDelivery(inbox)
+	suite.Equal("Follow", follow["type"])
+	suite.Equal(instanceAcct.URI, follow["actor"])
+	suite.Equal("https://www.w3.org/ns/activitystreams#Public", follow["object"])
+
+	dbRelay, err := suite.state.DB.GetRelayByID(ctx, relay.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(dbRelay.FollowURI, follow["id"])
+
+	// Adding the same relay again should conflict.
+	_, errWithCode = suite.adminProcessor.RelayCreate(ctx, adminAcct, &apimodel.AdminRelayCreateRequest{
+		InboxURL: inbox,
+	})
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+}
+
+func (suite *RelayTestSuite) TestRelayCreateInvalid() {
+	var (
+		ctx       = suite.T().Context()
+		adminAcct = suite.testAccounts["admin_account"]
+	)
+
+	for _, inbox := range []string{
+		"not a url",
+		"ftp://relay.example.org/inbox",
+		"http://localhost:8080/inbox",
+	} {
+		_, errWithCode := suite.adminProcessor.RelayCreate(ctx, adminAcct, &apimodel.AdminRelayCreateRequest{
+			InboxURL: inbox,
+		})
+		if suite.NotNil(errWithCode, inbox) {
+			suite.Equal(http.StatusBadRequest, errWithCode.Code(), inbox)
+		}
+	}
+}
+
+func (suite *RelayTestSuite) TestRelayDelete() {
+	var (
+		ctx          = suite.T().Context()
+		adminAcct    = suite.testAccounts["admin_account"]
+		instanceAcct = suite.testAccounts["instance_account"]
+		inbox        = "https://relay.example.org/inbox"
+	)
+
+	relay, errWithCode := suite.adminProcessor.RelayCreate(ctx, adminAcct, &apimodel.AdminRelayCreateRequest{
+		InboxURL: inbox,
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	follow := suite.popRelayDelivery(inbox)
+
+	if _, errWithCode := suite.adminProcessor.RelayDelete(ctx, relay.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// An Undo of the Follow should be
+	// queued for delivery to the relay.
+	undo := suite.popRelayDelivery(inbox)
+	suite.Equal("Undo", undo["type"])
+	suite.Equal(instanceAcct.URI, undo["actor"])
+	if object, ok := undo["object"].(map[string]any); suite.True(ok) {
+		suite.Equal(follow["id"], object["id"])
+	}
+
+	relays, errWithCode := suite.adminProcessor.RelaysGet(ctx)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(relays)
+
+	_, errWithCode = suite.adminProcessor.RelayGet(ctx, relay.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestRelayTestSuite(t *testing.T) {
+	suite.Run(t, new(RelayTestSuite))
+}
@@ -0,0 +1,142 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+)
+
+// StaffPicksGet returns all accounts currently picked
+// by staff to be suggested to other accounts to follow.
+func (p *Processor) StaffPicksGet(ctx context.Context) ([]*apimodel.Account, gtserror.WithCode) {
+	picks, err := p.state.DB.GetStaffPicks(ctx)
+	if err != nil {
+		err := gtserror.Newf("db error getting staff picks: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiAccounts := make([]*apimodel.Account, 0, len(picks))
+	for _, pick := range picks {
+		account, err := p.state.DB.GetAccountByID(ctx, pick.AccountID)
+		if err != nil {
+			log.Errorf(ctx, "db error getting staff picked account %s: %v", pick.AccountID, err)
+			continue
+		}
+
+		apiAccount, err := p.converter.AccountToAPIAccountPublic(ctx, account)
+		if err != nil {
+			log.Errorf(ctx, "error converting account %s: %v", account.ID, err)
+			continue
+		}
+
+		apiAccounts = append(apiAccounts, apiAccount)
+	}
+
+	return apiAccounts, nil
+}
+
+// StaffPickCreate picks the account with given ID to be
+// suggested to other accounts to follow. This is a no-op
+// if the account has already been picked.
+func (p *Processor) StaffPickCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	accountID string,
+) (*apimodel.Account, gtserror.WithCode) {
+	account, errWithCode := p.staffPickAccount(ctx, accountID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	_, err := p.state.DB.GetStaffPickByAccountID(ctx, accountID)
+	switch {
+	case errors.Is(err, db.ErrNoEntries):
+		// Not yet picked, pick it.
+		if err := p.state.DB.PutStaffPick(ctx, &gtsmodel.StaffPick{
+			ID:                 id.NewULID(),
+			AccountID:          accountID,
+			CreatedByAccountID: adminAcct.ID,
+		}); err != nil {
+			err := gtserror.Newf("db error putting staff pick: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+	case err != nil:
+		err := gtserror.Newf("db error getting staff pick: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiStaffPickAccount(ctx, account)
+}
+
+// StaffPickDelete stops the account with given ID from
+// being suggested to other accounts as a staff pick.
+func (p *Processor) StaffPickDelete(
+	ctx context.Context,
+	accountID string,
+) (*apimodel.Account, gtserror.WithCode) {
+	account, errWithCode := p.staffPickAccount(ctx, accountID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteStaffPickByAccountID(ctx, accountID); err != nil {
+		err := gtserror.Newf("db error deleting staff pick: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiStaffPickAccount(ctx, account)
+}
+
+// staffPickAccount fetches the
+// account with given ID to (un)pick.
+func (p *Processor) staffPickAccount(ctx context.Context, accountID string) (*gtsmodel.Account, gtserror.WithCode) {
+	account, err := p.state.DB.GetAccountByID(ctx, accountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting account %s: %w", accountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if account == nil {
+		err := fmt.Errorf("account %s not found", accountID)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	return account, nil
+}
+
+// apiStaffPickAccount converts the
+// given (un)picked account to api model.
+func (p *Processor) apiStaffPickAccount(ctx context.Context, account *gtsmodel.Account) (*apimodel.Account, gtserror.WithCode) {
+	apiAccount, err := p.converter.AccountToAPIAccountPublic(ctx, account)
+	if err != nil {
+		err := gtserror.Newf("error converting account %s: %w", account.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiAccount, nil
+}
@@ -0,0 +1,207 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/cache"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+)
+
+// TrendsGet returns all currently calculated trends of the given
+// type, regardless of review state, ordered by score (highest first).
+func (p *Processor) TrendsGet(
+	ctx context.Context,
+	trendType gtsmodel.TrendType,
+) ([]*apimodel.AdminTrend, gtserror.WithCode) {
+	trends := p.state.Caches.Trends.Get(trendType, false)
+	apiTrends := make([]*apimodel.AdminTrend, 0, len(trends))
+
+	for _, trend := range trends {
+		apiTrend, err := p.apiTrend(ctx, trendType, trend)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if apiTrend == nil {
+			// Target of trend
+			// no longer exists.
+			continue
+		}
+
+		apiTrends = append(apiTrends, apiTrend)
+	}
+
+	return apiTrends, nil
+}
+
+// TrendApprove approves the trend review of given type
+// with given ID, allowing it to be shown publicly.
+func (p *Processor) TrendApprove(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	trendType gtsmodel.TrendType,
+	reviewID string,
+) (*apimodel.AdminTrend, gtserror.WithCode) {
+	return p.trendReview(ctx, adminAcct, trendType, reviewID,
+		gtsmodel.TrendReviewStateApproved,
+	)
+}
+
+// TrendReject rejects the trend review of given type
+// with given ID, preventing it from being shown publicly.
+func (p *Processor) TrendReject(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	trendType gtsmodel.TrendType,
+	reviewID string,
+) (*apimodel.AdminTrend, gtserror.WithCode) {
+	return p.trendReview(ctx, adminAcct, trendType, reviewID,
+		gtsmodel.TrendReviewStateRejected,
+	)
+}
+
+// trendReview sets the review of given type with given
+// ID to given state, updating the trends cache to match.
+func (p *Processor) trendReview(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	trendType gtsmodel.TrendType,
+	reviewID string,
+	state gtsmodel.TrendReviewState,
+) (*apimodel.AdminTrend, gtserror.WithCode) {
+	review, err := p.state.DB.GetTrendReviewByID(ctx, reviewID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting trend review %s: %w", reviewID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if review == nil || review.TrendType != trendType {
+		err := fmt.Errorf("%s trend %s not found", trendType, reviewID)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	review.State = state
+	review.ReviewedAt = time.Now()
+	review.ReviewedByAccountID = adminAcct.ID
+
+	if err := p.state.DB.UpdateTrendReview(ctx, review,
+		"state",
+		"reviewed_at",
+		"reviewed_by_account_id",
+	); err != nil {
+		err := gtserror.Newf("db error updating trend review %s: %w", reviewID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Update state of the trend
+	// in cache, if still trending.
+	p.state.Caches.Trends.SetState(trendType, review.ID, review.State)
+
+	// Look for the current calculated trend for this
+	// review, so we can return its score + history.
+	// Cached trends are read-only, so take a copy.
+	trend := cache.CachedTrend{TargetID: review.TargetID}
+	for _, t := range p.state.Caches.Trends.Get(trendType, false) {
+		if t.ReviewID == review.ID {
+			trend = *t
+			break
+		}
+	}
+
+	// Ensure correct
+	// details are set.
+	trend.ReviewID = review.ID
+	trend.State = review.State
+
+	apiTrend, err := p.apiTrend(ctx, trendType, &trend)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if apiTrend == nil {
+		err := fmt.Errorf("target of %s trend %s no longer exists", trendType, reviewID)
+		return nil, gtserror.NewErrorNotFound(err, err.Error())
+	}
+
+	return apiTrend, nil
+}
+
+// apiTrend converts the given calculated trend to its admin
+// api representation, returning nil if the target of the trend
+// (ie., the tag or status) could not be found in the database.
+func (p *Processor) apiTrend(
+	ctx context.Context,
+	trendType gtsmodel.TrendType,
+	trend *cache.CachedTrend,
+) (*apimodel.AdminTrend, error) {
+	apiTrend := &apimodel.AdminTrend{
+		ID:    trend.ReviewID,
+		Type:  trendType.String(),
+		State: trend.State.String(),
+		Score: trend.Score,
+	}
+
+	switch trendType {
+	case gtsmodel.TrendTypeTag:
+		tag, err := p.state.DB.GetTag(ctx, trend.TargetID)
+		if err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				return nil, nil
+			}
+			return nil, gtserror.Newf("db error getting tag %s: %w", trend.TargetID, err)
+		}
+
+		apiTag, err := p.converter.TagToAPITag(ctx, tag, false, nil)
+		if err != nil {
+			return nil, gtserror.Newf("error converting tag %s: %w", tag.ID, err)
+		}
+
+		history := p.converter.TrendHistoryToAPIHistory(trend.History)
+		apiTag.History = &history
+		apiTrend.Tag = &apiTag
+
+	case gtsmodel.TrendTypeStatus:
+		status, err := p.state.DB.GetStatusByID(ctx, trend.TargetID)
+		if err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				return nil, nil
+			}
+			return nil, gtserror.Newf("db error getting status %s: %w", trend.TargetID, err)
+		}
+
+		apiStatus, err := p.converter.StatusToAPIStatus(ctx, status, nil)
+		if err != nil {
+			return nil, gtserror.Newf("error converting status %s: %w", status.ID, err)
+		}
+
+		apiTrend.Status = apiStatus
+
+	case gtsmodel.TrendTypeLink:
+		apiTrend.Link = p.converter.TrendLinkToAPITrendsLink(ctx, trend)
+	}
+
+	return apiTrend, nil
+}
@@ -0,0 +1,133 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package announcements
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/processing/stream"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"code.superseriousbusiness.org/gotosocial/internal/text"
+	"code.superseriousbusiness.org/gotosocial/internal/typeutils"
+)
+
+type Processor struct {
+	state        *state.State
+	converter    *typeutils.Converter
+	stream       *stream.Processor
+	formatter    *text.Formatter
+	parseMention gtsmodel.ParseMentionFunc
+}
+
+// New returns a new announcements processor.
+func New(
+	state *state.State,
+	converter *typeutils.Converter,
+	stream *stream.Processor,
+	parseMention gtsmodel.ParseMentionFunc,
+) Processor {
+	return Processor{
+		state:        state,
+		converter:    converter,
+		stream:       stream,
+		formatter:    text.NewFormatter(state.DB),
+		parseMention: parseMention,
+	}
+}
+
+// getAnnouncement fetches the announcement with the given
+// ID, returning an appropriate error with HTTP code on failure.
+func (p *Processor) getAnnouncement(ctx context.Context, id string) (*gtsmodel.Announcement, gtserror.WithCode) {
+	announcement, err := p.state.DB.GetAnnouncementByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting announcement %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if announcement == nil {
+		const text = "announcement not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return announcement, nil
+}
+
+// getActiveAnnouncement is like getAnnouncement, but
+// also returns 404 if the announcement is not active,
+// ie., is unpublished or has ended.
+func (p *Processor) getActiveAnnouncement(ctx context.Context, id string) (*gtsmodel.Announcement, gtserror.WithCode) {
+	announcement, errWithCode := p.getAnnouncement(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if !announcement.IsActive(time.Now()) {
+		const text = "announcement not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return announcement, nil
+}
+
+// apiAnnouncement converts the given announcement to its frontend API
+// model for the given requester ID, fetching reactions to the announcement.
+func (p *Processor) apiAnnouncement(
+	ctx context.Context,
+	announcement *gtsmodel.Announcement,
+	requesterID string,
+	read bool,
+) (*apimodel.Announcement, gtserror.WithCode) {
+	reactions, err := p.state.DB.GetAnnouncementReactions(ctx, announcement.ID)
+	if err != nil {
+		err := gtserror.Newf("db error getting announcement reactions: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiAnnouncement, err := p.converter.AnnouncementToAPIAnnouncement(
+		ctx,
+		announcement,
+		reactions,
+		requesterID,
+		read,
+	)
+	if err != nil {
+		err := gtserror.Newf("error converting announcement to api model: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiAnnouncement, nil
+}
+
+// streamAnnouncement streams the given announcement
+// to all users as a new, unread announcement.
+func (p *Processor) streamAnnouncement(ctx context.Context, announcement *gtsmodel.Announcement) {
+	apiAnnouncement, errWithCode := p.apiAnnouncement(ctx, announcement, "", false)
+	if errWithCode != nil {
+		log.Errorf(ctx, "error streaming announcement %s: %v", announcement.ID, errWithCode.Unwrap())
+		return
+	}
+
+	p.stream.Announcement(ctx, apiAnnouncement)
+}
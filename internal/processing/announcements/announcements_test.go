@@ -0,0 +1,302 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package announcements_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/media"
+	"code.superseriousbusiness.org/gotosocial/internal/processing"
+	"code.superseriousbusiness.org/gotosocial/internal/processing/announcements"
+	"code.superseriousbusiness.org/gotosocial/internal/processing/stream"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	streampkg "code.superseriousbusiness.org/gotosocial/internal/stream"
+	"code.superseriousbusiness.org/gotosocial/internal/typeutils"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+	"code.superseriousbusiness.org/gotosocial/testrig"
+	"github.com/stretchr/testify/suite"
+)
+
+type AnnouncementsTestSuite struct {
+	suite.Suite
+	state         state.State
+	stream        stream.Processor
+	announcements announcements.Processor
+
+	testAccounts map[string]*gtsmodel.Account
+}
+
+func (suite *AnnouncementsTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+	suite.state.Caches.Init()
+	testrig.StartNoopWorkers(&suite.state)
+	testrig.NewTestDB(&suite.state)
+	converter := typeutils.NewConverter(&suite.state)
+	controller := testrig.NewTestTransportController(&suite.state, nil)
+	federator := testrig.NewTestFederator(&suite.state, controller, media.NewManager(&suite.state))
+	suite.stream = stream.New(&suite.state, testrig.NewTestOauthServer(&suite.state))
+	suite.announcements = announcements.New(
+		&suite.state,
+		converter,
+		&suite.stream,
+		processing.GetParseMentionFunc(&suite.state, federator),
+	)
+	suite.testAccounts = testrig.NewTestAccounts()
+	testrig.StandardDBSetup(suite.state.DB, suite.testAccounts)
+}
+
+func (suite *AnnouncementsTestSuite) TearDownTest() {
+	testrig.StopWorkers(&suite.state)
+	testrig.StandardDBTeardown(suite.state.DB)
+}
+
+func (suite *AnnouncementsTestSuite) openStream(account *gtsmodel.Account) *streampkg.Stream {
+	openStream, errWithCode := suite.stream.Open(suite.T().Context(), account, streampkg.TimelineHome)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	return openStream
+}
+
+func (suite *AnnouncementsTestSuite) recv(openStream *streampkg.Stream) streampkg.Message {
+	ctx, cncl := context.WithTimeout(suite.T().Context(), 5*time.Second)
+	defer cncl()
+
+	msg, ok := openStream.Recv(ctx)
+	if !ok {
+		suite.FailNow("no message received on stream")
+	}
+	return msg
+}
+
+func (suite *AnnouncementsTestSuite) TestAnnouncementLifecycle() {
+	var (
+		ctx        = suite.T().Context()
+		admin      = suite.testAccounts["admin_account"]
+		user       = suite.testAccounts["local_account_1"]
+		userStream = suite.openStream(user)
+	)
+
+	// Create an announcement, which should be
+	// published immediately and streamed.
+	announcement, errWithCode := suite.announcements.Create(ctx, admin, &apimodel.AnnouncementRequest{
+		Text:     util.Ptr("maintenance tonight :rainbow:"),
+		StartsAt: util.Ptr(time.Now().Add(time.Hour)),
+		EndsAt:   util.Ptr(time.Now().Add(2 * time.Hour)),
+	})
+	suite.NoError(errWithCode)
+	suite.True(announcement.Published)
+	suite.NotEmpty(announcement.PublishedAt)
+	suite.NotNil(announcement.StartsAt)
+	suite.Len(announcement.Emojis, 1)
+	suite.Equal("<p>maintenance tonight :rainbow:</p>", announcement.Content)
+
+	msg := suite.recv(userStream)
+	suite.Equal(streampkg.EventTypeAnnouncement, msg.Event)
+	suite.Contains(msg.Payload, announcement.ID)
+
+	// User should see it as unread.
+	active, errWithCode := suite.announcements.GetActive(ctx, user, false)
+	suite.NoError(errWithCode)
+	suite.Len(active, 1)
+	suite.False(active[0].Read)
+
+	// React with a unicode and a custom emoji.
+	suite.NoError(suite.announcements.ReactionAdd(ctx, user, announcement.ID, "👍"))
+	suite.NoError(suite.announcements.ReactionAdd(ctx, user, announcement.ID, "rainbow"))
+	suite.NoError(suite.announcements.ReactionAdd(ctx, admin, announcement.ID, "👍"))
+
+	msg = suite.recv(userStream)
+	suite.Equal(streampkg.EventTypeAnnouncementReaction, msg.Event)
+	suite.Equal(`{"name":"👍","count":1,"me":false,"announcement_id":"`+announcement.ID+`"}`, msg.Payload)
+
+	// Reacting twice is a no-op.
+	suite.NoError(suite.announcements.ReactionAdd(ctx, user, announcement.ID, "👍"))
+
+	active, errWithCode = suite.announcements.GetActive(ctx, user, false)
+	suite.NoError(errWithCode)
+	suite.Len(active, 1)
+	suite.Len(active[0].Reactions, 2)
+
+	// Reactions created in the same millisecond
+	// may come back in either order, so index them.
+	reactions := make(map[string]apimodel.AnnouncementReaction, 2)
+	for _, reaction := range active[0].Reactions {
+		reactions[reaction.Name] = reaction
+	}
+	suite.Equal(2, reactions["👍"].Count)
+	suite.True(reactions["👍"].Me)
+	suite.Equal(1, reactions["rainbow"].Count)
+	suite.NotEmpty(reactions["rainbow"].URL)
+
+	// Remove a reaction.
+	suite.NoError(suite.announcements.ReactionRemove(ctx, user, announcement.ID, "rainbow"))
+	errWithCode = suite.announcements.ReactionRemove(ctx, user, announcement.ID, "rainbow")
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	// Dismiss the announcement, it should now be
+	// hidden from user, unless requested with dismissed.
+	suite.NoError(suite.announcements.Dismiss(ctx, user, announcement.ID))
+	suite.NoError(suite.announcements.Dismiss(ctx, user, announcement.ID))
+
+	active, errWithCode = suite.announcements.GetActive(ctx, user, false)
+	suite.NoError(errWithCode)
+	suite.Empty(active)
+
+	active, errWithCode = suite.announcements.GetActive(ctx, user, true)
+	suite.NoError(errWithCode)
+	suite.Len(active, 1)
+	suite.True(active[0].Read)
+
+	// End the announcement, this should remove it from users.
+	updated, errWithCode := suite.announcements.Update(ctx, admin, announcement.ID, &apimodel.AnnouncementRequest{
+		StartsAt: util.Ptr(time.Time{}),
+		EndsAt:   util.Ptr(time.Now().Add(-time.Minute)),
+	})
+	suite.NoError(errWithCode)
+	suite.Nil(updated.StartsAt)
+
+	// Skip remaining reaction events.
+	for msg.Event != streampkg.EventTypeAnnouncementDelete {
+		msg = suite.recv(userStream)
+	}
+	suite.Equal(announcement.ID, msg.Payload)
+
+	active, errWithCode = suite.announcements.GetActive(ctx, user, true)
+	suite.NoError(errWithCode)
+	suite.Empty(active)
+
+	// Can't react to ended announcements.
+	errWithCode = suite.announcements.ReactionAdd(ctx, user, announcement.ID, "👍")
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	// Delete it altogether.
+	suite.NoError(suite.announcements.Delete(ctx, announcement.ID))
+	all, errWithCode := suite.announcements.GetAll(ctx, admin)
+	suite.NoError(errWithCode)
+	suite.Empty(all)
+}
+
+func (suite *AnnouncementsTestSuite) TestAnnouncementScheduled() {
+	var (
+		ctx   = suite.T().Context()
+		admin = suite.testAccounts["admin_account"]
+		user  = suite.testAccounts["local_account_1"]
+	)
+
+	announcement, errWithCode := suite.announcements.Create(ctx, admin, &apimodel.AnnouncementRequest{
+		Text:        util.Ptr("coming soon"),
+		ScheduledAt: util.Ptr(time.Now().Add(time.Hour)),
+	})
+	suite.NoError(errWithCode)
+	suite.False(announcement.Published)
+	suite.Empty(announcement.PublishedAt)
+	suite.NotNil(announcement.ScheduledAt)
+
+	// Not visible to users yet.
+	active, errWithCode := suite.announcements.GetActive(ctx, user, true)
+	suite.NoError(errWithCode)
+	suite.Empty(active)
+
+	errWithCode = suite.announcements.Dismiss(ctx, user, announcement.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	// Visible to admins.
+	all, errWithCode := suite.announcements.GetAll(ctx, admin)
+	suite.NoError(errWithCode)
+	suite.Len(all, 1)
+
+	// Publish it now instead.
+	updated, errWithCode := suite.announcements.Update(ctx, admin, announcement.ID, &apimodel.AnnouncementRequest{
+		ScheduledAt: util.Ptr(time.Time{}),
+	})
+	suite.NoError(errWithCode)
+	suite.True(updated.Published)
+	suite.Nil(updated.ScheduledAt)
+
+	active, errWithCode = suite.announcements.GetActive(ctx, user, false)
+	suite.NoError(errWithCode)
+	suite.Len(active, 1)
+
+	// Schedule can't be changed after publishing.
+	_, errWithCode = suite.announcements.Update(ctx, admin, announcement.ID, &apimodel.AnnouncementRequest{
+		ScheduledAt: util.Ptr(time.Now().Add(time.Hour)),
+	})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func (suite *AnnouncementsTestSuite) TestAnnouncementInvalid() {
+	var (
+		ctx   = suite.T().Context()
+		admin = suite.testAccounts["admin_account"]
+		user  = suite.testAccounts["local_account_1"]
+	)
+
+	for _, test := range []struct {
+		form *apimodel.AnnouncementRequest
+		err  string
+	}{
+		{
+			form: &apimodel.AnnouncementRequest{},
+			err:  "Bad Request: text must be set",
+		},
+		{
+			form: &apimodel.AnnouncementRequest{Text: util.Ptr("")},
+			err:  "Bad Request: announcement text must not be empty",
+		},
+		{
+			form: &apimodel.AnnouncementRequest{
+				Text:     util.Ptr("backwards"),
+				StartsAt: util.Ptr(time.Now().Add(time.Hour)),
+				EndsAt:   util.Ptr(time.Now()),
+			},
+			err: "Bad Request: ends_at must be after starts_at",
+		},
+	} {
+		_, errWithCode := suite.announcements.Create(ctx, admin, test.form)
+		if suite.Error(errWithCode) {
+			suite.Equal(test.err, errWithCode.Safe())
+		}
+	}
+
+	announcement, errWithCode := suite.announcements.Create(ctx, admin, &apimodel.AnnouncementRequest{
+		Text: util.Ptr("react to me"),
+	})
+	suite.NoError(errWithCode)
+
+	for _, name := range []string{
+		"not an emoji",
+		"👍👍",
+		"not_a_custom_emoji",
+	} {
+		errWithCode := suite.announcements.ReactionAdd(ctx, user, announcement.ID, name)
+		if suite.Error(errWithCode) {
+			suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+		}
+	}
+}
+
+func TestAnnouncementsTestSuite(t *testing.T) {
+	suite.Run(t, new(AnnouncementsTestSuite))
+}
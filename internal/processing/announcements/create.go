@@ -0,0 +1,140 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package announcements
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+	"code.superseriousbusiness.org/gotosocial/internal/validate"
+)
+
+// Create creates a new announcement from the given form.
+// The announcement is published, and streamed to users,
+// immediately, unless it's scheduled for a future time.
+func (p *Processor) Create(
+	ctx context.Context,
+	admin *gtsmodel.Account,
+	form *apimodel.AnnouncementRequest,
+) (*apimodel.Announcement, gtserror.WithCode) {
+	if form.Text == nil {
+		const text = "text must be set"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	announcement := &gtsmodel.Announcement{
+		ID:                 id.NewULID(),
+		AllDay:             util.Ptr(util.PtrOrZero(form.AllDay)),
+		CreatedByAccountID: admin.ID,
+	}
+
+	if errWithCode := p.setText(ctx, announcement, *form.Text); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if errWithCode := setTimes(announcement, form); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	now := time.Now()
+	if form.ScheduledAt != nil && form.ScheduledAt.After(now) {
+		// Publish later.
+		announcement.ScheduledAt = *form.ScheduledAt
+	} else {
+		// Publish right away.
+		announcement.PublishedAt = now
+	}
+
+	if err := p.state.DB.PutAnnouncement(ctx, announcement); err != nil {
+		err := gtserror.Newf("db error putting announcement: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if announcement.IsPublished() {
+		if announcement.IsActive(now) {
+			p.streamAnnouncement(ctx, announcement)
+		}
+	} else if err := p.schedulePublication(ctx, announcement); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiAnnouncement(ctx, announcement, admin.ID, false)
+}
+
+// setText validates the given markdown text, and sets
+// it along with its HTML content on the announcement.
+func (p *Processor) setText(
+	ctx context.Context,
+	announcement *gtsmodel.Announcement,
+	text string,
+) gtserror.WithCode {
+	if err := validate.AnnouncementText(text); err != nil {
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	res := p.formatter.FromMarkdown(ctx,
+		p.parseMention,
+		announcement.CreatedByAccountID,
+		"",
+		text,
+	)
+
+	announcement.Text = text
+	announcement.Content = res.HTML
+	announcement.Emojis = res.Emojis
+	announcement.EmojiIDs = make([]string, 0, len(res.Emojis))
+	for _, emoji := range res.Emojis {
+		announcement.EmojiIDs = append(announcement.EmojiIDs, emoji.ID)
+	}
+
+	return nil
+}
+
+// setTimes sets start and end times from the given form on
+// the announcement, a zero time clearing the current value.
+func setTimes(
+	announcement *gtsmodel.Announcement,
+	form *apimodel.AnnouncementRequest,
+) gtserror.WithCode {
+	if form.StartsAt != nil {
+		announcement.StartsAt = *form.StartsAt
+	}
+
+	if form.EndsAt != nil {
+		announcement.EndsAt = *form.EndsAt
+	}
+
+	if form.AllDay != nil {
+		announcement.AllDay = form.AllDay
+	}
+
+	if !announcement.StartsAt.IsZero() &&
+		!announcement.EndsAt.IsZero() &&
+		!announcement.EndsAt.After(announcement.StartsAt) {
+		const text = "ends_at must be after starts_at"
+		return gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package announcements
+
+import (
+	"context"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+)
+
+// Delete deletes the announcement with the given ID, along
+// with any reads of and reactions to it, removing it from
+// users' clients if it was active.
+func (p *Processor) Delete(ctx context.Context, id string) gtserror.WithCode {
+	announcement, errWithCode := p.getAnnouncement(ctx, id)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if !announcement.IsPublished() {
+		// Cancel any scheduled publication.
+		_ = p.state.Workers.Scheduler.Cancel(announcement.ID)
+	}
+
+	if err := p.state.DB.DeleteAnnouncementByID(ctx, announcement.ID); err != nil {
+		err := gtserror.Newf("db error deleting announcement: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if announcement.IsActive(time.Now()) {
+		p.stream.AnnouncementDelete(ctx, announcement.ID)
+	}
+
+	return nil
+}
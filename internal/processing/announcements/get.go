@@ -0,0 +1,114 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package announcements
+
+import (
+	"context"
+	"slices"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+)
+
+// GetActive returns all currently active announcements,
+// ie., those which are published and haven't yet ended,
+// as seen by the requesting account. If withDismissed
+// is false, announcements already read (dismissed)
+// by the requester are not included.
+func (p *Processor) GetActive(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	withDismissed bool,
+) ([]*apimodel.Announcement, gtserror.WithCode) {
+	announcements, err := p.state.DB.GetActiveAnnouncements(ctx)
+	if err != nil {
+		err := gtserror.Newf("db error getting active announcements: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	readIDs, err := p.state.DB.GetReadAnnouncementIDs(ctx, requester.ID)
+	if err != nil {
+		err := gtserror.Newf("db error getting read announcement ids: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiAnnouncements := make([]*apimodel.Announcement, 0, len(announcements))
+	for _, announcement := range announcements {
+		read := slices.Contains(readIDs, announcement.ID)
+		if read && !withDismissed {
+			continue
+		}
+
+		apiAnnouncement, errWithCode := p.apiAnnouncement(ctx,
+			announcement,
+			requester.ID,
+			read,
+		)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		apiAnnouncements = append(apiAnnouncements, apiAnnouncement)
+	}
+
+	return apiAnnouncements, nil
+}
+
+// GetAll returns all announcements, including
+// unpublished and ended ones, for an admin.
+func (p *Processor) GetAll(
+	ctx context.Context,
+	admin *gtsmodel.Account,
+) ([]*apimodel.Announcement, gtserror.WithCode) {
+	announcements, err := p.state.DB.GetAnnouncements(ctx)
+	if err != nil {
+		err := gtserror.Newf("db error getting announcements: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiAnnouncements := make([]*apimodel.Announcement, 0, len(announcements))
+	for _, announcement := range announcements {
+		apiAnnouncement, errWithCode := p.apiAnnouncement(ctx,
+			announcement,
+			admin.ID,
+			false,
+		)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		apiAnnouncements = append(apiAnnouncements, apiAnnouncement)
+	}
+
+	return apiAnnouncements, nil
+}
+
+// GetByID returns the announcement with the given ID, for an admin.
+func (p *Processor) GetByID(
+	ctx context.Context,
+	admin *gtsmodel.Account,
+	id string,
+) (*apimodel.Announcement, gtserror.WithCode) {
+	announcement, errWithCode := p.getAnnouncement(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiAnnouncement(ctx, announcement, admin.ID, false)
+}
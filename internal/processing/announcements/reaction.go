@@ -0,0 +1,175 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package announcements
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/regexes"
+	"code.superseriousbusiness.org/gotosocial/internal/validate"
+)
+
+// maxReactions is the maximum number of different
+// reactions (by name) allowed on one announcement.
+const maxReactions = 8
+
+// ReactionAdd adds a reaction with the given name, which must be
+// either a unicode emoji or the shortcode of an enabled local emoji,
+// from the requesting account to the active announcement with the
+// given ID. Adding a reaction that already exists is a no-op.
+func (p *Processor) ReactionAdd(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	announcementID string,
+	name string,
+) gtserror.WithCode {
+	announcement, errWithCode := p.getActiveAnnouncement(ctx, announcementID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	if err := validate.EmojiReaction(name); err != nil {
+		return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	reactions, err := p.state.DB.GetAnnouncementReactions(ctx, announcement.ID)
+	if err != nil {
+		err := gtserror.Newf("db error getting announcement reactions: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Check for existing reactions with this name.
+	names := make(map[string]struct{}, len(reactions))
+	for _, reaction := range reactions {
+		if reaction.Name == name && reaction.AccountID == requester.ID {
+			// Already reacted, nothing to do.
+			return nil
+		}
+		names[reaction.Name] = struct{}{}
+	}
+
+	if _, ok := names[name]; !ok && len(names) >= maxReactions {
+		text := fmt.Sprintf("announcement already has the maximum of %d different reactions", maxReactions)
+		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	reaction := &gtsmodel.AnnouncementReaction{
+		ID:             id.NewULID(),
+		AnnouncementID: announcement.ID,
+		AccountID:      requester.ID,
+		Name:           name,
+	}
+
+	if regexes.EmojiValidator.MatchString(name) {
+		// Custom emoji shortcode, ensure it
+		// refers to an enabled local emoji.
+		emoji, err := p.state.DB.GetEmojiByShortcodeDomain(ctx, name, "")
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting emoji %s: %w", name, err)
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		if emoji == nil || *emoji.Disabled {
+			text := fmt.Sprintf("custom emoji %s not found", name)
+			return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+
+		reaction.EmojiID = emoji.ID
+		reaction.Emoji = emoji
+	}
+
+	if err := p.state.DB.PutAnnouncementReaction(ctx, reaction); err != nil &&
+		!errors.Is(err, db.ErrAlreadyExists) {
+		err := gtserror.Newf("db error putting announcement reaction: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	p.streamReaction(ctx, announcement.ID, name, append(reactions, reaction))
+	return nil
+}
+
+// ReactionRemove removes the reaction with the given name from
+// the requesting account to the active announcement with the given ID.
+func (p *Processor) ReactionRemove(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	announcementID string,
+	name string,
+) gtserror.WithCode {
+	announcement, errWithCode := p.getActiveAnnouncement(ctx, announcementID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	reaction, err := p.state.DB.GetAnnouncementReaction(ctx,
+		announcement.ID,
+		requester.ID,
+		name,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting announcement reaction: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if reaction == nil {
+		const text = "reaction not found"
+		return gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	if err := p.state.DB.DeleteAnnouncementReactionByID(ctx, reaction.ID); err != nil {
+		err := gtserror.Newf("db error deleting announcement reaction: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	reactions, err := p.state.DB.GetAnnouncementReactions(ctx, announcement.ID)
+	if err != nil {
+		err := gtserror.Newf("db error getting announcement reactions: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	p.streamReaction(ctx, announcement.ID, name, reactions)
+	return nil
+}
+
+// streamReaction streams the updated count of reactions
+// with the given name to the given announcement to all users.
+func (p *Processor) streamReaction(
+	ctx context.Context,
+	announcementID string,
+	name string,
+	reactions []*gtsmodel.AnnouncementReaction,
+) {
+	apiReaction := &apimodel.AnnouncementReaction{Name: name}
+
+	for _, r := range p.converter.AnnouncementReactionsToAPIAnnouncementReactions(reactions, "") {
+		if r.Name == name {
+			apiReaction = &r
+			break
+		}
+	}
+
+	apiReaction.AnnouncementID = announcementID
+	p.stream.AnnouncementReaction(ctx, apiReaction)
+}
@@ -0,0 +1,100 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package announcements
+
+import (
+	"context"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+)
+
+// ScheduleAll schedules publication of all
+// announcements scheduled for publication.
+func (p *Processor) ScheduleAll(ctx context.Context) error {
+	// Fetch all scheduled announcements from the database (barebones models are enough).
+	announcements, err := p.state.DB.GetScheduledAnnouncements(gtscontext.SetBarebones(ctx))
+	if err != nil {
+		return gtserror.Newf("error getting scheduled announcements from db: %w", err)
+	}
+
+	var errs gtserror.MultiError
+
+	for _, announcement := range announcements {
+		// Schedule each of the announcements and catch any errors.
+		if err := p.schedulePublication(ctx, announcement); err != nil {
+			errs.Append(err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+func (p *Processor) schedulePublication(ctx context.Context, announcement *gtsmodel.Announcement) error {
+	// Add the given announcement to the scheduler.
+	ok := p.state.Workers.Scheduler.AddOnce(
+		announcement.ID,
+		announcement.ScheduledAt,
+		p.onPublish(announcement.ID),
+	)
+
+	if !ok {
+		// Failed to add the announcement to the scheduler, either it was
+		// starting / stopping or there already exists a task for announcement.
+		return gtserror.Newf("failed adding announcement %s to scheduler", announcement.ID)
+	}
+
+	atStr := announcement.ScheduledAt.Local().Format("Jan _2 2006 15:04:05")
+	log.Infof(ctx, "scheduled announcement publication for %s at '%s'", announcement.ID, atStr)
+	return nil
+}
+
+// onPublish returns a callback function to be used by the
+// scheduler when the given announcement is to be published.
+func (p *Processor) onPublish(announcementID string) func(context.Context, time.Time) {
+	return func(ctx context.Context, now time.Time) {
+		// Get the latest version of announcement from database.
+		announcement, err := p.state.DB.GetAnnouncementByID(ctx, announcementID)
+		if err != nil {
+			log.Errorf(ctx, "error getting announcement %s from db: %v", announcementID, err)
+			return
+		}
+
+		if announcement.IsPublished() {
+			// Publish handler has already been run for this announcement.
+			log.Errorf(ctx, "announcement %s already published", announcementID)
+			return
+		}
+
+		// Set "published" time.
+		announcement.PublishedAt = now
+
+		// Update the announcement to mark it as published in the database.
+		if err := p.state.DB.UpdateAnnouncement(ctx, announcement, "published_at"); err != nil {
+			log.Errorf(ctx, "error updating announcement %s in db: %v", announcementID, err)
+			return
+		}
+
+		if announcement.IsActive(now) {
+			p.streamAnnouncement(ctx, announcement)
+		}
+	}
+}
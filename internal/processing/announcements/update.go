@@ -0,0 +1,100 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package announcements
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+)
+
+// Update updates the announcement with the given ID using
+// any fields set on the given form. If the announcement is
+// (or becomes) active, it's streamed to users again as
+// unread; if it stops being active, it's removed from them.
+func (p *Processor) Update(
+	ctx context.Context,
+	admin *gtsmodel.Account,
+	id string,
+	form *apimodel.AnnouncementRequest,
+) (*apimodel.Announcement, gtserror.WithCode) {
+	announcement, errWithCode := p.getAnnouncement(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	now := time.Now()
+	wasActive := announcement.IsActive(now)
+
+	if form.Text != nil {
+		if errWithCode := p.setText(ctx, announcement, *form.Text); errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	if errWithCode := setTimes(announcement, form); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Whether the announcement
+	// should be (re)scheduled.
+	var reschedule bool
+
+	if form.ScheduledAt != nil {
+		if announcement.IsPublished() {
+			const text = "scheduled_at cannot be changed once an announcement is published"
+			return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+
+		// Cancel any existing scheduled publication.
+		_ = p.state.Workers.Scheduler.Cancel(announcement.ID)
+
+		if form.ScheduledAt.After(now) {
+			// Publish later.
+			announcement.ScheduledAt = *form.ScheduledAt
+			reschedule = true
+		} else {
+			// Publish right away.
+			announcement.PublishedAt = now
+		}
+	}
+
+	if err := p.state.DB.UpdateAnnouncement(ctx, announcement); err != nil {
+		err := gtserror.Newf("db error updating announcement: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	switch {
+	case reschedule:
+		if err := p.schedulePublication(ctx, announcement); err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+	case announcement.IsActive(now):
+		p.streamAnnouncement(ctx, announcement)
+
+	case wasActive:
+		p.stream.AnnouncementDelete(ctx, announcement.ID)
+	}
+
+	return p.apiAnnouncement(ctx, announcement, admin.ID, false)
+}
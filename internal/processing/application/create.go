@@ -99,6 +99,7 @@ func (p *Processor) Create(
 		ClientSecret:    uuid.NewString(),
 		Scopes:          scopes,
 		ManagedByUserID: managedByUserID,
+		Public:          &form.Public,
 	}
 	if err := p.state.DB.PutApplication(ctx, app); err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
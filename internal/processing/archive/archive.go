@@ -0,0 +1,61 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package archive
+
+import (
+	"code.superseriousbusiness.org/gotosocial/internal/processing/account"
+	"code.superseriousbusiness.org/gotosocial/internal/processing/common"
+	mediaprocessing "code.superseriousbusiness.org/gotosocial/internal/processing/media"
+	"code.superseriousbusiness.org/gotosocial/internal/processing/status"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"code.superseriousbusiness.org/gotosocial/internal/typeutils"
+)
+
+// Processor wraps functionality for exporting an
+// account's data to a downloadable archive, and
+// for importing such an archive to re-create the
+// archived statuses and profile on this instance.
+type Processor struct {
+	// common processor logic
+	c *common.Processor
+
+	state     *state.State
+	converter *typeutils.Converter
+	account   *account.Processor
+	status    *status.Processor
+	media     *mediaprocessing.Processor
+}
+
+// New returns a new archive processor.
+func New(
+	common *common.Processor,
+	state *state.State,
+	converter *typeutils.Converter,
+	account *account.Processor,
+	status *status.Processor,
+	media *mediaprocessing.Processor,
+) Processor {
+	return Processor{
+		c:         common,
+		state:     state,
+		converter: converter,
+		account:   account,
+		status:    status,
+		media:     media,
+	}
+}
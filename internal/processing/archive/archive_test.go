@@ -0,0 +1,403 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package archive_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"code.superseriousbusiness.org/gotosocial/testrig"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	rMediaPath    = "../../../testrig/media"
+	rTemplatePath = "../../../web/template"
+)
+
+type ArchiveTestSuite struct {
+	suite.Suite
+
+	testAccounts map[string]*gtsmodel.Account
+}
+
+func (suite *ArchiveTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+	suite.testAccounts = testrig.NewTestAccounts()
+}
+
+// export builds an archive of the given
+// account and returns its downloaded bytes.
+func (suite *ArchiveTestSuite) export(
+	testStructs *testrig.TestStructs,
+	account *gtsmodel.Account,
+) []byte {
+	ctx := suite.T().Context()
+	p := testStructs.Processor.Archive()
+
+	apiArchive, errWithCode := p.Create(ctx, account)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("pending", apiArchive.State)
+
+	if !testrig.WaitFor(func() bool {
+		apiArchive, errWithCode = p.Get(ctx, account)
+		return errWithCode == nil && apiArchive.State != "pending"
+	}) {
+		suite.FailNow("timed out waiting for archive")
+	}
+	suite.Equal("ready", apiArchive.State)
+	suite.NotEmpty(apiArchive.URL)
+
+	content, errWithCode := p.Download(ctx, account)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	defer content.Content.Close()
+
+	b, err := io.ReadAll(content.Content)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.EqualValues(apiArchive.Size, len(b))
+
+	return b
+}
+
+func (suite *ArchiveTestSuite) TestExport() {
+	testStructs := testrig.SetupTestStructs(rMediaPath, rTemplatePath)
+	defer testrig.TearDownTestStructs(testStructs)
+
+	account := suite.testAccounts["local_account_1"]
+	b := suite.export(testStructs, account)
+
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	readJSON := func(name string) map[string]any {
+		f, err := zr.Open(name)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		defer f.Close()
+
+		var m map[string]any
+		if err := json.NewDecoder(f).Decode(&m); err != nil {
+			suite.FailNow(err.Error())
+		}
+		return m
+	}
+
+	actor := readJSON("actor.json")
+	suite.Equal(account.URI, actor["id"])
+	suite.Equal("outbox.json", actor["outbox"])
+
+	outbox := readJSON("outbox.json")
+	suite.Equal("OrderedCollection", outbox["type"])
+	items, _ := outbox["orderedItems"].([]any)
+	suite.NotEmpty(items)
+	suite.EqualValues(len(items), outbox["totalItems"])
+
+	// Each archived attachment must
+	// point to a file in the archive.
+	for _, item := range items {
+		object, _ := item.(map[string]any)["object"].(map[string]any)
+		attachments, _ := object["attachment"].([]any)
+		for _, a := range attachments {
+			url, _ := a.(map[string]any)["url"].(string)
+			suite.Regexp(`^/media_attachments/files/`, url)
+			_, err := zr.Open(url[1:])
+			suite.NoError(err)
+		}
+	}
+
+	readJSON("likes.json")
+	readJSON("bookmarks.json")
+
+	// A second archive can't be
+	// requested straight away.
+	_, errWithCode := testStructs.Processor.Archive().Create(suite.T().Context(), account)
+	suite.EqualError(errWithCode, "a new archive can only be requested once every 7 days")
+}
+
+func (suite *ArchiveTestSuite) TestExportImport() {
+	testStructs := testrig.SetupTestStructs(rMediaPath, rTemplatePath)
+	defer testrig.TearDownTestStructs(testStructs)
+
+	var (
+		ctx    = suite.T().Context()
+		state  = testStructs.State
+		source = suite.testAccounts["local_account_1"]
+		target = suite.testAccounts["local_account_2"]
+	)
+
+	b := suite.export(testStructs, source)
+
+	// Note creation times of
+	// the source's own statuses.
+	sourceCreated := make(map[int64]bool)
+	for _, status := range suite.statuses(state, source) {
+		if status.BoostOfID == "" {
+			sourceCreated[status.CreatedAt.Unix()] = true
+		}
+	}
+
+	before := len(suite.statuses(state, target))
+
+	errWithCode := testStructs.Processor.Archive().Import(ctx, target, suite.fileHeader(b))
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Wait for (at least some) of the
+	// archived statuses to be imported.
+	var statuses []*gtsmodel.Status
+	if !testrig.WaitFor(func() bool {
+		statuses = suite.statuses(state, target)
+		return len(statuses) > before
+	}) {
+		suite.FailNow("timed out waiting for imported statuses")
+	}
+
+	// Imported statuses should be
+	// backdated to their originals.
+	var backdated int
+	for _, status := range statuses {
+		if sourceCreated[status.CreatedAt.Unix()] {
+			backdated++
+		}
+	}
+	suite.NotZero(backdated)
+}
+
+// statuses returns all statuses of the given account.
+func (suite *ArchiveTestSuite) statuses(
+	state *state.State,
+	account *gtsmodel.Account,
+) []*gtsmodel.Status {
+	statuses, err := state.DB.GetAccountStatuses(
+		suite.T().Context(),
+		account.ID,
+		0, false, false, "", "", false, false,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		suite.FailNow(err.Error())
+	}
+	return statuses
+}
+
+func (suite *ArchiveTestSuite) TestImportBackdatingDisabled() {
+	testStructs := testrig.SetupTestStructs(rMediaPath, rTemplatePath)
+	defer testrig.TearDownTestStructs(testStructs)
+
+	config.SetInstanceAllowBackdatingStatuses(false)
+
+	errWithCode := testStructs.Processor.Archive().Import(
+		suite.T().Context(),
+		suite.testAccounts["local_account_2"],
+		suite.fileHeader([]byte("not a zip")),
+	)
+	suite.EqualError(errWithCode, "importing archives requires backdating statuses, which has been disabled on this instance")
+}
+
+func (suite *ArchiveTestSuite) TestImportInvalid() {
+	testStructs := testrig.SetupTestStructs(rMediaPath, rTemplatePath)
+	defer testrig.TearDownTestStructs(testStructs)
+
+	errWithCode := testStructs.Processor.Archive().Import(
+		suite.T().Context(),
+		suite.testAccounts["local_account_2"],
+		suite.fileHeader([]byte("not a zip")),
+	)
+	suite.Equal(400, errWithCode.Code())
+}
+
+func (suite *ArchiveTestSuite) TestImportTooLarge() {
+	testStructs := testrig.SetupTestStructs(rMediaPath, rTemplatePath)
+	defer testrig.TearDownTestStructs(testStructs)
+
+	b := suite.export(testStructs, suite.testAccounts["local_account_1"])
+
+	config.SetAccountsArchiveJSONMaxSize(16)
+
+	errWithCode := testStructs.Processor.Archive().Import(
+		suite.T().Context(),
+		suite.testAccounts["local_account_2"],
+		suite.fileHeader(b),
+	)
+	suite.EqualError(errWithCode, "error reading archive: actor.json exceeds maximum size 16B")
+	suite.Equal(400, errWithCode.Code())
+}
+
+func (suite *ArchiveTestSuite) TestImportFailedNoteMedia() {
+	testStructs := testrig.SetupTestStructs(rMediaPath, rTemplatePath)
+	defer testrig.TearDownTestStructs(testStructs)
+
+	var (
+		ctx    = suite.T().Context()
+		state  = testStructs.State
+		target = suite.testAccounts["local_account_2"]
+	)
+
+	image, err := os.ReadFile(rMediaPath + "/beeplushie.jpg")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// The first note is too long to be
+	// created, the second should import.
+	b := suite.zip(map[string]any{
+		"actor.json": map[string]any{
+			"id": "https://example.org/users/someone",
+		},
+		"outbox.json": map[string]any{
+			"orderedItems": []any{
+				map[string]any{
+					"type": "Create",
+					"object": map[string]any{
+						"id":        "https://example.org/users/someone/statuses/1",
+						"type":      "Note",
+						"published": "2020-01-01T00:00:00Z",
+						"content":   strings.Repeat("a", 6000),
+						"to":        "https://www.w3.org/ns/activitystreams#Public",
+						"attachment": map[string]any{
+							"url": "/media_attachments/files/beeplushie.jpg",
+						},
+					},
+				},
+				map[string]any{
+					"type": "Create",
+					"object": map[string]any{
+						"id":        "https://example.org/users/someone/statuses/2",
+						"type":      "Note",
+						"published": "2021-01-01T00:00:00Z",
+						"content":   "hello",
+						"to":        "https://www.w3.org/ns/activitystreams#Public",
+					},
+				},
+			},
+		},
+		"media_attachments/files/beeplushie.jpg": image,
+	})
+
+	before := len(suite.statuses(state, target))
+
+	errWithCode := testStructs.Processor.Archive().Import(ctx, target, suite.fileHeader(b))
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	if !testrig.WaitFor(func() bool {
+		return len(suite.statuses(state, target)) > before
+	}) {
+		suite.FailNow("timed out waiting for imported status")
+	}
+
+	// Media stored for the failed
+	// note should have been removed.
+	attachments, err := state.DB.GetAttachments(ctx, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	for _, attachment := range attachments {
+		if attachment.AccountID == target.ID {
+			suite.NotEmpty(attachment.StatusID)
+		}
+	}
+}
+
+// zip returns a zip archive containing given entries, with
+// byte slices written as-is and other values as JSON.
+func (suite *ArchiveTestSuite) zip(entries map[string]any) []byte {
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+
+	for name, entry := range entries {
+		w, err := zw.Create(name)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		b, ok := entry.([]byte)
+		if !ok {
+			b, err = json.Marshal(entry)
+			if err != nil {
+				suite.FailNow(err.Error())
+			}
+		}
+
+		if _, err := w.Write(b); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return buf.Bytes()
+}
+
+// fileHeader returns a multipart
+// file header containing b.
+func (suite *ArchiveTestSuite) fileHeader(b []byte) *multipart.FileHeader {
+	body := new(bytes.Buffer)
+	w := multipart.NewWriter(body)
+
+	fw, err := w.CreateFormFile("data", "archive.zip")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if _, err := fw.Write(b); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := w.Close(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	r := httptest.NewRequest("POST", "/", body)
+	r.Header.Set("Content-Type", w.FormDataContentType())
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return r.MultipartForm.File["data"][0]
+}
+
+func TestArchiveTestSuite(t *testing.T) {
+	suite.Run(t, new(ArchiveTestSuite))
+}
@@ -0,0 +1,606 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package archive
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/ap"
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/typeutils"
+)
+
+const (
+	// archiveInterval is the minimum time that must
+	// pass between requests for a new archive, unless
+	// building of the previous archive failed.
+	archiveInterval = 7 * 24 * time.Hour
+
+	// archiveStaleAfter is the time after which
+	// a still-pending archive is assumed to have
+	// been interrupted (eg., by a restart), and
+	// may be superseded by a new request.
+	archiveStaleAfter = 24 * time.Hour
+
+	// archivePageSize is the number of statuses /
+	// bookmarks to fetch per database query when
+	// building an archive.
+	archivePageSize = 100
+)
+
+// Get returns the most recently requested
+// archive of the given account, if any.
+func (p *Processor) Get(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) (*apimodel.Archive, gtserror.WithCode) {
+	archive, err := p.state.DB.GetLatestArchive(ctx, requester.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting archive: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if archive == nil {
+		const text = "no archive has been requested yet"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return p.apiArchive(ctx, archive)
+}
+
+// Create queues a new archive of the given account
+// to be built in the background, removing any older
+// archives of the account. Only one archive may be
+// requested per archiveInterval, unless building the
+// previous one failed.
+func (p *Processor) Create(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) (*apimodel.Archive, gtserror.WithCode) {
+	latest, err := p.state.DB.GetLatestArchive(ctx, requester.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting archive: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if latest != nil {
+		switch latest.State {
+		case gtsmodel.ArchiveStatePending:
+			if time.Since(latest.UpdatedAt) < archiveStaleAfter {
+				const text = "an archive is already being built"
+				return nil, gtserror.NewErrorConflict(errors.New(text), text)
+			}
+
+		case gtsmodel.ArchiveStateReady:
+			if time.Since(latest.CreatedAt) < archiveInterval {
+				const text = "a new archive can only be requested once every 7 days"
+				return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+			}
+		}
+	}
+
+	// Clear out previous archives
+	// before queueing up a new one.
+	if err := p.DeleteAll(ctx, requester.ID); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	archive := &gtsmodel.Archive{
+		ID:        id.NewULID(),
+		AccountID: requester.ID,
+		State:     gtsmodel.ArchiveStatePending,
+	}
+
+	if err := p.state.DB.PutArchive(ctx, archive); err != nil {
+		err := gtserror.Newf("db error putting archive: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Build the archive asynchronously.
+	p.state.Workers.Processing.Queue.Push(func(ctx context.Context) {
+		p.buildArchive(ctx, requester, archive)
+	})
+
+	return p.apiArchive(ctx, archive)
+}
+
+// Download returns the content of the most recently
+// built archive of the given account, if it's ready.
+func (p *Processor) Download(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) (*apimodel.Content, gtserror.WithCode) {
+	archive, err := p.state.DB.GetLatestArchive(ctx, requester.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting archive: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if archive == nil || archive.State != gtsmodel.ArchiveStateReady {
+		const text = "no archive is ready for download"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	rc, err := p.state.Storage.GetStream(ctx, archive.Key)
+	if err != nil {
+		err := gtserror.Newf("storage error getting archive %s: %w", archive.Key, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.Content{
+		ContentType:   "application/zip",
+		ContentLength: archive.Size,
+		Content:       rc,
+	}, nil
+}
+
+// apiArchive converts the given archive to its API model.
+func (p *Processor) apiArchive(
+	ctx context.Context,
+	archive *gtsmodel.Archive,
+) (*apimodel.Archive, gtserror.WithCode) {
+	apiArchive, err := p.converter.ArchiveToAPIArchive(ctx, archive)
+	if err != nil {
+		err := gtserror.Newf("error converting archive to api model: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiArchive, nil
+}
+
+// DeleteAll deletes all archives of the
+// given account, and their stored files.
+func (p *Processor) DeleteAll(ctx context.Context, accountID string) error {
+	archives, err := p.state.DB.GetArchives(ctx, accountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting archives: %w", err)
+	}
+
+	for _, archive := range archives {
+		if archive.Key != "" {
+			if err := p.state.Storage.Delete(ctx, archive.Key); err != nil &&
+				!errors.Is(err, os.ErrNotExist) {
+				log.Warnf(ctx, "error deleting archive %s from storage: %v", archive.Key, err)
+			}
+		}
+
+		if err := p.state.DB.DeleteArchiveByID(ctx, archive.ID); err != nil {
+			return gtserror.Newf("db error deleting archive %s: %w", archive.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// buildArchive builds the given archive of the given
+// account, updating the archive state on completion.
+func (p *Processor) buildArchive(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	archive *gtsmodel.Archive,
+) {
+	if err := p.writeArchive(ctx, account, archive); err != nil {
+		log.Errorf(ctx, "error building archive for account %s: %v", account.ID, err)
+		archive.State = gtsmodel.ArchiveStateFailed
+		archive.Key = ""
+		archive.Size = 0
+	} else {
+		archive.State = gtsmodel.ArchiveStateReady
+	}
+
+	if err := p.state.DB.UpdateArchive(ctx,
+		archive,
+		"state",
+		"key",
+		"size",
+	); err != nil {
+		log.Errorf(ctx, "db error updating archive %s: %v", archive.ID, err)
+	}
+}
+
+// writeArchive writes the account's data to a temporary
+// zip file, then moves it into storage under the archive
+// key, setting the archive's key and size on success.
+func (p *Processor) writeArchive(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	archive *gtsmodel.Archive,
+) error {
+	tmp, err := os.CreateTemp("", "gotosocial-archive-*.zip")
+	if err != nil {
+		return gtserror.Newf("error creating temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	zw := zip.NewWriter(tmp)
+	if err := p.writeArchiveEntries(ctx, zw, account); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := zw.Close(); err != nil {
+		tmp.Close()
+		return gtserror.Newf("error closing zip writer: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return gtserror.Newf("error closing temp file: %w", err)
+	}
+
+	key := path.Join(account.ID, "archive", archive.ID+".zip")
+	size, err := p.state.Storage.PutFile(ctx, key, tmp.Name(), "application/zip")
+	if err != nil {
+		return gtserror.Newf("error storing archive: %w", err)
+	}
+
+	archive.Key = key
+	archive.Size = size
+	return nil
+}
+
+// writeArchiveEntries writes the actor, outbox,
+// likes, bookmarks and media of the account to zw.
+func (p *Processor) writeArchiveEntries(
+	ctx context.Context,
+	zw *zip.Writer,
+	account *gtsmodel.Account,
+) error {
+	if err := p.writeActor(ctx, zw, account); err != nil {
+		return err
+	}
+
+	if err := p.writeOutbox(ctx, zw, account); err != nil {
+		return err
+	}
+
+	if err := p.writeLikes(ctx, zw, account); err != nil {
+		return err
+	}
+
+	return p.writeBookmarks(ctx, zw, account)
+}
+
+// writeActor writes the account's actor to zw,
+// along with its avatar and header images.
+func (p *Processor) writeActor(
+	ctx context.Context,
+	zw *zip.Writer,
+	account *gtsmodel.Account,
+) error {
+	if err := p.state.DB.PopulateAccount(ctx, account); err != nil {
+		log.Warnf(ctx, "error populating account %s: %v", account.ID, err)
+	}
+
+	accountable, err := p.converter.AccountToAS(ctx, account)
+	if err != nil {
+		return gtserror.Newf("error converting account: %w", err)
+	}
+
+	actor, err := ap.Serialize(accountable)
+	if err != nil {
+		return gtserror.Newf("error serializing account: %w", err)
+	}
+
+	// Point to the other entries of the archive.
+	actor["outbox"] = archiveOutboxFile
+	actor["likes"] = archiveLikesFile
+	actor["bookmarks"] = archiveBookmarksFile
+
+	// Include avatar and header, pointing
+	// the actor's images to the copies.
+	images := []struct {
+		prop       string
+		name       string
+		attachment *gtsmodel.MediaAttachment
+	}{
+		{"icon", "avatar", account.AvatarMediaAttachment},
+		{"image", "header", account.HeaderMediaAttachment},
+	}
+
+	for _, image := range images {
+		obj, ok := actor[image.prop].(map[string]any)
+		if !ok || image.attachment == nil {
+			continue
+		}
+
+		name := image.name + path.Ext(image.attachment.File.Path)
+		if err := p.writeMedia(ctx, zw, name, image.attachment); err != nil {
+			return err
+		}
+
+		obj["url"] = name
+	}
+
+	return writeJSON(zw, archiveActorFile, actor)
+}
+
+// writeOutbox writes an outbox collection of
+// the account's statuses and boosts to zw,
+// along with any media attached to them.
+func (p *Processor) writeOutbox(
+	ctx context.Context,
+	zw *zip.Writer,
+	account *gtsmodel.Account,
+) error {
+	var (
+		items []any
+		maxID string
+	)
+
+	for {
+		statuses, err := p.state.DB.GetAccountStatuses(ctx,
+			account.ID,
+			archivePageSize,
+			false, // excludeReplies
+			false, // excludeReblogs
+			maxID,
+			"",    // minID
+			false, // mediaOnly
+			false, // publicOnly
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting statuses: %w", err)
+		}
+
+		if len(statuses) == 0 {
+			break
+		}
+
+		maxID = statuses[len(statuses)-1].ID
+
+		for _, status := range statuses {
+			item, err := p.statusToArchiveItem(ctx, zw, status)
+			if err != nil {
+				// Not fatal, keep going
+				// with the rest of them.
+				log.Warnf(ctx, "error archiving status %s: %v", status.ID, err)
+				continue
+			}
+
+			items = append(items, item)
+		}
+	}
+
+	return writeJSON(zw, archiveOutboxFile, orderedCollection(archiveOutboxFile, items))
+}
+
+// statusToArchiveItem converts the given status to a
+// Create (or Announce, for boosts) activity, writing
+// its attachments to zw and pointing the activity's
+// attachment urls to the copies in the archive.
+func (p *Processor) statusToArchiveItem(
+	ctx context.Context,
+	zw *zip.Writer,
+	status *gtsmodel.Status,
+) (map[string]any, error) {
+	if status.BoostOfID != "" {
+		announce, err := p.converter.BoostToAS(ctx, status)
+		if err != nil {
+			return nil, err
+		}
+
+		return ap.Serialize(announce)
+	}
+
+	statusable, err := p.converter.StatusToAS(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	create := typeutils.WrapStatusableInCreate(statusable, false)
+	item, err := ap.Serialize(create)
+	if err != nil {
+		return nil, err
+	}
+
+	object, ok := item["object"].(map[string]any)
+	if !ok {
+		return item, nil
+	}
+
+	var attachments []any
+	switch a := object["attachment"].(type) {
+	case []any:
+		attachments = a
+	case map[string]any:
+		attachments = []any{a}
+	}
+
+	for _, a := range attachments {
+		obj, ok := a.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		url, _ := obj["url"].(string)
+		for _, attachment := range status.Attachments {
+			if attachment.URL != url || attachment.File.Path == "" {
+				continue
+			}
+
+			name := path.Join(
+				archiveMediaDir,
+				attachment.ID,
+				"original",
+				path.Base(attachment.File.Path),
+			)
+
+			if err := p.writeMedia(ctx, zw, name, attachment); err != nil {
+				return nil, err
+			}
+
+			obj["url"] = "/" + name
+			break
+		}
+	}
+
+	return item, nil
+}
+
+// writeLikes writes a collection of the
+// URIs of statuses faved by account to zw.
+func (p *Processor) writeLikes(
+	ctx context.Context,
+	zw *zip.Writer,
+	account *gtsmodel.Account,
+) error {
+	faves, err := p.state.DB.GetAccountFaves(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting faves: %w", err)
+	}
+
+	items := make([]any, 0, len(faves))
+	for _, fave := range faves {
+		if uri := p.statusURI(ctx, fave.Status, fave.StatusID); uri != "" {
+			items = append(items, uri)
+		}
+	}
+
+	return writeJSON(zw, archiveLikesFile, orderedCollection(archiveLikesFile, items))
+}
+
+// writeBookmarks writes a collection of the
+// URIs of statuses bookmarked by account to zw.
+func (p *Processor) writeBookmarks(
+	ctx context.Context,
+	zw *zip.Writer,
+	account *gtsmodel.Account,
+) error {
+	var (
+		items []any
+		maxID string
+	)
+
+	for {
+		bookmarks, err := p.state.DB.GetStatusBookmarks(ctx,
+			account.ID,
+			archivePageSize,
+			maxID,
+			"", // minID
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting bookmarks: %w", err)
+		}
+
+		if len(bookmarks) == 0 {
+			break
+		}
+
+		maxID = bookmarks[len(bookmarks)-1].ID
+
+		for _, bookmark := range bookmarks {
+			if uri := p.statusURI(ctx, bookmark.Status, bookmark.StatusID); uri != "" {
+				items = append(items, uri)
+			}
+		}
+	}
+
+	return writeJSON(zw, archiveBookmarksFile, orderedCollection(archiveBookmarksFile, items))
+}
+
+// statusURI returns the URI of the given status,
+// fetching it by ID if not already populated.
+// Returns an empty string if it can't be found.
+func (p *Processor) statusURI(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	statusID string,
+) string {
+	if status == nil {
+		var err error
+		status, err = p.state.DB.GetStatusByID(
+			gtscontext.SetBarebones(ctx),
+			statusID,
+		)
+		if err != nil {
+			log.Debugf(ctx, "error getting status %s: %v", statusID, err)
+			return ""
+		}
+	}
+
+	return status.URI
+}
+
+// writeMedia copies the original file of
+// attachment from storage to name in zw.
+func (p *Processor) writeMedia(
+	ctx context.Context,
+	zw *zip.Writer,
+	name string,
+	attachment *gtsmodel.MediaAttachment,
+) error {
+	rc, err := p.state.Storage.GetStream(ctx, attachment.File.Path)
+	if err != nil {
+		return gtserror.Newf("storage error getting %s: %w", attachment.File.Path, err)
+	}
+	defer rc.Close()
+
+	w, err := zw.Create(name)
+	if err != nil {
+		return gtserror.Newf("error creating %s: %w", name, err)
+	}
+
+	if _, err := io.Copy(w, rc); err != nil {
+		return gtserror.Newf("error writing %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// writeJSON writes v as JSON to name in zw.
+func writeJSON(zw *zip.Writer, name string, v any) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return gtserror.Newf("error creating %s: %w", name, err)
+	}
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		return gtserror.Newf("error writing %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// orderedCollection returns a serialized
+// OrderedCollection with the given items.
+func orderedCollection(id string, items []any) map[string]any {
+	if items == nil {
+		items = []any{}
+	}
+
+	return map[string]any{
+		"@context":     activityStreamsContext,
+		"id":           id,
+		"type":         ap.ObjectOrderedCollection,
+		"totalItems":   len(items),
+		"orderedItems": items,
+	}
+}
@@ -0,0 +1,509 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package archive
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"os"
+	"slices"
+	"strings"
+
+	"code.superseriousbusiness.org/gotosocial/internal/ap"
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/media"
+	"code.superseriousbusiness.org/gotosocial/internal/text"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+	"codeberg.org/gruf/go-iotools"
+)
+
+// Import imports the given account archive (as built by Create,
+// or by Mastodon) to the requesting account. The archive is
+// validated synchronously, then the profile is updated and the
+// archived statuses are re-created as backdated local statuses
+// in the background. Boosts, polls, and replies to statuses
+// not present in the archive are skipped.
+//
+// Since imported statuses are backdated, this requires
+// instance-allow-backdating-statuses to be enabled.
+func (p *Processor) Import(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	data *multipart.FileHeader,
+) gtserror.WithCode {
+	if !config.GetInstanceAllowBackdatingStatuses() {
+		const text = "importing archives requires backdating statuses, which has been disabled on this instance"
+		return gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	// Copy the upload to a temporary file,
+	// since the multipart form data will be
+	// cleaned up once this request returns.
+	tmpPath, err := copyToTemp(data)
+	if err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	actor, outbox, err := readArchive(tmpPath)
+	if err != nil {
+		os.Remove(tmpPath)
+		err := fmt.Errorf("error reading archive: %w", err)
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	// Do remaining processing of this import asynchronously.
+	p.state.Workers.Processing.Queue.Push(func(ctx context.Context) {
+		p.importArchive(ctx, requester, tmpPath, actor, outbox)
+	})
+
+	return nil
+}
+
+// copyToTemp copies the given multipart
+// file to a new temporary file, returning
+// the path of the temporary file.
+func copyToTemp(data *multipart.FileHeader) (string, error) {
+	file, err := data.Open()
+	if err != nil {
+		return "", gtserror.Newf("error opening archive file: %w", err)
+	}
+	defer file.Close()
+
+	tmp, err := os.CreateTemp("", "gotosocial-import-*.zip")
+	if err != nil {
+		return "", gtserror.Newf("error creating temp file: %w", err)
+	}
+
+	if _, err := io.Copy(tmp, file); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return "", gtserror.Newf("error copying archive file: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return "", gtserror.Newf("error closing temp file: %w", err)
+	}
+
+	return tmp.Name(), nil
+}
+
+// readArchive reads the actor
+// and outbox of the archive at path.
+func readArchive(path string) (*archiveActor, *archiveOutbox, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer zr.Close()
+
+	actor := new(archiveActor)
+	if err := readJSON(&zr.Reader, archiveActorFile, actor); err != nil {
+		return nil, nil, err
+	}
+
+	if actor.ID == "" {
+		return nil, nil, errors.New(archiveActorFile + " has no id")
+	}
+
+	outbox := new(archiveOutbox)
+	if err := readJSON(&zr.Reader, archiveOutboxFile, outbox); err != nil {
+		return nil, nil, err
+	}
+
+	return actor, outbox, nil
+}
+
+// readJSON decodes the JSON entry name of zr into v,
+// refusing entries larger than the configured maximum.
+func readJSON(zr *zip.Reader, name string, v any) error {
+	maxsz := config.GetAccountsArchiveJSONMaxSize()
+	maxszInt64 := int64(maxsz) // #nosec G115 -- Already validated.
+
+	i := slices.IndexFunc(zr.File, func(f *zip.File) bool {
+		return f.Name == name
+	})
+	if i < 0 {
+		return fmt.Errorf("%s not found in archive", name)
+	}
+
+	// Check the size declared in the archive before
+	// decompressing, then limit what is read anyway,
+	// since the declared size can't be trusted.
+	if zr.File[i].UncompressedSize64 > uint64(maxsz) {
+		return fmt.Errorf("%s exceeds maximum size %s", name, maxsz)
+	}
+
+	f, err := zr.File[i].Open()
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := io.LimitReader(f, maxszInt64)
+	if err := json.NewDecoder(r).Decode(v); err != nil {
+		return fmt.Errorf("error decoding %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// importBatchSize is the number of archived notes
+// imported per processing job, so that large imports
+// don't hold up a processing worker for too long.
+const importBatchSize = 20
+
+// noteImport holds the state of an
+// in-progress import of archived notes.
+type noteImport struct {
+	account *gtsmodel.Account
+	app     *gtsmodel.Application
+	path    string
+	actor   *archiveActor
+	notes   []*archiveNote
+	total   int
+
+	// Map of archived status URIs
+	// to IDs of imported statuses.
+	imported map[string]string
+}
+
+// importArchive imports the profile of the archive at path to
+// account, then queues import of the archived statuses in batches.
+// The archive at path is removed once the import has finished.
+func (p *Processor) importArchive(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	path string,
+	actor *archiveActor,
+	outbox *archiveOutbox,
+) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		log.Errorf(ctx, "error opening archive: %v", err)
+		os.Remove(path)
+		return
+	}
+
+	p.importProfile(ctx, account, &zr.Reader, actor)
+	zr.Close()
+
+	app, err := p.state.DB.GetInstanceApplication(ctx)
+	if err != nil {
+		log.Errorf(ctx, "db error getting instance application: %v", err)
+		os.Remove(path)
+		return
+	}
+
+	// Gather archived notes. Boosts
+	// (and polls, which can't be
+	// backdated) are skipped.
+	notes := make([]*archiveNote, 0, len(outbox.OrderedItems))
+	for _, item := range outbox.OrderedItems {
+		if item.Type != ap.ActivityCreate {
+			continue
+		}
+
+		note := new(archiveNote)
+		if err := json.Unmarshal(item.Object, note); err != nil {
+			log.Debugf(ctx, "skipping unparseable archive object: %v", err)
+			continue
+		}
+
+		if note.Type != ap.ObjectNote && note.Type != ap.ObjectArticle {
+			continue
+		}
+
+		notes = append(notes, note)
+	}
+
+	// Import oldest first, so that replies
+	// can be threaded to imported parents.
+	slices.SortStableFunc(notes, func(a, b *archiveNote) int {
+		return a.Published.Compare(b.Published)
+	})
+
+	p.importNotes(ctx, &noteImport{
+		account:  account,
+		app:      app,
+		path:     path,
+		actor:    actor,
+		notes:    notes,
+		total:    len(notes),
+		imported: make(map[string]string, len(notes)),
+	})
+}
+
+// importNotes imports the next batch of archived notes
+// of imp, then queues the next batch (if any). Batches
+// are queued one after another rather than all at once,
+// so that replies are still imported after their parents.
+func (p *Processor) importNotes(ctx context.Context, imp *noteImport) {
+	zr, err := zip.OpenReader(imp.path)
+	if err != nil {
+		log.Errorf(ctx, "error opening archive: %v", err)
+		os.Remove(imp.path)
+		return
+	}
+
+	n := min(len(imp.notes), importBatchSize)
+	for _, note := range imp.notes[:n] {
+		statusID, err := p.importNote(ctx, imp.account, imp.app, &zr.Reader, imp.actor, note, imp.imported)
+		if err != nil {
+			log.Debugf(ctx, "skipping archived status %s: %v", note.ID, err)
+			continue
+		}
+
+		imp.imported[note.ID] = statusID
+	}
+
+	zr.Close()
+	imp.notes = imp.notes[n:]
+
+	if len(imp.notes) > 0 {
+		// Queue the next batch.
+		p.state.Workers.Processing.Queue.Push(func(ctx context.Context) {
+			p.importNotes(ctx, imp)
+		})
+		return
+	}
+
+	os.Remove(imp.path)
+	log.Infof(ctx,
+		"imported %d of %d archived statuses for account %s",
+		len(imp.imported), imp.total, imp.account.ID,
+	)
+}
+
+// importProfile updates the display name, note,
+// avatar and header of account from the archive.
+func (p *Processor) importProfile(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	zr *zip.Reader,
+	actor *archiveActor,
+) {
+	var columns []string
+
+	if actor.Icon != nil && actor.Icon.URL != "" {
+		avatar, err := p.importMedia(ctx, account, zr, string(actor.Icon.URL),
+			media.AdditionalMediaInfo{Avatar: util.Ptr(true)},
+		)
+		if err != nil {
+			log.Warnf(ctx, "error importing avatar: %v", err)
+		} else {
+			account.AvatarMediaAttachmentID = avatar.ID
+			account.AvatarMediaAttachment = avatar
+			columns = append(columns, "avatar_media_attachment_id")
+		}
+	}
+
+	if actor.Image != nil && actor.Image.URL != "" {
+		header, err := p.importMedia(ctx, account, zr, string(actor.Image.URL),
+			media.AdditionalMediaInfo{Header: util.Ptr(true)},
+		)
+		if err != nil {
+			log.Warnf(ctx, "error importing header: %v", err)
+		} else {
+			account.HeaderMediaAttachmentID = header.ID
+			account.HeaderMediaAttachment = header
+			columns = append(columns, "header_media_attachment_id")
+		}
+	}
+
+	if len(columns) > 0 {
+		if err := p.state.DB.UpdateAccount(ctx, account, columns...); err != nil {
+			log.Errorf(ctx, "db error updating account: %v", err)
+		}
+	}
+
+	// Update display name and note through the
+	// account processor, so they're formatted as
+	// usual and the update is federated out.
+	form := &apimodel.UpdateCredentialsRequest{
+		Note: util.Ptr(text.ParseHTMLToPlain(actor.Summary)),
+	}
+
+	if actor.Name != "" {
+		form.DisplayName = &actor.Name
+	}
+
+	if _, errWithCode := p.account.Update(ctx, account, form); errWithCode != nil {
+		log.Warnf(ctx, "error updating profile: %v", errWithCode)
+	}
+}
+
+// importNote re-creates the archived note as a backdated
+// status of account, returning the new status ID.
+func (p *Processor) importNote(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	app *gtsmodel.Application,
+	zr *zip.Reader,
+	actor *archiveActor,
+	note *archiveNote,
+	imported map[string]string,
+) (string, error) {
+	if note.Published.IsZero() {
+		return "", errors.New("no published time")
+	}
+
+	var inReplyToID string
+	if inReplyTo := string(note.InReplyTo); inReplyTo != "" {
+		var ok bool
+		inReplyToID, ok = imported[inReplyTo]
+		if !ok {
+			return "", errors.New("reply to status not in archive")
+		}
+	}
+
+	mediaIDs := make([]string, 0, len(note.Attachment))
+	for _, a := range note.Attachment {
+		info := media.AdditionalMediaInfo{Description: util.Ptr(a.Name)}
+		if len(a.FocalPoint) == 2 {
+			info.FocusX = &a.FocalPoint[0]
+			info.FocusY = &a.FocalPoint[1]
+		}
+
+		attachment, err := p.importMedia(ctx, account, zr, string(a.URL), info)
+		if err != nil {
+			p.deleteMedia(ctx, mediaIDs)
+			return "", err
+		}
+
+		mediaIDs = append(mediaIDs, attachment.ID)
+	}
+
+	form := &apimodel.StatusCreateRequest{
+		Status:      text.ParseHTMLToPlain(note.Content),
+		SpoilerText: text.ParseHTMLToPlain(note.Summary),
+		Sensitive:   note.Sensitive,
+		MediaIDs:    mediaIDs,
+		InReplyToID: inReplyToID,
+		Visibility:  archiveVisibility(note, actor.Followers),
+		Language:    archiveLanguage(note),
+		ContentType: apimodel.StatusContentTypePlain,
+		ScheduledAt: &note.Published,
+	}
+
+	created, errWithCode := p.status.Create(ctx, account, app, form, nil)
+	if errWithCode != nil {
+		p.deleteMedia(ctx, mediaIDs)
+		return "", errWithCode
+	}
+
+	status, ok := created.(*apimodel.Status)
+	if !ok {
+		return "", fmt.Errorf("unexpected status create result %T", created)
+	}
+
+	return status.ID, nil
+}
+
+// importMedia stores the archive entry at url
+// as a new local media attachment of account.
+func (p *Processor) importMedia(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	zr *zip.Reader,
+	url string,
+	info media.AdditionalMediaInfo,
+) (*gtsmodel.MediaAttachment, error) {
+	f, err := zr.Open(strings.TrimPrefix(url, "/"))
+	if err != nil {
+		return nil, err
+	}
+
+	// Get maximum supported local media size.
+	maxsz := config.GetMediaLocalMaxSize()
+	maxszInt64 := int64(maxsz) // #nosec G115 -- Already validated.
+
+	// Wrap the archive entry to ensure is limited to max.
+	rc, _, _ := iotools.UpdateReadCloserLimit(f, maxszInt64)
+
+	attachment, errWithCode := p.c.StoreLocalMedia(ctx,
+		account.ID,
+		func(ctx context.Context) (io.ReadCloser, error) {
+			return rc, nil
+		},
+		info,
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return attachment, nil
+}
+
+// deleteMedia deletes the media attachments with given IDs,
+// stored for an archived note that then failed to import.
+func (p *Processor) deleteMedia(ctx context.Context, ids []string) {
+	for _, id := range ids {
+		if errWithCode := p.media.Delete(ctx, id); errWithCode != nil {
+			log.Warnf(ctx, "error deleting attachment %s: %v", id, errWithCode)
+		}
+	}
+}
+
+// archiveVisibility derives the visibility of an
+// archived note from its to and cc properties.
+func archiveVisibility(note *archiveNote, followersURI string) apimodel.Visibility {
+	isPublic := func(uri string) bool {
+		return uri == ap.PublicIRI().String() ||
+			uri == "as:Public" || uri == "Public"
+	}
+
+	switch {
+	case slices.ContainsFunc(note.To, isPublic):
+		return apimodel.VisibilityPublic
+
+	case slices.ContainsFunc(note.Cc, isPublic):
+		return apimodel.VisibilityUnlisted
+
+	case followersURI != "" &&
+		(slices.Contains(note.To, followersURI) ||
+			slices.Contains(note.Cc, followersURI)):
+		return apimodel.VisibilityPrivate
+
+	default:
+		return apimodel.VisibilityDirect
+	}
+}
+
+// archiveLanguage returns the language of an
+// archived note, taken from its contentMap.
+func archiveLanguage(note *archiveNote) string {
+	var lang string
+	for l := range note.ContentMap {
+		// Pick lowest for
+		// determinism.
+		if lang == "" || l < lang {
+			lang = l
+		}
+	}
+	return lang
+}
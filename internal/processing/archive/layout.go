@@ -0,0 +1,170 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package archive
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+// Entries of an account archive. The layout follows
+// that of Mastodon's account archives, so archives
+// can be moved between GoToSocial and Mastodon.
+const (
+	archiveActorFile     = "actor.json"
+	archiveOutboxFile    = "outbox.json"
+	archiveLikesFile     = "likes.json"
+	archiveBookmarksFile = "bookmarks.json"
+	archiveMediaDir      = "media_attachments/files"
+
+	activityStreamsContext = "https://www.w3.org/ns/activitystreams"
+)
+
+// archiveActor is the subset of
+// an archived actor that's used
+// when importing an archive.
+type archiveActor struct {
+	ID        string        `json:"id"`
+	Name      string        `json:"name"`
+	Summary   string        `json:"summary"`
+	Followers string        `json:"followers"`
+	Icon      *archiveImage `json:"icon"`
+	Image     *archiveImage `json:"image"`
+}
+
+// archiveImage is an archived
+// actor's avatar or header.
+type archiveImage struct {
+	URL flexString `json:"url"`
+}
+
+// archiveOutbox is an archived
+// outbox collection of activities.
+type archiveOutbox struct {
+	OrderedItems []archiveActivity `json:"orderedItems"`
+}
+
+// archiveActivity is an archived
+// Create or Announce activity.
+type archiveActivity struct {
+	Type   string          `json:"type"`
+	Object json.RawMessage `json:"object"`
+}
+
+// archiveNote is the subset of
+// an archived status that's used
+// when importing an archive.
+type archiveNote struct {
+	ID         string                 `json:"id"`
+	Type       string                 `json:"type"`
+	Published  time.Time              `json:"published"`
+	Summary    string                 `json:"summary"`
+	Content    string                 `json:"content"`
+	ContentMap map[string]string      `json:"contentMap"`
+	Sensitive  bool                   `json:"sensitive"`
+	InReplyTo  flexString             `json:"inReplyTo"`
+	To         flexStrings            `json:"to"`
+	Cc         flexStrings            `json:"cc"`
+	Attachment flexList[archiveMedia] `json:"attachment"`
+}
+
+// archiveMedia is an archived
+// status media attachment.
+type archiveMedia struct {
+	URL        flexString `json:"url"`
+	Name       string     `json:"name"`
+	FocalPoint []float32  `json:"focalPoint"`
+}
+
+// flexString is a JSON-LD property that may be given as
+// a single string, as a Link object with an href, or as
+// an array of these, of which only the first is used.
+type flexString string
+
+func (s *flexString) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	switch {
+	case len(b) == 0 || bytes.Equal(b, []byte("null")):
+		return nil
+
+	case b[0] == '[':
+		var list []flexString
+		if err := json.Unmarshal(b, &list); err != nil {
+			return err
+		}
+		if len(list) > 0 {
+			*s = list[0]
+		}
+		return nil
+
+	case b[0] == '{':
+		var link struct {
+			Href string `json:"href"`
+		}
+		if err := json.Unmarshal(b, &link); err != nil {
+			return err
+		}
+		*s = flexString(link.Href)
+		return nil
+
+	default:
+		return json.Unmarshal(b, (*string)(s))
+	}
+}
+
+// flexStrings is a JSON-LD property that may
+// be given as either one string or an array.
+type flexStrings []string
+
+func (s *flexStrings) UnmarshalJSON(b []byte) error {
+	var list flexList[flexString]
+	if err := json.Unmarshal(b, &list); err != nil {
+		return err
+	}
+
+	*s = make([]string, 0, len(list))
+	for _, str := range list {
+		*s = append(*s, string(str))
+	}
+
+	return nil
+}
+
+// flexList is a JSON-LD property that may be
+// given as either one object or an array.
+type flexList[T any] []T
+
+func (l *flexList[T]) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	switch {
+	case len(b) == 0 || bytes.Equal(b, []byte("null")):
+		return nil
+
+	case b[0] == '[':
+		return json.Unmarshal(b, (*[]T)(l))
+
+	default:
+		var t T
+		if err := json.Unmarshal(b, &t); err != nil {
+			return err
+		}
+		*l = []T{t}
+		return nil
+	}
+}
@@ -52,6 +52,74 @@ func (p *Processor) StoreLocalMedia(
 
 	// Immediately trigger write to storage.
 	attachment, err := processing.Load(ctx)
+	return loadedLocalMedia(attachment, err)
+}
+
+// StoreLocalMediaAsync is as StoreLocalMedia(), except that if the
+// media needs transcoding, it returns early with the attachment in
+// its still-processing state, leaving processing to finish in the
+// background. Callers can then poll the attachment in the database.
+func (p *Processor) StoreLocalMediaAsync(
+	ctx context.Context,
+	accountID string,
+	data media.DataFunc,
+	info media.AdditionalMediaInfo,
+) (
+	*gtsmodel.MediaAttachment,
+	gtserror.WithCode,
+) {
+	// Create a new processing media attachment.
+	processing, err := p.media.CreateMedia(ctx,
+		accountID,
+		data,
+		info,
+	)
+	if err != nil {
+		err := gtserror.Newf("error creating media: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	type loaded struct {
+		attachment *gtsmodel.MediaAttachment
+		err        error
+	}
+
+	// Buffered so the worker
+	// never blocks on sending.
+	done := make(chan loaded, 1)
+
+	// Trigger write to storage in the background,
+	// as this may outlive the request if transcoding.
+	p.state.Workers.Processing.Queue.Push(func(ctx context.Context) {
+		attachment, err := processing.Load(ctx)
+		done <- loaded{attachment, err}
+	})
+
+	select {
+	case l := <-done:
+		return loadedLocalMedia(l.attachment, l.err)
+
+	case <-processing.Transcoding():
+		// Fetch attachment as marked processing in database.
+		attachment, err := p.state.DB.GetAttachmentByID(ctx, processing.ID())
+		if err != nil {
+			err := gtserror.Newf("error getting media: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		return attachment, nil
+
+	case <-ctx.Done():
+		err := gtserror.Newf("error processing media: %w", ctx.Err())
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+}
+
+// loadedLocalMedia checks the results of loading local
+// processing media, returning appropriate error responses.
+func loadedLocalMedia(attachment *gtsmodel.MediaAttachment, err error) (
+	*gtsmodel.MediaAttachment,
+	gtserror.WithCode,
+) {
 	switch {
 	case gtserror.LimitReached(err):
 		limit := config.GetMediaLocalMaxSize()
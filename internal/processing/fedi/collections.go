@@ -443,3 +443,31 @@ func (p *Processor) FeaturedCollectionGet(ctx context.Context, requestedUser str
 
 	return data, nil
 }
+
+// FeaturedTagsCollectionGet returns an ordered collection of the requested username's featured hashtags.
+// The returned collection have an `orderedItems` property which contains an ordered list of Hashtags.
+func (p *Processor) FeaturedTagsCollectionGet(ctx context.Context, requestedUser string) (interface{}, gtserror.WithCode) {
+	// Authenticate incoming request, getting related accounts.
+	auth, errWithCode := p.authenticate(ctx, requestedUser)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+	receivingAcct := auth.receivingAcct
+
+	featuredTags, err := p.state.DB.GetAccountFeaturedTags(ctx, receivingAcct.ID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	collection, err := p.converter.FeaturedTagsToASCollection(ctx, receivingAcct.FeaturedTagsURI, featuredTags)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	data, err := ap.Serialize(collection)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return data, nil
+}
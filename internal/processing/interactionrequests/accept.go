@@ -68,7 +68,11 @@ func (p *Processor) Accept(
 	// and generate URIs for it.
 	req.AcceptedAt = time.Now()
 	req.ResponseURI = uris.GenerateURIForAccept(acct.Username, req.ID)
-	req.AuthorizationURI = uris.GenerateURIForAuthorization(acct.Username, req.ID)
+	if req.InteractionType != gtsmodel.InteractionQuote {
+		// There's no Authorization type for quotes
+		// (yet), they're approved by the Accept URI.
+		req.AuthorizationURI = uris.GenerateURIForAuthorization(acct.Username, req.ID)
+	}
 	if err := p.state.DB.UpdateInteractionRequest(
 		ctx,
 		req,
@@ -97,6 +101,11 @@ func (p *Processor) Accept(
 			return nil, errWithCode
 		}
 
+	case gtsmodel.InteractionQuote:
+		if errWithCode := p.acceptQuote(ctx, req); errWithCode != nil {
+			return nil, errWithCode
+		}
+
 	default:
 		err := gtserror.Newf("unknown interaction type for interaction request %s", reqID)
 		return nil, gtserror.NewErrorInternalError(err)
@@ -239,3 +248,43 @@ func (p *Processor) acceptAnnounce(
 
 	return nil
 }
+
+// Package-internal convenience
+// function to accept a quote.
+func (p *Processor) acceptQuote(
+	ctx context.Context,
+	req *gtsmodel.InteractionRequest,
+) gtserror.WithCode {
+	// If the Quote is missing, that means it's
+	// probably already been deleted by someone,
+	// so there's nothing to actually accept.
+	if req.Quote == nil {
+		err := gtserror.Newf("no Quote found for interaction request %s", req.ID)
+		return gtserror.NewErrorNotFound(err)
+	}
+
+	// Update the Quote.
+	req.Quote.QuotePendingApproval = util.Ptr(false)
+	req.Quote.QuoteApprovedByURI = req.ResponseURI
+	if err := p.state.DB.UpdateStatus(
+		ctx,
+		req.Quote,
+		"quote_pending_approval",
+		"quote_approved_by_uri",
+	); err != nil {
+		err := gtserror.Newf("db error updating status quote: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Send the accepted request off through the
+	// client API processor to handle side effects.
+	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ActivityQuoteRequest,
+		APActivityType: ap.ActivityAccept,
+		GTSModel:       req,
+		Origin:         req.TargetAccount,
+		Target:         req.InteractingAccount,
+	})
+
+	return nil
+}
@@ -0,0 +1,86 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package invites
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"time"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+)
+
+// InviteCreate creates a new invite link
+// as the requesting account, with the given
+// usage limits, expiry, and autofollow setting.
+//
+// Callers should have already checked whether
+// requester is permitted to create invites.
+func (p *Processor) InviteCreate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	form *apimodel.InviteCreateRequest,
+) (*apimodel.Invite, gtserror.WithCode) {
+	if !config.GetAccountsInvitesEnabled() {
+		const errText = "invites are not enabled on this instance"
+		return nil, gtserror.NewErrorForbidden(errors.New(errText), errText)
+	}
+
+	if form.MaxUses < 0 {
+		const errText = "max_uses must be 0 (unlimited) or greater"
+		return nil, gtserror.NewErrorBadRequest(errors.New(errText), errText)
+	}
+
+	if form.ExpiresIn < 0 {
+		const errText = "expires_in must be 0 (never) or greater"
+		return nil, gtserror.NewErrorBadRequest(errors.New(errText), errText)
+	}
+
+	invite := &gtsmodel.Invite{
+		ID:         id.NewULID(),
+		Code:       rand.Text(),
+		AccountID:  requester.ID,
+		Account:    requester,
+		MaxUses:    form.MaxUses,
+		Autofollow: util.Ptr(form.Autofollow),
+	}
+
+	if form.ExpiresIn > 0 {
+		expiresIn := time.Duration(form.ExpiresIn) * time.Second
+		invite.ExpiresAt = time.Now().Add(expiresIn)
+	}
+
+	if err := p.state.DB.PutInvite(ctx, invite); err != nil {
+		err := gtserror.Newf("db error putting invite: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiInvite, err := p.converter.InviteToAPIInvite(ctx, invite)
+	if err != nil {
+		err := gtserror.Newf("error converting invite: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiInvite, nil
+}
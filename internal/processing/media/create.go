@@ -33,7 +33,8 @@
 )
 
 // Create creates a new media attachment belonging to the given account, using the request form.
-func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form *apimodel.AttachmentRequest) (*apimodel.Attachment, gtserror.WithCode) {
+// If async is true and the media needs transcoding, the attachment is returned while still processing.
+func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form *apimodel.AttachmentRequest, async bool) (*apimodel.Attachment, gtserror.WithCode) {
 
 	// Get maximum supported local media size.
 	maxsz := config.GetMediaLocalMaxSize()
@@ -73,8 +74,15 @@ func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form
 	// Wrap multipart file reader to ensure is limited to max size.
 	rc, _, _ := iotools.UpdateReadCloserLimit(mpfile, maxszInt64)
 
+	// Transcoding media may take a while, so if
+	// enabled and allowed, return before it's done.
+	store := p.c.StoreLocalMedia
+	if async && config.GetMediaTranscodeEnabled() {
+		store = p.c.StoreLocalMediaAsync
+	}
+
 	// Create local media and write to instance storage.
-	attachment, errWithCode := p.c.StoreLocalMedia(ctx,
+	attachment, errWithCode := store(ctx,
 		account.ID,
 		func(ctx context.Context) (reader io.ReadCloser, err error) {
 			return rc, nil
@@ -95,5 +103,6 @@ func(ctx context.Context) (reader io.ReadCloser, err error) {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	p.setProcessingProgress(&apiAttachment, attachment)
 	return &apiAttachment, nil
 }
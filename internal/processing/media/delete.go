@@ -25,6 +25,7 @@
 
 	"code.superseriousbusiness.org/gotosocial/internal/db"
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/media"
 	"code.superseriousbusiness.org/gotosocial/internal/storage"
 )
 
@@ -42,17 +43,22 @@ func (p *Processor) Delete(ctx context.Context, mediaAttachmentID string) gtserr
 
 	errs := []string{}
 
-	// delete the thumbnail from storage
-	if attachment.Thumbnail.Path != "" {
-		if err := p.state.Storage.Delete(ctx, attachment.Thumbnail.Path); err != nil && !storage.IsNotFound(err) {
-			errs = append(errs, fmt.Sprintf("remove thumbnail at path %s: %s", attachment.Thumbnail.Path, err))
-		}
+	// get the thumbnail and file paths that can be deleted
+	// from storage, skipping any still shared with other media
+	paths, unlock, err := media.UnusedFiles(ctx, p.state, attachment.ID,
+		attachment.Thumbnail.Path,
+		attachment.File.Path,
+	)
+	if err != nil {
+		errs = append(errs, fmt.Sprintf("check files in use: %s", err))
+	} else {
+		defer unlock()
 	}
 
-	// delete the file from storage
-	if attachment.File.Path != "" {
-		if err := p.state.Storage.Delete(ctx, attachment.File.Path); err != nil && !storage.IsNotFound(err) {
-			errs = append(errs, fmt.Sprintf("remove file at path %s: %s", attachment.File.Path, err))
+	// delete the thumbnail and file from storage
+	for _, path := range paths {
+		if err := p.state.Storage.Delete(ctx, path); err != nil && !storage.IsNotFound(err) {
+			errs = append(errs, fmt.Sprintf("remove file at path %s: %s", path, err))
 		}
 	}
 
@@ -47,5 +47,19 @@ func (p *Processor) Get(ctx context.Context, account *gtsmodel.Account, mediaAtt
 		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error converting attachment: %s", err))
 	}
 
+	p.setProcessingProgress(&a, attachment)
 	return &a, nil
 }
+
+// setProcessingProgress sets the progress of processing
+// on API attachment, if media is still being transcoded.
+func (p *Processor) setProcessingProgress(apiAttachment *apimodel.Attachment, attachment *gtsmodel.MediaAttachment) {
+	if attachment.Processing == gtsmodel.ProcessingStatusProcessed {
+		// Nothing to show.
+		return
+	}
+
+	if progress, ok := p.mediaManager.TranscodeProgress(attachment.ID); ok {
+		apiAttachment.ProcessingProgress = &progress
+	}
+}
@@ -19,9 +19,17 @@
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"net/url"
+	"time"
 
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/oauth"
+	"code.superseriousbusiness.org/gotosocial/internal/uris"
 	"code.superseriousbusiness.org/oauth2/v4"
 )
 
@@ -40,16 +48,99 @@ func (p *Processor) OAuthValidateBearerToken(r *http.Request) (oauth2.TokenInfo,
 	return p.oauthServer.ValidationBearerToken(r)
 }
 
-func (p *Processor) OAuthRevokeAccessToken(
+func (p *Processor) OAuthRevokeToken(
 	ctx context.Context,
 	clientID string,
 	clientSecret string,
-	accessToken string,
+	token string,
 ) gtserror.WithCode {
-	return p.oauthServer.RevokeAccessToken(
+	return p.oauthServer.RevokeToken(
 		ctx,
 		clientID,
 		clientSecret,
-		accessToken,
+		token,
 	)
 }
+
+// OAuthDeviceAuthorization creates a new device authorization
+// for the client in the given form, returning the device code
+// and user code, and where the user should enter the user code.
+func (p *Processor) OAuthDeviceAuthorization(
+	ctx context.Context,
+	form *apimodel.DeviceAuthorizationRequest,
+) (*apimodel.DeviceAuthorization, gtserror.WithCode) {
+	deviceAuth, errWithCode := p.oauthServer.CreateDeviceAuthorization(
+		ctx,
+		form.ClientID,
+		form.ClientSecret,
+		form.Scope,
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	verificationURI := uris.GenerateURIForDevice()
+	return &apimodel.DeviceAuthorization{
+		DeviceCode:              deviceAuth.DeviceCode,
+		UserCode:                deviceAuth.UserCode,
+		VerificationURI:         verificationURI,
+		VerificationURIComplete: verificationURI + "?user_code=" + url.QueryEscape(deviceAuth.UserCode),
+		ExpiresIn:               int64(time.Until(deviceAuth.ExpiresAt) / time.Second),
+		Interval:                int64(oauth.DevicePollInterval / time.Second),
+	}, nil
+}
+
+// OAuthGetDeviceAuthorization returns the device authorization with
+// the given user code, provided it's not expired or already approved.
+func (p *Processor) OAuthGetDeviceAuthorization(
+	ctx context.Context,
+	userCode string,
+) (*gtsmodel.DeviceAuthorization, gtserror.WithCode) {
+	const help = "code is invalid, expired, or has already been used; " +
+		"check the code shown on your device, or start again on your device to get a new code"
+
+	userCode = oauth.NormalizeUserCode(userCode)
+	if userCode == "" {
+		err := errors.New("user code not valid")
+		return nil, gtserror.NewErrorNotFound(err, help)
+	}
+
+	deviceAuth, err := p.state.DB.GetDeviceAuthorizationByUserCode(ctx, userCode)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting device authorization: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if deviceAuth == nil || deviceAuth.IsExpired() || deviceAuth.IsApproved() {
+		err := errors.New("device authorization not found, expired, or already approved")
+		return nil, gtserror.NewErrorNotFound(err, help)
+	}
+
+	return deviceAuth, nil
+}
+
+// OAuthApproveDeviceAuthorization marks the device authorization
+// with the given user code as approved by the given user, so that
+// the client polling with the device code will receive a token.
+func (p *Processor) OAuthApproveDeviceAuthorization(
+	ctx context.Context,
+	userCode string,
+	user *gtsmodel.User,
+) gtserror.WithCode {
+	deviceAuth, errWithCode := p.OAuthGetDeviceAuthorization(ctx, userCode)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	deviceAuth.UserID = user.ID
+	if err := p.state.DB.UpdateDeviceAuthorization(
+		ctx,
+		deviceAuth,
+		"user_id",
+	); err != nil {
+		err := gtserror.Newf("db error updating device authorization: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
@@ -18,6 +18,7 @@
 package processing
 
 import (
+	"code.superseriousbusiness.org/gotosocial/internal/cards"
 	"code.superseriousbusiness.org/gotosocial/internal/cleaner"
 	"code.superseriousbusiness.org/gotosocial/internal/email"
 	"code.superseriousbusiness.org/gotosocial/internal/federation"
@@ -31,7 +32,9 @@
 	"code.superseriousbusiness.org/gotosocial/internal/processing/account"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/admin"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/advancedmigrations"
+	"code.superseriousbusiness.org/gotosocial/internal/processing/announcements"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/application"
+	"code.superseriousbusiness.org/gotosocial/internal/processing/archive"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/common"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/conversations"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/fedi"
@@ -39,6 +42,7 @@
 	filtersv1 "code.superseriousbusiness.org/gotosocial/internal/processing/filters/v1"
 	filtersv2 "code.superseriousbusiness.org/gotosocial/internal/processing/filters/v2"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/interactionrequests"
+	"code.superseriousbusiness.org/gotosocial/internal/processing/invites"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/list"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/markers"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/media"
@@ -48,8 +52,10 @@
 	"code.superseriousbusiness.org/gotosocial/internal/processing/search"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/status"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/stream"
+	"code.superseriousbusiness.org/gotosocial/internal/processing/suggestions"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/tags"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/timeline"
+	"code.superseriousbusiness.org/gotosocial/internal/processing/trends"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/user"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/workers"
 	"code.superseriousbusiness.org/gotosocial/internal/state"
@@ -85,12 +91,15 @@ type Processor struct {
 	account             account.Processor
 	admin               admin.Processor
 	advancedmigrations  advancedmigrations.Processor
+	announcements       announcements.Processor
 	application         application.Processor
+	archive             archive.Processor
 	conversations       conversations.Processor
 	fedi                fedi.Processor
 	filtersv1           filtersv1.Processor
 	filtersv2           filtersv2.Processor
 	interactionRequests interactionrequests.Processor
+	invites             invites.Processor
 	list                list.Processor
 	markers             markers.Processor
 	media               media.Processor
@@ -100,8 +109,10 @@ type Processor struct {
 	search              search.Processor
 	status              status.Processor
 	stream              stream.Processor
+	suggestions         suggestions.Processor
 	tags                tags.Processor
 	timeline            timeline.Processor
+	trends              trends.Processor
 	user                user.Processor
 	workers             workers.Processor
 }
@@ -118,10 +129,18 @@ func (p *Processor) AdvancedMigrations() *advancedmigrations.Processor {
 	return &p.advancedmigrations
 }
 
+func (p *Processor) Announcements() *announcements.Processor {
+	return &p.announcements
+}
+
 func (p *Processor) Application() *application.Processor {
 	return &p.application
 }
 
+func (p *Processor) Archive() *archive.Processor {
+	return &p.archive
+}
+
 func (p *Processor) Conversations() *conversations.Processor {
 	return &p.conversations
 }
@@ -142,6 +161,10 @@ func (p *Processor) InteractionRequests() *interactionrequests.Processor {
 	return &p.interactionRequests
 }
 
+func (p *Processor) Invites() *invites.Processor {
+	return &p.invites
+}
+
 func (p *Processor) List() *list.Processor {
 	return &p.list
 }
@@ -178,6 +201,10 @@ func (p *Processor) Stream() *stream.Processor {
 	return &p.stream
 }
 
+func (p *Processor) Suggestions() *suggestions.Processor {
+	return &p.suggestions
+}
+
 func (p *Processor) Tags() *tags.Processor {
 	return &p.tags
 }
@@ -186,6 +213,10 @@ func (p *Processor) Timeline() *timeline.Processor {
 	return &p.timeline
 }
 
+func (p *Processor) Trends() *trends.Processor {
+	return &p.trends
+}
+
 func (p *Processor) User() *user.Processor {
 	return &p.user
 }
@@ -234,22 +265,27 @@ func NewProcessor(
 	// processors + pin them to this struct.
 	processor.account = account.New(&common, state, converter, mediaManager, federator, visFilter, statusFilter, parseMentionFunc)
 	processor.admin = admin.New(&common, state, cleaner, subscriptions, federator, converter, mediaManager, federator.TransportController(), emailSender)
+	processor.announcements = announcements.New(state, converter, &processor.stream, parseMentionFunc)
 	processor.application = application.New(state, converter)
 	processor.conversations = conversations.New(state, converter, visFilter, muteFilter, statusFilter)
 	processor.fedi = fedi.New(state, &common, converter, federator, visFilter)
 	processor.filtersv1 = filtersv1.New(state, converter, filterCommon)
 	processor.filtersv2 = filtersv2.New(state, converter, filterCommon)
 	processor.interactionRequests = interactionrequests.New(&common, state, converter)
+	processor.invites = invites.New(state, converter)
 	processor.list = list.New(state, converter)
 	processor.markers = markers.New(state, converter)
 	processor.polls = polls.New(&common, state, converter)
 	processor.push = push.New(state, converter)
 	processor.report = report.New(state, converter)
+	processor.suggestions = suggestions.New(state, converter, visFilter, muteFilter)
 	processor.tags = tags.New(state, converter)
 	processor.timeline = timeline.New(state, converter, visFilter, muteFilter, statusFilter)
+	processor.trends = trends.New(state, converter, visFilter)
 	processor.search = search.New(state, federator, converter, visFilter)
 	processor.status = status.New(state, &common, &processor.polls, &processor.interactionRequests, federator, converter, visFilter, intFilter, parseMentionFunc)
 	processor.user = user.New(state, converter, oauthServer, emailSender)
+	processor.archive = archive.New(&common, state, converter, &processor.account, &processor.status, &processor.media)
 
 	// The advanced migrations processor sequences advanced migrations from all other processors.
 	processor.advancedmigrations = advancedmigrations.New(&processor.conversations)
@@ -271,6 +307,7 @@ func NewProcessor(
 		&processor.media,
 		&processor.stream,
 		&processor.conversations,
+		cards.New(state, federator.TransportController(), mediaManager),
 	)
 
 	return processor
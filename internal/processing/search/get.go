@@ -24,6 +24,7 @@
 	"net/mail"
 	"net/url"
 	"strings"
+	"time"
 
 	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
 	"code.superseriousbusiness.org/gotosocial/internal/config"
@@ -31,6 +32,7 @@
 	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
 	"code.superseriousbusiness.org/gotosocial/internal/log"
 	"code.superseriousbusiness.org/gotosocial/internal/text"
 	"code.superseriousbusiness.org/gotosocial/internal/util"
@@ -119,21 +121,6 @@ func (p *Processor) Get(
 		}...).
 		Debugf("beginning search")
 
-	// todo: Currently we don't support offset for paging;
-	// a caller can page using maxID or minID, but if they
-	// supply an offset greater than 0, return nothing as
-	// though there were no additional results.
-	if req.Offset > 0 {
-		return p.packageSearchResult(
-			ctx,
-			account,
-			nil, nil, nil, // No results.
-			req.APIv1,
-			includeInstanceAccounts,
-			includeBlockedAccounts,
-		)
-	}
-
 	var (
 		foundStatuses = make([]*gtsmodel.Status, 0, limit)
 		foundAccounts = make([]*gtsmodel.Account, 0, limit)
@@ -221,6 +208,20 @@ func (p *Processor) Get(
 		// caller wants to include blocked accounts too.
 		includeBlockedAccounts = true
 
+		// A URI search only ever has one page
+		// of results, so there's nothing more
+		// to return past the first one.
+		if offset > 0 {
+			return p.packageSearchResult(
+				ctx,
+				account,
+				nil, nil, nil, // No results.
+				req.APIv1,
+				includeInstanceAccounts,
+				includeBlockedAccounts,
+			)
+		}
+
 		if err := p.byURI(
 			ctx,
 			account,
@@ -375,6 +376,13 @@ func (p *Processor) accountsByUsernameDomain(
 	// Domain and username were both set.
 	// Caller is likely trying to search for an exact
 	// match, from either a remote instance or local.
+	//
+	// An exact match is only ever one result, so
+	// there's nothing more to return past page one.
+	if offset > 0 {
+		return nil
+	}
+
 	foundAccount, err := p.accountByUsernameDomain(
 		ctx,
 		requestingAccount,
@@ -704,6 +712,15 @@ func (p *Processor) hashtag(
 	// Query looks like a hashtag, and we're allowed
 	// to search for hashtags.
 	//
+	// todo: Currently we don't support offset for
+	// paging tags; a caller can page using maxID or
+	// minID, but if they supply an offset greater
+	// than 0, return nothing as though there were
+	// no additional results.
+	if offset > 0 {
+		return false, nil
+	}
+
 	// Ensure this is a valid tag for our instance.
 	normalized, ok := text.NormalizeHashtag(query)
 	if !ok {
@@ -853,12 +870,18 @@ func (p *Processor) statusesByText(
 	if fromAccountID == "" {
 		fromAccountID = parsed.fromAccountID
 	}
+	// If a `before:` operator was given, only return statuses older than
+	// that, taking whichever is the lower bound of that and maxID.
+	if parsed.maxID != "" && (maxID == "" || parsed.maxID < maxID) {
+		maxID = parsed.maxID
+	}
 
 	statuses, err := p.state.DB.SearchForStatuses(
 		ctx,
 		requestingAccountID,
 		query,
 		fromAccountID,
+		parsed.hasMedia,
 		maxID,
 		minID,
 		limit,
@@ -881,6 +904,10 @@ type parsedQuery struct {
 	query string
 	// fromAccountID is the account from a successfully resolved `from:` operator, if present.
 	fromAccountID string
+	// hasMedia is true if the `has:media` operator is present.
+	hasMedia bool
+	// maxID is derived from the date given by a `before:` operator, if present.
+	maxID string
 }
 
 // parseQuery parses query text and handles any search operator terms present.
@@ -894,6 +921,16 @@ func (p *Processor) parseQuery(ctx context.Context, query string) (parsed parsed
 			if err != nil {
 				return
 			}
+		} else if arg, hasPrefix := strings.CutPrefix(queryPart, "has:"); hasPrefix {
+			parsed.hasMedia, err = parseHasOperatorArg(arg)
+			if err != nil {
+				return
+			}
+		} else if arg, hasPrefix := strings.CutPrefix(queryPart, "before:"); hasPrefix {
+			parsed.maxID, err = parseBeforeOperatorArg(arg)
+			if err != nil {
+				return
+			}
 		} else {
 			nonOperatorQueryParts = append(nonOperatorQueryParts, queryPart)
 		}
@@ -931,3 +968,42 @@ func (p *Processor) parseFromOperatorArg(ctx context.Context, namestring string)
 
 	return account.ID, nil
 }
+
+// parseHasOperatorArg checks the has: operator's argument,
+// and returns true if it's a supported kind of attachment.
+// Currently only `has:media` is supported.
+func parseHasOperatorArg(arg string) (bool, error) {
+	switch strings.ToLower(arg) {
+	case "media":
+		return true, nil
+	case "":
+		return false, gtserror.New(
+			"the 'has:' search operator requires an argument, but it wasn't provided",
+		)
+	default:
+		return false, gtserror.Newf(
+			"the 'has:' search operator doesn't support %q, only 'media'",
+			arg,
+		)
+	}
+}
+
+// parseBeforeOperatorArg attempts to parse the before: operator's argument
+// as a YYYY-MM-DD date, and returns an ID corresponding to the start of that day (UTC).
+func parseBeforeOperatorArg(date string) (string, error) {
+	if date == "" {
+		return "", gtserror.New(
+			"the 'before:' search operator requires a date, but it wasn't provided",
+		)
+	}
+
+	t, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return "", gtserror.Newf(
+			"the 'before:' search operator couldn't parse its argument as a YYYY-MM-DD date: %w",
+			err,
+		)
+	}
+
+	return id.NewULIDFromTime(t), nil
+}
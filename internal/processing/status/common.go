@@ -321,6 +321,12 @@ func (p *Processor) processMedia(
 			return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
 		}
 
+		// Check media has finished processing (e.g. transcoding).
+		if media.Processing != gtsmodel.ProcessingStatusProcessed {
+			text := fmt.Sprintf("media has not finished processing: %s", id)
+			return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+
 		// Check media description chars within range,
 		// this needs to be done here as lots of clients
 		// only update media description on status post.
@@ -196,7 +196,8 @@ func (p *Processor) Create(
 
 		// Assume not pending approval; this may
 		// change when permissivity is checked.
-		PendingApproval: util.Ptr(false),
+		PendingApproval:      util.Ptr(false),
+		QuotePendingApproval: util.Ptr(false),
 	}
 
 	// Only store ContentWarningText if the parsed
@@ -227,6 +228,16 @@ func (p *Processor) Create(
 		return nil, errWithCode
 	}
 
+	// Check + attach quoted status.
+	if errWithCode := p.processQuote(ctx,
+		requester,
+		status,
+		form.QuotedStatusID,
+		backfill,
+	); errWithCode != nil {
+		return nil, errWithCode
+	}
+
 	// Process the incoming created status visibility.
 	if errWithCode := processVisibility(form, requester.Settings.Privacy, status); errWithCode != nil {
 		return nil, errWithCode
@@ -453,6 +464,78 @@ func (p *Processor) processInReplyTo(
 	return nil
 }
 
+func (p *Processor) processQuote(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	status *gtsmodel.Status,
+	quotedStatusID string,
+	backfill bool,
+) gtserror.WithCode {
+	if quotedStatusID == "" {
+		// Not a quote.
+		// Nothing to do.
+		return nil
+	}
+
+	// Fetch target quoted status (checking visibility).
+	quoteOf, errWithCode := p.c.GetVisibleTargetStatus(ctx,
+		requester,
+		quotedStatusID,
+		nil,
+	)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	// If this is a boost, unwrap it to get source status.
+	quoteOf, errWithCode = p.c.UnwrapIfBoost(ctx,
+		requester,
+		quoteOf,
+	)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	// Ensure valid quote target for requester.
+	policyResult, err := p.intFilter.StatusQuoteable(ctx,
+		requester,
+		quoteOf,
+	)
+	if err != nil {
+		err := gtserror.Newf("error seeing if status %s is quoteable: %w", status.ID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if policyResult.Forbidden() {
+		const errText = "you do not have permission to quote this status"
+		err := gtserror.New(errText)
+		return gtserror.NewErrorForbidden(err, errText)
+	}
+
+	// When backfilling, only self-quotes are allowed.
+	if backfill && requester.ID != quoteOf.AccountID {
+		const errText = "quotes of others can't be backfilled"
+		err := gtserror.New(errText)
+		return gtserror.NewErrorForbidden(err, errText)
+	}
+
+	// If manual approval is required, the quote will
+	// be pending until the quotee Accepts it. Unlike
+	// replies, quotes matched on a collection aren't
+	// preapproved, they're just approved, as there's
+	// no authorization object to attach to them.
+	status.QuotePendingApproval = util.Ptr(policyResult.ManualApproval())
+
+	// Set status fields from quoteOf.
+	status.QuoteOfID = quoteOf.ID
+	status.QuoteOf = quoteOf
+	status.QuoteOfURI = quoteOf.URI
+	status.QuoteOfAccountID = quoteOf.AccountID
+	status.QuoteOfAccount = quoteOf.Account
+
+	return nil
+}
+
 func processVisibility(
 	form *apimodel.StatusCreateRequest,
 	accountDefaultVis gtsmodel.Visibility,
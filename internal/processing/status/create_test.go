@@ -150,6 +150,39 @@ func (suite *StatusCreateTestSuite) TestProcessMediaDescriptionTooShort() {
 	suite.Nil(apiStatus)
 }
 
+func (suite *StatusCreateTestSuite) TestProcessMediaStillProcessing() {
+	ctx := suite.T().Context()
+
+	// Mark the attachment as still being
+	// processed, as if it were transcoding.
+	attachment := suite.testAttachments["local_account_1_unattached_1"]
+	attachment.Processing = gtsmodel.ProcessingStatusProcessing
+	if err := suite.db.UpdateAttachment(ctx, attachment, "processing"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	statusCreateForm := &apimodel.StatusCreateRequest{
+		Status:      "poopoo peepee",
+		MediaIDs:    []string{attachment.ID},
+		Poll:        nil,
+		InReplyToID: "",
+		Sensitive:   false,
+		SpoilerText: "",
+		Visibility:  apimodel.VisibilityPublic,
+		LocalOnly:   util.Ptr(false),
+		ScheduledAt: nil,
+		Language:    "en",
+		ContentType: apimodel.StatusContentTypePlain,
+	}
+
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm, nil)
+	suite.EqualError(err, "media has not finished processing: "+attachment.ID)
+	suite.Nil(apiStatus)
+}
+
 func (suite *StatusCreateTestSuite) TestProcessLanguageWithScriptPart() {
 	ctx := suite.T().Context()
 
@@ -272,6 +305,100 @@ func (suite *StatusCreateTestSuite) TestProcessInvalidVisibility() {
 	suite.Equal("Unprocessable Entity: processVisibility: invalid visibility", errWithCode.Safe())
 }
 
+func (suite *StatusCreateTestSuite) TestProcessQuotePendingApproval() {
+	ctx := suite.T().Context()
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+	quoteOf := suite.testStatuses["admin_account_status_1"]
+
+	// Quote a public status by someone
+	// else; the default quote policy for
+	// public statuses requires approval.
+	statusCreateForm := &apimodel.StatusCreateRequest{
+		Status:         "look at this!",
+		QuotedStatusID: quoteOf.ID,
+		Visibility:     apimodel.VisibilityPublic,
+		LocalOnly:      util.Ptr(false),
+		Language:       "en",
+		ContentType:    apimodel.StatusContentTypePlain,
+	}
+
+	apiStatusAny, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm, nil)
+	suite.NoError(errWithCode)
+	apiStatus := apiStatusAny.(*apimodel.Status)
+
+	// Quote should be pending, and
+	// quoted status not rendered yet.
+	if suite.NotNil(apiStatus.Quote) {
+		suite.Equal("pending", apiStatus.Quote.State)
+		suite.Equal(quoteOf.ID, *apiStatus.Quote.QuotedStatusID)
+		suite.Nil(apiStatus.Quote.QuotedStatus)
+	}
+
+	dbStatus, err := suite.state.DB.GetStatusByID(ctx, apiStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(quoteOf.ID, dbStatus.QuoteOfID)
+	suite.Equal(quoteOf.URI, dbStatus.QuoteOfURI)
+	suite.Equal(quoteOf.AccountID, dbStatus.QuoteOfAccountID)
+	suite.True(*dbStatus.QuotePendingApproval)
+}
+
+func (suite *StatusCreateTestSuite) TestProcessQuoteSelf() {
+	ctx := suite.T().Context()
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+	quoteOf := suite.testStatuses["local_account_1_status_1"]
+
+	// Quote own status,
+	// no approval needed.
+	statusCreateForm := &apimodel.StatusCreateRequest{
+		Status:         "as i was saying",
+		QuotedStatusID: quoteOf.ID,
+		Visibility:     apimodel.VisibilityPublic,
+		LocalOnly:      util.Ptr(false),
+		Language:       "en",
+		ContentType:    apimodel.StatusContentTypePlain,
+	}
+
+	apiStatusAny, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm, nil)
+	suite.NoError(errWithCode)
+	apiStatus := apiStatusAny.(*apimodel.Status)
+
+	if suite.NotNil(apiStatus.Quote) {
+		suite.Equal("accepted", apiStatus.Quote.State)
+		if suite.NotNil(apiStatus.Quote.QuotedStatus) {
+			suite.Equal(quoteOf.ID, apiStatus.Quote.QuotedStatus.ID)
+			suite.Equal(creatingAccount.ID, apiStatus.Quote.QuotedStatus.Account.ID)
+		}
+	}
+}
+
+func (suite *StatusCreateTestSuite) TestProcessQuoteForbidden() {
+	ctx := suite.T().Context()
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+	quoteOf := suite.testStatuses["local_account_2_status_7"]
+
+	// Quote a followers-only status by someone
+	// else; the default quote policy for these
+	// only allows the author to quote.
+	statusCreateForm := &apimodel.StatusCreateRequest{
+		Status:         "spilling the tea",
+		QuotedStatusID: quoteOf.ID,
+		Visibility:     apimodel.VisibilityPublic,
+		LocalOnly:      util.Ptr(false),
+		Language:       "en",
+		ContentType:    apimodel.StatusContentTypePlain,
+	}
+
+	apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm, nil)
+	suite.Nil(apiStatus)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+	suite.Equal("Forbidden: you do not have permission to quote this status", errWithCode.Safe())
+}
+
 func TestStatusCreateTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusCreateTestSuite))
 }
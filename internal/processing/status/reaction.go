@@ -0,0 +1,277 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"code.superseriousbusiness.org/gotosocial/internal/ap"
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/messages"
+	"code.superseriousbusiness.org/gotosocial/internal/regexes"
+	"code.superseriousbusiness.org/gotosocial/internal/uris"
+	"code.superseriousbusiness.org/gotosocial/internal/validate"
+)
+
+// ReactionsGet returns the reactions to the given status, grouped
+// by name, and filtered according to blocks. If name is set, only
+// reactions with the given name will be returned.
+func (p *Processor) ReactionsGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	targetStatusID string,
+	name string,
+) ([]*apimodel.EmojiReaction, gtserror.WithCode) {
+	targetStatus, errWithCode := p.c.GetVisibleTargetStatus(ctx,
+		requester,
+		targetStatusID,
+		nil, // default freshness
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	reactions, err := p.state.DB.GetStatusReactions(ctx, targetStatus.ID)
+	if err != nil {
+		err := gtserror.Newf("db error getting status reactions: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	name = trimReactionName(name)
+
+	// Only show the requester reactions with the requested
+	// name (if set), from accounts that they don't block,
+	// and which don't block them.
+	filtered := make([]*gtsmodel.StatusReaction, 0, len(reactions))
+	for _, reaction := range reactions {
+		if name != "" && reaction.Name != name {
+			continue
+		}
+
+		blocked, err := p.state.DB.IsEitherBlocked(ctx, requester.ID, reaction.AccountID)
+		if err != nil {
+			err := gtserror.Newf("error checking blocks: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if blocked {
+			continue
+		}
+
+		filtered = append(filtered, reaction)
+	}
+
+	apiReactions, err := p.converter.StatusReactionsToAPIEmojiReactions(ctx, filtered, requester.ID)
+	if err != nil {
+		err := gtserror.Newf("error converting status reactions: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiReactions, nil
+}
+
+// ReactionAdd adds a reaction with the given name, which must be
+// either a unicode emoji or the shortcode of an enabled local emoji,
+// from the requesting account to the given status. Adding a reaction
+// that already exists is a no-op.
+func (p *Processor) ReactionAdd(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	targetStatusID string,
+	name string,
+) (*apimodel.Status, gtserror.WithCode) {
+	status, existing, errWithCode := p.getReactableStatus(ctx, requester, targetStatusID, name)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if existing != nil {
+		// Already reacted, nothing to do.
+		return p.c.GetAPIStatus(ctx, requester, status)
+	}
+
+	name = trimReactionName(name)
+	if err := validate.EmojiReaction(name); err != nil {
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+	}
+
+	// Ensure valid reaction target for requester.
+	// Reactions are treated in the same way as
+	// faves, but can't be pending approval.
+	policyResult, err := p.intFilter.StatusLikeable(ctx,
+		requester,
+		status,
+	)
+	if err != nil {
+		err := gtserror.Newf("error seeing if status %s is likeable: %w", status.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if policyResult.Forbidden() || policyResult.ManualApproval() {
+		const errText = "you do not have permission to react to this status"
+		err := gtserror.New(errText)
+		return nil, gtserror.NewErrorForbidden(err, errText)
+	}
+
+	reactionID := id.NewULID()
+	reaction := &gtsmodel.StatusReaction{
+		ID:              reactionID,
+		AccountID:       requester.ID,
+		Account:         requester,
+		TargetAccountID: status.AccountID,
+		TargetAccount:   status.Account,
+		StatusID:        status.ID,
+		Status:          status,
+		Name:            name,
+		URI:             uris.GenerateURIForEmojiReact(requester.Username, reactionID),
+	}
+
+	if regexes.EmojiValidator.MatchString(name) {
+		// Custom emoji shortcode, ensure it
+		// refers to an enabled local emoji.
+		emoji, err := p.state.DB.GetEmojiByShortcodeDomain(ctx, name, "")
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting emoji %s: %w", name, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if emoji == nil || *emoji.Disabled {
+			text := fmt.Sprintf("custom emoji %s not found", name)
+			return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+
+		reaction.EmojiID = emoji.ID
+		reaction.Emoji = emoji
+	}
+
+	if err := p.state.DB.PutStatusReaction(ctx, reaction); err != nil {
+		err := gtserror.Newf("db error putting reaction: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Queue remaining reaction side effects
+	// (send out reaction, notify, etc).
+	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ActivityEmojiReact,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       reaction,
+		Origin:         requester,
+		Target:         status.Account,
+	})
+
+	return p.c.GetAPIStatus(ctx, requester, status)
+}
+
+// ReactionRemove removes the reaction with the given name from the requesting
+// account to the given status (no-op if the reaction doesn't exist).
+func (p *Processor) ReactionRemove(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	targetStatusID string,
+	name string,
+) (*apimodel.Status, gtserror.WithCode) {
+	status, existing, errWithCode := p.getReactableStatus(ctx, requester, targetStatusID, name)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if existing == nil {
+		// Status isn't reacted to.
+		return p.c.GetAPIStatus(ctx, requester, status)
+	}
+
+	// We have a reaction to remove.
+	if err := p.state.DB.DeleteStatusReactionByID(ctx, existing.ID); err != nil {
+		err := gtserror.Newf("db error removing reaction: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Process remove reaction side effects.
+	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ActivityEmojiReact,
+		APActivityType: ap.ActivityUndo,
+		GTSModel:       existing,
+		Origin:         requester,
+		Target:         status.Account,
+	})
+
+	return p.c.GetAPIStatus(ctx, requester, status)
+}
+
+func (p *Processor) getReactableStatus(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	targetID string,
+	name string,
+) (
+	*gtsmodel.Status,
+	*gtsmodel.StatusReaction,
+	gtserror.WithCode,
+) {
+	// Get target status and ensure it's not a boost.
+	target, errWithCode := p.c.GetVisibleTargetStatus(
+		ctx,
+		requester,
+		targetID,
+		nil, // default freshness
+	)
+	if errWithCode != nil {
+		return nil, nil, errWithCode
+	}
+
+	target, errWithCode = p.c.UnwrapIfBoost(
+		ctx,
+		requester,
+		target,
+	)
+	if errWithCode != nil {
+		return nil, nil, errWithCode
+	}
+
+	reaction, err := p.state.DB.GetStatusReaction(ctx,
+		requester.ID,
+		target.ID,
+		trimReactionName(name),
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("error checking existing reaction: %w", err)
+		return nil, nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return target, reaction, nil
+}
+
+// trimReactionName trims whitespace and any
+// wrapping colons from the given reaction
+// name, ie., ":blobcat:" becomes "blobcat".
+func trimReactionName(name string) string {
+	name = strings.TrimSpace(name)
+	if len(name) > 2 &&
+		strings.HasPrefix(name, ":") &&
+		strings.HasSuffix(name, ":") {
+		name = name[1 : len(name)-1]
+	}
+	return name
+}
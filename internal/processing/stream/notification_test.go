@@ -44,6 +44,7 @@ func (suite *NotificationTestSuite) TestStreamNotification() {
 	notification := &apimodel.Notification{
 		ID:        "01FH57SJCMDWQGEAJ0X08CE3WV",
 		Type:      "follow",
+		GroupKey:  "follow-20211004",
 		CreatedAt: "2021-10-04T08:52:36.000Z",
 		Account:   followAccountAPIModel,
 	}
@@ -59,6 +60,7 @@ func (suite *NotificationTestSuite) TestStreamNotification() {
 	suite.Equal(`{
   "id": "01FH57SJCMDWQGEAJ0X08CE3WV",
   "type": "follow",
+  "group_key": "follow-20211004",
   "created_at": "2021-10-04T08:52:36.000Z",
   "account": {
     "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
@@ -67,6 +69,7 @@ func (suite *NotificationTestSuite) TestStreamNotification() {
     "display_name": "big gerald",
     "locked": false,
     "discoverable": true,
+    "indexable": false,
     "bot": false,
     "created_at": "2021-09-26T10:52:36.000Z",
     "note": "i post about like, i dunno, stuff, or whatever!!!!",
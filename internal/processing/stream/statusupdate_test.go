@@ -78,6 +78,7 @@ func (suite *StatusUpdateTestSuite) TestStreamNotification() {
     "display_name": "big gerald",
     "locked": false,
     "discoverable": true,
+    "indexable": false,
     "bot": false,
     "created_at": "2021-09-26T10:52:36.000Z",
     "note": "i post about like, i dunno, stuff, or whatever!!!!",
@@ -167,6 +168,22 @@ func (suite *StatusUpdateTestSuite) TestStreamNotification() {
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "automatic_approval": [
+        "author",
+        "me"
+      ],
+      "manual_approval": [
+        "public"
+      ],
+      "always": [
+        "author",
+        "me"
+      ],
+      "with_approval": [
+        "public"
+      ]
     }
   }
 }`, dst.String())
@@ -0,0 +1,265 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package suggestions
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+)
+
+// interactionsWindow is the period, prior to now,
+// in which interactions with followed accounts are
+// taken into account when gathering suggestions.
+const interactionsWindow = 30 * 24 * time.Hour
+
+// candidate is one account that may be
+// suggested, along with why it was found.
+type candidate struct {
+	accountID string
+	sources   []gtsmodel.SuggestionSource
+}
+
+// SuggestionsGet returns up to limit accounts suggested for requester to follow.
+//
+// Suggestions are gathered from staff picks, accounts followed by accounts that
+// requester follows, and accounts that often interact with accounts that requester
+// follows. Accounts that are not discoverable, that requester already follows or
+// has requested to follow, that either account blocks, that requester mutes, or
+// that requester has dismissed from suggestions, are never suggested.
+func (p *Processor) SuggestionsGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	limit int,
+) ([]*apimodel.Suggestion, gtserror.WithCode) {
+	candidates, err := p.candidates(ctx, requester, limit)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	suggestions := make([]*apimodel.Suggestion, 0, min(limit, len(candidates)))
+	for _, c := range candidates {
+		if len(suggestions) >= limit {
+			break
+		}
+
+		account, err := p.suggestable(ctx, requester, c.accountID)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if account == nil {
+			// Not suggestable.
+			continue
+		}
+
+		apiAccount, err := p.converter.AccountToAPIAccountPublic(ctx, account)
+		if err != nil {
+			log.Errorf(ctx, "error converting account %s: %v", account.ID, err)
+			continue
+		}
+
+		suggestion := &apimodel.Suggestion{
+			Source:  "global",
+			Sources: make([]string, len(c.sources)),
+			Account: apiAccount,
+		}
+
+		for i, source := range c.sources {
+			if source == gtsmodel.SuggestionSourceStaffPick {
+				suggestion.Source = "staff"
+			}
+			suggestion.Sources[i] = source.String()
+		}
+
+		suggestions = append(suggestions, suggestion)
+	}
+
+	return suggestions, nil
+}
+
+// candidates gathers possible suggestions for requester from
+// all sources, ordered with staff picks first, followed by
+// those found by the most sources, and otherwise in order of
+// relevance as returned by each source.
+func (p *Processor) candidates(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	limit int,
+) ([]*candidate, error) {
+	// Fetch more than necessary from each
+	// source, as some may be filtered out.
+	fetch := limit * 2
+
+	picks, err := p.state.DB.GetStaffPicks(ctx)
+	if err != nil {
+		return nil, gtserror.Newf("db error getting staff picks: %w", err)
+	}
+
+	friendsOfFriends, err := p.state.DB.GetFriendsOfFriendsSuggestionIDs(ctx,
+		requester.ID,
+		fetch,
+	)
+	if err != nil {
+		return nil, gtserror.Newf("db error getting friends of friends: %w", err)
+	}
+
+	interactions, err := p.state.DB.GetInteractionsSuggestionIDs(ctx,
+		requester.ID,
+		time.Now().Add(-interactionsWindow),
+		fetch,
+	)
+	if err != nil {
+		return nil, gtserror.Newf("db error getting interactions: %w", err)
+	}
+
+	var (
+		candidates []*candidate
+		byID       = make(map[string]*candidate)
+	)
+
+	// add appends account ID as candidate
+	// with source, or adds source to the
+	// existing candidate if already found.
+	add := func(accountID string, source gtsmodel.SuggestionSource) {
+		c, ok := byID[accountID]
+		if !ok {
+			c = &candidate{accountID: accountID}
+			byID[accountID] = c
+			candidates = append(candidates, c)
+		}
+		c.sources = append(c.sources, source)
+	}
+
+	for _, pick := range picks {
+		add(pick.AccountID, gtsmodel.SuggestionSourceStaffPick)
+	}
+
+	for _, accountID := range friendsOfFriends {
+		add(accountID, gtsmodel.SuggestionSourceFriendsOfFriends)
+	}
+
+	for _, accountID := range interactions {
+		add(accountID, gtsmodel.SuggestionSourceInteractions)
+	}
+
+	// Sort staff picks first, then by number of sources.
+	slices.SortStableFunc(candidates, func(a, b *candidate) int {
+		aPick := a.sources[0] == gtsmodel.SuggestionSourceStaffPick
+		bPick := b.sources[0] == gtsmodel.SuggestionSourceStaffPick
+		if aPick != bPick {
+			if aPick {
+				return -1
+			}
+			return 1
+		}
+		return cmp.Compare(len(b.sources), len(a.sources))
+	})
+
+	return candidates, nil
+}
+
+// suggestable fetches the account with given ID, returning
+// it only if it may be suggested to requester to follow.
+func (p *Processor) suggestable(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	accountID string,
+) (*gtsmodel.Account, error) {
+	if accountID == requester.ID {
+		// Don't suggest self.
+		return nil, nil
+	}
+
+	account, err := p.state.DB.GetAccountByID(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// Account gone.
+			return nil, nil
+		}
+		return nil, gtserror.Newf("db error getting account %s: %w", accountID, err)
+	}
+
+	if !util.PtrOrZero(account.Discoverable) ||
+		account.IsSuspended() ||
+		account.IsMoving() ||
+		account.IsInstance() {
+		// Account doesn't want to be suggested,
+		// or shouldn't be followed regardless.
+		return nil, nil
+	}
+
+	// Check account is visible to requester (ie., no blocks).
+	visible, err := p.visFilter.AccountVisible(ctx, requester, account)
+	if err != nil {
+		return nil, gtserror.Newf("error checking visibility of account %s: %w", accountID, err)
+	}
+
+	if !visible {
+		return nil, nil
+	}
+
+	muted, err := p.muteFilter.AccountMuted(ctx, requester, account)
+	if err != nil {
+		return nil, gtserror.Newf("error checking mute of account %s: %w", accountID, err)
+	}
+
+	if muted {
+		return nil, nil
+	}
+
+	// Staff picks aren't filtered by
+	// the database, so check these here.
+	following, err := p.state.DB.IsFollowing(ctx, requester.ID, accountID)
+	if err != nil {
+		return nil, gtserror.Newf("db error checking follow: %w", err)
+	}
+
+	if following {
+		return nil, nil
+	}
+
+	requested, err := p.state.DB.IsFollowRequested(ctx, requester.ID, accountID)
+	if err != nil {
+		return nil, gtserror.Newf("db error checking follow request: %w", err)
+	}
+
+	if requested {
+		return nil, nil
+	}
+
+	dismissed, err := p.state.DB.IsSuggestionDismissed(ctx, requester.ID, accountID)
+	if err != nil {
+		return nil, gtserror.Newf("db error checking suggestion dismissal: %w", err)
+	}
+
+	if dismissed {
+		return nil, nil
+	}
+
+	return account, nil
+}
@@ -41,12 +41,22 @@ func (p *Processor) NotificationsGet(
 	page *paging.Page,
 	types []gtsmodel.NotificationType,
 	excludeTypes []gtsmodel.NotificationType,
+	originAccountID string,
+	includeFiltered bool,
 ) (*apimodel.PageableResponse, gtserror.WithCode) {
+	// Notifications held back by the notification
+	// policy are always included when looking at the
+	// notifications from one account, as is done when
+	// viewing the notifications of a notification request.
+	includeFiltered = includeFiltered || originAccountID != ""
+
 	notifs, err := p.state.DB.GetAccountNotifications(ctx,
 		requester.ID,
 		page,
 		types,
 		excludeTypes,
+		originAccountID,
+		includeFiltered,
 	)
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
 		err = fmt.Errorf("NotificationsGet: db error getting notifications: %w", err)
@@ -68,65 +78,11 @@ func (p *Processor) NotificationsGet(
 	)
 
 	for _, n := range notifs {
-		visible, err := p.notifVisible(ctx, n, requester)
-		if err != nil {
-			log.Debugf(ctx, "skipping notification %s because of an error checking notification visibility: %v", n.ID, err)
-			continue
-		}
-
-		if !visible {
-			continue
-		}
-
-		// Check whether notification origin account is muted.
-		muted, err := p.muteFilter.AccountNotificationsMuted(ctx,
-			requester,
-			n.OriginAccount,
-		)
-		if err != nil {
-			log.Errorf(ctx, "error checking account mute: %v", err)
-			continue
-		}
-
-		if muted {
+		filtered, ok := p.notifFilter(ctx, requester, n)
+		if !ok {
 			continue
 		}
 
-		var filtered []apimodel.FilterResult
-
-		if n.Status != nil {
-			var hide bool
-
-			// Check whether notification status is muted by requester.
-			muted, err = p.muteFilter.StatusNotificationsMuted(ctx,
-				requester,
-				n.Status,
-			)
-			if err != nil {
-				log.Errorf(ctx, "error checking status mute: %v", err)
-				continue
-			}
-
-			if muted {
-				continue
-			}
-
-			// Check whether notification status is filtered by requester in notifs.
-			filtered, hide, err = p.statusFilter.StatusFilterResultsInContext(ctx,
-				requester,
-				n.Status,
-				gtsmodel.FilterContextNotifications,
-			)
-			if err != nil {
-				log.Errorf(ctx, "error checking status filtering: %v", err)
-				continue
-			}
-
-			if hide {
-				continue
-			}
-		}
-
 		item, err := p.converter.NotificationToAPINotification(ctx, n)
 		if err != nil {
 			continue
@@ -149,6 +105,12 @@ func (p *Processor) NotificationsGet(
 	for _, typ := range excludeTypes {
 		query.Add("exclude_types[]", typ.String())
 	}
+	if originAccountID != "" {
+		query.Set("account_id", originAccountID)
+	}
+	if includeFiltered {
+		query.Set("include_filtered", "true")
+	}
 
 	return paging.PackageResponse(paging.ResponseParams{
 		Items: items,
@@ -201,6 +163,75 @@ func (p *Processor) NotificationsClear(ctx context.Context, authed *apiutil.Auth
 	return nil
 }
 
+// notifFilter checks whether the given notification should be shown
+// to requester in notification timelines, ie., whether it's visible,
+// not muted and not hidden by filters. Any filter results for the
+// notification status are returned, to be set on the API status.
+func (p *Processor) notifFilter(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	n *gtsmodel.Notification,
+) ([]apimodel.FilterResult, bool) {
+	visible, err := p.notifVisible(ctx, n, requester)
+	if err != nil {
+		log.Debugf(ctx, "skipping notification %s because of an error checking notification visibility: %v", n.ID, err)
+		return nil, false
+	}
+
+	if !visible {
+		return nil, false
+	}
+
+	// Check whether notification origin account is muted.
+	muted, err := p.muteFilter.AccountNotificationsMuted(ctx,
+		requester,
+		n.OriginAccount,
+	)
+	if err != nil {
+		log.Errorf(ctx, "error checking account mute: %v", err)
+		return nil, false
+	}
+
+	if muted {
+		return nil, false
+	}
+
+	if n.Status == nil {
+		return nil, true
+	}
+
+	// Check whether notification status is muted by requester.
+	muted, err = p.muteFilter.StatusNotificationsMuted(ctx,
+		requester,
+		n.Status,
+	)
+	if err != nil {
+		log.Errorf(ctx, "error checking status mute: %v", err)
+		return nil, false
+	}
+
+	if muted {
+		return nil, false
+	}
+
+	// Check whether notification status is filtered by requester in notifs.
+	filtered, hide, err := p.statusFilter.StatusFilterResultsInContext(ctx,
+		requester,
+		n.Status,
+		gtsmodel.FilterContextNotifications,
+	)
+	if err != nil {
+		log.Errorf(ctx, "error checking status filtering: %v", err)
+		return nil, false
+	}
+
+	if hide {
+		return nil, false
+	}
+
+	return filtered, true
+}
+
 func (p *Processor) notifVisible(
 	ctx context.Context,
 	n *gtsmodel.Notification,
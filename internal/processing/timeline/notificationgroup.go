@@ -0,0 +1,490 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package timeline
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/paging"
+)
+
+const (
+	// notifGroupBatch is the number of notifications
+	// to fetch at a time when building groups.
+	notifGroupBatch = 80
+
+	// notifGroupMaxBatches is the maximum number of
+	// batches to fetch when building a page of groups,
+	// to limit work done for a single page.
+	notifGroupMaxBatches = 10
+
+	// notifGroupSampleSize is the maximum number
+	// of sample accounts to include per group.
+	notifGroupSampleSize = 8
+)
+
+// notifGroup is a group of notifications
+// sharing a group key, ordered newest first.
+type notifGroup struct {
+	key      string
+	notifs   []*gtsmodel.Notification
+	filtered []apimodel.FilterResult
+}
+
+// add adds the given notification to the group, keeping
+// filter results of the newest notification in the group.
+func (g *notifGroup) add(n *gtsmodel.Notification, filtered []apimodel.FilterResult) {
+	if len(g.notifs) == 0 || n.ID > g.notifs[0].ID {
+		g.filtered = filtered
+	}
+	g.notifs = append(g.notifs, n)
+}
+
+// NotificationGroupsGet returns a page of grouped notifications for requester, where
+// the page limit applies to the number of groups returned. Notifications are grouped
+// according to gtsmodel.Notification{}.GroupKey() with given grouped types, or with
+// gtsmodel.NotificationGroupedTypes if none are given. Groups are built only from
+// notifications in the page, which are gathered until limit of groups is reached.
+//
+// Returned is the page of results, along with a Link header value for paging.
+func (p *Processor) NotificationGroupsGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	page *paging.Page,
+	types []gtsmodel.NotificationType,
+	excludeTypes []gtsmodel.NotificationType,
+	groupedTypes []gtsmodel.NotificationType,
+) (*apimodel.GroupedNotificationsResults, string, gtserror.WithCode) {
+	var (
+		grouping = groupableTypes(groupedTypes)
+		limit    = page.GetLimit()
+		order    = page.GetOrder()
+		groups   = make(map[string]*notifGroup)
+
+		// Lowest and highest ID
+		// values, used for paging.
+		lo, hi string
+
+		// Batch page that we
+		// update as we scan.
+		batch = &paging.Page{
+			Min:   page.Min,
+			Max:   page.Max,
+			Limit: notifGroupBatch,
+		}
+	)
+
+scan:
+	for i := 0; i < notifGroupMaxBatches; i++ {
+		notifs, err := p.state.DB.GetAccountNotifications(ctx,
+			requester.ID,
+			batch,
+			types,
+			excludeTypes,
+			"",    // Any origin account.
+			false, // Exclude filtered.
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting notifications: %w", err)
+			return nil, "", gtserror.NewErrorInternalError(err)
+		}
+
+		if len(notifs) == 0 {
+			break
+		}
+
+		if order == paging.OrderAscending {
+			// Paging up, scan
+			// oldest first.
+			slices.Reverse(notifs)
+		}
+
+		for _, n := range notifs {
+			filtered, ok := p.notifFilter(ctx, requester, n)
+			if ok {
+				key := n.GroupKey(grouping)
+
+				group := groups[key]
+				if group == nil {
+					if limit > 0 && len(groups) >= limit {
+						// Reached limit
+						// of groups, done.
+						break scan
+					}
+
+					group = &notifGroup{key: key}
+					groups[key] = group
+				}
+
+				group.add(n, filtered)
+			}
+
+			// Update paging values
+			// (including filtered).
+			if lo == "" || n.ID < lo {
+				lo = n.ID
+			}
+			if hi == "" || n.ID > hi {
+				hi = n.ID
+			}
+		}
+
+		if len(notifs) < notifGroupBatch {
+			// Reached end.
+			break
+		}
+
+		// Move batch page along.
+		if order == paging.OrderAscending {
+			batch.Min = paging.MinID(hi)
+		} else {
+			batch.Max = paging.MaxID(lo)
+		}
+	}
+
+	if lo == "" {
+		// Nothing found.
+		return &apimodel.GroupedNotificationsResults{
+			Accounts:           []*apimodel.Account{},
+			Statuses:           []*apimodel.Status{},
+			NotificationGroups: []*apimodel.NotificationGroup{},
+		}, "", nil
+	}
+
+	// Sort groups by most recent notification.
+	sorted := make([]*notifGroup, 0, len(groups))
+	for _, group := range groups {
+		slices.SortFunc(group.notifs, func(a, b *gtsmodel.Notification) int {
+			return cmp.Compare(b.ID, a.ID)
+		})
+		sorted = append(sorted, group)
+	}
+	slices.SortFunc(sorted, func(a, b *notifGroup) int {
+		return cmp.Compare(b.notifs[0].ID, a.notifs[0].ID)
+	})
+
+	results := p.notifGroupsToAPI(ctx, sorted)
+
+	// Build type query string.
+	query := make(url.Values)
+	for _, typ := range types {
+		query.Add("types[]", typ.String())
+	}
+	for _, typ := range excludeTypes {
+		query.Add("exclude_types[]", typ.String())
+	}
+	for _, typ := range groupedTypes {
+		query.Add("grouped_types[]", typ.String())
+	}
+
+	resp := paging.PackageResponse(paging.ResponseParams{
+		Path:  "/api/v2/notifications",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+		Query: query,
+	})
+
+	return results, resp.LinkHeader, nil
+}
+
+// NotificationGroupGet returns the notification group with given key for
+// requester, including all notifications belonging to it, not just a page.
+func (p *Processor) NotificationGroupGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	groupKey string,
+) (*apimodel.GroupedNotificationsResults, gtserror.WithCode) {
+	group := &notifGroup{key: groupKey}
+
+	// Add each notification in given batch
+	// of notifications that belongs to group.
+	addAll := func(notifs []*gtsmodel.Notification) {
+		for _, n := range notifs {
+			filtered, ok := p.notifFilter(ctx, requester, n)
+			if ok && n.GroupKey(gtsmodel.NotificationGroupedTypes) == groupKey {
+				group.add(n, filtered)
+			}
+		}
+	}
+
+	prefix, value, _ := strings.Cut(groupKey, "-")
+	ntype := gtsmodel.ParseNotificationType(prefix)
+
+	switch {
+	case prefix == "ungrouped":
+		// Single notification.
+		notif, err := p.state.DB.GetNotificationByID(ctx, value)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting notification: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if notif != nil && notif.TargetAccountID == requester.ID {
+			filtered, ok := p.notifFilter(ctx, requester, notif)
+			if ok {
+				group.add(notif, filtered)
+			}
+		}
+
+	case !slices.Contains(gtsmodel.NotificationGroupedTypes, ntype):
+		// Not a group key
+		// we know about.
+
+	case len(value) == len("20060102"):
+		// Notifications of type on one day.
+		day, err := time.Parse("20060102", value)
+		if err != nil {
+			break
+		}
+
+		// Page over notification IDs for that day, with a
+		// little leeway in case ID and creation time differ.
+		page := &paging.Page{
+			Min:   paging.SinceID(id.NewULIDFromTime(day.Add(-time.Minute))),
+			Max:   paging.MaxID(id.NewULIDFromTime(day.Add(24*time.Hour + time.Minute))),
+			Limit: notifGroupBatch,
+		}
+
+		if err := notifGroupScan(page, func(page *paging.Page) ([]*gtsmodel.Notification, error) {
+			notifs, err := p.state.DB.GetAccountNotifications(ctx,
+				requester.ID,
+				page,
+				[]gtsmodel.NotificationType{ntype},
+				nil,
+				"",    // Any origin account.
+				false, // Exclude filtered.
+			)
+			addAll(notifs)
+			return notifs, err
+		}); err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+	default:
+		// Notifications of type about a status.
+		page := &paging.Page{Limit: notifGroupBatch}
+
+		if err := notifGroupScan(page, func(page *paging.Page) ([]*gtsmodel.Notification, error) {
+			notifs, err := p.state.DB.GetAccountStatusNotifications(ctx,
+				requester.ID,
+				ntype,
+				value,
+				page,
+			)
+			addAll(notifs)
+			return notifs, err
+		}); err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	if len(group.notifs) == 0 {
+		const text = "notification group not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return p.notifGroupsToAPI(ctx, []*notifGroup{group}), nil
+}
+
+// NotificationsUnreadCount returns the number of notification groups for
+// requester that are newer than their notifications marker, considering
+// at most limit notifications. Grouping is as in NotificationGroupsGet().
+func (p *Processor) NotificationsUnreadCount(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	limit int,
+	types []gtsmodel.NotificationType,
+	excludeTypes []gtsmodel.NotificationType,
+	groupedTypes []gtsmodel.NotificationType,
+) (*apimodel.NotificationsUnreadCount, gtserror.WithCode) {
+	groupedTypes = groupableTypes(groupedTypes)
+
+	marker, err := p.state.DB.GetMarker(ctx,
+		requester.ID,
+		gtsmodel.MarkerNameNotifications,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting marker: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	page := &paging.Page{Limit: limit}
+	if marker != nil && marker.LastReadID != "" {
+		page.Min = paging.SinceID(marker.LastReadID)
+	}
+
+	notifs, err := p.state.DB.GetAccountNotifications(ctx,
+		requester.ID,
+		page,
+		types,
+		excludeTypes,
+		"",    // Any origin account.
+		false, // Exclude filtered.
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting notifications: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	keys := make(map[string]struct{}, len(notifs))
+	for _, n := range notifs {
+		if _, ok := p.notifFilter(ctx, requester, n); ok {
+			keys[n.GroupKey(groupedTypes)] = struct{}{}
+		}
+	}
+
+	return &apimodel.NotificationsUnreadCount{Count: len(keys)}, nil
+}
+
+// notifGroupScan pages down through all notifications
+// returned by get for given starting page, in batches.
+func notifGroupScan(
+	page *paging.Page,
+	get func(*paging.Page) ([]*gtsmodel.Notification, error),
+) error {
+	for {
+		notifs, err := get(page)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting notifications: %w", err)
+		}
+
+		if len(notifs) < page.GetLimit() {
+			// Reached end.
+			return nil
+		}
+
+		// Move page along.
+		page.Max = paging.MaxID(notifs[len(notifs)-1].ID)
+	}
+}
+
+// notifGroupsToAPI converts the given notification groups, each sorted
+// newest first, to API grouped notification results, skipping groups
+// that cannot be converted.
+func (p *Processor) notifGroupsToAPI(
+	ctx context.Context,
+	groups []*notifGroup,
+) *apimodel.GroupedNotificationsResults {
+	var (
+		results = &apimodel.GroupedNotificationsResults{
+			Accounts:           make([]*apimodel.Account, 0, len(groups)),
+			Statuses:           make([]*apimodel.Status, 0, len(groups)),
+			NotificationGroups: make([]*apimodel.NotificationGroup, 0, len(groups)),
+		}
+
+		// Accounts and statuses already added.
+		accounts = make(map[string]struct{})
+		statuses = make(map[string]struct{})
+	)
+
+	addAccount := func(account *apimodel.Account) {
+		if _, ok := accounts[account.ID]; !ok {
+			accounts[account.ID] = struct{}{}
+			results.Accounts = append(results.Accounts, account)
+		}
+	}
+
+	for _, group := range groups {
+		var (
+			newest = group.notifs[0]
+			oldest = group.notifs[len(group.notifs)-1]
+		)
+
+		// Convert newest notif to get
+		// the main account and status.
+		apiNotif, err := p.converter.NotificationToAPINotification(ctx, newest)
+		if err != nil {
+			log.Errorf(ctx, "error converting notification %s: %v", newest.ID, err)
+			continue
+		}
+
+		apiGroup := &apimodel.NotificationGroup{
+			GroupKey:                 group.key,
+			NotificationsCount:       len(group.notifs),
+			Type:                     apiNotif.Type,
+			MostRecentNotificationID: newest.ID,
+			PageMinID:                oldest.ID,
+			PageMaxID:                newest.ID,
+			LatestPageNotificationAt: apiNotif.CreatedAt,
+			SampleAccountIDs:         []string{apiNotif.Account.ID},
+			Emoji:                    apiNotif.Emoji,
+			EmojiURL:                 apiNotif.EmojiURL,
+		}
+		addAccount(apiNotif.Account)
+
+		if apiNotif.Status != nil {
+			apiGroup.StatusID = apiNotif.Status.ID
+			if _, ok := statuses[apiNotif.Status.ID]; !ok {
+				// Set filter results on status,
+				// in case any were set on group.
+				apiNotif.Status.Filtered = group.filtered
+				statuses[apiNotif.Status.ID] = struct{}{}
+				results.Statuses = append(results.Statuses, apiNotif.Status)
+			}
+		}
+
+		// Add further distinct accounts as samples.
+		for _, n := range group.notifs[1:] {
+			if len(apiGroup.SampleAccountIDs) >= notifGroupSampleSize {
+				break
+			}
+
+			if n.OriginAccount == nil ||
+				slices.Contains(apiGroup.SampleAccountIDs, n.OriginAccountID) {
+				continue
+			}
+
+			apiAccount, err := p.converter.AccountToAPIAccountPublic(ctx, n.OriginAccount)
+			if err != nil {
+				log.Errorf(ctx, "error converting account %s: %v", n.OriginAccountID, err)
+				continue
+			}
+
+			apiGroup.SampleAccountIDs = append(apiGroup.SampleAccountIDs, apiAccount.ID)
+			addAccount(apiAccount)
+		}
+
+		results.NotificationGroups = append(results.NotificationGroups, apiGroup)
+	}
+
+	return results
+}
+
+// groupableTypes returns the given notification types that may be
+// grouped, or the default grouped types if none are given.
+func groupableTypes(types []gtsmodel.NotificationType) []gtsmodel.NotificationType {
+	if len(types) == 0 {
+		return gtsmodel.NotificationGroupedTypes
+	}
+
+	return slices.DeleteFunc(slices.Clone(types), func(t gtsmodel.NotificationType) bool {
+		return !slices.Contains(gtsmodel.NotificationGroupedTypes, t)
+	})
+}
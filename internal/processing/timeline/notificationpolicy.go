@@ -0,0 +1,310 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package timeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/paging"
+)
+
+// NotificationPolicyGet returns the notification
+// policy of requester, or the default policy if
+// they haven't set one, along with a summary of
+// their pending notification requests.
+func (p *Processor) NotificationPolicyGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) (*apimodel.NotificationPolicy, gtserror.WithCode) {
+	policy, err := p.state.DB.GetNotificationPolicy(ctx, requester.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting notification policy: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if policy == nil {
+		policy = gtsmodel.DefaultNotificationPolicy(requester.ID)
+	}
+
+	return p.apiNotificationPolicy(ctx, policy)
+}
+
+// NotificationPolicyUpdate updates the notification policy of
+// requester with the values set in form, returning the result.
+func (p *Processor) NotificationPolicyUpdate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	form *apimodel.NotificationPolicyUpdateRequest,
+) (*apimodel.NotificationPolicy, gtserror.WithCode) {
+	policy, err := p.state.DB.GetNotificationPolicy(ctx, requester.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting notification policy: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if policy == nil {
+		policy = gtsmodel.DefaultNotificationPolicy(requester.ID)
+	}
+
+	for _, field := range []struct {
+		name  string
+		value *string
+		dst   *gtsmodel.NotificationPolicyValue
+	}{
+		{"for_not_following", form.ForNotFollowing, &policy.ForNotFollowing},
+		{"for_not_followers", form.ForNotFollowers, &policy.ForNotFollowers},
+		{"for_new_accounts", form.ForNewAccounts, &policy.ForNewAccounts},
+		{"for_private_mentions", form.ForPrivateMentions, &policy.ForPrivateMentions},
+		{"for_limited_accounts", form.ForLimitedAccounts, &policy.ForLimitedAccounts},
+	} {
+		if field.value == nil {
+			// Not updating.
+			continue
+		}
+
+		value := gtsmodel.ParseNotificationPolicyValue(*field.value)
+		if value == gtsmodel.NotificationPolicyUnknown {
+			text := fmt.Sprintf("%s must be one of accept, filter, or drop, got %q", field.name, *field.value)
+			return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+
+		*field.dst = value
+	}
+
+	if err := p.state.DB.PutNotificationPolicy(ctx, policy); err != nil {
+		err := gtserror.Newf("db error putting notification policy: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiNotificationPolicy(ctx, policy)
+}
+
+// apiNotificationPolicy converts the given policy to
+// its API model, including the pending requests summary.
+func (p *Processor) apiNotificationPolicy(
+	ctx context.Context,
+	policy *gtsmodel.NotificationPolicy,
+) (*apimodel.NotificationPolicy, gtserror.WithCode) {
+	requests, notifications, err := p.state.DB.CountAccountNotificationRequests(ctx, policy.AccountID)
+	if err != nil {
+		err := gtserror.Newf("db error counting notification requests: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.converter.NotificationPolicyToAPINotificationPolicy(
+		policy,
+		requests,
+		notifications,
+	), nil
+}
+
+// NotificationRequestsGet returns a page of pending
+// notification requests targeting requester.
+func (p *Processor) NotificationRequestsGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	requests, err := p.state.DB.GetAccountNotificationRequests(ctx,
+		requester.ID,
+		page,
+	)
+	if err != nil {
+		err := gtserror.Newf("db error getting notification requests: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(requests)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	var (
+		items = make([]any, 0, count)
+
+		// Get the lowest and highest
+		// ID values, used for paging.
+		lo = requests[count-1].ID
+		hi = requests[0].ID
+	)
+
+	for _, request := range requests {
+		item, err := p.converter.NotificationRequestToAPINotificationRequest(ctx, request)
+		if err != nil {
+			log.Errorf(ctx, "error converting notification request %s: %v", request.ID, err)
+			continue
+		}
+
+		items = append(items, item)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/notifications/requests",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// NotificationRequestGet returns the notification
+// request with given ID targeting requester.
+func (p *Processor) NotificationRequestGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	requestID string,
+) (*apimodel.NotificationRequest, gtserror.WithCode) {
+	request, errWithCode := p.getNotificationRequest(ctx, requester, requestID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiRequest, err := p.converter.NotificationRequestToAPINotificationRequest(ctx, request)
+	if err != nil {
+		err := gtserror.Newf("error converting notification request: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiRequest, nil
+}
+
+// NotificationRequestsAccept accepts the notification requests with
+// given IDs targeting requester: notifications held in each request
+// are shown as normal, and further notifications from the requesting
+// account bypass requester's notification policy. IDs of requests
+// that can't be found are ignored if there's more than one given.
+func (p *Processor) NotificationRequestsAccept(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	requestIDs ...string,
+) gtserror.WithCode {
+	return p.notificationRequestsResolve(ctx, requester, requestIDs,
+		func(request *gtsmodel.NotificationRequest) error {
+			// Permit further notifications from this account.
+			if err := p.state.DB.PutNotificationPermission(ctx, &gtsmodel.NotificationPermission{
+				ID:            id.NewULID(),
+				AccountID:     request.AccountID,
+				FromAccountID: request.FromAccountID,
+			}); err != nil {
+				return gtserror.Newf("db error putting notification permission: %w", err)
+			}
+
+			// Show the filtered notifications.
+			if err := p.state.DB.UnfilterNotifications(ctx,
+				request.AccountID,
+				request.FromAccountID,
+			); err != nil {
+				return gtserror.Newf("db error unfiltering notifications: %w", err)
+			}
+
+			return nil
+		},
+	)
+}
+
+// NotificationRequestsDismiss dismisses the notification requests with
+// given IDs targeting requester, deleting the notifications held in each
+// request. Further notifications from the requesting account are still
+// subject to requester's notification policy, and may start a new request.
+// IDs of requests that can't be found are ignored if there's more than one.
+func (p *Processor) NotificationRequestsDismiss(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	requestIDs ...string,
+) gtserror.WithCode {
+	return p.notificationRequestsResolve(ctx, requester, requestIDs,
+		func(request *gtsmodel.NotificationRequest) error {
+			if err := p.state.DB.DeleteFilteredNotifications(ctx,
+				request.AccountID,
+				request.FromAccountID,
+			); err != nil {
+				return gtserror.Newf("db error deleting filtered notifications: %w", err)
+			}
+
+			return nil
+		},
+	)
+}
+
+// notificationRequestsResolve calls resolve for each notification
+// request with given IDs targeting requester, deleting each request
+// after. If only one ID is given, it must belong to a request.
+func (p *Processor) notificationRequestsResolve(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	requestIDs []string,
+	resolve func(*gtsmodel.NotificationRequest) error,
+) gtserror.WithCode {
+	for _, requestID := range requestIDs {
+		request, errWithCode := p.getNotificationRequest(
+			gtscontext.SetBarebones(ctx),
+			requester,
+			requestID,
+		)
+		if errWithCode != nil {
+			if len(requestIDs) > 1 &&
+				errWithCode.Code() == http.StatusNotFound {
+				// Ignore missing
+				// in bulk requests.
+				continue
+			}
+			return errWithCode
+		}
+
+		if err := resolve(request); err != nil {
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		if err := p.state.DB.DeleteNotificationRequestByID(ctx, request.ID); err != nil {
+			err := gtserror.Newf("db error deleting notification request: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return nil
+}
+
+// getNotificationRequest gets the notification request with given ID,
+// returning a not found error if it doesn't target requester.
+func (p *Processor) getNotificationRequest(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	requestID string,
+) (*gtsmodel.NotificationRequest, gtserror.WithCode) {
+	request, err := p.state.DB.GetNotificationRequestByID(ctx, requestID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting notification request: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if request == nil || request.AccountID != requester.ID {
+		const text = "notification request not found"
+		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
+	}
+
+	return request, nil
+}
@@ -0,0 +1,80 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"context"
+	"errors"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+)
+
+// StatusesGet returns up to limit currently trending, admin-approved
+// statuses visible to requester (may be nil), starting at given offset.
+func (p *Processor) StatusesGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	limit int,
+	offset int,
+) ([]*apimodel.Status, gtserror.WithCode) {
+	trends := p.approved(gtsmodel.TrendTypeStatus, offset)
+	apiStatuses := make([]*apimodel.Status, 0, min(limit, len(trends)))
+
+	for _, trend := range trends {
+		if len(apiStatuses) >= limit {
+			break
+		}
+
+		status, err := p.state.DB.GetStatusByID(ctx, trend.TargetID)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				err := gtserror.Newf("db error getting status %s: %w", trend.TargetID, err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+
+			// Status was
+			// deleted.
+			continue
+		}
+
+		// Ensure requester can see trending status.
+		visible, err := p.visFilter.StatusVisible(ctx, requester, status)
+		if err != nil {
+			err := gtserror.Newf("error checking visibility of status %s: %w", status.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if !visible {
+			continue
+		}
+
+		apiStatus, err := p.converter.StatusToAPIStatus(ctx, status, requester)
+		if err != nil {
+			log.Errorf(ctx, "error converting status %s: %v", status.ID, err)
+			continue
+		}
+
+		apiStatuses = append(apiStatuses, apiStatus)
+	}
+
+	return apiStatuses, nil
+}
@@ -0,0 +1,78 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"context"
+	"errors"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
+)
+
+// TagsGet returns up to limit currently trending,
+// admin-approved hashtags, starting at given offset.
+func (p *Processor) TagsGet(
+	ctx context.Context,
+	limit int,
+	offset int,
+) ([]*apimodel.Tag, gtserror.WithCode) {
+	trends := p.approved(gtsmodel.TrendTypeTag, offset)
+	apiTags := make([]*apimodel.Tag, 0, min(limit, len(trends)))
+
+	for _, trend := range trends {
+		if len(apiTags) >= limit {
+			break
+		}
+
+		tag, err := p.state.DB.GetTag(ctx, trend.TargetID)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				err := gtserror.Newf("db error getting tag %s: %w", trend.TargetID, err)
+				return nil, gtserror.NewErrorInternalError(err)
+			}
+
+			// Tag was
+			// deleted.
+			continue
+		}
+
+		if !util.PtrOrValue(tag.Listable, true) {
+			// Admin has marked
+			// this tag unlisted.
+			continue
+		}
+
+		apiTag, err := p.converter.TagToAPITag(ctx, tag, false, nil)
+		if err != nil {
+			log.Errorf(ctx, "error converting tag %s: %v", tag.ID, err)
+			continue
+		}
+
+		history := p.converter.TrendHistoryToAPIHistory(trend.History)
+		apiTag.History = &history
+
+		apiTags = append(apiTags, &apiTag)
+	}
+
+	return apiTags, nil
+}
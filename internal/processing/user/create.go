@@ -19,16 +19,24 @@
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/netip"
+	"strings"
 	"time"
 
 	"code.superseriousbusiness.org/gotosocial/internal/ap"
 	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
 	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
 	"code.superseriousbusiness.org/gotosocial/internal/messages"
 	"code.superseriousbusiness.org/gotosocial/internal/text"
+	"code.superseriousbusiness.org/gotosocial/internal/uris"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
 	"code.superseriousbusiness.org/oauth2/v4"
 )
 
@@ -49,6 +57,24 @@ func (p *Processor) Create(
 		regBacklog  = config.GetAccountsRegistrationBacklogLimit()
 	)
 
+	// If an invite code was provided,
+	// make sure it's (still) usable.
+	var invite *gtsmodel.Invite
+	if form.InviteCode != "" {
+		var errWithCode gtserror.WithCode
+		invite, errWithCode = p.getSignupInvite(ctx, form.InviteCode)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	// Check the sign-up against admin
+	// IP blocks and email domain blocks.
+	requireApproval, errWithCode := p.checkSignupBlocks(ctx, form)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
 	// If usersPerDay limit is in place,
 	// ensure no more than usersPerDay
 	// have registered in the last 24h.
@@ -67,7 +93,10 @@ func (p *Processor) Create(
 
 	// If registration backlog limit is
 	// in place, ensure backlog isn't full.
-	if regBacklog > 0 {
+	//
+	// Invited sign-ups skip the backlog,
+	// unless they'll need approval anyway.
+	if regBacklog > 0 && (invite == nil || requireApproval) {
 		backlogLen, err := p.state.DB.CountUnhandledSignups(ctx)
 		if err != nil {
 			err := fmt.Errorf("db error counting registration backlog length: %w", err)
@@ -103,9 +132,10 @@ func (p *Processor) Create(
 		return nil, gtserror.NewErrorConflict(err, err.Error())
 	}
 
-	// Only store reason if one is required.
+	// Only store reason if one is required,
+	// which is not the case for invited users.
 	var reason string
-	if config.GetAccountsReasonRequired() {
+	if config.GetAccountsReasonRequired() && invite == nil {
 		reason = form.Reason
 	}
 
@@ -118,7 +148,7 @@ func (p *Processor) Create(
 		}
 	}
 
-	user, err := p.state.DB.NewSignup(ctx, gtsmodel.NewSignup{
+	newSignup := gtsmodel.NewSignup{
 		Username: form.Username,
 		Email:    form.Email,
 		Password: form.Password,
@@ -126,8 +156,38 @@ func (p *Processor) Create(
 		SignUpIP: form.IP,
 		Locale:   form.Locale,
 		AppID:    app.ID,
-	})
+	}
+
+	if invite != nil {
+		// Use up the invite now, just before
+		// creating the user, so that concurrent
+		// sign-ups can't exceed its max uses.
+		if err := p.state.DB.IncrementInviteUses(ctx, invite); err != nil {
+			if errors.Is(err, db.ErrNoEntries) {
+				const errText = "invite code is expired or has already been used"
+				return nil, gtserror.NewErrorUnprocessableEntity(errors.New(errText), errText)
+			}
+			err := fmt.Errorf("db error using invite: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		// Users signing up with an invite don't
+		// need approval, unless their IP range
+		// has been marked as requiring it.
+		newSignup.InviteID = invite.ID
+		newSignup.PreApproved = !requireApproval
+	}
+
+	user, err := p.state.DB.NewSignup(ctx, newSignup)
 	if err != nil {
+		if invite != nil {
+			// Sign-up failed, so give back the invite
+			// use taken above, or it would be burned.
+			if err := p.state.DB.DecrementInviteUses(ctx, invite); err != nil {
+				log.Errorf(ctx, "db error giving back invite use: %v", err)
+			}
+		}
+
 		err := fmt.Errorf("db error creating new signup: %w", err)
 		return nil, gtserror.NewErrorInternalError(err)
 	}
@@ -144,9 +204,134 @@ func (p *Processor) Create(
 		Origin:         user.Account,
 	})
 
+	if invite != nil && *invite.Autofollow {
+		// Make the new account follow the inviter.
+		if err := p.inviteAutofollow(ctx, user.Account, invite); err != nil {
+			log.Errorf(ctx, "error following inviter: %v", err)
+		}
+	}
+
 	return user, nil
 }
 
+// checkSignupBlocks checks the sign-up IP and email
+// address domain against admin-created blocks. If
+// sign-up is blocked, an error is returned. Otherwise,
+// returns whether the sign-up must be approved by an
+// admin regardless of any invite used.
+func (p *Processor) checkSignupBlocks(
+	ctx context.Context,
+	form *apimodel.AccountCreateRequest,
+) (bool, gtserror.WithCode) {
+	var requireApproval bool
+
+	if addr, ok := netip.AddrFromSlice(form.IP); ok {
+		block, err := p.state.DB.MatchIPBlock(ctx, addr)
+		if err != nil {
+			err := fmt.Errorf("db error checking ip blocks: %w", err)
+			return false, gtserror.NewErrorInternalError(err)
+		}
+
+		if block != nil {
+			switch block.Severity {
+			case gtsmodel.IPBlockSeveritySignUpRequiresApproval:
+				requireApproval = true
+
+			case gtsmodel.IPBlockSeveritySignUpBlock,
+				gtsmodel.IPBlockSeverityNoAccess:
+				const errText = "sign-ups from your IP address are not permitted"
+				return false, gtserror.NewErrorForbidden(errors.New(errText), errText)
+			}
+		}
+	}
+
+	// Email has already been validated, so
+	// everything after the last @ is the domain.
+	domain := form.Email[strings.LastIndexByte(form.Email, '@')+1:]
+
+	blocked, err := p.state.DB.IsEmailDomainBlocked(ctx, domain)
+	if err != nil {
+		err := fmt.Errorf("db error checking email domain blocks: %w", err)
+		return false, gtserror.NewErrorInternalError(err)
+	}
+
+	if blocked {
+		const errText = "sign-ups using email addresses at this domain are not permitted"
+		return false, gtserror.NewErrorUnprocessableEntity(errors.New(errText), errText)
+	}
+
+	return requireApproval, nil
+}
+
+// getSignupInvite returns the invite with the given code,
+// if invites are enabled and the invite is still usable.
+func (p *Processor) getSignupInvite(ctx context.Context, code string) (*gtsmodel.Invite, gtserror.WithCode) {
+	if !config.GetAccountsInvitesEnabled() {
+		const errText = "invites are not enabled on this instance"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(errText), errText)
+	}
+
+	invite, err := p.state.DB.GetInviteByCode(ctx, code)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := fmt.Errorf("db error getting invite: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if invite == nil {
+		const errText = "invite code not recognized"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(errText), errText)
+	}
+
+	// Invites from deleted or suspended
+	// accounts can no longer be used.
+	if !invite.Valid(time.Now()) ||
+		invite.Account == nil ||
+		invite.Account.IsSuspended() {
+		const errText = "invite code is expired or has already been used"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(errText), errText)
+	}
+
+	return invite, nil
+}
+
+// inviteAutofollow creates a follow request from
+// the newly signed-up account to the inviter. As
+// both accounts are local, the follow is accepted
+// straight away by the worker if the inviter's
+// account is not locked.
+func (p *Processor) inviteAutofollow(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	invite *gtsmodel.Invite,
+) error {
+	followID := id.NewRandomULID()
+	fr := &gtsmodel.FollowRequest{
+		ID:              followID,
+		URI:             uris.GenerateURIForFollow(account.Username, followID),
+		AccountID:       account.ID,
+		Account:         account,
+		TargetAccountID: invite.AccountID,
+		TargetAccount:   invite.Account,
+		ShowReblogs:     util.Ptr(true),
+		Notify:          util.Ptr(false),
+	}
+
+	if err := p.state.DB.PutFollowRequest(ctx, fr); err != nil {
+		return gtserror.Newf("db error putting follow request: %w", err)
+	}
+
+	// Handle side effects async.
+	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ActivityFollow,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       fr,
+		Origin:         account,
+		Target:         invite.Account,
+	})
+
+	return nil
+}
+
 // TokenForNewUser generates an OAuth Bearer token
 // for a new user (with account) created by Create().
 func (p *Processor) TokenForNewUser(
@@ -20,8 +20,11 @@
 import (
 	"net"
 	"testing"
+	"time"
 
 	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -68,6 +71,208 @@ func (suite *CreateTestSuite) TestCreateOK() {
 	suite.Equal("Bearer", userAccessToken.TokenType)
 }
 
+func (suite *CreateTestSuite) TestCreateWithInvite() {
+	var (
+		ctx     = suite.T().Context()
+		app     = suite.testApps["application_1"]
+		inviter = suite.testUsers["admin_account"]
+		invite  = &gtsmodel.Invite{
+			ID:         "01JCT3JVTK4QVKBK1ZQHZ9E8NY",
+			Code:       "K5B3ZNCQ7WBVC4BXMQAW6DZN5Y",
+			AccountID:  inviter.AccountID,
+			MaxUses:    1,
+			Autofollow: util.Ptr(true),
+		}
+		newForm = func(username string) *apimodel.AccountCreateRequest {
+			return &apimodel.AccountCreateRequest{
+				Username:   username,
+				Email:      username + "@example.org",
+				Password:   "a long enough password for this endpoint",
+				Agreement:  true,
+				Locale:     "en-us",
+				InviteCode: invite.Code,
+				IP:         net.ParseIP("192.0.2.128"),
+			}
+		}
+	)
+
+	if err := suite.state.DB.PutInvite(ctx, invite); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Create user with the invite code.
+	user, errWithCode := suite.user.Create(ctx, app, newForm("someone_invited"))
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// User should be pre-approved,
+	// with the invite ID recorded.
+	suite.True(*user.Approved)
+	suite.Equal(invite.ID, user.InviteID)
+
+	// Invite should now be used up.
+	dbInvite, err := suite.state.DB.GetInviteByID(ctx, invite.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(1, dbInvite.Uses)
+	suite.False(dbInvite.Valid(time.Now()))
+
+	// New account should have
+	// requested to follow inviter.
+	requested, err := suite.state.DB.IsFollowRequested(ctx, user.AccountID, inviter.AccountID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(requested)
+
+	// Trying to use the invite again should fail.
+	_, errWithCode = suite.user.Create(ctx, app, newForm("someone_else"))
+	suite.EqualError(errWithCode, "invite code is expired or has already been used")
+}
+
+func (suite *CreateTestSuite) TestCreateWithExpiredInvite() {
+	var (
+		ctx    = suite.T().Context()
+		app    = suite.testApps["application_1"]
+		invite = &gtsmodel.Invite{
+			ID:         "01JCT3JVTK4QVKBK1ZQHZ9E8NY",
+			Code:       "K5B3ZNCQ7WBVC4BXMQAW6DZN5Y",
+			AccountID:  suite.testUsers["admin_account"].AccountID,
+			ExpiresAt:  time.Now().Add(-time.Hour),
+			Autofollow: util.Ptr(false),
+		}
+		form = &apimodel.AccountCreateRequest{
+			Username:   "someone_invited",
+			Email:      "someone_invited@example.org",
+			Password:   "a long enough password for this endpoint",
+			Agreement:  true,
+			Locale:     "en-us",
+			InviteCode: invite.Code,
+			IP:         net.ParseIP("192.0.2.128"),
+		}
+	)
+
+	if err := suite.state.DB.PutInvite(ctx, invite); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, errWithCode := suite.user.Create(ctx, app, form)
+	suite.EqualError(errWithCode, "invite code is expired or has already been used")
+}
+
+func (suite *CreateTestSuite) TestCreateFromBlockedIP() {
+	var (
+		ctx   = suite.T().Context()
+		app   = suite.testApps["application_1"]
+		block = &gtsmodel.IPBlock{
+			ID:                 "01JCT3JVTK4QVKBK1ZQHZ9E8NY",
+			IP:                 "192.0.2.0/24",
+			Severity:           gtsmodel.IPBlockSeveritySignUpBlock,
+			CreatedByAccountID: suite.testUsers["admin_account"].AccountID,
+		}
+		form = &apimodel.AccountCreateRequest{
+			Username:  "someone_blocked",
+			Email:     "someone_blocked@example.org",
+			Password:  "a long enough password for this endpoint",
+			Agreement: true,
+			Locale:    "en-us",
+			IP:        net.ParseIP("192.0.2.128"),
+		}
+	)
+
+	if err := suite.state.DB.PutIPBlock(ctx, block); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, errWithCode := suite.user.Create(ctx, app, form)
+	suite.EqualError(errWithCode, "sign-ups from your IP address are not permitted")
+
+	// Once the block has expired,
+	// the sign-up should go through.
+	block.ExpiresAt = time.Now().Add(-time.Minute)
+	if err := suite.state.DB.UpdateIPBlock(ctx, block, "expires_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, errWithCode = suite.user.Create(ctx, app, form)
+	suite.NoError(errWithCode)
+}
+
+func (suite *CreateTestSuite) TestCreateWithInviteFromApprovalIP() {
+	var (
+		ctx    = suite.T().Context()
+		app    = suite.testApps["application_1"]
+		invite = &gtsmodel.Invite{
+			ID:         "01JCT3JVTK4QVKBK1ZQHZ9E8NY",
+			Code:       "K5B3ZNCQ7WBVC4BXMQAW6DZN5Y",
+			AccountID:  suite.testUsers["admin_account"].AccountID,
+			Autofollow: util.Ptr(false),
+		}
+		block = &gtsmodel.IPBlock{
+			ID:                 "01JCT3KV9Q5Z3MSFWJ2GV9X0C4",
+			IP:                 "2001:db8::/32",
+			Severity:           gtsmodel.IPBlockSeveritySignUpRequiresApproval,
+			CreatedByAccountID: suite.testUsers["admin_account"].AccountID,
+		}
+		form = &apimodel.AccountCreateRequest{
+			Username:   "someone_invited",
+			Email:      "someone_invited@example.org",
+			Password:   "a long enough password for this endpoint",
+			Agreement:  true,
+			Locale:     "en-us",
+			InviteCode: invite.Code,
+			IP:         net.ParseIP("2001:db8::1"),
+		}
+	)
+
+	if err := suite.state.DB.PutInvite(ctx, invite); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.state.DB.PutIPBlock(ctx, block); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	user, errWithCode := suite.user.Create(ctx, app, form)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Invite was used, but the
+	// user still needs approval.
+	suite.Equal(invite.ID, user.InviteID)
+	suite.False(*user.Approved)
+}
+
+func (suite *CreateTestSuite) TestCreateWithBlockedEmailDomain() {
+	var (
+		ctx   = suite.T().Context()
+		app   = suite.testApps["application_1"]
+		block = &gtsmodel.EmailDomainBlock{
+			ID:                 "01JCT3JVTK4QVKBK1ZQHZ9E8NY",
+			Domain:             "example.org",
+			CreatedByAccountID: suite.testUsers["admin_account"].AccountID,
+		}
+		form = &apimodel.AccountCreateRequest{
+			Username:  "someone_blocked",
+			Email:     "someone_blocked@mail.example.org",
+			Password:  "a long enough password for this endpoint",
+			Agreement: true,
+			Locale:    "en-us",
+			IP:        net.ParseIP("192.0.2.128"),
+		}
+	)
+
+	if err := suite.state.DB.PutEmailDomainBlock(ctx, block); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, errWithCode := suite.user.Create(ctx, app, form)
+	suite.EqualError(errWithCode, "sign-ups using email addresses at this domain are not permitted")
+}
+
 func TestCreateTestSuite(t *testing.T) {
 	suite.Run(t, &CreateTestSuite{})
 }
@@ -0,0 +1,91 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"errors"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/email"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+)
+
+// EmailGetUserForUnsubscribeToken retrieves the user (with account)
+// from the database for the given account ID, checking that the given
+// one-click unsubscribe token (from a notification email) is valid for it.
+func (p *Processor) EmailGetUserForUnsubscribeToken(ctx context.Context, accountID string, token string) (*gtsmodel.User, gtserror.WithCode) {
+	if accountID == "" || token == "" {
+		err := errors.New("no account id or token provided")
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	session, err := p.state.DB.GetSession(ctx)
+	if err != nil {
+		err := gtserror.Newf("db error getting router session: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if !email.ValidUnsubscribeToken(session.Auth, accountID, token) {
+		err := errors.New("invalid unsubscribe token")
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	user, err := p.state.DB.GetUserByAccountID(ctx, accountID)
+	if err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			// Real error.
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		// No user found for this account.
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return user, nil
+}
+
+// EmailUnsubscribe turns off notification emails for the
+// user with given account ID, usually initiated as a result
+// of clicking the one-click unsubscribe link in such an email.
+func (p *Processor) EmailUnsubscribe(ctx context.Context, accountID string, token string) (*gtsmodel.User, gtserror.WithCode) {
+	user, errWithCode := p.EmailGetUserForUnsubscribeToken(ctx, accountID, token)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	settings, err := p.state.DB.GetAccountSettings(ctx, accountID)
+	if err != nil {
+		err := gtserror.Newf("db error getting account settings: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if settings.EmailNotifications == gtsmodel.EmailNotificationsNone {
+		// Unsubscribed already, just return.
+		return user, nil
+	}
+
+	settings.EmailNotifications = gtsmodel.EmailNotificationsNone
+	if err := p.state.DB.UpdateAccountSettings(ctx, settings, "email_notifications"); err != nil {
+		err := gtserror.Newf("db error updating account settings: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return user, nil
+}
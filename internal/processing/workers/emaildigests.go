@@ -0,0 +1,63 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package workers
+
+import (
+	"context"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+)
+
+// How often to check for
+// due notification digests.
+const emailDigestsEvery = time.Hour
+
+// ScheduleEmailDigests schedules emailing notification
+// digests to accounts that have daily or weekly digests
+// enabled, checking every hour for digests that are due.
+func (p *Processor) ScheduleEmailDigests() error {
+	if !p.workers.Scheduler.AddRecurring(
+		"@emaildigests",
+		time.Time{}, // start
+		emailDigestsEvery,
+		p.surface.EmailNotificationDigests,
+	) {
+		return gtserror.New("failed to schedule @emaildigests")
+	}
+
+	return nil
+}
+
+// EmailNotificationDigests emails notification digests
+// that are due as of the given time, to all accounts
+// that have daily or weekly digests enabled.
+func (s *Surface) EmailNotificationDigests(ctx context.Context, now time.Time) {
+	settings, err := s.State.DB.GetEmailDigestAccountSettings(ctx)
+	if err != nil {
+		log.Errorf(ctx, "db error getting email digest account settings: %v", err)
+		return
+	}
+
+	for _, settings := range settings {
+		if err := s.emailUserNotificationDigest(ctx, settings, now); err != nil {
+			log.Errorf(ctx, "error emailing notification digest to account %s: %v", settings.AccountID, err)
+		}
+	}
+}
@@ -99,6 +99,10 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, cMsg *messages.Fro
 		case ap.ActivityLike:
 			return p.clientAPI.CreateLike(ctx, cMsg)
 
+		// CREATE EMOJI REACTION
+		case ap.ActivityEmojiReact:
+			return p.clientAPI.CreateEmojiReact(ctx, cMsg)
+
 		// CREATE ANNOUNCE/BOOST
 		case ap.ActivityAnnounce:
 			return p.clientAPI.CreateAnnounce(ctx, cMsg)
@@ -152,6 +156,10 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, cMsg *messages.Fro
 		// ACCEPT BOOST
 		case ap.ActivityAnnounce:
 			return p.clientAPI.AcceptAnnounce(ctx, cMsg)
+
+		// ACCEPT QUOTE
+		case ap.ActivityQuoteRequest:
+			return p.clientAPI.AcceptQuote(ctx, cMsg)
 		}
 
 	// REJECT SOMETHING
@@ -177,6 +185,10 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, cMsg *messages.Fro
 		// REJECT BOOST
 		case ap.ActivityAnnounce:
 			return p.clientAPI.RejectAnnounce(ctx, cMsg)
+
+		// REJECT QUOTE
+		case ap.ActivityQuoteRequest:
+			return p.clientAPI.RejectQuote(ctx, cMsg)
 		}
 
 	// UNDO SOMETHING
@@ -195,6 +207,10 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, cMsg *messages.Fro
 		case ap.ActivityLike:
 			return p.clientAPI.UndoFave(ctx, cMsg)
 
+		// UNDO EMOJI REACTION
+		case ap.ActivityEmojiReact:
+			return p.clientAPI.UndoEmojiReact(ctx, cMsg)
+
 		// UNDO ANNOUNCE/BOOST
 		case ap.ActivityAnnounce:
 			return p.clientAPI.UndoAnnounce(ctx, cMsg)
@@ -351,6 +367,17 @@ func (p *clientAPI) CreateStatus(ctx context.Context, cMsg *messages.FromClientA
 		// Don't return, just continue as normal.
 	}
 
+	// If the status quotes a status that requires
+	// approval for the quote, store an interaction
+	// request for it (if the quoted status is ours).
+	// The status is otherwise processed as normal,
+	// the quote is just hidden until it's approved.
+	if util.PtrOrZero(status.QuotePendingApproval) {
+		if err := p.utils.impoliteQuoteRequest(ctx, status); err != nil {
+			log.Errorf(ctx, "error pending quote: %v", err)
+		}
+	}
+
 	// Update stats for the actor account.
 	if err := p.utils.incrementStatusesCount(ctx, cMsg.Origin, status); err != nil {
 		log.Errorf(ctx, "error updating account stats: %v", err)
@@ -369,6 +396,9 @@ func (p *clientAPI) CreateStatus(ctx context.Context, cMsg *messages.FromClientA
 		if err := p.federate.CreateStatus(ctx, status); err != nil {
 			log.Errorf(ctx, "error federating status: %v", err)
 		}
+
+		// Fetch link preview card (async).
+		p.utils.fetchStatusCard(status)
 	}
 
 	if status.InReplyToID != "" {
@@ -780,6 +810,10 @@ func (p *clientAPI) UpdateStatus(ctx context.Context, cMsg *messages.FromClientA
 	// Status representation has changed, invalidate from timelines.
 	p.surface.invalidateStatusFromTimelines(status.ID)
 
+	// Links may have changed, refetch
+	// link preview card (async).
+	p.utils.fetchStatusCard(status)
+
 	return nil
 }
 
@@ -955,6 +989,36 @@ func (p *clientAPI) UndoFave(ctx context.Context, cMsg *messages.FromClientAPI)
 	return nil
 }
 
+func (p *clientAPI) CreateEmojiReact(ctx context.Context, cMsg *messages.FromClientAPI) error {
+	reaction, ok := cMsg.GTSModel.(*gtsmodel.StatusReaction)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.StatusReaction", cMsg.GTSModel)
+	}
+
+	if err := p.surface.notifyEmojiReaction(ctx, reaction); err != nil {
+		log.Errorf(ctx, "error notifying reaction: %v", err)
+	}
+
+	if err := p.federate.EmojiReact(ctx, reaction); err != nil {
+		log.Errorf(ctx, "error federating reaction: %v", err)
+	}
+
+	return nil
+}
+
+func (p *clientAPI) UndoEmojiReact(ctx context.Context, cMsg *messages.FromClientAPI) error {
+	reaction, ok := cMsg.GTSModel.(*gtsmodel.StatusReaction)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.StatusReaction", cMsg.GTSModel)
+	}
+
+	if err := p.federate.UndoEmojiReact(ctx, reaction); err != nil {
+		log.Errorf(ctx, "error federating reaction undo: %v", err)
+	}
+
+	return nil
+}
+
 func (p *clientAPI) UndoAnnounce(ctx context.Context, cMsg *messages.FromClientAPI) error {
 	status, ok := cMsg.GTSModel.(*gtsmodel.Status)
 	if !ok {
@@ -1323,6 +1387,31 @@ func (p *clientAPI) AcceptAnnounce(ctx context.Context, cMsg *messages.FromClien
 	return nil
 }
 
+func (p *clientAPI) AcceptQuote(ctx context.Context, cMsg *messages.FromClientAPI) error {
+	req, ok := cMsg.GTSModel.(*gtsmodel.InteractionRequest)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.InteractionRequest", cMsg.GTSModel)
+	}
+
+	quote := req.Quote
+
+	// Notify the quote (distinct from the notif for the pending quote).
+	if err := p.surface.notifyQuote(ctx, quote); err != nil {
+		log.Errorf(ctx, "error notifying quote: %v", err)
+	}
+
+	// Send out the Accept.
+	if err := p.federate.AcceptInteraction(ctx, req); err != nil {
+		log.Errorf(ctx, "error federating approval of quote: %v", err)
+	}
+
+	// The quote is now shown on the quoting status;
+	// uncache the prepared version from all timelines.
+	p.surface.invalidateStatusFromTimelines(quote.ID)
+
+	return nil
+}
+
 func (p *clientAPI) RejectLike(ctx context.Context, cMsg *messages.FromClientAPI) error {
 	req, ok := cMsg.GTSModel.(*gtsmodel.InteractionRequest)
 	if !ok {
@@ -1442,3 +1531,36 @@ func (p *clientAPI) RejectAnnounce(ctx context.Context, cMsg *messages.FromClien
 
 	return nil
 }
+
+func (p *clientAPI) RejectQuote(ctx context.Context, cMsg *messages.FromClientAPI) error {
+	req, ok := cMsg.GTSModel.(*gtsmodel.InteractionRequest)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.InteractionRequest", cMsg.GTSModel)
+	}
+
+	// At this point the InteractionRequest should already
+	// be in the database, we just need to do side effects.
+
+	// Send out the Reject.
+	if err := p.federate.RejectInteraction(ctx, req); err != nil {
+		log.Errorf(ctx, "error federating rejection of quote: %v", err)
+	}
+
+	// Get the rejected quote.
+	quote, err := p.state.DB.GetStatusByURI(
+		gtscontext.SetBarebones(ctx),
+		req.InteractionURI,
+	)
+	if err != nil {
+		return gtserror.Newf("db error getting rejected quote: %w", err)
+	}
+
+	// Unlike replies and boosts, we don't delete
+	// the quoting status, as it stands on its own.
+	// Just unlink it from the status it quoted.
+	if err := p.utils.unlinkQuote(ctx, quote); err != nil {
+		log.Errorf(ctx, "error unlinking quote: %v", err)
+	}
+
+	return nil
+}
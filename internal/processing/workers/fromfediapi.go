@@ -34,6 +34,7 @@
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
 	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/media"
 	"code.superseriousbusiness.org/gotosocial/internal/messages"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/account"
 	"code.superseriousbusiness.org/gotosocial/internal/processing/common"
@@ -104,6 +105,10 @@ func (p *Processor) ProcessFromFediAPI(ctx context.Context, fMsg *messages.FromF
 		case ap.ActivityLikeRequest:
 			return p.fediAPI.CreateLikeRequest(ctx, fMsg)
 
+		// CREATE EMOJI REACTION
+		case ap.ActivityEmojiReact:
+			return p.fediAPI.CreateEmojiReact(ctx, fMsg)
+
 		// CREATE ANNOUNCE/BOOST
 		case ap.ActivityAnnounce:
 			return p.fediAPI.CreateAnnounce(ctx, fMsg)
@@ -166,6 +171,10 @@ func (p *Processor) ProcessFromFediAPI(ctx context.Context, fMsg *messages.FromF
 		case ap.ActivityAnnounce:
 			return p.fediAPI.AcceptAnnounce(ctx, fMsg)
 
+		// ACCEPT (pending) QUOTE
+		case ap.ActivityQuoteRequest:
+			return p.fediAPI.AcceptQuote(ctx, fMsg)
+
 		// ACCEPT (remote) IMPOLITE REPLY or ANNOUNCE
 		case ap.ObjectUnknown:
 			return p.fediAPI.AcceptRemoteStatus(ctx, fMsg)
@@ -186,6 +195,10 @@ func (p *Processor) ProcessFromFediAPI(ctx context.Context, fMsg *messages.FromF
 		// REJECT BOOST
 		case ap.ActivityAnnounce:
 			return p.fediAPI.RejectAnnounce(ctx, fMsg)
+
+		// REJECT QUOTE
+		case ap.ActivityQuoteRequest:
+			return p.fediAPI.RejectQuote(ctx, fMsg)
 		}
 
 	// DELETE SOMETHING
@@ -369,8 +382,21 @@ func (p *fediAPI) CreateStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 		// Don't return, just continue as normal.
 	}
 
-	// Update stats for the remote account.
-	if err := p.utils.incrementStatusesCount(ctx, fMsg.Requesting, status); err != nil {
+	// If the status quotes one of our statuses
+	// that requires approval for the quote, store
+	// an interaction request for it and notify.
+	// The status is otherwise processed as normal,
+	// the quote is just hidden until it's approved.
+	if util.PtrOrZero(status.QuotePendingApproval) {
+		if err := p.utils.impoliteQuoteRequest(ctx, status); err != nil {
+			log.Errorf(ctx, "error pending quote: %v", err)
+		}
+	}
+
+	// Update stats for the remote account. Use the status
+	// author rather than requester, as these may differ for
+	// forwarded statuses, and statuses shared by relays.
+	if err := p.utils.incrementStatusesCount(ctx, status.Account, status); err != nil {
 		log.Errorf(ctx, "error updating account stats: %v", err)
 	}
 
@@ -378,6 +404,9 @@ func (p *fediAPI) CreateStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 		log.Errorf(ctx, "error timelining and notifying status: %v", err)
 	}
 
+	// Fetch link preview card (async).
+	p.utils.fetchStatusCard(status)
+
 	if status.InReplyToID != "" {
 		// Interaction counts changed on the replied status; uncache the
 		// prepared version from all timelines. The status dereferencer
@@ -732,6 +761,53 @@ func (p *fediAPI) CreateLike(ctx context.Context, fMsg *messages.FromFediAPI) er
 	return nil
 }
 
+// CreateEmojiReact handles an emoji reaction to one of
+// our statuses, dereferencing any custom emoji it uses.
+func (p *fediAPI) CreateEmojiReact(ctx context.Context, fMsg *messages.FromFediAPI) error {
+	reaction, ok := fMsg.GTSModel.(*gtsmodel.StatusReaction)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.StatusReaction", fMsg.GTSModel)
+	}
+
+	if placeholder := reaction.Emoji; placeholder != nil {
+		// Reaction uses a custom emoji, dereference
+		// it (or fetch it from the db if it's local).
+		emoji, err := p.federate.GetEmoji(ctx,
+			placeholder.Shortcode,
+			placeholder.Domain,
+			placeholder.ImageRemoteURL,
+			media.AdditionalEmojiInfo{
+				URI:                  &placeholder.URI,
+				ImageRemoteURL:       &placeholder.ImageRemoteURL,
+				ImageStaticRemoteURL: &placeholder.ImageStaticRemoteURL,
+			},
+			false,
+		)
+		if err != nil && emoji == nil {
+			return gtserror.Newf("error loading reaction emoji %s: %w", reaction.Name, err)
+		}
+
+		reaction.EmojiID = emoji.ID
+		reaction.Emoji = emoji
+	}
+
+	if err := p.state.DB.PutStatusReaction(ctx, reaction); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			// The reaction already exists in the
+			// database, which means we've already
+			// handled side effects.
+			return nil
+		}
+		return gtserror.Newf("db error putting reaction %s: %w", reaction.URI, err)
+	}
+
+	if err := p.surface.notifyEmojiReaction(ctx, reaction); err != nil {
+		log.Errorf(ctx, "error notifying reaction: %v", err)
+	}
+
+	return nil
+}
+
 // CreateLikeRequest handles a polite LikeRequest, as
 // opposed to CreateLike, which handles *impolite* like
 // requests (ie., Likes sent directly).
@@ -1192,6 +1268,19 @@ func (p *fediAPI) AcceptReply(ctx context.Context, fMsg *messages.FromFediAPI) e
 	return nil
 }
 
+func (p *fediAPI) AcceptQuote(ctx context.Context, fMsg *messages.FromFediAPI) error {
+	quote, ok := fMsg.GTSModel.(*gtsmodel.Status)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.Status", fMsg.GTSModel)
+	}
+
+	// The quote is now shown on the quoting status;
+	// uncache the prepared version from all timelines.
+	p.surface.invalidateStatusFromTimelines(quote.ID)
+
+	return nil
+}
+
 func (p *fediAPI) AcceptRemoteStatus(ctx context.Context, fMsg *messages.FromFediAPI) error {
 	// See if we can accept a remote
 	// status we don't have stored yet.
@@ -1406,6 +1495,10 @@ func (p *fediAPI) UpdateStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 	// Status representation changed, uncache from timelines.
 	p.surface.invalidateStatusFromTimelines(status.ID)
 
+	// Links may have changed, refetch
+	// link preview card (async).
+	p.utils.fetchStatusCard(status)
+
 	return nil
 }
 
@@ -1415,6 +1508,23 @@ func (p *fediAPI) DeleteStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 		return gtserror.Newf("%T not parseable as *gtsmodel.Status", fMsg.GTSModel)
 	}
 
+	if fMsg.APIRI != nil {
+		// This is a forwarded delete, only
+		// proceed if verified by origin server.
+		gone, err := p.federate.DereferenceGone(ctx,
+			fMsg.Receiving.Username,
+			fMsg.APIRI,
+		)
+		if err != nil {
+			return gtserror.Newf("error verifying forwarded delete of %s: %w", fMsg.APIRI, err)
+		}
+
+		if !gone {
+			log.Infof(ctx, "ignoring unverified forwarded delete of %s", fMsg.APIRI)
+			return nil
+		}
+	}
+
 	// Try to populate status structs if possible,
 	// in order to more thoroughly remove them.
 	if err := p.state.DB.PopulateStatus(
@@ -1455,9 +1565,12 @@ func (p *fediAPI) DeleteStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 		log.Errorf(ctx, "error wiping status: %v", err)
 	}
 
-	// Update stats for the remote account.
-	if err := p.utils.decrementStatusesCount(ctx, fMsg.Requesting, status); err != nil {
-		log.Errorf(ctx, "error updating account stats: %v", err)
+	// Update stats for the remote account. Note this
+	// may not be the requester, if delete was forwarded.
+	if status.Account != nil {
+		if err := p.utils.decrementStatusesCount(ctx, status.Account, status); err != nil {
+			log.Errorf(ctx, "error updating account stats: %v", err)
+		}
 	}
 
 	if status.InReplyToID != "" {
@@ -1475,6 +1588,23 @@ func (p *fediAPI) DeleteAccount(ctx context.Context, fMsg *messages.FromFediAPI)
 		return gtserror.Newf("%T not parseable as *gtsmodel.Account", fMsg.GTSModel)
 	}
 
+	if fMsg.APIRI != nil {
+		// This is a forwarded delete, only
+		// proceed if verified by origin server.
+		gone, err := p.federate.DereferenceGone(ctx,
+			fMsg.Receiving.Username,
+			fMsg.APIRI,
+		)
+		if err != nil {
+			return gtserror.Newf("error verifying forwarded delete of %s: %w", fMsg.APIRI, err)
+		}
+
+		if !gone {
+			log.Infof(ctx, "ignoring unverified forwarded delete of %s", fMsg.APIRI)
+			return nil
+		}
+	}
+
 	// Drop any outgoing queued AP requests to / from / targeting
 	// this account, (stops queued likes, boosts, creates etc).
 	p.state.Workers.Delivery.Queue.Delete("ObjectID", account.URI)
@@ -1611,6 +1741,33 @@ func (p *fediAPI) RejectAnnounce(ctx context.Context, fMsg *messages.FromFediAPI
 	return nil
 }
 
+func (p *fediAPI) RejectQuote(ctx context.Context, fMsg *messages.FromFediAPI) error {
+	req, ok := fMsg.GTSModel.(*gtsmodel.InteractionRequest)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.InteractionRequest", fMsg.GTSModel)
+	}
+
+	// At this point the InteractionRequest should already
+	// be in the database, we just need to do side effects.
+
+	// Get the rejected quote.
+	quote, err := p.state.DB.GetStatusByURI(
+		gtscontext.SetBarebones(ctx),
+		req.InteractionURI,
+	)
+	if err != nil {
+		return gtserror.Newf("db error getting rejected quote: %w", err)
+	}
+
+	// Don't delete the quoting status,
+	// just unlink it from the quoted status.
+	if err := p.utils.unlinkQuote(ctx, quote); err != nil {
+		log.Errorf(ctx, "error unlinking quote: %v", err)
+	}
+
+	return nil
+}
+
 func (p *fediAPI) UndoFollow(ctx context.Context, fMsg *messages.FromFediAPI) error {
 	follow, ok := fMsg.GTSModel.(*gtsmodel.Follow)
 	if !ok {
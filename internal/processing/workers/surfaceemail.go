@@ -27,6 +27,9 @@
 	"code.superseriousbusiness.org/gotosocial/internal/email"
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/paging"
+	"code.superseriousbusiness.org/gotosocial/internal/text"
 	"code.superseriousbusiness.org/gotosocial/internal/uris"
 	"github.com/google/uuid"
 )
@@ -269,3 +272,295 @@ func (s *Surface) emailAdminNewSignup(ctx context.Context, newUser *gtsmodel.Use
 
 	return nil
 }
+
+// emailUserNotification emails the target account of the
+// given notification about it, if they've opted to receive
+// notifications of this type by email as they arrive.
+func (s *Surface) emailUserNotification(ctx context.Context, notif *gtsmodel.Notification) error {
+	settings, err := s.State.DB.GetAccountSettings(ctx, notif.TargetAccountID)
+	if err != nil {
+		return gtserror.Newf("db error getting account settings: %w", err)
+	}
+
+	if settings.EmailNotifications != gtsmodel.EmailNotificationsImmediate ||
+		!settings.EmailNotificationTypes.Get(notif.NotificationType) {
+		// User doesn't want
+		// this emailed now.
+		return nil
+	}
+
+	user, err := s.State.DB.GetUserByAccountID(ctx, notif.TargetAccountID)
+	if err != nil {
+		return gtserror.Newf("db error getting user: %w", err)
+	}
+
+	if !canEmailUser(user) {
+		return nil
+	}
+
+	instance, err := s.State.DB.GetInstance(ctx, config.GetHost())
+	if err != nil {
+		return gtserror.Newf("db error getting instance: %w", err)
+	}
+
+	item, err := s.emailNotificationItem(ctx, notif)
+	if err != nil {
+		return err
+	}
+
+	unsubscribeURL, err := s.emailUnsubscribeURL(ctx, notif.TargetAccountID)
+	if err != nil {
+		return err
+	}
+
+	// Assemble email contents and send the email.
+	if err := s.EmailSender.SendNotificationEmail(
+		user.Email,
+		email.NotificationData{
+			Username:       user.Account.Username,
+			InstanceURL:    instance.URI,
+			InstanceName:   instance.Title,
+			Notification:   item,
+			UnsubscribeURL: unsubscribeURL,
+		},
+	); err != nil {
+		return err
+	}
+
+	// Email sent, update the user
+	// entry with the emailed time.
+	user.LastEmailedAt = time.Now()
+	if err := s.State.DB.UpdateUser(ctx, user, "last_emailed_at"); err != nil {
+		return gtserror.Newf("error updating user entry after email sent: %w", err)
+	}
+
+	return nil
+}
+
+// emailUserNotificationDigest emails the account owning
+// the given settings a digest of notifications they've
+// received since their last digest, if one is now due.
+func (s *Surface) emailUserNotificationDigest(
+	ctx context.Context,
+	settings *gtsmodel.AccountSettings,
+	now time.Time,
+) error {
+	period := settings.EmailNotifications.DigestPeriod()
+	if period == 0 {
+		// Digests not enabled.
+		return nil
+	}
+
+	since := settings.EmailDigestSentAt
+	if since.IsZero() {
+		// No digest sent yet, cover
+		// one period back from now.
+		since = now.Add(-period)
+	} else if now.Sub(since) < period {
+		// Not due yet.
+		return nil
+	}
+
+	user, err := s.State.DB.GetUserByAccountID(ctx, settings.AccountID)
+	if err != nil {
+		return gtserror.Newf("db error getting user: %w", err)
+	}
+
+	if !canEmailUser(user) {
+		return nil
+	}
+
+	// Get (unfiltered) notifications of selected
+	// types created since the last digest was sent.
+	notifs, err := s.State.DB.GetAccountNotifications(ctx,
+		settings.AccountID,
+		&paging.Page{
+			Min:   paging.EitherMinID("", id.NewULIDFromTime(since)),
+			Limit: emailDigestMaxNotifications,
+		},
+		settings.EmailNotificationTypes.ToSlice(),
+		nil,   // No excluded types.
+		"",    // Any origin account.
+		false, // Exclude filtered.
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting notifications: %w", err)
+	}
+
+	items := make([]email.NotificationItem, 0, len(notifs))
+	for _, notif := range notifs {
+		muted, err := s.notificationMuted(ctx, user.Account, notif)
+		if err != nil {
+			return err
+		}
+
+		if muted {
+			continue
+		}
+
+		item, err := s.emailNotificationItem(ctx, notif)
+		if err != nil {
+			return err
+		}
+
+		items = append(items, item)
+	}
+
+	if len(items) > 0 {
+		instance, err := s.State.DB.GetInstance(ctx, config.GetHost())
+		if err != nil {
+			return gtserror.Newf("db error getting instance: %w", err)
+		}
+
+		unsubscribeURL, err := s.emailUnsubscribeURL(ctx, settings.AccountID)
+		if err != nil {
+			return err
+		}
+
+		// Only list so many notifications
+		// in the email, summarize the rest.
+		var more int
+		if len(items) > emailDigestMaxItems {
+			more = len(items) - emailDigestMaxItems
+			items = items[:emailDigestMaxItems]
+		}
+
+		// Assemble email contents and send the email.
+		if err := s.EmailSender.SendNotificationDigestEmail(
+			user.Email,
+			email.NotificationDigestData{
+				Username:       user.Account.Username,
+				InstanceURL:    instance.URI,
+				InstanceName:   instance.Title,
+				Period:         settings.EmailNotifications.String(),
+				Notifications:  items,
+				More:           more,
+				UnsubscribeURL: unsubscribeURL,
+			},
+		); err != nil {
+			return err
+		}
+
+		// Email sent, update the user
+		// entry with the emailed time.
+		user.LastEmailedAt = now
+		if err := s.State.DB.UpdateUser(ctx, user, "last_emailed_at"); err != nil {
+			return gtserror.Newf("error updating user entry after email sent: %w", err)
+		}
+	}
+
+	// Digest period covered (even if there
+	// was nothing to send), mark it as sent.
+	settings.EmailDigestSentAt = now
+	if err := s.State.DB.UpdateAccountSettings(ctx, settings, "email_digest_sent_at"); err != nil {
+		return gtserror.Newf("error updating account settings after digest sent: %w", err)
+	}
+
+	return nil
+}
+
+const (
+	// Max number of notifications to fetch for a digest.
+	emailDigestMaxNotifications = 500
+
+	// Max number of notifications to list in a digest email.
+	emailDigestMaxItems = 25
+
+	// Max length of status text to include in notification emails.
+	emailStatusTextMaxLength = 500
+)
+
+// emailNotificationItem converts the given
+// notification for inclusion in an email.
+func (s *Surface) emailNotificationItem(ctx context.Context, notif *gtsmodel.Notification) (email.NotificationItem, error) {
+	if err := s.State.DB.PopulateNotification(ctx, notif); err != nil {
+		return email.NotificationItem{}, gtserror.Newf("error populating notification: %w", err)
+	}
+
+	name := notif.OriginAccount.DisplayName
+	if name == "" {
+		name = notif.OriginAccount.Username
+	}
+
+	item := email.NotificationItem{
+		Type:          notif.NotificationType.String(),
+		AccountName:   name,
+		AccountHandle: notif.OriginAccount.UsernameDomain(),
+		AccountURL:    notif.OriginAccount.URL,
+	}
+
+	if status := notif.Status; status != nil {
+		item.StatusURL = status.URL
+		item.StatusContentWarning = text.StripHTMLFromText(status.ContentWarning)
+		if item.StatusContentWarning == "" {
+			statusText := []rune(text.StripHTMLFromText(status.Content))
+			if len(statusText) > emailStatusTextMaxLength {
+				statusText = append(statusText[:emailStatusTextMaxLength], '…')
+			}
+			item.StatusText = string(statusText)
+		}
+	}
+
+	return item, nil
+}
+
+// notificationMuted returns whether the given notification
+// is muted by the target account, either by muting the
+// origin account or the status the notification is about.
+func (s *Surface) notificationMuted(
+	ctx context.Context,
+	target *gtsmodel.Account,
+	notif *gtsmodel.Notification,
+) (bool, error) {
+	if err := s.State.DB.PopulateNotification(ctx, notif); err != nil {
+		return false, gtserror.Newf("error populating notification: %w", err)
+	}
+
+	muted, err := s.MuteFilter.AccountNotificationsMuted(ctx,
+		target,
+		notif.OriginAccount,
+	)
+	if err != nil {
+		return false, gtserror.Newf("error checking account mute: %w", err)
+	}
+
+	if muted || notif.Status == nil {
+		return muted, nil
+	}
+
+	muted, err = s.MuteFilter.StatusNotificationsMuted(ctx,
+		target,
+		notif.Status,
+	)
+	if err != nil {
+		return false, gtserror.Newf("error checking status mute: %w", err)
+	}
+
+	return muted, nil
+}
+
+// emailUnsubscribeURL returns a signed link to
+// unsubscribe the given account from notification
+// emails in one click, without being logged in.
+func (s *Surface) emailUnsubscribeURL(ctx context.Context, accountID string) (string, error) {
+	session, err := s.State.DB.GetSession(ctx)
+	if err != nil {
+		return "", gtserror.Newf("db error getting router session: %w", err)
+	}
+
+	token := email.UnsubscribeToken(session.Auth, accountID)
+	return uris.GenerateURIForEmailUnsubscribe(accountID, token), nil
+}
+
+// canEmailUser returns whether the given user can be
+// sent notification emails, ie., whether they:
+//   - are confirmed
+//   - are approved
+//   - are not disabled
+//   - have an email address
+func canEmailUser(user *gtsmodel.User) bool {
+	return !user.ConfirmedAt.IsZero() &&
+		*user.Approved &&
+		!*user.Disabled &&
+		user.Email != ""
+}
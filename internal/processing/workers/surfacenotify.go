@@ -20,7 +20,9 @@
 import (
 	"context"
 	"errors"
+	"slices"
 	"strings"
+	"time"
 
 	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
 	"code.superseriousbusiness.org/gotosocial/internal/db"
@@ -359,6 +361,59 @@ func (s *Surface) notifyableFave(
 	return true, nil
 }
 
+// notifyEmojiReaction notifies the target of the given
+// reaction that their status has been reacted to.
+func (s *Surface) notifyEmojiReaction(
+	ctx context.Context,
+	reaction *gtsmodel.StatusReaction,
+) error {
+	if reaction.TargetAccountID == reaction.AccountID {
+		// Self-reaction, nothing to do.
+		return nil
+	}
+
+	// Beforehand, ensure the passed reaction is fully populated.
+	if err := s.State.DB.PopulateStatusReaction(ctx, reaction); err != nil {
+		return gtserror.Newf("error populating reaction %s: %w", reaction.ID, err)
+	}
+
+	if reaction.TargetAccount.IsRemote() {
+		// no need to notify
+		// remote accounts.
+		return nil
+	}
+
+	// Ensure reactee hasn't
+	// muted the thread.
+	muted, err := s.State.DB.IsThreadMutedByAccount(ctx,
+		reaction.Status.ThreadID,
+		reaction.TargetAccountID,
+	)
+	if err != nil {
+		return gtserror.Newf("error checking status thread mute %s: %w", reaction.StatusID, err)
+	}
+
+	if muted {
+		// Reactee doesn't want
+		// notifs for this thread.
+		return nil
+	}
+
+	// notify status author
+	// of reaction by account.
+	if err := s.Notify(ctx,
+		gtsmodel.NotificationEmojiReaction,
+		reaction.TargetAccount,
+		reaction.Account,
+		reaction.Status,
+		nil,
+	); err != nil {
+		return gtserror.Newf("error notifying status author %s: %w", reaction.TargetAccountID, err)
+	}
+
+	return nil
+}
+
 // notifyAnnounce notifies the status boost target
 // account that their status has been boosted.
 func (s *Surface) notifyAnnounce(
@@ -470,6 +525,123 @@ func (s *Surface) notifyableAnnounce(
 	return true, nil
 }
 
+// notifyQuote notifies the quoted status
+// account that their status has been quoted.
+func (s *Surface) notifyQuote(
+	ctx context.Context,
+	quote *gtsmodel.Status,
+) error {
+	notifyable, err := s.notifyableQuote(ctx, quote)
+	if err != nil {
+		return err
+	}
+
+	if !notifyable {
+		// Nothing to do.
+		return nil
+	}
+
+	// notify status author
+	// of quote by account.
+	if err := s.Notify(ctx,
+		gtsmodel.NotificationQuote,
+		quote.QuoteOfAccount,
+		quote.Account,
+		quote,
+		nil,
+	); err != nil {
+		return gtserror.Newf("error notifying quote target %s: %w", quote.QuoteOfAccountID, err)
+	}
+
+	return nil
+}
+
+// notifyPendingQuote notifies the quoted status
+// account that their status has been quoted,
+// and that the quote requires approval.
+func (s *Surface) notifyPendingQuote(
+	ctx context.Context,
+	quote *gtsmodel.Status,
+) error {
+	notifyable, err := s.notifyableQuote(ctx, quote)
+	if err != nil {
+		return err
+	}
+
+	if !notifyable {
+		// Nothing to do.
+		return nil
+	}
+
+	// notify status author
+	// of quote by account.
+	if err := s.Notify(ctx,
+		gtsmodel.NotificationPendingQuote,
+		quote.QuoteOfAccount,
+		quote.Account,
+		quote,
+		nil,
+	); err != nil {
+		return gtserror.Newf("error notifying quote target %s: %w", quote.QuoteOfAccountID, err)
+	}
+
+	return nil
+}
+
+// notifyableQuote checks that the given
+// quote should be notified, taking account
+// of localness of receiving account, and mutes.
+func (s *Surface) notifyableQuote(
+	ctx context.Context,
+	status *gtsmodel.Status,
+) (bool, error) {
+	if status.QuoteOfID == "" {
+		// Not a quote, nothing to do.
+		return false, nil
+	}
+
+	if status.QuoteOfAccountID == status.AccountID {
+		// Self-quote, nothing to do.
+		return false, nil
+	}
+
+	// Beforehand, ensure the passed status is fully populated.
+	if err := s.State.DB.PopulateStatus(ctx, status); err != nil {
+		return false, gtserror.Newf("error populating status %s: %w", status.ID, err)
+	}
+
+	if status.QuoteOf == nil {
+		// Quoted status has
+		// since been deleted.
+		return false, nil
+	}
+
+	if status.QuoteOfAccount.IsRemote() {
+		// no need to notify
+		// remote accounts.
+		return false, nil
+	}
+
+	// Ensure quotee hasn't
+	// muted the thread.
+	muted, err := s.State.DB.IsThreadMutedByAccount(ctx,
+		status.QuoteOf.ThreadID,
+		status.QuoteOfAccountID,
+	)
+
+	if err != nil {
+		return false, gtserror.Newf("error checking status thread mute %s: %w", status.QuoteOfID, err)
+	}
+
+	if muted {
+		// Quotee doesn't want
+		// notifs for this thread.
+		return false, nil
+	}
+
+	return true, nil
+}
+
 func (s *Surface) notifyPollClose(ctx context.Context, status *gtsmodel.Status) error {
 	// Beforehand, ensure the passed status is fully populated.
 	if err := s.State.DB.PopulateStatus(ctx, status); err != nil {
@@ -611,6 +783,168 @@ func(v gtsmodel.Interaction) string {
 	return errs.Combine()
 }
 
+// notifyPolicyExemptTypes are notification types
+// that are never filtered by notification policies.
+var notifyPolicyExemptTypes = []gtsmodel.NotificationType{
+	gtsmodel.NotificationPoll,
+	gtsmodel.NotificationAdminSignup,
+	gtsmodel.NotificationAdminReport,
+	gtsmodel.NotificationUpdate,
+}
+
+// notifyNewAccountAge is the age below which origin accounts are
+// considered to be new accounts by notification policies.
+const notifyNewAccountAge = 30 * 24 * time.Hour
+
+// notifyPolicy returns how a notification of the given type should be
+// handled according to the notification policy of targetAccount, ie.,
+// whether it should be accepted, filtered into a notification request,
+// or dropped. Where multiple policy conditions match, the strictest
+// handling of the matching conditions is returned.
+func (s *Surface) notifyPolicy(
+	ctx context.Context,
+	notificationType gtsmodel.NotificationType,
+	targetAccount *gtsmodel.Account,
+	originAccount *gtsmodel.Account,
+	status *gtsmodel.Status,
+) (gtsmodel.NotificationPolicyValue, error) {
+	if targetAccount.ID == originAccount.ID ||
+		slices.Contains(notifyPolicyExemptTypes, notificationType) {
+		// Nothing to check.
+		return gtsmodel.NotificationPolicyAccept, nil
+	}
+
+	// Check if target previously accepted
+	// a notification request from origin.
+	permitted, err := s.State.DB.IsNotificationPermitted(ctx,
+		targetAccount.ID,
+		originAccount.ID,
+	)
+	if err != nil {
+		return 0, gtserror.Newf("error checking notification permission: %w", err)
+	}
+
+	if permitted {
+		return gtsmodel.NotificationPolicyAccept, nil
+	}
+
+	policy, err := s.State.DB.GetNotificationPolicy(ctx, targetAccount.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return 0, gtserror.Newf("error getting notification policy: %w", err)
+	}
+
+	if policy == nil {
+		// No policy set,
+		// use the default.
+		policy = gtsmodel.DefaultNotificationPolicy(targetAccount.ID)
+	}
+
+	following, err := s.State.DB.IsFollowing(ctx,
+		targetAccount.ID,
+		originAccount.ID,
+	)
+	if err != nil {
+		return 0, gtserror.Newf("error checking follow: %w", err)
+	}
+
+	followedBy, err := s.State.DB.IsFollowing(ctx,
+		originAccount.ID,
+		targetAccount.ID,
+	)
+	if err != nil {
+		return 0, gtserror.Newf("error checking follow: %w", err)
+	}
+
+	// Private mentions are unsolicited if
+	// they're not a reply to the target.
+	privateMention := notificationType == gtsmodel.NotificationMention &&
+		status != nil &&
+		status.Visibility == gtsmodel.VisibilityDirect &&
+		status.InReplyToAccountID != targetAccount.ID
+
+	result := gtsmodel.NotificationPolicyAccept
+	for _, cond := range []struct {
+		match bool
+		value gtsmodel.NotificationPolicyValue
+	}{
+		{!following, policy.ForNotFollowing},
+		{!followedBy, policy.ForNotFollowers},
+		{time.Since(originAccount.CreatedAt) < notifyNewAccountAge, policy.ForNewAccounts},
+		{!following && privateMention, policy.ForPrivateMentions},
+		{!following && originAccount.IsSilenced(), policy.ForLimitedAccounts},
+	} {
+		// Policy values are ordered by
+		// strictness: accept, filter, drop.
+		if cond.match && cond.value > result {
+			result = cond.value
+		}
+	}
+
+	return result, nil
+}
+
+// putNotificationRequest adds the given filtered notification to the
+// notification request for its target and origin accounts, creating
+// the notification request if it doesn't exist yet.
+func (s *Surface) putNotificationRequest(
+	ctx context.Context,
+	notif *gtsmodel.Notification,
+	status *gtsmodel.Status,
+) error {
+	// Lock on this combo of request params, as
+	// notifs from origin may arrive concurrently.
+	unlock := s.State.ProcessingLocks.Lock(
+		"notification_request:?targetAcct=" + notif.TargetAccount.URI +
+			"&originAcct=" + notif.OriginAccount.URI,
+	)
+	defer unlock()
+
+	request, err := s.State.DB.GetNotificationRequest(
+		gtscontext.SetBarebones(ctx),
+		notif.TargetAccountID,
+		notif.OriginAccountID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting notification request: %w", err)
+	}
+
+	if request == nil {
+		// First filtered notif from
+		// origin, create new request.
+		request = &gtsmodel.NotificationRequest{
+			ID:                 id.NewULID(),
+			AccountID:          notif.TargetAccountID,
+			FromAccountID:      notif.OriginAccountID,
+			NotificationsCount: 1,
+		}
+
+		if status != nil {
+			request.LastStatusID = status.ID
+		}
+
+		if err := s.State.DB.PutNotificationRequest(ctx, request); err != nil {
+			return gtserror.Newf("error putting notification request: %w", err)
+		}
+
+		return nil
+	}
+
+	// Add notif to existing request.
+	request.NotificationsCount++
+	columns := []string{"notifications_count"}
+
+	if status != nil {
+		request.LastStatusID = status.ID
+		columns = append(columns, "last_status_id")
+	}
+
+	if err := s.State.DB.UpdateNotificationRequest(ctx, request, columns...); err != nil {
+		return gtserror.Newf("error updating notification request: %w", err)
+	}
+
+	return nil
+}
+
 func getNotifyLockURI(
 	notificationType gtsmodel.NotificationType,
 	targetAccount *gtsmodel.Account,
@@ -693,6 +1027,23 @@ func (s *Surface) Notify(
 		return gtserror.Newf("error checking existence of notification: %w", err)
 	}
 
+	// Check how the target account's notification
+	// policy says to handle notifs from this origin.
+	policy, err := s.notifyPolicy(ctx,
+		notificationType,
+		targetAccount,
+		originAccount,
+		status,
+	)
+	if err != nil {
+		return err
+	}
+
+	if policy == gtsmodel.NotificationPolicyDrop {
+		// Don't notify.
+		return nil
+	}
+
 	// Notification doesn't yet exist, so
 	// we need to create + store one.
 	notif := &gtsmodel.Notification{
@@ -703,6 +1054,7 @@ func (s *Surface) Notify(
 		OriginAccountID:  originAccount.ID,
 		OriginAccount:    originAccount,
 		StatusOrEditID:   statusOrEditID,
+		Filtered:         util.Ptr(policy == gtsmodel.NotificationPolicyFilter),
 	}
 
 	if err := s.State.DB.PutNotification(ctx, notif); err != nil {
@@ -713,6 +1065,12 @@ func (s *Surface) Notify(
 	// with the state-y stuff.
 	unlock()
 
+	if *notif.Filtered {
+		// Collect filtered notif into a notification
+		// request, instead of streaming / pushing it.
+		return s.putNotificationRequest(ctx, notif, status)
+	}
+
 	// Check whether origin account is muted by target account.
 	muted, err := s.MuteFilter.AccountNotificationsMuted(ctx,
 		targetAccount,
@@ -782,5 +1140,10 @@ func (s *Surface) Notify(
 		return gtserror.Newf("error sending Web Push notifications: %w", err)
 	}
 
+	// Email notification to the user, if they want it.
+	if err := s.emailUserNotification(ctx, notif); err != nil {
+		return gtserror.Newf("error emailing notification: %w", err)
+	}
+
 	return nil
 }
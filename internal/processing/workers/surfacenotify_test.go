@@ -93,6 +93,7 @@ func (suite *SurfaceNotifyTestSuite) TestSpamNotifs() {
 		gtscontext.SetBarebones(ctx),
 		targetAccount.ID,
 		nil, nil, nil,
+		"", false,
 	)
 	if err != nil {
 		suite.FailNow(err.Error())
@@ -115,6 +116,221 @@ func (suite *SurfaceNotifyTestSuite) TestSpamNotifs() {
 	}
 }
 
+func (suite *SurfaceNotifyTestSuite) TestFilteredNotifs() {
+	testStructs := testrig.SetupTestStructs(rMediaPath, rTemplatePath)
+	defer testrig.TearDownTestStructs(testStructs)
+
+	surface := &workers.Surface{
+		State:         testStructs.State,
+		Converter:     testStructs.TypeConverter,
+		Stream:        testStructs.Processor.Stream(),
+		VisFilter:     visibility.NewFilter(testStructs.State),
+		MuteFilter:    mutes.NewFilter(testStructs.State),
+		EmailSender:   testStructs.EmailSender,
+		WebPushSender: testStructs.WebPushSender,
+		Conversations: testStructs.Processor.Conversations(),
+	}
+
+	var (
+		ctx           = suite.T().Context()
+		targetAccount = suite.testAccounts["local_account_1"]
+		originAccount = suite.testAccounts["remote_account_1"]
+	)
+
+	// Filter notifications from
+	// accounts target doesn't follow.
+	policy := gtsmodel.DefaultNotificationPolicy(targetAccount.ID)
+	policy.ForNotFollowing = gtsmodel.NotificationPolicyFilter
+	if err := testStructs.State.DB.PutNotificationPolicy(ctx, policy); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	for _, notificationType := range []gtsmodel.NotificationType{
+		gtsmodel.NotificationFollowRequest,
+		gtsmodel.NotificationFollow,
+	} {
+		if err := surface.Notify(ctx,
+			notificationType,
+			targetAccount,
+			originAccount,
+			nil,
+			nil,
+		); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Both notifications should
+	// be held in one request.
+	request, err := testStructs.State.DB.GetNotificationRequest(ctx,
+		targetAccount.ID,
+		originAccount.ID,
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(2, request.NotificationsCount)
+
+	// Filtered notifications should
+	// only be returned when requested.
+	for _, includeFiltered := range []bool{false, true} {
+		notifs, err := testStructs.State.DB.GetAccountNotifications(
+			gtscontext.SetBarebones(ctx),
+			targetAccount.ID,
+			nil, nil, nil,
+			originAccount.ID,
+			includeFiltered,
+		)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		if !includeFiltered {
+			suite.Empty(notifs)
+			continue
+		}
+
+		suite.Len(notifs, 2)
+		for _, notif := range notifs {
+			suite.True(*notif.Filtered)
+		}
+	}
+}
+
+func (suite *SurfaceNotifyTestSuite) TestEmailNotifs() {
+	testStructs := testrig.SetupTestStructs(rMediaPath, rTemplatePath)
+	defer testrig.TearDownTestStructs(testStructs)
+
+	sentEmails := make(map[string]string)
+	surface := &workers.Surface{
+		State:         testStructs.State,
+		Converter:     testStructs.TypeConverter,
+		Stream:        testStructs.Processor.Stream(),
+		VisFilter:     visibility.NewFilter(testStructs.State),
+		MuteFilter:    mutes.NewFilter(testStructs.State),
+		EmailSender:   testrig.NewEmailSender(rTemplatePath, sentEmails),
+		WebPushSender: testStructs.WebPushSender,
+		Conversations: testStructs.Processor.Conversations(),
+	}
+
+	var (
+		ctx           = suite.T().Context()
+		targetAccount = suite.testAccounts["local_account_1"]
+		originAccount = suite.testAccounts["local_account_2"]
+		targetUser    = suite.testUsers["local_account_1"]
+	)
+
+	// Have follows emailed as they arrive.
+	settings, err := testStructs.State.DB.GetAccountSettings(ctx, targetAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	settings.EmailNotifications = gtsmodel.EmailNotificationsImmediate
+	settings.EmailNotificationTypes = gtsmodel.NotificationFlagsFromSlice(
+		[]gtsmodel.NotificationType{gtsmodel.NotificationFollow},
+	)
+	if err := testStructs.State.DB.UpdateAccountSettings(ctx, settings); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Only the follow should be emailed,
+	// the follow request type wasn't selected.
+	for _, notificationType := range []gtsmodel.NotificationType{
+		gtsmodel.NotificationFollow,
+		gtsmodel.NotificationFollowRequest,
+	} {
+		if err := surface.Notify(ctx,
+			notificationType,
+			targetAccount,
+			originAccount,
+			nil,
+			nil,
+		); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	suite.Len(sentEmails, 1)
+	sent := sentEmails[targetUser.Email]
+	suite.Contains(sent, "Subject: GoToSocial New Notification")
+	suite.Contains(sent, "List-Unsubscribe: <http://localhost:8080/unsubscribe?account="+targetAccount.ID+"&token=")
+	suite.Contains(sent, "(@1happyturtle) followed you.")
+	suite.NotContains(sent, "requested to follow you.")
+}
+
+func (suite *SurfaceNotifyTestSuite) TestEmailNotificationDigests() {
+	testStructs := testrig.SetupTestStructs(rMediaPath, rTemplatePath)
+	defer testrig.TearDownTestStructs(testStructs)
+
+	sentEmails := make(map[string]string)
+	surface := &workers.Surface{
+		State:         testStructs.State,
+		Converter:     testStructs.TypeConverter,
+		Stream:        testStructs.Processor.Stream(),
+		VisFilter:     visibility.NewFilter(testStructs.State),
+		MuteFilter:    mutes.NewFilter(testStructs.State),
+		EmailSender:   testrig.NewEmailSender(rTemplatePath, sentEmails),
+		WebPushSender: testStructs.WebPushSender,
+		Conversations: testStructs.Processor.Conversations(),
+	}
+
+	var (
+		ctx           = suite.T().Context()
+		targetAccount = suite.testAccounts["local_account_1"]
+		originAccount = suite.testAccounts["local_account_2"]
+		targetUser    = suite.testUsers["local_account_1"]
+		now           = time.Now()
+	)
+
+	// Have follows emailed in a daily
+	// digest, last sent over a day ago.
+	settings, err := testStructs.State.DB.GetAccountSettings(ctx, targetAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	settings.EmailNotifications = gtsmodel.EmailNotificationsDaily
+	settings.EmailNotificationTypes = gtsmodel.NotificationFlagsFromSlice(
+		[]gtsmodel.NotificationType{gtsmodel.NotificationFollow},
+	)
+	settings.EmailDigestSentAt = now.Add(-25 * time.Hour)
+	if err := testStructs.State.DB.UpdateAccountSettings(ctx, settings); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := surface.Notify(ctx,
+		gtsmodel.NotificationFollow,
+		targetAccount,
+		originAccount,
+		nil,
+		nil,
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Nothing should be emailed right away.
+	suite.Empty(sentEmails)
+
+	// Digest should be sent now it's due.
+	surface.EmailNotificationDigests(ctx, now)
+	suite.Len(sentEmails, 1)
+	sent := sentEmails[targetUser.Email]
+	suite.Contains(sent, "Subject: GoToSocial Notifications Digest")
+	suite.Contains(sent, "Here's your daily digest")
+	suite.Contains(sent, "(@1happyturtle) followed you.")
+
+	// Digest should be marked as sent.
+	settings, err = testStructs.State.DB.GetAccountSettings(ctx, targetAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.WithinDuration(now, settings.EmailDigestSentAt, time.Second)
+
+	// And not sent again until it's next due.
+	clear(sentEmails)
+	surface.EmailNotificationDigests(ctx, now.Add(time.Hour))
+	suite.Empty(sentEmails)
+}
+
 func TestSurfaceNotifyTestSuite(t *testing.T) {
 	suite.Run(t, new(SurfaceNotifyTestSuite))
 }
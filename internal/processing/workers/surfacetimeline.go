@@ -80,6 +80,14 @@ func (s *Surface) timelineAndNotifyStatus(ctx context.Context, status *gtsmodel.
 		return gtserror.Newf("error notifying status mentions for status %s: %w", status.ID, err)
 	}
 
+	// Notify the author of a quoted status, if local.
+	// Pending quotes are notified on creation instead.
+	if !util.PtrOrValue(status.QuotePendingApproval, false) {
+		if err := s.notifyQuote(ctx, status); err != nil {
+			return gtserror.Newf("error notifying quote for status %s: %w", status.ID, err)
+		}
+	}
+
 	// Update any conversations containing this status, and get notifications for them.
 	notifications, err := s.Conversations.UpdateConversationsForStatus(ctx, status)
 	if err != nil {
@@ -20,8 +20,10 @@
 import (
 	"context"
 	"errors"
+	"slices"
 
 	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/cards"
 	"code.superseriousbusiness.org/gotosocial/internal/db"
 	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
@@ -42,6 +44,53 @@ type utils struct {
 	account   *account.Processor
 	surface   *Surface
 	converter *typeutils.Converter
+	cards     *cards.Fetcher
+}
+
+// fetchStatusCard queues an asynchronous fetch
+// of the link preview card for the given status,
+// updating the status card if it has changed.
+func (u *utils) fetchStatusCard(status *gtsmodel.Status) {
+	statusID := status.ID
+	u.state.Workers.Dereference.Queue.Push(func(ctx context.Context) {
+		// Get a fresh copy of the status,
+		// we don't want to modify the model
+		// that may be in use by the caller.
+		status, err := u.state.DB.GetStatusByID(
+			gtscontext.SetBarebones(ctx),
+			statusID,
+		)
+		if err != nil {
+			log.Errorf(ctx, "db error getting status %s: %v", statusID, err)
+			return
+		}
+
+		card, err := u.cards.FetchForStatus(ctx, status)
+		if err != nil {
+			log.Errorf(ctx, "error fetching card for status %s: %v", status.URI, err)
+			return
+		}
+
+		var cardID string
+		if card != nil {
+			cardID = card.ID
+		}
+
+		if cardID == status.CardID {
+			// Nothing changed.
+			return
+		}
+
+		status.CardID = cardID
+		status.Card = card
+		if err := u.state.DB.UpdateStatus(ctx, status, "card_id"); err != nil {
+			log.Errorf(ctx, "db error updating status %s card: %v", status.URI, err)
+			return
+		}
+
+		// Status representation changed, uncache from timelines.
+		u.surface.invalidateStatusFromTimelines(status.ID)
+	})
 }
 
 // wipeStatus encapsulates common logic used to
@@ -142,6 +191,11 @@ func (u *utils) wipeStatus(
 		errs.Appendf("error deleting status faves: %w", err)
 	}
 
+	// Delete all reactions to this status.
+	if err := u.state.DB.DeleteStatusReactionsForStatus(ctx, status.ID); err != nil {
+		errs.Appendf("error deleting status reactions: %w", err)
+	}
+
 	if id := status.PollID; id != "" {
 		// Delete this poll by ID from the database.
 		if err := u.state.DB.DeletePollByID(ctx, id); err != nil {
@@ -312,7 +366,8 @@ func (u *utils) incrementStatusesCount(
 		return gtserror.Newf("db error updating account stats: %w", err)
 	}
 
-	return nil
+	// Update stats of featured tags used by status.
+	return u.updateFeaturedTagStats(ctx, account, status)
 }
 
 func (u *utils) decrementStatusesCount(
@@ -346,6 +401,41 @@ func (u *utils) decrementStatusesCount(
 		return gtserror.Newf("db error updating account stats: %w", err)
 	}
 
+	// Update stats of featured tags used by status.
+	return u.updateFeaturedTagStats(ctx, account, status)
+}
+
+// updateFeaturedTagStats recalculates the stats of
+// those featured tags of account used by the status.
+func (u *utils) updateFeaturedTagStats(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	status *gtsmodel.Status,
+) error {
+	if len(status.TagIDs) == 0 {
+		// No tags,
+		// nothing to do.
+		return nil
+	}
+
+	featuredTags, err := u.state.DB.GetAccountFeaturedTags(
+		gtscontext.SetBarebones(ctx),
+		account.ID,
+	)
+	if err != nil {
+		return gtserror.Newf("db error getting featured tags: %w", err)
+	}
+
+	for _, featuredTag := range featuredTags {
+		if !slices.Contains(status.TagIDs, featuredTag.TagID) {
+			continue
+		}
+
+		if err := u.state.DB.UpdateFeaturedTagStats(ctx, featuredTag); err != nil {
+			return gtserror.Newf("db error updating featured tag stats: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -666,3 +756,87 @@ func (u *utils) impoliteAnnounceRequest(
 
 	return nil
 }
+
+// impoliteQuoteRequest stores an interaction request
+// for the given quote, and notifies the interactee.
+//
+// There's no QuoteRequest type (yet), so this is used
+// whenever a status quotes a post that requires approval
+// for it, whether the quoting status is local or remote.
+func (u *utils) impoliteQuoteRequest(
+	ctx context.Context,
+	quote *gtsmodel.Status,
+) error {
+	// Only create interaction request if
+	// status quotes a local status.
+	if quote.QuoteOf == nil ||
+		!quote.QuoteOf.IsLocal() {
+		return nil
+	}
+
+	// Lock on the interaction URI.
+	unlock := u.state.ProcessingLocks.Lock(quote.URI)
+	defer unlock()
+
+	// Ensure no req with this URI exists already.
+	//
+	// Note this also means a status that is both
+	// a pending reply and a pending quote only
+	// gets one request, for whichever came first.
+	req, err := u.state.DB.GetInteractionRequestByInteractionURI(ctx, quote.URI)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error checking for existing interaction request: %w", err)
+	}
+
+	if req != nil {
+		// Interaction req already exists,
+		// no need to do anything else.
+		return nil
+	}
+
+	// Create + store impolite interaction request.
+	req = typeutils.StatusToImpoliteQuoteRequest(quote)
+	if err := u.state.DB.PutInteractionRequest(ctx, req); err != nil {
+		return gtserror.Newf("db error storing interaction request: %w", err)
+	}
+
+	// Notify *local* account of pending quote.
+	if err := u.surface.notifyPendingQuote(ctx, quote); err != nil {
+		return gtserror.Newf("error notifying pending quote: %w", err)
+	}
+
+	return nil
+}
+
+// unlinkQuote removes the link between the given
+// quoting status and the status it quoted, eg.,
+// when the quote has been rejected by the quotee.
+func (u *utils) unlinkQuote(
+	ctx context.Context,
+	quote *gtsmodel.Status,
+) error {
+	quote.QuoteOfID = ""
+	quote.QuoteOfURI = ""
+	quote.QuoteOfAccountID = ""
+	quote.QuoteOf = nil
+	quote.QuoteOfAccount = nil
+	quote.QuotePendingApproval = util.Ptr(false)
+	quote.QuoteApprovedByURI = ""
+	if err := u.state.DB.UpdateStatus(
+		ctx,
+		quote,
+		"quote_of_id",
+		"quote_of_uri",
+		"quote_of_account_id",
+		"quote_pending_approval",
+		"quote_approved_by_uri",
+	); err != nil {
+		return gtserror.Newf("db error updating status: %w", err)
+	}
+
+	// Quote no longer shown on the status;
+	// uncache prepared version from timelines.
+	u.surface.invalidateStatusFromTimelines(quote.ID)
+
+	return nil
+}
@@ -18,6 +18,7 @@
 package workers
 
 import (
+	"code.superseriousbusiness.org/gotosocial/internal/cards"
 	"code.superseriousbusiness.org/gotosocial/internal/email"
 	"code.superseriousbusiness.org/gotosocial/internal/federation"
 	"code.superseriousbusiness.org/gotosocial/internal/filter/mutes"
@@ -37,6 +38,7 @@
 type Processor struct {
 	clientAPI clientAPI
 	fediAPI   fediAPI
+	surface   *Surface
 	workers   *workers.Workers
 }
 
@@ -54,6 +56,7 @@ func New(
 	media *media.Processor,
 	stream *stream.Processor,
 	conversations *conversations.Processor,
+	cards *cards.Fetcher,
 ) Processor {
 	// Init federate logic
 	// wrapper struct.
@@ -84,10 +87,12 @@ func New(
 		account:   account,
 		surface:   surface,
 		converter: converter,
+		cards:     cards,
 	}
 
 	return Processor{
 		workers: &state.Workers,
+		surface: surface,
 		clientAPI: clientAPI{
 			state:     state,
 			converter: converter,
@@ -75,6 +75,8 @@
 	blockPath         = userPathPrefix + `/` + blocks + `/(` + ulid + `)$`
 	reportPath        = `^/?` + reports + `/(` + ulid + `)$`
 	filePath          = `^/?(` + ulid + `)/([a-z]+)/([a-z]+)/(` + ulid + `)\.([a-z0-9]+)$`
+	blobPath          = `^/?blob/[a-f0-9]{2}/([a-f0-9]{64})\.([a-z0-9]+)$`
+	archivePath       = `^/?(` + ulid + `)/archive/(` + ulid + `)\.zip$`
 )
 
 var (
@@ -163,6 +165,16 @@
 	// It captures the account id, media type, media size, file name, and file extension, eg
 	// `01F8MH1H7YV1Z7D2C8K2730QBF`, `attachment`, `small`, `01F8MH8RMYQ6MSNY3JM2XT1CQ5`, `jpeg`.
 	FilePath = regexp.MustCompile(filePath)
+
+	// BlobPath parses a content-addressed file storage path of the form blob/[PREFIX]/[CHECKSUM].[EXT]
+	// eg blob/9f/9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08.jpeg
+	// It captures the checksum and file extension.
+	BlobPath = regexp.MustCompile(blobPath)
+
+	// ArchivePath parses an account data archive storage path of the form [ACCOUNT_ID]/archive/[ARCHIVE_ID].zip
+	// eg 01F8MH1H7YV1Z7D2C8K2730QBF/archive/01F8MH8RMYQ6MSNY3JM2XT1CQ5.zip
+	// It captures the account id and archive id.
+	ArchivePath = regexp.MustCompile(archivePath)
 )
 
 // bufpool is a memory pool of byte buffers for use in our regex utility functions.
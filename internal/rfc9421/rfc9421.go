@@ -0,0 +1,296 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package rfc9421
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Algorithm is an RFC 9421 HTTP
+// signature algorithm identifier.
+//
+// See: https://www.rfc-editor.org/rfc/rfc9421#name-http-signature-algorithms
+type Algorithm string
+
+const (
+	// Ed25519 is EdDSA using curve Ed25519.
+	Ed25519 Algorithm = "ed25519"
+
+	// RSAPSSSHA512 is RSASSA-PSS using SHA-512.
+	RSAPSSSHA512 Algorithm = "rsa-pss-sha512"
+
+	// RSAv15SHA256 is RSASSA-PKCS1-v1_5 using SHA-256.
+	RSAv15SHA256 Algorithm = "rsa-v1_5-sha256"
+)
+
+const (
+	// SignatureInputHeader is the header
+	// containing signature metadata.
+	SignatureInputHeader = "Signature-Input"
+
+	// SignatureHeader is the header
+	// containing the signature itself.
+	SignatureHeader = "Signature"
+
+	// ContentDigestHeader is the RFC 9530
+	// header containing the body digest.
+	ContentDigestHeader = "Content-Digest"
+
+	// signature label we use when signing.
+	label = "sig1"
+)
+
+var (
+	// ErrNoSignature is returned when a request
+	// contains no RFC 9421 signature headers.
+	ErrNoSignature = errors.New("no rfc9421 signature present")
+
+	// ErrVerification is returned when signature
+	// verification fails with the given key.
+	ErrVerification = errors.New("rfc9421 signature verification failed")
+)
+
+// Signed returns whether the given request
+// carries an RFC 9421 signature, as opposed
+// to (say) a draft-cavage signature, which
+// would not include the Signature-Input header.
+func Signed(r *http.Request) bool {
+	return r.Header.Get(SignatureInputHeader) != ""
+}
+
+// signMessage signs the given signature base
+// with private key, returning the signature.
+func signMessage(key crypto.PrivateKey, base []byte) ([]byte, error) {
+	switch key := key.(type) {
+	case ed25519.PrivateKey:
+		return ed25519.Sign(key, base), nil
+
+	case *rsa.PrivateKey:
+		sum := sha512.Sum512(base)
+		return rsa.SignPSS(rand.Reader, key, crypto.SHA512, sum[:], &rsa.PSSOptions{
+			SaltLength: sha512.Size,
+		})
+
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+}
+
+// verifyMessage verifies the given signature over the signature base
+// with public key. If alg is empty it will be inferred from key type.
+func verifyMessage(key crypto.PublicKey, alg Algorithm, base []byte, sig []byte) error {
+	switch key := key.(type) {
+	case ed25519.PublicKey:
+		if alg != "" && alg != Ed25519 {
+			return fmt.Errorf("algorithm %s incompatible with ed25519 key", alg)
+		}
+		if !ed25519.Verify(key, base, sig) {
+			return ErrVerification
+		}
+		return nil
+
+	case *rsa.PublicKey:
+		switch alg {
+		case RSAPSSSHA512:
+			return verifyRSAPSS(key, base, sig)
+		case RSAv15SHA256:
+			return verifyRSAv15(key, base, sig)
+		case "":
+			// No algorithm given,
+			// try both RSA variants.
+			if verifyRSAPSS(key, base, sig) == nil ||
+				verifyRSAv15(key, base, sig) == nil {
+				return nil
+			}
+			return ErrVerification
+		default:
+			return fmt.Errorf("algorithm %s incompatible with rsa key", alg)
+		}
+
+	default:
+		return fmt.Errorf("unsupported public key type %T", key)
+	}
+}
+
+func verifyRSAPSS(key *rsa.PublicKey, base []byte, sig []byte) error {
+	sum := sha512.Sum512(base)
+	if rsa.VerifyPSS(key, crypto.SHA512, sum[:], sig, &rsa.PSSOptions{
+		SaltLength: rsa.PSSSaltLengthAuto,
+	}) != nil {
+		return ErrVerification
+	}
+	return nil
+}
+
+func verifyRSAv15(key *rsa.PublicKey, base []byte, sig []byte) error {
+	sum := sha256.Sum256(base)
+	if rsa.VerifyPKCS1v15(key, crypto.SHA256, sum[:], sig) != nil {
+		return ErrVerification
+	}
+	return nil
+}
+
+// signatureBase builds the RFC 9421 signature base
+// for the given component values + signature params.
+//
+// See: https://www.rfc-editor.org/rfc/rfc9421#name-creating-the-signature-base
+func signatureBase(components []item, values []string, ps params) []byte {
+	var b strings.Builder
+	for i, c := range components {
+		b.WriteByte('"')
+		b.WriteString(c.value.(string))
+		b.WriteString(`": `)
+		b.WriteString(values[i])
+		b.WriteByte('\n')
+	}
+	b.WriteString(`"@signature-params": `)
+	b.WriteString(serializeInnerList(components, ps))
+	return []byte(b.String())
+}
+
+// componentValue returns the value of the named component
+// for the request. The targetURI is required for derived
+// components as incoming server requests lack full URLs.
+func componentValue(r *http.Request, targetURI string, name string) (string, error) {
+	switch name {
+	case "@method":
+		return strings.ToUpper(r.Method), nil
+
+	case "@target-uri":
+		return targetURI, nil
+
+	case "@authority":
+		return strings.ToLower(authority(r)), nil
+
+	case "@scheme":
+		scheme, _, _ := strings.Cut(targetURI, "://")
+		return strings.ToLower(scheme), nil
+
+	case "@request-target":
+		return r.URL.RequestURI(), nil
+
+	case "@path":
+		path := r.URL.EscapedPath()
+		if path == "" {
+			path = "/"
+		}
+		return path, nil
+
+	case "@query":
+		return "?" + r.URL.RawQuery, nil
+	}
+
+	if strings.HasPrefix(name, "@") {
+		return "", fmt.Errorf("unsupported derived component %s", name)
+	}
+
+	// Regular header field, should
+	// be lowercase in the component
+	// identifier to be valid.
+	if name != strings.ToLower(name) {
+		return "", fmt.Errorf("invalid component %s", name)
+	}
+
+	if name == "host" {
+		// Go moves this
+		// to the request.
+		return authority(r), nil
+	}
+
+	raw := r.Header.Values(name)
+	if len(raw) == 0 {
+		return "", fmt.Errorf("covered header %s not present", name)
+	}
+
+	values := make([]string, len(raw))
+	for i := range raw {
+		values[i] = strings.TrimSpace(raw[i])
+	}
+
+	return strings.Join(values, ", "), nil
+}
+
+// authority returns the request host, preferring
+// the Host header as set on incoming requests.
+func authority(r *http.Request) string {
+	if r.Host != "" {
+		return r.Host
+	}
+	return r.URL.Host
+}
+
+// contentDigest returns the Content-Digest
+// header value for given body, using sha-256.
+func contentDigest(body []byte) string {
+	sum := sha256.Sum256(body)
+	var b strings.Builder
+	b.WriteString("sha-256=")
+	serializeBareItem(&b, sum[:])
+	return b.String()
+}
+
+// verifyContentDigest checks body against the given
+// Content-Digest header value, requiring at least one
+// supported digest algorithm, and that all of these match.
+func verifyContentDigest(header string, body []byte) error {
+	members, err := parseDictionary(header)
+	if err != nil {
+		return fmt.Errorf("invalid content-digest: %w", err)
+	}
+
+	var checked bool
+	for _, m := range members {
+		want, ok := m.item.value.([]byte)
+		if m.isList || !ok {
+			return errors.New("invalid content-digest value")
+		}
+
+		var got []byte
+		switch m.key {
+		case "sha-256":
+			sum := sha256.Sum256(body)
+			got = sum[:]
+		case "sha-512":
+			sum := sha512.Sum512(body)
+			got = sum[:]
+		default:
+			// Unsupported, skip.
+			continue
+		}
+
+		if string(got) != string(want) {
+			return fmt.Errorf("%s content-digest mismatch", m.key)
+		}
+		checked = true
+	}
+
+	if !checked {
+		return errors.New("no supported content-digest algorithm")
+	}
+
+	return nil
+}
@@ -0,0 +1,270 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package rfc9421_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/rfc9421"
+)
+
+const keyID = "https://example.org/users/someone#main-key"
+
+// serverRequest converts the signed client request
+// into an incoming request, as it would be received.
+func serverRequest(t *testing.T, r *http.Request, body []byte) *http.Request {
+	var rbody io.Reader
+	if body != nil {
+		rbody = bytes.NewReader(body)
+	}
+	sr := httptest.NewRequest(r.Method, r.URL.String(), rbody)
+	sr.Header = r.Header.Clone()
+	return sr
+}
+
+func TestSignVerify(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		name string
+		priv crypto.PrivateKey
+		pub  crypto.PublicKey
+		alg  rfc9421.Algorithm
+		body []byte
+	}{
+		{"ed25519 GET", edKey, edKey.Public(), rfc9421.Ed25519, nil},
+		{"ed25519 POST", edKey, edKey.Public(), rfc9421.Ed25519, []byte(`{"type":"Create"}`)},
+		{"rsa-pss GET", rsaKey, &rsaKey.PublicKey, rfc9421.RSAPSSSHA512, nil},
+		{"rsa-pss POST", rsaKey, &rsaKey.PublicKey, rfc9421.RSAPSSSHA512, []byte(`{"type":"Create"}`)},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			method := http.MethodGet
+			if test.body != nil {
+				method = http.MethodPost
+			}
+
+			r, _ := http.NewRequest(method, "https://example.org/users/target/inbox?page=true", nil)
+			if err := rfc9421.Sign(r, test.body, keyID, test.priv); err != nil {
+				t.Fatal(err)
+			}
+
+			if !rfc9421.Signed(r) {
+				t.Fatal("expected request to be signed")
+			}
+
+			sr := serverRequest(t, r, test.body)
+			v, err := rfc9421.NewVerifier(sr, "https")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := v.VerifyContentDigest(sr); err != nil {
+				t.Fatal(err)
+			}
+
+			// Body should still be readable after.
+			if body, _ := io.ReadAll(sr.Body); !bytes.Equal(body, test.body) {
+				t.Fatalf("unexpected body %q", body)
+			}
+
+			if v.KeyID() != keyID {
+				t.Fatalf("unexpected key id %s", v.KeyID())
+			}
+
+			if v.Algorithm() != test.alg {
+				t.Fatalf("unexpected algorithm %s", v.Algorithm())
+			}
+
+			if err := v.Verify(test.pub); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestVerifyWrongKey(t *testing.T) {
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	otherPub, _, _ := ed25519.GenerateKey(rand.Reader)
+
+	r, _ := http.NewRequest(http.MethodGet, "https://example.org/users/target", nil)
+	if err := rfc9421.Sign(r, nil, keyID, edKey); err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := rfc9421.NewVerifier(serverRequest(t, r, nil), "https")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := v.Verify(otherPub); !errors.Is(err, rfc9421.ErrVerification) {
+		t.Fatalf("expected verification error, got %v", err)
+	}
+}
+
+func TestVerifyTamperedBody(t *testing.T) {
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+
+	r, _ := http.NewRequest(http.MethodPost, "https://example.org/users/target/inbox", nil)
+	if err := rfc9421.Sign(r, []byte(`{"type":"Like"}`), keyID, edKey); err != nil {
+		t.Fatal(err)
+	}
+
+	sr := serverRequest(t, r, []byte(`{"type":"Delete"}`))
+	v, err := rfc9421.NewVerifier(sr, "https")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := v.VerifyContentDigest(sr); err == nil {
+		t.Fatal("expected content-digest error")
+	}
+}
+
+func TestVerifyBodyTooLarge(t *testing.T) {
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	body := bytes.Repeat([]byte("a"), int(rfc9421.MaxBodySize)+1)
+
+	r, _ := http.NewRequest(http.MethodPost, "https://example.org/users/target/inbox", nil)
+	if err := rfc9421.Sign(r, body, keyID, edKey); err != nil {
+		t.Fatal(err)
+	}
+
+	// Parsing the signature doesn't need the body.
+	sr := serverRequest(t, r, body)
+	v, err := rfc9421.NewVerifier(sr, "https")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := v.VerifyContentDigest(sr); err == nil {
+		t.Fatal("expected body size error")
+	}
+
+	// Also with a missing content length.
+	sr = serverRequest(t, r, body)
+	sr.ContentLength = -1
+	if err := v.VerifyContentDigest(sr); err == nil {
+		t.Fatal("expected body size error")
+	}
+}
+
+func TestVerifyWrongTarget(t *testing.T) {
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+
+	r, _ := http.NewRequest(http.MethodGet, "https://example.org/users/target", nil)
+	if err := rfc9421.Sign(r, nil, keyID, edKey); err != nil {
+		t.Fatal(err)
+	}
+
+	// Replay the signature against another path.
+	sr := serverRequest(t, r, nil)
+	sr.URL.Path = "/users/other"
+
+	v, err := rfc9421.NewVerifier(sr, "https")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := v.Verify(edKey.Public()); !errors.Is(err, rfc9421.ErrVerification) {
+		t.Fatalf("expected verification error, got %v", err)
+	}
+}
+
+func TestVerifyRSAv15(t *testing.T) {
+	// Hand-roll a signature as some other
+	// implementations would send it, using
+	// rsa-v1_5-sha256 and no alg parameter.
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	created := strconv.FormatInt(time.Now().Unix(), 10)
+	input := `("@method" "@target-uri");created=` + created + `;keyid="` + keyID + `"`
+	base := "\"@method\": GET\n" +
+		"\"@target-uri\": https://example.org/users/target\n" +
+		"\"@signature-params\": " + input
+
+	sum := sha256.Sum256([]byte(base))
+	sig, err := rsa.SignPKCS1v15(nil, rsaKey, crypto.SHA256, sum[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "https://example.org/users/target", nil)
+	r.Header.Set("Signature-Input", "sig-a="+input)
+	r.Header.Set("Signature", "sig-a=:"+base64.StdEncoding.EncodeToString(sig)+":")
+
+	v, err := rfc9421.NewVerifier(r, "https")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := v.Verify(&rsaKey.PublicKey); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerifyInsufficientCoverage(t *testing.T) {
+	created := strconv.FormatInt(time.Now().Unix(), 10)
+
+	r := httptest.NewRequest(http.MethodGet, "https://example.org/users/target", nil)
+	r.Header.Set("Signature-Input", `sig1=("@method");created=`+created+`;keyid="`+keyID+`"`)
+	r.Header.Set("Signature", "sig1=:AAAA:")
+
+	if _, err := rfc9421.NewVerifier(r, "https"); err == nil {
+		t.Fatal("expected coverage error")
+	}
+}
+
+func TestVerifyExpired(t *testing.T) {
+	created := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+	expires := strconv.FormatInt(time.Now().Add(-time.Minute).Unix(), 10)
+
+	r := httptest.NewRequest(http.MethodGet, "https://example.org/users/target", nil)
+	r.Header.Set("Signature-Input", `sig1=("@method" "@target-uri");created=`+created+`;expires=`+expires+`;keyid="`+keyID+`"`)
+	r.Header.Set("Signature", "sig1=:AAAA:")
+
+	if _, err := rfc9421.NewVerifier(r, "https"); err == nil {
+		t.Fatal("expected expiry error")
+	}
+}
+
+func TestVerifyUnsigned(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "https://example.org/users/target", nil)
+	if _, err := rfc9421.NewVerifier(r, "https"); !errors.Is(err, rfc9421.ErrNoSignature) {
+		t.Fatalf("expected no signature error, got %v", err)
+	}
+}
@@ -0,0 +1,423 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package rfc9421
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// This file contains just enough of an RFC 8941 structured
+// field values parser + serializer to handle the Dictionary
+// headers used by RFC 9421 and RFC 9530, ie., Signature-Input,
+// Signature, and Content-Digest. Decimals are not supported.
+
+// token is a bare (unquoted) structured field token.
+type token string
+
+// param is a single structured field parameter.
+type param struct {
+	key   string
+	value any // int64, string, token, bool or []byte
+}
+
+// params is an ordered list of parameters.
+type params []param
+
+// get returns the value of parameter with key, if any.
+func (ps params) get(key string) (any, bool) {
+	for _, p := range ps {
+		if p.key == key {
+			return p.value, true
+		}
+	}
+	return nil, false
+}
+
+// item is a bare item with parameters.
+type item struct {
+	value  any // int64, string, token, bool or []byte
+	params params
+}
+
+// member is a single dictionary member, which
+// is either an inner list of items or one item.
+type member struct {
+	key    string
+	list   []item
+	isList bool
+	item   item
+	params params
+}
+
+var errMalformed = errors.New("malformed structured field")
+
+// parser is a simple cursor over header data.
+type parser struct {
+	s string
+	i int
+}
+
+// parseDictionary parses a structured field Dictionary,
+// returning members in the order they were given.
+func parseDictionary(s string) ([]member, error) {
+	p := &parser{s: s}
+	p.skipSP()
+
+	var members []member
+	for !p.done() {
+		key, err := p.parseKey()
+		if err != nil {
+			return nil, err
+		}
+
+		m := member{key: key}
+		if p.peek() == '=' {
+			p.i++
+			if err := p.parseMemberValue(&m); err != nil {
+				return nil, err
+			}
+		} else {
+			// Bare key means boolean true.
+			m.item.value = true
+			if m.params, err = p.parseParams(); err != nil {
+				return nil, err
+			}
+		}
+
+		// Later duplicate keys override earlier ones.
+		members = removeMember(members, key)
+		members = append(members, m)
+
+		p.skipOWS()
+		if p.done() {
+			break
+		}
+
+		if p.peek() != ',' {
+			return nil, errMalformed
+		}
+		p.i++
+		p.skipOWS()
+
+		if p.done() {
+			// Trailing comma.
+			return nil, errMalformed
+		}
+	}
+
+	return members, nil
+}
+
+func removeMember(members []member, key string) []member {
+	for i := range members {
+		if members[i].key == key {
+			return append(members[:i], members[i+1:]...)
+		}
+	}
+	return members
+}
+
+func (p *parser) parseMemberValue(m *member) error {
+	var err error
+	if p.peek() == '(' {
+		m.isList = true
+		m.list, err = p.parseInnerList()
+	} else {
+		m.item.value, err = p.parseBareItem()
+	}
+	if err != nil {
+		return err
+	}
+	m.params, err = p.parseParams()
+	return err
+}
+
+func (p *parser) parseInnerList() ([]item, error) {
+	p.i++ // skip '('
+
+	var items []item
+	for !p.done() {
+		p.skipSP()
+
+		if p.peek() == ')' {
+			p.i++
+			return items, nil
+		}
+
+		v, err := p.parseBareItem()
+		if err != nil {
+			return nil, err
+		}
+
+		ps, err := p.parseParams()
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item{value: v, params: ps})
+
+		if c := p.peek(); c != ' ' && c != ')' {
+			return nil, errMalformed
+		}
+	}
+
+	// Unterminated list.
+	return nil, errMalformed
+}
+
+func (p *parser) parseParams() (params, error) {
+	var ps params
+	for p.peek() == ';' {
+		p.i++
+		p.skipSP()
+
+		key, err := p.parseKey()
+		if err != nil {
+			return nil, err
+		}
+
+		var v any = true
+		if p.peek() == '=' {
+			p.i++
+			if v, err = p.parseBareItem(); err != nil {
+				return nil, err
+			}
+		}
+
+		ps = append(ps, param{key: key, value: v})
+	}
+	return ps, nil
+}
+
+func (p *parser) parseKey() (string, error) {
+	start := p.i
+	if c := p.peek(); !isLCAlpha(c) && c != '*' {
+		return "", errMalformed
+	}
+	p.i++
+
+	for !p.done() {
+		c := p.peek()
+		if !isLCAlpha(c) && !isDigit(c) &&
+			c != '_' && c != '-' && c != '.' && c != '*' {
+			break
+		}
+		p.i++
+	}
+
+	return p.s[start:p.i], nil
+}
+
+func (p *parser) parseBareItem() (any, error) {
+	switch c := p.peek(); {
+	case c == '-' || isDigit(c):
+		return p.parseInteger()
+	case c == '"':
+		return p.parseString()
+	case c == ':':
+		return p.parseByteSequence()
+	case c == '?':
+		return p.parseBoolean()
+	case isAlpha(c) || c == '*':
+		return p.parseToken(), nil
+	default:
+		return nil, errMalformed
+	}
+}
+
+func (p *parser) parseInteger() (int64, error) {
+	start := p.i
+	if p.peek() == '-' {
+		p.i++
+	}
+	for !p.done() && isDigit(p.peek()) {
+		p.i++
+	}
+
+	// Max 15 digits, and no decimals.
+	digits := p.s[start:p.i]
+	if len(strings.TrimPrefix(digits, "-")) > 15 || p.peek() == '.' {
+		return 0, errMalformed
+	}
+
+	return strconv.ParseInt(digits, 10, 64)
+}
+
+func (p *parser) parseString() (string, error) {
+	p.i++ // skip '"'
+
+	var b strings.Builder
+	for !p.done() {
+		c := p.s[p.i]
+		p.i++
+
+		switch {
+		case c == '\\':
+			if p.done() {
+				return "", errMalformed
+			}
+			next := p.s[p.i]
+			if next != '"' && next != '\\' {
+				return "", errMalformed
+			}
+			b.WriteByte(next)
+			p.i++
+		case c == '"':
+			return b.String(), nil
+		case c < 0x20 || c > 0x7e:
+			return "", errMalformed
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	// Unterminated string.
+	return "", errMalformed
+}
+
+func (p *parser) parseByteSequence() ([]byte, error) {
+	p.i++ // skip ':'
+
+	end := strings.IndexByte(p.s[p.i:], ':')
+	if end < 0 {
+		return nil, errMalformed
+	}
+
+	data := p.s[p.i : p.i+end]
+	p.i += end + 1
+
+	return base64.StdEncoding.DecodeString(data)
+}
+
+func (p *parser) parseBoolean() (bool, error) {
+	p.i++ // skip '?'
+	switch p.peek() {
+	case '1':
+		p.i++
+		return true, nil
+	case '0':
+		p.i++
+		return false, nil
+	default:
+		return false, errMalformed
+	}
+}
+
+func (p *parser) parseToken() token {
+	start := p.i
+	for !p.done() {
+		c := p.peek()
+		if c <= ' ' || c >= 0x7f || strings.IndexByte(`"(),;<=>?@[\]{}`, c) >= 0 {
+			// Note ':' and '/' are
+			// valid token characters.
+			break
+		}
+		p.i++
+	}
+	return token(p.s[start:p.i])
+}
+
+func (p *parser) done() bool { return p.i >= len(p.s) }
+
+func (p *parser) peek() byte {
+	if p.done() {
+		return 0
+	}
+	return p.s[p.i]
+}
+
+func (p *parser) skipSP() {
+	for p.peek() == ' ' {
+		p.i++
+	}
+}
+
+func (p *parser) skipOWS() {
+	for c := p.peek(); c == ' ' || c == '\t'; c = p.peek() {
+		p.i++
+	}
+}
+
+func isLCAlpha(c byte) bool { return c >= 'a' && c <= 'z' }
+
+func isAlpha(c byte) bool { return isLCAlpha(c) || (c >= 'A' && c <= 'Z') }
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+// serializeInnerList serializes the given inner
+// list of strings with parameters, as used for
+// the "@signature-params" component value.
+func serializeInnerList(list []item, ps params) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for i, it := range list {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		serializeBareItem(&b, it.value)
+		serializeParams(&b, it.params)
+	}
+	b.WriteByte(')')
+	serializeParams(&b, ps)
+	return b.String()
+}
+
+func serializeParams(b *strings.Builder, ps params) {
+	for _, p := range ps {
+		b.WriteByte(';')
+		b.WriteString(p.key)
+		if v, ok := p.value.(bool); ok && v {
+			// True booleans
+			// have no value.
+			continue
+		}
+		b.WriteByte('=')
+		serializeBareItem(b, p.value)
+	}
+}
+
+func serializeBareItem(b *strings.Builder, v any) {
+	switch v := v.(type) {
+	case int64:
+		b.WriteString(strconv.FormatInt(v, 10))
+	case string:
+		b.WriteByte('"')
+		for i := 0; i < len(v); i++ {
+			if v[i] == '"' || v[i] == '\\' {
+				b.WriteByte('\\')
+			}
+			b.WriteByte(v[i])
+		}
+		b.WriteByte('"')
+	case token:
+		b.WriteString(string(v))
+	case bool:
+		if v {
+			b.WriteString("?1")
+		} else {
+			b.WriteString("?0")
+		}
+	case []byte:
+		b.WriteByte(':')
+		b.WriteString(base64.StdEncoding.EncodeToString(v))
+		b.WriteByte(':')
+	}
+}
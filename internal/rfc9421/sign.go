@@ -0,0 +1,102 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package rfc9421
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Sign signs the given client request with private key, under the
+// given key ID, setting the Signature-Input and Signature headers.
+// The private key must be either ed25519.PrivateKey, in which case
+// the ed25519 algorithm is used, or *rsa.PrivateKey, in which case
+// rsa-pss-sha512 is used.
+//
+// The signature covers "@method" and "@target-uri". If body is
+// non-nil, a Content-Digest header will be set and also covered.
+func Sign(r *http.Request, body []byte, keyID string, key crypto.PrivateKey) error {
+	// Clear any existing signature headers,
+	// in case this request is being re-signed.
+	r.Header.Del(SignatureInputHeader)
+	r.Header.Del(SignatureHeader)
+	r.Header.Del(ContentDigestHeader)
+
+	components := []item{
+		{value: "@method"},
+		{value: "@target-uri"},
+	}
+
+	if body != nil {
+		r.Header.Set(ContentDigestHeader, contentDigest(body))
+		components = append(components, item{value: "content-digest"})
+	}
+
+	// Gather the covered component values.
+	targetURI := r.URL.String()
+	values := make([]string, len(components))
+	for i, c := range components {
+		var err error
+		values[i], err = componentValue(r, targetURI, c.value.(string))
+		if err != nil {
+			return err
+		}
+	}
+
+	// Algorithm is determined by key type,
+	// so check this before building params.
+	alg, err := algorithmFor(key)
+	if err != nil {
+		return err
+	}
+
+	ps := params{
+		{key: "created", value: time.Now().Unix()},
+		{key: "keyid", value: keyID},
+		{key: "alg", value: string(alg)},
+	}
+
+	// Build signature base and sign it.
+	base := signatureBase(components, values, ps)
+	sig, err := signMessage(key, base)
+	if err != nil {
+		return err
+	}
+
+	r.Header.Set(SignatureInputHeader, label+"="+serializeInnerList(components, ps))
+	r.Header.Set(SignatureHeader, label+"=:"+base64.StdEncoding.EncodeToString(sig)+":")
+	return nil
+}
+
+// algorithmFor returns the signing
+// algorithm we use for private key.
+func algorithmFor(key crypto.PrivateKey) (Algorithm, error) {
+	switch key.(type) {
+	case ed25519.PrivateKey:
+		return Ed25519, nil
+	case *rsa.PrivateKey:
+		return RSAPSSSHA512, nil
+	default:
+		return "", fmt.Errorf("unsupported private key type %T", key)
+	}
+}
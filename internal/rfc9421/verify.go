@@ -0,0 +1,298 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package rfc9421
+
+import (
+	"bytes"
+	"crypto"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"codeberg.org/gruf/go-bytesize"
+)
+
+const (
+	// maxClockSkew is how far in the future
+	// we'll accept a signature's created time.
+	maxClockSkew = time.Hour
+
+	// maxAge is the maximum age of a signature's
+	// created time, regardless of given expiry.
+	maxAge = 12 * time.Hour
+
+	// MaxBodySize is the maximum size of request
+	// body that will be read in to check against
+	// a signature's covered content digest.
+	MaxBodySize = int64(1 * bytesize.MiB)
+)
+
+// Verifier wraps a parsed RFC 9421 signature on an
+// incoming request, ready for verification by key.
+type Verifier struct {
+	keyID  string
+	alg    Algorithm
+	sig    []byte
+	base   []byte
+	digest string
+}
+
+// NewVerifier parses the RFC 9421 signature on the given incoming server request,
+// preparing it for verification. Requests can carry multiple signatures, in which
+// case the first one we can support is used. Scheme is the scheme with which the
+// request was received, which must be given as it will not be set on incoming
+// requests behind a reverse proxy, but is needed to reconstruct "@target-uri".
+//
+// Signatures are required to cover "@method" and "@target-uri", and if the request
+// has a body, "content-digest". The body is not read here, so that callers can
+// check the key ID first, the digest must be checked with VerifyContentDigest().
+//
+// If no signature is present at all, ErrNoSignature is returned.
+func NewVerifier(r *http.Request, scheme string) (*Verifier, error) {
+	inputHdr := headerValue(r.Header, SignatureInputHeader)
+	sigHdr := headerValue(r.Header, SignatureHeader)
+	if inputHdr == "" || sigHdr == "" {
+		return nil, ErrNoSignature
+	}
+
+	inputs, err := parseDictionary(inputHdr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", SignatureInputHeader, err)
+	}
+
+	sigs, err := parseDictionary(sigHdr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", SignatureHeader, err)
+	}
+
+	targetURI := scheme + "://" + authority(r) + r.URL.RequestURI()
+
+	var errs []error
+	for _, input := range inputs {
+		v, err := newVerifier(r, targetURI, input, sigs)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("signature %s: %w", input.key, err))
+			continue
+		}
+		return v, nil
+	}
+
+	if len(errs) == 0 {
+		return nil, ErrNoSignature
+	}
+
+	return nil, errors.Join(errs...)
+}
+
+func newVerifier(r *http.Request, targetURI string, input member, sigs []member) (*Verifier, error) {
+	if !input.isList {
+		return nil, errors.New("signature input not an inner list")
+	}
+
+	// Find the signature with matching label.
+	idx := slices.IndexFunc(sigs, func(m member) bool {
+		return m.key == input.key
+	})
+	if idx < 0 {
+		return nil, errors.New("no matching signature")
+	}
+	sig, ok := sigs[idx].item.value.([]byte)
+	if !ok || sigs[idx].isList {
+		return nil, errors.New("signature not a byte sequence")
+	}
+
+	// Gather covered component names
+	// + values from the request.
+	names := make([]string, len(input.list))
+	values := make([]string, len(input.list))
+	for i, c := range input.list {
+		name, ok := c.value.(string)
+		if !ok {
+			return nil, errors.New("component identifier not a string")
+		}
+
+		if len(c.params) > 0 {
+			// We don't support any of the component
+			// parameters, eg. ";sf", ";key", ";req".
+			return nil, fmt.Errorf("unsupported parameters on component %s", name)
+		}
+
+		value, err := componentValue(r, targetURI, name)
+		if err != nil {
+			return nil, err
+		}
+
+		names[i] = name
+		values[i] = value
+	}
+
+	// Ensure minimum coverage of the request.
+	for _, required := range []string{"@method", "@target-uri"} {
+		if !slices.Contains(names, required) {
+			return nil, fmt.Errorf("signature does not cover %s", required)
+		}
+	}
+
+	var digest string
+	if hasBody(r) {
+		if !slices.Contains(names, "content-digest") {
+			return nil, errors.New("signature does not cover content-digest")
+		}
+
+		// Keep the covered digest to
+		// check against body later.
+		digest = headerValue(r.Header, ContentDigestHeader)
+	}
+
+	// Check signature params.
+	keyID, _ := paramString(input.params, "keyid")
+	if keyID == "" {
+		return nil, errors.New("missing keyid")
+	}
+
+	alg, _ := paramString(input.params, "alg")
+	switch Algorithm(alg) {
+	case "", Ed25519, RSAPSSSHA512, RSAv15SHA256:
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %s", alg)
+	}
+
+	if err := checkTimes(input.params, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return &Verifier{
+		keyID:  keyID,
+		alg:    Algorithm(alg),
+		sig:    sig,
+		base:   signatureBase(input.list, values, input.params),
+		digest: digest,
+	}, nil
+}
+
+// VerifyContentDigest checks the body of given request, which must be the
+// one the verifier was created from, against the content digest covered by
+// the signature. Bodies over MaxBodySize are rejected without being read in
+// full. The request body will be replaced with an in-memory copy, for later
+// readers. Requests without a body, and so without a digest, always pass.
+func (v *Verifier) VerifyContentDigest(r *http.Request) error {
+	if v.digest == "" {
+		// No body.
+		return nil
+	}
+
+	if r.ContentLength > MaxBodySize {
+		return fmt.Errorf("body size %d exceeds max %d", r.ContentLength, MaxBodySize)
+	}
+
+	// Read in body so we can check digest (up
+	// to just over max, to detect a longer body
+	// with unknown or incorrect content length).
+	body, err := io.ReadAll(io.LimitReader(r.Body, MaxBodySize+1))
+	_ = r.Body.Close()
+	if err != nil {
+		return fmt.Errorf("error reading body: %w", err)
+	}
+
+	if int64(len(body)) > MaxBodySize {
+		return fmt.Errorf("body exceeds max size %d", MaxBodySize)
+	}
+
+	// Replace with copy for later readers.
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	return verifyContentDigest(v.digest, body)
+}
+
+// checkTimes checks the created and
+// expires signature params against now.
+func checkTimes(ps params, now time.Time) error {
+	v, _ := ps.get("created")
+	created, ok := v.(int64)
+	if !ok {
+		return errors.New("missing created")
+	}
+
+	createdAt := time.Unix(created, 0)
+	if createdAt.After(now.Add(maxClockSkew)) {
+		return errors.New("signature created in the future")
+	}
+
+	if createdAt.Before(now.Add(-maxAge)) {
+		return errors.New("signature too old")
+	}
+
+	if v, ok := ps.get("expires"); ok {
+		expires, ok := v.(int64)
+		if !ok {
+			return errors.New("invalid expires")
+		}
+
+		if time.Unix(expires, 0).Before(now) {
+			return errors.New("signature expired")
+		}
+	}
+
+	return nil
+}
+
+// paramString returns param with key, if it is a string.
+func paramString(ps params, key string) (string, bool) {
+	v, _ := ps.get(key)
+	s, ok := v.(string)
+	return s, ok
+}
+
+// hasBody returns whether request
+// is expected to contain a body.
+func hasBody(r *http.Request) bool {
+	return r.Body != nil &&
+		r.Body != http.NoBody &&
+		r.ContentLength != 0
+}
+
+// headerValue fetches a header value
+// that may be split over multiple lines.
+func headerValue(h http.Header, key string) string {
+	return strings.Join(h.Values(key), ", ")
+}
+
+// KeyID returns the "keyid" parameter of the signature.
+func (v *Verifier) KeyID() string {
+	return v.keyID
+}
+
+// Algorithm returns the "alg" parameter of
+// the signature. This is optional, so may
+// be empty, in which case the algorithm
+// is determined by key type on Verify().
+func (v *Verifier) Algorithm() Algorithm {
+	return v.alg
+}
+
+// Verify verifies the signature with given public key, which
+// must be either ed25519.PublicKey or *rsa.PublicKey. If no
+// algorithm was given in the signature, RSA keys will be tried
+// with both rsa-pss-sha512 and rsa-v1_5-sha256.
+func (v *Verifier) Verify(key crypto.PublicKey) error {
+	return verifyMessage(key, v.alg, v.base, v.sig)
+}
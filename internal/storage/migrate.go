@@ -0,0 +1,187 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"hash"
+	"io"
+	"mime"
+	"path"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+)
+
+// MigrateResult contains counts of
+// keys handled during a storage migration.
+type MigrateResult struct {
+	Copied  int   // keys copied to destination
+	Skipped int   // keys already in destination
+	Failed  int   // keys that failed to copy / verify
+	Bytes   int64 // total bytes copied
+}
+
+// Migrate copies all keys from src storage to dst storage,
+// setting content-type where supported by the destination
+// and verifying a SHA256 checksum of the data after write.
+//
+// Keys already present in dst with a matching size are skipped,
+// so an interrupted migration can be resumed by running it again.
+// Failures of individual keys are logged and counted, they do not
+// stop the migration. Note that only the underlying storage of each
+// driver is accessed, any configured fallback storage is ignored.
+func Migrate(ctx context.Context, src, dst *Driver) (MigrateResult, error) {
+	var res MigrateResult
+
+	// Gather all keys first, rather than copying
+	// while walking, to avoid holding open long
+	// running storage listings during the copy.
+	var keys []string
+	if err := src.WalkKeys(ctx, func(key string) error {
+		keys = append(keys, key)
+		return nil
+	}); err != nil {
+		return res, gtserror.Newf("error walking source storage: %w", err)
+	}
+
+	log.Infof(ctx, "migrating %d keys", len(keys))
+
+	// Reuse one hasher for checksums.
+	hash := sha256.New()
+
+	for i, key := range keys {
+		if err := ctx.Err(); err != nil {
+			return res, err
+		}
+
+		copied, sz, err := migrateKey(ctx, src, dst, key, hash)
+		switch {
+		case err != nil:
+			log.Errorf(ctx, "error migrating %s: %v", key, err)
+			res.Failed++
+		case copied:
+			res.Copied++
+			res.Bytes += sz
+		default:
+			res.Skipped++
+		}
+
+		if (i+1)%1000 == 0 {
+			log.Infof(ctx, "migrated %d/%d keys", i+1, len(keys))
+		}
+	}
+
+	return res, nil
+}
+
+// migrateKey copies the value at key from src to dst storage, verifying it
+// after write. Returns whether the key was copied, or skipped if it already exists.
+func migrateKey(ctx context.Context, src, dst *Driver, key string, hash hash.Hash) (bool, int64, error) {
+	srcStat, err := src.Storage.Stat(ctx, key)
+	if err != nil {
+		return false, 0, gtserror.Newf("error getting source stat: %w", err)
+	} else if srcStat == nil {
+		// Removed since walk.
+		return false, 0, nil
+	}
+
+	dstStat, err := dst.Storage.Stat(ctx, key)
+	if err != nil {
+		return false, 0, gtserror.Newf("error getting destination stat: %w", err)
+	}
+
+	if dstStat != nil {
+		if dstStat.Size == srcStat.Size {
+			// Already migrated.
+			return false, 0, nil
+		}
+
+		// Size mismatch, likely a partial write from an
+		// interrupted migration. Remove so it can be rewritten.
+		if err := dst.Storage.Remove(ctx, key); err != nil && !IsNotFound(err) {
+			return false, 0, gtserror.Newf("error removing partial destination: %w", err)
+		}
+	}
+
+	// Get content-type from key file extension, as
+	// is done when generating presigned S3 URLs.
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	rc, err := src.Storage.ReadStream(ctx, key)
+	if err != nil {
+		return false, 0, gtserror.Newf("error opening source: %w", err)
+	}
+
+	// Write to destination while
+	// calculating source checksum.
+	hash.Reset()
+	sz, err := dst.PutStream(ctx, key,
+		io.TeeReader(rc, hash),
+		contentType,
+	)
+
+	// Close the source: done with it.
+	if e := rc.Close(); e != nil {
+		log.Errorf(ctx, "error closing source %s: %v", key, e)
+	}
+
+	if err != nil {
+		return false, 0, err
+	}
+
+	srcSum := hash.Sum(nil)
+
+	// Read back and checksum destination.
+	dstSum, err := checksum(ctx, dst, key, hash)
+	if err == nil && !bytes.Equal(srcSum, dstSum) {
+		err = gtserror.New("checksum mismatch")
+	}
+
+	if err != nil {
+		// Don't leave unverified data
+		// behind in destination storage.
+		if e := dst.Storage.Remove(ctx, key); e != nil {
+			log.Errorf(ctx, "error removing destination %s: %v", key, e)
+		}
+		return false, 0, gtserror.Newf("error verifying destination: %w", err)
+	}
+
+	return true, sz, nil
+}
+
+// checksum returns the checksum of the value at key in storage, using given hash.
+func checksum(ctx context.Context, d *Driver, key string, hash hash.Hash) ([]byte, error) {
+	rc, err := d.Storage.ReadStream(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	hash.Reset()
+	if _, err := io.Copy(hash, rc); err != nil {
+		return nil, err
+	}
+
+	return hash.Sum(nil), nil
+}
@@ -0,0 +1,128 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"code.superseriousbusiness.org/gotosocial/internal/storage"
+	"codeberg.org/gruf/go-storage/disk"
+)
+
+func openDisk(t *testing.T) *storage.Driver {
+	st, err := disk.Open(t.TempDir(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &storage.Driver{Storage: st}
+}
+
+func TestMigrate(t *testing.T) {
+	ctx := context.Background()
+	src := openDisk(t)
+	dst := openDisk(t)
+
+	values := map[string][]byte{
+		"01F8MH1H7YV1Z7D2C8K2730QBF/attachment/original/01F8MH6NEM8D7527KZAECTCR76.jpg": []byte("some jpeg data"),
+		"01F8MH1H7YV1Z7D2C8K2730QBF/attachment/small/01F8MH6NEM8D7527KZAECTCR76.webp":   []byte("some webp data"),
+		"01F8MH17FWEB39HZJ76B6VXSKF/emoji/original/01F8MH9H8E4VG3KDYJR9EGPXCQ.png":      []byte("some png data"),
+	}
+	for key, value := range values {
+		if _, err := src.Put(ctx, key, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Already migrated key should be skipped.
+	if _, err := dst.Put(ctx, "01F8MH17FWEB39HZJ76B6VXSKF/emoji/original/01F8MH9H8E4VG3KDYJR9EGPXCQ.png", []byte("some png data")); err != nil {
+		t.Fatal(err)
+	}
+
+	// Partially written key should be rewritten.
+	if _, err := dst.Put(ctx, "01F8MH1H7YV1Z7D2C8K2730QBF/attachment/small/01F8MH6NEM8D7527KZAECTCR76.webp", []byte("some")); err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := storage.Migrate(ctx, src, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Copied != 2 || res.Skipped != 1 || res.Failed != 0 {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+
+	for key, value := range values {
+		b, err := dst.Get(ctx, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(b, value) {
+			t.Fatalf("unexpected value at %s: %q", key, b)
+		}
+	}
+
+	// Running again should skip everything.
+	res, err = storage.Migrate(ctx, src, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res.Copied != 0 || res.Skipped != 3 || res.Failed != 0 {
+		t.Fatalf("unexpected result: %+v", res)
+	}
+}
+
+func TestFallback(t *testing.T) {
+	ctx := context.Background()
+	fallback := openDisk(t)
+	driver := openDisk(t)
+	driver.Fallback = fallback
+
+	const key = "01F8MH1H7YV1Z7D2C8K2730QBF/attachment/original/01F8MH6NEM8D7527KZAECTCR76.jpg"
+	if _, err := fallback.Put(ctx, key, []byte("some jpeg data")); err != nil {
+		t.Fatal(err)
+	}
+
+	if has, err := driver.Has(ctx, key); err != nil || !has {
+		t.Fatalf("expected key in storage: %v", err)
+	}
+
+	b, err := driver.Get(ctx, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "some jpeg data" {
+		t.Fatalf("unexpected value: %q", b)
+	}
+
+	// Delete should remove from both.
+	if err := driver.Delete(ctx, key); err != nil {
+		t.Fatal(err)
+	}
+
+	if has, err := driver.Has(ctx, key); err != nil || has {
+		t.Fatalf("expected key not in storage: %v", err)
+	}
+
+	if err := driver.Delete(ctx, key); !storage.IsNotFound(err) {
+		t.Fatalf("expected not found error, got: %v", err)
+	}
+}
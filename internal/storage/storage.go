@@ -75,6 +75,11 @@ type Driver struct {
 	// Underlying storage
 	Storage storage.Storage
 
+	// Fallback storage, if set, is read from
+	// for keys not found in underlying storage,
+	// eg., while migrating between backends.
+	Fallback *Driver
+
 	// S3-only parameters
 	Proxy          bool
 	Bucket         string
@@ -84,12 +89,20 @@ type Driver struct {
 
 // Get returns the byte value for key in storage.
 func (d *Driver) Get(ctx context.Context, key string) ([]byte, error) {
-	return d.Storage.ReadBytes(ctx, key)
+	b, err := d.Storage.ReadBytes(ctx, key)
+	if IsNotFound(err) && d.Fallback != nil {
+		return d.Fallback.Get(ctx, key)
+	}
+	return b, err
 }
 
 // GetStream returns an io.ReadCloser for the value bytes at key in the storage.
 func (d *Driver) GetStream(ctx context.Context, key string) (io.ReadCloser, error) {
-	return d.Storage.ReadStream(ctx, key)
+	rc, err := d.Storage.ReadStream(ctx, key)
+	if IsNotFound(err) && d.Fallback != nil {
+		return d.Fallback.GetStream(ctx, key)
+	}
+	return rc, err
 }
 
 // Put writes the supplied value bytes at key in the storage
@@ -106,16 +119,32 @@ func (d *Driver) PutFile(ctx context.Context, key, filepath, contentType string)
 		return 0, gtserror.Newf("error opening file %s: %w", filepath, err)
 	}
 
-	var sz int64
+	// Write the file data to storage under key.
+	sz, err := d.PutStream(ctx, key, file, contentType)
+
+	// Close the file: done with it.
+	if e := file.Close(); e != nil {
+		log.Errorf(ctx, "error closing file %s: %v", filepath, e)
+	}
+
+	return sz, err
+}
+
+// PutStream writes the contents of reader to storage.Driver{} under given key (with content-type if supported).
+func (d *Driver) PutStream(ctx context.Context, key string, r io.Reader, contentType string) (int64, error) {
+	var (
+		sz  int64
+		err error
+	)
 
 	switch d := d.Storage.(type) {
 	case *s3.S3Storage:
 		var info minio.UploadInfo
 
-		// For S3 storage, write the file but specifically pass in the
+		// For S3 storage, write the data but specifically pass in the
 		// content-type as an extra option. This handles the case of media
 		// being served via CDN redirect (where we don't handle content-type).
-		info, err = d.PutObject(ctx, key, file, minio.PutObjectOptions{
+		info, err = d.PutObject(ctx, key, r, minio.PutObjectOptions{
 			ContentType: contentType,
 		})
 
@@ -124,20 +153,15 @@ func (d *Driver) PutFile(ctx context.Context, key, filepath, contentType string)
 		sz = info.Size
 
 	default:
-		// Write the file data to storage under key. Note
-		// that for disk.DiskStorage{} this should end up
-		// being a highly optimized Linux sendfile syscall.
-		sz, err = d.WriteStream(ctx, key, file)
+		// Write the data to storage under key. Note that
+		// for disk.DiskStorage{} with an *os.File reader this
+		// should end up being a highly optimized Linux sendfile.
+		sz, err = d.WriteStream(ctx, key, r)
 	}
 
 	// Wrap write error.
 	if err != nil {
-		err = gtserror.Newf("error writing file %s: %w", key, err)
-	}
-
-	// Close the file: done with it.
-	if e := file.Close(); e != nil {
-		log.Errorf(ctx, "error closing file %s: %v", filepath, e)
+		err = gtserror.Newf("error writing %s: %w", key, err)
 	}
 
 	return sz, err
@@ -145,12 +169,35 @@ func (d *Driver) PutFile(ctx context.Context, key, filepath, contentType string)
 
 // Delete attempts to remove the supplied key (and corresponding value) from storage.
 func (d *Driver) Delete(ctx context.Context, key string) error {
-	return d.Storage.Remove(ctx, key)
+	err := d.Storage.Remove(ctx, key)
+	if d.Fallback == nil {
+		return err
+	}
+
+	// Also remove from fallback storage, so
+	// the key can't reappear via fallback reads.
+	ferr := d.Fallback.Delete(ctx, key)
+
+	switch {
+	case IsNotFound(err):
+		// Not in underlying storage,
+		// result is that of fallback.
+		return ferr
+	case err != nil:
+		return err
+	case ferr != nil && !IsNotFound(ferr):
+		return ferr
+	default:
+		return nil
+	}
 }
 
 // Has checks if the supplied key is in the storage.
 func (d *Driver) Has(ctx context.Context, key string) (bool, error) {
 	stat, err := d.Storage.Stat(ctx, key)
+	if stat == nil && err == nil && d.Fallback != nil {
+		return d.Fallback.Has(ctx, key)
+	}
 	return (stat != nil), err
 }
 
@@ -182,6 +229,15 @@ func (d *Driver) URL(ctx context.Context, key string) *PresignedURL {
 		return &e.Value
 	}
 
+	if d.Fallback != nil {
+		// During migration the key may only be in
+		// fallback storage, in which case we return
+		// nil so it gets proxied via GetStream().
+		if stat, _ := s3.Stat(ctx, key); stat == nil {
+			return nil
+		}
+	}
+
 	var (
 		u   *url.URL
 		err error
@@ -278,7 +334,34 @@ func (d *Driver) ProbeCSPUri(ctx context.Context) (string, error) {
 }
 
 func AutoConfig() (*Driver, error) {
-	switch backend := config.GetStorageBackend(); backend {
+	backend := config.GetStorageBackend()
+	driver, err := NewBackend(backend)
+	if err != nil {
+		return nil, err
+	}
+
+	switch fallback := config.GetStorageFallbackBackend(); fallback {
+	case "":
+		// no fallback
+
+	case backend:
+		return nil, fmt.Errorf("%s must differ from %s", config.StorageFallbackBackendFlag, config.StorageBackendFlag)
+
+	default:
+		driver.Fallback, err = NewBackend(fallback)
+		if err != nil {
+			return nil, err
+		}
+
+		log.Infof(nil, "reading media not found in %s storage from %s storage", backend, fallback)
+	}
+
+	return driver, nil
+}
+
+// NewBackend returns a new storage driver for the given backend name.
+func NewBackend(backend string) (*Driver, error) {
+	switch backend {
 	case "s3":
 		return NewS3Storage()
 	case "local":
@@ -50,6 +50,18 @@
 	// EventTypeConversation -- a user
 	// should be shown an updated conversation.
 	EventTypeConversation = "conversation"
+
+	// EventTypeAnnouncement -- a user should be
+	// shown a new or updated instance announcement.
+	EventTypeAnnouncement = "announcement"
+
+	// EventTypeAnnouncementReaction -- a reaction to
+	// an instance announcement was added or removed.
+	EventTypeAnnouncementReaction = "announcement.reaction"
+
+	// EventTypeAnnouncementDelete -- an instance
+	// announcement should be removed from a user.
+	EventTypeAnnouncementDelete = "announcement.delete"
 )
 
 const (
@@ -0,0 +1,56 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package text
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SearchTerms splits the given plaintext into lowercase
+// terms for full-text search, ie., runs of letters, numbers
+// and marks. Anything else (whitespace, punctuation, symbols)
+// is treated as a boundary between terms.
+//
+// Text is split the same way both when it is indexed and
+// when it is queried, so that what's stored in the search
+// index always lines up with what callers type in.
+func SearchTerms(s string) []string {
+	return strings.FieldsFunc(
+		strings.ToLower(s),
+		func(r rune) bool {
+			return !unicode.IsLetter(r) &&
+				!unicode.IsNumber(r) &&
+				!unicode.IsMark(r)
+		},
+	)
+}
+
+// SearchText converts the given HTML strings to
+// plaintext, and joins their search terms with
+// single spaces, ready for full-text search indexing.
+func SearchText(html ...string) string {
+	var terms []string
+	for _, h := range html {
+		if h == "" {
+			continue
+		}
+		terms = append(terms, SearchTerms(ParseHTMLToPlain(h))...)
+	}
+	return strings.Join(terms, " ")
+}
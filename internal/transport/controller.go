@@ -20,6 +20,7 @@
 import (
 	"bytes"
 	"context"
+	"crypto/ed25519"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/json"
@@ -80,6 +81,17 @@ func NewController(state *state.State, federatingDB *federatingdb.DB, client pub
 }
 
 func (c *controller) NewTransport(pubKeyID string, privkey *rsa.PrivateKey) (Transport, error) {
+	return c.newTransport(pubKeyID, privkey, "", nil)
+}
+
+// newTransport returns an http signature transport with the given rsa public
+// key ID and private key, and optional ed25519 public key ID and private key.
+func (c *controller) newTransport(
+	pubKeyID string,
+	privkey *rsa.PrivateKey,
+	edPubKeyID string,
+	edPrivkey ed25519.PrivateKey,
+) (Transport, error) {
 	// Generate public key string for cache key
 	//
 	// NOTE: it is safe to use the public key as the cache
@@ -87,6 +99,9 @@ func (c *controller) NewTransport(pubKeyID string, privkey *rsa.PrivateKey) (Tra
 	// private key. If we were simply using a public key
 	// provided as argument that would absolutely NOT be safe.
 	pubStr := privkeyToPublicStr(privkey)
+	if edPrivkey != nil {
+		pubStr += edPubKeyID
+	}
 
 	// First check for cached transport
 	transp, ok := c.trspCache.Get(pubStr)
@@ -99,6 +114,8 @@ func (c *controller) NewTransport(pubKeyID string, privkey *rsa.PrivateKey) (Tra
 		controller: c,
 		pubKeyID:   pubKeyID,
 		privkey:    privkey,
+		edPubKeyID: edPubKeyID,
+		edPrivkey:  edPrivkey,
 	}
 
 	// Cache this transport under pubkey
@@ -134,7 +151,12 @@ func (c *controller) NewTransportForUsername(ctx context.Context, username strin
 		return nil, fmt.Errorf("error getting account %s from db: %s", username, err)
 	}
 
-	transport, err := c.NewTransport(ourAccount.PublicKeyURI, ourAccount.PrivateKey)
+	transport, err := c.newTransport(
+		ourAccount.PublicKeyURI,
+		ourAccount.PrivateKey,
+		ourAccount.Ed25519PublicKeyURI,
+		ourAccount.Ed25519PrivateKey,
+	)
 	if err != nil {
 		return nil, fmt.Errorf("error creating transport for user %s: %s", username, err)
 	}
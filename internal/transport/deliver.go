@@ -131,8 +131,9 @@ func (t *transport) prepare(
 	*delivery.Delivery,
 	error,
 ) {
-	// Prepare POST signer.
-	sign := t.signPOST(data)
+	// Prepare POST signer, negotiated
+	// with remote's signature scheme.
+	sign, knock := t.negotiate(ctx, to.Host, data, t.signPOST(data))
 
 	// Use *bytes.Reader for request body,
 	// as NewRequest() automatically will
@@ -143,6 +144,7 @@ func (t *transport) prepare(
 	// Update to-be-used request context with signing details.
 	ctx = gtscontext.SetOutgoingPublicKeyID(ctx, t.pubKeyID)
 	ctx = gtscontext.SetHTTPClientSignFunc(ctx, sign)
+	ctx = gtscontext.SetHTTPClientKnockFunc(ctx, knock)
 
 	// Prepare a new request with data body directed at URL.
 	r, err := http.NewRequestWithContext(ctx, "POST", to.String(), body)
@@ -191,16 +193,21 @@ func (t *transport) SignDelivery(dlv *delivery.Delivery) error {
 		return gtserror.Newf("error reading request body: %w", err)
 	}
 
-	// Get signing function for POST data.
-	// (note that delivery is ALWAYS POST).
-	sign := t.signPOST(data)
-
 	// Extract delivery context.
 	ctx := dlv.Request.Context()
 
+	// Get signing function for POST data.
+	// (note that delivery is ALWAYS POST).
+	sign, knock := t.negotiate(ctx,
+		dlv.Request.URL.Host,
+		data,
+		t.signPOST(data),
+	)
+
 	// Update delivery request context with signing details.
 	ctx = gtscontext.SetOutgoingPublicKeyID(ctx, t.pubKeyID)
 	ctx = gtscontext.SetHTTPClientSignFunc(ctx, sign)
+	ctx = gtscontext.SetHTTPClientKnockFunc(ctx, knock)
 	dlv.Request.Request = dlv.Request.Request.WithContext(ctx)
 
 	return nil
@@ -43,8 +43,8 @@ func (suite *DereferenceTestSuite) TestDerefLocalUser() {
 	defer resp.Body.Close()
 
 	suite.Equal(http.StatusOK, resp.StatusCode)
-	suite.EqualValues(2109, resp.ContentLength)
-	suite.Equal("2109", resp.Header.Get("Content-Length"))
+	suite.EqualValues(2580, resp.ContentLength)
+	suite.Equal("2580", resp.Header.Get("Content-Length"))
 	suite.Equal(apiutil.AppActivityLDJSON, resp.Header.Get("Content-Type"))
 
 	b, err := io.ReadAll(resp.Body)
@@ -62,18 +62,33 @@ func (suite *DereferenceTestSuite) TestDerefLocalUser() {
     "https://gotosocial.org/ns",
     "https://w3id.org/security/v1",
     "https://www.w3.org/ns/activitystreams",
+    "https://w3id.org/security/data-integrity/v1",
     {
       "discoverable": "toot:discoverable",
       "featured": {
         "@id": "toot:featured",
         "@type": "@id"
       },
+      "featuredTags": {
+        "@id": "toot:featuredTags",
+        "@type": "@id"
+      },
+      "indexable": "toot:indexable",
       "manuallyApprovesFollowers": "as:manuallyApprovesFollowers",
       "toot": "http://joinmastodon.org/ns#"
     }
   ],
+  "assertionMethod": [
+    {
+      "controller": "http://localhost:8080/users/the_mighty_zork",
+      "id": "http://localhost:8080/users/the_mighty_zork/main-key#ed25519-key",
+      "publicKeyMultibase": "z6Mku8JuRFRNwnAe7TszvAQq8AqmXJB1LBve5wVaJENz18eb",
+      "type": "Multikey"
+    }
+  ],
   "discoverable": true,
   "featured": "http://localhost:8080/users/the_mighty_zork/collections/featured",
+  "featuredTags": "http://localhost:8080/users/the_mighty_zork/collections/tags",
   "followers": "http://localhost:8080/users/the_mighty_zork/followers",
   "following": "http://localhost:8080/users/the_mighty_zork/following",
   "hidesCcPublicFromUnauthedWeb": false,
@@ -92,6 +107,7 @@ func (suite *DereferenceTestSuite) TestDerefLocalUser() {
     "url": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/original/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg"
   },
   "inbox": "http://localhost:8080/users/the_mighty_zork/inbox",
+  "indexable": false,
   "manuallyApprovesFollowers": false,
   "name": "original zork (he/they)",
   "outbox": "http://localhost:8080/users/the_mighty_zork/outbox",
@@ -119,8 +135,8 @@ func (suite *DereferenceTestSuite) TestDerefLocalStatus() {
 	defer resp.Body.Close()
 
 	suite.Equal(http.StatusOK, resp.StatusCode)
-	suite.EqualValues(1769, resp.ContentLength)
-	suite.Equal("1769", resp.Header.Get("Content-Length"))
+	suite.EqualValues(1916, resp.ContentLength)
+	suite.Equal("1916", resp.Header.Get("Content-Length"))
 	suite.Equal(apiutil.AppActivityLDJSON, resp.Header.Get("Content-Type"))
 
 	b, err := io.ReadAll(resp.Body)
@@ -170,6 +186,14 @@ func (suite *DereferenceTestSuite) TestDerefLocalStatus() {
       ],
       "manualApproval": []
     },
+    "canQuote": {
+      "automaticApproval": [
+        "http://localhost:8080/users/the_mighty_zork"
+      ],
+      "manualApproval": [
+        "https://www.w3.org/ns/activitystreams#Public"
+      ]
+    },
     "canReply": {
       "always": [
         "https://www.w3.org/ns/activitystreams#Public"
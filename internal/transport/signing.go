@@ -18,6 +18,16 @@
 package transport
 
 import (
+	"context"
+	"errors"
+	"net/http"
+
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/httpclient"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/rfc9421"
 	"code.superseriousbusiness.org/httpsig"
 )
 
@@ -26,7 +36,9 @@
 	prefs      = []httpsig.Algorithm{httpsig.RSA_SHA256}
 	digestAlgo = httpsig.DigestSha256
 
-	// TODO: Update these to use `(created)` pseudo-header instead of `Date`.
+	// NOTE: these remain on `Date` rather than `(created)`
+	// for compatibility with draft-cavage verifiers; peers
+	// supporting `created` should negotiate RFC 9421 instead.
 	getHeaders  = []string{httpsig.RequestTarget, "host", "date"}
 	postHeaders = []string{httpsig.RequestTarget, "host", "date", "digest"}
 )
@@ -42,3 +54,90 @@ func NewPOSTSigner(expiresIn int64) (httpsig.SignerWithOptions, error) {
 	sig, _, err := httpsig.NewSigner(prefs, digestAlgo, postHeaders, httpsig.Signature, expiresIn)
 	return sig, err
 }
+
+// negotiate wraps the given draft-cavage signing func for a request to
+// host, returning a signing func for whichever signature scheme the host
+// is last known to accept, and a "double-knock" func which retries with
+// the alternative scheme on 401 Unauthorized, storing the scheme that
+// succeeded on the host's instance model for subsequent requests.
+func (t *transport) negotiate(
+	ctx context.Context,
+	host string,
+	body []byte,
+	cavage httpclient.SignFunc,
+) (
+	httpclient.SignFunc,
+	func(*http.Request, *http.Response) func(*http.Request) error,
+) {
+	msg := t.signRFC9421(body)
+
+	// Look for a scheme we know this host accepts.
+	var scheme gtsmodel.SignatureScheme
+	instance, err := t.controller.state.DB.GetInstance(
+		gtscontext.SetBarebones(ctx),
+		host,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		log.Errorf(ctx, "db error getting instance %s: %v", host, err)
+	} else if instance != nil {
+		scheme = instance.SignatureScheme
+	}
+
+	knock := func(r *http.Request, rsp *http.Response) func(*http.Request) error {
+		if rsp.StatusCode == http.StatusUnauthorized {
+			// Signature was rejected,
+			// try the alternative.
+			if rfc9421.Signed(r) {
+				return cavage
+			}
+			return msg
+		}
+
+		if instance == nil {
+			// Nowhere to
+			// store result.
+			return nil
+		}
+
+		// Determine scheme that was accepted.
+		accepted := gtsmodel.SignatureSchemeCavage
+		if rfc9421.Signed(r) {
+			accepted = gtsmodel.SignatureSchemeRFC9421
+		}
+
+		if accepted != instance.SignatureScheme {
+			// Store newly negotiated scheme on copy
+			// of instance (as it may be shared) for
+			// use in subsequent outgoing requests.
+			instance2 := new(gtsmodel.Instance)
+			*instance2 = *instance
+			instance2.SignatureScheme = accepted
+			if err := t.controller.state.DB.UpdateInstance(r.Context(),
+				instance2,
+				"signature_scheme",
+			); err != nil {
+				log.Errorf(r.Context(), "error updating instance %s: %v", host, err)
+			}
+		}
+
+		return nil
+	}
+
+	if scheme == gtsmodel.SignatureSchemeCavage {
+		return cavage, knock
+	}
+
+	return msg, knock
+}
+
+// signRFC9421 will sign an HTTP request for given
+// body (nil for GET) as an RFC 9421 message signature,
+// using ed25519 key where available, else RSA-PSS.
+func (t *transport) signRFC9421(body []byte) httpclient.SignFunc {
+	return func(r *http.Request) error {
+		if t.edPrivkey != nil {
+			return rfc9421.Sign(r, body, t.edPubKeyID, t.edPrivkey)
+		}
+		return rfc9421.Sign(r, body, t.pubKeyID, t.privkey)
+	}
+}
@@ -20,6 +20,7 @@
 import (
 	"context"
 	"crypto"
+	"crypto/ed25519"
 	"errors"
 	"io"
 	"net/http"
@@ -102,6 +103,8 @@ type transport struct {
 	controller *controller
 	pubKeyID   string
 	privkey    crypto.PrivateKey
+	edPubKeyID string
+	edPrivkey  ed25519.PrivateKey
 
 	signerExp  time.Time
 	getSigner  httpsig.SignerWithOptions
@@ -114,14 +117,18 @@ func (t *transport) GET(r *http.Request) (*http.Response, error) {
 		return nil, errors.New("must be GET request")
 	}
 
-	// Prepare HTTP GET signing func with opts.
-	sign := t.signGET(httpsig.SignatureOption{
-		ExcludeQueryStringFromPathPseudoHeader: false,
-	})
+	// Prepare HTTP GET signing func with opts,
+	// negotiated with remote's signature scheme.
+	sign, knock := t.negotiate(r.Context(), r.URL.Host, nil,
+		t.signGET(httpsig.SignatureOption{
+			ExcludeQueryStringFromPathPseudoHeader: false,
+		}),
+	)
 
 	ctx := r.Context() // update with signing details.
 	ctx = gtscontext.SetOutgoingPublicKeyID(ctx, t.pubKeyID)
 	ctx = gtscontext.SetHTTPClientSignFunc(ctx, sign)
+	ctx = gtscontext.SetHTTPClientKnockFunc(ctx, knock)
 	r = r.WithContext(ctx) // replace request ctx.
 
 	// Set our predefined controller user-agent.
@@ -144,6 +151,7 @@ func (t *transport) GET(r *http.Request) (*http.Response, error) {
 
 	ctx = r.Context() // update with signing details.
 	ctx = gtscontext.SetHTTPClientSignFunc(ctx, sign)
+	ctx = gtscontext.SetHTTPClientKnockFunc(ctx, nil)
 	r = r.WithContext(ctx) // replace request ctx.
 
 	// Pass to underlying HTTP client.
@@ -155,12 +163,14 @@ func (t *transport) POST(r *http.Request, body []byte) (*http.Response, error) {
 		return nil, errors.New("must be POST request")
 	}
 
-	// Prepare POST signer.
-	sign := t.signPOST(body)
+	// Prepare POST signer, negotiated
+	// with remote's signature scheme.
+	sign, knock := t.negotiate(r.Context(), r.URL.Host, body, t.signPOST(body))
 
 	ctx := r.Context() // update with signing details.
 	ctx = gtscontext.SetOutgoingPublicKeyID(ctx, t.pubKeyID)
 	ctx = gtscontext.SetHTTPClientSignFunc(ctx, sign)
+	ctx = gtscontext.SetHTTPClientKnockFunc(ctx, knock)
 	r = r.WithContext(ctx) // replace request ctx.
 
 	// Set our predefined controller user-agent.
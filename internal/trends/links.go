@@ -0,0 +1,104 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"golang.org/x/net/html"
+)
+
+// ExtractLinks returns the normalized URLs of all
+// distinct external links in the given content HTML,
+// ignoring mention and hashtag links, and any links
+// pointing back to this instance.
+func ExtractLinks(content string) []string {
+	var links []string
+
+	tokenizer := html.NewTokenizer(strings.NewReader(content))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			// End of content.
+			return links
+
+		case html.StartTagToken:
+			token := tokenizer.Token()
+			if token.Data != "a" {
+				continue
+			}
+
+			link, ok := linkFromAnchor(token.Attr)
+			if ok && !slices.Contains(links, link) {
+				links = append(links, link)
+			}
+		}
+	}
+}
+
+// linkFromAnchor returns the normalized href of an anchor
+// element with given attributes, if it is an external link.
+func linkFromAnchor(attrs []html.Attribute) (string, bool) {
+	var href string
+
+	for _, attr := range attrs {
+		switch attr.Key {
+		case "href":
+			href = attr.Val
+
+		case "class":
+			// Mastodon / GtS style mention
+			// and hashtag classes, skip.
+			for _, class := range strings.Fields(attr.Val) {
+				if class == "mention" || class == "hashtag" {
+					return "", false
+				}
+			}
+
+		case "rel":
+			// Tag links, skip.
+			if slices.Contains(strings.Fields(attr.Val), "tag") {
+				return "", false
+			}
+		}
+	}
+
+	u, err := url.Parse(href)
+	if err != nil {
+		return "", false
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", false
+	}
+
+	u.Host = strings.ToLower(u.Host)
+	if u.Host == "" || u.Host == config.GetHost() {
+		return "", false
+	}
+
+	// Drop fragments, they don't
+	// make a link distinct.
+	u.Fragment = ""
+	u.RawFragment = ""
+
+	return u.String(), true
+}
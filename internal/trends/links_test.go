@@ -0,0 +1,59 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends_test
+
+import (
+	"testing"
+
+	"code.superseriousbusiness.org/gotosocial/internal/trends"
+	"code.superseriousbusiness.org/gotosocial/testrig"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractLinks(t *testing.T) {
+	testrig.InitTestConfig()
+
+	for _, test := range []struct {
+		content string
+		expect  []string
+	}{
+		{
+			content: `<p>hello world</p>`,
+			expect:  nil,
+		},
+		{
+			content: `<p><a href="https://Example.org/some/article#comments" rel="nofollow noreferrer noopener" target="_blank">https://example.org/some/article</a></p>`,
+			expect:  []string{"https://example.org/some/article"},
+		},
+		{
+			// Duplicate links only counted once.
+			content: `<p><a href="https://example.org/a">a</a> <a href="https://example.org/a#again">a again</a> <a href="https://example.org/b">b</a></p>`,
+			expect:  []string{"https://example.org/a", "https://example.org/b"},
+		},
+		{
+			// Mentions, hashtags, local and non-http links ignored.
+			content: `<p><span class="h-card"><a href="https://example.org/@someone" class="u-url mention">@<span>someone</span></a></span> ` +
+				`<a href="https://example.org/tags/test" class="mention hashtag" rel="tag">#<span>test</span></a> ` +
+				`<a href="http://localhost:8080/@the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY">local</a> ` +
+				`<a href="mailto:someone@example.org">email</a></p>`,
+			expect: nil,
+		},
+	} {
+		assert.Equal(t, test.expect, trends.ExtractLinks(test.content), test.content)
+	}
+}
@@ -0,0 +1,316 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/cache"
+	"code.superseriousbusiness.org/gotosocial/internal/config"
+	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/log"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+)
+
+const (
+	// Window is the period of usage,
+	// prior to time of calculation,
+	// that is taken into account.
+	Window = 7 * 24 * time.Hour
+
+	// recalculateEvery is the frequency
+	// with which trends are recalculated.
+	recalculateEvery = 15 * time.Minute
+
+	// maxTrends is the maximum number
+	// of trends of each type to keep.
+	maxTrends = 50
+
+	// minAccounts is the minimum number of
+	// distinct accounts that must have used
+	// an item for it to be considered trending.
+	minAccounts = 2
+)
+
+// halfLives contains, per trend type, the period after which
+// the weight of a single use of a trending item is halved.
+var halfLives = map[gtsmodel.TrendType]time.Duration{
+	gtsmodel.TrendTypeTag:    24 * time.Hour,
+	gtsmodel.TrendTypeStatus: 12 * time.Hour,
+	gtsmodel.TrendTypeLink:   24 * time.Hour,
+}
+
+// Trends calculates trending tags, statuses and links
+// from both local and federated usage, storing results
+// in the trends cache for later use by the processor.
+type Trends struct {
+	state *state.State
+}
+
+// New returns a new Trends
+// using the given state.
+func New(state *state.State) *Trends {
+	return &Trends{state: state}
+}
+
+// ScheduleJobs schedules trends recalculation
+// to run periodically, if trends are enabled.
+func (t *Trends) ScheduleJobs() error {
+	if !config.GetInstanceTrendsEnabled() {
+		log.Info(nil, "trends disabled, not scheduling recalculation")
+		return nil
+	}
+
+	log.Infof(nil,
+		"scheduling trends recalculation to run every %s",
+		recalculateEvery,
+	)
+
+	if !t.state.Workers.Scheduler.AddRecurring(
+		"@trendsrecalculate",
+		time.Time{}, // start
+		recalculateEvery,
+		t.Recalculate,
+	) {
+		return gtserror.New("failed to schedule @trendsrecalculate")
+	}
+
+	return nil
+}
+
+// Recalculate recalculates trending tags, statuses and links
+// as of given time, based on usage within the preceding Window,
+// and stores the results in the trends cache. Any trend reviews
+// of items which stopped trending while still pending are dropped.
+func (t *Trends) Recalculate(ctx context.Context, now time.Time) {
+	since := now.Add(-Window)
+
+	for _, trendType := range []gtsmodel.TrendType{
+		gtsmodel.TrendTypeTag,
+		gtsmodel.TrendTypeStatus,
+		gtsmodel.TrendTypeLink,
+	} {
+		samples, err := t.getSamples(ctx, trendType, since)
+		if err != nil {
+			log.Errorf(ctx, "error gathering %s trend samples: %v", trendType, err)
+			continue
+		}
+
+		// Calculate scores for this trend type from samples.
+		trends := calculate(samples, now, halfLives[trendType],
+			// History is only relevant for tags + links.
+			trendType != gtsmodel.TrendTypeStatus,
+		)
+
+		// Fetch or create the review for each trend.
+		trends = t.populateReviews(ctx, trendType, trends)
+
+		// Store the calculated trends.
+		t.state.Caches.Trends.Set(trendType, trends)
+	}
+
+	// Drop reviews for items that are no longer trending.
+	if err := t.state.DB.DeletePendingTrendReviewsOlderThan(ctx, since); err != nil {
+		log.Errorf(ctx, "error deleting stale trend reviews: %v", err)
+	}
+}
+
+// getSamples fetches usage samples
+// for given trend type since time.
+func (t *Trends) getSamples(
+	ctx context.Context,
+	trendType gtsmodel.TrendType,
+	since time.Time,
+) ([]*gtsmodel.TrendSample, error) {
+	switch trendType {
+	case gtsmodel.TrendTypeTag:
+		return t.state.DB.GetTagTrendSamples(ctx, since)
+	case gtsmodel.TrendTypeStatus:
+		return t.state.DB.GetStatusTrendSamples(ctx, since)
+	case gtsmodel.TrendTypeLink:
+		return t.state.DB.GetLinkTrendSamples(ctx, since)
+	default:
+		panic("undefined TrendType")
+	}
+}
+
+// populateReviews sets the review ID and state of each of the
+// given trends, creating a new pending review where necessary.
+// Trends for which review could not be fetched are dropped.
+func (t *Trends) populateReviews(
+	ctx context.Context,
+	trendType gtsmodel.TrendType,
+	trends []*cache.CachedTrend,
+) []*cache.CachedTrend {
+	return slices.DeleteFunc(trends, func(trend *cache.CachedTrend) bool {
+		review, err := t.state.DB.GetTrendReviewByTarget(ctx, trendType, trend.TargetID)
+		switch {
+
+		case errors.Is(err, db.ErrNoEntries):
+			// First time item has trended,
+			// create a new pending review.
+			review = &gtsmodel.TrendReview{
+				ID:        id.NewULID(),
+				TrendType: trendType,
+				TargetID:  trend.TargetID,
+				State:     gtsmodel.TrendReviewStatePending,
+			}
+
+			if err := t.state.DB.PutTrendReview(ctx, review); err != nil {
+				log.Errorf(ctx, "error putting trend review: %v", err)
+				return true
+			}
+
+		case err != nil:
+			log.Errorf(ctx, "error getting trend review: %v", err)
+			return true
+
+		case review.IsPending():
+			// Still pending, bump updated_at so
+			// review isn't dropped as stale.
+			if err := t.state.DB.UpdateTrendReview(ctx, review); err != nil {
+				log.Errorf(ctx, "error updating trend review: %v", err)
+			}
+		}
+
+		trend.ReviewID = review.ID
+		trend.State = review.State
+		return false
+	})
+}
+
+// calculate calculates trend scores from given usage samples, as of
+// given time, returning the top trends sorted by score (highest first).
+//
+// Each distinct account contributes only its most recent use of an item
+// to its score, weighted to decay exponentially with the given half life,
+// which prevents single accounts from pushing items into trending. If
+// history is set, daily usage history within Window is also calculated.
+func calculate(
+	samples []*gtsmodel.TrendSample,
+	now time.Time,
+	halfLife time.Duration,
+	history bool,
+) []*cache.CachedTrend {
+	type usage struct {
+		latest  map[string]time.Time // account ID -> latest use
+		samples []*gtsmodel.TrendSample
+	}
+
+	// Group samples by target.
+	usages := make(map[string]*usage)
+	for _, sample := range samples {
+		u := usages[sample.TargetID]
+		if u == nil {
+			u = &usage{latest: make(map[string]time.Time)}
+			usages[sample.TargetID] = u
+		}
+
+		if sample.CreatedAt.After(u.latest[sample.AccountID]) {
+			u.latest[sample.AccountID] = sample.CreatedAt
+		}
+
+		if history {
+			u.samples = append(u.samples, sample)
+		}
+	}
+
+	trends := make([]*cache.CachedTrend, 0, len(usages))
+	for targetID, u := range usages {
+		if len(u.latest) < minAccounts {
+			// Not enough
+			// accounts.
+			continue
+		}
+
+		// Sum decayed weight of each account's latest use.
+		var score float64
+		for _, at := range u.latest {
+			age := max(now.Sub(at), 0)
+			score += math.Exp2(-float64(age) / float64(halfLife))
+		}
+
+		trend := &cache.CachedTrend{
+			TargetID: targetID,
+			Score:    score,
+		}
+
+		if history {
+			trend.History = dailyHistory(u.samples, now)
+		}
+
+		trends = append(trends, trend)
+	}
+
+	// Sort by score, highest first (ties broken by target ID for stability).
+	slices.SortFunc(trends, func(a, b *cache.CachedTrend) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.TargetID, b.TargetID)
+	})
+
+	if len(trends) > maxTrends {
+		trends = trends[:maxTrends]
+	}
+
+	return trends
+}
+
+// dailyHistory calculates the daily usage of an item from
+// given samples, for each UTC day within Window of now,
+// ordered from the current day to the oldest.
+func dailyHistory(samples []*gtsmodel.TrendSample, now time.Time) []cache.TrendHistory {
+	const day = 24 * time.Hour
+	days := int(Window / day)
+
+	// Midnight (UTC) of today.
+	today := now.UTC().Truncate(day)
+
+	history := make([]cache.TrendHistory, days)
+	accounts := make([]map[string]struct{}, days)
+	for i := range history {
+		history[i].Day = today.Add(-time.Duration(i) * day)
+		accounts[i] = make(map[string]struct{})
+	}
+
+	for _, sample := range samples {
+		i := int(today.Sub(sample.CreatedAt.UTC().Truncate(day)) / day)
+		if i < 0 || i >= days {
+			// Out of range.
+			continue
+		}
+
+		history[i].Uses++
+		accounts[i][sample.AccountID] = struct{}{}
+	}
+
+	for i := range history {
+		history[i].Accounts = len(accounts[i])
+	}
+
+	return history
+}
@@ -0,0 +1,309 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends_test
+
+import (
+	"testing"
+	"time"
+
+	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/id"
+	"code.superseriousbusiness.org/gotosocial/internal/state"
+	"code.superseriousbusiness.org/gotosocial/internal/trends"
+	"code.superseriousbusiness.org/gotosocial/testrig"
+	"github.com/stretchr/testify/suite"
+)
+
+type TrendsTestSuite struct {
+	state  state.State
+	trends *trends.Trends
+
+	testAccounts map[string]*gtsmodel.Account
+	testStatuses map[string]*gtsmodel.Status
+	testTags     map[string]*gtsmodel.Tag
+
+	suite.Suite
+}
+
+func TestTrendsTestSuite(t *testing.T) {
+	suite.Run(t, &TrendsTestSuite{})
+}
+
+func (suite *TrendsTestSuite) SetupSuite() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+}
+
+func (suite *TrendsTestSuite) SetupTest() {
+	suite.state.Caches.Init()
+	testrig.StartNoopWorkers(&suite.state)
+
+	_ = testrig.NewTestDB(&suite.state)
+	testrig.StandardDBSetup(suite.state.DB, nil)
+
+	suite.trends = trends.New(&suite.state)
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testStatuses = testrig.NewTestStatuses()
+	suite.testTags = testrig.NewTestTags()
+}
+
+func (suite *TrendsTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.state.DB)
+	testrig.StopWorkers(&suite.state)
+}
+
+// putStatus stores a new public status by the given
+// account, created at the given time, with the given
+// content and tags, copying other fields from a test status.
+func (suite *TrendsTestSuite) putStatus(
+	account *gtsmodel.Account,
+	createdAt time.Time,
+	content string,
+	tags ...*gtsmodel.Tag,
+) *gtsmodel.Status {
+	status := new(gtsmodel.Status)
+	*status = *suite.testStatuses["admin_account_status_1"]
+
+	status.ID = id.NewULID()
+	status.URI = account.URI + "/statuses/" + status.ID
+	status.URL = account.URL + "/statuses/" + status.ID
+	status.CreatedAt = createdAt
+	status.Content = content
+	status.AccountID = account.ID
+	status.AccountURI = account.URI
+	status.Account = account
+	status.Visibility = gtsmodel.VisibilityPublic
+	status.AttachmentIDs = nil
+	status.Attachments = nil
+	status.MentionIDs = nil
+	status.Mentions = nil
+	status.EmojiIDs = nil
+	status.Emojis = nil
+	status.TagIDs = nil
+	status.Tags = tags
+	for _, tag := range tags {
+		status.TagIDs = append(status.TagIDs, tag.ID)
+	}
+
+	if err := suite.state.DB.PutStatus(suite.T().Context(), status); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return status
+}
+
+// putCard stores a new link
+// preview card for the given URL.
+func (suite *TrendsTestSuite) putCard(url string) *gtsmodel.Card {
+	card := &gtsmodel.Card{
+		ID:    id.NewULID(),
+		URL:   url,
+		Type:  gtsmodel.CardTypeLink,
+		Title: "An article",
+	}
+
+	if err := suite.state.DB.PutCard(suite.T().Context(), card); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return card
+}
+
+// putCardStatus stores a new public status as putStatus
+// does, with the given link preview card attached.
+func (suite *TrendsTestSuite) putCardStatus(
+	account *gtsmodel.Account,
+	createdAt time.Time,
+	content string,
+	card *gtsmodel.Card,
+) *gtsmodel.Status {
+	status := suite.putStatus(account, createdAt, content)
+	status.CardID = card.ID
+
+	if err := suite.state.DB.UpdateStatus(suite.T().Context(), status, "card_id"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return status
+}
+
+// putFave stores a new fave of the given
+// status by account, at the given time.
+func (suite *TrendsTestSuite) putFave(
+	account *gtsmodel.Account,
+	status *gtsmodel.Status,
+	createdAt time.Time,
+) {
+	faveID := id.NewULID()
+	if err := suite.state.DB.PutStatusFave(suite.T().Context(), &gtsmodel.StatusFave{
+		ID:              faveID,
+		CreatedAt:       createdAt,
+		UpdatedAt:       createdAt,
+		AccountID:       account.ID,
+		TargetAccountID: status.AccountID,
+		StatusID:        status.ID,
+		URI:             account.URI + "/liked/" + faveID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *TrendsTestSuite) TestRecalculateTags() {
+	var (
+		ctx     = suite.T().Context()
+		now     = time.Now()
+		hashtag = suite.testTags["Hashtag"]
+		welcome = suite.testTags["welcome"]
+	)
+
+	// #hashtag used by two accounts, #welcome used
+	// twice by just one account, so shouldn't trend.
+	suite.putStatus(suite.testAccounts["local_account_1"], now.Add(-2*time.Hour), "hi", hashtag, welcome)
+	suite.putStatus(suite.testAccounts["local_account_2"], now.Add(-26*time.Hour), "hey", hashtag)
+	suite.putStatus(suite.testAccounts["local_account_1"], now.Add(-time.Hour), "hello", welcome)
+
+	suite.trends.Recalculate(ctx, now)
+
+	cached := suite.state.Caches.Trends.Get(gtsmodel.TrendTypeTag, false)
+	if !suite.Len(cached, 1) {
+		suite.FailNow("")
+	}
+
+	trend := cached[0]
+	suite.Equal(hashtag.ID, trend.TargetID)
+	suite.Equal(gtsmodel.TrendReviewStatePending, trend.State)
+
+	// Latest use (2h old) weighted at 2^(-1/12), other (26h old) at 2^(-26/24).
+	suite.InDelta(0.944+0.472, trend.Score, 0.01)
+
+	// Daily history should contain both uses.
+	suite.Len(trend.History, int(trends.Window/(24*time.Hour)))
+	var uses, accounts int
+	for _, h := range trend.History {
+		uses += h.Uses
+		accounts += h.Accounts
+	}
+	suite.Equal(2, uses)
+	suite.Equal(2, accounts)
+
+	// Pending review should have been created.
+	review, err := suite.state.DB.GetTrendReviewByID(ctx, trend.ReviewID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(gtsmodel.TrendTypeTag, review.TrendType)
+	suite.Equal(hashtag.ID, review.TargetID)
+	suite.True(review.IsPending())
+
+	// Not approved, so nothing public yet.
+	suite.Empty(suite.state.Caches.Trends.Get(gtsmodel.TrendTypeTag, true))
+
+	// Approve the review; trend should now be
+	// shown, and stay approved on recalculation.
+	review.State = gtsmodel.TrendReviewStateApproved
+	if err := suite.state.DB.UpdateTrendReview(ctx, review, "state"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.trends.Recalculate(ctx, now)
+
+	approved := suite.state.Caches.Trends.Get(gtsmodel.TrendTypeTag, true)
+	if suite.Len(approved, 1) {
+		suite.Equal(review.ID, approved[0].ReviewID)
+	}
+}
+
+func (suite *TrendsTestSuite) TestRecalculateStatuses() {
+	var (
+		ctx    = suite.T().Context()
+		now    = time.Now()
+		status = suite.putStatus(suite.testAccounts["admin_account"], now.Add(-3*time.Hour), "trending!")
+	)
+
+	suite.putFave(suite.testAccounts["local_account_1"], status, now.Add(-2*time.Hour))
+	suite.putFave(suite.testAccounts["local_account_2"], status, now.Add(-time.Hour))
+
+	suite.trends.Recalculate(ctx, now)
+
+	cached := suite.state.Caches.Trends.Get(gtsmodel.TrendTypeStatus, false)
+	if !suite.Len(cached, 1) {
+		suite.FailNow("")
+	}
+
+	suite.Equal(status.ID, cached[0].TargetID)
+	suite.Empty(cached[0].History)
+}
+
+func (suite *TrendsTestSuite) TestRecalculateLinks() {
+	var (
+		ctx     = suite.T().Context()
+		now     = time.Now()
+		content = `<p>look at <a href="https://example.org/article#section" rel="nofollow noreferrer noopener" target="_blank">this</a></p>`
+		card    = suite.putCard("https://example.org/article")
+	)
+
+	// Link used by two accounts, but only
+	// statuses with a preview card count.
+	suite.putCardStatus(suite.testAccounts["local_account_1"], now.Add(-time.Hour), content, card)
+	suite.putCardStatus(suite.testAccounts["local_account_2"], now.Add(-time.Hour), content, card)
+	suite.putStatus(suite.testAccounts["admin_account"], now.Add(-time.Hour), content)
+
+	suite.trends.Recalculate(ctx, now)
+
+	cached := suite.state.Caches.Trends.Get(gtsmodel.TrendTypeLink, false)
+	if !suite.Len(cached, 1) {
+		suite.FailNow("")
+	}
+
+	suite.Equal(card.URL, cached[0].TargetID)
+	// Two uses (1h old), each weighted at 2^(-1/24).
+	suite.InDelta(2*0.972, cached[0].Score, 0.01)
+}
+
+func (suite *TrendsTestSuite) TestRecalculateLinksNoCards() {
+	var (
+		ctx     = suite.T().Context()
+		now     = time.Now()
+		content = `<p>look at <a href="https://example.org/article" rel="nofollow noreferrer noopener" target="_blank">this</a></p>`
+	)
+
+	// Links without preview
+	// cards aren't sampled.
+	suite.putStatus(suite.testAccounts["local_account_1"], now.Add(-time.Hour), content)
+	suite.putStatus(suite.testAccounts["local_account_2"], now.Add(-time.Hour), content)
+
+	suite.trends.Recalculate(ctx, now)
+
+	suite.Empty(suite.state.Caches.Trends.Get(gtsmodel.TrendTypeLink, false))
+}
+
+func (suite *TrendsTestSuite) TestRecalculateOutsideWindow() {
+	var (
+		ctx     = suite.T().Context()
+		now     = time.Now()
+		hashtag = suite.testTags["Hashtag"]
+	)
+
+	// Uses are older than the trends window.
+	suite.putStatus(suite.testAccounts["local_account_1"], now.Add(-trends.Window-time.Hour), "hi", hashtag)
+	suite.putStatus(suite.testAccounts["local_account_2"], now.Add(-trends.Window-time.Hour), "hey", hashtag)
+
+	suite.trends.Recalculate(ctx, now)
+
+	suite.Empty(suite.state.Caches.Trends.Get(gtsmodel.TrendTypeTag, false))
+}
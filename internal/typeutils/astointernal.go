@@ -21,7 +21,9 @@
 	"cmp"
 	"context"
 	"errors"
+	"html"
 	"net/url"
+	"strings"
 	"time"
 
 	"code.superseriousbusiness.org/gotosocial/internal/ap"
@@ -162,6 +164,10 @@ func (c *Converter) ASRepresentationToAccount(
 	discoverable := ap.GetDiscoverable(accountable)
 	acct.Discoverable = &discoverable
 
+	// Extract account search indexability (default = false).
+	indexable := ap.GetIndexable(accountable)
+	acct.Indexable = &indexable
+
 	// Extract the URL property.
 	urls := ap.GetURL(accountable)
 	if len(urls) == 0 {
@@ -209,7 +215,11 @@ func (c *Converter) ASRepresentationToAccount(
 		acct.FeaturedCollectionURI = featuredURI.String()
 	}
 
-	// TODO: FeaturedTagsURI
+	// Extract a FeaturedTagsURI, but only trust if equal to / subdomain of account's domain.
+	if featuredTagsURI := ap.GetFeaturedTags(accountable); // nocollapse
+	featuredTagsURI != nil && dns.CompareDomainName(acct.Domain, featuredTagsURI.Host) >= 2 {
+		acct.FeaturedTagsURI = featuredTagsURI.String()
+	}
 
 	// Moved and AlsoKnownAsURIs,
 	// needed for account migrations.
@@ -244,6 +254,14 @@ func (c *Converter) ASRepresentationToAccount(
 	acct.PublicKey = pkey
 	acct.PublicKeyURI = pkeyURL.String()
 
+	// Extract any Ed25519 key (FEP-521a), ignoring it
+	// if it's not owned by the account, as it's optional.
+	edKey, edKeyURI, edKeyController := ap.GetEd25519AssertionMethod(accountable, nil)
+	if edKey != nil && edKeyController.String() == acct.URI {
+		acct.Ed25519PublicKey = edKey
+		acct.Ed25519PublicKeyURI = edKeyURI.String()
+	}
+
 	// Web visibility for statuses.
 	acct.HidesToPublicFromUnauthedWeb = util.Ptr(ap.GetHidesToPublicFromUnauthedWeb(accountable))
 	acct.HidesCcPublicFromUnauthedWeb = util.Ptr(ap.GetHidesCcPublicFromUnauthedWeb(accountable))
@@ -421,6 +439,46 @@ func (c *Converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 		}
 	}
 
+	// status.QuoteOfURI
+	// status.QuoteOfID
+	// status.QuoteOf
+	// status.QuoteOfAccountID
+	// status.QuoteOfAccount
+	//
+	// Status that this status quotes, if applicable.
+	// If we don't have this status in the database, we
+	// just set the URI and assume we can deref it later.
+	if quoteURI := ap.ExtractQuoteURI(statusable); quoteURI != nil {
+
+		// Set the quoted URI string.
+		quoteURIStr := quoteURI.String()
+		status.QuoteOfURI = quoteURIStr
+
+		// Check if we already have the quoted status.
+		quoteOf, err := c.state.DB.GetStatusByURI(ctx, quoteURIStr)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("error getting quote %s from db: %w", quoteURIStr, err)
+			return nil, err
+		}
+
+		if quoteOf != nil {
+			// We have it in the DB! Set
+			// appropriate fields here and now.
+			status.QuoteOfID = quoteOf.ID
+			status.QuoteOf = quoteOf
+			status.QuoteOfAccountID = quoteOf.AccountID
+			status.QuoteOfAccount = quoteOf.Account
+		}
+
+		// Set quoteAuthorization if present,
+		// for later dereferencing.
+		if wup, ok := statusable.(ap.WithUnknownProperties); ok {
+			if authURI := ap.GetQuoteAuthorization(wup); authURI != nil {
+				status.QuoteApprovedByURI = authURI.String()
+			}
+		}
+	}
+
 	// Calculate intended visibility of the status.
 	status.Visibility, err = ap.ExtractVisibility(
 		statusable,
@@ -454,11 +512,18 @@ func (c *Converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 	// Assume not pending approval; this may
 	// change when permissivity is checked.
 	status.PendingApproval = util.Ptr(false)
+	status.QuotePendingApproval = util.Ptr(false)
 
 	// status.Sensitive
 	sensitive := ap.ExtractSensitive(statusable)
 	status.Sensitive = &sensitive
 
+	// Apply any moderation limits set
+	// on status author or their domain.
+	if err := c.applyContentLimits(ctx, &status); err != nil {
+		return nil, err
+	}
+
 	// ActivityStreamsType
 	status.ActivityStreamsType = statusable.GetTypeName()
 
@@ -563,6 +628,99 @@ func (c *Converter) ASLikeToFave(ctx context.Context, likeable ap.Likeable) (*gt
 	}, nil
 }
 
+// ASEmojiReactToStatusReaction converts a remote activitystreams
+// emoji reaction, ie., an EmojiReact or a Like with content, into
+// a gts model status reaction. If the reaction uses a custom emoji,
+// then the returned reaction's Emoji will be set to a placeholder
+// which the caller should dereference and store before use.
+func (c *Converter) ASEmojiReactToStatusReaction(ctx context.Context, reactable ap.EmojiReactable) (*gtsmodel.StatusReaction, error) {
+	uriObj := ap.GetJSONLDId(reactable)
+	if uriObj == nil {
+		err := gtserror.New("unusable iri property")
+		return nil, gtserror.SetMalformed(err)
+	}
+
+	// Stringify uri obj.
+	uri := uriObj.String()
+
+	// Get the reaction itself from content,
+	// eg., "👍" or ":some_custom_emoji:".
+	content := ap.ExtractContent(reactable).Content
+	content = strings.TrimSpace(content)
+	if content == "" {
+		err := gtserror.Newf("no reaction content for %s", uri)
+		return nil, gtserror.SetMalformed(err)
+	}
+
+	origin, err := c.getASActorAccount(ctx, uri, reactable)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := c.getASObjectStatus(ctx, uri, reactable)
+	if err != nil {
+		return nil, err
+	}
+
+	reaction := &gtsmodel.StatusReaction{
+		AccountID:       origin.ID,
+		Account:         origin,
+		TargetAccountID: target.AccountID,
+		TargetAccount:   target.Account,
+		StatusID:        target.ID,
+		Status:          target,
+		Name:            content,
+		URI:             uri,
+	}
+
+	if len(content) < 3 ||
+		!strings.HasPrefix(content, ":") ||
+		!strings.HasSuffix(content, ":") {
+		// Not a custom emoji
+		// shortcode, we're done.
+		return reaction, nil
+	}
+
+	// Custom emoji reaction, look for
+	// the emoji it uses in the tag property.
+	emojis, err := ap.ExtractEmojis(reactable, origin.Domain)
+	if err != nil {
+		err := gtserror.Newf("error extracting emojis for %s: %w", uri, err)
+		return nil, gtserror.SetMalformed(err)
+	}
+
+	shortcode := strings.Trim(content, ":")
+	for _, emoji := range emojis {
+		if emoji.Shortcode != shortcode {
+			continue
+		}
+
+		// Derive emoji domain from its URI, as
+		// reactions may use emojis from other
+		// instances, including our own.
+		emojiURI, err := url.Parse(emoji.URI)
+		if err != nil {
+			err := gtserror.Newf("error parsing emoji uri %s: %w", emoji.URI, err)
+			return nil, gtserror.SetMalformed(err)
+		}
+
+		switch host := emojiURI.Host; host {
+		case config.GetHost(), config.GetAccountDomain():
+			emoji.Domain = ""
+			reaction.Name = shortcode
+		default:
+			emoji.Domain = host
+			reaction.Name = shortcode + "@" + host
+		}
+
+		reaction.Emoji = emoji
+		return reaction, nil
+	}
+
+	err = gtserror.Newf("no emoji tag for custom reaction %s of %s", content, uri)
+	return nil, gtserror.SetMalformed(err)
+}
+
 // ASBlockToBlock converts a remote activity streams 'block' representation into a gts model block.
 func (c *Converter) ASBlockToBlock(ctx context.Context, blockable ap.Blockable) (*gtsmodel.Block, error) {
 	uriObj := ap.GetJSONLDId(blockable)
@@ -899,3 +1057,53 @@ func (c *Converter) getASObjectStatus(ctx context.Context, id string, with ap.Wi
 
 	return status, nil
 }
+
+// applyContentLimits applies any admin-configured content limits for
+// the author of the given status (and the author's domain) to status,
+// forcing it to be marked as sensitive, and/or prepending content warnings.
+func (c *Converter) applyContentLimits(ctx context.Context, status *gtsmodel.Status) error {
+	var (
+		sensitive bool
+		cws       []string
+	)
+
+	// Check for a limit on author's domain.
+	limit, err := c.state.DB.MatchDomainLimit(ctx, status.Account.Domain)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error checking domain limit for %s: %w", status.Account.Domain, err)
+	}
+
+	if limit != nil {
+		sensitive = *limit.Sensitive
+		if limit.ContentWarning != "" {
+			cws = append(cws, html.EscapeString(limit.ContentWarning))
+		}
+	}
+
+	// Check for a limit on the author themself.
+	if status.Account.IsSensitized() {
+		sensitive = true
+		if cw := status.Account.SensitizedContentWarning; cw != "" {
+			cws = append(cws, html.EscapeString(cw))
+		}
+	}
+
+	if len(cws) != 0 {
+		// Prepend admin content warnings to
+		// any content warning set by author.
+		if status.ContentWarning != "" {
+			cws = append(cws, status.ContentWarning)
+		}
+		status.ContentWarning = strings.Join(cws, "; ")
+
+		// Anything behind a content
+		// warning is sensitive too.
+		sensitive = true
+	}
+
+	if sensitive {
+		status.Sensitive = util.Ptr(true)
+	}
+
+	return nil
+}
@@ -23,12 +23,14 @@
 	"encoding/json"
 	"io"
 	"testing"
+	"time"
 
 	"code.superseriousbusiness.org/activity/streams"
 	"code.superseriousbusiness.org/activity/streams/vocab"
 	"code.superseriousbusiness.org/gotosocial/internal/ap"
 	"code.superseriousbusiness.org/gotosocial/internal/cache"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -188,6 +190,50 @@ func (suite *ASToInternalTestSuite) TestParseReplyWithMention() {
 	suite.Equal(gtsmodel.VisibilityUnlocked, status.Visibility)
 }
 
+func (suite *ASToInternalTestSuite) TestParseStatusContentLimits() {
+	ctx := suite.T().Context()
+
+	// Limit the domain of the posting account.
+	if err := suite.db.PutDomainLimit(ctx, &gtsmodel.DomainLimit{
+		ID:                 "01K7PBBNDMJ4Y5Q0ZB9K6P0C8R",
+		Domain:             "fossbros-anonymous.io",
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		ContentWarning:     "unmarked <nsfw>",
+		Sensitive:          util.Ptr(false),
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Sensitize the posting account as well.
+	postingAccount := new(gtsmodel.Account)
+	*postingAccount = *suite.testAccounts["remote_account_1"]
+	postingAccount.SensitizedAt = time.Now()
+	postingAccount.SensitizedContentWarning = "satanic"
+	if err := suite.db.UpdateAccount(ctx, postingAccount,
+		"sensitized_at",
+		"sensitized_content_warning",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	t := suite.jsonToType(statusWithMentionsActivityJson)
+	create, ok := t.(vocab.ActivityStreamsCreate)
+	if !ok {
+		suite.FailNow("type not coercible")
+	}
+
+	statusable := create.GetActivityStreamsObject().Begin().GetActivityStreamsNote()
+	status, err := suite.typeconverter.ASStatusToStatus(ctx, statusable)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Both content warnings should be prepended,
+	// and status should have been made sensitive.
+	suite.Equal("unmarked &lt;nsfw&gt;; satanic", status.ContentWarning)
+	suite.True(*status.Sensitive)
+}
+
 func (suite *ASToInternalTestSuite) TestParseOwncastService() {
 	t := suite.jsonToType(owncastService)
 	rep, ok := t.(ap.Accountable)
@@ -22,6 +22,7 @@
 	"context"
 	"slices"
 	"strconv"
+	"strings"
 
 	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
 	"code.superseriousbusiness.org/gotosocial/internal/config"
@@ -646,3 +647,165 @@ func (c *Converter) CSVToMutes(
 
 	return mutes, nil
 }
+
+// CSVToBookmarks converts a slice of CSV records
+// to a slice of barebones *gtsmodel.StatusBookmark's,
+// ready for further processing.
+//
+// Only Status.URI will be set on each StatusBookmark.
+func (c *Converter) CSVToBookmarks(
+	ctx context.Context,
+	records [][]string,
+) ([]*gtsmodel.StatusBookmark, error) {
+	bookmarks := make([]*gtsmodel.StatusBookmark, 0, len(records))
+
+	for _, record := range records {
+		if len(record) != 1 {
+			// Badly formatted,
+			// skip this one.
+			continue
+		}
+
+		uri := record[0]
+		if uri == "" {
+			// Badly formatted,
+			// skip this one.
+			continue
+		}
+
+		// Looks good, whack it in the slice.
+		bookmarks = append(bookmarks, &gtsmodel.StatusBookmark{
+			Status: &gtsmodel.Status{
+				URI: uri,
+			},
+		})
+	}
+
+	return bookmarks, nil
+}
+
+// CSVToLists converts a slice of CSV records
+// to a slice of barebones *gtsmodel.ListEntry's,
+// ready for further processing.
+//
+// Only List.Title, Follow.TargetAccount.Username
+// and Follow.TargetAccount.Domain will be set on
+// each ListEntry. Entries of the same list share
+// the same barebones *gtsmodel.List.
+func (c *Converter) CSVToLists(
+	ctx context.Context,
+	records [][]string,
+) ([]*gtsmodel.ListEntry, error) {
+	// We need to know our own domain for this.
+	// Try account domain, fall back to host.
+	var (
+		thisHost          = config.GetHost()
+		thisAccountDomain = config.GetAccountDomain()
+		lists             = make(map[string]*gtsmodel.List)
+		entries           = make([]*gtsmodel.ListEntry, 0, len(records))
+	)
+
+	for _, record := range records {
+		if len(record) != 2 {
+			// Badly formatted,
+			// skip this one.
+			continue
+		}
+
+		// "List title"
+		title := record[0]
+		if title == "" {
+			// Badly formatted,
+			// skip this one.
+			continue
+		}
+
+		// "Account address"
+		namestring := record[1]
+		if namestring == "" {
+			// Badly formatted,
+			// skip this one.
+			continue
+		}
+
+		// Prepend with "@"
+		// if not included.
+		if namestring[0] != '@' {
+			namestring = "@" + namestring
+		}
+
+		username, domain, err := util.ExtractNamestringParts(namestring)
+		if err != nil {
+			// Badly formatted,
+			// skip this one.
+			continue
+		}
+
+		if domain == thisHost || domain == thisAccountDomain {
+			// Clear the domain,
+			// since it's ours.
+			domain = ""
+		}
+
+		// Reuse list if
+		// we've seen it.
+		list, ok := lists[title]
+		if !ok {
+			list = &gtsmodel.List{Title: title}
+			lists[title] = list
+		}
+
+		// Looks good, whack it in the slice.
+		entries = append(entries, &gtsmodel.ListEntry{
+			List: list,
+			Follow: &gtsmodel.Follow{
+				TargetAccount: &gtsmodel.Account{
+					Username: username,
+					Domain:   domain,
+				},
+			},
+		})
+	}
+
+	return entries, nil
+}
+
+// CSVToDomainBlocks converts a slice of CSV records
+// to a slice of barebones *gtsmodel.UserDomainBlock's,
+// ready for further processing.
+//
+// Only Domain will be set on each UserDomainBlock.
+func (c *Converter) CSVToDomainBlocks(
+	ctx context.Context,
+	records [][]string,
+) ([]*gtsmodel.UserDomainBlock, error) {
+	blocks := make([]*gtsmodel.UserDomainBlock, 0, len(records))
+
+	for _, record := range records {
+		if len(record) != 1 {
+			// Badly formatted,
+			// skip this one.
+			continue
+		}
+
+		domain := strings.TrimSpace(record[0])
+		if domain == "" {
+			// Badly formatted,
+			// skip this one.
+			continue
+		}
+
+		if domain == "#domain" {
+			// CSV header row,
+			// skip this one.
+			continue
+		}
+
+		// Looks good, whack it in the slice.
+		blocks = append(blocks, &gtsmodel.UserDomainBlock{
+			Domain: domain,
+		})
+	}
+
+	return blocks, nil
+}
@@ -178,11 +178,24 @@ func APIInteractionPolicyToInteractionPolicy(
 		return nil, err
 	}
 
+	canQuoteAlways, err := convertURIs(p.CanQuote.Always)
+	if err != nil {
+		err := fmt.Errorf("error converting %s.can_quote.always: %w", v, err)
+		return nil, err
+	}
+
+	canQuoteWithApproval, err := convertURIs(p.CanQuote.WithApproval)
+	if err != nil {
+		err := fmt.Errorf("error converting %s.can_quote.with_approval: %w", v, err)
+		return nil, err
+	}
+
 	// Normalize URIs.
 	//
 	// 1. Ensure canLikeAlways, canReplyAlways,
-	//    and canAnnounceAlways include self
-	//    (either explicitly or within public).
+	//    canAnnounceAlways, and canQuoteAlways
+	//    include self (either explicitly or
+	//    within public).
 
 	// ensureIncludesSelf adds the "author" PolicyValue
 	// to given slice of PolicyValues, if not already
@@ -212,6 +225,7 @@ func(uri gtsmodel.PolicyValue) bool {
 	canLikeAlways = ensureIncludesSelf(canLikeAlways)
 	canReplyAlways = ensureIncludesSelf(canReplyAlways)
 	canAnnounceAlways = ensureIncludesSelf(canAnnounceAlways)
+	canQuoteAlways = ensureIncludesSelf(canQuoteAlways)
 
 	// 2. Ensure canReplyAlways includes mentioned
 	//    accounts (either explicitly or within public).
@@ -228,6 +242,18 @@ func(uri gtsmodel.PolicyValue) bool {
 		)
 	}
 
+	// 3. If can_quote wasn't provided at all,
+	//    (eg., by older clients that don't know
+	//    about quotes), use the default for vis.
+	canQuote := &gtsmodel.PolicyRules{
+		AutomaticApproval: canQuoteAlways,
+		ManualApproval:    canQuoteWithApproval,
+	}
+	if len(p.CanQuote.Always) == 0 &&
+		len(p.CanQuote.WithApproval) == 0 {
+		canQuote = gtsmodel.DefaultCanQuoteFor(visibility)
+	}
+
 	return &gtsmodel.InteractionPolicy{
 		CanLike: &gtsmodel.PolicyRules{
 			AutomaticApproval: canLikeAlways,
@@ -241,6 +267,7 @@ func(uri gtsmodel.PolicyValue) bool {
 			AutomaticApproval: canAnnounceAlways,
 			ManualApproval:    canAnnounceWithApproval,
 		},
+		CanQuote: canQuote,
 	}, nil
 }
 
@@ -155,6 +155,24 @@ func StatusToImpoliteInteractionRequest(status *gtsmodel.Status) *gtsmodel.Inter
 	}
 }
 
+func StatusToImpoliteQuoteRequest(status *gtsmodel.Status) *gtsmodel.InteractionRequest {
+	reqID := id.NewULIDFromTime(status.CreatedAt)
+	return &gtsmodel.InteractionRequest{
+		ID:                    reqID,
+		TargetStatusID:        status.QuoteOfID,
+		TargetStatus:          status.QuoteOf,
+		TargetAccountID:       status.QuoteOfAccountID,
+		TargetAccount:         status.QuoteOfAccount,
+		InteractingAccountID:  status.AccountID,
+		InteractingAccount:    status.Account,
+		InteractionRequestURI: gtsmodel.ForwardCompatibleInteractionRequestURI(status.URI, gtsmodel.QuoteRequestSuffix),
+		InteractionURI:        status.URI,
+		InteractionType:       gtsmodel.InteractionQuote,
+		Polite:                util.Ptr(false),
+		Quote:                 status,
+	}
+}
+
 func StatusFaveToImpoliteInteractionRequest(fave *gtsmodel.StatusFave) *gtsmodel.InteractionRequest {
 	reqID := id.NewULIDFromTime(fave.CreatedAt)
 	return &gtsmodel.InteractionRequest{
@@ -38,6 +38,7 @@
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
 	"code.superseriousbusiness.org/gotosocial/internal/log"
 	"code.superseriousbusiness.org/gotosocial/internal/uris"
+	"code.superseriousbusiness.org/gotosocial/internal/util"
 	"code.superseriousbusiness.org/gotosocial/internal/util/xslices"
 )
 
@@ -150,7 +151,14 @@ func (c *Converter) AccountToAS(
 	accountable.SetTootFeatured(featuredProp)
 
 	// featuredTags
-	// NOT IMPLEMENTED
+	// Featured hashtags.
+	if a.FeaturedTagsURI != "" {
+		featuredTagsURI, err := url.Parse(a.FeaturedTagsURI)
+		if err != nil {
+			return nil, err
+		}
+		ap.SetFeaturedTags(accountable, featuredTagsURI)
+	}
 
 	// preferredUsername
 	// Used for Webfinger lookup. Must be unique on the domain, and must correspond to a Webfinger acct: URI.
@@ -198,6 +206,12 @@ func (c *Converter) AccountToAS(
 	discoverableProp.Set(*a.Discoverable)
 	accountable.SetTootDiscoverable(discoverableProp)
 
+	// indexable
+	// Public statuses may be included in search results.
+	indexableProp := streams.NewTootIndexableProperty()
+	indexableProp.Set(util.PtrOrZero(a.Indexable))
+	accountable.SetTootIndexable(indexableProp)
+
 	// devices
 	// NOT IMPLEMENTED, probably won't implement
 
@@ -268,6 +282,12 @@ func (c *Converter) AccountToAS(
 	// set the public key property on the Person
 	accountable.SetW3IDSecurityV1PublicKey(publicKeyProp)
 
+	// assertionMethod
+	// Ed25519 key for RFC 9421 signatures (FEP-521a).
+	if err := setEd25519AssertionMethod(accountable, a, profileIDURI); err != nil {
+		return nil, err
+	}
+
 	// tags
 	tagProp := streams.NewActivityStreamsTagProperty()
 
@@ -479,9 +499,31 @@ func (c *Converter) AccountToASMinimal(
 	// set the public key property on the Person
 	accountable.SetW3IDSecurityV1PublicKey(publicKeyProp)
 
+	// assertionMethod
+	// Ed25519 key for RFC 9421 signatures (FEP-521a).
+	if err := setEd25519AssertionMethod(accountable, a, profileIDURI); err != nil {
+		return nil, err
+	}
+
 	return accountable, nil
 }
 
+// setEd25519AssertionMethod sets the account's Ed25519
+// public key, if any, as an assertionMethod Multikey.
+func setEd25519AssertionMethod(accountable ap.Accountable, a *gtsmodel.Account, profileIDURI *url.URL) error {
+	if a.Ed25519PublicKey == nil {
+		return nil
+	}
+
+	keyURI, err := url.Parse(a.Ed25519PublicKeyURI)
+	if err != nil {
+		return err
+	}
+
+	ap.SetEd25519AssertionMethod(accountable, a.Ed25519PublicKey, keyURI, profileIDURI)
+	return nil
+}
+
 // StatusToAS converts a gts model status into an ActivityStreams Statusable implementation, suitable for federation
 func (c *Converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Statusable, error) {
 	// Ensure the status model is fully populated.
@@ -579,6 +621,15 @@ func (c *Converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Stat
 		tagProp.AppendTootHashtag(asHashtag)
 	}
 
+	// `tag`: quote link
+	if s.QuoteOfURI != "" {
+		asQuoteLink, err := c.quoteLinkToAS(s.QuoteOfURI)
+		if err != nil {
+			return nil, gtserror.Newf("error converting quote to AS link: %w", err)
+		}
+		tagProp.AppendActivityStreamsLink(asQuoteLink)
+	}
+
 	// Append built `tag` property.
 	statusable.SetActivityStreamsTag(tagProp)
 
@@ -633,6 +684,18 @@ func (c *Converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Stat
 			}
 			ap.AppendCc(statusable, iri)
 		}
+
+		// As does the author of a quoted
+		// status, so they can see the quote.
+		if s.QuoteOfAccount != nil &&
+			s.QuoteOfAccountID != s.AccountID &&
+			!s.MentionsAccount(s.QuoteOfAccountID) {
+			iri, err := url.Parse(s.QuoteOfAccount.URI)
+			if err != nil {
+				return nil, gtserror.Newf("error parsing uri %s: %w", s.QuoteOfAccount.URI, err)
+			}
+			ap.AppendCc(statusable, iri)
+		}
 	}
 
 	// `content` and `contentMap` properties.
@@ -698,9 +761,50 @@ func (c *Converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Stat
 		}
 	}
 
+	// `quoteAuthorization` property.
+	if s.QuoteApprovedByURI != "" {
+		authURI, err := url.Parse(s.QuoteApprovedByURI)
+		if err != nil {
+			return nil, gtserror.Newf("error parsing uri %s: %w", s.QuoteApprovedByURI, err)
+		}
+
+		if wup, ok := statusable.(ap.WithUnknownProperties); ok {
+			ap.SetQuoteAuthorization(wup, authURI)
+		}
+	}
+
 	return statusable, nil
 }
 
+// quoteLinkToAS returns an FEP-e232 object
+// Link pointing to the given quoted status URI.
+func (c *Converter) quoteLinkToAS(quoteURI string) (vocab.ActivityStreamsLink, error) {
+	href, err := url.Parse(quoteURI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing quote uri %s: %w", quoteURI, err)
+	}
+
+	link := streams.NewActivityStreamsLink()
+
+	hrefProp := streams.NewActivityStreamsHrefProperty()
+	hrefProp.SetIRI(href)
+	link.SetActivityStreamsHref(hrefProp)
+
+	mediaTypeProp := streams.NewActivityStreamsMediaTypeProperty()
+	mediaTypeProp.Set(ap.LinkMediaTypeActivityStreams)
+	link.SetActivityStreamsMediaType(mediaTypeProp)
+
+	relProp := streams.NewActivityStreamsRelProperty()
+	relProp.AppendRFCRfc5988(ap.LinkRelMisskeyQuote)
+	link.SetActivityStreamsRel(relProp)
+
+	nameProp := streams.NewActivityStreamsNameProperty()
+	nameProp.AppendXMLSchemaString("RE: " + quoteURI)
+	link.SetActivityStreamsName(nameProp)
+
+	return link, nil
+}
+
 func (c *Converter) addPollToAS(poll *gtsmodel.Poll, dst ap.Pollable) error {
 	var optionsProp interface {
 		// the minimum interface for appending AS Notes
@@ -955,6 +1059,77 @@ func (c *Converter) FollowToAS(ctx context.Context, f *gtsmodel.Follow) (vocab.A
 	return follow, nil
 }
 
+// RelayToASFollow converts a gts model relay into the
+// activity streams Follow sent by our instance actor to
+// subscribe to the relay. As is convention for relays,
+// the Follow targets the special Public collection.
+func (c *Converter) RelayToASFollow(ctx context.Context, r *gtsmodel.Relay) (vocab.ActivityStreamsFollow, error) {
+	instanceAcct, err := c.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		return nil, gtserror.Newf("db error getting instance account: %w", err)
+	}
+
+	instanceAcctURI, err := url.Parse(instanceAcct.URI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing instance account uri: %w", err)
+	}
+
+	followURI, err := url.Parse(r.FollowURI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing follow uri: %w", err)
+	}
+
+	follow := streams.NewActivityStreamsFollow()
+
+	// Set the id.
+	followIDProp := streams.NewJSONLDIdProperty()
+	followIDProp.SetIRI(followURI)
+	follow.SetJSONLDId(followIDProp)
+
+	// Set the instance account as actor.
+	followActorProp := streams.NewActivityStreamsActorProperty()
+	followActorProp.AppendIRI(instanceAcctURI)
+	follow.SetActivityStreamsActor(followActorProp)
+
+	// Set the Public collection as object.
+	followObjectProp := streams.NewActivityStreamsObjectProperty()
+	followObjectProp.AppendIRI(ap.PublicIRI())
+	follow.SetActivityStreamsObject(followObjectProp)
+
+	return follow, nil
+}
+
+// RelayToASUndo converts a gts model relay into an activity streams
+// Undo of the Follow sent by our instance actor to the relay.
+func (c *Converter) RelayToASUndo(ctx context.Context, r *gtsmodel.Relay) (vocab.ActivityStreamsUndo, error) {
+	follow, err := c.RelayToASFollow(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	undoURI, err := url.Parse(r.FollowURI + "/undo")
+	if err != nil {
+		return nil, gtserror.Newf("error parsing undo uri: %w", err)
+	}
+
+	undo := streams.NewActivityStreamsUndo()
+
+	// Set the id.
+	undoIDProp := streams.NewJSONLDIdProperty()
+	undoIDProp.SetIRI(undoURI)
+	undo.SetJSONLDId(undoIDProp)
+
+	// Set the actor, same as the actor of the Follow.
+	undo.SetActivityStreamsActor(follow.GetActivityStreamsActor())
+
+	// Set the whole Follow as object.
+	undoObjectProp := streams.NewActivityStreamsObjectProperty()
+	undoObjectProp.AppendActivityStreamsFollow(follow)
+	undo.SetActivityStreamsObject(undoObjectProp)
+
+	return undo, nil
+}
+
 // MentionToAS converts a gts model mention into an activity streams Mention, suitable for federation
 func (c *Converter) MentionToAS(ctx context.Context, m *gtsmodel.Mention) (vocab.ActivityStreamsMention, error) {
 	if m.TargetAccount == nil {
@@ -1230,6 +1405,65 @@ func (c *Converter) FaveToAS(ctx context.Context, f *gtsmodel.StatusFave) (vocab
 	return like, nil
 }
 
+// StatusReactionToASEmojiReact converts a gts model status
+// reaction into a litepub EmojiReact, suitable for federation.
+func (c *Converter) StatusReactionToASEmojiReact(ctx context.Context, r *gtsmodel.StatusReaction) (vocab.LitePubEmojiReact, error) {
+	// Ensure the status reaction model is fully populated.
+	if err := c.state.DB.PopulateStatusReaction(ctx, r); err != nil {
+		return nil, gtserror.Newf("error populating status reaction: %w", err)
+	}
+
+	// Start building the EmojiReact.
+	react := streams.NewLitePubEmojiReact()
+
+	// `id` property.
+	if err := ap.SetJSONLDIdStr(react, r.URI); err != nil {
+		return nil, gtserror.Newf("error setting id: %w", err)
+	}
+
+	// `actor` property is the reacting account URI.
+	actorIRI, err := url.Parse(r.Account.URI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing actor uri: %w", err)
+	}
+	ap.AppendActorIRIs(react, actorIRI)
+
+	// `object` property is the target status URI.
+	targetStatusIRI, err := url.Parse(r.Status.URI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing status uri: %w", err)
+	}
+	ap.AppendObjectIRIs(react, targetStatusIRI)
+
+	// `to` is the owner of the target status.
+	toIRI, err := url.Parse(r.TargetAccount.URI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing account uri: %w", err)
+	}
+	ap.AppendTo(react, toIRI)
+
+	if r.Emoji == nil {
+		// `content` is just the unicode emoji.
+		ap.AppendContent(react, r.Name)
+		return react, nil
+	}
+
+	// `content` is the custom emoji shortcode,
+	// with the emoji itself included as a tag.
+	ap.AppendContent(react, ":"+r.Emoji.Shortcode+":")
+
+	asEmoji, err := c.EmojiToAS(ctx, r.Emoji)
+	if err != nil {
+		return nil, gtserror.Newf("error converting emoji to AS emoji: %w", err)
+	}
+
+	tagProp := streams.NewActivityStreamsTagProperty()
+	tagProp.AppendTootEmoji(asEmoji)
+	react.SetActivityStreamsTag(tagProp)
+
+	return react, nil
+}
+
 // BoostToAS converts a *gtsmodel.Status boost wrapper into
 // an ActivityStreams Announce activity, suitable for federation.
 //
@@ -1650,6 +1884,43 @@ func (c *Converter) StatusesToASFeaturedCollection(ctx context.Context, featured
 	return collection, nil
 }
 
+// FeaturedTagsToASCollection converts a slice of featured tags into an ordered collection
+// of hashtags, suitable for serializing and serving via the activitypub API.
+func (c *Converter) FeaturedTagsToASCollection(ctx context.Context, featuredTagsCollectionID string, featuredTags []*gtsmodel.FeaturedTag) (vocab.ActivityStreamsOrderedCollection, error) {
+	collection := streams.NewActivityStreamsOrderedCollection()
+
+	collectionIDProp := streams.NewJSONLDIdProperty()
+	featuredTagsCollectionIDURI, err := url.Parse(featuredTagsCollectionID)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing url %s", featuredTagsCollectionID)
+	}
+	collectionIDProp.SetIRI(featuredTagsCollectionIDURI)
+	collection.SetJSONLDId(collectionIDProp)
+
+	itemsProp := streams.NewActivityStreamsOrderedItemsProperty()
+	for _, f := range featuredTags {
+		if f.Tag == nil {
+			f.Tag, err = c.state.DB.GetTag(ctx, f.TagID)
+			if err != nil {
+				return nil, gtserror.Newf("error getting tag %s: %w", f.TagID, err)
+			}
+		}
+
+		tag, err := c.TagToAS(ctx, f.Tag)
+		if err != nil {
+			return nil, gtserror.Newf("error converting tag %s: %w", f.Tag.Name, err)
+		}
+		itemsProp.AppendTootHashtag(tag)
+	}
+	collection.SetActivityStreamsOrderedItems(itemsProp)
+
+	totalItemsProp := streams.NewActivityStreamsTotalItemsProperty()
+	totalItemsProp.Set(len(featuredTags))
+	collection.SetActivityStreamsTotalItems(totalItemsProp)
+
+	return collection, nil
+}
+
 // ReportToASFlag converts a gts model report into an activitystreams FLAG, suitable for federation.
 func (c *Converter) ReportToASFlag(ctx context.Context, r *gtsmodel.Report) (vocab.ActivityStreamsFlag, error) {
 	flag := streams.NewActivityStreamsFlag()
@@ -2064,6 +2335,52 @@ func (c *Converter) InteractionPolicyToASInteractionPolicy(
 	canAnnounceProp.AppendGoToSocialCanAnnounce(canAnnounce)
 	policy.SetGoToSocialCanAnnounce(canAnnounceProp)
 
+	/*
+		CAN QUOTE
+	*/
+
+	// Statuses created before canQuote
+	// was introduced won't have it set,
+	// so fall back to the default.
+	canQuoteRules := interactionPolicy.CanQuote
+	if canQuoteRules == nil {
+		canQuoteRules = gtsmodel.DefaultCanQuoteFor(status.Visibility)
+	}
+
+	// Build canQuote
+	canQuote := streams.NewGoToSocialCanQuote()
+
+	// Build canQuote.automaticApproval
+	canQuoteAutomaticApprovalProp := streams.NewGoToSocialAutomaticApprovalProperty()
+	if err := populateValuesForProp(
+		canQuoteAutomaticApprovalProp,
+		status,
+		canQuoteRules.AutomaticApproval,
+	); err != nil {
+		return nil, gtserror.Newf("error setting canQuote.automaticApproval: %w", err)
+	}
+
+	// Set canQuote.automaticApproval
+	canQuote.SetGoToSocialAutomaticApproval(canQuoteAutomaticApprovalProp)
+
+	// Build canQuote.manualApproval
+	canQuoteManualApprovalProp := streams.NewGoToSocialManualApprovalProperty()
+	if err := populateValuesForProp(
+		canQuoteManualApprovalProp,
+		status,
+		canQuoteRules.ManualApproval,
+	); err != nil {
+		return nil, gtserror.Newf("error setting canQuote.manualApproval: %w", err)
+	}
+
+	// Set canQuote.manualApproval.
+	canQuote.SetGoToSocialManualApproval(canQuoteManualApprovalProp)
+
+	// Set canQuote on the policy.
+	canQuoteProp := streams.NewGoToSocialCanQuoteProperty()
+	canQuoteProp.AppendGoToSocialCanQuote(canQuote)
+	policy.SetGoToSocialCanQuote(canQuoteProp)
+
 	return policy, nil
 }
 
@@ -2208,6 +2525,10 @@ func (c *Converter) InteractionReqToASAccept(
 	case gtsmodel.InteractionAnnounce:
 		// Accept of announce gets cc'd.
 		cc = true
+
+	case gtsmodel.InteractionQuote:
+		// Accept of quote gets cc'd.
+		cc = true
 	}
 
 	if cc {
@@ -2353,6 +2674,10 @@ func (c *Converter) InteractionReqToASReject(
 	case gtsmodel.InteractionAnnounce:
 		// Reject of announce gets cc'd.
 		cc = true
+
+	case gtsmodel.InteractionQuote:
+		// Reject of quote gets cc'd.
+		cc = true
 	}
 
 	if cc {
@@ -2406,6 +2731,11 @@ func (c *Converter) InteractionReqToASAuthorization(
 		auth = streams.NewGoToSocialReplyAuthorization()
 	case gtsmodel.InteractionAnnounce:
 		auth = streams.NewGoToSocialAnnounceAuthorization()
+	default:
+		// There's no Authorization type for
+		// quotes (yet), they're approved by
+		// the URI of the Accept instead.
+		return nil, gtserror.Newf("no Authorization type for interaction type %s", req.InteractionType)
 	}
 
 	// Set the ID.
@@ -53,18 +53,33 @@ func (suite *InternalToASTestSuite) TestAccountToAS() {
     "https://gotosocial.org/ns",
     "https://w3id.org/security/v1",
     "https://www.w3.org/ns/activitystreams",
+    "https://w3id.org/security/data-integrity/v1",
     {
       "discoverable": "toot:discoverable",
       "featured": {
         "@id": "toot:featured",
         "@type": "@id"
       },
+      "featuredTags": {
+        "@id": "toot:featuredTags",
+        "@type": "@id"
+      },
+      "indexable": "toot:indexable",
       "manuallyApprovesFollowers": "as:manuallyApprovesFollowers",
       "toot": "http://joinmastodon.org/ns#"
     }
   ],
+  "assertionMethod": [
+    {
+      "controller": "http://localhost:8080/users/the_mighty_zork",
+      "id": "http://localhost:8080/users/the_mighty_zork/main-key#ed25519-key",
+      "publicKeyMultibase": "z6Mku8JuRFRNwnAe7TszvAQq8AqmXJB1LBve5wVaJENz18eb",
+      "type": "Multikey"
+    }
+  ],
   "discoverable": true,
   "featured": "http://localhost:8080/users/the_mighty_zork/collections/featured",
+  "featuredTags": "http://localhost:8080/users/the_mighty_zork/collections/tags",
   "followers": "http://localhost:8080/users/the_mighty_zork/followers",
   "following": "http://localhost:8080/users/the_mighty_zork/following",
   "hidesCcPublicFromUnauthedWeb": false,
@@ -83,6 +98,7 @@ func (suite *InternalToASTestSuite) TestAccountToAS() {
     "url": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/original/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg"
   },
   "inbox": "http://localhost:8080/users/the_mighty_zork/inbox",
+  "indexable": false,
   "manuallyApprovesFollowers": false,
   "name": "original zork (he/they)",
   "outbox": "http://localhost:8080/users/the_mighty_zork/outbox",
@@ -124,18 +140,33 @@ func (suite *InternalToASTestSuite) TestAccountToASBot() {
     "https://gotosocial.org/ns",
     "https://w3id.org/security/v1",
     "https://www.w3.org/ns/activitystreams",
+    "https://w3id.org/security/data-integrity/v1",
     {
       "discoverable": "toot:discoverable",
       "featured": {
         "@id": "toot:featured",
         "@type": "@id"
       },
+      "featuredTags": {
+        "@id": "toot:featuredTags",
+        "@type": "@id"
+      },
+      "indexable": "toot:indexable",
       "manuallyApprovesFollowers": "as:manuallyApprovesFollowers",
       "toot": "http://joinmastodon.org/ns#"
     }
   ],
+  "assertionMethod": [
+    {
+      "controller": "http://localhost:8080/users/the_mighty_zork",
+      "id": "http://localhost:8080/users/the_mighty_zork/main-key#ed25519-key",
+      "publicKeyMultibase": "z6Mku8JuRFRNwnAe7TszvAQq8AqmXJB1LBve5wVaJENz18eb",
+      "type": "Multikey"
+    }
+  ],
   "discoverable": true,
   "featured": "http://localhost:8080/users/the_mighty_zork/collections/featured",
+  "featuredTags": "http://localhost:8080/users/the_mighty_zork/collections/tags",
   "followers": "http://localhost:8080/users/the_mighty_zork/followers",
   "following": "http://localhost:8080/users/the_mighty_zork/following",
   "hidesCcPublicFromUnauthedWeb": false,
@@ -154,6 +185,7 @@ func (suite *InternalToASTestSuite) TestAccountToASBot() {
     "url": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/original/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg"
   },
   "inbox": "http://localhost:8080/users/the_mighty_zork/inbox",
+  "indexable": false,
   "manuallyApprovesFollowers": false,
   "name": "original zork (he/they)",
   "outbox": "http://localhost:8080/users/the_mighty_zork/outbox",
@@ -189,6 +221,7 @@ func (suite *InternalToASTestSuite) TestAccountToASWithFields() {
     "https://gotosocial.org/ns",
     "https://w3id.org/security/v1",
     "https://www.w3.org/ns/activitystreams",
+    "https://w3id.org/security/data-integrity/v1",
     {
       "PropertyValue": "schema:PropertyValue",
       "discoverable": "toot:discoverable",
@@ -196,12 +229,25 @@ func (suite *InternalToASTestSuite) TestAccountToASWithFields() {
         "@id": "toot:featured",
         "@type": "@id"
       },
+      "featuredTags": {
+        "@id": "toot:featuredTags",
+        "@type": "@id"
+      },
+      "indexable": "toot:indexable",
       "manuallyApprovesFollowers": "as:manuallyApprovesFollowers",
       "schema": "http://schema.org#",
       "toot": "http://joinmastodon.org/ns#",
       "value": "schema:value"
     }
   ],
+  "assertionMethod": [
+    {
+      "controller": "http://localhost:8080/users/1happyturtle",
+      "id": "http://localhost:8080/users/1happyturtle/main-key#ed25519-key",
+      "publicKeyMultibase": "z6MkkdvS3b9FzEuG3PcNsLAkAHz7n9WtKMbGvvXG2daZ9KiL",
+      "type": "Multikey"
+    }
+  ],
   "attachment": [
     {
       "name": "should you follow me?",
@@ -216,12 +262,14 @@ func (suite *InternalToASTestSuite) TestAccountToASWithFields() {
   ],
   "discoverable": false,
   "featured": "http://localhost:8080/users/1happyturtle/collections/featured",
+  "featuredTags": "http://localhost:8080/users/1happyturtle/collections/tags",
   "followers": "http://localhost:8080/users/1happyturtle/followers",
   "following": "http://localhost:8080/users/1happyturtle/following",
   "hidesCcPublicFromUnauthedWeb": true,
   "hidesToPublicFromUnauthedWeb": false,
   "id": "http://localhost:8080/users/1happyturtle",
   "inbox": "http://localhost:8080/users/1happyturtle/inbox",
+  "indexable": false,
   "manuallyApprovesFollowers": true,
   "name": "happy little turtle :3",
   "outbox": "http://localhost:8080/users/1happyturtle/outbox",
@@ -270,12 +318,18 @@ func (suite *InternalToASTestSuite) TestAccountToASAliasedAndMoved() {
     "https://gotosocial.org/ns",
     "https://w3id.org/security/v1",
     "https://www.w3.org/ns/activitystreams",
+    "https://w3id.org/security/data-integrity/v1",
     {
       "discoverable": "toot:discoverable",
       "featured": {
         "@id": "toot:featured",
         "@type": "@id"
       },
+      "featuredTags": {
+        "@id": "toot:featuredTags",
+        "@type": "@id"
+      },
+      "indexable": "toot:indexable",
       "manuallyApprovesFollowers": "as:manuallyApprovesFollowers",
       "movedTo": {
         "@id": "as:movedTo",
@@ -287,8 +341,17 @@ func (suite *InternalToASTestSuite) TestAccountToASAliasedAndMoved() {
   "alsoKnownAs": [
     "http://localhost:8080/users/1happyturtle"
   ],
+  "assertionMethod": [
+    {
+      "controller": "http://localhost:8080/users/the_mighty_zork",
+      "id": "http://localhost:8080/users/the_mighty_zork/main-key#ed25519-key",
+      "publicKeyMultibase": "z6Mku8JuRFRNwnAe7TszvAQq8AqmXJB1LBve5wVaJENz18eb",
+      "type": "Multikey"
+    }
+  ],
   "discoverable": true,
   "featured": "http://localhost:8080/users/the_mighty_zork/collections/featured",
+  "featuredTags": "http://localhost:8080/users/the_mighty_zork/collections/tags",
   "followers": "http://localhost:8080/users/the_mighty_zork/followers",
   "following": "http://localhost:8080/users/the_mighty_zork/following",
   "hidesCcPublicFromUnauthedWeb": false,
@@ -307,6 +370,7 @@ func (suite *InternalToASTestSuite) TestAccountToASAliasedAndMoved() {
     "url": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/original/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg"
   },
   "inbox": "http://localhost:8080/users/the_mighty_zork/inbox",
+  "indexable": false,
   "manuallyApprovesFollowers": false,
   "movedTo": "http://localhost:8080/users/1happyturtle",
   "name": "original zork (he/they)",
@@ -345,6 +409,7 @@ func (suite *InternalToASTestSuite) TestAccountToASWithOneField() {
     "https://gotosocial.org/ns",
     "https://w3id.org/security/v1",
     "https://www.w3.org/ns/activitystreams",
+    "https://w3id.org/security/data-integrity/v1",
     {
       "PropertyValue": "schema:PropertyValue",
       "discoverable": "toot:discoverable",
@@ -352,12 +417,25 @@ func (suite *InternalToASTestSuite) TestAccountToASWithOneField() {
         "@id": "toot:featured",
         "@type": "@id"
       },
+      "featuredTags": {
+        "@id": "toot:featuredTags",
+        "@type": "@id"
+      },
+      "indexable": "toot:indexable",
       "manuallyApprovesFollowers": "as:manuallyApprovesFollowers",
       "schema": "http://schema.org#",
       "toot": "http://joinmastodon.org/ns#",
       "value": "schema:value"
     }
   ],
+  "assertionMethod": [
+    {
+      "controller": "http://localhost:8080/users/1happyturtle",
+      "id": "http://localhost:8080/users/1happyturtle/main-key#ed25519-key",
+      "publicKeyMultibase": "z6MkkdvS3b9FzEuG3PcNsLAkAHz7n9WtKMbGvvXG2daZ9KiL",
+      "type": "Multikey"
+    }
+  ],
   "attachment": [
     {
       "name": "should you follow me?",
@@ -367,12 +445,14 @@ func (suite *InternalToASTestSuite) TestAccountToASWithOneField() {
   ],
   "discoverable": false,
   "featured": "http://localhost:8080/users/1happyturtle/collections/featured",
+  "featuredTags": "http://localhost:8080/users/1happyturtle/collections/tags",
   "followers": "http://localhost:8080/users/1happyturtle/followers",
   "following": "http://localhost:8080/users/1happyturtle/following",
   "hidesCcPublicFromUnauthedWeb": true,
   "hidesToPublicFromUnauthedWeb": false,
   "id": "http://localhost:8080/users/1happyturtle",
   "inbox": "http://localhost:8080/users/1happyturtle/inbox",
+  "indexable": false,
   "manuallyApprovesFollowers": true,
   "name": "happy little turtle :3",
   "outbox": "http://localhost:8080/users/1happyturtle/outbox",
@@ -409,6 +489,7 @@ func (suite *InternalToASTestSuite) TestAccountToASWithEmoji() {
     "https://gotosocial.org/ns",
     "https://w3id.org/security/v1",
     "https://www.w3.org/ns/activitystreams",
+    "https://w3id.org/security/data-integrity/v1",
     {
       "Emoji": "toot:Emoji",
       "discoverable": "toot:discoverable",
@@ -416,12 +497,26 @@ func (suite *InternalToASTestSuite) TestAccountToASWithEmoji() {
         "@id": "toot:featured",
         "@type": "@id"
       },
+      "featuredTags": {
+        "@id": "toot:featuredTags",
+        "@type": "@id"
+      },
+      "indexable": "toot:indexable",
       "manuallyApprovesFollowers": "as:manuallyApprovesFollowers",
       "toot": "http://joinmastodon.org/ns#"
     }
   ],
+  "assertionMethod": [
+    {
+      "controller": "http://localhost:8080/users/the_mighty_zork",
+      "id": "http://localhost:8080/users/the_mighty_zork/main-key#ed25519-key",
+      "publicKeyMultibase": "z6Mku8JuRFRNwnAe7TszvAQq8AqmXJB1LBve5wVaJENz18eb",
+      "type": "Multikey"
+    }
+  ],
   "discoverable": true,
   "featured": "http://localhost:8080/users/the_mighty_zork/collections/featured",
+  "featuredTags": "http://localhost:8080/users/the_mighty_zork/collections/tags",
   "followers": "http://localhost:8080/users/the_mighty_zork/followers",
   "following": "http://localhost:8080/users/the_mighty_zork/following",
   "hidesCcPublicFromUnauthedWeb": false,
@@ -440,6 +535,7 @@ func (suite *InternalToASTestSuite) TestAccountToASWithEmoji() {
     "url": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/original/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg"
   },
   "inbox": "http://localhost:8080/users/the_mighty_zork/inbox",
+  "indexable": false,
   "manuallyApprovesFollowers": false,
   "name": "original zork (he/they)",
   "outbox": "http://localhost:8080/users/the_mighty_zork/outbox",
@@ -487,21 +583,36 @@ func (suite *InternalToASTestSuite) TestAccountToASWithSharedInbox() {
     "https://gotosocial.org/ns",
     "https://w3id.org/security/v1",
     "https://www.w3.org/ns/activitystreams",
+    "https://w3id.org/security/data-integrity/v1",
     {
       "discoverable": "toot:discoverable",
       "featured": {
         "@id": "toot:featured",
         "@type": "@id"
       },
+      "featuredTags": {
+        "@id": "toot:featuredTags",
+        "@type": "@id"
+      },
+      "indexable": "toot:indexable",
       "manuallyApprovesFollowers": "as:manuallyApprovesFollowers",
       "toot": "http://joinmastodon.org/ns#"
     }
   ],
+  "assertionMethod": [
+    {
+      "controller": "http://localhost:8080/users/the_mighty_zork",
+      "id": "http://localhost:8080/users/the_mighty_zork/main-key#ed25519-key",
+      "publicKeyMultibase": "z6Mku8JuRFRNwnAe7TszvAQq8AqmXJB1LBve5wVaJENz18eb",
+      "type": "Multikey"
+    }
+  ],
   "discoverable": true,
   "endpoints": {
     "sharedInbox": "http://localhost:8080/sharedInbox"
   },
   "featured": "http://localhost:8080/users/the_mighty_zork/collections/featured",
+  "featuredTags": "http://localhost:8080/users/the_mighty_zork/collections/tags",
   "followers": "http://localhost:8080/users/the_mighty_zork/followers",
   "following": "http://localhost:8080/users/the_mighty_zork/following",
   "hidesCcPublicFromUnauthedWeb": false,
@@ -520,6 +631,7 @@ func (suite *InternalToASTestSuite) TestAccountToASWithSharedInbox() {
     "url": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/original/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg"
   },
   "inbox": "http://localhost:8080/users/the_mighty_zork/inbox",
+  "indexable": false,
   "manuallyApprovesFollowers": false,
   "name": "original zork (he/they)",
   "outbox": "http://localhost:8080/users/the_mighty_zork/outbox",
@@ -587,6 +699,14 @@ func (suite *InternalToASTestSuite) TestStatusToAS() {
       ],
       "manualApproval": []
     },
+    "canQuote": {
+      "automaticApproval": [
+        "http://localhost:8080/users/the_mighty_zork"
+      ],
+      "manualApproval": [
+        "https://www.w3.org/ns/activitystreams#Public"
+      ]
+    },
     "canReply": {
       "always": [
         "https://www.w3.org/ns/activitystreams#Public"
@@ -689,6 +809,14 @@ func (suite *InternalToASTestSuite) TestStatusWithTagsToASWithIDs() {
       ],
       "manualApproval": []
     },
+    "canQuote": {
+      "automaticApproval": [
+        "http://localhost:8080/users/admin"
+      ],
+      "manualApproval": [
+        "https://www.w3.org/ns/activitystreams#Public"
+      ]
+    },
     "canReply": {
       "always": [
         "https://www.w3.org/ns/activitystreams#Public"
@@ -809,6 +937,14 @@ func (suite *InternalToASTestSuite) TestStatusWithTagsToASFromDB() {
       ],
       "manualApproval": []
     },
+    "canQuote": {
+      "automaticApproval": [
+        "http://localhost:8080/users/admin"
+      ],
+      "manualApproval": [
+        "https://www.w3.org/ns/activitystreams#Public"
+      ]
+    },
     "canReply": {
       "always": [
         "https://www.w3.org/ns/activitystreams#Public"
@@ -914,6 +1050,14 @@ func (suite *InternalToASTestSuite) TestStatusToASWithMentions() {
       ],
       "manualApproval": []
     },
+    "canQuote": {
+      "automaticApproval": [
+        "http://localhost:8080/users/admin"
+      ],
+      "manualApproval": [
+        "https://www.w3.org/ns/activitystreams#Public"
+      ]
+    },
     "canReply": {
       "always": [
         "https://www.w3.org/ns/activitystreams#Public"
@@ -1044,6 +1188,14 @@ func (suite *InternalToASTestSuite) TestStatusToASPoliteApproved() {
       ],
       "manualApproval": []
     },
+    "canQuote": {
+      "automaticApproval": [
+        "http://localhost:8080/users/admin"
+      ],
+      "manualApproval": [
+        "https://www.w3.org/ns/activitystreams#Public"
+      ]
+    },
     "canReply": {
       "always": [
         "https://www.w3.org/ns/activitystreams#Public"
@@ -1171,6 +1323,14 @@ func (suite *InternalToASTestSuite) TestStatusToASPImpoliteApproved() {
       ],
       "manualApproval": []
     },
+    "canQuote": {
+      "automaticApproval": [
+        "http://localhost:8080/users/admin"
+      ],
+      "manualApproval": [
+        "https://www.w3.org/ns/activitystreams#Public"
+      ]
+    },
     "canReply": {
       "always": [
         "https://www.w3.org/ns/activitystreams#Public"
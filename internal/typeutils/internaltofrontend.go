@@ -24,12 +24,15 @@
 	"fmt"
 	"math"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	"code.superseriousbusiness.org/gotosocial/internal/cache"
 	"code.superseriousbusiness.org/gotosocial/internal/config"
 	"code.superseriousbusiness.org/gotosocial/internal/db"
+	"code.superseriousbusiness.org/gotosocial/internal/gtscontext"
 	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
 	"code.superseriousbusiness.org/gotosocial/internal/id"
@@ -151,17 +154,24 @@ func (c *Converter) AccountToAPIAccountSensitive(ctx context.Context, a *gtsmode
 		webVisibility = apimodel.VisibilityPublic
 	}
 
+	emailNotificationTypes := make([]string, 0)
+	for _, notificationType := range a.Settings.EmailNotificationTypes.ToSlice() {
+		emailNotificationTypes = append(emailNotificationTypes, notificationType.String())
+	}
+
 	apiAccount.Source = &apimodel.Source{
-		Privacy:             VisToAPIVis(a.Settings.Privacy),
-		WebVisibility:       webVisibility,
-		WebLayout:           a.Settings.WebLayout.String(),
-		Sensitive:           *a.Settings.Sensitive,
-		Language:            a.Settings.Language,
-		StatusContentType:   statusContentType,
-		Note:                a.NoteRaw,
-		Fields:              c.fieldsToAPIFields(a.FieldsRaw),
-		FollowRequestsCount: *a.Stats.FollowRequestsCount,
-		AlsoKnownAsURIs:     a.AlsoKnownAsURIs,
+		Privacy:                VisToAPIVis(a.Settings.Privacy),
+		WebVisibility:          webVisibility,
+		WebLayout:              a.Settings.WebLayout.String(),
+		EmailNotifications:     a.Settings.EmailNotifications.String(),
+		EmailNotificationTypes: emailNotificationTypes,
+		Sensitive:              *a.Settings.Sensitive,
+		Language:               a.Settings.Language,
+		StatusContentType:      statusContentType,
+		Note:                   a.NoteRaw,
+		Fields:                 c.fieldsToAPIFields(a.FieldsRaw),
+		FollowRequestsCount:    *a.Stats.FollowRequestsCount,
+		AlsoKnownAsURIs:        a.AlsoKnownAsURIs,
 	}
 
 	return apiAccount, nil
@@ -378,6 +388,7 @@ func (c *Converter) accountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 	var (
 		locked       = util.PtrOrValue(a.Locked, true)
 		discoverable = util.PtrOrValue(a.Discoverable, false)
+		indexable    = util.PtrOrValue(a.Indexable, false)
 	)
 
 	// Remaining properties are simple and
@@ -390,6 +401,7 @@ func (c *Converter) accountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 		DisplayName:       a.DisplayName,
 		Locked:            locked,
 		Discoverable:      discoverable,
+		Indexable:         indexable,
 		Bot:               a.ActorType.IsBot(),
 		CreatedAt:         util.FormatISO8601(a.CreatedAt),
 		Note:              a.Note,
@@ -567,6 +579,7 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		disabled               bool
 		role                   = *c.APIAccountDisplayRoleToAPIAccountRoleSensitive(nil)
 		createdByApplicationID string
+		invitedByAccountID     string
 	)
 
 	if err := c.state.DB.PopulateAccount(ctx, a); err != nil {
@@ -613,6 +626,22 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		approved = *user.Approved
 		disabled = *user.Disabled
 		createdByApplicationID = user.CreatedByApplicationID
+
+		if user.InviteID != "" {
+			// User signed up with an invite,
+			// look up who created the invite.
+			invite, err := c.state.DB.GetInviteByID(
+				gtscontext.SetBarebones(ctx),
+				user.InviteID,
+			)
+			if err != nil && !errors.Is(err, db.ErrNoEntries) {
+				return nil, fmt.Errorf("AccountToAdminAPIAccount: error getting invite %s: %w", user.InviteID, err)
+			}
+
+			if invite != nil {
+				invitedByAccountID = invite.AccountID
+			}
+		}
 	}
 
 	apiAccount, err := c.AccountToAPIAccountPublic(ctx, a)
@@ -621,24 +650,26 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 	}
 
 	return &apimodel.AdminAccountInfo{
-		ID:                     a.ID,
-		Username:               a.Username,
-		Domain:                 domain,
-		CreatedAt:              util.FormatISO8601(a.CreatedAt),
-		Email:                  email,
-		IP:                     ip,
-		IPs:                    []interface{}{}, // not implemented,
-		Locale:                 locale,
-		InviteRequest:          inviteRequest,
-		Role:                   role,
-		Confirmed:              confirmed,
-		Approved:               approved,
-		Disabled:               disabled,
-		Silenced:               !a.SilencedAt.IsZero(),
-		Suspended:              !a.SuspendedAt.IsZero(),
-		Account:                apiAccount,
-		CreatedByApplicationID: createdByApplicationID,
-		InvitedByAccountID:     "", // not implemented (yet)
+		ID:                       a.ID,
+		Username:                 a.Username,
+		Domain:                   domain,
+		CreatedAt:                util.FormatISO8601(a.CreatedAt),
+		Email:                    email,
+		IP:                       ip,
+		IPs:                      []interface{}{}, // not implemented,
+		Locale:                   locale,
+		InviteRequest:            inviteRequest,
+		Role:                     role,
+		Confirmed:                confirmed,
+		Approved:                 approved,
+		Disabled:                 disabled,
+		Sensitized:               a.IsSensitized(),
+		SensitizedContentWarning: a.SensitizedContentWarning,
+		Silenced:                 !a.SilencedAt.IsZero(),
+		Suspended:                !a.SuspendedAt.IsZero(),
+		Account:                  apiAccount,
+		CreatedByApplicationID:   createdByApplicationID,
+		InvitedByAccountID:       invitedByAccountID,
 	}, nil
 }
 
@@ -653,7 +684,7 @@ func (c *Converter) AppToAPIAppSensitive(ctx context.Context, a *gtsmodel.Applic
 		return nil, gtserror.Newf("error converting id to time: %w", err)
 	}
 
-	return &apimodel.Application{
+	apiApp := &apimodel.Application{
 		ID:           a.ID,
 		CreatedAt:    util.FormatISO8601(createdAt),
 		Name:         a.Name,
@@ -661,10 +692,19 @@ func (c *Converter) AppToAPIAppSensitive(ctx context.Context, a *gtsmodel.Applic
 		RedirectURI:  strings.Join(a.RedirectURIs, "\n"),
 		RedirectURIs: a.RedirectURIs,
 		ClientID:     a.ClientID,
-		ClientSecret: a.ClientSecret,
 		VapidKey:     vapidKeyPair.Public,
 		Scopes:       strings.Split(a.Scopes, " "),
-	}, nil
+		Public:       a.IsPublic(),
+	}
+
+	// Public clients don't get to see
+	// their secret, as they shouldn't
+	// be using it for anything.
+	if !a.IsPublic() {
+		apiApp.ClientSecret = a.ClientSecret
+	}
+
+	return apiApp, nil
 }
 
 // AppToAPIAppPublic takes a db model application as a param, and returns a populated apitype application, or an error
@@ -835,18 +875,116 @@ func (c *Converter) TagToAPITag(ctx context.Context, t *gtsmodel.Tag, stubHistor
 	return apimodel.Tag{
 		Name: strings.ToLower(t.Name),
 		URL:  uris.URIForTag(t.Name),
-		History: func() *[]any {
+		History: func() *[]apimodel.History {
 			if !stubHistory {
 				return nil
 			}
 
-			h := make([]any, 0)
+			h := make([]apimodel.History, 0)
 			return &h
 		}(),
 		Following: following,
 	}, nil
 }
 
+// FeaturedTagToAPIFeaturedTag converts a gts model featured tag into its api (frontend) representation.
+func (c *Converter) FeaturedTagToAPIFeaturedTag(ctx context.Context, f *gtsmodel.FeaturedTag) (*apimodel.FeaturedTag, error) {
+	if f.Tag == nil {
+		var err error
+		f.Tag, err = c.state.DB.GetTag(ctx, f.TagID)
+		if err != nil {
+			return nil, gtserror.Newf("error getting tag %s: %w", f.TagID, err)
+		}
+	}
+
+	var lastStatusAt *string
+	if !f.LastStatusAt.IsZero() {
+		lastStatusAt = util.Ptr(util.FormatISO8601Date(f.LastStatusAt))
+	}
+
+	return &apimodel.FeaturedTag{
+		ID:            f.ID,
+		Name:          strings.ToLower(f.Tag.Name),
+		URL:           uris.URIForTag(f.Tag.Name),
+		StatusesCount: strconv.Itoa(f.StatusesCount),
+		LastStatusAt:  lastStatusAt,
+	}, nil
+}
+
+// TrendHistoryToAPIHistory converts the daily usage history of a
+// calculated trend into its api (frontend) representation.
+func (c *Converter) TrendHistoryToAPIHistory(history []cache.TrendHistory) []apimodel.History {
+	apiHistory := make([]apimodel.History, len(history))
+	for i, h := range history {
+		apiHistory[i] = apimodel.History{
+			Day:      strconv.FormatInt(h.Day.Unix(), 10),
+			Uses:     strconv.Itoa(h.Uses),
+			Accounts: strconv.Itoa(h.Accounts),
+		}
+	}
+	return apiHistory
+}
+
+// TrendLinkToAPITrendsLink converts a calculated link trend into its
+// api (frontend) representation. If a preview card has been fetched
+// for the link it will be used, else the card describes just the link.
+func (c *Converter) TrendLinkToAPITrendsLink(ctx context.Context, trend *cache.CachedTrend) *apimodel.TrendsLink {
+	apiLink := &apimodel.TrendsLink{
+		Card: apimodel.Card{
+			URL:   trend.TargetID,
+			Title: trend.TargetID,
+			Type:  gtsmodel.CardTypeLink.String(),
+		},
+		History: c.TrendHistoryToAPIHistory(trend.History),
+	}
+
+	card, err := c.state.DB.GetCardByURL(ctx, trend.TargetID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		log.Errorf(ctx, "error getting card for link %s: %v", trend.TargetID, err)
+	}
+
+	if card != nil {
+		apiLink.Card = *c.CardToAPICard(card)
+	}
+
+	return apiLink
+}
+
+// CardToAPICard converts a gts model link preview
+// card into its api (frontend) representation.
+func (c *Converter) CardToAPICard(card *gtsmodel.Card) *apimodel.Card {
+	apiCard := &apimodel.Card{
+		URL:          card.URL,
+		Title:        card.Title,
+		Description:  card.Description,
+		Type:         card.Type.String(),
+		AuthorName:   card.AuthorName,
+		AuthorURL:    card.AuthorURL,
+		ProviderName: card.ProviderName,
+		ProviderURL:  card.ProviderURL,
+		EmbedURL:     card.EmbedURL,
+		Width:        card.Width,
+		Height:       card.Height,
+	}
+
+	// Only show the thumbnail if it's
+	// actually cached on this instance.
+	if image := card.Image; image != nil &&
+		*image.Cached {
+		apiCard.Image = image.URL
+		apiCard.Blurhash = image.Blurhash
+
+		// Fall back to thumbnail
+		// dimensions if necessary.
+		if apiCard.Width == 0 {
+			apiCard.Width = image.FileMeta.Original.Width
+			apiCard.Height = image.FileMeta.Original.Height
+		}
+	}
+
+	return apiCard
+}
+
 // StatusToAPIStatus converts a gts model
 // status into its api (frontend) representation
 // for serialization on the API.
@@ -1155,11 +1293,99 @@ func (c *Converter) statusToFrontend(
 		apiStatus.Reblogged = apiStatus.Reblog.Reblogged
 		apiStatus.Pinned = apiStatus.Reblog.Pinned
 		apiStatus.Filtered = apiStatus.Reblog.Filtered
+
+		// Set quote info of the boosted status.
+		apiStatus.Reblog.Quote, err = c.statusQuoteToFrontend(ctx,
+			status.BoostOf,
+			requestingAccount,
+		)
+		if err != nil {
+			return nil, gtserror.Newf("error converting boosted status quote: %w", err)
+		}
+	}
+
+	// Set quote info of the status.
+	apiStatus.Quote, err = c.statusQuoteToFrontend(ctx,
+		status,
+		requestingAccount,
+	)
+	if err != nil {
+		return nil, gtserror.Newf("error converting status quote: %w", err)
 	}
 
 	return apiStatus, nil
 }
 
+// statusQuoteToFrontend converts information about the
+// status quoted by the given status into its frontend
+// representation, returning nil if the status is not
+// a quote. Quotes nested within the quoted status are
+// not rendered, to avoid deep (or infinite) recursion.
+//
+// Requesting account can be nil.
+func (c *Converter) statusQuoteToFrontend(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	requester *gtsmodel.Account,
+) (*apimodel.StatusQuote, error) {
+	if status.QuoteOfURI == "" {
+		// Not a quote.
+		return nil, nil
+	}
+
+	apiQuote := &apimodel.StatusQuote{
+		QuotedStatusID: util.PtrIf(status.QuoteOfID),
+	}
+
+	if util.PtrOrZero(status.QuotePendingApproval) {
+		// Not yet approved by
+		// quoted status author.
+		apiQuote.State = "pending"
+		return apiQuote, nil
+	}
+
+	if status.QuoteOf == nil {
+		// Quoted status is gone
+		// or was never dereferenced.
+		apiQuote.State = "deleted"
+		return apiQuote, nil
+	}
+
+	visible, err := c.visFilter.StatusVisible(ctx,
+		requester,
+		status.QuoteOf,
+	)
+	if err != nil {
+		return nil, gtserror.Newf("error checking quoted status visibility: %w", err)
+	}
+
+	if !visible {
+		// Requester can't
+		// see quoted status.
+		apiQuote.State = "unauthorized"
+		return apiQuote, nil
+	}
+
+	quotedStatus, err := c.baseStatusToFrontend(ctx,
+		status.QuoteOf,
+		requester,
+	)
+	if err != nil {
+		return nil, gtserror.Newf("error converting quoted status: %w", err)
+	}
+
+	quotedStatus.Account, err = c.AccountToAPIAccountPublic(ctx,
+		status.QuoteOf.Account,
+	)
+	if err != nil {
+		return nil, gtserror.Newf("error converting quoted status acct: %w", err)
+	}
+
+	apiQuote.State = "accepted"
+	apiQuote.QuotedStatus = quotedStatus
+	return apiQuote, nil
+}
+
 // baseStatusToFrontend performs the main logic
 // of statusToFrontend() without handling of boost
 // logic, to prevent *possible* recursion issues.
@@ -1259,7 +1485,7 @@ func (c *Converter) baseStatusToFrontend(
 		Mentions:           apiMentions,
 		Tags:               apiTags,
 		Emojis:             apiEmojis,
-		Card:               nil, // TODO: implement cards
+		Card:               nil, // Set below.
 		Text:               status.Text,
 		ContentType:        ContentTypeToAPIContentType(status.ContentType),
 		InteractionPolicy:  *apiInteractionPolicy,
@@ -1315,6 +1541,10 @@ func (c *Converter) baseStatusToFrontend(
 		}
 	}
 
+	if status.Card != nil {
+		apiStatus.Card = c.CardToAPICard(status.Card)
+	}
+
 	// Status interactions.
 	//
 	if status.BoostOf != nil { //nolint
@@ -1564,6 +1794,11 @@ func (c *Converter) InstanceToAPIV1Instance(ctx context.Context, i *gtsmodel.Ins
 		domain = accDomain
 	}
 
+	// Like Mastodon, only advertise invites as
+	// enabled if any user may generate them.
+	invitesEnabled := config.GetAccountsInvitesEnabled() &&
+		!config.GetAccountsInvitesAdminOnly()
+
 	instance := &apimodel.InstanceV1{
 		URI:                  domain,
 		AccountDomain:        accDomain,
@@ -1577,8 +1812,8 @@ func (c *Converter) InstanceToAPIV1Instance(ctx context.Context, i *gtsmodel.Ins
 		Version:              config.GetSoftwareVersion(),
 		Languages:            config.GetInstanceLanguages().TagStrs(),
 		Registrations:        config.GetAccountsRegistrationOpen(),
-		ApprovalRequired:     true,                               // approval always required
-		InvitesEnabled:       false,                              // todo: not supported yet
+		ApprovalRequired:     true, // approval always required
+		InvitesEnabled:       invitesEnabled,
 		MaxTootChars:         uint(config.GetStatusesMaxChars()), // #nosec G115 -- Already validated.
 		Rules:                InstanceRulesToAPIRules(i.Rules),
 		Terms:                i.Terms,
@@ -1897,12 +2132,91 @@ func (c *Converter) NotificationToAPINotification(
 		}
 	}
 
-	return &apimodel.Notification{
+	apiNotif := &apimodel.Notification{
 		ID:        notif.ID,
 		Type:      notif.NotificationType.String(),
+		GroupKey:  notif.GroupKey(gtsmodel.NotificationGroupedTypes),
 		CreatedAt: util.FormatISO8601(notif.CreatedAt),
 		Account:   apiAccount,
 		Status:    apiStatus,
+	}
+
+	if notif.NotificationType == gtsmodel.NotificationEmojiReaction {
+		// Set the emoji of the most recent
+		// reaction by the origin account.
+		reactions, err := c.state.DB.GetStatusReactions(ctx, notif.StatusOrEditID)
+		if err != nil {
+			return nil, gtserror.Newf("error getting status reactions: %w", err)
+		}
+
+		for i := len(reactions) - 1; i >= 0; i-- {
+			reaction := reactions[i]
+			if reaction.AccountID != notif.OriginAccountID {
+				continue
+			}
+
+			if reaction.Emoji != nil {
+				apiNotif.Emoji = ":" + reaction.Emoji.Shortcode + ":"
+				apiNotif.EmojiURL = reaction.Emoji.ImageURL
+			} else {
+				apiNotif.Emoji = reaction.Name
+			}
+			break
+		}
+	}
+
+	return apiNotif, nil
+}
+
+// NotificationPolicyToAPINotificationPolicy converts a gts notification policy into
+// an api notification policy, with summary counts of the given pending requests.
+func (c *Converter) NotificationPolicyToAPINotificationPolicy(
+	policy *gtsmodel.NotificationPolicy,
+	pendingRequests int,
+	pendingNotifications int,
+) *apimodel.NotificationPolicy {
+	return &apimodel.NotificationPolicy{
+		ForNotFollowing:    policy.ForNotFollowing.String(),
+		ForNotFollowers:    policy.ForNotFollowers.String(),
+		ForNewAccounts:     policy.ForNewAccounts.String(),
+		ForPrivateMentions: policy.ForPrivateMentions.String(),
+		ForLimitedAccounts: policy.ForLimitedAccounts.String(),
+		Summary: apimodel.NotificationPolicySummary{
+			PendingRequestsCount:      pendingRequests,
+			PendingNotificationsCount: pendingNotifications,
+		},
+	}
+}
+
+// NotificationRequestToAPINotificationRequest converts
+// a gts notification request into an api notification request.
+func (c *Converter) NotificationRequestToAPINotificationRequest(
+	ctx context.Context,
+	request *gtsmodel.NotificationRequest,
+) (*apimodel.NotificationRequest, error) {
+	apiAccount, err := c.AccountToAPIAccountPublic(ctx, request.FromAccount)
+	if err != nil {
+		return nil, gtserror.Newf("error converting account to api: %w", err)
+	}
+
+	var apiStatus *apimodel.Status
+	if request.LastStatus != nil {
+		apiStatus, err = c.StatusToAPIStatus(ctx,
+			request.LastStatus,
+			request.Account,
+		)
+		if err != nil {
+			return nil, gtserror.Newf("error converting status to api: %w", err)
+		}
+	}
+
+	return &apimodel.NotificationRequest{
+		ID:                 request.ID,
+		CreatedAt:          util.FormatISO8601(request.CreatedAt),
+		UpdatedAt:          util.FormatISO8601(request.UpdatedAt),
+		Account:            apiAccount,
+		NotificationsCount: strconv.Itoa(request.NotificationsCount),
+		LastStatus:         apiStatus,
 	}, nil
 }
 
@@ -2093,6 +2407,226 @@ func (c *Converter) DomainPermSubToAPIDomainPermSub(
 	}, nil
 }
 
+// RelayToAdminAPIRelay converts a gts model relay
+// into an admin view relay, for serving at /api/v1/admin/relays.
+func (c *Converter) RelayToAdminAPIRelay(ctx context.Context, r *gtsmodel.Relay) (*apimodel.AdminRelay, error) {
+	apiRelay := &apimodel.AdminRelay{
+		ID:        r.ID,
+		InboxURL:  r.InboxURI,
+		State:     r.State.String(),
+		Publish:   util.PtrOrZero(r.Publish),
+		CreatedAt: util.FormatISO8601(r.CreatedAt),
+		CreatedBy: r.CreatedByAccountID,
+	}
+
+	if r.Account != nil {
+		apiRelay.ActorURL = r.Account.URI
+	}
+
+	return apiRelay, nil
+}
+
+// InviteToAPIInvite converts a gts model invite
+// into its api (frontend) representation.
+func (c *Converter) InviteToAPIInvite(ctx context.Context, i *gtsmodel.Invite) (*apimodel.Invite, error) {
+	apiInvite := &apimodel.Invite{
+		ID:         i.ID,
+		Code:       i.Code,
+		URL:        uris.GenerateURIForInvite(i.Code),
+		CreatedAt:  util.FormatISO8601(i.CreatedAt),
+		Uses:       i.Uses,
+		Autofollow: util.PtrOrZero(i.Autofollow),
+		Expired:    !i.Valid(time.Now()),
+	}
+
+	if !i.ExpiresAt.IsZero() {
+		expiresAt := util.FormatISO8601(i.ExpiresAt)
+		apiInvite.ExpiresAt = &expiresAt
+	}
+
+	if i.MaxUses > 0 {
+		apiInvite.MaxUses = util.Ptr(i.MaxUses)
+	}
+
+	return apiInvite, nil
+}
+
+// InviteToAdminAPIInvite converts a gts model invite into its
+// api (frontend) representation for admins, which additionally
+// includes the account that created the invite.
+func (c *Converter) InviteToAdminAPIInvite(ctx context.Context, i *gtsmodel.Invite) (*apimodel.Invite, error) {
+	apiInvite, err := c.InviteToAPIInvite(ctx, i)
+	if err != nil {
+		return nil, err
+	}
+
+	if i.Account == nil {
+		// Account was
+		// deleted since.
+		return apiInvite, nil
+	}
+
+	apiInvite.Account, err = c.AccountToAPIAccountPublic(ctx, i.Account)
+	if err != nil {
+		return nil, gtserror.Newf("error converting invite account: %w", err)
+	}
+
+	return apiInvite, nil
+}
+
+// DomainLimitToAPIDomainLimit converts a gts model domain limit into its api (frontend) representation.
+func (c *Converter) DomainLimitToAPIDomainLimit(ctx context.Context, l *gtsmodel.DomainLimit) (*apimodel.DomainLimit, error) {
+	// Domain may be in Punycode,
+	// de-punify it just in case.
+	domain, err := util.DePunify(l.Domain)
+	if err != nil {
+		return nil, gtserror.Newf("error de-punifying domain %s: %w", l.Domain, err)
+	}
+
+	return &apimodel.DomainLimit{
+		ID:             l.ID,
+		Domain:         domain,
+		ContentWarning: l.ContentWarning,
+		Sensitive:      util.PtrOrZero(l.Sensitive) || l.ContentWarning != "",
+		PrivateComment: l.PrivateComment,
+		CreatedAt:      util.FormatISO8601(l.CreatedAt),
+		CreatedBy:      l.CreatedByAccountID,
+	}, nil
+}
+
+// AnnouncementToAPIAnnouncement converts a gts model announcement into
+// its api (frontend) representation, for the requesting account ID, with
+// the given reactions to the announcement. Read indicates whether the
+// requesting account has already read (dismissed) the announcement.
+func (c *Converter) AnnouncementToAPIAnnouncement(
+	ctx context.Context,
+	a *gtsmodel.Announcement,
+	reactions []*gtsmodel.AnnouncementReaction,
+	requesterID string,
+	read bool,
+) (*apimodel.Announcement, error) {
+	emojis, err := c.convertEmojisToAPIEmojis(ctx, a.Emojis, a.EmojiIDs)
+	if err != nil {
+		log.Errorf(ctx, "error converting announcement emojis: %v", err)
+	}
+
+	apiAnnouncement := &apimodel.Announcement{
+		ID:        a.ID,
+		Content:   a.Content,
+		AllDay:    util.PtrOrZero(a.AllDay),
+		UpdatedAt: util.FormatISO8601(a.UpdatedAt),
+		Published: a.IsPublished(),
+		Read:      read,
+		Mentions:  []apimodel.Mention{},
+		Statuses:  []apimodel.Status{},
+		Tags:      []apimodel.Tag{},
+		Emojis:    emojis,
+		Reactions: c.AnnouncementReactionsToAPIAnnouncementReactions(reactions, requesterID),
+	}
+
+	if !a.StartsAt.IsZero() {
+		startsAt := util.FormatISO8601(a.StartsAt)
+		apiAnnouncement.StartsAt = &startsAt
+	}
+
+	if !a.EndsAt.IsZero() {
+		endsAt := util.FormatISO8601(a.EndsAt)
+		apiAnnouncement.EndsAt = &endsAt
+	}
+
+	if a.IsPublished() {
+		apiAnnouncement.PublishedAt = util.FormatISO8601(a.PublishedAt)
+	} else if !a.ScheduledAt.IsZero() {
+		scheduledAt := util.FormatISO8601(a.ScheduledAt)
+		apiAnnouncement.ScheduledAt = &scheduledAt
+	}
+
+	return apiAnnouncement, nil
+}
+
+// AnnouncementReactionsToAPIAnnouncementReactions converts the given
+// reactions to a single announcement into their api (frontend)
+// representation, grouped by reaction name in order of first use.
+func (c *Converter) AnnouncementReactionsToAPIAnnouncementReactions(
+	reactions []*gtsmodel.AnnouncementReaction,
+	requesterID string,
+) []apimodel.AnnouncementReaction {
+	apiReactions := make([]apimodel.AnnouncementReaction, 0, len(reactions))
+	indices := make(map[string]int, len(reactions))
+
+	for _, reaction := range reactions {
+		i, ok := indices[reaction.Name]
+		if !ok {
+			// First reaction with this
+			// name, add a new entry.
+			apiReaction := apimodel.AnnouncementReaction{
+				Name: reaction.Name,
+			}
+
+			if reaction.Emoji != nil {
+				apiReaction.URL = reaction.Emoji.ImageURL
+				apiReaction.StaticURL = reaction.Emoji.ImageStaticURL
+			}
+
+			i = len(apiReactions)
+			indices[reaction.Name] = i
+			apiReactions = append(apiReactions, apiReaction)
+		}
+
+		apiReactions[i].Count++
+		if reaction.AccountID == requesterID {
+			apiReactions[i].Me = true
+		}
+	}
+
+	return apiReactions
+}
+
+// StatusReactionsToAPIEmojiReactions groups the given reactions to a status
+// by name, in order of first use, and converts them to API emoji reactions.
+// Callers should filter the reactions according to blocks etc beforehand.
+func (c *Converter) StatusReactionsToAPIEmojiReactions(
+	ctx context.Context,
+	reactions []*gtsmodel.StatusReaction,
+	requesterID string,
+) ([]*apimodel.EmojiReaction, error) {
+	apiReactions := make([]*apimodel.EmojiReaction, 0, len(reactions))
+	indices := make(map[string]int, len(reactions))
+
+	for _, reaction := range reactions {
+		i, ok := indices[reaction.Name]
+		if !ok {
+			// First reaction with this
+			// name, add a new entry.
+			apiReaction := &apimodel.EmojiReaction{
+				Name:     reaction.Name,
+				Accounts: []*apimodel.Account{},
+			}
+
+			if reaction.Emoji != nil {
+				apiReaction.URL = reaction.Emoji.ImageURL
+			}
+
+			i = len(apiReactions)
+			indices[reaction.Name] = i
+			apiReactions = append(apiReactions, apiReaction)
+		}
+
+		apiAccount, err := c.AccountToAPIAccountPublic(ctx, reaction.Account)
+		if err != nil {
+			return nil, gtserror.Newf("error converting account %s: %w", reaction.AccountID, err)
+		}
+
+		apiReactions[i].Count++
+		apiReactions[i].Accounts = append(apiReactions[i].Accounts, apiAccount)
+		if reaction.AccountID == requesterID {
+			apiReactions[i].Me = true
+		}
+	}
+
+	return apiReactions, nil
+}
+
 // ReportToAPIReport converts a gts model report into an api model report, for serving at /api/v1/reports
 func (c *Converter) ReportToAPIReport(ctx context.Context, r *gtsmodel.Report) (*apimodel.Report, error) {
 	report := &apimodel.Report{
@@ -2718,6 +3252,29 @@ func (c *Converter) InteractionPolicyToAPIInteractionPolicy(
 		}
 	}
 
+	// gtsmodel CanQuote -> apimodel CanQuote
+	if policy.CanQuote != nil {
+		// Use the set CanQuote value.
+		apiPolicy.CanQuote = apimodel.PolicyRules{
+			AutomaticApproval: policyValsToAPIPolicyVals(policy.CanQuote.AutomaticApproval),
+			ManualApproval:    policyValsToAPIPolicyVals(policy.CanQuote.ManualApproval),
+		}
+	} else if status != nil {
+		// Use default CanQuote value for this vis.
+		pCanQuote := gtsmodel.DefaultCanQuoteFor(status.Visibility)
+		apiPolicy.CanQuote = apimodel.PolicyRules{
+			AutomaticApproval: policyValsToAPIPolicyVals(pCanQuote.AutomaticApproval),
+			ManualApproval:    policyValsToAPIPolicyVals(pCanQuote.ManualApproval),
+		}
+	} else {
+		// No status to take vis
+		// from, so just leave empty.
+		apiPolicy.CanQuote = apimodel.PolicyRules{
+			AutomaticApproval: make([]apimodel.PolicyValue, 0),
+			ManualApproval:    make([]apimodel.PolicyValue, 0),
+		}
+	}
+
 	defer func() {
 		// Include deprecated fields for back-compat. TODO: Remove these in 0.21.0.
 		apiPolicy.CanFavourite.Always = apiPolicy.CanFavourite.AutomaticApproval
@@ -2726,6 +3283,8 @@ func (c *Converter) InteractionPolicyToAPIInteractionPolicy(
 		apiPolicy.CanReply.WithApproval = apiPolicy.CanReply.ManualApproval
 		apiPolicy.CanReblog.Always = apiPolicy.CanReblog.AutomaticApproval
 		apiPolicy.CanReblog.WithApproval = apiPolicy.CanReblog.ManualApproval
+		apiPolicy.CanQuote.Always = apiPolicy.CanQuote.AutomaticApproval
+		apiPolicy.CanQuote.WithApproval = apiPolicy.CanQuote.ManualApproval
 	}()
 
 	if status == nil || requester == nil {
@@ -2797,6 +3356,26 @@ func (c *Converter) InteractionPolicyToAPIInteractionPolicy(
 		)
 	}
 
+	quoteable, err := c.intFilter.StatusQuoteable(ctx, requester, status)
+	if err != nil {
+		err := gtserror.Newf("error checking status quoteable by requester: %w", err)
+		return nil, err
+	}
+
+	if quoteable.Permission == gtsmodel.PolicyPermissionAutomaticApproval {
+		// We can do this!
+		apiPolicy.CanQuote.AutomaticApproval = append(
+			apiPolicy.CanQuote.AutomaticApproval,
+			apimodel.PolicyValueMe,
+		)
+	} else if quoteable.Permission == gtsmodel.PolicyPermissionManualApproval {
+		// We can do this with approval.
+		apiPolicy.CanQuote.ManualApproval = append(
+			apiPolicy.CanQuote.ManualApproval,
+			apimodel.PolicyValueMe,
+		)
+	}
+
 	return apiPolicy, nil
 }
 
@@ -2925,6 +3504,21 @@ func (c *Converter) InteractionReqToAPIInteractionReq(
 		}
 	}
 
+	var quote *apimodel.Status
+	if req.InteractionType == gtsmodel.InteractionQuote && req.Quote != nil {
+		quote, err = c.statusToAPIStatus(
+			ctx,
+			req.Quote,
+			requestingAcct,
+			true,  // Placehold unknown attachments.
+			false, // Don't add note about pending.
+		)
+		if err != nil {
+			err := gtserror.Newf("error converting quote: %w", err)
+			return nil, err
+		}
+	}
+
 	var acceptedAt string
 	if req.IsAccepted() {
 		acceptedAt = util.FormatISO8601(req.AcceptedAt)
@@ -2948,6 +3542,7 @@ func (c *Converter) InteractionReqToAPIInteractionReq(
 		Account:    interactingAcct,
 		Status:     interactedStatus,
 		Reply:      reply,
+		Quote:      quote,
 		AcceptedAt: acceptedAt,
 		RejectedAt: rejectedAt,
 	}, nil
@@ -3089,3 +3684,90 @@ func (c *Converter) ScheduledStatusToAPIScheduledStatus(
 
 	return apiScheduledStatus, nil
 }
+
+// IPBlockToAdminAPIIPBlock converts a gts model IP block
+// into an admin view IP block, for serving at /api/v1/admin/ip_blocks.
+func (c *Converter) IPBlockToAdminAPIIPBlock(ctx context.Context, b *gtsmodel.IPBlock) (*apimodel.AdminIPBlock, error) {
+	apiBlock := &apimodel.AdminIPBlock{
+		ID:        b.ID,
+		IP:        b.IP,
+		Severity:  b.Severity.String(),
+		Comment:   b.Comment,
+		CreatedAt: util.FormatISO8601(b.CreatedAt),
+	}
+
+	if !b.ExpiresAt.IsZero() {
+		expiresAt := util.FormatISO8601(b.ExpiresAt)
+		apiBlock.ExpiresAt = &expiresAt
+	}
+
+	return apiBlock, nil
+}
+
+// EmailDomainBlockToAdminAPIEmailDomainBlock converts a gts model email domain block
+// into an admin view email domain block, for serving at /api/v1/admin/email_domain_blocks.
+func (c *Converter) EmailDomainBlockToAdminAPIEmailDomainBlock(ctx context.Context, b *gtsmodel.EmailDomainBlock) (*apimodel.AdminEmailDomainBlock, error) {
+	domain, err := util.DePunify(b.Domain)
+	if err != nil {
+		return nil, gtserror.Newf("error depunifying domain %s: %w", b.Domain, err)
+	}
+
+	return &apimodel.AdminEmailDomainBlock{
+		ID:        b.ID,
+		Domain:    domain,
+		CreatedAt: util.FormatISO8601(b.CreatedAt),
+		CreatedBy: b.CreatedByAccountID,
+	}, nil
+}
+
+// ArchiveToAPIArchive converts a gts model archive
+// into its api (frontend) representation.
+func (c *Converter) ArchiveToAPIArchive(ctx context.Context, a *gtsmodel.Archive) (*apimodel.Archive, error) {
+	apiArchive := &apimodel.Archive{
+		ID:        a.ID,
+		State:     a.State.String(),
+		CreatedAt: util.FormatISO8601(a.CreatedAt),
+	}
+
+	if a.State == gtsmodel.ArchiveStateReady {
+		apiArchive.Size = a.Size
+		apiArchive.URL = config.GetProtocol() + "://" + config.GetHost() + "/api/v1/exports/archive.zip"
+	}
+
+	return apiArchive, nil
+}
+
+// ImportToAPIImport converts a gts model import, along
+// with any of its failures, into its api (frontend)
+// representation.
+func (c *Converter) ImportToAPIImport(
+	ctx context.Context,
+	i *gtsmodel.Import,
+	failures []*gtsmodel.ImportFailure,
+) (*apimodel.Import, error) {
+	mode := "merge"
+	if *i.Overwrite {
+		mode = "overwrite"
+	}
+
+	apiImport := &apimodel.Import{
+		ID:             i.ID,
+		Type:           i.Type,
+		Mode:           mode,
+		State:          i.State.String(),
+		CreatedAt:      util.FormatISO8601(i.CreatedAt),
+		UpdatedAt:      util.FormatISO8601(i.UpdatedAt),
+		TotalItems:     i.TotalItems,
+		ProcessedItems: i.ProcessedItems,
+		FailedItems:    i.FailedItems,
+	}
+
+	for _, failure := range failures {
+		apiImport.Failures = append(apiImport.Failures, apimodel.ImportFailure{
+			Entry: failure.Entry,
+			Error: failure.Error,
+		})
+	}
+
+	return apiImport, nil
+}
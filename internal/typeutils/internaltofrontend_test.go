@@ -51,6 +51,7 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontend() {
   "display_name": "original zork (he/they)",
   "locked": false,
   "discoverable": true,
+  "indexable": false,
   "bot": false,
   "created_at": "2022-05-20T11:09:18.000Z",
   "note": "\u003cp\u003ehey yo this is my profile!\u003c/p\u003e",
@@ -104,6 +105,7 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendAliasedAndMoved()
   "display_name": "original zork (he/they)",
   "locked": false,
   "discoverable": true,
+  "indexable": false,
   "bot": false,
   "created_at": "2022-05-20T11:09:18.000Z",
   "note": "\u003cp\u003ehey yo this is my profile!\u003c/p\u003e",
@@ -126,6 +128,8 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendAliasedAndMoved()
     "privacy": "public",
     "web_visibility": "unlisted",
     "web_layout": "microblog",
+    "email_notifications": "none",
+    "email_notification_types": [],
     "sensitive": false,
     "language": "en",
     "status_content_type": "text/plain",
@@ -151,6 +155,7 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendAliasedAndMoved()
     "display_name": "happy little turtle :3",
     "locked": true,
     "discoverable": false,
+    "indexable": false,
     "bot": false,
     "created_at": "2022-06-04T13:12:00.000Z",
     "note": "\u003cp\u003ei post about things that concern me\u003c/p\u003e",
@@ -205,6 +210,7 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendWithEmojiStruct()
   "display_name": "original zork (he/they)",
   "locked": false,
   "discoverable": true,
+  "indexable": false,
   "bot": false,
   "created_at": "2022-05-20T11:09:18.000Z",
   "note": "\u003cp\u003ehey yo this is my profile!\u003c/p\u003e",
@@ -255,6 +261,7 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendWithEmojiIDs() {
   "display_name": "original zork (he/they)",
   "locked": false,
   "discoverable": true,
+  "indexable": false,
   "bot": false,
   "created_at": "2022-05-20T11:09:18.000Z",
   "note": "\u003cp\u003ehey yo this is my profile!\u003c/p\u003e",
@@ -301,6 +308,7 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendSensitive() {
   "display_name": "original zork (he/they)",
   "locked": false,
   "discoverable": true,
+  "indexable": false,
   "bot": false,
   "created_at": "2022-05-20T11:09:18.000Z",
   "note": "\u003cp\u003ehey yo this is my profile!\u003c/p\u003e",
@@ -323,6 +331,8 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendSensitive() {
     "privacy": "public",
     "web_visibility": "unlisted",
     "web_layout": "microblog",
+    "email_notifications": "none",
+    "email_notification_types": [],
     "sensitive": false,
     "language": "en",
     "status_content_type": "text/plain",
@@ -361,6 +371,7 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendPublicPunycode()
   "display_name": "",
   "locked": false,
   "discoverable": false,
+  "indexable": false,
   "bot": false,
   "created_at": "2020-08-10T12:13:28.000Z",
   "note": "",
@@ -401,6 +412,7 @@ func (suite *InternalToFrontendTestSuite) TestLocalInstanceAccountToFrontendPubl
   "display_name": "",
   "locked": false,
   "discoverable": true,
+  "indexable": false,
   "bot": true,
   "created_at": "2020-05-17T13:10:59.000Z",
   "note": "",
@@ -441,6 +453,7 @@ func (suite *InternalToFrontendTestSuite) TestLocalInstanceAccountToFrontendBloc
   "display_name": "",
   "locked": false,
   "discoverable": false,
+  "indexable": false,
   "bot": true,
   "created_at": "2020-05-17T13:10:59.000Z",
   "note": "",
@@ -502,6 +515,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontend() {
     "display_name": "",
     "locked": false,
     "discoverable": true,
+    "indexable": false,
     "bot": false,
     "created_at": "2022-05-17T13:10:59.000Z",
     "note": "",
@@ -614,6 +628,22 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontend() {
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "automatic_approval": [
+        "author"
+      ],
+      "manual_approval": [
+        "public",
+        "me"
+      ],
+      "always": [
+        "author"
+      ],
+      "with_approval": [
+        "public",
+        "me"
+      ]
     }
   }
 }`, string(b))
@@ -665,6 +695,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendHTMLContentWarning
     "display_name": "",
     "locked": false,
     "discoverable": true,
+    "indexable": false,
     "bot": false,
     "created_at": "2022-05-17T13:10:59.000Z",
     "note": "",
@@ -777,6 +808,22 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendHTMLContentWarning
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "automatic_approval": [
+        "author"
+      ],
+      "manual_approval": [
+        "public",
+        "me"
+      ],
+      "always": [
+        "author"
+      ],
+      "with_approval": [
+        "public",
+        "me"
+      ]
     }
   }
 }`, string(b))
@@ -830,6 +877,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendApplicationDeleted
     "display_name": "",
     "locked": false,
     "discoverable": true,
+    "indexable": false,
     "bot": false,
     "created_at": "2022-05-17T13:10:59.000Z",
     "note": "",
@@ -942,6 +990,22 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendApplicationDeleted
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "automatic_approval": [
+        "author"
+      ],
+      "manual_approval": [
+        "public",
+        "me"
+      ],
+      "always": [
+        "author"
+      ],
+      "with_approval": [
+        "public",
+        "me"
+      ]
     }
   }
 }`, string(b))
@@ -986,6 +1050,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownAttachments
     "display_name": "some user",
     "locked": true,
     "discoverable": true,
+    "indexable": false,
     "bot": false,
     "created_at": "2020-08-10T12:13:28.000Z",
     "note": "i'm a real son of a gun",
@@ -1082,6 +1147,22 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownAttachments
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "automatic_approval": [
+        "author",
+        "me"
+      ],
+      "manual_approval": [
+        "public"
+      ],
+      "always": [
+        "author",
+        "me"
+      ],
+      "with_approval": [
+        "public"
+      ]
     }
   }
 }`, string(b))
@@ -1170,6 +1251,20 @@ func (suite *InternalToFrontendTestSuite) TestStatusToWebStatus() {
         "public"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "automatic_approval": [
+        "author"
+      ],
+      "manual_approval": [
+        "public"
+      ],
+      "always": [
+        "author"
+      ],
+      "with_approval": [
+        "public"
+      ]
     }
   },
   "account": {
@@ -1179,6 +1274,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToWebStatus() {
     "display_name": "some user",
     "locked": true,
     "discoverable": true,
+    "indexable": false,
     "bot": false,
     "created_at": "2020-08-10T12:13:28.000Z",
     "note": "i'm a real son of a gun",
@@ -1317,6 +1413,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownLanguage()
     "display_name": "",
     "locked": false,
     "discoverable": true,
+    "indexable": false,
     "bot": false,
     "created_at": "2022-05-17T13:10:59.000Z",
     "note": "",
@@ -1429,6 +1526,22 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownLanguage()
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "automatic_approval": [
+        "author"
+      ],
+      "manual_approval": [
+        "public",
+        "me"
+      ],
+      "always": [
+        "author"
+      ],
+      "with_approval": [
+        "public",
+        "me"
+      ]
     }
   }
 }`, string(b))
@@ -1478,6 +1591,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendPartialInteraction
     "display_name": "original zork (he/they)",
     "locked": false,
     "discoverable": true,
+    "indexable": false,
     "bot": false,
     "created_at": "2022-05-20T11:09:18.000Z",
     "note": "\u003cp\u003ehey yo this is my profile!\u003c/p\u003e",
@@ -1537,6 +1651,16 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendPartialInteraction
         "author"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "automatic_approval": [
+        "author"
+      ],
+      "manual_approval": [],
+      "always": [
+        "author"
+      ],
+      "with_approval": []
     }
   }
 }`, string(b))
@@ -1600,6 +1724,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToAPIStatusPendingApproval()
     "display_name": "",
     "locked": false,
     "discoverable": true,
+    "indexable": false,
     "bot": false,
     "created_at": "2022-05-17T13:10:59.000Z",
     "note": "",
@@ -1676,6 +1801,22 @@ func (suite *InternalToFrontendTestSuite) TestStatusToAPIStatusPendingApproval()
         "me"
       ],
       "with_approval": []
+    },
+    "can_quote": {
+      "automatic_approval": [
+        "author"
+      ],
+      "manual_approval": [
+        "public",
+        "me"
+      ],
+      "always": [
+        "author"
+      ],
+      "with_approval": [
+        "public",
+        "me"
+      ]
     }
   }
 }
@@ -1830,6 +1971,7 @@ func (suite *InternalToFrontendTestSuite) TestInstanceV1ToFrontend() {
     "display_name": "",
     "locked": false,
     "discoverable": true,
+    "indexable": false,
     "bot": false,
     "created_at": "2022-05-17T13:10:59.000Z",
     "note": "",
@@ -1990,6 +2132,7 @@ func (suite *InternalToFrontendTestSuite) TestInstanceV2ToFrontend() {
       "display_name": "",
       "locked": false,
       "discoverable": true,
+      "indexable": false,
       "bot": false,
       "created_at": "2022-05-17T13:10:59.000Z",
       "note": "",
@@ -2112,6 +2255,7 @@ func (suite *InternalToFrontendTestSuite) TestReportToFrontend1() {
     "display_name": "big gerald",
     "locked": false,
     "discoverable": true,
+    "indexable": false,
     "bot": false,
     "created_at": "2021-09-26T10:52:36.000Z",
     "note": "i post about like, i dunno, stuff, or whatever!!!!",
@@ -2157,6 +2301,7 @@ func (suite *InternalToFrontendTestSuite) TestReportToFrontend2() {
     "display_name": "happy little turtle :3",
     "locked": true,
     "discoverable": false,
+    "indexable": false,
     "bot": false,
     "created_at": "2022-06-04T13:12:00.000Z",
     "note": "\u003cp\u003ei post about things that concern me\u003c/p\u003e",
@@ -2226,6 +2371,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
     "confirmed": false,
     "approved": false,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -2235,6 +2381,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
       "display_name": "big gerald",
       "locked": false,
       "discoverable": true,
+      "indexable": false,
       "bot": false,
       "created_at": "2021-09-26T10:52:36.000Z",
       "note": "i post about like, i dunno, stuff, or whatever!!!!",
@@ -2273,6 +2420,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
     "confirmed": true,
     "approved": true,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -2282,6 +2430,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
       "display_name": "happy little turtle :3",
       "locked": true,
       "discoverable": false,
+      "indexable": false,
       "bot": false,
       "created_at": "2022-06-04T13:12:00.000Z",
       "note": "\u003cp\u003ei post about things that concern me\u003c/p\u003e",
@@ -2333,6 +2482,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
     "confirmed": true,
     "approved": true,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -2342,6 +2492,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
       "display_name": "",
       "locked": false,
       "discoverable": true,
+      "indexable": false,
       "bot": false,
       "created_at": "2022-05-17T13:10:59.000Z",
       "note": "",
@@ -2389,6 +2540,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
     "confirmed": true,
     "approved": true,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -2398,6 +2550,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
       "display_name": "",
       "locked": false,
       "discoverable": true,
+      "indexable": false,
       "bot": false,
       "created_at": "2022-05-17T13:10:59.000Z",
       "note": "",
@@ -2468,6 +2621,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
     "confirmed": true,
     "approved": true,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -2477,6 +2631,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
       "display_name": "happy little turtle :3",
       "locked": true,
       "discoverable": false,
+      "indexable": false,
       "bot": false,
       "created_at": "2022-06-04T13:12:00.000Z",
       "note": "\u003cp\u003ei post about things that concern me\u003c/p\u003e",
@@ -2528,6 +2683,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
     "confirmed": false,
     "approved": false,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -2537,6 +2693,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
       "display_name": "big gerald",
       "locked": false,
       "discoverable": true,
+      "indexable": false,
       "bot": false,
       "created_at": "2021-09-26T10:52:36.000Z",
       "note": "i post about like, i dunno, stuff, or whatever!!!!",
@@ -2587,6 +2744,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
         "display_name": "big gerald",
         "locked": false,
         "discoverable": true,
+        "indexable": false,
         "bot": false,
         "created_at": "2021-09-26T10:52:36.000Z",
         "note": "i post about like, i dunno, stuff, or whatever!!!!",
@@ -2676,6 +2834,22 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
             "me"
           ],
           "with_approval": []
+        },
+        "can_quote": {
+          "automatic_approval": [
+            "author",
+            "me"
+          ],
+          "manual_approval": [
+            "public"
+          ],
+          "always": [
+            "author",
+            "me"
+          ],
+          "with_approval": [
+            "public"
+          ]
         }
       }
     }
@@ -2753,6 +2927,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
     "confirmed": false,
     "approved": false,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -2762,6 +2937,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
       "display_name": "big gerald",
       "locked": false,
       "discoverable": true,
+      "indexable": false,
       "bot": false,
       "created_at": "2021-09-26T10:52:36.000Z",
       "note": "i post about like, i dunno, stuff, or whatever!!!!",
@@ -2800,6 +2976,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
     "confirmed": true,
     "approved": true,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": true,
     "account": {
@@ -2809,6 +2986,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
       "display_name": "",
       "locked": true,
       "discoverable": false,
+      "indexable": false,
       "bot": false,
       "created_at": "2022-06-04T13:12:00.000Z",
       "note": "",
@@ -2849,6 +3027,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
     "confirmed": true,
     "approved": true,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -2858,6 +3037,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
       "display_name": "",
       "locked": false,
       "discoverable": true,
+      "indexable": false,
       "bot": false,
       "created_at": "2022-05-17T13:10:59.000Z",
       "note": "",
@@ -2905,6 +3085,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
     "confirmed": true,
     "approved": true,
     "disabled": false,
+    "sensitized": false,
     "silenced": false,
     "suspended": false,
     "account": {
@@ -2914,6 +3095,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
       "display_name": "",
       "locked": false,
       "discoverable": true,
+      "indexable": false,
       "bot": false,
       "created_at": "2022-05-17T13:10:59.000Z",
       "note": "",
@@ -3062,6 +3244,7 @@ func (suite *InternalToFrontendTestSuite) TestIntReqToAPI() {
     "display_name": "",
     "locked": false,
     "discoverable": true,
+    "indexable": false,
     "bot": false,
     "created_at": "2022-05-17T13:10:59.000Z",
     "note": "",
@@ -3120,6 +3303,7 @@ func (suite *InternalToFrontendTestSuite) TestIntReqToAPI() {
       "display_name": "happy little turtle :3",
       "locked": true,
       "discoverable": false,
+      "indexable": false,
       "bot": false,
       "created_at": "2022-06-04T13:12:00.000Z",
       "note": "\u003cp\u003ei post about things that concern me\u003c/p\u003e",
@@ -3197,6 +3381,22 @@ func (suite *InternalToFrontendTestSuite) TestIntReqToAPI() {
           "me"
         ],
         "with_approval": []
+      },
+      "can_quote": {
+        "automatic_approval": [
+          "author",
+          "me"
+        ],
+        "manual_approval": [
+          "public"
+        ],
+        "always": [
+          "author",
+          "me"
+        ],
+        "with_approval": [
+          "public"
+        ]
       }
     }
   },
@@ -3233,6 +3433,7 @@ func (suite *InternalToFrontendTestSuite) TestIntReqToAPI() {
       "display_name": "",
       "locked": false,
       "discoverable": true,
+      "indexable": false,
       "bot": false,
       "created_at": "2022-05-17T13:10:59.000Z",
       "note": "",
@@ -3309,6 +3510,22 @@ func (suite *InternalToFrontendTestSuite) TestIntReqToAPI() {
           "me"
         ],
         "with_approval": []
+      },
+      "can_quote": {
+        "automatic_approval": [
+          "author"
+        ],
+        "manual_approval": [
+          "public",
+          "me"
+        ],
+        "always": [
+          "author"
+        ],
+        "with_approval": [
+          "public",
+          "me"
+        ]
       }
     }
   }
@@ -3360,6 +3577,7 @@ func (suite *InternalToFrontendTestSuite) TestConversationToAPISelfConvo() {
       "display_name": "original zork (he/they)",
       "locked": false,
       "discoverable": true,
+      "indexable": false,
       "bot": false,
       "created_at": "2022-05-20T11:09:18.000Z",
       "note": "\u003cp\u003ehey yo this is my profile!\u003c/p\u003e",
@@ -3415,6 +3633,7 @@ func (suite *InternalToFrontendTestSuite) TestConversationToAPISelfConvo() {
       "display_name": "original zork (he/they)",
       "locked": false,
       "discoverable": true,
+      "indexable": false,
       "bot": false,
       "created_at": "2022-05-20T11:09:18.000Z",
       "note": "\u003cp\u003ehey yo this is my profile!\u003c/p\u003e",
@@ -3480,6 +3699,22 @@ func (suite *InternalToFrontendTestSuite) TestConversationToAPISelfConvo() {
           "me"
         ],
         "with_approval": []
+      },
+      "can_quote": {
+        "automatic_approval": [
+          "author",
+          "me"
+        ],
+        "manual_approval": [
+          "public"
+        ],
+        "always": [
+          "author",
+          "me"
+        ],
+        "with_approval": [
+          "public"
+        ]
       }
     }
   }
@@ -3533,6 +3768,7 @@ func (suite *InternalToFrontendTestSuite) TestConversationToAPI() {
       "display_name": "happy little turtle :3",
       "locked": true,
       "discoverable": false,
+      "indexable": false,
       "bot": false,
       "created_at": "2022-06-04T13:12:00.000Z",
       "note": "\u003cp\u003ei post about things that concern me\u003c/p\u003e",
@@ -3596,6 +3832,7 @@ func (suite *InternalToFrontendTestSuite) TestConversationToAPI() {
       "display_name": "original zork (he/they)",
       "locked": false,
       "discoverable": true,
+      "indexable": false,
       "bot": false,
       "created_at": "2022-05-20T11:09:18.000Z",
       "note": "\u003cp\u003ehey yo this is my profile!\u003c/p\u003e",
@@ -3661,6 +3898,22 @@ func (suite *InternalToFrontendTestSuite) TestConversationToAPI() {
           "me"
         ],
         "with_approval": []
+      },
+      "can_quote": {
+        "automatic_approval": [
+          "author",
+          "me"
+        ],
+        "manual_approval": [
+          "public"
+        ],
+        "always": [
+          "author",
+          "me"
+        ],
+        "with_approval": [
+          "public"
+        ]
       }
     }
   }
@@ -3698,6 +3951,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToAPIEdits() {
             "display_name": "happy little turtle :3",
             "locked": true,
             "discoverable": false,
+            "indexable": false,
             "bot": false,
             "created_at": "2022-06-04T13:12:00.000Z",
             "note": "\u003cp\u003ei post about things that concern me\u003c/p\u003e",
@@ -3743,6 +3997,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToAPIEdits() {
             "display_name": "happy little turtle :3",
             "locked": true,
             "discoverable": false,
+            "indexable": false,
             "bot": false,
             "created_at": "2022-06-04T13:12:00.000Z",
             "note": "\u003cp\u003ei post about things that concern me\u003c/p\u003e",
@@ -3801,6 +4056,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToAPIEdits() {
             "display_name": "happy little turtle :3",
             "locked": true,
             "discoverable": false,
+            "indexable": false,
             "bot": false,
             "created_at": "2022-06-04T13:12:00.000Z",
             "note": "\u003cp\u003ei post about things that concern me\u003c/p\u003e",
@@ -3846,6 +4102,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToAPIEdits() {
             "display_name": "happy little turtle :3",
             "locked": true,
             "discoverable": false,
+            "indexable": false,
             "bot": false,
             "created_at": "2022-06-04T13:12:00.000Z",
             "note": "\u003cp\u003ei post about things that concern me\u003c/p\u003e",
@@ -115,6 +115,14 @@ func (suite *WrapTestSuite) TestWrapNoteInCreate() {
         ],
         "manualApproval": []
       },
+      "canQuote": {
+        "automaticApproval": [
+          "http://localhost:8080/users/the_mighty_zork"
+        ],
+        "manualApproval": [
+          "https://www.w3.org/ns/activitystreams#Public"
+        ]
+      },
       "canReply": {
         "always": [
           "https://www.w3.org/ns/activitystreams#Public"
@@ -181,12 +189,18 @@ func (suite *WrapTestSuite) TestWrapAccountableInUpdate() {
     "https://gotosocial.org/ns",
     "https://w3id.org/security/v1",
     "https://www.w3.org/ns/activitystreams",
+    "https://w3id.org/security/data-integrity/v1",
     {
       "discoverable": "toot:discoverable",
       "featured": {
         "@id": "toot:featured",
         "@type": "@id"
       },
+      "featuredTags": {
+        "@id": "toot:featuredTags",
+        "@type": "@id"
+      },
+      "indexable": "toot:indexable",
       "manuallyApprovesFollowers": "as:manuallyApprovesFollowers",
       "toot": "http://joinmastodon.org/ns#"
     }
@@ -195,8 +209,17 @@ func (suite *WrapTestSuite) TestWrapAccountableInUpdate() {
   "bcc": "http://localhost:8080/users/the_mighty_zork/followers",
   "id": "`+createID.String()+`",
   "object": {
+    "assertionMethod": [
+      {
+        "controller": "http://localhost:8080/users/the_mighty_zork",
+        "id": "http://localhost:8080/users/the_mighty_zork/main-key#ed25519-key",
+        "publicKeyMultibase": "z6Mku8JuRFRNwnAe7TszvAQq8AqmXJB1LBve5wVaJENz18eb",
+        "type": "Multikey"
+      }
+    ],
     "discoverable": true,
     "featured": "http://localhost:8080/users/the_mighty_zork/collections/featured",
+    "featuredTags": "http://localhost:8080/users/the_mighty_zork/collections/tags",
     "followers": "http://localhost:8080/users/the_mighty_zork/followers",
     "following": "http://localhost:8080/users/the_mighty_zork/following",
     "hidesCcPublicFromUnauthedWeb": false,
@@ -215,6 +238,7 @@ func (suite *WrapTestSuite) TestWrapAccountableInUpdate() {
       "url": "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/original/01PFPMWK2FF0D9WMHEJHR07C3Q.jpg"
     },
     "inbox": "http://localhost:8080/users/the_mighty_zork/inbox",
+    "indexable": false,
     "manuallyApprovesFollowers": false,
     "name": "original zork (he/they)",
     "outbox": "http://localhost:8080/users/the_mighty_zork/outbox",
@@ -43,12 +43,17 @@
 	MovesPath          = "moves"          // MovesPath is used to generate the URI for a move
 	ReportsPath        = "reports"        // ReportsPath is used to generate the URI for a report/flag
 	ConfirmEmailPath   = "confirm_email"  // ConfirmEmailPath is used to generate the URI for an email confirmation link
+	UnsubscribePath    = "unsubscribe"    // UnsubscribePath is used to generate the URI for a one-click email unsubscribe link
+	DevicePath         = "device"         // DevicePath is used to generate the URI where users enter an OAuth device user code
+	SignupPath         = "signup"         // SignupPath is used to generate the URI for an invite link to the sign-up form
 	FileserverPath     = "fileserver"     // FileserverPath is a path component for serving attachments + media
 	EmojiPath          = "emoji"          // EmojiPath represents the activitypub emoji location
 	TagsPath           = "tags"           // TagsPath represents the activitypub tags location
 	AcceptsPath        = "accepts"        // AcceptsPath represents the activitypub Accept's location
 	AuthorizationsPath = "authorizations" // AuthorizationsPath represents the location of an Authorization type such as LikeAuthorization, ReplyAuthorization, etc.
 	RejectsPath        = "rejects"        // RejectsPath represents the activitypub Reject's location
+	ReactionsPath      = "reactions"      // ReactionsPath is used to generate the URI for an emoji reaction
+	Ed25519KeyFragment = "ed25519-key"    // Ed25519KeyFragment is appended to the public key URI to identify an account's Ed25519 key
 )
 
 // UserURIs contains a bunch of UserURIs
@@ -99,9 +104,17 @@ type UserURIs struct {
 	// eg., https://example.org/users/example_user/collections/featured
 	FeaturedCollectionURI string
 
+	// The activitypub URI for this user's featured tags collection,
+	// eg., https://example.org/users/example_user/collections/tags
+	FeaturedTagsURI string
+
 	// The URI for this user's public key,
 	// eg., https://example.org/users/example_user/publickey
 	PublicKeyURI string
+
+	// The URI for this user's Ed25519 public key,
+	// eg., https://example.org/users/example_user/main-key#ed25519-key
+	Ed25519PublicKeyURI string
 }
 
 // GenerateURIForFollow returns the AP URI for a new follow -- something like:
@@ -132,6 +145,20 @@ func GenerateURIForLike(username string, thisFavedID string) string {
 	)
 }
 
+// GenerateURIForEmojiReact returns the AP URI for a new emoji reaction -- something like:
+// https://example.org/users/whatever_user/reactions/01F7XTH1QGBAPMGF49WJZ91XGC
+func GenerateURIForEmojiReact(username string, thisReactionID string) string {
+	proto := config.GetProtocol()
+	host := config.GetHost()
+	return buildURL4(proto,
+		host,
+		UsersPath,
+		username,
+		ReactionsPath,
+		thisReactionID,
+	)
+}
+
 // GenerateURIForUpdate returns the AP URI for a new update activity -- something like:
 // https://example.org/users/whatever_user#updates/01F7XTH1QGBAPMGF49WJZ91XGC
 func GenerateURIForUpdate(username string, thisUpdateID string) string {
@@ -197,6 +224,32 @@ func GenerateURIForEmailConfirm(token string) string {
 	return buildURL1(proto, host, ConfirmEmailPath) + "?token=" + token
 }
 
+// GenerateURIForEmailUnsubscribe returns a link for one-click unsubscribing
+// from notification emails -- something like:
+// https://example.org/unsubscribe?account=01FV...&token=abc...
+func GenerateURIForEmailUnsubscribe(accountID string, token string) string {
+	proto := config.GetProtocol()
+	host := config.GetHost()
+	return buildURL1(proto, host, UnsubscribePath) + "?account=" + accountID + "&token=" + token
+}
+
+// GenerateURIForInvite returns a link to the sign-up form with
+// the given invite code pre-filled -- something like:
+// https://example.org/signup?invite=3b2Gq8WJ
+func GenerateURIForInvite(code string) string {
+	proto := config.GetProtocol()
+	host := config.GetHost()
+	return buildURL1(proto, host, SignupPath) + "?invite=" + code
+}
+
+// GenerateURIForDevice returns the verification link where users enter an
+// OAuth device flow user code -- something like: https://example.org/device
+func GenerateURIForDevice() string {
+	proto := config.GetProtocol()
+	host := config.GetHost()
+	return buildURL1(proto, host, DevicePath)
+}
+
 // GenerateURIForAccept returns the AP URI for a new Accept activity -- something like:
 // https://example.org/users/whatever_user/accepts/01F7XTH1QGBAPMGF49WJZ91XGC
 func GenerateURIForAccept(username string, thisAcceptID string) string {
@@ -260,7 +313,9 @@ func GenerateURIsForAccount(username string) UserURIs {
 	followingURI := userURI + "/" + FollowingPath
 	likedURI := userURI + "/" + LikedPath
 	collectionURI := userURI + "/" + CollectionsPath + "/" + FeaturedPath
+	featuredTagsURI := userURI + "/" + CollectionsPath + "/" + TagsPath
 	publicKeyURI := userURI + "/" + PublicKeyPath
+	ed25519PublicKeyURI := publicKeyURI + "#" + Ed25519KeyFragment
 
 	return UserURIs{
 		HostURL:     hostURL,
@@ -275,7 +330,9 @@ func GenerateURIsForAccount(username string) UserURIs {
 		FollowingURI:          followingURI,
 		LikedURI:              likedURI,
 		FeaturedCollectionURI: collectionURI,
+		FeaturedTagsURI:       featuredTagsURI,
 		PublicKeyURI:          publicKeyURI,
+		Ed25519PublicKeyURI:   ed25519PublicKeyURI,
 	}
 }
 
@@ -325,6 +382,16 @@ func StoragePathForAttachment(
 	) + "." + extension
 }
 
+// StoragePathForBlob generates a content-addressed
+// storage path for media, from its hex-encoded checksum.
+//
+// Will produce something like:
+//
+//	"blob/9f/9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08.gif"
+func StoragePathForBlob(checksum string, extension string) string {
+	return "blob/" + checksum[:2] + "/" + checksum + "." + extension
+}
+
 // URIForEmoji generates an
 // ActivityPub URI for an emoji.
 //
@@ -21,11 +21,13 @@
 	"errors"
 	"fmt"
 	"net/mail"
+	"unicode"
 
 	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
 	"code.superseriousbusiness.org/gotosocial/internal/config"
 	"code.superseriousbusiness.org/gotosocial/internal/gtsmodel"
 	"code.superseriousbusiness.org/gotosocial/internal/regexes"
+	"github.com/rivo/uniseg"
 	pwv "github.com/wagslane/go-password-validator"
 	"golang.org/x/text/language"
 )
@@ -45,6 +47,7 @@
 	maximumListTitleLength        = 200
 	maximumFilterKeywordLength    = 40
 	maximumFilterTitleLength      = 200
+	maximumAnnouncementLength     = 5000
 )
 
 // Password returns a helpful error if the given password
@@ -208,6 +211,29 @@ func EmojiShortcode(shortcode string) error {
 	return nil
 }
 
+// EmojiReaction validates the given emoji reaction name, which
+// must be either a single unicode emoji (which may be made up of
+// several codepoints), or a valid custom emoji shortcode.
+func EmojiReaction(name string) error {
+	if regexes.EmojiValidator.MatchString(name) {
+		// Custom emoji shortcode.
+		return nil
+	}
+
+	if uniseg.GraphemeClusterCount(name) == 1 {
+		// Single grapheme: check it contains
+		// at least one symbol codepoint, or
+		// a keycap for eg., "1️⃣".
+		for _, r := range name {
+			if unicode.Is(unicode.So, r) || r == '\u20e3' {
+				return nil
+			}
+		}
+	}
+
+	return fmt.Errorf("reaction %s did not pass validation, must be a single unicode emoji or a custom emoji shortcode", name)
+}
+
 // EmojiCategory validates the length of the given category string.
 func EmojiCategory(category string) error {
 	if length := len(category); length > maximumEmojiCategoryLength {
@@ -252,6 +278,19 @@ func SiteTerms(t string) error {
 	return nil
 }
 
+// AnnouncementText ensures that the given announcement text is within spec.
+func AnnouncementText(t string) error {
+	if t == "" {
+		return errors.New("announcement text must not be empty")
+	}
+
+	if length := len([]rune(t)); length > maximumAnnouncementLength {
+		return fmt.Errorf("announcement text should be no more than %d chars but given text was %d", maximumAnnouncementLength, length)
+	}
+
+	return nil
+}
+
 // ULID returns an error if the passed string is not a valid ULID.
 // The name param is used to form error messages.
 func ULID(i string, name string) error {
@@ -406,7 +445,12 @@ func CreateAccount(form *apimodel.AccountCreateRequest) error {
 		return errors.New("form was nil")
 	}
 
-	if !config.GetAccountsRegistrationOpen() {
+	// An invite code allows sign-up even when registration
+	// is closed. Whether the code is actually valid is
+	// checked later on, when the account is created.
+	invited := form.InviteCode != "" && config.GetAccountsInvitesEnabled()
+
+	if !invited && !config.GetAccountsRegistrationOpen() {
 		return errors.New("registration is not open for this server")
 	}
 
@@ -432,5 +476,7 @@ func CreateAccount(form *apimodel.AccountCreateRequest) error {
 	}
 	form.Locale = locale
 
-	return SignUpReason(form.Reason, config.GetAccountsReasonRequired())
+	// Invited sign-ups skip the approval
+	// queue, so no reason is required.
+	return SignUpReason(form.Reason, !invited && config.GetAccountsReasonRequired())
 }
@@ -385,6 +385,32 @@ type testStruct struct {
 	}
 }
 
+func (suite *ValidationTestSuite) TestValidateEmojiReaction() {
+	for _, test := range []struct {
+		name string
+		ok   bool
+	}{
+		{name: "blobcat_uwu", ok: true},
+		{name: "👍", ok: true},
+		{name: "👍🏽", ok: true},
+		{name: "🏳️‍🌈", ok: true},
+		{name: "🇳🇿", ok: true},
+		{name: "1️⃣", ok: true},
+		{name: "❤️", ok: true},
+		{name: "", ok: false},
+		{name: "👍👍", ok: false},
+		{name: "a b", ok: false},
+		{name: "é", ok: false},
+		{name: ":blobcat_uwu:", ok: false},
+	} {
+		err := validate.EmojiReaction(test.name)
+		ok := err == nil
+		if !suite.Equal(test.ok, ok) {
+			suite.T().Logf("fail on %s", test.name)
+		}
+	}
+}
+
 func TestValidationTestSuite(t *testing.T) {
 	suite.Run(t, new(ValidationTestSuite))
 }
@@ -0,0 +1,89 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	apimodel "code.superseriousbusiness.org/gotosocial/internal/api/model"
+	apiutil "code.superseriousbusiness.org/gotosocial/internal/api/util"
+	"code.superseriousbusiness.org/gotosocial/internal/gtserror"
+	"code.superseriousbusiness.org/gotosocial/internal/uris"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	devicePath = "/" + uris.DevicePath
+)
+
+// deviceGETHandler serves the page where a user
+// enters the user code shown by an OAuth client
+// using the device authorization flow. Once the
+// code is entered (or if it's already provided
+// in the query), the user is sent to sign in and
+// approve the request at the authorize endpoint.
+func (m *Module) deviceGETHandler(c *gin.Context) {
+	instance, errWithCode := m.processor.InstanceGetV1(c.Request.Context())
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// Return instance we already got from the db,
+	// don't try to fetch it again when erroring.
+	instanceGet := func(ctx context.Context) (*apimodel.InstanceV1, gtserror.WithCode) {
+		return instance, nil
+	}
+
+	// We only serve text/html at this endpoint.
+	if _, err := apiutil.NegotiateAccept(c, apiutil.TextHTML); err != nil {
+		apiutil.WebErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), instanceGet)
+		return
+	}
+
+	// If the user has entered a code,
+	// check it before sending them on
+	// to sign in, so they don't have to
+	// sign in only to find it's wrong.
+	if userCode := c.Query("user_code"); userCode != "" {
+		deviceAuth, errWithCode := m.processor.OAuthGetDeviceAuthorization(
+			c.Request.Context(),
+			userCode,
+		)
+		if errWithCode != nil {
+			apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+			return
+		}
+
+		c.Redirect(
+			http.StatusSeeOther,
+			"/oauth/authorize?user_code="+url.QueryEscape(deviceAuth.UserCode),
+		)
+		return
+	}
+
+	page := apiutil.WebPage{
+		Template: "device.tmpl",
+		Instance: instance,
+		OGMeta:   apiutil.OGBase(instance),
+	}
+
+	apiutil.TemplateWebPage(c, page)
+}
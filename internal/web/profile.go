@@ -37,6 +37,7 @@ type profile struct {
 	rssFeed        string
 	robotsMeta     string
 	pinnedStatuses []*apimodel.WebStatus
+	featuredTags   []*apimodel.FeaturedTag
 	statusResp     *apimodel.PageableResponse
 	paging         bool
 }
@@ -144,6 +145,13 @@ func (m *Module) prepareProfile(c *gin.Context) *profile {
 		}
 	}
 
+	// Load hashtags featured on the profile.
+	featuredTags, errWithCode := m.processor.Account().WebFeaturedTagsGet(ctx, account.ID)
+	if errWithCode != nil {
+		apiutil.WebErrorHandler(c, errWithCode, instanceGet)
+		return nil
+	}
+
 	// Limit varies depending on whether this is a gallery view or not.
 	// If gallery view, we want a nice full screen of media, else we
 	// don't want to overwhelm the viewer with a shitload of posts.
@@ -172,6 +180,7 @@ func (m *Module) prepareProfile(c *gin.Context) *profile {
 		rssFeed:        rssFeed,
 		robotsMeta:     robotsMeta,
 		pinnedStatuses: pinnedStatuses,
+		featuredTags:   featuredTags,
 		statusResp:     statusResp,
 		paging:         doPaging,
 	}
@@ -260,6 +269,7 @@ func (m *Module) profileMicroblog(c *gin.Context, p *profile) {
 			"statuses":         p.statusResp.Items,
 			"statuses_next":    p.statusResp.NextLink,
 			"pinned_statuses":  p.pinnedStatuses,
+			"featuredTags":     p.featuredTags,
 			"show_back_to_top": p.paging,
 		},
 	}
@@ -336,6 +346,7 @@ func (m *Module) profileGallery(c *gin.Context, p *profile) {
 			"statuses":           p.statusResp.Items,
 			"statuses_next":      p.statusResp.NextLink,
 			"pinned_statuses":    p.pinnedStatuses,
+			"featuredTags":       p.featuredTags,
 			"show_back_to_top":   p.paging,
 		},
 	}
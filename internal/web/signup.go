@@ -53,13 +53,22 @@ func (m *Module) signupGETHandler(c *gin.Context) {
 		return
 	}
 
+	// If an invite link was followed, show
+	// the sign-up form with the invite code
+	// filled in, even if registration is closed.
+	var inviteCode string
+	if config.GetAccountsInvitesEnabled() {
+		inviteCode = c.Query("invite")
+	}
+
 	page := apiutil.WebPage{
 		Template: "sign-up.tmpl",
 		Instance: instance,
 		OGMeta:   apiutil.OGBase(instance),
 		Extra: map[string]any{
-			"reasonRequired":   config.GetAccountsReasonRequired(),
-			"registrationOpen": config.GetAccountsRegistrationOpen(),
+			"reasonRequired":   config.GetAccountsReasonRequired() && inviteCode == "",
+			"registrationOpen": config.GetAccountsRegistrationOpen() || inviteCode != "",
+			"inviteCode":       inviteCode,
 		},
 	}
 
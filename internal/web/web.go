@@ -34,6 +34,7 @@
 
 const (
 	confirmEmailPath      = "/" + uris.ConfirmEmailPath
+	unsubscribePath       = "/" + uris.UnsubscribePath
 	profileGroupPath      = "/@:username"
 	statusPath            = "/statuses/:" + apiutil.WebStatusIDKey // leave out the '/@:username' prefix as this will be served within the profile group
 	tagsPath              = "/tags/:" + apiutil.TagNameKey
@@ -121,6 +122,8 @@ func (m *Module) Route(r *router.Router, mi ...gin.HandlerFunc) {
 	everythingElseGroup.Handle(http.MethodGet, rssFeedPath, m.rssFeedGETHandler)
 	everythingElseGroup.Handle(http.MethodGet, confirmEmailPath, m.confirmEmailGETHandler)
 	everythingElseGroup.Handle(http.MethodPost, confirmEmailPath, m.confirmEmailPOSTHandler)
+	everythingElseGroup.Handle(http.MethodGet, unsubscribePath, m.unsubscribeGETHandler)
+	everythingElseGroup.Handle(http.MethodPost, unsubscribePath, m.unsubscribePOSTHandler)
 	everythingElseGroup.Handle(http.MethodGet, aboutPath, m.aboutGETHandler)
 	everythingElseGroup.Handle(http.MethodGet, loginPath, m.loginGETHandler)
 	everythingElseGroup.Handle(http.MethodGet, domainBlocklistPath, m.domainBlocklistGETHandler)
@@ -128,6 +131,7 @@ func (m *Module) Route(r *router.Router, mi ...gin.HandlerFunc) {
 	everythingElseGroup.Handle(http.MethodGet, tagsPath, m.tagGETHandler)
 	everythingElseGroup.Handle(http.MethodGet, signupPath, m.signupGETHandler)
 	everythingElseGroup.Handle(http.MethodPost, signupPath, m.signupPOSTHandler)
+	everythingElseGroup.Handle(http.MethodGet, devicePath, m.deviceGETHandler)
 
 	// Redirects from old endpoints for back compat.
 	r.AttachHandler(http.MethodGet, "/auth/edit", func(c *gin.Context) { c.Redirect(http.StatusMovedPermanently, userPanelPath) })
@@ -19,6 +19,8 @@
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -201,6 +203,7 @@ func (r *realSender) sendToSubscription(
 	pushNotification := &apimodel.WebPushNotification{
 		NotificationID:   apiNotification.ID,
 		NotificationType: apiNotification.Type,
+		GroupKey:         apiNotification.GroupKey,
 		Title:            formatNotificationTitle(ctx, subscription, notification, apiNotification),
 		Body:             formatNotificationBody(apiNotification),
 		Icon:             apiNotification.Account.Avatar,
@@ -232,6 +235,7 @@ func (r *realSender) sendToSubscription(
 			VAPIDPublicKey:  vapidKeyPair.Public,
 			VAPIDPrivateKey: vapidKeyPair.Private,
 			TTL:             int(TTL.Seconds()),
+			Topic:           notificationTopic(apiNotification.GroupKey),
 		},
 	)
 	if err != nil {
@@ -284,6 +288,20 @@ func (r *realSender) sendToSubscription(
 	}
 }
 
+// notificationTopic returns a Web Push topic for the given notification
+// group key, so that push servers replace any pending, undelivered push
+// notification of the same group. Ungrouped notifications get no topic.
+//
+// Topics are limited to 32 characters of the URL-safe base64
+// alphabet, so we use the hex encoded truncated key hash.
+func notificationTopic(groupKey string) string {
+	if groupKey == "" || strings.HasPrefix(groupKey, "ungrouped-") {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(groupKey))
+	return hex.EncodeToString(sum[:16])
+}
+
 // formatNotificationTitle creates a title for a Web Push notification from the notification type and account's name.
 func formatNotificationTitle(
 	ctx context.Context,
@@ -326,6 +344,12 @@ func formatNotificationTitle(
 		return displayNameOrAcct + " submitted a report"
 	case gtsmodel.NotificationUpdate:
 		return displayNameOrAcct + " updated their post"
+	case gtsmodel.NotificationEmojiReaction:
+		return displayNameOrAcct + " reacted to your post"
+	case gtsmodel.NotificationQuote:
+		return displayNameOrAcct + " quoted your post"
+	case gtsmodel.NotificationPendingQuote:
+		return displayNameOrAcct + " quoted your post, which requires your approval"
 	default:
 		log.Warnf(ctx, "Unknown notification type: %d", notification.NotificationType)
 		return displayNameOrAcct + " did something (unknown notification type)"
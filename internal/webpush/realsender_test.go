@@ -72,6 +72,7 @@ type RealSenderStandardTestSuite struct {
 	processor *processing.Processor
 
 	webPushHttpClientDo func(request *http.Request) (*http.Response, error)
+	webPushRequest      *http.Request
 }
 
 func (suite *RealSenderStandardTestSuite) SetupSuite() {
@@ -185,6 +186,7 @@ func (suite *RealSenderStandardTestSuite) simulatePushNotification(
 
 	// Simulate a response from the Web Push server.
 	suite.webPushHttpClientDo = func(request *http.Request) (*http.Response, error) {
+		suite.webPushRequest = request
 		return &http.Response{
 			Status:     http.StatusText(statusCode),
 			StatusCode: statusCode,
@@ -239,6 +241,10 @@ func (suite *RealSenderStandardTestSuite) simulatePushNotification(
 func (suite *RealSenderStandardTestSuite) TestSendSuccess() {
 	notificationID := suite.testNotifications["local_account_1_like"].ID
 	suite.NoError(suite.simulatePushNotification(notificationID, http.StatusOK, true, false))
+
+	// Grouped notification should have a topic,
+	// derived from the group key of the fave.
+	suite.Equal("1947ba0c1727e5e217536513000c472c", suite.webPushRequest.Header.Get("Topic"))
 }
 
 // Test a rate-limiting response to sending a push notification.
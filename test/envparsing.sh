@@ -6,7 +6,10 @@ EXPECT=$(cat << "EOF"
 {
     "account-domain": "peepee",
     "accounts-allow-custom-css": true,
+    "accounts-archive-json-max-size": "50.0MiB",
     "accounts-custom-css-length": 5000,
+    "accounts-invites-admin-only": true,
+    "accounts-invites-enabled": true,
     "accounts-max-profile-fields": 8,
     "accounts-reason-required": false,
     "accounts-registration-backlog-limit": 100,
@@ -33,6 +36,7 @@ EXPECT=$(cat << "EOF"
     "cache-block-ids-mem-ratio": 3,
     "cache-block-mem-ratio": 2,
     "cache-boost-of-ids-mem-ratio": 3,
+    "cache-card-mem-ratio": 1,
     "cache-client-mem-ratio": 0.1,
     "cache-conversation-last-status-ids-mem-ratio": 2,
     "cache-conversation-mem-ratio": 1,
@@ -79,6 +83,7 @@ EXPECT=$(cat << "EOF"
     "cache-thread-mute-mem-ratio": 0.2,
     "cache-token-mem-ratio": 0.75,
     "cache-tombstone-mem-ratio": 0.5,
+    "cache-user-domain-blocks-mem-ratio": 0.5,
     "cache-user-mem-ratio": 0.25,
     "cache-user-mute-ids-mem-ratio": 3,
     "cache-user-mute-mem-ratio": 2,
@@ -128,6 +133,7 @@ EXPECT=$(cat << "EOF"
     "instance-stats-mode": "baffle",
     "instance-subscriptions-process-every": 86400000000000,
     "instance-subscriptions-process-from": "23:00",
+    "instance-trends-enabled": true,
     "landing-page-user": "admin",
     "letsencrypt-cert-dir": "/gotosocial/storage/certs",
     "letsencrypt-email-address": "",
@@ -141,6 +147,7 @@ EXPECT=$(cat << "EOF"
     "log-timestamp-format": "banana",
     "media-cleanup-every": 86400000000000,
     "media-cleanup-from": "00:00",
+    "media-dedupe": false,
     "media-description-max-chars": 5000,
     "media-description-min-chars": 69,
     "media-emoji-local-max-size": "420B",
@@ -148,11 +155,22 @@ EXPECT=$(cat << "EOF"
     "media-ffmpeg-pool-size": 8,
     "media-image-size-hint": "5.00MiB",
     "media-local-max-size": "420B",
+    "media-preview-cards-blocked-domains": [
+        "example.org"
+    ],
+    "media-preview-cards-enabled": false,
     "media-remote-cache-days": 30,
     "media-remote-max-size": "420B",
     "media-thumb-max-pixels": 42069,
+    "media-transcode-audio-bitrate": 128,
+    "media-transcode-enabled": false,
+    "media-transcode-format": "mp4",
+    "media-transcode-max-pixels": 1920,
+    "media-transcode-video-bitrate": 4000,
     "media-video-size-hint": "40.0MiB",
     "metrics-enabled": false,
+    "oauth-access-token-expiry": 7200000000000,
+    "oauth-refresh-token-expiry": 2592000000000000,
     "oidc-admin-groups": [
         "steamy"
     ],
@@ -192,6 +210,7 @@ EXPECT=$(cat << "EOF"
     "statuses-poll-max-options": 1,
     "statuses-poll-option-max-chars": 50,
     "storage-backend": "local",
+    "storage-fallback-backend": "",
     "storage-local-base-path": "/root/store",
     "storage-s3-access-key": "minio",
     "storage-s3-bucket": "gts",
@@ -207,6 +226,7 @@ EXPECT=$(cat << "EOF"
     "syslog-protocol": "udp",
     "tls-certificate-chain": "",
     "tls-certificate-key": "",
+    "to": "",
     "tracing-enabled": false,
     "trusted-proxies": [
         "127.0.0.1/32",
@@ -264,6 +284,7 @@ GTS_INSTANCE_INJECT_MASTODON_VERSION=true \
 GTS_INSTANCE_LANGUAGES="nl,en-gb" \
 GTS_INSTANCE_STATS_MODE="baffle" \
 GTS_ACCOUNTS_ALLOW_CUSTOM_CSS=true \
+GTS_ACCOUNTS_ARCHIVE_JSON_MAX_SIZE=50MiB \
 GTS_ACCOUNTS_CUSTOM_CSS_LENGTH=5000 \
 GTS_ACCOUNTS_MAX_PROFILE_FIELDS=8 \
 GTS_ACCOUNTS_REGISTRATION_BACKLOG_LIMIT=100 \
@@ -281,6 +302,8 @@ GTS_MEDIA_EMOJI_REMOTE_MAX_SIZE=420 \
 GTS_MEDIA_FFMPEG_POOL_SIZE=8 \
 GTS_MEDIA_VIDEO_SIZE_HINT='40MiB' \
 GTS_MEDIA_THUMB_MAX_PIXELS=42069 \
+GTS_MEDIA_PREVIEW_CARDS_ENABLED=false \
+GTS_MEDIA_PREVIEW_CARDS_BLOCKED_DOMAINS='example.org' \
 GTS_METRICS_ENABLED=false \
 GTS_STORAGE_BACKEND='local' \
 GTS_STORAGE_LOCAL_BASE_PATH='/root/store' \
@@ -301,6 +324,7 @@ GTS_LETS_ENCRYPT_ENABLED=false \
 GTS_LETS_ENCRYPT_PORT=8080 \
 GTS_LETS_ENCRYPT_CERT_DIR='/root/certs' \
 GTS_LETS_ENCRYPT_EMAIL_ADDRESS='le@example.com' \
+GTS_OAUTH_ACCESS_TOKEN_EXPIRY='2h' \
 GTS_OIDC_ENABLED=true \
 GTS_OIDC_IDP_NAME='sex-haver' \
 GTS_OIDC_SKIP_VERIFICATION=true \
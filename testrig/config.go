@@ -107,14 +107,18 @@ func testDefaults() config.Configuration {
 		InstanceSubscriptionsProcessFrom:  "23:00",        // 11pm,
 		InstanceSubscriptionsProcessEvery: 24 * time.Hour, // 1/day.
 		InstanceAllowBackdatingStatuses:   true,
+		InstanceTrendsEnabled:             true,
 
 		AccountsRegistrationOpen:         true,
 		AccountsReasonRequired:           true,
 		AccountsRegistrationDailyLimit:   10,
 		AccountsRegistrationBacklogLimit: 20,
+		AccountsInvitesEnabled:           true,
+		AccountsInvitesAdminOnly:         true,
 		AccountsAllowCustomCSS:           true,
 		AccountsCustomCSSLength:          10000,
 		AccountsMaxProfileFields:         8,
+		AccountsArchiveJSONMaxSize:       100 * bytesize.MiB,
 
 		Media: config.MediaConfiguration{
 			DescriptionMinChars: 0,
@@ -127,6 +131,10 @@ func testDefaults() config.Configuration {
 			CleanupFrom:         "00:00",        // midnight.
 			CleanupEvery:        24 * time.Hour, // 1/day.
 			ThumbMaxPixels:      512,
+
+			// Don't fetch preview cards by default,
+			// this is enabled in specific tests.
+			PreviewCardsEnabled: false,
 		},
 
 		// the testrig uses in-memory storage by default, so we can
@@ -148,6 +156,9 @@ func testDefaults() config.Configuration {
 		LetsEncryptCertDir:      "",
 		LetsEncryptEmailAddress: "",
 
+		OAuthAccessTokenExpiry:  0,
+		OAuthRefreshTokenExpiry: 30 * 24 * time.Hour,
+
 		OIDCEnabled:          false,
 		OIDCIdpName:          "",
 		OIDCSkipVerification: false,
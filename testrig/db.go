@@ -33,15 +33,24 @@
 	&gtsmodel.AccountNote{},
 	&gtsmodel.AccountSettings{},
 	&gtsmodel.AccountToEmoji{},
+	&gtsmodel.Announcement{},
+	&gtsmodel.AnnouncementRead{},
+	&gtsmodel.AnnouncementReaction{},
 	&gtsmodel.Application{},
+	&gtsmodel.Archive{},
 	&gtsmodel.Block{},
+	&gtsmodel.Card{},
 	&gtsmodel.DomainBlock{},
+	&gtsmodel.DomainLimit{},
 	&gtsmodel.EmailDomainBlock{},
+	&gtsmodel.FeaturedTag{},
 	&gtsmodel.Filter{},
 	&gtsmodel.FilterKeyword{},
 	&gtsmodel.FilterStatus{},
 	&gtsmodel.Follow{},
 	&gtsmodel.FollowRequest{},
+	&gtsmodel.Import{},
+	&gtsmodel.ImportFailure{},
 	&gtsmodel.InteractionRequest{},
 	&gtsmodel.List{},
 	&gtsmodel.ListEntry{},
@@ -55,21 +64,33 @@
 	&gtsmodel.StatusToTag{},
 	&gtsmodel.StatusEdit{},
 	&gtsmodel.StatusFave{},
+	&gtsmodel.StatusReaction{},
 	&gtsmodel.StatusBookmark{},
+	&gtsmodel.StaffPick{},
+	&gtsmodel.SuggestionDismissal{},
+	&gtsmodel.Relay{},
+	&gtsmodel.Invite{},
+	&gtsmodel.IPBlock{},
 	&gtsmodel.Tag{},
 	&gtsmodel.Thread{},
 	&gtsmodel.ThreadMute{},
 	&gtsmodel.User{},
 	&gtsmodel.UserMute{},
+	&gtsmodel.UserDomainBlock{},
 	&gtsmodel.VAPIDKeyPair{},
 	&gtsmodel.WebPushSubscription{},
 	&gtsmodel.Emoji{},
 	&gtsmodel.Instance{},
 	&gtsmodel.Notification{},
+	&gtsmodel.NotificationPermission{},
+	&gtsmodel.NotificationPolicy{},
+	&gtsmodel.NotificationRequest{},
 	&gtsmodel.RouterSession{},
 	&gtsmodel.Token{},
+	&gtsmodel.DeviceAuthorization{},
 	&gtsmodel.EmojiCategory{},
 	&gtsmodel.Tombstone{},
+	&gtsmodel.TrendReview{},
 	&gtsmodel.Report{},
 	&gtsmodel.Rule{},
 	&gtsmodel.WorkerTask{},
@@ -238,6 +259,12 @@ func StandardDBSetup(db db.DB, accounts map[string]*gtsmodel.Account) {
 		}
 	}
 
+	for _, v := range NewTestFeaturedTags() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(ctx, err)
+		}
+	}
+
 	for _, v := range NewTestMentions() {
 		if err := db.Put(ctx, v); err != nil {
 			log.Panic(ctx, err)
@@ -377,6 +404,12 @@ func StandardDBSetup(db db.DB, accounts map[string]*gtsmodel.Account) {
 		log.Panic(nil, err)
 	}
 
+	// Test models are inserted directly
+	// above, so index them for search here.
+	if err := db.RebuildSearchIndexes(ctx); err != nil {
+		log.Panic(ctx, err)
+	}
+
 	log.Debug(ctx, "testing db setup complete")
 }
 
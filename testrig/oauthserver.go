@@ -34,6 +34,7 @@ func NewTestOauthServer(state *state.State) oauth.Server {
 		handlers.GetValidateURIHandler(ctx),
 		handlers.GetClientScopeHandler(ctx, state),
 		handlers.GetAuthorizeScopeHandler(),
+		handlers.GetRefreshingScopeHandler(),
 		handlers.GetInternalErrorHandler(ctx),
 		handlers.GetResponseErrorHandler(ctx),
 		handlers.GetUserAuthorizationHandler(),
@@ -19,8 +19,10 @@
 
 import (
 	"bytes"
+	"crypto/ed25519"
 	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
@@ -129,6 +131,7 @@ func NewTestApplications() map[string]*gtsmodel.Application {
 			ClientID:     "01AY6P665V14JJR0AFVRT7311Y", // instance account ID
 			ClientSecret: "baedee87-6d00-4cf5-87b9-4d78ee58ef01",
 			Scopes:       "write:accounts",
+			Public:       util.Ptr(false),
 		},
 		"admin_account": {
 			ID:           "01F8MGXQRHYF5QPMTMXP78QC2F",
@@ -138,6 +141,7 @@ func NewTestApplications() map[string]*gtsmodel.Application {
 			ClientID:     "01F8MGWSJCND9BWBD4WGJXBM93",           // admin client
 			ClientSecret: "dda8e835-2c9c-4bd2-9b8b-77c2e26d7a7a", // admin client
 			Scopes:       "read write push",
+			Public:       util.Ptr(false),
 		},
 		"application_1": {
 			ID:           "01F8MGY43H3N2C8EWPR2FPYEXG",
@@ -147,6 +151,7 @@ func NewTestApplications() map[string]*gtsmodel.Application {
 			ClientID:     "01F8MGV8AC3NGSJW0FE8W1BV70",           // client_1
 			ClientSecret: "c3724c74-dc3b-41b2-a108-0ea3d8399830", // client_1
 			Scopes:       "read write push",
+			Public:       util.Ptr(false),
 		},
 		"application_2": {
 			ID:           "01F8MGYG9E893WRHW0TAEXR8GJ",
@@ -156,6 +161,7 @@ func NewTestApplications() map[string]*gtsmodel.Application {
 			ClientID:     "01F8MGW47HN8ZXNHNZ7E47CDMQ",           // client_2
 			ClientSecret: "8f5603a5-c721-46cd-8f1b-2e368f51379f", // client_2
 			Scopes:       "read write push",
+			Public:       util.Ptr(false),
 		},
 	}
 	return apps
@@ -298,6 +304,7 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			UpdatedAt:                    TimeMustParse("2020-05-17T13:10:59Z"),
 			Locked:                       util.Ptr(false),
 			Discoverable:                 util.Ptr(true),
+			Indexable:                    util.Ptr(false),
 			URI:                          "http://localhost:8080/users/localhost:8080",
 			URL:                          "http://localhost:8080/@localhost:8080",
 			PublicKeyURI:                 "http://localhost:8080/users/localhost:8080#main-key",
@@ -306,6 +313,7 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			FollowersURI:                 "http://localhost:8080/users/localhost:8080/followers",
 			FollowingURI:                 "http://localhost:8080/users/localhost:8080/following",
 			FeaturedCollectionURI:        "http://localhost:8080/users/localhost:8080/collections/featured",
+			FeaturedTagsURI:              "http://localhost:8080/users/localhost:8080/collections/tags",
 			ActorType:                    gtsmodel.AccountActorTypeService,
 			PrivateKey:                   &rsa.PrivateKey{},
 			PublicKey:                    &rsa.PublicKey{},
@@ -319,6 +327,7 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			UpdatedAt:                    TimeMustParse("2022-06-04T13:12:00Z"),
 			Locked:                       util.Ptr(false),
 			Discoverable:                 util.Ptr(false),
+			Indexable:                    util.Ptr(false),
 			URI:                          "http://localhost:8080/users/weed_lord420",
 			URL:                          "http://localhost:8080/@weed_lord420",
 			InboxURI:                     "http://localhost:8080/users/weed_lord420/inbox",
@@ -326,6 +335,7 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			FollowersURI:                 "http://localhost:8080/users/weed_lord420/followers",
 			FollowingURI:                 "http://localhost:8080/users/weed_lord420/following",
 			FeaturedCollectionURI:        "http://localhost:8080/users/weed_lord420/collections/featured",
+			FeaturedTagsURI:              "http://localhost:8080/users/weed_lord420/collections/tags",
 			ActorType:                    gtsmodel.AccountActorTypePerson,
 			PrivateKey:                   &rsa.PrivateKey{},
 			PublicKey:                    &rsa.PublicKey{},
@@ -341,6 +351,7 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			UpdatedAt:                    TimeMustParse("2022-05-17T13:10:59Z"),
 			Locked:                       util.Ptr(false),
 			Discoverable:                 util.Ptr(true),
+			Indexable:                    util.Ptr(false),
 			URI:                          "http://localhost:8080/users/admin",
 			URL:                          "http://localhost:8080/@admin",
 			PublicKeyURI:                 "http://localhost:8080/users/admin#main-key",
@@ -349,6 +360,7 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			FollowersURI:                 "http://localhost:8080/users/admin/followers",
 			FollowingURI:                 "http://localhost:8080/users/admin/following",
 			FeaturedCollectionURI:        "http://localhost:8080/users/admin/collections/featured",
+			FeaturedTagsURI:              "http://localhost:8080/users/admin/collections/tags",
 			ActorType:                    gtsmodel.AccountActorTypePerson,
 			PrivateKey:                   &rsa.PrivateKey{},
 			PublicKey:                    &rsa.PublicKey{},
@@ -368,6 +380,7 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			UpdatedAt:                    TimeMustParse("2022-05-20T11:09:18Z"),
 			Locked:                       util.Ptr(false),
 			Discoverable:                 util.Ptr(true),
+			Indexable:                    util.Ptr(false),
 			URI:                          "http://localhost:8080/users/the_mighty_zork",
 			URL:                          "http://localhost:8080/@the_mighty_zork",
 			InboxURI:                     "http://localhost:8080/users/the_mighty_zork/inbox",
@@ -375,6 +388,7 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			FollowersURI:                 "http://localhost:8080/users/the_mighty_zork/followers",
 			FollowingURI:                 "http://localhost:8080/users/the_mighty_zork/following",
 			FeaturedCollectionURI:        "http://localhost:8080/users/the_mighty_zork/collections/featured",
+			FeaturedTagsURI:              "http://localhost:8080/users/the_mighty_zork/collections/tags",
 			ActorType:                    gtsmodel.AccountActorTypePerson,
 			PrivateKey:                   &rsa.PrivateKey{},
 			PublicKey:                    &rsa.PublicKey{},
@@ -413,6 +427,7 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			UpdatedAt:                    TimeMustParse("2022-06-04T13:12:00Z"),
 			Locked:                       util.Ptr(true),
 			Discoverable:                 util.Ptr(false),
+			Indexable:                    util.Ptr(false),
 			URI:                          "http://localhost:8080/users/1happyturtle",
 			URL:                          "http://localhost:8080/@1happyturtle",
 			InboxURI:                     "http://localhost:8080/users/1happyturtle/inbox",
@@ -420,6 +435,7 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			FollowersURI:                 "http://localhost:8080/users/1happyturtle/followers",
 			FollowingURI:                 "http://localhost:8080/users/1happyturtle/following",
 			FeaturedCollectionURI:        "http://localhost:8080/users/1happyturtle/collections/featured",
+			FeaturedTagsURI:              "http://localhost:8080/users/1happyturtle/collections/tags",
 			ActorType:                    gtsmodel.AccountActorTypePerson,
 			PrivateKey:                   &rsa.PrivateKey{},
 			PublicKey:                    &rsa.PublicKey{},
@@ -459,6 +475,7 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			UpdatedAt:                    TimeMustParse("2025-03-15T11:08:00Z"),
 			Locked:                       util.Ptr(false),
 			Discoverable:                 util.Ptr(false),
+			Indexable:                    util.Ptr(false),
 			URI:                          "http://localhost:8080/users/media_mogul",
 			URL:                          "http://localhost:8080/@media_mogul",
 			InboxURI:                     "http://localhost:8080/users/media_mogul/inbox",
@@ -466,6 +483,7 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			FollowersURI:                 "http://localhost:8080/users/media_mogul/followers",
 			FollowingURI:                 "http://localhost:8080/users/media_mogul/following",
 			FeaturedCollectionURI:        "http://localhost:8080/users/media_mogul/collections/featured",
+			FeaturedTagsURI:              "http://localhost:8080/users/media_mogul/collections/tags",
 			ActorType:                    gtsmodel.AccountActorTypePerson,
 			PrivateKey:                   &rsa.PrivateKey{},
 			PublicKey:                    &rsa.PublicKey{},
@@ -484,6 +502,7 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			UpdatedAt:                    TimeMustParse("2022-06-04T13:12:00Z"),
 			Locked:                       util.Ptr(false),
 			Discoverable:                 util.Ptr(true),
+			Indexable:                    util.Ptr(false),
 			URI:                          "http://fossbros-anonymous.io/users/foss_satan",
 			URL:                          "http://fossbros-anonymous.io/@foss_satan",
 			InboxURI:                     "http://fossbros-anonymous.io/users/foss_satan/inbox",
@@ -509,6 +528,7 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			UpdatedAt:                    TimeMustParse("2022-06-04T13:12:00Z"),
 			Locked:                       util.Ptr(true),
 			Discoverable:                 util.Ptr(true),
+			Indexable:                    util.Ptr(false),
 			URI:                          "http://example.org/users/Some_User",
 			URL:                          "http://example.org/@Some_User",
 			InboxURI:                     "http://example.org/users/Some_User/inbox",
@@ -534,6 +554,7 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			UpdatedAt:                    TimeMustParse("2022-06-04T13:12:00Z"),
 			Locked:                       util.Ptr(true),
 			Discoverable:                 util.Ptr(true),
+			Indexable:                    util.Ptr(false),
 			URI:                          "http://thequeenisstillalive.technology/users/her_fuckin_maj",
 			URL:                          "http://thequeenisstillalive.technology/@her_fuckin_maj",
 			InboxURI:                     "http://thequeenisstillalive.technology/users/her_fuckin_maj/inbox",
@@ -558,6 +579,7 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 			UpdatedAt:                    TimeMustParse("2022-06-04T13:12:00Z"),
 			Locked:                       util.Ptr(false),
 			Discoverable:                 util.Ptr(false),
+			Indexable:                    util.Ptr(false),
 			URI:                          "https://xn--xample-ova.org/users/%C3%BCser",
 			URL:                          "https://xn--xample-ova.org/users/@%C3%BCser",
 			FetchedAt:                    time.Time{},
@@ -623,6 +645,15 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 		}
 		v.PrivateKey = priv
 		v.PublicKey = &priv.PublicKey
+
+		if v.Domain == "" {
+			// Derive a stable Ed25519 key for local accounts.
+			seed := sha256.Sum256([]byte(v.ID))
+			edPriv := ed25519.NewKeyFromSeed(seed[:])
+			v.Ed25519PrivateKey = edPriv
+			v.Ed25519PublicKey = edPriv.Public().(ed25519.PublicKey)
+			v.Ed25519PublicKeyURI = v.URI + "/main-key#ed25519-key"
+		}
 	}
 
 	return accounts
@@ -631,59 +662,64 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 func NewTestAccountSettings() map[string]*gtsmodel.AccountSettings {
 	return map[string]*gtsmodel.AccountSettings{
 		"unconfirmed_account": {
-			AccountID:       "01F8MH0BBE4FHXPH513MBVFHB0",
-			CreatedAt:       TimeMustParse("2022-06-04T13:12:00Z"),
-			UpdatedAt:       TimeMustParse("2022-06-04T13:12:00Z"),
-			Privacy:         gtsmodel.VisibilityPublic,
-			Sensitive:       util.Ptr(false),
-			Language:        "en",
-			EnableRSS:       util.Ptr(false),
-			HideCollections: util.Ptr(false),
-			WebLayout:       gtsmodel.WebLayoutMicroblog,
+			AccountID:          "01F8MH0BBE4FHXPH513MBVFHB0",
+			CreatedAt:          TimeMustParse("2022-06-04T13:12:00Z"),
+			UpdatedAt:          TimeMustParse("2022-06-04T13:12:00Z"),
+			Privacy:            gtsmodel.VisibilityPublic,
+			Sensitive:          util.Ptr(false),
+			Language:           "en",
+			EnableRSS:          util.Ptr(false),
+			HideCollections:    util.Ptr(false),
+			WebLayout:          gtsmodel.WebLayoutMicroblog,
+			EmailNotifications: gtsmodel.EmailNotificationsNone,
 		},
 		"admin_account": {
-			AccountID:       "01F8MH17FWEB39HZJ76B6VXSKF",
-			CreatedAt:       TimeMustParse("2022-05-17T13:10:59Z"),
-			UpdatedAt:       TimeMustParse("2022-05-17T13:10:59Z"),
-			Privacy:         gtsmodel.VisibilityPublic,
-			Sensitive:       util.Ptr(false),
-			Language:        "en",
-			EnableRSS:       util.Ptr(true),
-			HideCollections: util.Ptr(false),
-			WebLayout:       gtsmodel.WebLayoutMicroblog,
+			AccountID:          "01F8MH17FWEB39HZJ76B6VXSKF",
+			CreatedAt:          TimeMustParse("2022-05-17T13:10:59Z"),
+			UpdatedAt:          TimeMustParse("2022-05-17T13:10:59Z"),
+			Privacy:            gtsmodel.VisibilityPublic,
+			Sensitive:          util.Ptr(false),
+			Language:           "en",
+			EnableRSS:          util.Ptr(true),
+			HideCollections:    util.Ptr(false),
+			WebLayout:          gtsmodel.WebLayoutMicroblog,
+			EmailNotifications: gtsmodel.EmailNotificationsNone,
 		},
 		"local_account_1": {
-			AccountID:       "01F8MH1H7YV1Z7D2C8K2730QBF",
-			CreatedAt:       TimeMustParse("2022-05-20T11:09:18Z"),
-			UpdatedAt:       TimeMustParse("2022-05-20T11:09:18Z"),
-			Privacy:         gtsmodel.VisibilityPublic,
-			Sensitive:       util.Ptr(false),
-			Language:        "en",
-			EnableRSS:       util.Ptr(true),
-			HideCollections: util.Ptr(false),
-			WebLayout:       gtsmodel.WebLayoutMicroblog,
+			AccountID:          "01F8MH1H7YV1Z7D2C8K2730QBF",
+			CreatedAt:          TimeMustParse("2022-05-20T11:09:18Z"),
+			UpdatedAt:          TimeMustParse("2022-05-20T11:09:18Z"),
+			Privacy:            gtsmodel.VisibilityPublic,
+			Sensitive:          util.Ptr(false),
+			Language:           "en",
+			EnableRSS:          util.Ptr(true),
+			HideCollections:    util.Ptr(false),
+			WebLayout:          gtsmodel.WebLayoutMicroblog,
+			EmailNotifications: gtsmodel.EmailNotificationsNone,
 		},
 		"local_account_2": {
-			AccountID:       "01F8MH5NBDF2MV7CTC4Q5128HF",
-			CreatedAt:       TimeMustParse("2022-06-04T13:12:00Z"),
-			UpdatedAt:       TimeMustParse("2022-06-04T13:12:00Z"),
-			Privacy:         gtsmodel.VisibilityFollowersOnly,
-			Sensitive:       util.Ptr(true),
-			Language:        "fr",
-			EnableRSS:       util.Ptr(false),
-			HideCollections: util.Ptr(true),
-			WebLayout:       gtsmodel.WebLayoutMicroblog,
+			AccountID:          "01F8MH5NBDF2MV7CTC4Q5128HF",
+			CreatedAt:          TimeMustParse("2022-06-04T13:12:00Z"),
+			UpdatedAt:          TimeMustParse("2022-06-04T13:12:00Z"),
+			Privacy:            gtsmodel.VisibilityFollowersOnly,
+			Sensitive:          util.Ptr(true),
+			Language:           "fr",
+			EnableRSS:          util.Ptr(false),
+			HideCollections:    util.Ptr(true),
+			WebLayout:          gtsmodel.WebLayoutMicroblog,
+			EmailNotifications: gtsmodel.EmailNotificationsNone,
 		},
 		"local_account_3": {
-			AccountID:       "01JPCMD83Y4WR901094YES3QC5",
-			CreatedAt:       TimeMustParse("2025-03-15T11:08:00Z"),
-			UpdatedAt:       TimeMustParse("2025-03-15T11:08:00Z"),
-			Privacy:         gtsmodel.VisibilityPublic,
-			Sensitive:       util.Ptr(true),
-			Language:        "en",
-			EnableRSS:       util.Ptr(true),
-			HideCollections: util.Ptr(false),
-			WebLayout:       gtsmodel.WebLayoutGallery,
+			AccountID:          "01JPCMD83Y4WR901094YES3QC5",
+			CreatedAt:          TimeMustParse("2025-03-15T11:08:00Z"),
+			UpdatedAt:          TimeMustParse("2025-03-15T11:08:00Z"),
+			Privacy:            gtsmodel.VisibilityPublic,
+			Sensitive:          util.Ptr(true),
+			Language:           "en",
+			EnableRSS:          util.Ptr(true),
+			HideCollections:    util.Ptr(false),
+			WebLayout:          gtsmodel.WebLayoutGallery,
+			EmailNotifications: gtsmodel.EmailNotificationsNone,
 		},
 	}
 }
@@ -2965,6 +3001,19 @@ func NewTestStatusToTags() map[string]*gtsmodel.StatusToTag {
 	}
 }
 
+func NewTestFeaturedTags() map[string]*gtsmodel.FeaturedTag {
+	return map[string]*gtsmodel.FeaturedTag{
+		"admin_account_welcome": {
+			ID:            "01GX2V6Y3N3ZQ8P7K6S9F0B2CD",
+			CreatedAt:     TimeMustParse("2023-04-04T10:21:09+02:00"),
+			AccountID:     "01F8MH17FWEB39HZJ76B6VXSKF",
+			TagID:         "01F8MHA1A2NF9MJ3WCCQ3K8BSZ",
+			StatusesCount: 1,
+			LastStatusAt:  TimeMustParse("2021-10-20T11:36:45Z"),
+		},
+	}
+}
+
 func NewTestThreads() map[string]*gtsmodel.Thread {
 	return map[string]*gtsmodel.Thread{
 		"admin_account_status_1": {
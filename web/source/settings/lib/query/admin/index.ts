@@ -91,9 +91,27 @@ const extended = gtsApi.injectEndpoints({
 			async onQueryStarted({ id, action }, { dispatch, queryFulfilled }) {
 				const patchResult = dispatch(
 					extended.util.updateQueryData("getAccount", id, (draft) => {
-						if (action === "suspend") {
-							draft.suspended = true;
-							draft.account.suspended = true;
+						switch (action) {
+							case "suspend":
+								draft.suspended = true;
+								draft.account.suspended = true;
+								break;
+							case "unsuspend":
+								draft.suspended = false;
+								draft.account.suspended = false;
+								break;
+							case "silence":
+								draft.silenced = true;
+								break;
+							case "unsilence":
+								draft.silenced = false;
+								break;
+							case "disable":
+								draft.disabled = true;
+								break;
+							case "reenable":
+								draft.disabled = false;
+								break;
 						}
 					})
 				);
@@ -37,6 +37,7 @@ export interface AdminAccount {
 	silenced: boolean,
 	suspended: boolean,
 	created_by_application_id: string,
+	invited_by_account_id?: string,
 	account: Account,
 }
 
@@ -47,6 +48,7 @@ export interface Account {
 	display_name: string,
 	locked: boolean,
 	discoverable: boolean,
+	indexable: boolean,
 	bot: boolean,
 	created_at: string,
 	note: string,
@@ -113,7 +115,7 @@ export interface HandleSignupParams {
 
 export interface ActionAccountParams {
 	id: string;
-	action: "suspend";
+	action: "suspend" | "unsuspend" | "silence" | "unsilence" | "disable" | "reenable";
 	reason: string;
 }
 
@@ -43,9 +43,9 @@ export function AccountActions({ account, backLocation }: AccountActionsProps) {
 	// on the account's current status.
 	switch (true) {
 		case account.suspended:
-			// Can't do anything with
-			// suspended accounts currently.
-			return null;
+			// Suspended accounts
+			// can only be unsuspended.
+			return <UnsuspendAccount account={account} />;
 		case local && !account.approved:
 			// Unapproved local account sign-up,
 			// only show HandleSignup form.
@@ -63,6 +63,7 @@ export function AccountActions({ account, backLocation }: AccountActionsProps) {
 }
 
 function ModerateAccount({ account }: { account: AdminAccount }) {
+	const local = !account.domain;
 	const form = {
 		id: useValue("id", account.id),
 		reason: useTextInput("text")
@@ -78,7 +79,13 @@ function ModerateAccount({ account }: { account: AdminAccount }) {
 		>
 			<h3 id="account-moderation-actions">Account Moderation Actions</h3>
 			<div>
-				Currently only the "suspend" action is implemented.
+				Silencing an account hides its posts from public timelines,
+				and from anyone who doesn't already follow it.
+				{ local && <>
+					<br/>
+					Disabling a local account prevents it from logging in,
+					and hides its posts, until the account is reenabled.
+				</> }
 				<br/>
 				Suspending an account will delete it from your server,
 				and remove all of its media, posts, relationships, etc.
@@ -87,7 +94,8 @@ function ModerateAccount({ account }: { account: AdminAccount }) {
 				send out a "delete" message to other servers, requesting
 				them to remove its data from their instance as well.
 				<br/>
-				<b>Account suspension cannot be reversed.</b>
+				<b>Data removed by account suspension cannot be restored,
+				even if the account is later unsuspended.</b>
 			</div>
 			<TextInput
 				field={form.reason}
@@ -95,6 +103,20 @@ function ModerateAccount({ account }: { account: AdminAccount }) {
 				autoCapitalize="sentences"
 			/>
 			<div className="action-buttons">
+				<MutationButton
+					disabled={false}
+					label={account.silenced ? "Unsilence" : "Silence"}
+					name={account.silenced ? "unsilence" : "silence"}
+					result={result}
+				/>
+				{ local &&
+					<MutationButton
+						disabled={false}
+						label={account.disabled ? "Reenable" : "Disable"}
+						name={account.disabled ? "reenable" : "disable"}
+						result={result}
+					/>
+				}
 				<MutationButton
 					disabled={account.suspended || reallySuspend.value === undefined || reallySuspend.value === false}
 					label="Suspend"
@@ -110,6 +132,45 @@ function ModerateAccount({ account }: { account: AdminAccount }) {
 	);
 }
 
+function UnsuspendAccount({ account }: { account: AdminAccount }) {
+	const form = {
+		id: useValue("id", account.id),
+		reason: useTextInput("text")
+	};
+
+	const [accountAction, result] = useFormSubmit(form, useActionAccountMutation());
+
+	return (
+		<form
+			onSubmit={accountAction}
+			aria-labelledby="account-moderation-actions"
+		>
+			<h3 id="account-moderation-actions">Account Moderation Actions</h3>
+			<div>
+				This account is suspended. Unsuspending it allows it to
+				interact with your server again, but media, posts, and
+				relationships removed by the suspension will not come back.
+				<br/>
+				If the account is local, the user will have to reset
+				their password before they can log in again.
+			</div>
+			<TextInput
+				field={form.reason}
+				placeholder="Reason for this action"
+				autoCapitalize="sentences"
+			/>
+			<div className="action-buttons">
+				<MutationButton
+					disabled={false}
+					label="Unsuspend"
+					name="unsuspend"
+					result={result}
+				/>
+			</div>
+		</form>
+	);
+}
+
 function HandleSignup({ account, backLocation }: { account: AdminAccount, backLocation: string }) {
 	const form = {
 		id: useValue("id", account.id),
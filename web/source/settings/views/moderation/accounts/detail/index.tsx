@@ -27,6 +27,7 @@ import { AccountActions } from "./actions";
 import { useParams } from "wouter";
 import { useBaseUrl } from "../../../../lib/navigation/util";
 import BackButton from "../../../../components/back-button";
+import UsernameLozenge from "../../../../components/username-lozenge";
 import { UseOurInstanceAccount, yesOrNo } from "../../../../lib/util";
 
 export default function AccountDetail() {
@@ -191,6 +192,16 @@ function LocalAccountDetails({ adminAcct }: { adminAcct: AdminAccount }) {
 					<dt>Sign-Up Reason</dt>
 					<dd>{adminAcct.invite_request ?? <i>none provided</i>}</dd>
 				</div>
+				{ adminAcct.invited_by_account_id &&
+					<div className="info-list-entry">
+						<dt>Invited By</dt>
+						<dd>
+							<UsernameLozenge
+								account={adminAcct.invited_by_account_id}
+								linkTo={`~/settings/moderation/accounts/${adminAcct.invited_by_account_id}`}
+							/>
+						</dd>
+					</div> }
 				{ (adminAcct.ip && adminAcct.ip !== "0.0.0.0") &&
 					<div className="info-list-entry">
 						<dt>Sign-Up IP</dt>
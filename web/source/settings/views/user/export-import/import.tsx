@@ -69,6 +69,9 @@ export default function Import() {
 						<option value="following">Following list</option>
 						<option value="blocks">Blocked accounts list</option>
 						<option value="mutes">Muted accounts list</option>
+						<option value="bookmarks">Bookmarks list</option>
+						<option value="lists">Lists</option>
+						<option value="domain_blocks">Blocked domains list</option>
 					</>
 				}>
 			</Select>
@@ -108,6 +108,7 @@ function ProfileForm({ data: profile }: ProfileFormProps) {
 		bot: useBoolInput("bot", { source: profile }),
 		locked: useBoolInput("locked", { source: profile }),
 		discoverable: useBoolInput("discoverable", { source: profile}),
+		indexable: useBoolInput("indexable", { source: profile }),
 		enableRSS: useBoolInput("enable_rss", { source: profile }),
 		hideCollections: useBoolInput("hide_collections", { source: profile }),
 		webVisibility: useTextInput("web_visibility", { source: profile, valueSelector: (p: Account) => p.source?.web_visibility }),
@@ -314,6 +315,10 @@ function ProfileForm({ data: profile }: ProfileFormProps) {
 				field={form.discoverable}
 				label="Mark account as discoverable by search engines and directories."
 			/>
+			<Checkbox
+				field={form.indexable}
+				label="Allow your public posts to be found with full-text search."
+			/>
 			<Checkbox
 				field={form.enableRSS}
 				label="Enable RSS feed of posts."